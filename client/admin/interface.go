@@ -35,6 +35,7 @@ import (
 //go:generate gowrap gen -g -p . -i Client -t ../templates/grpc.tmpl -o ../wrappers/grpc/admin_generated.go -v client=Admin -v package=adminv1 -v path=github.com/uber/cadence-idl/go/proto/admin/v1 -v prefix=Admin
 //go:generate gowrap gen -g -p . -i Client -t ../templates/thrift.tmpl -o ../wrappers/thrift/admin_generated.go -v client=Admin -v prefix=Admin
 //go:generate gowrap gen -g -p . -i Client -t ../templates/timeout.tmpl -o ../wrappers/timeout/admin_generated.go -v client=Admin
+//go:generate gowrap gen -g -p . -i Client -t ../templates/tracing.tmpl -o ../wrappers/tracing/admin_generated.go -v client=Admin
 
 // Client is the interface exposed by admin service client
 type Client interface {
@@ -34,6 +34,7 @@ import (
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/client/sharddistributor"
+	"github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/client/wrappers/timeout"
 	"github.com/uber/cadence/common/cluster"
 )
@@ -48,6 +49,7 @@ type (
 		GetShardDistributorClient() sharddistributor.Client
 		GetRemoteAdminClient(cluster string) (admin.Client, error)
 		SetRemoteAdminClient(cluster string, client admin.Client)
+		GetRemoteAdminJSONClient(cluster string) (json.AdminClient, error)
 		GetRemoteFrontendClient(cluster string) (frontend.Client, error)
 	}
 
@@ -59,6 +61,7 @@ type (
 		frontendClient         frontend.Client
 		shardDistributorClient sharddistributor.Client
 		remoteAdminClients     map[string]admin.Client
+		remoteAdminJSONClients map[string]json.AdminClient
 		remoteFrontendClients  map[string]frontend.Client
 		factory                Factory
 	}
@@ -73,6 +76,7 @@ func NewClientBean(factory Factory, dispatcher *yarpc.Dispatcher, clusterMetadat
 	}
 
 	remoteAdminClients := map[string]admin.Client{}
+	remoteAdminJSONClients := map[string]json.AdminClient{}
 	remoteFrontendClients := map[string]frontend.Client{}
 	for clusterName := range clusterMetadata.GetEnabledClusterInfo() {
 		clientConfig := dispatcher.ClientConfig(clusterName)
@@ -96,6 +100,7 @@ func NewClientBean(factory Factory, dispatcher *yarpc.Dispatcher, clusterMetadat
 		}
 
 		remoteAdminClients[clusterName] = adminClient
+		remoteAdminJSONClients[clusterName] = json.NewAdminClient(clientConfig)
 		remoteFrontendClients[clusterName] = frontendClient
 	}
 
@@ -111,6 +116,7 @@ func NewClientBean(factory Factory, dispatcher *yarpc.Dispatcher, clusterMetadat
 		frontendClient:         remoteFrontendClients[clusterMetadata.GetCurrentClusterName()],
 		shardDistributorClient: shardDistributorClient,
 		remoteAdminClients:     remoteAdminClients,
+		remoteAdminJSONClients: remoteAdminJSONClients,
 		remoteFrontendClients:  remoteFrontendClients,
 	}, nil
 }
@@ -154,6 +160,14 @@ func (h *clientBeanImpl) SetRemoteAdminClient(
 	h.remoteAdminClients[cluster] = client
 }
 
+func (h *clientBeanImpl) GetRemoteAdminJSONClient(cluster string) (json.AdminClient, error) {
+	client, ok := h.remoteAdminJSONClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster name: %v with given cluster client map: %v", cluster, h.remoteAdminJSONClients)
+	}
+	return client, nil
+}
+
 func (h *clientBeanImpl) GetRemoteFrontendClient(cluster string) (frontend.Client, error) {
 	client, ok := h.remoteFrontendClients[cluster]
 	if !ok {
@@ -19,6 +19,7 @@ import (
 	history "github.com/uber/cadence/client/history"
 	matching "github.com/uber/cadence/client/matching"
 	sharddistributor "github.com/uber/cadence/client/sharddistributor"
+	json "github.com/uber/cadence/client/wrappers/json"
 )
 
 // MockBean is a mock of Bean interface.
@@ -117,6 +118,21 @@ func (mr *MockBeanMockRecorder) GetRemoteAdminClient(cluster any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteAdminClient", reflect.TypeOf((*MockBean)(nil).GetRemoteAdminClient), cluster)
 }
 
+// GetRemoteAdminJSONClient mocks base method.
+func (m *MockBean) GetRemoteAdminJSONClient(cluster string) (json.AdminClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteAdminJSONClient", cluster)
+	ret0, _ := ret[0].(json.AdminClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemoteAdminJSONClient indicates an expected call of GetRemoteAdminJSONClient.
+func (mr *MockBeanMockRecorder) GetRemoteAdminJSONClient(cluster any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteAdminJSONClient", reflect.TypeOf((*MockBean)(nil).GetRemoteAdminJSONClient), cluster)
+}
+
 // GetRemoteFrontendClient mocks base method.
 func (m *MockBean) GetRemoteFrontendClient(cluster string) (frontend.Client, error) {
 	m.ctrl.T.Helper()
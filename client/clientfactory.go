@@ -42,9 +42,11 @@ import (
 	"github.com/uber/cadence/client/sharddistributor"
 	"github.com/uber/cadence/client/wrappers/errorinjectors"
 	"github.com/uber/cadence/client/wrappers/grpc"
+	"github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/client/wrappers/metered"
 	"github.com/uber/cadence/client/wrappers/thrift"
 	timeoutwrapper "github.com/uber/cadence/client/wrappers/timeout"
+	"github.com/uber/cadence/client/wrappers/tracing"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
@@ -124,6 +126,7 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 	} else {
 		rawClient = thrift.NewHistoryClient(historyserviceclient.New(outboundConfig))
 	}
+	rawClient = json.NewHistoryClient(rawClient, outboundConfig)
 
 	peerResolver := history.NewPeerResolver(cf.numberOfHistoryShards, cf.resolver, namedPort)
 
@@ -140,6 +143,7 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 	if cf.metricsClient != nil {
 		client = metered.NewHistoryClient(client, cf.metricsClient)
 	}
+	client = tracing.NewHistoryClient(client)
 	client = timeoutwrapper.NewHistoryClient(client, timeout)
 	return client, peerResolver, nil
 }
@@ -185,6 +189,7 @@ func (cf *rpcClientFactory) NewMatchingClientWithTimeout(
 	if cf.metricsClient != nil {
 		client = metered.NewMatchingClient(client, cf.metricsClient)
 	}
+	client = tracing.NewMatchingClient(client)
 	return client, nil
 }
 
@@ -207,6 +212,7 @@ func (cf *rpcClientFactory) NewAdminClientWithTimeoutAndConfig(
 	if cf.metricsClient != nil {
 		client = metered.NewAdminClient(client, cf.metricsClient)
 	}
+	client = tracing.NewAdminClient(client)
 	return client, nil
 }
 
@@ -234,6 +240,7 @@ func (cf *rpcClientFactory) NewFrontendClientWithTimeoutAndConfig(
 	if cf.metricsClient != nil {
 		client = metered.NewFrontendClient(client, cf.metricsClient)
 	}
+	client = tracing.NewFrontendClient(client)
 	return client, nil
 }
 
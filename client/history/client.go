@@ -273,6 +273,29 @@ func (c *clientImpl) DescribeQueue(
 	return response, nil
 }
 
+func (c *clientImpl) DescribeReplicationQueue(
+	ctx context.Context,
+	request *types.DescribeReplicationQueueRequest,
+	opts ...yarpc.CallOption,
+) (*types.ReplicationShardStatus, error) {
+	peer, err := c.peerResolver.FromShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *types.ReplicationShardStatus
+	op := func(ctx context.Context, peer string) error {
+		var err error
+		response, err = c.client.DescribeReplicationQueue(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, peer, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *types.DescribeMutableStateRequest,
@@ -349,6 +349,20 @@ func TestClient_withResponse(t *testing.T) {
 			},
 			want: &types.DescribeQueueResponse{},
 		},
+		{
+			name: "DescribeReplicationQueue",
+			op: func(c Client) (any, error) {
+				return c.DescribeReplicationQueue(context.Background(), &types.DescribeReplicationQueueRequest{
+					ShardID: 123,
+				})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().FromShardID(123).Return("test-peer", nil).Times(1)
+				c.EXPECT().DescribeReplicationQueue(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer")}).
+					Return(&types.ReplicationShardStatus{ShardID: 123}, nil).Times(1)
+			},
+			want: &types.ReplicationShardStatus{ShardID: 123},
+		},
 		{
 			name: "CountDLQMessages",
 			op: func(c Client) (any, error) {
@@ -826,6 +840,20 @@ func TestClient_withResponse(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "DescribeReplicationQueue fail",
+			op: func(c Client) (any, error) {
+				return c.DescribeReplicationQueue(context.Background(), &types.DescribeReplicationQueueRequest{
+					ShardID: 123,
+				})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().FromShardID(123).Return("test-peer", nil).Times(1)
+				c.EXPECT().DescribeReplicationQueue(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer")}).
+					Return(nil, fmt.Errorf("DescribeReplicationQueue failed")).Times(1)
+			},
+			wantError: true,
+		},
 		{
 			name: "CountDLQMessages fail",
 			op: func(c Client) (any, error) {
@@ -142,6 +142,26 @@ func (mr *MockClientMockRecorder) DescribeQueue(arg0, arg1 any, arg2 ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueue", reflect.TypeOf((*MockClient)(nil).DescribeQueue), varargs...)
 }
 
+// DescribeReplicationQueue mocks base method.
+func (m *MockClient) DescribeReplicationQueue(arg0 context.Context, arg1 *types.DescribeReplicationQueueRequest, arg2 ...yarpc.CallOption) (*types.ReplicationShardStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeReplicationQueue", varargs...)
+	ret0, _ := ret[0].(*types.ReplicationShardStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReplicationQueue indicates an expected call of DescribeReplicationQueue.
+func (mr *MockClientMockRecorder) DescribeReplicationQueue(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReplicationQueue", reflect.TypeOf((*MockClient)(nil).DescribeReplicationQueue), varargs...)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockClient) DescribeWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDescribeWorkflowExecutionRequest, arg2 ...yarpc.CallOption) (*types.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,7 @@ import (
 //go:generate gowrap gen -g -p . -i Client -t ../templates/grpc.tmpl -o ../wrappers/grpc/matching_generated.go -v client=Matching -v package=matchingv1 -v path=github.com/uber/cadence/.gen/proto/matching/v1 -v prefix=Matching
 //go:generate gowrap gen -g -p . -i Client -t ../templates/thrift.tmpl -o ../wrappers/thrift/matching_generated.go -v client=Matching -v prefix=Matching
 //go:generate gowrap gen -g -p . -i Client -t ../templates/timeout.tmpl -o ../wrappers/timeout/matching_generated.go -v client=Matching
+//go:generate gowrap gen -g -p . -i Client -t ../templates/tracing.tmpl -o ../wrappers/tracing/matching_generated.go -v client=Matching
 
 // Client is the interface exposed by types service client
 type Client interface {
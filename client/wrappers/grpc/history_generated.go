@@ -38,6 +38,10 @@ func (g historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQue
 	return proto.ToHistoryDescribeQueueResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) DescribeReplicationQueue(ctx context.Context, dp1 *types.DescribeReplicationQueueRequest, p1 ...yarpc.CallOption) (rp1 *types.ReplicationShardStatus, err error) {
+	return nil, &types.BadRequestError{Message: "DescribeReplicationQueue is only served as JSON"}
+}
+
 func (g historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	response, err := g.c.DescribeWorkflowExecution(ctx, proto.FromHistoryDescribeWorkflowExecutionRequest(hp1), p1...)
 	return proto.ToHistoryDescribeWorkflowExecutionResponse(response), proto.ToError(err)
@@ -0,0 +1,125 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"context"
+
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	yarpcjson "go.uber.org/yarpc/encoding/json"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+)
+
+type adminClient struct {
+	c yarpcjson.Client
+}
+
+// NewAdminClient creates an AdminClient calling the frontend behind the given client config
+func NewAdminClient(c transport.ClientConfig) AdminClient {
+	return adminClient{yarpcjson.New(c)}
+}
+
+func (g adminClient) GetReplicationStatus(ctx context.Context, request *types.GetReplicationStatusRequest, opts ...yarpc.CallOption) (*types.GetReplicationStatusResponse, error) {
+	var response types.GetReplicationStatusResponse
+	if err := g.c.Call(ctx, AdminGetReplicationStatusProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g adminClient) ImportWorkflowExecution(ctx context.Context, request *types.ImportWorkflowExecutionRequest, opts ...yarpc.CallOption) (*types.ImportWorkflowExecutionResponse, error) {
+	var response types.ImportWorkflowExecutionResponse
+	if err := g.c.Call(ctx, AdminImportWorkflowExecutionProcedure, request, &response, opts...); err != nil {
+		// the frontend maps errors to yarpc statuses the same way as for gRPC
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g adminClient) ReadDLQMessages(ctx context.Context, request *types.ReadDLQMessagesRequest, opts ...yarpc.CallOption) (*types.ReadDLQMessagesResponse, error) {
+	var response types.ReadDLQMessagesResponse
+	if err := g.c.Call(ctx, AdminReadDLQMessagesProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g adminClient) ReapplyEvents(ctx context.Context, request *types.ReapplyEventsRequest, opts ...yarpc.CallOption) error {
+	var response struct{}
+	if err := g.c.Call(ctx, AdminReapplyEventsProcedure, request, &response, opts...); err != nil {
+		return proto.ToError(err)
+	}
+	return nil
+}
+
+type apiClient struct {
+	c yarpcjson.Client
+}
+
+// NewAPIClient creates an APIClient calling the frontend behind the given client config
+func NewAPIClient(c transport.ClientConfig) APIClient {
+	return apiClient{yarpcjson.New(c)}
+}
+
+func (g apiClient) DescribeAsyncRequest(ctx context.Context, request *types.DescribeAsyncRequestRequest, opts ...yarpc.CallOption) (*types.DescribeAsyncRequestResponse, error) {
+	var response types.DescribeAsyncRequestResponse
+	if err := g.c.Call(ctx, APIDescribeAsyncRequestProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g apiClient) RequestCancelWorkflowExecutionAsync(ctx context.Context, request *types.RequestCancelWorkflowExecutionAsyncRequest, opts ...yarpc.CallOption) (*types.RequestCancelWorkflowExecutionAsyncResponse, error) {
+	var response types.RequestCancelWorkflowExecutionAsyncResponse
+	if err := g.c.Call(ctx, APIRequestCancelWorkflowExecutionAsyncProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g apiClient) ResetWorkflowExecution(ctx context.Context, request *types.ResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (*types.ResetWorkflowExecutionResponse, error) {
+	var response types.ResetWorkflowExecutionResponse
+	if err := g.c.Call(ctx, APIResetWorkflowExecutionProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g apiClient) SignalWorkflowExecutionAsync(ctx context.Context, request *types.SignalWorkflowExecutionAsyncRequest, opts ...yarpc.CallOption) (*types.SignalWorkflowExecutionAsyncResponse, error) {
+	var response types.SignalWorkflowExecutionAsyncResponse
+	if err := g.c.Call(ctx, APISignalWorkflowExecutionAsyncProcedure, NewSignalWorkflowExecutionAsyncRequest(request), &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g apiClient) TerminateWorkflowExecutionAsync(ctx context.Context, request *types.TerminateWorkflowExecutionAsyncRequest, opts ...yarpc.CallOption) (*types.TerminateWorkflowExecutionAsyncResponse, error) {
+	var response types.TerminateWorkflowExecutionAsyncResponse
+	if err := g.c.Call(ctx, APITerminateWorkflowExecutionAsyncProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
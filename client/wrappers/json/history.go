@@ -0,0 +1,98 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"context"
+
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	yarpcjson "go.uber.org/yarpc/encoding/json"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+)
+
+// historyClient sends the APIs and the requests using fields that cadence-idl does not define as JSON,
+// every other request goes through the thrift or gRPC client it wraps.
+// Requests without those fields keep using thrift or gRPC, so they are still served by hosts of older releases.
+type historyClient struct {
+	history.Client
+	c yarpcjson.Client
+}
+
+// NewHistoryClient wraps the thrift or gRPC history client built for the same outbound.
+// It is wrapped by history.NewClient, which picks the host by shard the same way for every encoding.
+func NewHistoryClient(client history.Client, c transport.ClientConfig) history.Client {
+	return historyClient{Client: client, c: yarpcjson.New(c)}
+}
+
+func (g historyClient) DescribeReplicationQueue(ctx context.Context, request *types.DescribeReplicationQueueRequest, opts ...yarpc.CallOption) (*types.ReplicationShardStatus, error) {
+	var response types.ReplicationShardStatus
+	if err := g.c.Call(ctx, HistoryDescribeReplicationQueueProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g historyClient) ReadDLQMessages(ctx context.Context, request *types.ReadDLQMessagesRequest, opts ...yarpc.CallOption) (*types.ReadDLQMessagesResponse, error) {
+	if !request.GetIncludeRedriveStatus() {
+		return g.Client.ReadDLQMessages(ctx, request, opts...)
+	}
+	var response types.ReadDLQMessagesResponse
+	if err := g.c.Call(ctx, HistoryReadDLQMessagesProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func (g historyClient) ReapplyEvents(ctx context.Context, request *types.HistoryReapplyEventsRequest, opts ...yarpc.CallOption) error {
+	if request.GetRequest().GetReapplyFilter() == nil {
+		return g.Client.ReapplyEvents(ctx, request, opts...)
+	}
+	var response struct{}
+	if err := g.c.Call(ctx, HistoryReapplyEventsProcedure, request, &response, opts...); err != nil {
+		return proto.ToError(err)
+	}
+	return nil
+}
+
+func (g historyClient) ResetWorkflowExecution(ctx context.Context, request *types.HistoryResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (*types.ResetWorkflowExecutionResponse, error) {
+	if request == nil || !hasResetFieldsOutsideIDL(request.ResetRequest) {
+		return g.Client.ResetWorkflowExecution(ctx, request, opts...)
+	}
+	var response types.ResetWorkflowExecutionResponse
+	if err := g.c.Call(ctx, HistoryResetWorkflowExecutionProcedure, request, &response, opts...); err != nil {
+		return nil, proto.ToError(err)
+	}
+	return &response, nil
+}
+
+func hasResetFieldsOutsideIDL(request *types.ResetWorkflowExecutionRequest) bool {
+	if request == nil {
+		return false
+	}
+	return request.ResetType != nil || request.ResetTimestamp != nil || request.BadBinaryChecksum != "" || request.DryRun ||
+		request.ReapplyFilter != nil
+}
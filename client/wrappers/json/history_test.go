@@ -0,0 +1,199 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+// fakeJSONClient records the procedure called and answers with its response encoded as JSON
+type fakeJSONClient struct {
+	procedure string
+	request   interface{}
+	response  interface{}
+}
+
+func (f *fakeJSONClient) Call(ctx context.Context, procedure string, reqBody interface{}, resBodyOut interface{}, opts ...yarpc.CallOption) error {
+	f.procedure = procedure
+	f.request = reqBody
+	data, err := json.Marshal(f.response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, resBodyOut)
+}
+
+func (f *fakeJSONClient) CallOneway(ctx context.Context, procedure string, reqBody interface{}, opts ...yarpc.CallOption) (transport.Ack, error) {
+	panic("not implemented")
+}
+
+func TestHistoryClientResetWorkflowExecution(t *testing.T) {
+	execution := &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"}
+	tests := map[string]struct {
+		request *types.ResetWorkflowExecutionRequest
+		viaJSON bool
+	}{
+		"decision finish event ID": {
+			request: &types.ResetWorkflowExecutionRequest{WorkflowExecution: execution, DecisionFinishEventID: 4},
+		},
+		"reset type": {
+			request: &types.ResetWorkflowExecutionRequest{WorkflowExecution: execution, ResetType: types.ResetTypeLastDecisionCompleted.Ptr()},
+			viaJSON: true,
+		},
+		"reset timestamp": {
+			request: &types.ResetWorkflowExecutionRequest{WorkflowExecution: execution, ResetTimestamp: common.Int64Ptr(123)},
+			viaJSON: true,
+		},
+		"bad binary checksum": {
+			request: &types.ResetWorkflowExecutionRequest{WorkflowExecution: execution, ResetType: types.ResetTypeBadBinary.Ptr(), BadBinaryChecksum: "checksum"},
+			viaJSON: true,
+		},
+		"dry-run": {
+			request: &types.ResetWorkflowExecutionRequest{WorkflowExecution: execution, DecisionFinishEventID: 4, DryRun: true},
+			viaJSON: true,
+		},
+		"reapply filter": {
+			request: &types.ResetWorkflowExecutionRequest{
+				WorkflowExecution:     execution,
+				DecisionFinishEventID: 4,
+				ReapplyFilter:         &types.EventReapplyFilter{IncludeSignalNames: []string{"signal"}},
+			},
+			viaJSON: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			raw := history.NewMockClient(ctrl)
+			response := &types.ResetWorkflowExecutionResponse{RunID: "new-rid"}
+			c := &fakeJSONClient{response: response}
+			client := historyClient{Client: raw, c: c}
+
+			request := &types.HistoryResetWorkflowExecutionRequest{DomainUUID: "domain-id", ResetRequest: tc.request}
+			if !tc.viaJSON {
+				raw.EXPECT().ResetWorkflowExecution(gomock.Any(), request).Return(response, nil).Times(1)
+			}
+			resp, err := client.ResetWorkflowExecution(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, response, resp)
+			if tc.viaJSON {
+				assert.Equal(t, HistoryResetWorkflowExecutionProcedure, c.procedure)
+				assert.Equal(t, request, c.request)
+			} else {
+				assert.Empty(t, c.procedure)
+			}
+		})
+	}
+}
+
+func TestHistoryClientReadDLQMessages(t *testing.T) {
+	tests := map[string]struct {
+		includeRedriveStatus bool
+	}{
+		"messages only":        {},
+		"with re-drive status": {includeRedriveStatus: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			raw := history.NewMockClient(ctrl)
+			response := &types.ReadDLQMessagesResponse{
+				Type:            types.DLQTypeReplication.Ptr(),
+				RedriveStatuses: []*types.ReplicationDLQRedriveStatus{{TaskID: 1, Attempts: 2, ErrorClass: "bad-request", Quarantined: true}},
+			}
+			c := &fakeJSONClient{response: response}
+			client := historyClient{Client: raw, c: c}
+
+			request := &types.ReadDLQMessagesRequest{
+				Type:                 types.DLQTypeReplication.Ptr(),
+				ShardID:              1,
+				SourceCluster:        "cluster-b",
+				IncludeRedriveStatus: tc.includeRedriveStatus,
+			}
+			if !tc.includeRedriveStatus {
+				raw.EXPECT().ReadDLQMessages(gomock.Any(), request).Return(response, nil).Times(1)
+			}
+			resp, err := client.ReadDLQMessages(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, response, resp)
+			if tc.includeRedriveStatus {
+				assert.Equal(t, HistoryReadDLQMessagesProcedure, c.procedure)
+				assert.Equal(t, request, c.request)
+			} else {
+				assert.Empty(t, c.procedure)
+			}
+		})
+	}
+}
+
+func TestHistoryClientReapplyEvents(t *testing.T) {
+	execution := &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"}
+	tests := map[string]struct {
+		request *types.ReapplyEventsRequest
+		viaJSON bool
+	}{
+		"no filter": {
+			request: &types.ReapplyEventsRequest{DomainName: "domain", WorkflowExecution: execution},
+		},
+		"reapply filter": {
+			request: &types.ReapplyEventsRequest{
+				DomainName:        "domain",
+				WorkflowExecution: execution,
+				ReapplyFilter:     &types.EventReapplyFilter{ExcludeSignalNames: []string{"signal"}},
+			},
+			viaJSON: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			raw := history.NewMockClient(ctrl)
+			c := &fakeJSONClient{response: struct{}{}}
+			client := historyClient{Client: raw, c: c}
+
+			request := &types.HistoryReapplyEventsRequest{DomainUUID: "domain-id", Request: tc.request}
+			if !tc.viaJSON {
+				raw.EXPECT().ReapplyEvents(gomock.Any(), request).Return(nil).Times(1)
+			}
+			err := client.ReapplyEvents(context.Background(), request)
+			assert.NoError(t, err)
+			if tc.viaJSON {
+				assert.Equal(t, HistoryReapplyEventsProcedure, c.procedure)
+				assert.Equal(t, request, c.request)
+			} else {
+				assert.Empty(t, c.procedure)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination interface_mock.go -package json github.com/uber/cadence/client/wrappers/json AdminClient,APIClient
+
+// Package json calls the frontend, admin and history APIs that cadence-idl does not define yet.
+// The frontend and history serve them as JSON encoded yarpc procedures of the internal types,
+// on the same dispatcher and inbounds as the thrift and gRPC procedures,
+// see service/frontend/wrappers/json and service/history/wrappers/json.
+package json
+
+import (
+	"context"
+
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// Procedures served as JSON by the frontend
+const (
+	AdminGetReplicationStatusProcedure    = "cadence.admin.json::GetReplicationStatus"
+	AdminImportWorkflowExecutionProcedure = "cadence.admin.json::ImportWorkflowExecution"
+	AdminReadDLQMessagesProcedure         = "cadence.admin.json::ReadDLQMessages"
+	AdminReapplyEventsProcedure           = "cadence.admin.json::ReapplyEvents"
+
+	APIDescribeAsyncRequestProcedure                = "cadence.api.json::DescribeAsyncRequest"
+	APIRequestCancelWorkflowExecutionAsyncProcedure = "cadence.api.json::RequestCancelWorkflowExecutionAsync"
+	APIResetWorkflowExecutionProcedure              = "cadence.api.json::ResetWorkflowExecution"
+	APISignalWorkflowExecutionAsyncProcedure        = "cadence.api.json::SignalWorkflowExecutionAsync"
+	APITerminateWorkflowExecutionAsyncProcedure     = "cadence.api.json::TerminateWorkflowExecutionAsync"
+)
+
+// Procedures served as JSON by history
+const (
+	HistoryDescribeReplicationQueueProcedure = "cadence.history.json::DescribeReplicationQueue"
+	HistoryReadDLQMessagesProcedure          = "cadence.history.json::ReadDLQMessages"
+	HistoryReapplyEventsProcedure            = "cadence.history.json::ReapplyEvents"
+	HistoryResetWorkflowExecutionProcedure   = "cadence.history.json::ResetWorkflowExecution"
+)
+
+// AdminClient is the client of the admin APIs served as JSON
+type AdminClient interface {
+	GetReplicationStatus(context.Context, *types.GetReplicationStatusRequest, ...yarpc.CallOption) (*types.GetReplicationStatusResponse, error)
+	ImportWorkflowExecution(context.Context, *types.ImportWorkflowExecutionRequest, ...yarpc.CallOption) (*types.ImportWorkflowExecutionResponse, error)
+	// ReadDLQMessages carries the re-drive status of replication DLQ messages
+	ReadDLQMessages(context.Context, *types.ReadDLQMessagesRequest, ...yarpc.CallOption) (*types.ReadDLQMessagesResponse, error)
+	// ReapplyEvents carries the reapply filter of the request
+	ReapplyEvents(context.Context, *types.ReapplyEventsRequest, ...yarpc.CallOption) error
+}
+
+// APIClient is the client of the frontend APIs served as JSON
+type APIClient interface {
+	DescribeAsyncRequest(context.Context, *types.DescribeAsyncRequestRequest, ...yarpc.CallOption) (*types.DescribeAsyncRequestResponse, error)
+	RequestCancelWorkflowExecutionAsync(context.Context, *types.RequestCancelWorkflowExecutionAsyncRequest, ...yarpc.CallOption) (*types.RequestCancelWorkflowExecutionAsyncResponse, error)
+	// ResetWorkflowExecution carries the reset type, reset timestamp, bad binary checksum and dry-run of the request
+	ResetWorkflowExecution(context.Context, *types.ResetWorkflowExecutionRequest, ...yarpc.CallOption) (*types.ResetWorkflowExecutionResponse, error)
+	SignalWorkflowExecutionAsync(context.Context, *types.SignalWorkflowExecutionAsyncRequest, ...yarpc.CallOption) (*types.SignalWorkflowExecutionAsyncResponse, error)
+	TerminateWorkflowExecutionAsync(context.Context, *types.TerminateWorkflowExecutionAsyncRequest, ...yarpc.CallOption) (*types.TerminateWorkflowExecutionAsyncResponse, error)
+}
@@ -0,0 +1,53 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"github.com/uber/cadence/common/types"
+)
+
+// SignalWorkflowExecutionAsyncRequest is the JSON body of the SignalWorkflowExecutionAsync procedure.
+// The JSON encoding of types.SignalWorkflowExecutionRequest filters the input as PII, so it is carried separately.
+type SignalWorkflowExecutionAsyncRequest struct {
+	*types.SignalWorkflowExecutionAsyncRequest
+	Input []byte `json:"input,omitempty"`
+}
+
+// NewSignalWorkflowExecutionAsyncRequest returns the JSON body of the request
+func NewSignalWorkflowExecutionAsyncRequest(request *types.SignalWorkflowExecutionAsyncRequest) *SignalWorkflowExecutionAsyncRequest {
+	body := &SignalWorkflowExecutionAsyncRequest{SignalWorkflowExecutionAsyncRequest: request}
+	if request != nil && request.SignalWorkflowExecutionRequest != nil {
+		body.Input = request.Input
+	}
+	return body
+}
+
+// ToInternal returns the request carried by the JSON body, or nil if there is none
+func (r *SignalWorkflowExecutionAsyncRequest) ToInternal() *types.SignalWorkflowExecutionAsyncRequest {
+	if r == nil || r.SignalWorkflowExecutionAsyncRequest == nil || r.SignalWorkflowExecutionRequest == nil {
+		return nil
+	}
+	signalRequest := *r.SignalWorkflowExecutionRequest
+	signalRequest.Input = r.Input
+	return &types.SignalWorkflowExecutionAsyncRequest{SignalWorkflowExecutionRequest: &signalRequest}
+}
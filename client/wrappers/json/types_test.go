@@ -0,0 +1,56 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestSignalWorkflowExecutionAsyncRequest(t *testing.T) {
+	request := &types.SignalWorkflowExecutionAsyncRequest{
+		SignalWorkflowExecutionRequest: &types.SignalWorkflowExecutionRequest{
+			Domain:            "domain",
+			WorkflowExecution: &types.WorkflowExecution{WorkflowID: "wid"},
+			SignalName:        "signal",
+			Input:             []byte("input"),
+			RequestID:         "request-id",
+		},
+	}
+
+	encoded, err := json.Marshal(NewSignalWorkflowExecutionAsyncRequest(request))
+	require.NoError(t, err)
+
+	var decoded SignalWorkflowExecutionAsyncRequest
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, request, decoded.ToInternal())
+
+	var empty SignalWorkflowExecutionAsyncRequest
+	require.NoError(t, json.Unmarshal([]byte("{}"), &empty))
+	assert.Nil(t, empty.ToInternal())
+}
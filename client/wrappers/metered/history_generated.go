@@ -138,6 +138,28 @@ func (c *historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQu
 	return dp2, err
 }
 
+func (c *historyClient) DescribeReplicationQueue(ctx context.Context, dp1 *types.DescribeReplicationQueueRequest, p1 ...yarpc.CallOption) (rp1 *types.ReplicationShardStatus, err error) {
+	retryCount := getRetryCountFromContext(ctx)
+
+	var scope metrics.Scope
+	if retryCount == -1 {
+		scope = c.metricsClient.Scope(metrics.HistoryClientDescribeReplicationQueueScope)
+	} else {
+		scope = c.metricsClient.Scope(metrics.HistoryClientDescribeReplicationQueueScope, metrics.IsRetryTag(retryCount > 0))
+	}
+
+	scope.IncCounter(metrics.CadenceClientRequests)
+
+	sw := scope.StartTimer(metrics.CadenceClientLatency)
+	rp1, err = c.client.DescribeReplicationQueue(ctx, dp1, p1...)
+	sw.Stop()
+
+	if err != nil {
+		scope.IncCounter(metrics.CadenceClientFailures)
+	}
+	return rp1, err
+}
+
 func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	retryCount := getRetryCountFromContext(ctx)
 
@@ -82,6 +82,17 @@ func (c *historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQu
 	return resp, err
 }
 
+func (c *historyClient) DescribeReplicationQueue(ctx context.Context, dp1 *types.DescribeReplicationQueueRequest, p1 ...yarpc.CallOption) (rp1 *types.ReplicationShardStatus, err error) {
+	var resp *types.ReplicationShardStatus
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.DescribeReplicationQueue(ctx, dp1, p1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
+
 func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	var resp *types.DescribeWorkflowExecutionResponse
 	op := func(ctx context.Context) error {
@@ -37,6 +37,10 @@ func (g historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQue
 	return thrift.ToHistoryDescribeQueueResponse(response), thrift.ToError(err)
 }
 
+func (g historyClient) DescribeReplicationQueue(ctx context.Context, dp1 *types.DescribeReplicationQueueRequest, p1 ...yarpc.CallOption) (rp1 *types.ReplicationShardStatus, err error) {
+	return nil, thrift.ToError(&types.BadRequestError{Message: "Feature not supported on TChannel"})
+}
+
 func (g historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	response, err := g.c.DescribeWorkflowExecution(ctx, thrift.FromHistoryDescribeWorkflowExecutionRequest(hp1), p1...)
 	return thrift.ToHistoryDescribeWorkflowExecutionResponse(response), thrift.ToError(err)
@@ -22,6 +22,8 @@ package cadence
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/startreedata/pinot-client-go/pinot"
@@ -55,7 +57,10 @@ import (
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging/kafka"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/peerprovider"
+	"github.com/uber/cadence/common/peerprovider/dnsprovider"
 	"github.com/uber/cadence/common/peerprovider/ringpopprovider"
+	"github.com/uber/cadence/common/peerprovider/staticprovider"
 	pnt "github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/rpc"
@@ -66,9 +71,14 @@ import (
 	sharddistributorconstants "github.com/uber/cadence/service/sharddistributor/constants"
 	"github.com/uber/cadence/service/worker"
 	diagnosticsInvariant "github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/childworkflow"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/historysize"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/signal"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/timer"
 )
 
 type (
@@ -84,6 +94,15 @@ type (
 	}
 )
 
+var (
+	// builtinPeerProviders are the peer provider plugins which can be selected by their key under membership.provider
+	builtinPeerProviders = map[string]func() error{
+		staticprovider.ConfigKey: staticprovider.Register,
+		dnsprovider.ConfigKey:    dnsprovider.Register,
+	}
+	registerPeerProviderOnce sync.Once
+)
+
 // newServer returns a new instance of a daemon
 // that represents a cadence service
 func newServer(service string, cfg config.Config, logger log.Logger, dynamicCfgClient dynamicconfig.Client, scope tally.Scope, metricsClient metrics.Client) common.Daemon {
@@ -156,26 +175,17 @@ func (s *server) startService() common.Daemon {
 	rpcFactory := rpc.NewFactory(params.Logger, rpcParams)
 	params.RPCFactory = rpcFactory
 
-	peerProvider, err := ringpopprovider.New(
-		params.Name,
-		&s.cfg.Ringpop,
-		rpcFactory.GetTChannel(),
-		membership.PortMap{
-			membership.PortGRPC:     svcCfg.RPC.GRPCPort,
-			membership.PortTchannel: svcCfg.RPC.Port,
-		},
-		params.Logger,
-	)
-
+	peerProvider, err := s.newPeerProvider(params.Name, svcCfg, rpcFactory, params.Logger)
 	if err != nil {
-		s.logger.Fatal("ringpop provider failed", tag.Error(err))
+		s.logger.Fatal("peer provider failed", tag.Error(err))
 	}
 
 	shardDistributorClient := s.createShardDistributorClient(params, dc)
 
+	hashringOptions := s.cfg.Membership.Hashring.Options()
 	params.HashRings = make(map[string]membership.SingleProvider)
 	for _, s := range service.ListWithRing {
-		params.HashRings[s] = membership.NewHashring(s, peerProvider, clock.NewRealTimeSource(), params.Logger, params.MetricsClient.Scope(metrics.HashringScope))
+		params.HashRings[s] = membership.NewHashring(s, peerProvider, clock.NewRealTimeSource(), params.Logger, params.MetricsClient.Scope(metrics.HashringScope), hashringOptions...)
 	}
 
 	wrappedRings := s.newMethod(params.HashRings, shardDistributorClient, dc, params.Logger)
@@ -241,6 +251,7 @@ func (s *server) startService() common.Daemon {
 	params.ArchiverProvider = provider.NewArchiverProvider(s.cfg.Archival.History.Provider, s.cfg.Archival.Visibility.Provider)
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicproperties.TransactionSizeLimit)
 	params.PersistenceConfig.ErrorInjectionRate = dc.GetFloat64Property(dynamicproperties.PersistenceErrorInjectionRate)
+	params.PersistenceConfig.HistoryZstdDictionaries = dc.GetListProperty(dynamicproperties.HistoryZstdDictionaries)
 	params.AuthorizationConfig = s.cfg.Authorization
 	params.BlobstoreClient, err = filestore.NewFilestoreClient(s.cfg.Blobstore.Filestore)
 	if err != nil {
@@ -254,7 +265,22 @@ func (s *server) startService() common.Daemon {
 	}
 
 	params.KafkaConfig = s.cfg.Kafka
-	params.DiagnosticsInvariants = []diagnosticsInvariant.Invariant{timeout.NewInvariant(timeout.Params{Client: params.PublicClient}), failure.NewInvariant(), retry.NewInvariant()}
+	params.DiagnosticsInvariants = []diagnosticsInvariant.Invariant{
+		timeout.NewInvariant(timeout.Params{Client: params.PublicClient}),
+		failure.NewInvariant(),
+		retry.NewInvariant(),
+		decision.NewInvariant(),
+		historysize.NewInvariant(historysize.Params{
+			CountLimitWarn:  dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitWarn),
+			CountLimitError: dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitError),
+			SizeLimitWarn:   dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistorySizeLimitWarn),
+			SizeLimitError:  dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistorySizeLimitError),
+		}),
+		signal.NewInvariant(),
+		childworkflow.NewInvariant(),
+		timer.NewInvariant(timer.Params{}),
+	}
+	params.DiagnosticsRuleFiles = s.cfg.Diagnostics.RuleFiles
 
 	params.Logger.Info("Starting service " + s.name)
 
@@ -299,6 +325,56 @@ func (*server) newMethod(
 	return hashRings
 }
 
+// newPeerProvider creates the peer provider plugin configured under membership.provider,
+// ringpop is used if none is configured
+func (s *server) newPeerProvider(service string, svcCfg config.Service, rpcFactory rpc.Factory, logger log.Logger) (membership.PeerProvider, error) {
+	portMap := membership.PortMap{
+		membership.PortGRPC:     svcCfg.RPC.GRPCPort,
+		membership.PortTchannel: svcCfg.RPC.Port,
+	}
+	if len(s.cfg.Membership.Provider) == 0 {
+		return ringpopprovider.New(service, &s.cfg.Ringpop, rpcFactory.GetTChannel(), portMap, logger)
+	}
+
+	key, err := peerProviderKey(s.cfg.Membership.Provider)
+	if err != nil {
+		return nil, err
+	}
+	// only one plugin can be registered per process, while every service in it builds its own provider
+	registerPeerProviderOnce.Do(func() {
+		if register, ok := builtinPeerProviders[key]; ok {
+			err = register()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peerprovider.New(s.cfg.Membership.Provider, peerprovider.Container{
+		Service: service,
+		Channel: rpcFactory.GetTChannel(),
+		Logger:  logger,
+		Portmap: portMap,
+	}).Provider()
+}
+
+// peerProviderKey returns the key of the peer provider plugin configured under membership.provider,
+// which has to be the only key as a single plugin can be registered
+func peerProviderKey(cfg config.PeerProvider) (string, error) {
+	if len(cfg) != 1 {
+		keys := make([]string, 0, len(cfg))
+		for key := range cfg {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return "", fmt.Errorf("membership.provider must configure exactly one peer provider, got %v", keys)
+	}
+	for key := range cfg {
+		return key, nil
+	}
+	return "", nil
+}
+
 func (*server) createShardDistributorClient(params resource.Params, dc *dynamicconfig.Collection) sharddistributorClient.Client {
 	shardDistributorClientConfig, ok := params.RPCFactory.GetDispatcher().OutboundConfig(service.ShardDistributor)
 	var shardDistributorClient sharddistributorClient.Client
@@ -154,3 +154,15 @@ func TestSettingGettingZonalIsolationGroupsFromIGError(t *testing.T) {
 		}, dc)()
 	})
 }
+
+func TestPeerProviderKey(t *testing.T) {
+	key, err := peerProviderKey(config.PeerProvider{"dns": &config.YamlNode{}})
+	require.NoError(t, err)
+	assert.Equal(t, "dns", key)
+
+	_, err = peerProviderKey(config.PeerProvider{"dns": &config.YamlNode{}, "static": &config.YamlNode{}})
+	assert.EqualError(t, err, "membership.provider must configure exactly one peer provider, got [dns static]")
+
+	_, err = peerProviderKey(config.PeerProvider{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package asyncrequest encodes the async requests that sqlblobs.AsyncRequestType does not define.
+// Their sqlblobs.AsyncRequestMessage has no type and EnvelopeEncoding as encoding, the payload
+// is an Envelope carrying the request type and ID along with the thriftrw encoded request.
+// Consumers which don't know the envelope reject such messages as an unsupported encoding.
+package asyncrequest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EnvelopeEncoding is the encoding of async request messages whose payload is an Envelope
+const EnvelopeEncoding = "thriftrw-envelope"
+
+// Type is the type of a request carried in an Envelope
+type Type string
+
+const (
+	// TypeSignalWorkflowExecution is a thriftrw encoded shared.SignalWorkflowExecutionRequest
+	TypeSignalWorkflowExecution Type = "SignalWorkflowExecutionAsyncRequest"
+	// TypeRequestCancelWorkflowExecution is a thriftrw encoded shared.RequestCancelWorkflowExecutionRequest
+	TypeRequestCancelWorkflowExecution Type = "RequestCancelWorkflowExecutionAsyncRequest"
+	// TypeTerminateWorkflowExecution is a thriftrw encoded shared.TerminateWorkflowExecutionRequest
+	TypeTerminateWorkflowExecution Type = "TerminateWorkflowExecutionAsyncRequest"
+)
+
+// Envelope is the payload of async request messages with EnvelopeEncoding
+type Envelope struct {
+	Type      Type   `json:"type"`
+	RequestID string `json:"requestID"`
+	// Request is thriftrw encoded, JSON encoding of requests would drop PII fields such as input
+	Request []byte `json:"request"`
+}
+
+// Encode returns the payload of an async request message carrying the envelope
+func Encode(envelope *Envelope) ([]byte, error) {
+	return json.Marshal(envelope)
+}
+
+// Decode returns the envelope carried by the payload of an async request message
+func Decode(payload []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Type == "" {
+		return nil, fmt.Errorf("async request envelope has no type")
+	}
+	return &envelope, nil
+}
@@ -36,6 +36,8 @@ import (
 	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/constants"
@@ -50,6 +52,7 @@ import (
 const (
 	defaultShutdownTimeout = 5 * time.Second
 	defaultStartWFTimeout  = 3 * time.Second
+	defaultRecordTimeout   = 3 * time.Second
 	defaultConcurrency     = 100
 )
 
@@ -66,6 +69,7 @@ type DefaultConsumer struct {
 	startWFTimeout  time.Duration
 	msgDecoder      codec.BinaryEncoder
 	concurrency     int
+	statusStore     requeststatus.Store
 }
 
 type Option func(*DefaultConsumer)
@@ -76,6 +80,13 @@ func WithConcurrency(concurrency int) Option {
 	}
 }
 
+// WithStatusStore records the outcome of requests carrying a request ID, outcomes are not recorded if the store is nil
+func WithStatusStore(store requeststatus.Store) Option {
+	return func(c *DefaultConsumer) {
+		c.statusStore = store
+	}
+}
+
 func New(
 	queueID string,
 	innerConsumer messaging.Consumer,
@@ -186,6 +197,10 @@ func (c *DefaultConsumer) processMessage(msg messaging.Message) {
 }
 
 func (c *DefaultConsumer) processRequest(logger log.Logger, request *sqlblobs.AsyncRequestMessage) ([]tag.Tag, error) {
+	if request.GetEncoding() == asyncrequest.EnvelopeEncoding {
+		return c.processEnvelope(logger, request)
+	}
+
 	requestType := request.GetType().String()
 	scope := c.scope.Tagged(metrics.AsyncWFRequestTypeTag(requestType))
 	logTags := []tag.Tag{tag.AsyncWFRequestType(requestType)}
@@ -200,28 +215,34 @@ func (c *DefaultConsumer) processRequest(logger log.Logger, request *sqlblobs.As
 		yarpcCallOpts := getYARPCOptions(request.GetHeader())
 		scope := scope.Tagged(metrics.DomainTag(startWFReq.GetDomain()))
 		logTags = append(logTags, tag.WorkflowDomainName(startWFReq.GetDomain()), tag.WorkflowID(startWFReq.GetWorkflowID()))
+		requestID := startWFReq.GetRequestID()
 
-		var resp *types.StartWorkflowExecutionResponse
+		status := newRequestStatus(requestType, requeststatus.StatusStarted, startWFReq.GetWorkflowID())
 		op := func(ctx1 context.Context) error {
 			ctx, cancel := context.WithTimeout(ctx1, c.startWFTimeout)
 			defer cancel()
-			resp, err = c.frontendClient.StartWorkflowExecution(ctx, startWFReq, yarpcCallOpts...)
+			resp, err := c.frontendClient.StartWorkflowExecution(ctx, startWFReq, yarpcCallOpts...)
 
 			var startedError *types.WorkflowExecutionAlreadyStartedError
 			if errors.As(err, &startedError) {
 				logger.Info("Received WorkflowExecutionAlreadyStartedError, treating it as a success", tag.WorkflowID(startWFReq.GetWorkflowID()), tag.WorkflowRunID(startedError.RunID))
+				status.Status = requeststatus.StatusDuplicate
+				status.RunID = startedError.RunID
 				return nil
 			}
+			status.RunID = resp.GetRunID()
 			return err
 		}
 
 		if err := callFrontendWithRetries(c.ctx, op); err != nil {
 			scope.IncCounter(metrics.AsyncWorkflowFailureByFrontendCount)
+			c.recordStatus(logger, startWFReq.GetDomain(), requestID, failedRequestStatus(requestType, startWFReq.GetWorkflowID(), err))
 			return logTags, fmt.Errorf("start workflow execution failed after all attempts: %w", err)
 		}
 
-		logTags = append(logTags, tag.WorkflowRunID(resp.GetRunID()))
+		logTags = append(logTags, tag.WorkflowRunID(status.RunID))
 		scope.IncCounter(metrics.AsyncWorkflowSuccessCount)
+		c.recordStatus(logger, startWFReq.GetDomain(), requestID, status)
 	case sqlblobs.AsyncRequestTypeSignalWithStartWorkflowExecutionAsyncRequest:
 		startWFReq, err := c.decodeSignalWithStartWorkflowRequest(request.GetPayload(), request.GetEncoding())
 		if err != nil {
@@ -232,27 +253,34 @@ func (c *DefaultConsumer) processRequest(logger log.Logger, request *sqlblobs.As
 		yarpcCallOpts := getYARPCOptions(request.GetHeader())
 		scope := c.scope.Tagged(metrics.DomainTag(startWFReq.GetDomain()))
 		logTags = append(logTags, tag.WorkflowDomainName(startWFReq.GetDomain()), tag.WorkflowID(startWFReq.GetWorkflowID()))
-		var resp *types.StartWorkflowExecutionResponse
+		requestID := startWFReq.GetRequestID()
+
+		status := newRequestStatus(requestType, requeststatus.StatusStarted, startWFReq.GetWorkflowID())
 		op := func(ctx1 context.Context) error {
 			ctx, cancel := context.WithTimeout(ctx1, c.startWFTimeout)
 			defer cancel()
-			resp, err = c.frontendClient.SignalWithStartWorkflowExecution(ctx, startWFReq, yarpcCallOpts...)
+			resp, err := c.frontendClient.SignalWithStartWorkflowExecution(ctx, startWFReq, yarpcCallOpts...)
 
 			var startedError *types.WorkflowExecutionAlreadyStartedError
 			if errors.As(err, &startedError) {
 				logger.Info("Received WorkflowExecutionAlreadyStartedError, treating it as a success", tag.WorkflowID(startWFReq.GetWorkflowID()), tag.WorkflowRunID(startedError.RunID))
+				status.Status = requeststatus.StatusDuplicate
+				status.RunID = startedError.RunID
 				return nil
 			}
+			status.RunID = resp.GetRunID()
 			return err
 		}
 
 		if err := callFrontendWithRetries(c.ctx, op); err != nil {
 			scope.IncCounter(metrics.AsyncWorkflowFailureByFrontendCount)
+			c.recordStatus(logger, startWFReq.GetDomain(), requestID, failedRequestStatus(requestType, startWFReq.GetWorkflowID(), err))
 			return logTags, fmt.Errorf("signal with start workflow execution failed after all attempts: %w", err)
 		}
 
 		scope.IncCounter(metrics.AsyncWorkflowSuccessCount)
-		logTags = append(logTags, tag.WorkflowRunID(resp.GetRunID()))
+		logTags = append(logTags, tag.WorkflowRunID(status.RunID))
+		c.recordStatus(logger, startWFReq.GetDomain(), requestID, status)
 	default:
 		c.scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
 		return logTags, &UnsupportedRequestType{Type: request.GetType()}
@@ -261,6 +289,144 @@ func (c *DefaultConsumer) processRequest(logger log.Logger, request *sqlblobs.As
 	return logTags, nil
 }
 
+// processEnvelope processes the requests whose type and ID are carried in the payload of the message
+func (c *DefaultConsumer) processEnvelope(logger log.Logger, request *sqlblobs.AsyncRequestMessage) ([]tag.Tag, error) {
+	envelope, err := asyncrequest.Decode(request.GetPayload())
+	if err != nil {
+		c.scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
+		return nil, err
+	}
+
+	requestType := string(envelope.Type)
+	scope := c.scope.Tagged(metrics.AsyncWFRequestTypeTag(requestType))
+	logTags := []tag.Tag{tag.AsyncWFRequestType(requestType)}
+	switch envelope.Type {
+	case asyncrequest.TypeSignalWorkflowExecution:
+		signalReq, err := c.decodeSignalWorkflowRequest(envelope.Request)
+		if err != nil {
+			scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
+			return logTags, err
+		}
+
+		yarpcCallOpts := getYARPCOptions(request.GetHeader())
+		workflowID := signalReq.GetWorkflowExecution().GetWorkflowID()
+		logTags = append(logTags, tag.WorkflowDomainName(signalReq.GetDomain()), tag.WorkflowID(workflowID))
+		op := func(ctx1 context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx1, c.startWFTimeout)
+			defer cancel()
+			return c.frontendClient.SignalWorkflowExecution(ctx, signalReq, yarpcCallOpts...)
+		}
+
+		if err := c.callFrontendAndRecord(logger, scope, requestType, envelope.RequestID, signalReq.GetDomain(), workflowID, op); err != nil {
+			return logTags, fmt.Errorf("signal workflow execution failed after all attempts: %w", err)
+		}
+	case asyncrequest.TypeRequestCancelWorkflowExecution:
+		cancelReq, err := c.decodeRequestCancelWorkflowRequest(envelope.Request)
+		if err != nil {
+			scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
+			return logTags, err
+		}
+
+		yarpcCallOpts := getYARPCOptions(request.GetHeader())
+		workflowID := cancelReq.GetWorkflowExecution().GetWorkflowID()
+		logTags = append(logTags, tag.WorkflowDomainName(cancelReq.GetDomain()), tag.WorkflowID(workflowID))
+		op := func(ctx1 context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx1, c.startWFTimeout)
+			defer cancel()
+			err := c.frontendClient.RequestCancelWorkflowExecution(ctx, cancelReq, yarpcCallOpts...)
+
+			var cancelRequestedError *types.CancellationAlreadyRequestedError
+			if errors.As(err, &cancelRequestedError) {
+				logger.Info("Received CancellationAlreadyRequestedError, treating it as a success", tag.WorkflowID(workflowID))
+				return nil
+			}
+			return err
+		}
+
+		if err := c.callFrontendAndRecord(logger, scope, requestType, envelope.RequestID, cancelReq.GetDomain(), workflowID, op); err != nil {
+			return logTags, fmt.Errorf("request cancel workflow execution failed after all attempts: %w", err)
+		}
+	case asyncrequest.TypeTerminateWorkflowExecution:
+		terminateReq, err := c.decodeTerminateWorkflowRequest(envelope.Request)
+		if err != nil {
+			scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
+			return logTags, err
+		}
+
+		yarpcCallOpts := getYARPCOptions(request.GetHeader())
+		workflowID := terminateReq.GetWorkflowExecution().GetWorkflowID()
+		logTags = append(logTags, tag.WorkflowDomainName(terminateReq.GetDomain()), tag.WorkflowID(workflowID))
+		op := func(ctx1 context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx1, c.startWFTimeout)
+			defer cancel()
+			return c.frontendClient.TerminateWorkflowExecution(ctx, terminateReq, yarpcCallOpts...)
+		}
+
+		if err := c.callFrontendAndRecord(logger, scope, requestType, envelope.RequestID, terminateReq.GetDomain(), workflowID, op); err != nil {
+			return logTags, fmt.Errorf("terminate workflow execution failed after all attempts: %w", err)
+		}
+	default:
+		c.scope.IncCounter(metrics.AsyncWorkflowFailureCorruptMsgCount)
+		return logTags, &UnsupportedEnvelopeType{Type: envelope.Type}
+	}
+
+	return logTags, nil
+}
+
+// callFrontendAndRecord calls the frontend with retries and records the outcome under the request ID
+func (c *DefaultConsumer) callFrontendAndRecord(
+	logger log.Logger,
+	scope metrics.Scope,
+	requestType string,
+	requestID string,
+	domain string,
+	workflowID string,
+	op func(ctx context.Context) error,
+) error {
+	scope = scope.Tagged(metrics.DomainTag(domain))
+	err := callFrontendWithRetries(c.ctx, op)
+	if err != nil {
+		scope.IncCounter(metrics.AsyncWorkflowFailureByFrontendCount)
+	} else {
+		scope.IncCounter(metrics.AsyncWorkflowSuccessCount)
+	}
+
+	status := newRequestStatus(requestType, requeststatus.StatusSucceeded, workflowID)
+	if err != nil {
+		status = failedRequestStatus(requestType, workflowID, err)
+	}
+	c.recordStatus(logger, domain, requestID, status)
+	return err
+}
+
+// recordStatus records the status of a consumed request, failing to record it doesn't fail the request
+func (c *DefaultConsumer) recordStatus(logger log.Logger, domain, requestID string, status *requeststatus.RequestStatus) {
+	// the message is delivered again if processing was interrupted by shutdown, so there is no outcome yet
+	if c.statusStore == nil || requestID == "" || c.ctx.Err() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, defaultRecordTimeout)
+	defer cancel()
+	if err := c.statusStore.Record(ctx, domain, requestID, status); err != nil {
+		logger.Warn("Failed to record async request status", tag.WorkflowDomainName(domain), tag.WorkflowID(status.WorkflowID), tag.Error(err))
+	}
+}
+
+func newRequestStatus(requestType string, status requeststatus.Status, workflowID string) *requeststatus.RequestStatus {
+	return &requeststatus.RequestStatus{
+		Status:      status,
+		RequestType: requestType,
+		WorkflowID:  workflowID,
+	}
+}
+
+func failedRequestStatus(requestType string, workflowID string, err error) *requeststatus.RequestStatus {
+	status := newRequestStatus(requestType, requeststatus.StatusFailed, workflowID)
+	status.Reason = err.Error()
+	return status
+}
+
 func callFrontendWithRetries(ctx context.Context, op func(ctx context.Context) error) error {
 	throttleRetry := backoff.NewThrottleRetry(
 		backoff.WithRetryPolicy(common.CreateFrontendServiceRetryPolicy()),
@@ -317,3 +483,30 @@ func (c *DefaultConsumer) decodeSignalWithStartWorkflowRequest(payload []byte, e
 	signalWithStartRequest := thrift.ToSignalWithStartWorkflowExecutionAsyncRequest(&thriftObj)
 	return signalWithStartRequest.SignalWithStartWorkflowExecutionRequest, nil
 }
+
+func (c *DefaultConsumer) decodeSignalWorkflowRequest(payload []byte) (*types.SignalWorkflowExecutionRequest, error) {
+	var thriftObj shared.SignalWorkflowExecutionRequest
+	if err := c.msgDecoder.Decode(payload, &thriftObj); err != nil {
+		return nil, err
+	}
+
+	return thrift.ToSignalWorkflowExecutionRequest(&thriftObj), nil
+}
+
+func (c *DefaultConsumer) decodeRequestCancelWorkflowRequest(payload []byte) (*types.RequestCancelWorkflowExecutionRequest, error) {
+	var thriftObj shared.RequestCancelWorkflowExecutionRequest
+	if err := c.msgDecoder.Decode(payload, &thriftObj); err != nil {
+		return nil, err
+	}
+
+	return thrift.ToRequestCancelWorkflowExecutionRequest(&thriftObj), nil
+}
+
+func (c *DefaultConsumer) decodeTerminateWorkflowRequest(payload []byte) (*types.TerminateWorkflowExecutionRequest, error) {
+	var thriftObj shared.TerminateWorkflowExecutionRequest
+	if err := c.msgDecoder.Decode(payload, &thriftObj); err != nil {
+		return nil, err
+	}
+
+	return thrift.ToTerminateWorkflowExecutionRequest(&thriftObj), nil
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/log/testlogger"
@@ -54,6 +56,31 @@ var (
 		},
 	}
 
+	testExecution = &types.WorkflowExecution{
+		WorkflowID: "test-workflow-id",
+		RunID:      "test-run-id",
+	}
+
+	testSignalReq = &types.SignalWorkflowExecutionRequest{
+		Domain:            "test-domain",
+		WorkflowExecution: testExecution,
+		SignalName:        "test-signal-name",
+		Input:             []byte("test-input"),
+		RequestID:         "test-request-id",
+	}
+
+	testCancelReq = &types.RequestCancelWorkflowExecutionRequest{
+		Domain:            "test-domain",
+		WorkflowExecution: testExecution,
+		RequestID:         "test-request-id",
+	}
+
+	testTerminateReq = &types.TerminateWorkflowExecutionRequest{
+		Domain:            "test-domain",
+		WorkflowExecution: testExecution,
+		Reason:            "test-reason",
+	}
+
 	testStartReq = &types.StartWorkflowExecutionAsyncRequest{
 		StartWorkflowExecutionRequest: &types.StartWorkflowExecutionRequest{
 			Domain:       "test-domain",
@@ -62,6 +89,15 @@ var (
 			Input:        []byte("test-input"),
 		},
 	}
+
+	testStartReqWithID = &types.StartWorkflowExecutionAsyncRequest{
+		StartWorkflowExecutionRequest: &types.StartWorkflowExecutionRequest{
+			Domain:       "test-domain",
+			WorkflowID:   "test-workflow-id",
+			WorkflowType: &types.WorkflowType{Name: "test-workflow-type"},
+			RequestID:    "test-request-id",
+		},
+	}
 )
 
 type fakeMessageConsumer struct {
@@ -300,6 +336,202 @@ func TestDefaultConsumer(t *testing.T) {
 	}
 }
 
+func TestDefaultConsumerRecordsStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        *fakeMessage
+		setupMocks func(*frontend.MockClient, *requeststatus.MockStore)
+	}{
+		{
+			name: "start ok",
+			msg:  &fakeMessage{val: mustGenerateRequestMsg(t, sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest, thrift.FromStartWorkflowExecutionAsyncRequest(testStartReqWithID)), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&types.StartWorkflowExecutionResponse{RunID: "test-run-id"}, nil)
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusStarted,
+					RequestType: "StartWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+					RunID:       "test-run-id",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "start already started by another request",
+			msg:  &fakeMessage{val: mustGenerateRequestMsg(t, sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest, thrift.FromStartWorkflowExecutionAsyncRequest(testStartReqWithID)), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.WorkflowExecutionAlreadyStartedError{RunID: "other-run-id"})
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusDuplicate,
+					RequestType: "StartWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+					RunID:       "other-run-id",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "signal with start failed",
+			msg: &fakeMessage{val: mustGenerateRequestMsg(t, sqlblobs.AsyncRequestTypeSignalWithStartWorkflowExecutionAsyncRequest, thrift.FromSignalWithStartWorkflowExecutionAsyncRequest(&types.SignalWithStartWorkflowExecutionAsyncRequest{
+				SignalWithStartWorkflowExecutionRequest: &types.SignalWithStartWorkflowExecutionRequest{
+					Domain:     "test-domain",
+					WorkflowID: "test-workflow-id",
+					SignalName: "test-signal",
+					RequestID:  "test-request-id",
+				},
+			})), wantAck: false},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().SignalWithStartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.BadRequestError{Message: "invalid workflow type"})
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusFailed,
+					RequestType: "SignalWithStartWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+					Reason:      "invalid workflow type",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "signal ok",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeSignalWorkflowExecution, thrift.FromSignalWorkflowExecutionRequest(testSignalReq), "test-request-id"), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().SignalWorkflowExecution(gomock.Any(), testSignalReq, gomock.Any(), gomock.Any()).Return(nil)
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusSucceeded,
+					RequestType: "SignalWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "signal failed",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeSignalWorkflowExecution, thrift.FromSignalWorkflowExecutionRequest(testSignalReq), "test-request-id"), wantAck: false},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().SignalWorkflowExecution(gomock.Any(), testSignalReq, gomock.Any(), gomock.Any()).Return(&types.EntityNotExistsError{Message: "workflow not found"})
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusFailed,
+					RequestType: "SignalWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+					Reason:      "workflow not found",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "cancel already requested is a success",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeRequestCancelWorkflowExecution, thrift.FromRequestCancelWorkflowExecutionRequest(testCancelReq), "test-request-id"), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), testCancelReq, gomock.Any(), gomock.Any()).Return(&types.CancellationAlreadyRequestedError{Message: "already requested"})
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{
+					Status:      requeststatus.StatusSucceeded,
+					RequestType: "RequestCancelWorkflowExecutionAsyncRequest",
+					WorkflowID:  "test-workflow-id",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "terminate ok even if recording fails",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeTerminateWorkflowExecution, thrift.FromTerminateWorkflowExecutionRequest(testTerminateReq), "test-terminate-id"), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().TerminateWorkflowExecution(gomock.Any(), testTerminateReq, gomock.Any(), gomock.Any()).Return(nil)
+				mockStore.EXPECT().Record(gomock.Any(), "test-domain", "test-terminate-id", gomock.Any()).Return(errors.New("blobstore unavailable"))
+			},
+		},
+		{
+			name: "not recorded without request ID",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeTerminateWorkflowExecution, thrift.FromTerminateWorkflowExecutionRequest(testTerminateReq), ""), wantAck: true},
+			setupMocks: func(mockFrontend *frontend.MockClient, mockStore *requeststatus.MockStore) {
+				mockFrontend.EXPECT().TerminateWorkflowExecution(gomock.Any(), testTerminateReq, gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "unsupported envelope type",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.Type("UnknownAsyncRequest"), thrift.FromTerminateWorkflowExecutionRequest(testTerminateReq), "test-terminate-id"), wantAck: false},
+		},
+		{
+			name: "terminate with invalid payload content",
+			msg:  &fakeMessage{val: mustGenerateEnvelopeMsg(t, asyncrequest.TypeTerminateWorkflowExecution, nil, "test-terminate-id"), wantAck: false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeConsumer := &fakeMessageConsumer{
+				ch: make(chan messaging.Message),
+			}
+			mockFrontend := frontend.NewMockClient(ctrl)
+			mockStore := requeststatus.NewMockStore(ctrl)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockFrontend, mockStore)
+			}
+
+			c := New("queueid1", fakeConsumer, testlogger.New(t), metrics.NewNoopMetricsClient(), mockFrontend, WithConcurrency(1), WithStatusStore(mockStore))
+			if err := c.Start(); err != nil {
+				t.Fatalf("Start() err: %v", err)
+			}
+
+			fakeConsumer.ch <- tc.msg
+			c.Stop()
+
+			if tc.msg.wantAck && !tc.msg.acked {
+				t.Error("message not acked")
+			}
+			if !tc.msg.wantAck && !tc.msg.nacked {
+				t.Error("message not nacked")
+			}
+		})
+	}
+}
+
+func mustGenerateRequestMsg(t *testing.T, requestType sqlblobs.AsyncRequestType, request codec.ThriftObject) []byte {
+	msg := &sqlblobs.AsyncRequestMessage{
+		Type:     requestType.Ptr(),
+		Header:   fakeHeaders(),
+		Encoding: common.StringPtr(string(constants.EncodingTypeThriftRW)),
+		Payload:  mustEncodeRequest(t, request),
+	}
+
+	res, err := codec.NewThriftRWEncoder().Encode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func mustGenerateEnvelopeMsg(t *testing.T, requestType asyncrequest.Type, request codec.ThriftObject, requestID string) []byte {
+	payload, err := asyncrequest.Encode(&asyncrequest.Envelope{
+		Type:      requestType,
+		RequestID: requestID,
+		Request:   mustEncodeRequest(t, request),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := &sqlblobs.AsyncRequestMessage{
+		Header:   fakeHeaders(),
+		Encoding: common.StringPtr(asyncrequest.EnvelopeEncoding),
+		Payload:  payload,
+	}
+
+	res, err := codec.NewThriftRWEncoder().Encode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func mustEncodeRequest(t *testing.T, request codec.ThriftObject) []byte {
+	if request == nil {
+		return []byte("invalid payload")
+	}
+
+	payload, err := codec.NewThriftRWEncoder().Encode(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
 func mustGenerateStartWorkflowExecutionRequestMsg(t *testing.T, encodingType constants.EncodingType, validPayload bool) []byte {
 	encoder := codec.NewThriftRWEncoder()
 	payload, err := encoder.Encode(thrift.FromStartWorkflowExecutionAsyncRequest(testStartReq))
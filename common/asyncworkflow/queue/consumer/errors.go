@@ -22,7 +22,10 @@
 
 package consumer
 
-import "github.com/uber/cadence/.gen/go/sqlblobs"
+import (
+	"github.com/uber/cadence/.gen/go/sqlblobs"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+)
 
 type UnsupportedRequestType struct {
 	Type sqlblobs.AsyncRequestType
@@ -32,6 +35,14 @@ func (e *UnsupportedRequestType) Error() string {
 	return "unsupported request type: " + e.Type.String()
 }
 
+type UnsupportedEnvelopeType struct {
+	Type asyncrequest.Type
+}
+
+func (e *UnsupportedEnvelopeType) Error() string {
+	return "unsupported request type: " + string(e.Type)
+}
+
 type UnsupportedEncoding struct {
 	EncodingType string
 }
@@ -0,0 +1,104 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package consumer
+
+import (
+	"github.com/uber/cadence/.gen/go/sqlblobs"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/codec"
+)
+
+// RequestInfo is a summary of an async request message that is used to inspect the queue
+type RequestInfo struct {
+	Type       string
+	Domain     string
+	WorkflowID string
+	RequestID  string
+}
+
+// DecodeRequestInfo decodes an async request message as published by the frontend
+func DecodeRequestInfo(payload []byte) (*RequestInfo, error) {
+	c := &DefaultConsumer{msgDecoder: codec.NewThriftRWEncoder()}
+
+	var request sqlblobs.AsyncRequestMessage
+	if err := c.msgDecoder.Decode(payload, &request); err != nil {
+		return nil, err
+	}
+	if request.GetEncoding() == asyncrequest.EnvelopeEncoding {
+		return c.decodeEnvelopeInfo(request.GetPayload())
+	}
+
+	info := &RequestInfo{Type: request.GetType().String()}
+	switch request.GetType() {
+	case sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest:
+		req, err := c.decodeStartWorkflowRequest(request.GetPayload(), request.GetEncoding())
+		if err != nil {
+			return nil, err
+		}
+		info.Domain, info.WorkflowID, info.RequestID = req.GetDomain(), req.GetWorkflowID(), req.GetRequestID()
+	case sqlblobs.AsyncRequestTypeSignalWithStartWorkflowExecutionAsyncRequest:
+		req, err := c.decodeSignalWithStartWorkflowRequest(request.GetPayload(), request.GetEncoding())
+		if err != nil {
+			return nil, err
+		}
+		info.Domain, info.WorkflowID, info.RequestID = req.GetDomain(), req.GetWorkflowID(), req.GetRequestID()
+	default:
+		return nil, &UnsupportedRequestType{Type: request.GetType()}
+	}
+	return info, nil
+}
+
+func (c *DefaultConsumer) decodeEnvelopeInfo(payload []byte) (*RequestInfo, error) {
+	envelope, err := asyncrequest.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &RequestInfo{
+		Type:      string(envelope.Type),
+		RequestID: envelope.RequestID,
+	}
+	switch envelope.Type {
+	case asyncrequest.TypeSignalWorkflowExecution:
+		req, err := c.decodeSignalWorkflowRequest(envelope.Request)
+		if err != nil {
+			return nil, err
+		}
+		info.Domain, info.WorkflowID = req.GetDomain(), req.GetWorkflowExecution().GetWorkflowID()
+	case asyncrequest.TypeRequestCancelWorkflowExecution:
+		req, err := c.decodeRequestCancelWorkflowRequest(envelope.Request)
+		if err != nil {
+			return nil, err
+		}
+		info.Domain, info.WorkflowID = req.GetDomain(), req.GetWorkflowExecution().GetWorkflowID()
+	case asyncrequest.TypeTerminateWorkflowExecution:
+		req, err := c.decodeTerminateWorkflowRequest(envelope.Request)
+		if err != nil {
+			return nil, err
+		}
+		info.Domain, info.WorkflowID = req.GetDomain(), req.GetWorkflowExecution().GetWorkflowID()
+	default:
+		return nil, &UnsupportedEnvelopeType{Type: envelope.Type}
+	}
+	return info, nil
+}
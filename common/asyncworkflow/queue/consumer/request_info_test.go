@@ -0,0 +1,120 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/.gen/go/sqlblobs"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types/mapper/thrift"
+)
+
+func TestDecodeRequestInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		want    *RequestInfo
+		wantErr bool
+	}{
+		{
+			name:    "start",
+			payload: mustGenerateRequestMsg(t, sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest, thrift.FromStartWorkflowExecutionAsyncRequest(testStartReqWithID)),
+			want: &RequestInfo{
+				Type:       "StartWorkflowExecutionAsyncRequest",
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow-id",
+				RequestID:  "test-request-id",
+			},
+		},
+		{
+			name:    "signal with start",
+			payload: mustGenerateSignalWithStartWorkflowExecutionRequestMsg(t, constants.EncodingTypeThriftRW, true),
+			want: &RequestInfo{
+				Type:       "SignalWithStartWorkflowExecutionAsyncRequest",
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow-id",
+			},
+		},
+		{
+			name:    "signal",
+			payload: mustGenerateEnvelopeMsg(t, asyncrequest.TypeSignalWorkflowExecution, thrift.FromSignalWorkflowExecutionRequest(testSignalReq), "test-request-id"),
+			want: &RequestInfo{
+				Type:       "SignalWorkflowExecutionAsyncRequest",
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow-id",
+				RequestID:  "test-request-id",
+			},
+		},
+		{
+			name:    "cancel",
+			payload: mustGenerateEnvelopeMsg(t, asyncrequest.TypeRequestCancelWorkflowExecution, thrift.FromRequestCancelWorkflowExecutionRequest(testCancelReq), "test-request-id"),
+			want: &RequestInfo{
+				Type:       "RequestCancelWorkflowExecutionAsyncRequest",
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow-id",
+				RequestID:  "test-request-id",
+			},
+		},
+		{
+			name:    "terminate",
+			payload: mustGenerateEnvelopeMsg(t, asyncrequest.TypeTerminateWorkflowExecution, thrift.FromTerminateWorkflowExecutionRequest(testTerminateReq), "terminate-request-id"),
+			want: &RequestInfo{
+				Type:       "TerminateWorkflowExecutionAsyncRequest",
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow-id",
+				RequestID:  "terminate-request-id",
+			},
+		},
+		{
+			name:    "corrupted message",
+			payload: []byte("invalid message"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid payload",
+			payload: mustGenerateEnvelopeMsg(t, asyncrequest.TypeSignalWorkflowExecution, nil, "test-request-id"),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported request type",
+			payload: mustGenerateUnsupportedRequestMsg(t),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeRequestInfo(tc.payload)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+)
+
+const (
+	// consumerName is the name the consumer offset is stored under in the queue ack levels
+	consumerName = "async-workflow-consumer"
+
+	defaultCommitInterval = 5 * time.Second
+	persistenceTimeout    = 10 * time.Second
+)
+
+type (
+	consumerImpl struct {
+		queueID        string
+		queueManager   persistence.QueueManager
+		resolver       membership.Resolver
+		logger         log.Logger
+		timeSource     clock.TimeSource
+		batchSize      int
+		pollInterval   time.Duration
+		commitInterval time.Duration
+		msgChan        chan messaging.Message
+		status         int32
+		ctx            context.Context
+		cancelFn       context.CancelFunc
+		wg             sync.WaitGroup
+
+		sync.Mutex
+		// ackMgr is replaced whenever this host takes over the queue so the in-memory
+		// offsets of a previous ownership never leak into the persisted ack level
+		ackMgr            messaging.AckManager
+		owner             bool
+		committedAckLevel int64
+	}
+
+	messageImpl struct {
+		id       int64
+		payload  []byte
+		ackMgr   messaging.AckManager
+		consumer *consumerImpl
+	}
+)
+
+var _ messaging.Consumer = (*consumerImpl)(nil)
+
+func newConsumer(
+	queueID string,
+	config *queueConfig,
+	queueManager persistence.QueueManager,
+	resolver membership.Resolver,
+	logger log.Logger,
+) *consumerImpl {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	return &consumerImpl{
+		queueID:           queueID,
+		queueManager:      queueManager,
+		resolver:          resolver,
+		logger:            logger.WithTags(tag.AsyncWFQueueID(queueID)),
+		timeSource:        clock.NewRealTimeSource(),
+		batchSize:         config.BatchSize,
+		pollInterval:      config.PollInterval,
+		commitInterval:    defaultCommitInterval,
+		msgChan:           make(chan messaging.Message, config.BatchSize),
+		status:            common.DaemonStatusInitialized,
+		ctx:               ctx,
+		cancelFn:          cancelFn,
+		committedAckLevel: -1,
+	}
+}
+
+// Start starts polling the database queue
+func (c *consumerImpl) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return nil
+	}
+
+	c.wg.Add(1)
+	go c.pollLoop()
+	c.logger.Info("Database queue consumer started")
+	return nil
+}
+
+// Stop stops polling and persists the offset of the processed messages
+func (c *consumerImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	c.cancelFn()
+	c.wg.Wait()
+	c.commitAckLevel()
+	c.logger.Info("Database queue consumer stopped")
+}
+
+// Messages returns the channel the polled messages are delivered to
+func (c *consumerImpl) Messages() <-chan messaging.Message {
+	return c.msgChan
+}
+
+func (c *consumerImpl) pollLoop() {
+	defer c.wg.Done()
+	defer close(c.msgChan)
+
+	c.wg.Add(1)
+	go c.commitLoop()
+
+	pollTicker := c.timeSource.NewTicker(c.pollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-pollTicker.Chan():
+			c.poll()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *consumerImpl) commitLoop() {
+	defer c.wg.Done()
+
+	commitTicker := c.timeSource.NewTicker(c.commitInterval)
+	defer commitTicker.Stop()
+
+	for {
+		select {
+		case <-commitTicker.Chan():
+			c.commitAckLevel()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// poll reads the messages after the current read level and delivers them to the message channel.
+// It keeps reading until the queue is drained or the consumer is stopped.
+func (c *consumerImpl) poll() {
+	ackMgr, ok := c.acquireOwnership()
+	if !ok {
+		return
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(c.ctx, persistenceTimeout)
+		messages, err := c.queueManager.ReadMessages(ctx, ackMgr.GetReadLevel(), c.batchSize)
+		cancel()
+		if err != nil {
+			c.logger.Error("Failed to read messages from database queue", tag.Error(err))
+			return
+		}
+
+		for _, message := range messages {
+			if err := ackMgr.ReadItem(message.ID); err != nil {
+				c.logger.Error("Failed to track message", tag.TaskID(message.ID), tag.Error(err))
+				return
+			}
+			select {
+			case c.msgChan <- &messageImpl{id: message.ID, payload: message.Payload, ackMgr: ackMgr, consumer: c}:
+			case <-c.ctx.Done():
+				return
+			}
+		}
+
+		if len(messages) < c.batchSize {
+			return
+		}
+	}
+}
+
+// acquireOwnership makes sure only one worker consumes the queue at a time. This is best effort,
+// during ring changes two workers may consume the same messages which is fine because
+// requests are deduplicated by request ID.
+func (c *consumerImpl) acquireOwnership() (messaging.AckManager, bool) {
+	isOwner := c.isOwner()
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !isOwner {
+		if c.owner {
+			c.logger.Info("Worker is no longer responsible for database queue")
+		}
+		c.owner = false
+		return nil, false
+	}
+	if c.owner {
+		return c.ackMgr, true
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, persistenceTimeout)
+	defer cancel()
+	ackLevel, err := getAckLevel(ctx, c.queueManager)
+	if err != nil {
+		c.logger.Error("Failed to load database queue ack level", tag.Error(err))
+		return nil, false
+	}
+
+	c.ackMgr = messaging.NewAckManager(c.logger)
+	c.ackMgr.SetAckLevel(ackLevel)
+	c.committedAckLevel = ackLevel
+	c.owner = true
+	c.logger.Info("Worker took over database queue", tag.AckLevel(ackLevel))
+	return c.ackMgr, true
+}
+
+func (c *consumerImpl) isOwner() bool {
+	if c.resolver == nil {
+		return true
+	}
+	self, err := c.resolver.WhoAmI()
+	if err != nil {
+		c.logger.Warn("Failed to get self host info", tag.Error(err))
+		return false
+	}
+	owner, err := c.resolver.Lookup(service.Worker, c.queueID)
+	if err != nil {
+		c.logger.Warn("Failed to lookup owner of database queue", tag.Error(err))
+		return false
+	}
+	return owner.Identity() == self.Identity()
+}
+
+// commitAckLevel persists the offset up to which all messages are processed and
+// removes them from the database.
+func (c *consumerImpl) commitAckLevel() {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.owner {
+		return
+	}
+	ackLevel := c.ackMgr.GetAckLevel()
+	if ackLevel <= c.committedAckLevel {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), persistenceTimeout)
+	defer cancel()
+	if err := c.queueManager.UpdateAckLevel(ctx, ackLevel, consumerName); err != nil {
+		c.logger.Error("Failed to update database queue ack level", tag.AckLevel(ackLevel), tag.Error(err))
+		return
+	}
+	c.committedAckLevel = ackLevel
+
+	// the message at the ack level is kept so that message IDs keep increasing after the cleanup
+	if err := c.queueManager.DeleteMessagesBefore(ctx, ackLevel); err != nil {
+		c.logger.Warn("Failed to delete processed messages from database queue", tag.AckLevel(ackLevel), tag.Error(err))
+	}
+}
+
+func getAckLevel(ctx context.Context, queueManager persistence.QueueManager) (int64, error) {
+	ackLevels, err := queueManager.GetAckLevels(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if ackLevel, ok := ackLevels[consumerName]; ok {
+		return ackLevel, nil
+	}
+	return -1, nil
+}
+
+func (m *messageImpl) Value() []byte {
+	return m.payload
+}
+
+// Partition always returns 0 since the database queue is not partitioned
+func (m *messageImpl) Partition() int32 {
+	return 0
+}
+
+func (m *messageImpl) Offset() int64 {
+	return m.id
+}
+
+func (m *messageImpl) Ack() error {
+	m.ackMgr.AckItem(m.id)
+	return nil
+}
+
+// Nack moves the message to the DLQ of the database queue so it doesn't block the consumer
+func (m *messageImpl) Nack() error {
+	ctx, cancel := context.WithTimeout(context.Background(), persistenceTimeout)
+	defer cancel()
+	if err := m.consumer.queueManager.EnqueueMessageToDLQ(ctx, m.payload); err != nil {
+		return err
+	}
+	m.ackMgr.AckItem(m.id)
+	return nil
+}
@@ -0,0 +1,171 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+)
+
+func newTestConsumer(t *testing.T, queueManager persistence.QueueManager, resolver membership.Resolver) *consumerImpl {
+	config := &queueConfig{BatchSize: 2, PollInterval: time.Second}
+	return newConsumer(queueType, config, queueManager, resolver, testlogger.New(t))
+}
+
+func receive(t *testing.T, c *consumerImpl, count int) []messaging.Message {
+	var msgs []messaging.Message
+	for i := 0; i < count; i++ {
+		select {
+		case msg := <-c.Messages():
+			msgs = append(msgs, msg)
+		default:
+			t.Fatalf("expected %d messages but got %d", count, len(msgs))
+		}
+	}
+	return msgs
+}
+
+func TestConsumerPollAndCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queueManager := persistence.NewMockQueueManager(ctrl)
+	c := newTestConsumer(t, queueManager, nil)
+
+	queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{consumerName: 4}, nil)
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(4), 2).Return(persistence.QueueMessageList{
+		{ID: 5, Payload: []byte("a")},
+		{ID: 6, Payload: []byte("b")},
+	}, nil)
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(6), 2).Return(nil, nil)
+	c.poll()
+
+	msgs := receive(t, c, 2)
+	assert.Equal(t, int64(5), msgs[0].Offset())
+	assert.Equal(t, []byte("a"), msgs[0].Value())
+	assert.Equal(t, int32(0), msgs[0].Partition())
+	assert.Equal(t, int64(6), msgs[1].Offset())
+
+	// message 5 is not processed yet so the ack level can't move
+	require.NoError(t, msgs[1].Ack())
+	c.commitAckLevel()
+
+	require.NoError(t, msgs[0].Ack())
+	queueManager.EXPECT().UpdateAckLevel(gomock.Any(), int64(6), consumerName).Return(nil)
+	queueManager.EXPECT().DeleteMessagesBefore(gomock.Any(), int64(6)).Return(nil)
+	c.commitAckLevel()
+
+	// nothing new to commit
+	c.commitAckLevel()
+
+	// next poll continues from the read level without reloading the ack level
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(6), 2).Return(nil, nil)
+	c.poll()
+}
+
+func TestConsumerNack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queueManager := persistence.NewMockQueueManager(ctrl)
+	c := newTestConsumer(t, queueManager, nil)
+
+	queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(nil, nil)
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(-1), 2).Return(persistence.QueueMessageList{
+		{ID: 0, Payload: []byte("a")},
+	}, nil)
+	c.poll()
+	msgs := receive(t, c, 1)
+
+	queueManager.EXPECT().EnqueueMessageToDLQ(gomock.Any(), []byte("a")).Return(errors.New("failed"))
+	assert.Error(t, msgs[0].Nack())
+	c.commitAckLevel()
+
+	queueManager.EXPECT().EnqueueMessageToDLQ(gomock.Any(), []byte("a")).Return(nil)
+	assert.NoError(t, msgs[0].Nack())
+	queueManager.EXPECT().UpdateAckLevel(gomock.Any(), int64(0), consumerName).Return(nil)
+	queueManager.EXPECT().DeleteMessagesBefore(gomock.Any(), int64(0)).Return(nil)
+	c.commitAckLevel()
+}
+
+func TestConsumerOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queueManager := persistence.NewMockQueueManager(ctrl)
+	resolver := membership.NewMockResolver(ctrl)
+	c := newTestConsumer(t, queueManager, resolver)
+
+	self := membership.NewHostInfo("self")
+	other := membership.NewHostInfo("other")
+	resolver.EXPECT().WhoAmI().Return(self, nil).AnyTimes()
+
+	// owned by another worker: nothing is read
+	resolver.EXPECT().Lookup(service.Worker, queueType).Return(other, nil)
+	c.poll()
+	c.commitAckLevel()
+
+	// took over the queue
+	resolver.EXPECT().Lookup(service.Worker, queueType).Return(self, nil)
+	queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{consumerName: 10}, nil)
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(10), 2).Return(persistence.QueueMessageList{
+		{ID: 11, Payload: []byte("a")},
+	}, nil)
+	c.poll()
+	msgs := receive(t, c, 1)
+
+	// lost ownership before the message was committed
+	resolver.EXPECT().Lookup(service.Worker, queueType).Return(other, nil)
+	c.poll()
+	require.NoError(t, msgs[0].Ack())
+	c.commitAckLevel()
+
+	// regained ownership: offsets are reloaded from the database
+	resolver.EXPECT().Lookup(service.Worker, queueType).Return(self, nil)
+	queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{consumerName: 11}, nil)
+	queueManager.EXPECT().ReadMessages(gomock.Any(), int64(11), 2).Return(nil, nil)
+	c.poll()
+
+	// lookup failure is treated as not owning the queue
+	resolver.EXPECT().Lookup(service.Worker, queueType).Return(membership.HostInfo{}, errors.New("failed"))
+	c.poll()
+}
+
+func TestConsumerStartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queueManager := persistence.NewMockQueueManager(ctrl)
+	c := newTestConsumer(t, queueManager, nil)
+
+	require.NoError(t, c.Start())
+	require.NoError(t, c.Start())
+	c.Stop()
+	c.Stop()
+
+	_, ok := <-c.Messages()
+	assert.False(t, ok, "message channel should be closed after stop")
+}
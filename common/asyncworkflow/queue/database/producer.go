@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uber/cadence/.gen/go/sqlblobs"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	producerImpl struct {
+		queueManager persistence.QueueManager
+		msgEncoder   codec.BinaryEncoder
+		logger       log.Logger
+	}
+)
+
+var _ messaging.Producer = (*producerImpl)(nil)
+
+func newProducer(queueManager persistence.QueueManager, logger log.Logger) messaging.Producer {
+	return &producerImpl{
+		queueManager: queueManager,
+		msgEncoder:   codec.NewThriftRWEncoder(),
+		logger:       logger,
+	}
+}
+
+// Publish stores the async request in the database queue
+func (p *producerImpl) Publish(ctx context.Context, msg interface{}) error {
+	message, ok := msg.(*sqlblobs.AsyncRequestMessage)
+	if !ok {
+		return errors.New("unknown producer message type")
+	}
+
+	payload, err := p.msgEncoder.Encode(message)
+	if err != nil {
+		p.logger.Error("Failed to serialize async request message", tag.Error(err))
+		return err
+	}
+
+	if err := p.queueManager.EnqueueMessage(ctx, payload); err != nil {
+		p.logger.Warn("Failed to publish message to database queue", tag.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/uber/cadence/common/asyncworkflow/queue/consumer"
+	"github.com/uber/cadence/common/asyncworkflow/queue/provider"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+)
+
+type (
+	queueImpl struct {
+		config *queueConfig
+	}
+)
+
+var (
+	_ provider.Queue     = (*queueImpl)(nil)
+	_ provider.Inspector = (*queueImpl)(nil)
+
+	errNoQueueManager = errors.New("database queue requires a persistence queue manager")
+)
+
+func newQueue(decoder provider.Decoder) (provider.Queue, error) {
+	var out queueConfig
+	if err := decoder.Decode(&out); err != nil {
+		return nil, fmt.Errorf("bad config: %w", err)
+	}
+	out.applyDefaults()
+	return &queueImpl{
+		config: &out,
+	}, nil
+}
+
+// NewInspector returns an inspector of the database queue. All domains which use
+// a database queue share the same persistence queue, so no config is required.
+func NewInspector() provider.Inspector {
+	out := queueConfig{}
+	out.applyDefaults()
+	return &queueImpl{
+		config: &out,
+	}
+}
+
+func (q *queueImpl) ID() string {
+	return q.config.ID()
+}
+
+func (q *queueImpl) CreateConsumer(p *provider.Params) (provider.Consumer, error) {
+	if p.QueueManager == nil {
+		return nil, errNoQueueManager
+	}
+	p.Logger.Info("Creating async wf consumer", tag.AsyncWFQueueID(q.ID()))
+	dbConsumer := newConsumer(q.ID(), q.config, p.QueueManager, p.MembershipResolver, p.Logger)
+	return consumer.New(q.ID(), dbConsumer, p.Logger, p.MetricsClient, p.FrontendClient, consumer.WithStatusStore(p.StatusStore)), nil
+}
+
+func (q *queueImpl) CreateProducer(p *provider.Params) (messaging.Producer, error) {
+	if p.QueueManager == nil {
+		return nil, errNoQueueManager
+	}
+	p.Logger.Info("Creating async wf producer", tag.AsyncWFQueueID(q.ID()))
+	return messaging.NewMetricProducer(newProducer(p.QueueManager, p.Logger), p.MetricsClient), nil
+}
+
+// Inspect returns the requests which are not yet processed by the consumer
+func (q *queueImpl) Inspect(ctx context.Context, p *provider.Params, maxCount int) (*provider.QueueState, error) {
+	if p.QueueManager == nil {
+		return nil, errNoQueueManager
+	}
+	ackLevel, err := getAckLevel(ctx, p.QueueManager)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := p.QueueManager.ReadMessages(ctx, ackLevel, maxCount+1)
+	if err != nil {
+		return nil, err
+	}
+	dlqSize, err := p.QueueManager.GetDLQSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &provider.QueueState{
+		AckLevel: ackLevel,
+		DLQSize:  dlqSize,
+	}
+	if len(messages) > maxCount {
+		messages = messages[:maxCount]
+		state.HasMore = true
+	}
+	for _, message := range messages {
+		state.Pending = append(state.Pending, &provider.PendingMessage{
+			ID:      message.ID,
+			Payload: message.Payload,
+		})
+	}
+	return state, nil
+}
@@ -0,0 +1,224 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/.gen/go/sqlblobs"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/asyncworkflow/queue/provider"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type MockDecoder struct {
+	DecodeFunc func(v any) error
+}
+
+func (m *MockDecoder) Decode(v any) error {
+	return m.DecodeFunc(v)
+}
+
+func TestNewQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		decoder   *MockDecoder
+		want      *queueImpl
+		wantErr   bool
+		errString string
+	}{
+		{
+			name: "defaults are applied",
+			decoder: &MockDecoder{
+				DecodeFunc: func(v any) error {
+					return nil
+				},
+			},
+			want: &queueImpl{
+				config: &queueConfig{BatchSize: defaultBatchSize, PollInterval: defaultPollInterval},
+			},
+		},
+		{
+			name: "configured values are kept",
+			decoder: &MockDecoder{
+				DecodeFunc: func(v any) error {
+					out := v.(*queueConfig)
+					out.BatchSize = 10
+					out.PollInterval = time.Minute
+					return nil
+				},
+			},
+			want: &queueImpl{
+				config: &queueConfig{BatchSize: 10, PollInterval: time.Minute},
+			},
+		},
+		{
+			name: "decoding failure",
+			decoder: &MockDecoder{
+				DecodeFunc: func(v any) error {
+					return errors.New("decoding error")
+				},
+			},
+			wantErr:   true,
+			errString: "bad config: decoding error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newQueue(tt.decoder)
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.errString)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, "database", got.ID())
+			}
+		})
+	}
+}
+
+func TestCreateConsumerAndProducer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	q := &queueImpl{config: &queueConfig{BatchSize: 10, PollInterval: time.Second}}
+
+	p := &provider.Params{
+		Logger:        testlogger.New(t),
+		MetricsClient: metrics.NewNoopMetricsClient(),
+	}
+	_, err := q.CreateConsumer(p)
+	assert.ErrorIs(t, err, errNoQueueManager)
+	_, err = q.CreateProducer(p)
+	assert.ErrorIs(t, err, errNoQueueManager)
+
+	p.QueueManager = persistence.NewMockQueueManager(ctrl)
+	consumer, err := q.CreateConsumer(p)
+	assert.NoError(t, err)
+	assert.NotNil(t, consumer)
+	producer, err := q.CreateProducer(p)
+	assert.NoError(t, err)
+	assert.NotNil(t, producer)
+}
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxCount  int
+		mockSetup func(m *persistence.MockQueueManager)
+		want      *provider.QueueState
+		wantErr   bool
+	}{
+		{
+			name:     "no ack level yet",
+			maxCount: 2,
+			mockSetup: func(m *persistence.MockQueueManager) {
+				m.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{}, nil)
+				m.EXPECT().ReadMessages(gomock.Any(), int64(-1), 3).Return(persistence.QueueMessageList{
+					{ID: 0, Payload: []byte("a")},
+				}, nil)
+				m.EXPECT().GetDLQSize(gomock.Any()).Return(int64(0), nil)
+			},
+			want: &provider.QueueState{
+				AckLevel: -1,
+				Pending:  []*provider.PendingMessage{{ID: 0, Payload: []byte("a")}},
+			},
+		},
+		{
+			name:     "more messages than requested",
+			maxCount: 2,
+			mockSetup: func(m *persistence.MockQueueManager) {
+				m.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{consumerName: 5}, nil)
+				m.EXPECT().ReadMessages(gomock.Any(), int64(5), 3).Return(persistence.QueueMessageList{
+					{ID: 6, Payload: []byte("a")},
+					{ID: 7, Payload: []byte("b")},
+					{ID: 8, Payload: []byte("c")},
+				}, nil)
+				m.EXPECT().GetDLQSize(gomock.Any()).Return(int64(3), nil)
+			},
+			want: &provider.QueueState{
+				AckLevel: 5,
+				Pending: []*provider.PendingMessage{
+					{ID: 6, Payload: []byte("a")},
+					{ID: 7, Payload: []byte("b")},
+				},
+				HasMore: true,
+				DLQSize: 3,
+			},
+		},
+		{
+			name:     "read failure",
+			maxCount: 2,
+			mockSetup: func(m *persistence.MockQueueManager) {
+				m.EXPECT().GetAckLevels(gomock.Any()).Return(nil, nil)
+				m.EXPECT().ReadMessages(gomock.Any(), int64(-1), 3).Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queueManager := persistence.NewMockQueueManager(ctrl)
+			tt.mockSetup(queueManager)
+
+			q := NewInspector()
+			got, err := q.Inspect(context.Background(), &provider.Params{QueueManager: queueManager}, tt.maxCount)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProducerPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queueManager := persistence.NewMockQueueManager(ctrl)
+	p := newProducer(queueManager, testlogger.New(t))
+
+	assert.Error(t, p.Publish(context.Background(), "unknown"))
+
+	msg := &sqlblobs.AsyncRequestMessage{PartitionKey: common.StringPtr("wid")}
+	queueManager.EXPECT().EnqueueMessage(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, payload []byte) error {
+		var decoded sqlblobs.AsyncRequestMessage
+		require.NoError(t, p.(*producerImpl).msgEncoder.Decode(payload, &decoded))
+		assert.Equal(t, "wid", decoded.GetPartitionKey())
+		return nil
+	})
+	assert.NoError(t, p.Publish(context.Background(), msg))
+
+	queueManager.EXPECT().EnqueueMessage(gomock.Any(), gomock.Any()).Return(errors.New("failed"))
+	assert.Error(t, p.Publish(context.Background(), msg))
+}
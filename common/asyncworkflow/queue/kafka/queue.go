@@ -87,7 +87,7 @@ func (q *queueImpl) CreateConsumer(p *provider.Params) (provider.Consumer, error
 		return nil, fmt.Errorf("failed to create kafka consumer: %w", err)
 	}
 	p.Logger.Info("Creating async wf consumer", tag.KafkaTopicName(q.config.Topic))
-	return consumer.New(q.ID(), kafkaConsumer, p.Logger, p.MetricsClient, p.FrontendClient), nil
+	return consumer.New(q.ID(), kafkaConsumer, p.Logger, p.MetricsClient, p.FrontendClient, consumer.WithStatusStore(p.StatusStore)), nil
 }
 
 func (q *queueImpl) CreateProducer(p *provider.Params) (messaging.Producer, error) {
@@ -25,12 +25,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/syncmap"
 	"github.com/uber/cadence/common/types"
 )
@@ -40,6 +44,11 @@ type (
 		Logger         log.Logger
 		MetricsClient  metrics.Client
 		FrontendClient frontend.Client
+		// QueueManager and MembershipResolver are only required by queues stored in the cadence database
+		QueueManager       persistence.QueueManager
+		MembershipResolver membership.Resolver
+		// StatusStore records the outcome of consumed requests, it is nil if outcomes are not recorded
+		StatusStore requeststatus.Store
 	}
 
 	Decoder interface {
@@ -58,6 +67,29 @@ type (
 		CreateProducer(*Params) (messaging.Producer, error)
 	}
 
+	// Inspector is implemented by queues which are able to report the requests still waiting in them
+	Inspector interface {
+		Inspect(ctx context.Context, p *Params, maxCount int) (*QueueState, error)
+	}
+
+	// QueueState is a snapshot of the requests waiting in a queue
+	QueueState struct {
+		// AckLevel is the ID of the last request processed by the consumer
+		AckLevel int64
+		// Pending contains up to maxCount requests which are not processed yet
+		Pending []*PendingMessage
+		// HasMore is set if there are more pending requests than returned
+		HasMore bool
+		// DLQSize is the number of requests which failed to be processed
+		DLQSize int64
+	}
+
+	// PendingMessage is a request waiting in a queue
+	PendingMessage struct {
+		ID      int64
+		Payload []byte
+	}
+
 	QueueConstructor func(Decoder) (Queue, error)
 
 	DecoderConstructor func(*types.DataBlob) Decoder
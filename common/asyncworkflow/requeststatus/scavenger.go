@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package requeststatus
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+)
+
+const (
+	scavengeInterval = time.Hour
+	scavengePageSize = 1000
+)
+
+type (
+	// scavenger deletes the expired statuses of a blobstore store, statuses which are never read again would be kept forever otherwise
+	scavenger struct {
+		client     blobstore.Client
+		timeSource clock.TimeSource
+		logger     log.Logger
+		interval   time.Duration
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+		status *atomic.Int32
+	}
+)
+
+// NewScavenger returns a daemon which periodically deletes the expired statuses recorded by a blobstore store
+func NewScavenger(client blobstore.Client, timeSource clock.TimeSource, logger log.Logger) common.Daemon {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &scavenger{
+		client:     client,
+		timeSource: timeSource,
+		logger:     logger,
+		interval:   scavengeInterval,
+		ctx:        ctx,
+		cancel:     cancel,
+		status:     atomic.NewInt32(common.DaemonStatusInitialized),
+	}
+}
+
+func (s *scavenger) Start() {
+	if !s.status.CompareAndSwap(common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	s.wg.Add(1)
+	go s.scavengeLoop()
+}
+
+func (s *scavenger) Stop() {
+	if !s.status.CompareAndSwap(common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *scavenger) scavengeLoop() {
+	defer s.wg.Done()
+	ticker := s.timeSource.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.Chan():
+			deleted, err := s.scavenge(s.ctx)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				s.logger.Warn("Failed to delete expired async request statuses", tag.Counter(deleted), tag.Error(err))
+				continue
+			}
+			s.logger.Info("Deleted expired async request statuses", tag.Counter(deleted))
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// scavenge deletes the expired statuses and returns how many were deleted
+func (s *scavenger) scavenge(ctx context.Context) (int, error) {
+	deleted := 0
+	request := &blobstore.ListRequest{Prefix: keyPrefix + "/", PageSize: scavengePageSize}
+	for {
+		resp, err := s.client.List(ctx, request)
+		if err != nil {
+			return deleted, err
+		}
+		for _, key := range resp.Keys {
+			expired, err := s.isExpired(ctx, key)
+			if err != nil {
+				s.logger.Warn("Failed to read async request status", tag.Key(key), tag.Error(err))
+				continue
+			}
+			if !expired {
+				continue
+			}
+			if _, err := s.client.Delete(ctx, &blobstore.DeleteRequest{Key: key}); err != nil {
+				s.logger.Warn("Failed to delete expired async request status", tag.Key(key), tag.Error(err))
+				continue
+			}
+			deleted++
+		}
+		if len(resp.NextPageToken) == 0 {
+			return deleted, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+func (s *scavenger) isExpired(ctx context.Context, key string) (bool, error) {
+	resp, err := s.client.Get(ctx, &blobstore.GetRequest{Key: key})
+	if err != nil {
+		return false, err
+	}
+	var status RequestStatus
+	if err := json.Unmarshal(resp.Blob.Body, &status); err != nil {
+		return false, err
+	}
+	return status.expired(s.timeSource.Now()), nil
+}
@@ -0,0 +1,102 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package requeststatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log/testlogger"
+)
+
+func TestScavenge(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	now := time.Unix(1700000000, 0).UTC()
+	s := NewScavenger(client, clock.NewMockedTimeSourceAt(now), testlogger.New(t)).(*scavenger)
+
+	blob := func(expiration time.Time) *blobstore.GetResponse {
+		body, err := json.Marshal(&RequestStatus{Status: StatusSucceeded, ExpirationTime: expiration})
+		require.NoError(t, err)
+		return &blobstore.GetResponse{Blob: blobstore.Blob{Body: body}}
+	}
+
+	client.On("List", mock.Anything, &blobstore.ListRequest{Prefix: "asyncrequest/", PageSize: scavengePageSize}).
+		Return(&blobstore.ListResponse{Keys: []string{"asyncrequest/d/expired", "asyncrequest/d/live"}, NextPageToken: []byte("asyncrequest/d/live")}, nil).Once()
+	client.On("List", mock.Anything, &blobstore.ListRequest{Prefix: "asyncrequest/", PageSize: scavengePageSize, NextPageToken: []byte("asyncrequest/d/live")}).
+		Return(&blobstore.ListResponse{Keys: []string{"asyncrequest/d/corrupted", "asyncrequest/d/unreadable", "asyncrequest/d/expired-now"}}, nil).Once()
+
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: "asyncrequest/d/expired"}).Return(blob(now.Add(-time.Minute)), nil).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: "asyncrequest/d/live"}).Return(blob(now.Add(time.Minute)), nil).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: "asyncrequest/d/corrupted"}).Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: []byte("{")}}, nil).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: "asyncrequest/d/unreadable"}).Return(nil, errors.New("get failed")).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: "asyncrequest/d/expired-now"}).Return(blob(now), nil).Once()
+
+	client.On("Delete", mock.Anything, &blobstore.DeleteRequest{Key: "asyncrequest/d/expired"}).Return(&blobstore.DeleteResponse{}, nil).Once()
+	client.On("Delete", mock.Anything, &blobstore.DeleteRequest{Key: "asyncrequest/d/expired-now"}).Return(nil, errors.New("delete failed")).Once()
+
+	deleted, err := s.scavenge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestScavengeListError(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	s := NewScavenger(client, clock.NewRealTimeSource(), testlogger.New(t)).(*scavenger)
+
+	client.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("list failed")).Once()
+	_, err := s.scavenge(context.Background())
+	assert.EqualError(t, err, "list failed")
+}
+
+func TestScavengerRunsPeriodically(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	timeSource := clock.NewMockedTimeSource()
+	s := NewScavenger(client, timeSource, testlogger.New(t))
+
+	scavenged := make(chan struct{})
+	client.On("List", mock.Anything, mock.Anything).Return(&blobstore.ListResponse{}, nil).Run(func(mock.Arguments) {
+		close(scavenged)
+	}).Once()
+
+	s.Start()
+	timeSource.BlockUntil(1)
+	timeSource.Advance(scavengeInterval)
+	select {
+	case <-scavenged:
+	case <-time.After(time.Second):
+		t.Fatal("expired statuses were not scavenged")
+	}
+	s.Stop()
+}
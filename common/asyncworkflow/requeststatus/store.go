@@ -0,0 +1,159 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination store_mock.go -self_package github.com/uber/cadence/common/asyncworkflow/requeststatus
+
+package requeststatus
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// StatusPending is recorded once the request was enqueued, until the consumer records its outcome
+	StatusPending Status = "pending"
+	// StatusStarted is recorded once the workflow of a start request was started
+	StatusStarted Status = "started"
+	// StatusDuplicate is recorded if the workflow of a start request was already started by another request
+	StatusDuplicate Status = "duplicate"
+	// StatusSucceeded is recorded once the request was applied to the workflow
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is recorded once the request failed after all attempts
+	StatusFailed Status = "failed"
+
+	keyPrefix = "asyncrequest"
+
+	domainTag    = "domain"
+	requestIDTag = "requestID"
+)
+
+type (
+	// Status is the outcome of an async request
+	Status string
+
+	// RequestStatus is the recorded status of an async request
+	RequestStatus struct {
+		Status      Status `json:"status"`
+		RequestType string `json:"requestType,omitempty"`
+		WorkflowID  string `json:"workflowID,omitempty"`
+		// RunID is set for started and duplicate start requests
+		RunID          string    `json:"runID,omitempty"`
+		Reason         string    `json:"reason,omitempty"`
+		UpdatedTime    time.Time `json:"updatedTime"`
+		ExpirationTime time.Time `json:"expirationTime"`
+	}
+
+	// Store records the status of async requests, keyed by domain and request ID.
+	// A recorded status expires once the TTL of the domain passed since it was last recorded,
+	// expired statuses are deleted by the Scavenger.
+	Store interface {
+		Record(ctx context.Context, domain, requestID string, status *RequestStatus) error
+		// Get returns EntityNotExistsError if no status is recorded for the request or it expired
+		Get(ctx context.Context, domain, requestID string) (*RequestStatus, error)
+	}
+
+	blobstoreStore struct {
+		client     blobstore.Client
+		timeSource clock.TimeSource
+		ttl        dynamicproperties.DurationPropertyFnWithDomainFilter
+	}
+)
+
+var _ Store = (*blobstoreStore)(nil)
+
+// NewBlobstoreStore returns a Store which keeps the status of each request as a blob.
+// Expired blobs are also deleted when they are read.
+func NewBlobstoreStore(client blobstore.Client, timeSource clock.TimeSource, ttl dynamicproperties.DurationPropertyFnWithDomainFilter) Store {
+	return &blobstoreStore{
+		client:     client,
+		timeSource: timeSource,
+		ttl:        ttl,
+	}
+}
+
+func (s *blobstoreStore) Record(ctx context.Context, domain, requestID string, status *RequestStatus) error {
+	record := *status
+	record.UpdatedTime = s.timeSource.Now()
+	record.ExpirationTime = record.UpdatedTime.Add(s.ttl(domain))
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Put(ctx, &blobstore.PutRequest{
+		Key: statusKey(domain, requestID),
+		Blob: blobstore.Blob{
+			Tags: map[string]string{
+				domainTag:    domain,
+				requestIDTag: requestID,
+			},
+			Body: body,
+		},
+	})
+	return err
+}
+
+func (s *blobstoreStore) Get(ctx context.Context, domain, requestID string) (*RequestStatus, error) {
+	key := statusKey(domain, requestID)
+	exists, err := s.client.Exists(ctx, &blobstore.ExistsRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if !exists.Exists {
+		return nil, notRecordedError(requestID)
+	}
+	resp, err := s.client.Get(ctx, &blobstore.GetRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	var status RequestStatus
+	if err := json.Unmarshal(resp.Blob.Body, &status); err != nil {
+		return nil, fmt.Errorf("failed to decode status of async request %v: %w", requestID, err)
+	}
+	if status.expired(s.timeSource.Now()) {
+		// the blob is only deleted on a best effort basis, it is reported as not recorded either way
+		_, _ = s.client.Delete(ctx, &blobstore.DeleteRequest{Key: key})
+		return nil, notRecordedError(requestID)
+	}
+	return &status, nil
+}
+
+func (s *RequestStatus) expired(now time.Time) bool {
+	return !s.ExpirationTime.IsZero() && !now.Before(s.ExpirationTime)
+}
+
+func notRecordedError(requestID string) error {
+	return &types.EntityNotExistsError{Message: fmt.Sprintf("No status is recorded for async request %v, it is recorded once the request is enqueued.", requestID)}
+}
+
+// statusKey encodes the domain and request ID, request IDs are chosen by callers and can't be used as parts of a path
+func statusKey(domain, requestID string) string {
+	return fmt.Sprintf("%v/%v/%v", keyPrefix, base64.RawURLEncoding.EncodeToString([]byte(domain)), base64.RawURLEncoding.EncodeToString([]byte(requestID)))
+}
@@ -0,0 +1,182 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package requeststatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	testDomain    = "test-domain"
+	testRequestID = "test-request-id"
+	testKey       = "asyncrequest/dGVzdC1kb21haW4/dGVzdC1yZXF1ZXN0LWlk"
+	testTTL       = time.Hour
+)
+
+var testTTLFn = dynamicproperties.GetDurationPropertyFnFilteredByDomain(testTTL)
+
+func TestRecordAndGet(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	now := time.Unix(1700000000, 0).UTC()
+	timeSource := clock.NewMockedTimeSourceAt(now)
+	store := NewBlobstoreStore(client, timeSource, testTTLFn)
+
+	var stored []byte
+	client.On("Put", mock.Anything, mock.MatchedBy(func(req *blobstore.PutRequest) bool {
+		stored = req.Blob.Body
+		return req.Key == testKey && req.Blob.Tags[requestIDTag] == testRequestID
+	})).Return(&blobstore.PutResponse{}, nil).Once()
+
+	err := store.Record(context.Background(), testDomain, testRequestID, &RequestStatus{
+		Status:      StatusFailed,
+		RequestType: "SignalWorkflowExecutionAsyncRequest",
+		WorkflowID:  "wid",
+		Reason:      "workflow not found",
+	})
+	require.NoError(t, err)
+
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: testKey}).Return(&blobstore.ExistsResponse{Exists: true}, nil).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: testKey}).Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: stored}}, nil).Once()
+
+	status, err := store.Get(context.Background(), testDomain, testRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, &RequestStatus{
+		Status:         StatusFailed,
+		RequestType:    "SignalWorkflowExecutionAsyncRequest",
+		WorkflowID:     "wid",
+		Reason:         "workflow not found",
+		UpdatedTime:    now,
+		ExpirationTime: now.Add(testTTL),
+	}, status)
+
+	// once expired the status is deleted and reported as not recorded
+	timeSource.Advance(testTTL)
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: testKey}).Return(&blobstore.ExistsResponse{Exists: true}, nil).Once()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: testKey}).Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: stored}}, nil).Once()
+	client.On("Delete", mock.Anything, &blobstore.DeleteRequest{Key: testKey}).Return(&blobstore.DeleteResponse{}, nil).Once()
+
+	_, err = store.Get(context.Background(), testDomain, testRequestID)
+	var notExists *types.EntityNotExistsError
+	assert.True(t, errors.As(err, &notExists))
+}
+
+func TestRecordPendingThenStarted(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	now := time.Unix(1700000000, 0).UTC()
+	timeSource := clock.NewMockedTimeSourceAt(now)
+	store := NewBlobstoreStore(client, timeSource, testTTLFn)
+
+	var stored []byte
+	client.On("Put", mock.Anything, mock.MatchedBy(func(req *blobstore.PutRequest) bool {
+		stored = req.Blob.Body
+		return req.Key == testKey
+	})).Return(&blobstore.PutResponse{}, nil).Twice()
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: testKey}).Return(&blobstore.ExistsResponse{Exists: true}, nil).Twice()
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: testKey}).Return(func(context.Context, *blobstore.GetRequest) *blobstore.GetResponse {
+		return &blobstore.GetResponse{Blob: blobstore.Blob{Body: stored}}
+	}, nil).Twice()
+
+	// the frontend records the request as pending when it is enqueued
+	err := store.Record(context.Background(), testDomain, testRequestID, &RequestStatus{
+		Status:      StatusPending,
+		RequestType: "StartWorkflowExecutionAsyncRequest",
+		WorkflowID:  "wid",
+	})
+	require.NoError(t, err)
+	status, err := store.Get(context.Background(), testDomain, testRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, &RequestStatus{
+		Status:         StatusPending,
+		RequestType:    "StartWorkflowExecutionAsyncRequest",
+		WorkflowID:     "wid",
+		UpdatedTime:    now,
+		ExpirationTime: now.Add(testTTL),
+	}, status)
+
+	// the consumer overwrites it with the outcome, which is kept for the same TTL from then on
+	timeSource.Advance(time.Minute)
+	err = store.Record(context.Background(), testDomain, testRequestID, &RequestStatus{
+		Status:      StatusStarted,
+		RequestType: "StartWorkflowExecutionAsyncRequest",
+		WorkflowID:  "wid",
+		RunID:       "rid",
+	})
+	require.NoError(t, err)
+	status, err = store.Get(context.Background(), testDomain, testRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, &RequestStatus{
+		Status:         StatusStarted,
+		RequestType:    "StartWorkflowExecutionAsyncRequest",
+		WorkflowID:     "wid",
+		RunID:          "rid",
+		UpdatedTime:    now.Add(time.Minute),
+		ExpirationTime: now.Add(time.Minute + testTTL),
+	}, status)
+}
+
+func TestGetNotRecorded(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	store := NewBlobstoreStore(client, clock.NewRealTimeSource(), testTTLFn)
+
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: testKey}).Return(&blobstore.ExistsResponse{Exists: false}, nil).Once()
+
+	_, err := store.Get(context.Background(), testDomain, testRequestID)
+	var notExists *types.EntityNotExistsError
+	assert.True(t, errors.As(err, &notExists))
+}
+
+func TestGetErrors(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	store := NewBlobstoreStore(client, clock.NewRealTimeSource(), testTTLFn)
+
+	client.On("Exists", mock.Anything, mock.Anything).Return(nil, errors.New("exists failed")).Once()
+	_, err := store.Get(context.Background(), testDomain, testRequestID)
+	assert.EqualError(t, err, "exists failed")
+
+	client.On("Exists", mock.Anything, mock.Anything).Return(&blobstore.ExistsResponse{Exists: true}, nil).Once()
+	client.On("Get", mock.Anything, mock.Anything).Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: []byte("{")}}, nil).Once()
+	_, err = store.Get(context.Background(), testDomain, testRequestID)
+	assert.ErrorContains(t, err, "failed to decode status of async request")
+}
+
+func TestStatusKey(t *testing.T) {
+	assert.Equal(t, testKey, statusKey(testDomain, testRequestID))
+	// request IDs are chosen by callers, they can't escape the key prefix
+	assert.Equal(t, "asyncrequest/dGVzdC1kb21haW4/Li4vLi4vZXRj", statusKey(testDomain, "../../etc"))
+}
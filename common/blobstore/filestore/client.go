@@ -27,13 +27,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/util"
 )
 
+// errInvalidKey is returned for keys which would resolve to a path outside of the output directory
+var errInvalidKey = errors.New("blob key must be a local path")
+
 type (
 	client struct {
 		outputDirectory string
@@ -65,12 +72,21 @@ func NewFilestoreClient(cfg *config.FileBlobstore) (blobstore.Client, error) {
 
 // Put stores a blob
 func (c *client) Put(_ context.Context, request *blobstore.PutRequest) (resp *blobstore.PutResponse, err error) {
+	if err := validateKey(request.Key); err != nil {
+		return nil, err
+	}
 	defer func() {
 		if err != nil {
 			os.Remove(c.bodyPath(request.Key))
 			os.Remove(c.tagsPath(request.Key))
 		}
 	}()
+	// keys can contain slashes, their directories are created on demand
+	for _, path := range []string{c.bodyPath(request.Key), c.tagsPath(request.Key)} {
+		if err := util.MkdirAll(filepath.Dir(path), os.FileMode(0766)); err != nil {
+			return nil, err
+		}
+	}
 	if err := util.WriteFile(c.bodyPath(request.Key), request.Blob.Body, os.FileMode(0666)); err != nil {
 		return nil, err
 	}
@@ -86,6 +102,9 @@ func (c *client) Put(_ context.Context, request *blobstore.PutRequest) (resp *bl
 
 // Get fetches a blob
 func (c *client) Get(_ context.Context, request *blobstore.GetRequest) (*blobstore.GetResponse, error) {
+	if err := validateKey(request.Key); err != nil {
+		return nil, err
+	}
 	data, err := util.ReadFile(c.bodyPath(request.Key))
 	if err != nil {
 		return nil, err
@@ -108,6 +127,9 @@ func (c *client) Get(_ context.Context, request *blobstore.GetRequest) (*blobsto
 
 // Exists determines if a blob exists
 func (c *client) Exists(_ context.Context, request *blobstore.ExistsRequest) (*blobstore.ExistsResponse, error) {
+	if err := validateKey(request.Key); err != nil {
+		return nil, err
+	}
 	exists, err := util.FileExists(c.bodyPath(request.Key))
 	if err != nil {
 		return nil, err
@@ -119,6 +141,9 @@ func (c *client) Exists(_ context.Context, request *blobstore.ExistsRequest) (*b
 
 // Delete deletes a blob
 func (c *client) Delete(_ context.Context, request *blobstore.DeleteRequest) (*blobstore.DeleteResponse, error) {
+	if err := validateKey(request.Key); err != nil {
+		return nil, err
+	}
 	if err := os.Remove(c.bodyPath(request.Key)); err != nil {
 		return nil, err
 	}
@@ -128,11 +153,66 @@ func (c *client) Delete(_ context.Context, request *blobstore.DeleteRequest) (*b
 	return &blobstore.DeleteResponse{}, nil
 }
 
+// List returns the keys starting with the prefix in lexical order, the page token is the last key of the previous page
+func (c *client) List(_ context.Context, request *blobstore.ListRequest) (*blobstore.ListResponse, error) {
+	root := filepath.Clean(c.outputDirectory)
+	var keys []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		// tags are kept in hidden files and directories at the top level
+		if strings.HasPrefix(d.Name(), ".") && filepath.Dir(path) == root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if !strings.HasPrefix(key+"/", request.Prefix) && !strings.HasPrefix(request.Prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(key, request.Prefix) && key > string(request.NextPageToken) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+	resp := &blobstore.ListResponse{Keys: keys}
+	if request.PageSize > 0 && len(keys) > request.PageSize {
+		resp.Keys = keys[:request.PageSize]
+		resp.NextPageToken = []byte(resp.Keys[request.PageSize-1])
+	}
+	return resp, nil
+}
+
 // IsRetryableError returns true if the error is retryable false otherwise
 func (c *client) IsRetryableError(err error) bool {
 	return false
 }
 
+// validateKey rejects keys which are absolute or escape the output directory, keys are joined into paths as they are
+func validateKey(key string) error {
+	if !filepath.IsLocal(key) {
+		return errInvalidKey
+	}
+	return nil
+}
+
 func (c *client) bodyPath(key string) string {
 	return fmt.Sprintf("%v/%v", c.outputDirectory, key)
 }
@@ -25,6 +25,7 @@ package filestore
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pborman/uuid"
@@ -78,6 +79,25 @@ func (s *ClientSuite) TestNewFilestoreClient_DirectoryNotAlreadyExists() {
 	os.RemoveAll(name)
 }
 
+func (s *ClientSuite) TestInvalidKeys() {
+	name := s.T().TempDir()
+	c, err := NewFilestoreClient(&config.FileBlobstore{OutputDirectory: name})
+	s.NoError(err)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "../escaped", "nested/../../escaped", "/absolute"} {
+		_, err = c.Put(ctx, &blobstore.PutRequest{Key: key, Blob: blobstore.Blob{Body: []byte{1}}})
+		s.Equal(errInvalidKey, err, key)
+		_, err = c.Get(ctx, &blobstore.GetRequest{Key: key})
+		s.Equal(errInvalidKey, err, key)
+		_, err = c.Exists(ctx, &blobstore.ExistsRequest{Key: key})
+		s.Equal(errInvalidKey, err, key)
+		_, err = c.Delete(ctx, &blobstore.DeleteRequest{Key: key})
+		s.Equal(errInvalidKey, err, key)
+	}
+	s.NoFileExists(filepath.Join(filepath.Dir(name), "escaped"))
+}
+
 func (s *ClientSuite) TestCrudOperations() {
 	name := s.T().TempDir()
 	c, err := NewFilestoreClient(&config.FileBlobstore{OutputDirectory: name})
@@ -151,3 +171,32 @@ func (s *ClientSuite) TestCrudOperations() {
 	s.Error(err)
 	s.Nil(get1)
 }
+
+func (s *ClientSuite) TestList() {
+	name := s.T().TempDir()
+	c, err := NewFilestoreClient(&config.FileBlobstore{OutputDirectory: name})
+	s.NoError(err)
+	ctx := context.Background()
+
+	for _, key := range []string{"a/c", "a/b/2", "a/b/1", "ab", "b/1"} {
+		_, err = c.Put(ctx, &blobstore.PutRequest{Key: key, Blob: blobstore.Blob{Tags: map[string]string{"key": key}, Body: []byte{1}}})
+		s.NoError(err)
+	}
+
+	resp, err := c.List(ctx, &blobstore.ListRequest{Prefix: "a/"})
+	s.NoError(err)
+	s.Equal([]string{"a/b/1", "a/b/2", "a/c"}, resp.Keys)
+	s.Nil(resp.NextPageToken)
+
+	resp, err = c.List(ctx, &blobstore.ListRequest{Prefix: "a", PageSize: 2})
+	s.NoError(err)
+	s.Equal([]string{"a/b/1", "a/b/2"}, resp.Keys)
+	resp, err = c.List(ctx, &blobstore.ListRequest{Prefix: "a", PageSize: 2, NextPageToken: resp.NextPageToken})
+	s.NoError(err)
+	s.Equal([]string{"a/c", "ab"}, resp.Keys)
+	s.Nil(resp.NextPageToken)
+
+	resp, err = c.List(ctx, &blobstore.ListRequest{})
+	s.NoError(err)
+	s.Equal([]string{"a/b/1", "a/b/2", "a/c", "ab", "b/1"}, resp.Keys)
+}
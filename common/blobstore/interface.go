@@ -31,6 +31,7 @@ type (
 		Get(context.Context, *GetRequest) (*GetResponse, error)
 		Exists(context.Context, *ExistsRequest) (*ExistsResponse, error)
 		Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+		List(context.Context, *ListRequest) (*ListResponse, error)
 		IsRetryableError(error) bool
 	}
 
@@ -71,6 +72,19 @@ type (
 	// DeleteResponse is the response from Delete
 	DeleteResponse struct{}
 
+	// ListRequest is the request to List
+	ListRequest struct {
+		Prefix        string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListResponse is the response from List, keys are in lexical order
+	ListResponse struct {
+		Keys          []string
+		NextPageToken []byte
+	}
+
 	// Blob defines a blob which can be stored and fetched from blobstore
 	Blob struct {
 		Tags map[string]string
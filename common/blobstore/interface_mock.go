@@ -104,6 +104,29 @@ func (_m *MockClient) Get(_a0 context.Context, _a1 *GetRequest) (*GetResponse, e
 	return r0, r1
 }
 
+// List provides a mock function with given fields: _a0, _a1
+func (_m *MockClient) List(_a0 context.Context, _a1 *ListRequest) (*ListResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ListResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *ListRequest) *ListResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ListResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ListRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Put provides a mock function with given fields: _a0, _a1
 func (_m *MockClient) Put(_a0 context.Context, _a1 *PutRequest) (*PutResponse, error) {
 	ret := _m.Called(_a0, _a1)
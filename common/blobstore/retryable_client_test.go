@@ -97,6 +97,22 @@ func TestRetryableClient_Delete(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestRetryableClient_List(t *testing.T) {
+	mockClient := new(MockClient)
+	policy := backoff.NewExponentialRetryPolicy(0)
+	client := NewRetryableClient(mockClient, policy)
+
+	req := &ListRequest{}
+	resp := &ListResponse{}
+	mockClient.On("List", mock.Anything, req).Return(resp, nil).Once()
+
+	result, err := client.List(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, resp, result)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestRetryableClient_RetryOnError(t *testing.T) {
 	mockClient := new(MockClient)
 	policy := backoff.NewExponentialRetryPolicy(1) // Adjusting the retry interval to ensure retry is attempted
@@ -35,6 +35,8 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig"
 	c "github.com/uber/cadence/common/dynamicconfig/configstore/config"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/membership"
+	motel "github.com/uber/cadence/common/metrics/tally/otel"
 	"github.com/uber/cadence/common/peerprovider/ringpopprovider"
 	"github.com/uber/cadence/common/service"
 )
@@ -92,14 +94,33 @@ type (
 
 		// LeaderElection is a config for the shard distributor leader election component that allows to run a single process per region and manage shard namespaces.
 		LeaderElection LeaderElection `yaml:"leaderElection"`
+
+		// Diagnostics is the config for workflow diagnostics
+		Diagnostics Diagnostics `yaml:"diagnostics"`
+
+		// Tracing is the config for OpenTelemetry tracing, spans are not exported if it is not set
+		Tracing Tracing `yaml:"tracing"`
 	}
 
 	// Membership holds peer provider configuration.
 	Membership struct {
 		Provider PeerProvider `yaml:"provider"`
+		// Hashring configures how hosts are placed on the hashrings of services
+		Hashring Hashring `yaml:"hashring"`
+	}
+
+	// Hashring holds hashring configuration.
+	Hashring struct {
+		// Weighted enables the hashring which places hosts according to their weight.
+		// Shard ownership changes when it is toggled, so all hosts of a cluster have to use the same setting.
+		Weighted bool `yaml:"weighted"`
+		// Hosts overrides the weight of hosts by their IP, e.g. to give larger hosts more shards
+		Hosts map[string]membership.HostCapacity `yaml:"hosts"`
 	}
 
-	// PeerProvider is provider config. Contents depends on plugin in use
+	// PeerProvider is provider config. Contents depends on plugin in use,
+	// built-in plugins are "static" (hosts listed in a file) and "dns" (hosts resolved from SRV or A records).
+	// Ringpop is used if no provider is configured.
 	PeerProvider map[string]*YamlNode
 
 	HeaderRule struct {
@@ -166,6 +187,9 @@ type (
 		// Mode represents the TLS mode of the transport.
 		// Available modes: disabled, permissive, enforced
 		TLSMode yarpctls.Mode `yaml:"TLSMode"`
+		// Gateway enables the JSON over HTTP routes of the public API under /api/v1.
+		// Only frontend serves these routes, other services ignore this flag.
+		Gateway bool `yaml:"gateway"`
 	}
 
 	// Blobstore contains the config for blobstore
@@ -173,6 +197,49 @@ type (
 		Filestore *FileBlobstore `yaml:"filestore"`
 	}
 
+	// Diagnostics contains the config for workflow diagnostics
+	Diagnostics struct {
+		// RuleFiles are declarative invariant rule files, loaded as diagnostics plugins.
+		// Plugins still need to be enabled per domain via dynamic config.
+		RuleFiles []string `yaml:"ruleFiles"`
+	}
+
+	// Tracing contains the config for OpenTelemetry tracing
+	Tracing struct {
+		// Exporter is where spans are exported to, either "otlp" or "file".
+		// Tracing is disabled if it is empty.
+		Exporter string `yaml:"exporter"`
+		// ServiceName is reported as the service.name of the spans, defaults to the name of the cadence service
+		ServiceName string `yaml:"serviceName"`
+		// SampleRate is the fraction of traces which are sampled, defaults to 1.
+		// Traces started by a caller keep the sampling decision of the caller.
+		SampleRate float64 `yaml:"sampleRate"`
+		// OTLP is the config of the otlp exporter
+		OTLP OTLPTracing `yaml:"otlp"`
+		// File is the config of the file exporter
+		File FileTracing `yaml:"file"`
+	}
+
+	// OTLPTracing contains the config for exporting spans to an OpenTelemetry collector
+	OTLPTracing struct {
+		// Endpoint is the host:port of the collector
+		Endpoint string `yaml:"endpoint"`
+		// Protocol is either "grpc" or "http", defaults to grpc
+		Protocol string `yaml:"protocol"`
+		// Insecure disables TLS when connecting to the collector
+		Insecure bool `yaml:"insecure"`
+		// Headers are sent with every export request, e.g. for authentication
+		Headers map[string]string `yaml:"headers"`
+		// Timeout is the timeout of an export request, defaults to 10s
+		Timeout time.Duration `yaml:"timeout"`
+	}
+
+	// FileTracing contains the config for writing spans to a local file
+	FileTracing struct {
+		// Path is the file spans are appended to, one JSON object per span
+		Path string `yaml:"path"`
+	}
+
 	// FileBlobstore contains the config for a file backed blobstore
 	FileBlobstore struct {
 		OutputDirectory string `yaml:"outputDirectory"`
@@ -206,6 +273,9 @@ type (
 		// TODO: move dynamic config out of static config
 		// ErrorInjectionRate is the the rate for injecting random error
 		ErrorInjectionRate dynamicproperties.FloatPropertyFn `yaml:"-" json:"-"`
+		// TODO: move dynamic config out of static config
+		// HistoryZstdDictionaries is the list of zstd dictionaries for thriftrw_zstd history event batches
+		HistoryZstdDictionaries dynamicproperties.ListPropertyFn `yaml:"-" json:"-"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -463,6 +533,8 @@ type (
 		// For summary, default objectives are defined in https://github.com/uber-go/tally/blob/137973e539cd3589f904c23d0b3a28c579fd0ae4/prometheus/reporter.go#L70
 		// You can customize the buckets/objectives if the default is not good enough.
 		Prometheus *prometheus.Configuration `yaml:"prometheus"`
+		// OTel is the configuration for OpenTelemetry reporter, metrics are exported to a collector with OTLP
+		OTel *motel.Configuration `yaml:"otel"`
 		// Tags is the set of key-value pairs to be reported
 		// as part of every metric
 		Tags map[string]string `yaml:"tags"`
@@ -722,6 +794,9 @@ func (c *Config) validate() error {
 	if err := c.Archival.Validate(&c.DomainDefaults.Archival); err != nil {
 		return err
 	}
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
 
 	return c.Authorization.Validate()
 }
@@ -89,6 +89,12 @@ func (c *Metrics) NewScope(logger log.Logger, service string) tally.Scope {
 		}
 		rootScope = c.newPrometheusScope(logger)
 	}
+	if c.OTel != nil {
+		if rootScope != tally.NoopScope {
+			logger.Fatal("error creating metric reporter: cannot have more than one types of metric configuration")
+		}
+		rootScope = c.newOTelScope(logger, service)
+	}
 	rootScope = rootScope.Tagged(map[string]string{metrics.CadenceServiceTagName: service})
 	return rootScope
 }
@@ -165,3 +171,21 @@ func (c *Metrics) newPrometheusScope(logger log.Logger) tally.Scope {
 	scope, _ := tally.NewRootScope(scopeOpts, c.ReportingInterval)
 	return scope
 }
+
+// newOTelScope returns a new OpenTelemetry scope with
+// a default reporting interval of a second
+func (c *Metrics) newOTelScope(logger log.Logger, service string) tally.Scope {
+	reporter, err := c.OTel.NewReporter(service, func(err error) {
+		logger.Warn("error in otel reporter", tag.Error(err))
+	})
+	if err != nil {
+		logger.Fatal("error creating otel reporter", tag.Error(err))
+	}
+	scopeOpts := tally.ScopeOptions{
+		Tags:     c.Tags,
+		Reporter: reporter,
+		Prefix:   c.Prefix,
+	}
+	scope, _ := tally.NewRootScope(scopeOpts, c.ReportingInterval)
+	return scope
+}
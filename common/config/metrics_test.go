@@ -31,6 +31,7 @@ import (
 
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
+	motel "github.com/uber/cadence/common/metrics/tally/otel"
 )
 
 type MetricsSuite struct {
@@ -82,6 +83,15 @@ func (s *MetricsSuite) TestPrometheus() {
 	s.NotNil(scope)
 }
 
+func (s *MetricsSuite) TestOTel() {
+	config := new(Metrics)
+	config.OTel = &motel.Configuration{
+		Exporter: motel.ExporterStdout,
+	}
+	scope := config.NewScope(testlogger.New(s.T()), "test")
+	s.NotNil(scope)
+}
+
 func (s *MetricsSuite) TestNoop() {
 	config := &Metrics{}
 	scope := config.NewScope(testlogger.New(s.T()), "test")
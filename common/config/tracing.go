@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import "fmt"
+
+const (
+	// TracingExporterOTLP exports spans to an OpenTelemetry collector
+	TracingExporterOTLP = "otlp"
+	// TracingExporterFile writes spans to a local file
+	TracingExporterFile = "file"
+
+	// OTLPProtocolGRPC is the default protocol of the otlp exporter
+	OTLPProtocolGRPC = "grpc"
+	// OTLPProtocolHTTP exports spans as protobuf over HTTP
+	OTLPProtocolHTTP = "http"
+)
+
+// Enabled returns true if spans are exported
+func (t *Tracing) Enabled() bool {
+	return t.Exporter != ""
+}
+
+// Validate validates the tracing config
+func (t *Tracing) Validate() error {
+	if t.SampleRate < 0 || t.SampleRate > 1 {
+		return fmt.Errorf("[TracingConfig] SampleRate must be between 0 and 1")
+	}
+
+	switch t.Exporter {
+	case "":
+		return nil
+	case TracingExporterOTLP:
+		if t.OTLP.Endpoint == "" {
+			return fmt.Errorf("[TracingConfig] OTLP endpoint is not set")
+		}
+		if t.OTLP.Protocol != "" && t.OTLP.Protocol != OTLPProtocolGRPC && t.OTLP.Protocol != OTLPProtocolHTTP {
+			return fmt.Errorf("[TracingConfig] Unknown OTLP protocol %q", t.OTLP.Protocol)
+		}
+	case TracingExporterFile:
+		if t.File.Path == "" {
+			return fmt.Errorf("[TracingConfig] File path is not set")
+		}
+	default:
+		return fmt.Errorf("[TracingConfig] Unknown exporter %q", t.Exporter)
+	}
+	return nil
+}
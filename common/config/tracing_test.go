@@ -0,0 +1,90 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Tracing
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			cfg:  Tracing{},
+		},
+		{
+			name: "otlp",
+			cfg:  Tracing{Exporter: TracingExporterOTLP, OTLP: OTLPTracing{Endpoint: "localhost:4317"}},
+		},
+		{
+			name: "otlp over http",
+			cfg:  Tracing{Exporter: TracingExporterOTLP, OTLP: OTLPTracing{Endpoint: "localhost:4318", Protocol: OTLPProtocolHTTP}},
+		},
+		{
+			name:    "otlp without endpoint",
+			cfg:     Tracing{Exporter: TracingExporterOTLP},
+			wantErr: "[TracingConfig] OTLP endpoint is not set",
+		},
+		{
+			name:    "otlp with unknown protocol",
+			cfg:     Tracing{Exporter: TracingExporterOTLP, OTLP: OTLPTracing{Endpoint: "localhost:4317", Protocol: "udp"}},
+			wantErr: `[TracingConfig] Unknown OTLP protocol "udp"`,
+		},
+		{
+			name: "file",
+			cfg:  Tracing{Exporter: TracingExporterFile, File: FileTracing{Path: "/tmp/spans.json"}},
+		},
+		{
+			name:    "file without path",
+			cfg:     Tracing{Exporter: TracingExporterFile},
+			wantErr: "[TracingConfig] File path is not set",
+		},
+		{
+			name:    "unknown exporter",
+			cfg:     Tracing{Exporter: "jaeger"},
+			wantErr: `[TracingConfig] Unknown exporter "jaeger"`,
+		},
+		{
+			name:    "invalid sample rate",
+			cfg:     Tracing{SampleRate: 2},
+			wantErr: "[TracingConfig] SampleRate must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -63,6 +63,7 @@ const (
 	EncodingTypeJSON           EncodingType = "json"
 	EncodingTypeThriftRW       EncodingType = "thriftrw"
 	EncodingTypeThriftRWSnappy EncodingType = "thriftrw_snappy"
+	EncodingTypeThriftRWZstd   EncodingType = "thriftrw_zstd"
 	EncodingTypeGob            EncodingType = "gob"
 	EncodingTypeUnknown        EncodingType = "unknow"
 	EncodingTypeEmpty          EncodingType = ""
@@ -0,0 +1,84 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package dns resolves the addresses of hosts from DNS A and SRV records
+package dns
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+)
+
+type (
+	// Resolver looks up DNS records, it is implemented by net.Resolver
+	Resolver interface {
+		LookupHost(ctx context.Context, host string) (addrs []string, err error)
+		LookupSRV(ctx context.Context, service, proto, name string) (cname string, addrs []*net.SRV, err error)
+	}
+
+	// Address is an IP resolved from DNS with the port of the host
+	Address struct {
+		IP   string
+		Port uint16
+	}
+)
+
+// String returns the address as ip:port, IPv6 addresses are enclosed in brackets
+func (a Address) String() string {
+	return net.JoinHostPort(a.IP, strconv.Itoa(int(a.Port)))
+}
+
+// LookupHost resolves the IPs of host from its A or AAAA records, all of them listening to port
+func LookupHost(ctx context.Context, resolver Resolver, host string, port uint16) ([]Address, error) {
+	ips, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]Address, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, Address{IP: ip, Port: port})
+	}
+	return addrs, nil
+}
+
+// LookupSRV resolves the targets of an SRV record by its full name, e.g. _tchannel._tcp.cadence-frontend.example.com,
+// to their IPs with the port of the target. Targets which fail to resolve are logged and skipped.
+func LookupSRV(ctx context.Context, resolver Resolver, name string, logger log.Logger) ([]Address, error) {
+	_, srvs, err := resolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []Address
+	for _, srv := range srvs {
+		targetAddrs, err := LookupHost(ctx, resolver, srv.Target, srv.Port)
+		if err != nil {
+			logger.Warn("could not resolve srv dns host", tag.Address(srv.Target), tag.Error(err))
+			continue
+		}
+		addrs = append(addrs, targetAddrs...)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,98 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/log/testlogger"
+)
+
+type fakeResolver struct {
+	hosts map[string][]string
+	srv   map[string][]*net.SRV
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, errors.New("host was not resolved: " + host)
+	}
+	return addrs, nil
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	srvs, ok := r.srv[name]
+	if !ok {
+		return "", nil, errors.New("srv was not resolved: " + name)
+	}
+	return name, srvs, nil
+}
+
+func TestLookupHost(t *testing.T) {
+	resolver := &fakeResolver{
+		hosts: map[string][]string{"history.example.com": {"10.0.0.1", "fd00::1"}},
+	}
+
+	addrs, err := LookupHost(context.Background(), resolver, "history.example.com", 7934)
+	require.NoError(t, err)
+	assert.Equal(t, []Address{{IP: "10.0.0.1", Port: 7934}, {IP: "fd00::1", Port: 7934}}, addrs)
+	assert.Equal(t, "10.0.0.1:7934", addrs[0].String())
+	assert.Equal(t, "[fd00::1]:7934", addrs[1].String())
+
+	_, err = LookupHost(context.Background(), resolver, "unknown.example.com", 7934)
+	assert.Error(t, err)
+}
+
+func TestLookupSRV(t *testing.T) {
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"frontend-1.example.com": {"10.0.0.1"},
+			"frontend-2.example.com": {"10.0.0.2", "10.0.0.3"},
+		},
+		srv: map[string][]*net.SRV{
+			"_tchannel._tcp.frontend.example.com": {
+				{Target: "frontend-1.example.com", Port: 7933},
+				{Target: "frontend-2.example.com", Port: 7943},
+				{Target: "unknown.example.com", Port: 7933},
+			},
+		},
+	}
+
+	addrs, err := LookupSRV(context.Background(), resolver, "_tchannel._tcp.frontend.example.com", testlogger.New(t))
+	require.NoError(t, err)
+	assert.Equal(t, []Address{
+		{IP: "10.0.0.1", Port: 7933},
+		{IP: "10.0.0.2", Port: 7943},
+		{IP: "10.0.0.3", Port: 7943},
+	}, addrs, "targets which fail to resolve are skipped")
+
+	_, err = LookupSRV(context.Background(), resolver, "_tchannel._tcp.unknown.example.com", testlogger.New(t))
+	assert.Error(t, err)
+}
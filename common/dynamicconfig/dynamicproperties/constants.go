@@ -369,6 +369,14 @@ const (
 	// Default value: 52428800 (50*1024*1024)
 	// Allowed filters: DomainName
 	HistorySizeLimitWarn
+	// LargePayloadOffloadThreshold is the size in bytes above which workflow inputs, results and signal payloads
+	// are stored in the blobstore and only a reference to them goes into history, 0 disables offloading and resolving
+	// references, a threshold above the blob size limit stops offloading while existing references are still resolved
+	// KeyName: limit.largePayloadOffloadThreshold
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: DomainName
+	LargePayloadOffloadThreshold
 	// HistoryCountLimitError is the per workflow execution history event count limit
 	// KeyName: limit.historyCount.error
 	// Value type: Int
@@ -1240,6 +1248,18 @@ const (
 	// Default value: 10
 	// Allowed filters: ShardID
 	ReplicationTaskProcessorErrorRetryMaxAttempts
+	// ReplicationDLQRedriveMaxAttempts is the max number of automatic re-drive attempts for a replication DLQ message before it is quarantined
+	// KeyName: history.replicationDLQRedriveMaxAttempts
+	// Value type: Int
+	// Default value: 5
+	// Allowed filters: ShardID
+	ReplicationDLQRedriveMaxAttempts
+	// ReplicationDLQRedriveBatchSize is the number of replication DLQ messages read per source cluster in one re-drive pass
+	// KeyName: history.replicationDLQRedriveBatchSize
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: ShardID
+	ReplicationDLQRedriveBatchSize
 
 	// WorkflowIDExternalRPS is the rate limit per workflowID for external calls
 	// KeyName: history.workflowIDExternalRPS
@@ -1664,6 +1684,12 @@ const (
 	// Value type: Bool
 	// Default value: false
 	MatchingEnableTasklistGuardAgainstOwnershipShardLoss
+	// MatchingEnableGlobalRatelimiter creates the task list dispatch ratelimiters of MatchingGlobalRatelimiterMode.
+	// It is only read at startup, when disabled every task list uses its own local dispatch ratelimiter regardless of the mode.
+	// KeyName: matching.enableGlobalRatelimiter
+	// Value type: Bool
+	// Default value: false
+	MatchingEnableGlobalRatelimiter
 	// MatchingEnableStandbyTaskCompletion is to enable completion of tasks in the domain's passive side
 	// KeyName: matching.enableStandbyTaskCompletion
 	// Value type: Bool
@@ -1800,6 +1826,12 @@ const (
 	// Default value: true
 	// Allowed filters: DomainID, WorkflowID
 	EnableReplicationTaskGeneration
+	// EnableReplicationDLQRedrive is the flag to enable the background re-driver that retries replication DLQ messages
+	// KeyName: history.enableReplicationDLQRedrive
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: ShardID
+	EnableReplicationDLQRedrive
 	// UseNewInitialFailoverVersion is a switch to issue a failover version based on the minFailoverVersion
 	// rather than the default initialFailoverVersion. USed as a per-domain migration switch
 	// KeyName: history.useNewInitialFailoverVersion
@@ -1838,6 +1870,12 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	HistoryScannerEnabled
+	// HistoryScannerDryRun indicates if history scanner should report orphaned history branches to the blobstore instead of deleting them
+	// KeyName: worker.historyScannerDryRun
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	HistoryScannerDryRun
 	// ConcreteExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner
 	// KeyName: worker.executionsScannerEnabled
 	// Value type: Bool
@@ -1880,6 +1918,18 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	ConcreteExecutionsScannerInvariantCollectionStale
+	// ConcreteExecutionsScannerInvariantCollectionReconciliation indicates if the invariants checking mutable state against history should be run
+	// KeyName: worker.executionsScannerInvariantCollectionReconciliation
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerInvariantCollectionReconciliation
+	// ConcreteExecutionsFixerInvariantCollectionReconciliation indicates if the invariants checking mutable state against history should be run
+	// KeyName: worker.executionsFixerInvariantCollectionReconciliation
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsFixerInvariantCollectionReconciliation
 	// CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner
 	// KeyName: worker.currentExecutionsScannerEnabled
 	// Value type: Bool
@@ -2328,7 +2378,7 @@ const (
 	// Default value: "enabled"
 	// Allowed filters: N/A
 	VisibilityArchivalStatus
-	// DefaultEventEncoding is the encoding type for history events
+	// DefaultEventEncoding is the encoding type for history events, thriftrw_zstd compresses the stored event batches and the events kept in mutable state
 	// KeyName: history.defaultEventEncoding
 	// Value type: String
 	// Default value: string(constants.EncodingTypeThriftRW)
@@ -2403,6 +2453,49 @@ const (
 	// Default value: "hash_ring"
 	MatchingShardDistributionMode
 
+	// PersistenceGlobalRatelimiterMode is the same as FrontendGlobalRatelimiterMode, but for the
+	// per-datastore persistence ratelimiters in all services.
+	// Global keys are in the form "<service>-persistence:<datastore>", e.g. "history-persistence:cass-default".
+	//
+	// KeyName: system.persistenceGlobalRatelimiterMode
+	// Value type: string enum: "disabled", "local", "global", "local-shadow-global", or "global-shadow-local"
+	// Default value: "disabled"
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	PersistenceGlobalRatelimiterMode
+	// MatchingGlobalRatelimiterMode is the same as FrontendGlobalRatelimiterMode, but for task list dispatch ratelimiters.
+	// Global keys are in the form "tasklist-dispatch:<domain-id>/<task list partition name>/<task list type>".
+	//
+	// KeyName: matching.globalRatelimiterMode
+	// Value type: string enum: "disabled", "local", "global", "local-shadow-global", or "global-shadow-local"
+	// Default value: "disabled"
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	MatchingGlobalRatelimiterMode
+	// HistoryGlobalRatelimiterMode is the same as FrontendGlobalRatelimiterMode, but for the per-domain task scheduler ratelimiters.
+	// Global keys are in the form "task-scheduler:<domain name>".
+	//
+	// KeyName: history.globalRatelimiterMode
+	// Value type: string enum: "disabled", "local", "global", "local-shadow-global", or "global-shadow-local"
+	// Default value: "disabled"
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	HistoryGlobalRatelimiterMode
+	// HistoryGlobalRatelimiterAlgorithm selects the weighting algorithm the aggregating history hosts
+	// use to divide a global ratelimit between limiting hosts, per global key.
+	//
+	// KeyName: history.globalRatelimiterAlgorithm
+	// Value type: string enum: "request-weighted", "max-min-fair", or "burst-credit"
+	// Default value: "request-weighted"
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	HistoryGlobalRatelimiterAlgorithm
+	// HistoryGlobalRatelimiterShadowAlgorithm selects a weighting algorithm to compute alongside
+	// HistoryGlobalRatelimiterAlgorithm, per global key.  Its results are never returned to limiting hosts,
+	// only compared against the primary algorithm and emitted as divergence metrics.
+	//
+	// KeyName: history.globalRatelimiterShadowAlgorithm
+	// Value type: string enum: "", "request-weighted", "max-min-fair", or "burst-credit"
+	// Default value: "" (no shadow)
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	HistoryGlobalRatelimiterShadowAlgorithm
+
 	// LastStringKey must be the last one in this const group
 	LastStringKey
 )
@@ -2775,6 +2868,24 @@ const (
 	// Default value: 30s (30 * time.Second)
 	// Allowed filters: ShardID
 	ReplicationTaskProcessorErrorSecondRetryMaxWait
+	// ReplicationDLQRedriveInterval is the interval between two re-drive passes over the replication DLQ
+	// KeyName: history.replicationDLQRedriveInterval
+	// Value type: Duration
+	// Default value: 1m (1*time.Minute)
+	// Allowed filters: ShardID
+	ReplicationDLQRedriveInterval
+	// ReplicationDLQRedriveInitialBackoff is the backoff after the first failed re-drive attempt of a replication DLQ message
+	// KeyName: history.replicationDLQRedriveInitialBackoff
+	// Value type: Duration
+	// Default value: 1m (1*time.Minute)
+	// Allowed filters: ShardID
+	ReplicationDLQRedriveInitialBackoff
+	// ReplicationDLQRedriveMaxBackoff is the max backoff between two re-drive attempts of a replication DLQ message
+	// KeyName: history.replicationDLQRedriveMaxBackoff
+	// Value type: Duration
+	// Default value: 1h (1*time.Hour)
+	// Allowed filters: ShardID
+	ReplicationDLQRedriveMaxBackoff
 	// ReplicationTaskProcessorErrorSecondRetryExpiration is the expiration duration for the second phase retry
 	// KeyName: history.ReplicationTaskProcessorErrorSecondRetryExpiration
 	// Value type: Duration
@@ -2860,6 +2971,12 @@ const (
 	// Value type: Duration
 	// Default value: 30 seconds
 	HistoryGlobalRatelimiterGCAfter
+	// HistoryGlobalRatelimiterBurstCreditWindow defines how many seconds of an even share of a limit a quiet host
+	// can bank as burst credit, when the "burst-credit" weighting algorithm is in use.
+	// KeyName: history.globalRatelimiterBurstCreditWindow
+	// Value type: Duration
+	// Default value: 10 seconds
+	HistoryGlobalRatelimiterBurstCreditWindow
 
 	// LocalPollWaitTime is the wait time for a poller to wait before considering request forwarding
 	// KeyName: matching.localPollWaitTime
@@ -2889,6 +3006,13 @@ const (
 	// Allowed filters: domainName, taskListName, taskListType
 	TaskIsolationPollerWindow
 
+	// AsyncWorkflowRequestStatusTTL is how long the status of an async workflow request is kept after its last update
+	// KeyName: system.asyncWorkflowRequestStatusTTL
+	// Value type: Duration
+	// Default value: 24h
+	// Allowed filters: DomainName
+	AsyncWorkflowRequestStatusTTL
+
 	// LastDurationKey must be the last one in this const group
 	LastDurationKey
 )
@@ -2953,6 +3077,13 @@ const (
 	// Allowed filters: N/A
 	SearchAttributesHiddenValueKeys
 
+	// DiagnosticsEnabledInvariants is the set of plugin invariants enabled for workflow diagnostics, keyed by plugin name with a bool value
+	// KeyName: worker.diagnosticsEnabledInvariants
+	// Value type: Map
+	// Default value: empty map
+	// Allowed filters: DomainName
+	DiagnosticsEnabledInvariants
+
 	// LastMapKey must be the last one in this const group
 	LastMapKey
 )
@@ -2978,6 +3109,15 @@ const (
 	// Default value: forward all headers.  (this is a problematic value, and it will be changing as we reduce to a list of known values)
 	HeaderForwardingRules
 
+	// HistoryZstdDictionaries is the list of base64 encoded trained zstd dictionaries for history event batches
+	// encoded with thriftrw_zstd. The first dictionary compresses new batches, all of them are used to read.
+	// Dictionaries must stay in the list for as long as batches compressed with them are retained.
+	// KeyName: system.historyZstdDictionaries
+	// Value type: []string
+	// Default value: empty list (no dictionary)
+	// Allowed filters: DomainName
+	HistoryZstdDictionaries
+
 	LastListKey
 )
 
@@ -3057,6 +3197,12 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "HistorySizeLimitWarn is the per workflow execution history size limit for warning",
 		DefaultValue: 50 * 1024 * 1024,
 	},
+	LargePayloadOffloadThreshold: {
+		KeyName:      "limit.largePayloadOffloadThreshold",
+		Filters:      []Filter{DomainName},
+		Description:  "LargePayloadOffloadThreshold is the size in bytes above which workflow inputs, results and signal payloads are stored in the blobstore and only a reference to them goes into history, 0 disables offloading and resolving references, a threshold above the blob size limit stops offloading while existing references are still resolved",
+		DefaultValue: 0,
+	},
 	HistoryCountLimitError: {
 		KeyName:      "limit.historyCount.error",
 		Filters:      []Filter{DomainName},
@@ -3796,6 +3942,18 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "ReplicationTaskProcessorErrorRetryMaxAttempts is the max retry attempts for applying replication tasks",
 		DefaultValue: 10,
 	},
+	ReplicationDLQRedriveMaxAttempts: {
+		KeyName:      "history.replicationDLQRedriveMaxAttempts",
+		Filters:      []Filter{ShardID},
+		Description:  "ReplicationDLQRedriveMaxAttempts is the max number of automatic re-drive attempts for a replication DLQ message before it is quarantined",
+		DefaultValue: 5,
+	},
+	ReplicationDLQRedriveBatchSize: {
+		KeyName:      "history.replicationDLQRedriveBatchSize",
+		Filters:      []Filter{ShardID},
+		Description:  "ReplicationDLQRedriveBatchSize is the number of replication DLQ messages read per source cluster in one re-drive pass",
+		DefaultValue: 100,
+	},
 	WorkflowIDExternalRPS: {
 		KeyName:      "history.workflowIDExternalRPS",
 		Filters:      []Filter{DomainName},
@@ -4178,6 +4336,11 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "allows guards to ensure that tasklists don't continue processing if there's signal that they've lost ownership",
 		DefaultValue: false,
 	},
+	MatchingEnableGlobalRatelimiter: {
+		KeyName:      "matching.enableGlobalRatelimiter",
+		Description:  "MatchingEnableGlobalRatelimiter creates the task list dispatch ratelimiters of MatchingGlobalRatelimiterMode, it is only read at startup",
+		DefaultValue: false,
+	},
 	MatchingEnableGetNumberOfPartitionsFromCache: {
 		KeyName:      "matching.enableGetNumberOfPartitionsFromCache",
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
@@ -4329,6 +4492,12 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableReplicationTaskGeneration is the flag to control replication generation",
 		DefaultValue: true,
 	},
+	EnableReplicationDLQRedrive: {
+		KeyName:      "history.enableReplicationDLQRedrive",
+		Filters:      []Filter{ShardID},
+		Description:  "EnableReplicationDLQRedrive is the flag to enable the background re-driver that retries replication DLQ messages",
+		DefaultValue: false,
+	},
 	UseNewInitialFailoverVersion: {
 		KeyName:      "history.useNewInitialFailoverVersion",
 		Description:  "use the minInitialFailover version",
@@ -4354,6 +4523,11 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "HistoryScannerEnabled indicates if history scanner should be started as part of worker.Scanner",
 		DefaultValue: false,
 	},
+	HistoryScannerDryRun: {
+		KeyName:      "worker.historyScannerDryRun",
+		Description:  "HistoryScannerDryRun indicates if history scanner should report orphaned history branches to the blobstore instead of deleting them",
+		DefaultValue: false,
+	},
 	ConcreteExecutionsScannerEnabled: {
 		KeyName:      "worker.executionsScannerEnabled",
 		Description:  "ConcreteExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner",
@@ -4389,6 +4563,16 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "ConcreteExecutionsFixerInvariantCollectionStale indicates if the stale-workflow invariant should be run",
 		DefaultValue: false, // may be enabled after further verification, but for now it's a bit too risky to enable by default
 	},
+	ConcreteExecutionsScannerInvariantCollectionReconciliation: {
+		KeyName:      "worker.executionsScannerInvariantCollectionReconciliation",
+		Description:  "ConcreteExecutionsScannerInvariantCollectionReconciliation indicates if the invariants checking mutable state against history should be run",
+		DefaultValue: false, // reads the whole history of every execution, so it is opt-in
+	},
+	ConcreteExecutionsFixerInvariantCollectionReconciliation: {
+		KeyName:      "worker.executionsFixerInvariantCollectionReconciliation",
+		Description:  "ConcreteExecutionsFixerInvariantCollectionReconciliation indicates if the invariants checking mutable state against history should be run",
+		DefaultValue: false,
+	},
 	CurrentExecutionsScannerEnabled: {
 		KeyName:      "worker.currentExecutionsScannerEnabled",
 		Description:  "CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner",
@@ -4804,7 +4988,7 @@ var StringKeys = map[StringKey]DynamicString{
 	DefaultEventEncoding: {
 		KeyName:      "history.defaultEventEncoding",
 		Filters:      []Filter{DomainName},
-		Description:  "DefaultEventEncoding is the encoding type for history events",
+		Description:  "DefaultEventEncoding is the encoding type for history events, thriftrw_zstd compresses the stored event batches and the events kept in mutable state",
 		DefaultValue: string(constants.EncodingTypeThriftRW),
 	},
 	AdminOperationToken: {
@@ -4865,6 +5049,36 @@ var StringKeys = map[StringKey]DynamicString{
 		Description:  "MatchingShardDistributionMode defines which shard distribution mode should be used",
 		DefaultValue: "hash_ring",
 	},
+	PersistenceGlobalRatelimiterMode: {
+		KeyName:      "system.persistenceGlobalRatelimiterMode",
+		Description:  "PersistenceGlobalRatelimiterMode defines which mode a global persistence ratelimiter key should be in, per key, to make gradual changes to ratelimiter algorithms",
+		DefaultValue: "disabled",
+		Filters:      []Filter{RatelimitKey},
+	},
+	MatchingGlobalRatelimiterMode: {
+		KeyName:      "matching.globalRatelimiterMode",
+		Description:  "MatchingGlobalRatelimiterMode defines which mode a global task list dispatch ratelimiter key should be in, per key, to make gradual changes to ratelimiter algorithms",
+		DefaultValue: "disabled",
+		Filters:      []Filter{RatelimitKey},
+	},
+	HistoryGlobalRatelimiterMode: {
+		KeyName:      "history.globalRatelimiterMode",
+		Description:  "HistoryGlobalRatelimiterMode defines which mode a global task scheduler ratelimiter key should be in, per key, to make gradual changes to ratelimiter algorithms",
+		DefaultValue: "disabled",
+		Filters:      []Filter{RatelimitKey},
+	},
+	HistoryGlobalRatelimiterAlgorithm: {
+		KeyName:      "history.globalRatelimiterAlgorithm",
+		Description:  "HistoryGlobalRatelimiterAlgorithm selects the weighting algorithm used to divide a global ratelimit between limiting hosts, per global key",
+		DefaultValue: "request-weighted",
+		Filters:      []Filter{RatelimitKey},
+	},
+	HistoryGlobalRatelimiterShadowAlgorithm: {
+		KeyName:      "history.globalRatelimiterShadowAlgorithm",
+		Description:  "HistoryGlobalRatelimiterShadowAlgorithm selects a weighting algorithm to compute and compare against HistoryGlobalRatelimiterAlgorithm without using its results, per global key",
+		DefaultValue: "",
+		Filters:      []Filter{RatelimitKey},
+	},
 }
 
 var DurationKeys = map[DurationKey]DynamicDuration{
@@ -5231,6 +5445,24 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "ReplicationTaskProcessorErrorSecondRetryMaxWait is the max wait time for the second phase retry",
 		DefaultValue: time.Second * 30,
 	},
+	ReplicationDLQRedriveInterval: {
+		KeyName:      "history.replicationDLQRedriveInterval",
+		Filters:      []Filter{ShardID},
+		Description:  "ReplicationDLQRedriveInterval is the interval between two re-drive passes over the replication DLQ",
+		DefaultValue: time.Minute,
+	},
+	ReplicationDLQRedriveInitialBackoff: {
+		KeyName:      "history.replicationDLQRedriveInitialBackoff",
+		Filters:      []Filter{ShardID},
+		Description:  "ReplicationDLQRedriveInitialBackoff is the backoff after the first failed re-drive attempt of a replication DLQ message",
+		DefaultValue: time.Minute,
+	},
+	ReplicationDLQRedriveMaxBackoff: {
+		KeyName:      "history.replicationDLQRedriveMaxBackoff",
+		Filters:      []Filter{ShardID},
+		Description:  "ReplicationDLQRedriveMaxBackoff is the max backoff between two re-drive attempts of a replication DLQ message",
+		DefaultValue: time.Hour,
+	},
 	ReplicationTaskProcessorErrorSecondRetryExpiration: {
 		KeyName:      "history.ReplicationTaskProcessorErrorSecondRetryExpiration",
 		Filters:      []Filter{ShardID},
@@ -5311,6 +5543,11 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "HistoryGlobalRatelimiterGCAfter defines how long to wait until a host's data is considered entirely useless, e.g. host has likely disappeared, its weight is very low, and the data can be deleted.",
 		DefaultValue: 30 * time.Second,
 	},
+	HistoryGlobalRatelimiterBurstCreditWindow: {
+		KeyName:      "history.globalRatelimiterBurstCreditWindow",
+		Description:  "HistoryGlobalRatelimiterBurstCreditWindow defines how many seconds of an even share of a limit a quiet host can bank as burst credit, when the \"burst-credit\" weighting algorithm is in use.",
+		DefaultValue: 10 * time.Second,
+	},
 	LocalPollWaitTime: {
 		KeyName:      "matching.localPollWaitTime",
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
@@ -5335,6 +5572,12 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "TaskIsolationDuration is the time period for which we attempt to respect tasklist isolation before allowing any poller to process the task",
 		DefaultValue: time.Second * 2,
 	},
+	AsyncWorkflowRequestStatusTTL: {
+		KeyName:      "system.asyncWorkflowRequestStatusTTL",
+		Filters:      []Filter{DomainName},
+		Description:  "AsyncWorkflowRequestStatusTTL is how long the status of an async workflow request is kept after its last update",
+		DefaultValue: time.Hour * 24,
+	},
 }
 
 var MapKeys = map[MapKey]DynamicMap{
@@ -5384,6 +5627,12 @@ var MapKeys = map[MapKey]DynamicMap{
 		Description:  "SearchAttributesHiddenValueKeys is the list of search attributes that values should be hidden",
 		DefaultValue: map[string]interface{}{},
 	},
+	DiagnosticsEnabledInvariants: {
+		KeyName:      "worker.diagnosticsEnabledInvariants",
+		Description:  "DiagnosticsEnabledInvariants is the set of plugin invariants enabled for workflow diagnostics, keyed by plugin name with a bool value",
+		Filters:      []Filter{DomainName},
+		DefaultValue: map[string]interface{}{},
+	},
 }
 
 var ListKeys = map[ListKey]DynamicList{
@@ -5410,6 +5659,11 @@ var ListKeys = map[ListKey]DynamicList{
 			},
 		},
 	},
+	HistoryZstdDictionaries: {
+		KeyName:     "system.historyZstdDictionaries",
+		Filters:     []Filter{DomainName},
+		Description: "HistoryZstdDictionaries is the list of base64 encoded trained zstd dictionaries for thriftrw_zstd history event batches, the first one compresses new batches and all of them are used to read",
+	},
 }
 
 var _keyNames map[string]Key
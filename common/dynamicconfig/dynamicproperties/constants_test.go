@@ -238,7 +238,7 @@ func (s *constantSuite) TestStringKey() {
 			Key:          DefaultEventEncoding,
 			KeyName:      "history.defaultEventEncoding",
 			Filters:      []Filter{DomainName},
-			Description:  "DefaultEventEncoding is the encoding type for history events",
+			Description:  "DefaultEventEncoding is the encoding type for history events, thriftrw_zstd compresses the stored event batches and the events kept in mutable state",
 			DefaultValue: string(constants.EncodingTypeThriftRW),
 		},
 		"ReadVisibilityStoreName": {
@@ -0,0 +1,53 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package largepayload
+
+import (
+	"context"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// Resolver resolves references to payloads offloaded to the blobstore.
+	// Only references to blobs owned by the given run are resolved and nothing is resolved
+	// for domains with offloading disabled, other payloads are returned as they are.
+	Resolver interface {
+		// Resolve returns the referenced payload if payload is a reference owned by the run, otherwise the payload itself
+		Resolve(ctx context.Context, domainID, domainName string, execution types.WorkflowExecution, payload []byte) ([]byte, error)
+		// ResolveEvents replaces the references owned by the run in the payloads of the events with the referenced payloads
+		ResolveEvents(ctx context.Context, domainID, domainName string, execution types.WorkflowExecution, events []*types.HistoryEvent) error
+	}
+
+	// Offloader stores payloads of history events above the domain threshold in the blobstore,
+	// so that only a reference to them goes into history.
+	// Each run owns the blobs referenced from its history, payloads referenced from another run
+	// (carried over by retries, cron, resets, signals or child workflows) are copied when offloaded.
+	Offloader interface {
+		Resolver
+		// OffloadEvents replaces the payloads of the events above the domain threshold with references
+		OffloadEvents(ctx context.Context, domainID, domainName string, execution types.WorkflowExecution, events []*types.HistoryEvent) error
+		// DeleteEventPayloads deletes the blobs owned by the run which are referenced by the events
+		DeleteEventPayloads(ctx context.Context, domainID string, execution types.WorkflowExecution, events []*types.HistoryEvent) error
+	}
+)
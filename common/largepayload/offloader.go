@@ -0,0 +1,268 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package largepayload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// referencePrefix marks a payload as a reference to a blob, the blob key follows the prefix
+	referencePrefix = "\x00cadence-large-payload:"
+	keyPrefix       = "largepayload"
+
+	domainIDTag   = "domainID"
+	workflowIDTag = "workflowID"
+	runIDTag      = "runID"
+)
+
+// keyPattern matches the blob keys written by the offloader: prefix, domainID, runID, event ID, event version and payload index.
+// Keys end up in blobstore paths, references not matching it are never resolved.
+var keyPattern = regexp.MustCompile(`^largepayload_[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}_[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}_-?[0-9]+_-?[0-9]+_[0-9]+$`)
+
+type offloader struct {
+	client    blobstore.Client
+	threshold dynamicproperties.IntPropertyFnWithDomainFilter
+}
+
+var _ Offloader = (*offloader)(nil)
+
+// NewOffloader returns a new Offloader, payloads are only offloaded for domains with a positive threshold
+func NewOffloader(
+	client blobstore.Client,
+	threshold dynamicproperties.IntPropertyFnWithDomainFilter,
+) Offloader {
+	return &offloader{
+		client:    client,
+		threshold: threshold,
+	}
+}
+
+// NewResolver returns a new Resolver, references are only resolved for domains with a positive threshold
+func NewResolver(
+	client blobstore.Client,
+	threshold dynamicproperties.IntPropertyFnWithDomainFilter,
+) Resolver {
+	return &offloader{
+		client:    client,
+		threshold: threshold,
+	}
+}
+
+// IsReference returns true if the payload is a reference to an offloaded payload
+func IsReference(payload []byte) bool {
+	return bytes.HasPrefix(payload, []byte(referencePrefix))
+}
+
+// ContainsReference returns true if the serialized data may embed a reference to an offloaded payload
+func ContainsReference(data []byte) bool {
+	return bytes.Contains(data, []byte(referencePrefix))
+}
+
+func (o *offloader) Resolve(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	execution types.WorkflowExecution,
+	payload []byte,
+) ([]byte, error) {
+	if !IsReference(payload) || !o.enabled(domainName) {
+		return payload, nil
+	}
+	// only blobs owned by the run are resolved, anything else is not a reference written for this run
+	if !isOwnedKey(referenceKey(payload), domainID, execution.GetRunID()) {
+		return payload, nil
+	}
+	return o.get(ctx, referenceKey(payload))
+}
+
+func (o *offloader) ResolveEvents(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	execution types.WorkflowExecution,
+	events []*types.HistoryEvent,
+) error {
+	if !o.enabled(domainName) {
+		return nil
+	}
+	for _, event := range events {
+		for _, payload := range eventPayloads(event) {
+			resolved, err := o.Resolve(ctx, domainID, domainName, execution, *payload)
+			if err != nil {
+				return err
+			}
+			*payload = resolved
+		}
+	}
+	return nil
+}
+
+func (o *offloader) OffloadEvents(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	execution types.WorkflowExecution,
+	events []*types.HistoryEvent,
+) error {
+	if !o.enabled(domainName) {
+		return nil
+	}
+	threshold := o.threshold(domainName)
+	owner := ownerKeyPrefix(domainID, execution.GetRunID())
+	for _, event := range events {
+		for idx, payload := range eventPayloads(event) {
+			if IsReference(*payload) {
+				key := referenceKey(*payload)
+				if isOwnedKey(key, domainID, execution.GetRunID()) {
+					continue
+				}
+				if !keyPattern.MatchString(key) {
+					return &types.BadRequestError{Message: fmt.Sprintf("Invalid large payload reference in event %v.", event.ID)}
+				}
+				resolved, err := o.get(ctx, key)
+				if err != nil {
+					return err
+				}
+				*payload = resolved
+			}
+			if len(*payload) <= threshold {
+				continue
+			}
+
+			// keys are derived from the event so retried writes of the same event overwrite the same blob
+			key := fmt.Sprintf("%v%v_%v_%v", owner, event.ID, event.Version, idx)
+			if _, err := o.client.Put(ctx, &blobstore.PutRequest{
+				Key: key,
+				Blob: blobstore.Blob{
+					Tags: map[string]string{
+						domainIDTag:   domainID,
+						workflowIDTag: execution.GetWorkflowID(),
+						runIDTag:      execution.GetRunID(),
+					},
+					Body: *payload,
+				},
+			}); err != nil {
+				return &types.InternalServiceError{Message: fmt.Sprintf("Failed to offload large payload %v: %v", key, err)}
+			}
+			*payload = []byte(referencePrefix + key)
+		}
+	}
+	return nil
+}
+
+func (o *offloader) DeleteEventPayloads(
+	ctx context.Context,
+	domainID string,
+	execution types.WorkflowExecution,
+	events []*types.HistoryEvent,
+) error {
+	if o.client == nil {
+		return nil
+	}
+	for _, event := range events {
+		for _, payload := range eventPayloads(event) {
+			if !IsReference(*payload) {
+				continue
+			}
+			key := referenceKey(*payload)
+			if !isOwnedKey(key, domainID, execution.GetRunID()) {
+				continue
+			}
+			// deletion is retried by the caller, blobs deleted by a previous attempt are skipped
+			resp, err := o.client.Exists(ctx, &blobstore.ExistsRequest{Key: key})
+			if err != nil {
+				return err
+			}
+			if !resp.Exists {
+				continue
+			}
+			if _, err := o.client.Delete(ctx, &blobstore.DeleteRequest{Key: key}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *offloader) enabled(domainName string) bool {
+	return o.client != nil && o.threshold != nil && o.threshold(domainName) > 0
+}
+
+func (o *offloader) get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := o.client.Get(ctx, &blobstore.GetRequest{Key: key})
+	if err != nil {
+		return nil, &types.InternalServiceError{Message: fmt.Sprintf("Failed to resolve large payload %v: %v", key, err)}
+	}
+	return resp.Blob.Body, nil
+}
+
+func referenceKey(payload []byte) string {
+	return string(payload[len(referencePrefix):])
+}
+
+func ownerKeyPrefix(domainID, runID string) string {
+	return fmt.Sprintf("%v_%v_%v_", keyPrefix, domainID, runID)
+}
+
+func isOwnedKey(key, domainID, runID string) bool {
+	return keyPattern.MatchString(key) && strings.HasPrefix(key, ownerKeyPrefix(domainID, runID))
+}
+
+// eventPayloads returns the workflow input, result and signal payloads of the event which can be offloaded
+func eventPayloads(event *types.HistoryEvent) []*[]byte {
+	switch {
+	case event.WorkflowExecutionStartedEventAttributes != nil:
+		attr := event.WorkflowExecutionStartedEventAttributes
+		return []*[]byte{&attr.Input, &attr.LastCompletionResult}
+	case event.WorkflowExecutionCompletedEventAttributes != nil:
+		return []*[]byte{&event.WorkflowExecutionCompletedEventAttributes.Result}
+	case event.WorkflowExecutionContinuedAsNewEventAttributes != nil:
+		attr := event.WorkflowExecutionContinuedAsNewEventAttributes
+		return []*[]byte{&attr.Input, &attr.LastCompletionResult}
+	case event.WorkflowExecutionSignaledEventAttributes != nil:
+		return []*[]byte{&event.WorkflowExecutionSignaledEventAttributes.Input}
+	case event.ActivityTaskScheduledEventAttributes != nil:
+		return []*[]byte{&event.ActivityTaskScheduledEventAttributes.Input}
+	case event.ActivityTaskCompletedEventAttributes != nil:
+		return []*[]byte{&event.ActivityTaskCompletedEventAttributes.Result}
+	case event.MarkerRecordedEventAttributes != nil:
+		return []*[]byte{&event.MarkerRecordedEventAttributes.Details}
+	case event.StartChildWorkflowExecutionInitiatedEventAttributes != nil:
+		return []*[]byte{&event.StartChildWorkflowExecutionInitiatedEventAttributes.Input}
+	case event.ChildWorkflowExecutionCompletedEventAttributes != nil:
+		return []*[]byte{&event.ChildWorkflowExecutionCompletedEventAttributes.Result}
+	case event.SignalExternalWorkflowExecutionInitiatedEventAttributes != nil:
+		return []*[]byte{&event.SignalExternalWorkflowExecutionInitiatedEventAttributes.Input}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,288 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package largepayload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	testDomainID   = "deadbeef-0123-4567-890a-bcdef0123456"
+	testDomainName = "test-domain"
+	testWorkflowID = "test-workflow-id"
+	testRunID      = "0d00698f-08e1-4d36-a3e2-3bf109f5d2b6"
+	testOtherRunID = "c4b9ea1a-1d6e-4c6a-9f2c-91f0a53e9b4d"
+)
+
+var testExecution = types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID}
+
+func TestOffloadEvents(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(4))
+
+	small := []byte("abc")
+	large := []byte("large payload")
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			Version:   10,
+			EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				Input: large,
+			},
+		},
+		{
+			ID:        2,
+			Version:   10,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				Input: small,
+			},
+		},
+		{
+			ID:        3,
+			Version:   10,
+			EventType: types.EventTypeDecisionTaskScheduled.Ptr(),
+		},
+	}
+
+	expectedKey := "largepayload_" + testDomainID + "_" + testRunID + "_1_10_0"
+	client.On("Put", mock.Anything, &blobstore.PutRequest{
+		Key: expectedKey,
+		Blob: blobstore.Blob{
+			Tags: map[string]string{
+				domainIDTag:   testDomainID,
+				workflowIDTag: testWorkflowID,
+				runIDTag:      testRunID,
+			},
+			Body: large,
+		},
+	}).Return(&blobstore.PutResponse{}, nil).Once()
+
+	require.NoError(t, o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events))
+	assert.True(t, IsReference(events[0].WorkflowExecutionStartedEventAttributes.Input))
+	assert.Equal(t, referencePrefix+expectedKey, string(events[0].WorkflowExecutionStartedEventAttributes.Input))
+	assert.Equal(t, small, events[1].WorkflowExecutionSignaledEventAttributes.Input)
+
+	// references owned by the run are kept as they are
+	reference := events[0].WorkflowExecutionStartedEventAttributes.Input
+	require.NoError(t, o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events[:1]))
+	assert.Equal(t, reference, events[0].WorkflowExecutionStartedEventAttributes.Input)
+
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: expectedKey}).
+		Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: large}}, nil).Once()
+	require.NoError(t, o.ResolveEvents(context.Background(), testDomainID, testDomainName, testExecution, events))
+	assert.Equal(t, large, events[0].WorkflowExecutionStartedEventAttributes.Input)
+}
+
+func TestOffloadEvents_CopiesReferencesOfOtherRuns(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(4))
+
+	large := []byte("large payload")
+	otherKey := ownerKeyPrefix(testDomainID, testOtherRunID) + "5_10_0"
+	events := []*types.HistoryEvent{
+		{
+			ID:        7,
+			Version:   10,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				Input: []byte(referencePrefix + otherKey),
+			},
+		},
+	}
+
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: otherKey}).
+		Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: large}}, nil).Once()
+	client.On("Put", mock.Anything, mock.MatchedBy(func(req *blobstore.PutRequest) bool {
+		return req.Key == ownerKeyPrefix(testDomainID, testRunID)+"7_10_0" && bytes.Equal(req.Blob.Body, large)
+	})).Return(&blobstore.PutResponse{}, nil).Once()
+
+	require.NoError(t, o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events))
+	assert.Equal(t, referencePrefix+ownerKeyPrefix(testDomainID, testRunID)+"7_10_0", string(events[0].WorkflowExecutionSignaledEventAttributes.Input))
+}
+
+func TestOffloadEvents_RejectsInvalidReferences(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(4))
+
+	events := []*types.HistoryEvent{
+		{
+			ID:        7,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				Input: []byte(referencePrefix + "../../etc/passwd"),
+			},
+		},
+	}
+	err := o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events)
+	assert.IsType(t, &types.BadRequestError{}, err)
+}
+
+func TestOffloadEvents_Disabled(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(0))
+
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			EventType: types.EventTypeActivityTaskCompleted.Ptr(),
+			ActivityTaskCompletedEventAttributes: &types.ActivityTaskCompletedEventAttributes{
+				Result: []byte("large payload"),
+			},
+		},
+	}
+	require.NoError(t, o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events))
+	assert.Equal(t, []byte("large payload"), events[0].ActivityTaskCompletedEventAttributes.Result)
+}
+
+func TestOffloadEvents_PutError(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(1))
+
+	client.On("Put", mock.Anything, mock.Anything).Return(nil, errors.New("put failed")).Once()
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				Input: []byte("payload"),
+			},
+		},
+	}
+	err := o.OffloadEvents(context.Background(), testDomainID, testDomainName, testExecution, events)
+	assert.IsType(t, &types.InternalServiceError{}, err)
+	assert.Equal(t, []byte("payload"), events[0].ActivityTaskScheduledEventAttributes.Input)
+}
+
+func TestResolve(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	r := NewResolver(client, dynamicproperties.GetIntPropertyFilteredByDomain(1))
+	ctx := context.Background()
+
+	payload, err := r.Resolve(ctx, testDomainID, testDomainName, testExecution, []byte("inline"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("inline"), payload)
+
+	ownedKey := ownerKeyPrefix(testDomainID, testRunID) + "1_-24_0"
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: ownedKey}).
+		Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: []byte("large")}}, nil).Once()
+	payload, err = r.Resolve(ctx, testDomainID, testDomainName, testExecution, []byte(referencePrefix+ownedKey))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("large"), payload)
+
+	missingKey := ownerKeyPrefix(testDomainID, testRunID) + "2_10_0"
+	client.On("Get", mock.Anything, &blobstore.GetRequest{Key: missingKey}).Return(nil, errors.New("not found")).Once()
+	_, err = r.Resolve(ctx, testDomainID, testDomainName, testExecution, []byte(referencePrefix+missingKey))
+	assert.IsType(t, &types.InternalServiceError{}, err)
+}
+
+func TestResolve_OnlyOwnedReferences(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	r := NewResolver(client, dynamicproperties.GetIntPropertyFilteredByDomain(1))
+
+	for _, key := range []string{
+		ownerKeyPrefix(testDomainID, testOtherRunID) + "1_10_0",
+		ownerKeyPrefix(testDomainID, testRunID) + "1_10_0/../../" + testOtherRunID,
+		ownerKeyPrefix(testDomainID, testRunID),
+		"../../etc/passwd",
+	} {
+		reference := []byte(referencePrefix + key)
+		payload, err := r.Resolve(context.Background(), testDomainID, testDomainName, testExecution, reference)
+		require.NoError(t, err)
+		assert.Equal(t, reference, payload, key)
+	}
+}
+
+func TestResolve_Disabled(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	reference := []byte(referencePrefix + ownerKeyPrefix(testDomainID, testRunID) + "1_10_0")
+
+	for _, r := range []Resolver{
+		NewResolver(client, dynamicproperties.GetIntPropertyFilteredByDomain(0)),
+		NewResolver(nil, dynamicproperties.GetIntPropertyFilteredByDomain(1)),
+	} {
+		payload, err := r.Resolve(context.Background(), testDomainID, testDomainName, testExecution, reference)
+		require.NoError(t, err)
+		assert.Equal(t, reference, payload)
+	}
+}
+
+func TestDeleteEventPayloads(t *testing.T) {
+	client := &blobstore.MockClient{}
+	defer client.AssertExpectations(t)
+	o := NewOffloader(client, dynamicproperties.GetIntPropertyFilteredByDomain(1))
+
+	ownedKey := ownerKeyPrefix(testDomainID, testRunID) + "1_10_0"
+	deletedKey := ownerKeyPrefix(testDomainID, testRunID) + "2_10_0"
+	otherKey := ownerKeyPrefix(testDomainID, testOtherRunID) + "1_10_0"
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				Input:                []byte(referencePrefix + ownedKey),
+				LastCompletionResult: []byte(referencePrefix + otherKey),
+			},
+		},
+		{
+			ID:        2,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				Input: []byte(referencePrefix + deletedKey),
+			},
+		},
+		{
+			ID:        3,
+			EventType: types.EventTypeMarkerRecorded.Ptr(),
+			MarkerRecordedEventAttributes: &types.MarkerRecordedEventAttributes{
+				Details: []byte("inline"),
+			},
+		},
+	}
+
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: ownedKey}).Return(&blobstore.ExistsResponse{Exists: true}, nil).Once()
+	client.On("Delete", mock.Anything, &blobstore.DeleteRequest{Key: ownedKey}).Return(&blobstore.DeleteResponse{}, nil).Once()
+	client.On("Exists", mock.Anything, &blobstore.ExistsRequest{Key: deletedKey}).Return(&blobstore.ExistsResponse{Exists: false}, nil).Once()
+
+	require.NoError(t, o.DeleteEventPayloads(context.Background(), testDomainID, testExecution, events))
+}
@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package largepayload
+
+import (
+	"github.com/uber/cadence/common/types"
+)
+
+// ErrReservedPrefix is returned for client payloads starting with the prefix of references to offloaded payloads
+var ErrReservedPrefix = &types.BadRequestError{Message: "Payload must not start with the prefix reserved for large payload references."}
+
+// ValidatePayloads returns ErrReservedPrefix if any of the client payloads could be taken for a reference
+func ValidatePayloads(payloads ...[]byte) error {
+	for _, payload := range payloads {
+		if IsReference(payload) {
+			return ErrReservedPrefix
+		}
+	}
+	return nil
+}
+
+// ValidateDecisions returns ErrReservedPrefix if any of the decision payloads which end up in history could be taken for a reference
+func ValidateDecisions(decisions []*types.Decision) error {
+	for _, decision := range decisions {
+		if err := ValidatePayloads(decisionPayloads(decision)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decisionPayloads returns the payloads of the decision which are copied into offloadable event payloads
+func decisionPayloads(decision *types.Decision) [][]byte {
+	switch {
+	case decision == nil:
+		return nil
+	case decision.ScheduleActivityTaskDecisionAttributes != nil:
+		return [][]byte{decision.ScheduleActivityTaskDecisionAttributes.Input}
+	case decision.CompleteWorkflowExecutionDecisionAttributes != nil:
+		return [][]byte{decision.CompleteWorkflowExecutionDecisionAttributes.Result}
+	case decision.ContinueAsNewWorkflowExecutionDecisionAttributes != nil:
+		attr := decision.ContinueAsNewWorkflowExecutionDecisionAttributes
+		return [][]byte{attr.Input, attr.LastCompletionResult}
+	case decision.RecordMarkerDecisionAttributes != nil:
+		return [][]byte{decision.RecordMarkerDecisionAttributes.Details}
+	case decision.StartChildWorkflowExecutionDecisionAttributes != nil:
+		return [][]byte{decision.StartChildWorkflowExecutionDecisionAttributes.Input}
+	case decision.SignalExternalWorkflowExecutionDecisionAttributes != nil:
+		return [][]byte{decision.SignalExternalWorkflowExecutionDecisionAttributes.Input}
+	default:
+		return nil
+	}
+}
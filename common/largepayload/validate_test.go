@@ -0,0 +1,71 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package largepayload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestValidatePayloads(t *testing.T) {
+	assert.NoError(t, ValidatePayloads(nil, []byte("payload"), []byte("x"+referencePrefix)))
+	assert.Equal(t, ErrReservedPrefix, ValidatePayloads([]byte("payload"), []byte(referencePrefix+"key")))
+}
+
+func TestValidateDecisions(t *testing.T) {
+	reference := []byte(referencePrefix + "key")
+	tests := map[string]*types.Decision{
+		"schedule activity": {
+			ScheduleActivityTaskDecisionAttributes: &types.ScheduleActivityTaskDecisionAttributes{Input: reference},
+		},
+		"complete workflow": {
+			CompleteWorkflowExecutionDecisionAttributes: &types.CompleteWorkflowExecutionDecisionAttributes{Result: reference},
+		},
+		"continue as new": {
+			ContinueAsNewWorkflowExecutionDecisionAttributes: &types.ContinueAsNewWorkflowExecutionDecisionAttributes{LastCompletionResult: reference},
+		},
+		"record marker": {
+			RecordMarkerDecisionAttributes: &types.RecordMarkerDecisionAttributes{Details: reference},
+		},
+		"start child workflow": {
+			StartChildWorkflowExecutionDecisionAttributes: &types.StartChildWorkflowExecutionDecisionAttributes{Input: reference},
+		},
+		"signal external workflow": {
+			SignalExternalWorkflowExecutionDecisionAttributes: &types.SignalExternalWorkflowExecutionDecisionAttributes{Input: reference},
+		},
+	}
+	for name, decision := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, ErrReservedPrefix, ValidateDecisions([]*types.Decision{decision}))
+		})
+	}
+
+	assert.NoError(t, ValidateDecisions([]*types.Decision{
+		nil,
+		{StartTimerDecisionAttributes: &types.StartTimerDecisionAttributes{TimerID: "timer"}},
+		{ScheduleActivityTaskDecisionAttributes: &types.ScheduleActivityTaskDecisionAttributes{Input: []byte("input")}},
+	}))
+}
@@ -629,6 +629,11 @@ func ReadLevel(lv int64) Tag {
 	return newInt64("read-level", lv)
 }
 
+// AckLevel returns tag for AckLevel
+func AckLevel(lv int64) Tag {
+	return newInt64("ack-level", lv)
+}
+
 // MinLevel returns tag for MinLevel
 func MinLevel(lv int64) Tag {
 	return newInt64("min-level", lv)
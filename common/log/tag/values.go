@@ -120,6 +120,7 @@ var (
 	ComponentReplicationDynamicTaskBatchSizer = component("replication-dynamic-task-batch-sizer")
 	ComponentHistoryReplicator                = component("history-replicator")
 	ComponentHistoryResender                  = component("history-resender")
+	ComponentHistoryImporter                  = component("history-importer")
 	ComponentIndexer                          = component("indexer")
 	ComponentIndexerProcessor                 = component("indexer-processor")
 	ComponentIndexerESProcessor               = component("indexer-es-processor")
@@ -147,6 +148,8 @@ var (
 	ComponentActiveClusterManager             = component("active-cluster-manager")
 	ComponentMembershipResolver               = component("membership-resolver")
 	ComponentHashring                         = component("hashring")
+	ComponentPeerProvider                     = component("peer-provider")
+	ComponentGateway                          = component("gateway")
 	ComponentNamespaceManager                 = component("shard-namespace-manager")
 	ComponentLeaderElection                   = component("shard-leader-election")
 	ComponentLeaderProcessor                  = component("shard-leader-processor")
@@ -394,6 +397,7 @@ var (
 	HistoryClientOperationCloseShard                        = clientOperation("history-close-shard")
 	HistoryClientOperationResetQueue                        = clientOperation("history-reset-queue")
 	HistoryClientOperationDescribeQueue                     = clientOperation("history-describe-queue")
+	HistoryClientOperationDescribeReplicationQueue          = clientOperation("history-describe-replication-queue")
 	HistoryClientOperationRemoveTask                        = clientOperation("history-remove-task")
 	HistoryClientOperationDescribeMutableState              = clientOperation("history-describe-mutable-state")
 	HistoryClientOperationGetMutableState                   = clientOperation("history-get-mutable-state")
@@ -52,6 +52,27 @@ const (
 	replicaPoints          = 100
 )
 
+// hashRing places hosts on a consistent hash ring, it is implemented by ringpop's hashring and weightedHashring
+type hashRing interface {
+	Lookup(key string) (string, bool)
+	ServerCount() int
+	Servers() []string
+}
+
+// HashringOption configures a Ring
+type HashringOption func(*Ring)
+
+// WithWeightedHashring makes the ring place hosts according to their weight.
+// Capacities are looked up by host IP and override values reported by the peer provider.
+// Key ownership differs from the default ring, so all hosts of a cluster have to use the same option.
+func WithWeightedHashring(capacities map[string]HostCapacity) HashringOption {
+	return func(r *Ring) {
+		r.newRing = func(members []HostInfo) hashRing {
+			return newWeightedHashring(members, capacities)
+		}
+	}
+}
+
 // PeerProvider is used to retrieve membership information from provider
 type PeerProvider interface {
 	common.Daemon
@@ -72,6 +93,7 @@ type Ring struct {
 	timeSource   clock.TimeSource
 	scope        metrics.Scope
 	logger       log.Logger
+	newRing      func(members []HostInfo) hashRing
 
 	value atomic.Value // this stores the current hashring
 
@@ -93,6 +115,7 @@ func NewHashring(
 	timeSource clock.TimeSource,
 	logger log.Logger,
 	scope metrics.Scope,
+	opts ...HashringOption,
 ) *Ring {
 	r := &Ring{
 		status:       common.DaemonStatusInitialized,
@@ -103,12 +126,16 @@ func NewHashring(
 		timeSource:   timeSource,
 		logger:       logger.WithTags(tag.ComponentHashring),
 		scope:        scope,
+		newRing:      newRingpopHashring,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
 
 	r.members.keys = make(map[string]HostInfo)
 	r.subscribers.keys = make(map[string]chan<- *ChangedEvent)
 
-	r.value.Store(emptyHashring())
+	r.value.Store(r.newRing(nil))
 	return r
 }
 
@@ -116,6 +143,12 @@ func emptyHashring() *hashring.HashRing {
 	return hashring.New(farm.Fingerprint32, replicaPoints)
 }
 
+func newRingpopHashring(members []HostInfo) hashRing {
+	ring := emptyHashring()
+	ring.AddMembers(castToMembers(members)...)
+	return ring
+}
+
 // Start starts the hashring
 func (r *Ring) Start() {
 	if !atomic.CompareAndSwapInt32(
@@ -155,7 +188,7 @@ func (r *Ring) Stop() {
 	defer r.logger.Info("Stopped hashring", tag.ComponentHashring)
 
 	r.peerProvider.Stop()
-	r.value.Store(emptyHashring())
+	r.value.Store(r.newRing(nil))
 
 	r.subscribers.Lock()
 	r.subscribers.keys = make(map[string]chan<- *ChangedEvent)
@@ -287,9 +320,7 @@ func (r *Ring) Refresh() error {
 		return nil
 	}
 
-	ring := emptyHashring()
-	ring.AddMembers(castToMembers(members)...)
-	r.value.Store(ring)
+	r.value.Store(r.newRing(members))
 	// sort members for deterministic order in the logs
 	sort.Slice(members, func(i, j int) bool { return members[i].addr < members[j].addr })
 	r.logger.Info("refreshed ring members", tag.Value(members), tag.Counter(len(members)), tag.Service(r.service))
@@ -332,8 +363,8 @@ func (r *Ring) refreshRingWorker() {
 	}
 }
 
-func (r *Ring) ring() *hashring.HashRing {
-	return r.value.Load().(*hashring.HashRing)
+func (r *Ring) ring() hashRing {
+	return r.value.Load().(hashRing)
 }
 
 func (r *Ring) emitHashIdentifier() float64 {
@@ -388,10 +419,13 @@ func (r *Ring) diffMembers(newMembers map[string]HostInfo) ChangedEvent {
 
 	var combinedChange ChangedEvent
 
-	// find newly added hosts
-	for addr := range newMembers {
-		if _, found := r.members.keys[addr]; !found {
+	// find newly added hosts and hosts which moved on the weighted ring
+	for addr, member := range newMembers {
+		current, found := r.members.keys[addr]
+		if !found {
 			combinedChange.HostsAdded = append(combinedChange.HostsAdded, addr)
+		} else if current.weight != member.weight {
+			combinedChange.HostsUpdated = append(combinedChange.HostsUpdated, addr)
 		}
 	}
 	// find removed hosts
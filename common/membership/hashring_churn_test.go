@@ -0,0 +1,145 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package membership
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// churn harness compares how many history shards change their owner when membership changes
+// between the default ringpop hashring and the weighted hashring
+
+const churnNumShards = 16384
+
+var ringImplementations = map[string]func(members []HostInfo) hashRing{
+	"ringpop": newRingpopHashring,
+	"weighted": func(members []HostInfo) hashRing {
+		return newWeightedHashring(members, nil)
+	},
+}
+
+// shardOwnership returns owner of every shard, shards are looked up the same way history service does
+func shardOwnership(ring hashRing, numShards int) []string {
+	owners := make([]string, numShards)
+	for shardID := 0; shardID < numShards; shardID++ {
+		owners[shardID], _ = ring.Lookup(string(rune(shardID)))
+	}
+	return owners
+}
+
+func ownedShardCounts(owners []string) map[string]int {
+	counts := make(map[string]int)
+	for _, owner := range owners {
+		counts[owner]++
+	}
+	return counts
+}
+
+// shardChurn returns the fraction of shards which moved to another host
+func shardChurn(before, after []string) float64 {
+	moved := 0
+	for i := range before {
+		if before[i] != after[i] {
+			moved++
+		}
+	}
+	return float64(moved) / float64(len(before))
+}
+
+func TestHashringChurn(t *testing.T) {
+	const numHosts = 20
+	hosts := testHosts(numHosts + 1)
+	current := hosts[:numHosts]
+
+	reweighted := append([]HostInfo{}, current...)
+	reweighted[0] = reweighted[0].WithWeight(2)
+
+	tests := []struct {
+		name string
+		next []HostInfo
+		// optimal is the fraction of shards which has to move at least
+		optimal float64
+	}{
+		{
+			name:    "host added",
+			next:    hosts,
+			optimal: 1.0 / (numHosts + 1),
+		},
+		{
+			name:    "host removed",
+			next:    current[1:],
+			optimal: 1.0 / numHosts,
+		},
+		{
+			name:    "host restarted",
+			next:    current,
+			optimal: 0,
+		},
+		{
+			name: "host weight doubled",
+			next: reweighted,
+			// ringpop ignores weights, so the optimal value only applies to the weighted hashring
+			optimal: 2.0/(numHosts+1) - 1.0/numHosts,
+		},
+	}
+
+	for _, tt := range tests {
+		for name, newRing := range ringImplementations {
+			t.Run(fmt.Sprintf("%s/%s", tt.name, name), func(t *testing.T) {
+				churn := shardChurn(
+					shardOwnership(newRing(current), churnNumShards),
+					shardOwnership(newRing(tt.next), churnNumShards),
+				)
+				t.Logf("%.2f%% shards moved, optimal %.2f%%", churn*100, tt.optimal*100)
+
+				if name == "ringpop" && tt.name == "host weight doubled" {
+					assert.Zero(t, churn)
+					return
+				}
+				// consistent hashing moves only shards of the changed host, allow some imbalance of virtual nodes
+				assert.GreaterOrEqual(t, churn, tt.optimal*0.5)
+				assert.LessOrEqual(t, churn, tt.optimal*1.5)
+			})
+		}
+	}
+}
+
+func TestHashringBalance(t *testing.T) {
+	hosts := testHosts(20)
+	for name, newRing := range ringImplementations {
+		t.Run(name, func(t *testing.T) {
+			counts := ownedShardCounts(shardOwnership(newRing(hosts), churnNumShards))
+			minCount, maxCount := churnNumShards, 0
+			for _, count := range counts {
+				minCount = min(minCount, count)
+				maxCount = max(maxCount, count)
+			}
+			t.Logf("shards per host: min %d, max %d, ideal %d", minCount, maxCount, churnNumShards/len(hosts))
+			assert.Len(t, counts, len(hosts))
+			assert.Less(t, float64(maxCount)/float64(minCount), 2.0)
+		})
+	}
+}
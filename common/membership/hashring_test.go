@@ -125,7 +125,7 @@ type hashringTestData struct {
 	observedLogs     *observer.ObservedLogs
 }
 
-func newHashringTestData(t *testing.T) *hashringTestData {
+func newHashringTestData(t *testing.T, opts ...HashringOption) *hashringTestData {
 	var td hashringTestData
 
 	ctrl := gomock.NewController(t)
@@ -142,6 +142,7 @@ func newHashringTestData(t *testing.T) *hashringTestData {
 		td.mockTimeSource,
 		logger,
 		metrics.NoopScope,
+		opts...,
 	)
 
 	return &td
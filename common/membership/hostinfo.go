@@ -43,6 +43,7 @@ type HostInfo struct {
 	ip       string // @todo should we set this to net.IP ?
 	identity string
 	portMap  PortMap // ports host is listening to
+	weight   int     // relative weight on the weighted hashring, 0 means default
 }
 
 // NewHostInfo creates a new HostInfo instance
@@ -74,6 +75,20 @@ func NewDetailedHostInfo(addr string, identity string, portMap PortMap) HostInfo
 	}
 }
 
+// WithWeight returns a copy of the host with its relative weight on the weighted hashring
+func (hi HostInfo) WithWeight(weight int) HostInfo {
+	hi.weight = weight
+	return hi
+}
+
+// Weight returns relative weight of the host, defaults to 1
+func (hi HostInfo) Weight() int {
+	if hi.weight <= 0 {
+		return 1
+	}
+	return hi.weight
+}
+
 // GetAddress returns the ip:port address
 func (hi HostInfo) GetAddress() string {
 	return hi.addr
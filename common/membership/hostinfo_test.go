@@ -56,3 +56,13 @@ func TestBelongs(t *testing.T) {
 	assert.False(t, belongs, "portmap has no such port, should return empty without an error")
 	assert.NoError(t, err)
 }
+
+func TestWeight(t *testing.T) {
+	host := NewDetailedHostInfo("127.0.0.1:1234", "dummy", PortMap{})
+	assert.Equal(t, 1, host.Weight(), "weight defaults to 1")
+
+	weighted := host.WithWeight(3)
+	assert.Equal(t, 3, weighted.Weight())
+	assert.Equal(t, 1, host.Weight(), "original host is not modified")
+	assert.Equal(t, host.GetAddress(), weighted.GetAddress())
+}
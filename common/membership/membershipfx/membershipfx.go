@@ -28,6 +28,7 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
@@ -42,6 +43,7 @@ type buildMembershipParams struct {
 	fx.In
 
 	Clock         clock.TimeSource
+	Config        config.Config
 	RPCFactory    rpc.Factory
 	PeerProvider  membership.PeerProvider
 	Logger        log.Logger
@@ -57,9 +59,10 @@ type buildMembershipResult struct {
 }
 
 func buildMembership(params buildMembershipParams) (buildMembershipResult, error) {
+	hashringOptions := params.Config.Membership.Hashring.Options()
 	rings := make(map[string]membership.SingleProvider)
 	for _, s := range service.ListWithRing {
-		rings[s] = membership.NewHashring(s, params.PeerProvider, params.Clock, params.Logger, params.MetricsClient.Scope(metrics.HashringScope))
+		rings[s] = membership.NewHashring(s, params.PeerProvider, params.Clock, params.Logger, params.MetricsClient.Scope(metrics.HashringScope), hashringOptions...)
 	}
 
 	resolver, err := membership.NewResolver(
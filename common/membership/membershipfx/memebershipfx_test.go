@@ -30,6 +30,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/membership"
@@ -54,6 +55,7 @@ func TestFxStartStop(t *testing.T) {
 		factory.EXPECT().Start(gomock.Any()).Return(nil)
 		return appParams{
 			Clock:         clock.NewMockedTimeSource(),
+			Config:        config.Config{},
 			PeerProvider:  provider,
 			Logger:        testlogger.New(t),
 			MetricsClient: metrics.NewNoopMetricsClient(),
@@ -67,6 +69,7 @@ type appParams struct {
 	fx.Out
 
 	Clock         clock.TimeSource
+	Config        config.Config
 	PeerProvider  membership.PeerProvider
 	Logger        log.Logger
 	MetricsClient metrics.Client
@@ -0,0 +1,110 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package membership
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/dgryski/go-farm"
+)
+
+type (
+	// HostCapacity overrides the weight a host is placed on the weighted hashring with
+	HostCapacity struct {
+		// Weight is relative to other hosts, a host with weight 2 owns twice as many keys as a host with weight 1
+		Weight int `yaml:"weight"`
+	}
+
+	// weightedHashring is a consistent hash ring which places replicaPoints virtual nodes per unit of host weight
+	weightedHashring struct {
+		points  []ringPoint
+		servers []string
+	}
+
+	ringPoint struct {
+		hash    uint32
+		address string
+	}
+)
+
+// newWeightedHashring creates a weighted hashring. Capacities are looked up by host IP and override
+// the weight reported by the peer provider
+func newWeightedHashring(members []HostInfo, capacities map[string]HostCapacity) *weightedHashring {
+	r := &weightedHashring{
+		servers: make([]string, 0, len(members)),
+	}
+
+	placed := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		address := member.GetAddress()
+		if _, ok := placed[address]; ok {
+			continue
+		}
+		placed[address] = struct{}{}
+
+		weight := member.Weight()
+		if capacity, ok := capacities[member.ip]; ok && capacity.Weight > 0 {
+			weight = capacity.Weight
+		}
+
+		r.servers = append(r.servers, address)
+		identity := member.Identity()
+		for i := 0; i < replicaPoints*weight; i++ {
+			r.points = append(r.points, ringPoint{
+				hash:    farm.Fingerprint32([]byte(identity + strconv.Itoa(i))),
+				address: address,
+			})
+		}
+	}
+
+	sort.Strings(r.servers)
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].address < r.points[j].address
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// Lookup returns the owner of a key, the host of the first virtual node at or after the hash of the key
+func (r *weightedHashring) Lookup(key string) (string, bool) {
+	if len(r.points) == 0 {
+		return "", false
+	}
+	hash := farm.Fingerprint32([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	return r.points[i%len(r.points)].address, true
+}
+
+// ServerCount returns the number of hosts on the ring
+func (r *weightedHashring) ServerCount() int {
+	return len(r.servers)
+}
+
+// Servers returns addresses of hosts on the ring
+func (r *weightedHashring) Servers() []string {
+	return slices.Clone(r.servers)
+}
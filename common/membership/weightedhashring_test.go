@@ -0,0 +1,115 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package membership
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHosts(n int) []HostInfo {
+	hosts := make([]HostInfo, 0, n)
+	for i := 0; i < n; i++ {
+		hosts = append(hosts, NewHostInfo(fmt.Sprintf("10.0.0.%d:7934", i+1)))
+	}
+	return hosts
+}
+
+func TestWeightedHashringEmpty(t *testing.T) {
+	ring := newWeightedHashring(nil, nil)
+
+	_, found := ring.Lookup("key")
+	assert.False(t, found)
+	assert.Equal(t, 0, ring.ServerCount())
+	assert.Empty(t, ring.Servers())
+}
+
+func TestWeightedHashringLookup(t *testing.T) {
+	hosts := testHosts(5)
+	ring := newWeightedHashring(hosts, nil)
+	assert.Equal(t, 5, ring.ServerCount())
+	assert.Equal(t, []string{"10.0.0.1:7934", "10.0.0.2:7934", "10.0.0.3:7934", "10.0.0.4:7934", "10.0.0.5:7934"}, ring.Servers())
+
+	// the same members always produce the same placement
+	other := newWeightedHashring([]HostInfo{hosts[4], hosts[3], hosts[2], hosts[1], hosts[0]}, nil)
+	for shardID := 0; shardID < 1000; shardID++ {
+		key := string(rune(shardID))
+		owner, found := ring.Lookup(key)
+		require.True(t, found)
+		otherOwner, _ := other.Lookup(key)
+		assert.Equal(t, owner, otherOwner)
+	}
+}
+
+func TestWeightedHashringWeights(t *testing.T) {
+	hosts := testHosts(4)
+	hosts[0] = hosts[0].WithWeight(3)
+	ring := newWeightedHashring(hosts, nil)
+
+	owned := shardOwnership(ring, 16384)
+	counts := ownedShardCounts(owned)
+
+	// host with weight 3 owns half of the shards, the rest is split evenly
+	assert.InDelta(t, 0.5, float64(counts[hosts[0].GetAddress()])/16384, 0.1)
+	for _, host := range hosts[1:] {
+		assert.InDelta(t, 1.0/6, float64(counts[host.GetAddress()])/16384, 0.07)
+	}
+}
+
+func TestWeightedHashringCapacitiesOverrideProvider(t *testing.T) {
+	hosts := testHosts(2)
+	hosts[0] = hosts[0].WithWeight(5)
+	ring := newWeightedHashring(hosts, map[string]HostCapacity{
+		"10.0.0.1": {Weight: 1},
+		"10.0.0.2": {Weight: 2},
+	})
+
+	assert.Len(t, ring.points, 3*replicaPoints)
+}
+
+func TestRingWithWeightedHashring(t *testing.T) {
+	td := newHashringTestData(t, WithWeightedHashring(nil))
+
+	hosts := testHosts(3)
+	td.mockPeerProvider.EXPECT().GetMembers("test-service").Return(hosts, nil)
+	td.mockPeerProvider.EXPECT().WhoAmI().AnyTimes()
+	require.NoError(t, td.hashRing.Refresh())
+
+	owner, err := td.hashRing.Lookup("key")
+	require.NoError(t, err)
+	assert.Contains(t, []string{"10.0.0.1:7934", "10.0.0.2:7934", "10.0.0.3:7934"}, owner.GetAddress())
+	assert.Equal(t, 3, td.hashRing.MemberCount())
+
+	// weight change of a known host rebuilds the ring
+	hosts[0] = hosts[0].WithWeight(2)
+	td.mockTimeSource.Advance(time.Minute)
+	td.mockPeerProvider.EXPECT().GetMembers("test-service").Return(hosts, nil)
+	require.NoError(t, td.hashRing.Refresh())
+	host, err := td.hashRing.AddressToHost(hosts[0].GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, 2, host.Weight())
+}
@@ -326,6 +326,8 @@ const (
 	HistoryClientResetQueueScope
 	// HistoryClientDescribeQueueScope tracks RPC calls to history service
 	HistoryClientDescribeQueueScope
+	// HistoryClientDescribeReplicationQueueScope tracks RPC calls to history service
+	HistoryClientDescribeReplicationQueueScope
 	// HistoryClientRecordActivityTaskHeartbeatScope tracks RPC calls to history service
 	HistoryClientRecordActivityTaskHeartbeatScope
 	// HistoryClientRespondDecisionTaskCompletedScope tracks RPC calls to history service
@@ -905,6 +907,10 @@ const (
 	AdminRefreshWorkflowTasksScope
 	// AdminResendReplicationTasksScope is the metric scope for admin.ResendReplicationTasks
 	AdminResendReplicationTasksScope
+	// AdminImportWorkflowExecutionScope is the metric scope for admin.ImportWorkflowExecution
+	AdminImportWorkflowExecutionScope
+	// AdminGetReplicationStatusScope is the metric scope for admin.GetReplicationStatus
+	AdminGetReplicationStatusScope
 	// AdminRemoveTaskScope is the metric scope for admin.AdminRemoveTaskScope
 	AdminRemoveTaskScope
 	// AdminCloseShardScope is the metric scope for admin.AdminCloseShardScope
@@ -997,14 +1003,22 @@ const (
 	FrontendPollForWorklfowExecutionRawHistoryScope
 	// FrontendSignalWorkflowExecutionScope is the metric scope for frontend.SignalWorkflowExecution
 	FrontendSignalWorkflowExecutionScope
+	// FrontendSignalWorkflowExecutionAsyncScope is the metric scope for frontend.SignalWorkflowExecutionAsync
+	FrontendSignalWorkflowExecutionAsyncScope
 	// FrontendSignalWithStartWorkflowExecutionScope is the metric scope for frontend.SignalWithStartWorkflowExecution
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendSignalWithStartWorkflowExecutionAsyncScope is the metric scope for frontend.SignalWithStartWorkflowExecutionAsync
 	FrontendSignalWithStartWorkflowExecutionAsyncScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
 	FrontendTerminateWorkflowExecutionScope
+	// FrontendTerminateWorkflowExecutionAsyncScope is the metric scope for frontend.TerminateWorkflowExecutionAsync
+	FrontendTerminateWorkflowExecutionAsyncScope
 	// FrontendRequestCancelWorkflowExecutionScope is the metric scope for frontend.RequestCancelWorkflowExecution
 	FrontendRequestCancelWorkflowExecutionScope
+	// FrontendRequestCancelWorkflowExecutionAsyncScope is the metric scope for frontend.RequestCancelWorkflowExecutionAsync
+	FrontendRequestCancelWorkflowExecutionAsyncScope
+	// FrontendDescribeAsyncRequestScope is the metric scope for frontend.DescribeAsyncRequest
+	FrontendDescribeAsyncRequestScope
 	// FrontendListArchivedWorkflowExecutionsScope is the metric scope for frontend.ListArchivedWorkflowExecutions
 	FrontendListArchivedWorkflowExecutionsScope
 	// FrontendListOpenWorkflowExecutionsScope is the metric scope for frontend.ListOpenWorkflowExecutions
@@ -1078,6 +1092,8 @@ const (
 	HistoryResetQueueScope
 	// HistoryDescribeQueueScope tracks DescribeQueue API calls received by service
 	HistoryDescribeQueueScope
+	// HistoryDescribeReplicationQueueScope tracks DescribeReplicationQueue API calls received by service
+	HistoryDescribeReplicationQueueScope
 	// HistoryDescribeMutabelStateScope tracks DescribeMutableState API calls received by service
 	HistoryDescribeMutabelStateScope
 	// HistoryGetMutableStateScope tracks GetMutableState API calls received by service
@@ -1551,6 +1567,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientCloseShardScope:                        {operation: "HistoryClientCloseShard", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientResetQueueScope:                        {operation: "HistoryClientResetQueue", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientDescribeQueueScope:                     {operation: "HistoryClientDescribeQueue", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientDescribeReplicationQueueScope:          {operation: "HistoryClientDescribeReplicationQueue", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientRecordActivityTaskHeartbeatScope:       {operation: "HistoryClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientRespondDecisionTaskCompletedScope:      {operation: "HistoryClientRespondDecisionTaskCompleted", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientRespondDecisionTaskFailedScope:         {operation: "HistoryClientRespondDecisionTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
@@ -1846,6 +1863,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminReapplyEventsScope:                     {operation: "ReapplyEvents"},
 		AdminRefreshWorkflowTasksScope:              {operation: "RefreshWorkflowTasks"},
 		AdminResendReplicationTasksScope:            {operation: "ResendReplicationTasks"},
+		AdminImportWorkflowExecutionScope:           {operation: "ImportWorkflowExecution"},
+		AdminGetReplicationStatusScope:              {operation: "GetReplicationStatus"},
 		AdminGetCrossClusterTasksScope:              {operation: "AdminGetCrossClusterTasks"},
 		AdminRespondCrossClusterTasksCompletedScope: {operation: "AdminRespondCrossClusterTasksCompleted"},
 		AdminGetDynamicConfigScope:                  {operation: "AdminGetDynamicConfig"},
@@ -1881,11 +1900,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendGetWorkflowExecutionRawHistoryScope:        {operation: "GetWorkflowExecutionRawHistory"},
 		FrontendPollForWorklfowExecutionRawHistoryScope:    {operation: "PollForWorklfowExecutionRawHistory"},
 		FrontendSignalWorkflowExecutionScope:               {operation: "SignalWorkflowExecution"},
+		FrontendSignalWorkflowExecutionAsyncScope:          {operation: "SignalWorkflowExecutionAsync"},
 		FrontendSignalWithStartWorkflowExecutionScope:      {operation: "SignalWithStartWorkflowExecution"},
 		FrontendSignalWithStartWorkflowExecutionAsyncScope: {operation: "SignalWithStartWorkflowExecutionAsync"},
 		FrontendTerminateWorkflowExecutionScope:            {operation: "TerminateWorkflowExecution"},
+		FrontendTerminateWorkflowExecutionAsyncScope:       {operation: "TerminateWorkflowExecutionAsync"},
 		FrontendResetWorkflowExecutionScope:                {operation: "ResetWorkflowExecution"},
 		FrontendRequestCancelWorkflowExecutionScope:        {operation: "RequestCancelWorkflowExecution"},
+		FrontendRequestCancelWorkflowExecutionAsyncScope:   {operation: "RequestCancelWorkflowExecutionAsync"},
+		FrontendDescribeAsyncRequestScope:                  {operation: "DescribeAsyncRequest"},
 		FrontendListArchivedWorkflowExecutionsScope:        {operation: "ListArchivedWorkflowExecutions"},
 		FrontendListOpenWorkflowExecutionsScope:            {operation: "ListOpenWorkflowExecutions"},
 		FrontendListClosedWorkflowExecutionsScope:          {operation: "ListClosedWorkflowExecutions"},
@@ -1921,6 +1944,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRespondActivityTaskCanceledScope:                         {operation: "RespondActivityTaskCanceled"},
 		HistoryResetQueueScope:                                          {operation: "ResetQueue"},
 		HistoryDescribeQueueScope:                                       {operation: "DescribeQueue"},
+		HistoryDescribeReplicationQueueScope:                            {operation: "DescribeReplicationQueue"},
 		HistoryDescribeMutabelStateScope:                                {operation: "DescribeMutableState"},
 		HistoryGetMutableStateScope:                                     {operation: "GetMutableState"},
 		HistoryPollMutableStateScope:                                    {operation: "PollMutableState"},
@@ -2343,6 +2367,8 @@ const (
 	GlobalRatelimiterHostLimitsQueried
 	GlobalRatelimiterRemovedLimits
 	GlobalRatelimiterRemovedHostLimits
+	GlobalRatelimiterShadowedLimits
+	GlobalRatelimiterShadowDivergence // absolute difference between primary and shadow weights, per host+limit
 
 	// p2p rpc metrics
 	P2PPeersCount
@@ -2618,6 +2644,11 @@ const (
 	ReplicationDLQProbeFailed
 	ReplicationDLQSize
 	ReplicationDLQValidationFailed
+	ReplicationDLQRedriveAttempts
+	ReplicationDLQRedriveSuccess
+	ReplicationDLQRedriveFailures
+	ReplicationDLQRedriveQuarantined
+	ReplicationDLQQuarantineSize
 	ReplicationMessageTooLargePerShard
 	GetReplicationMessagesForShardLatency
 	GetDLQReplicationMessagesLatency
@@ -3120,6 +3151,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		GlobalRatelimiterHostLimitsQueried: {metricName: "global_ratelimiter_host_limits_queried", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
 		GlobalRatelimiterRemovedLimits:     {metricName: "global_ratelimiter_removed_limits", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
 		GlobalRatelimiterRemovedHostLimits: {metricName: "global_ratelimiter_removed_host_limits", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
+		GlobalRatelimiterShadowedLimits:    {metricName: "global_ratelimiter_shadowed_limits", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
+		GlobalRatelimiterShadowDivergence:  {metricName: "global_ratelimiter_shadow_divergence", metricType: Histogram, buckets: GlobalRatelimiterWeightDivergenceHistogram},
 
 		P2PPeersCount:                        {metricName: "peers_count", metricType: Gauge},
 		P2PPeerAdded:                         {metricName: "peer_added", metricType: Counter},
@@ -3379,6 +3412,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationDLQProbeFailed:                                    {metricName: "replication_dlq_probe_failed", metricType: Counter},
 		ReplicationDLQSize:                                           {metricName: "replication_dlq_size", metricType: Gauge},
 		ReplicationDLQValidationFailed:                               {metricName: "replication_dlq_validation_failed", metricType: Counter},
+		ReplicationDLQRedriveAttempts:                                {metricName: "replication_dlq_redrive_attempts", metricType: Counter},
+		ReplicationDLQRedriveSuccess:                                 {metricName: "replication_dlq_redrive_success", metricType: Counter},
+		ReplicationDLQRedriveFailures:                                {metricName: "replication_dlq_redrive_failures", metricType: Counter},
+		ReplicationDLQRedriveQuarantined:                             {metricName: "replication_dlq_redrive_quarantined", metricType: Counter},
+		ReplicationDLQQuarantineSize:                                 {metricName: "replication_dlq_quarantine_size", metricType: Gauge},
 		ReplicationMessageTooLargePerShard:                           {metricName: "replication_message_too_large_per_shard", metricType: Counter},
 		GetReplicationMessagesForShardLatency:                        {metricName: "get_replication_messages_for_shard", metricType: Timer},
 		GetDLQReplicationMessagesLatency:                             {metricName: "get_dlq_replication_messages", metricType: Timer},
@@ -3732,6 +3770,14 @@ var GlobalRatelimiterUsageHistogram = append(
 	tally.MustMakeExponentialValueBuckets(1, 2, 17)..., // 1..65536
 )
 
+// GlobalRatelimiterWeightDivergenceHistogram contains buckets for the difference between two
+// host weights (which are always between 0 and 1) when comparing ratelimiter algorithms.
+var GlobalRatelimiterWeightDivergenceHistogram = tally.ValueBuckets{
+	0, // need an explicit 0 or zero is reported as 1
+	0.001, 0.005, 0.01, 0.025, 0.05, 0.075,
+	0.1, 0.15, 0.2, 0.3, 0.4, 0.5, 0.75, 1,
+}
+
 // ResponseRowSizeBuckets contains buckets for tracking how many rows are returned per persistence operation
 var ResponseRowSizeBuckets = append(
 	tally.ValueBuckets{0},                              // need an explicit 0 or zero is reported as 1
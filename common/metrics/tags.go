@@ -76,6 +76,8 @@ const (
 	globalRatelimitType           = "global_ratelimit_type"
 	globalRatelimitIsPrimary      = "is_primary"
 	globalRatelimitCollectionName = "global_ratelimit_collection"
+	globalRatelimitAlgorithm      = "global_ratelimit_algorithm"
+	globalRatelimitShadow         = "global_ratelimit_shadow_algorithm"
 
 	allValue     = "all"
 	unknownValue = "_unknown_"
@@ -304,6 +306,16 @@ func GlobalRatelimiterCollectionName(value string) Tag {
 	return simpleMetric{key: globalRatelimitCollectionName, value: value}
 }
 
+// GlobalRatelimiterAlgorithmTag reports the weighting algorithm used by an aggregator, e.g. "request-weighted".
+func GlobalRatelimiterAlgorithmTag(value string) Tag {
+	return metricWithUnknown(globalRatelimitAlgorithm, value)
+}
+
+// GlobalRatelimiterShadowAlgorithmTag reports the weighting algorithm being compared against the primary one.
+func GlobalRatelimiterShadowAlgorithmTag(value string) Tag {
+	return metricWithUnknown(globalRatelimitShadow, value)
+}
+
 // WorkflowTerminationReasonTag reports the reason for workflow termination
 func WorkflowTerminationReasonTag(value string) Tag {
 	value = safeAlphaNumericStringRE.ReplaceAllString(value, "_")
@@ -0,0 +1,150 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package otel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// ExporterOTLP exports metrics to an OpenTelemetry collector
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes metrics to stdout, it is meant for tests and local development
+	ExporterStdout = "stdout"
+
+	// ProtocolGRPC is the default protocol of the otlp exporter
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports metrics as protobuf over HTTP
+	ProtocolHTTP = "http"
+
+	// MeterName is the instrumentation name of the metrics reported by cadence
+	MeterName = "github.com/uber/cadence"
+
+	_defaultExportInterval = 10 * time.Second
+)
+
+// Configuration is the configuration of the OpenTelemetry metrics reporter
+type Configuration struct {
+	// Exporter is either "otlp" or "stdout", defaults to otlp
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the collector
+	Endpoint string `yaml:"endpoint"`
+	// Protocol is either "grpc" or "http", defaults to grpc
+	Protocol string `yaml:"protocol"`
+	// Insecure disables TLS when connecting to the collector
+	Insecure bool `yaml:"insecure"`
+	// Headers are sent with every export request, e.g. for authentication
+	Headers map[string]string `yaml:"headers"`
+	// Timeout is the timeout of an export request, defaults to 10s
+	Timeout time.Duration `yaml:"timeout"`
+	// ExportInterval is the interval metrics are exported at, defaults to 10s
+	ExportInterval time.Duration `yaml:"exportInterval"`
+}
+
+// Validate validates the configuration
+func (c *Configuration) Validate() error {
+	switch c.Exporter {
+	case "", ExporterOTLP:
+		if c.Endpoint == "" {
+			return fmt.Errorf("endpoint is not set")
+		}
+		if c.Protocol != "" && c.Protocol != ProtocolGRPC && c.Protocol != ProtocolHTTP {
+			return fmt.Errorf("unknown protocol %q", c.Protocol)
+		}
+	case ExporterStdout:
+	default:
+		return fmt.Errorf("unknown exporter %q", c.Exporter)
+	}
+	return nil
+}
+
+// NewReporter creates a meter provider which exports metrics as configured and
+// returns a tally reporter which records the metrics with it.
+// Closing the reporter shuts the meter provider down.
+func (c *Configuration) NewReporter(serviceName string, onError OnErrorFunc) (tally.StatsReporter, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	exporter, err := c.newExporter(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("create otel metrics exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("create otel metrics resource: %w", err)
+	}
+
+	interval := c.ExportInterval
+	if interval <= 0 {
+		interval = _defaultExportInterval
+	}
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
+		sdkmetric.WithResource(res),
+	)
+	return newReporter(provider.Meter(MeterName), onError, provider.Shutdown), nil
+}
+
+func (c *Configuration) newExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	if c.Exporter == ExporterStdout {
+		return stdoutmetric.New(stdoutmetric.WithWriter(os.Stdout))
+	}
+
+	if c.Protocol == ProtocolHTTP {
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(c.Endpoint)}
+		if c.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if len(c.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(c.Headers))
+		}
+		if c.Timeout > 0 {
+			opts = append(opts, otlpmetrichttp.WithTimeout(c.Timeout))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(c.Endpoint)}
+	if c.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(c.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(c.Headers))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(c.Timeout))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
@@ -0,0 +1,313 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package otel
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// defaultTimerBoundaries are the histogram bucket boundaries of timers in seconds, tally timers have no buckets.
+// The otel default boundaries are meant for milliseconds and would put nearly all latencies in the first bucket.
+var defaultTimerBoundaries = []float64{
+	0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60,
+}
+
+// OnErrorFunc is called when an instrument can not be created
+type OnErrorFunc func(err error)
+
+type (
+	reporter struct {
+		meter   metric.Meter
+		onError OnErrorFunc
+		closeFn func(context.Context) error
+
+		sync.RWMutex
+		counters   map[string]metric.Int64Counter
+		histograms map[string]metric.Float64Histogram
+		gauges     map[string]*gauge
+	}
+
+	// gauge keeps the last reported value of each tag set, tally gauges are
+	// reported once per interval while otel gauges are observed by the reader
+	gauge struct {
+		sync.Mutex
+		values map[attribute.Distinct]gaugeValue
+	}
+
+	gaugeValue struct {
+		attrs attribute.Set
+		value float64
+	}
+)
+
+var _ tally.StatsReporter = (*reporter)(nil)
+
+// NewReporter returns a tally reporter which records tally metrics with otel instruments created by meter:
+// counters are recorded as counters, gauges as gauges,
+// timers and histograms as histograms in seconds (durations) or in the unit of the values.
+// Histograms have the buckets of the tally histogram, timers have sub-second buckets.
+func NewReporter(meter metric.Meter, onError OnErrorFunc) tally.StatsReporter {
+	return newReporter(meter, onError, nil)
+}
+
+func newReporter(meter metric.Meter, onError OnErrorFunc, closeFn func(context.Context) error) *reporter {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	return &reporter{
+		meter:      meter,
+		onError:    onError,
+		closeFn:    closeFn,
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+		gauges:     make(map[string]*gauge),
+	}
+}
+
+func (r *reporter) ReportCounter(name string, tags map[string]string, value int64) {
+	counter, ok := r.counter(name)
+	if !ok {
+		return
+	}
+	counter.Add(context.Background(), value, metric.WithAttributeSet(toAttributeSet(tags)))
+}
+
+func (r *reporter) ReportGauge(name string, tags map[string]string, value float64) {
+	g, ok := r.gauge(name)
+	if !ok {
+		return
+	}
+	attrs := toAttributeSet(tags)
+	g.Lock()
+	g.values[attrs.Equivalent()] = gaugeValue{attrs: attrs, value: value}
+	g.Unlock()
+}
+
+func (r *reporter) ReportTimer(name string, tags map[string]string, interval time.Duration) {
+	histogram, ok := r.histogram(name, "s", defaultTimerBoundaries)
+	if !ok {
+		return
+	}
+	histogram.Record(context.Background(), interval.Seconds(), metric.WithAttributeSet(toAttributeSet(tags)))
+}
+
+func (r *reporter) ReportHistogramValueSamples(
+	name string,
+	tags map[string]string,
+	buckets tally.Buckets,
+	bucketLowerBound,
+	bucketUpperBound float64,
+	samples int64,
+) {
+	histogram, ok := r.histogram(name, "", boundaries(buckets.AsValues()))
+	if !ok {
+		return
+	}
+	recordSamples(histogram, toAttributeSet(tags), bucketLowerBound, bucketUpperBound, samples)
+}
+
+func (r *reporter) ReportHistogramDurationSamples(
+	name string,
+	tags map[string]string,
+	buckets tally.Buckets,
+	bucketLowerBound,
+	bucketUpperBound time.Duration,
+	samples int64,
+) {
+	histogram, ok := r.histogram(name, "s", durationBoundaries(buckets.AsDurations()))
+	if !ok {
+		return
+	}
+	upperBound := bucketUpperBound.Seconds()
+	if bucketUpperBound == time.Duration(math.MaxInt64) {
+		upperBound = math.Inf(1)
+	}
+	recordSamples(histogram, toAttributeSet(tags), bucketLowerBound.Seconds(), upperBound, samples)
+}
+
+func (r *reporter) Capabilities() tally.Capabilities {
+	return r
+}
+
+func (r *reporter) Reporting() bool {
+	return true
+}
+
+func (r *reporter) Tagging() bool {
+	return true
+}
+
+// Flush is a no-op, the metrics are exported periodically by the meter provider
+func (r *reporter) Flush() {}
+
+// Close shuts down the meter provider created for the reporter, which exports the remaining metrics
+func (r *reporter) Close() error {
+	if r.closeFn == nil {
+		return nil
+	}
+	return r.closeFn(context.Background())
+}
+
+func (r *reporter) counter(name string) (metric.Int64Counter, bool) {
+	r.RLock()
+	counter, ok := r.counters[name]
+	r.RUnlock()
+	if ok {
+		return counter, true
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if counter, ok := r.counters[name]; ok {
+		return counter, true
+	}
+	counter, err := r.meter.Int64Counter(name)
+	if err != nil {
+		r.onError(err)
+		return nil, false
+	}
+	r.counters[name] = counter
+	return counter, true
+}
+
+// histogram returns the histogram of name, boundaries are only used when it is created
+func (r *reporter) histogram(name string, unit string, boundaries []float64) (metric.Float64Histogram, bool) {
+	r.RLock()
+	histogram, ok := r.histograms[name]
+	r.RUnlock()
+	if ok {
+		return histogram, true
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if histogram, ok := r.histograms[name]; ok {
+		return histogram, true
+	}
+	var opts []metric.Float64HistogramOption
+	if unit != "" {
+		opts = append(opts, metric.WithUnit(unit))
+	}
+	if len(boundaries) > 0 {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(boundaries...))
+	}
+	histogram, err := r.meter.Float64Histogram(name, opts...)
+	if err != nil {
+		r.onError(err)
+		return nil, false
+	}
+	r.histograms[name] = histogram
+	return histogram, true
+}
+
+func (r *reporter) gauge(name string) (*gauge, bool) {
+	r.RLock()
+	g, ok := r.gauges[name]
+	r.RUnlock()
+	if ok {
+		return g, true
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if g, ok := r.gauges[name]; ok {
+		return g, true
+	}
+	g = &gauge{values: make(map[attribute.Distinct]gaugeValue)}
+	_, err := r.meter.Float64ObservableGauge(name, metric.WithFloat64Callback(g.observe))
+	if err != nil {
+		r.onError(err)
+		return nil, false
+	}
+	r.gauges[name] = g
+	return g, true
+}
+
+func (g *gauge) observe(_ context.Context, observer metric.Float64Observer) error {
+	g.Lock()
+	defer g.Unlock()
+	for _, v := range g.values {
+		observer.Observe(v.value, metric.WithAttributeSet(v.attrs))
+	}
+	return nil
+}
+
+// recordSamples records a value once per sample of a tally bucket.
+// Buckets are recorded as their upper bound, which falls in the same otel bucket as otel upper bounds are inclusive.
+// The last bucket has no upper bound and is recorded just above its lower bound, so it falls in the otel overflow bucket.
+func recordSamples(histogram metric.Float64Histogram, attrs attribute.Set, lowerBound, upperBound float64, samples int64) {
+	value := upperBound
+	if upperBound == math.MaxFloat64 || math.IsInf(upperBound, 1) {
+		value = math.Nextafter(lowerBound, math.Inf(1))
+	}
+	opt := metric.WithAttributeSet(attrs)
+	for i := int64(0); i < samples; i++ {
+		histogram.Record(context.Background(), value, opt)
+	}
+}
+
+// boundaries returns the finite tally bucket bounds in strictly increasing order, as otel requires
+func boundaries(values []float64) []float64 {
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if math.IsInf(v, 0) || math.IsNaN(v) || v == math.MaxFloat64 || v == -math.MaxFloat64 {
+			continue
+		}
+		finite = append(finite, v)
+	}
+	sort.Float64s(finite)
+	result := make([]float64, 0, len(finite))
+	for _, v := range finite {
+		if len(result) == 0 || v != result[len(result)-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func durationBoundaries(durations []time.Duration) []float64 {
+	values := make([]float64, 0, len(durations))
+	for _, d := range durations {
+		if d == time.Duration(math.MaxInt64) || d == time.Duration(math.MinInt64) {
+			continue
+		}
+		values = append(values, d.Seconds())
+	}
+	return boundaries(values)
+}
+
+func toAttributeSet(tags map[string]string) attribute.Set {
+	attrs := make([]attribute.KeyValue, 0, len(tags))
+	for k, v := range tags {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attribute.NewSet(attrs...)
+}
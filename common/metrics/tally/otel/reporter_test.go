@@ -0,0 +1,157 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package otel
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newTestReporter(t *testing.T) (tally.StatsReporter, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return NewReporter(provider.Meter(MeterName), func(err error) { t.Error(err) }), reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	result := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+func TestReporter(t *testing.T) {
+	reporter, reader := newTestReporter(t)
+	tags := map[string]string{"operation": "test"}
+	attrs := attribute.NewSet(attribute.String("operation", "test"))
+
+	reporter.ReportCounter("requests", tags, 2)
+	reporter.ReportCounter("requests", tags, 3)
+	reporter.ReportGauge("queue_size", tags, 5)
+	reporter.ReportGauge("queue_size", tags, 7)
+	reporter.ReportTimer("latency", tags, 500*time.Millisecond)
+	reporter.ReportHistogramValueSamples("history_size", tags, tally.ValueBuckets{10, 100}, 10, 100, 2)
+	reporter.ReportHistogramDurationSamples("wait", tags, tally.DurationBuckets{time.Second}, time.Second, time.Duration(math.MaxInt64), 1)
+
+	data := collect(t, reader)
+
+	counter, ok := data["requests"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, counter.DataPoints, 1)
+	assert.Equal(t, int64(5), counter.DataPoints[0].Value)
+	assert.Equal(t, attrs, counter.DataPoints[0].Attributes)
+
+	gauge, ok := data["queue_size"].(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, float64(7), gauge.DataPoints[0].Value)
+
+	timer, ok := data["latency"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, timer.DataPoints, 1)
+	assert.Equal(t, uint64(1), timer.DataPoints[0].Count)
+	assert.Equal(t, 0.5, timer.DataPoints[0].Sum)
+	assert.Equal(t, defaultTimerBoundaries, timer.DataPoints[0].Bounds)
+	assert.Equal(t, uint64(1), timer.DataPoints[0].BucketCounts[8], "0.5s falls in the (0.25, 0.5] bucket")
+
+	histogram, ok := data["history_size"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, uint64(2), histogram.DataPoints[0].Count)
+	assert.Equal(t, float64(200), histogram.DataPoints[0].Sum)
+	assert.Equal(t, []float64{10, 100}, histogram.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 2, 0}, histogram.DataPoints[0].BucketCounts)
+
+	durations, ok := data["wait"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, durations.DataPoints, 1)
+	assert.InDelta(t, float64(1), durations.DataPoints[0].Sum, 1e-9, "last bucket is recorded just above its lower bound")
+	assert.Equal(t, []float64{1}, durations.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 1}, durations.DataPoints[0].BucketCounts, "last bucket falls in the overflow bucket")
+}
+
+func TestBoundaries(t *testing.T) {
+	assert.Equal(t, []float64{1, 5, 10}, boundaries([]float64{10, 1, 5, 5, math.MaxFloat64, math.Inf(1), -math.MaxFloat64}))
+	assert.Empty(t, boundaries(nil))
+	assert.Equal(t, []float64{0.01, 1}, durationBoundaries([]time.Duration{time.Second, 10 * time.Millisecond, time.Duration(math.MaxInt64)}))
+}
+
+func TestReporterCapabilities(t *testing.T) {
+	reporter, _ := newTestReporter(t)
+	assert.True(t, reporter.Capabilities().Reporting())
+	assert.True(t, reporter.Capabilities().Tagging())
+}
+
+func TestConfigurationValidate(t *testing.T) {
+	tests := map[string]struct {
+		config  Configuration
+		wantErr bool
+	}{
+		"otlp": {
+			config: Configuration{Endpoint: "localhost:4317"},
+		},
+		"otlp over http": {
+			config: Configuration{Exporter: ExporterOTLP, Endpoint: "localhost:4318", Protocol: ProtocolHTTP},
+		},
+		"stdout": {
+			config: Configuration{Exporter: ExporterStdout},
+		},
+		"missing endpoint": {
+			config:  Configuration{Exporter: ExporterOTLP},
+			wantErr: true,
+		},
+		"unknown protocol": {
+			config:  Configuration{Endpoint: "localhost:4317", Protocol: "udp"},
+			wantErr: true,
+		},
+		"unknown exporter": {
+			config:  Configuration{Exporter: "prometheus"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
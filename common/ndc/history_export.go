@@ -0,0 +1,118 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ndc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+)
+
+const (
+	// HistoryExportFormatVersion is the version of the history export envelope written by this package
+	HistoryExportFormatVersion = 1
+)
+
+type (
+	// HistoryExport is a portable copy of a single workflow run's history.
+	// Events are stored in batches, the same way they were written to the source cluster,
+	// each batch encoded with EventEncoding.
+	HistoryExport struct {
+		FormatVersion  int                    `json:"formatVersion"`
+		Domain         string                 `json:"domain"`
+		WorkflowID     string                 `json:"workflowId"`
+		RunID          string                 `json:"runId"`
+		EventEncoding  constants.EncodingType `json:"eventEncoding"`
+		VersionHistory *types.VersionHistory  `json:"versionHistory,omitempty"`
+		Batches        [][]byte               `json:"batches"`
+	}
+)
+
+var historySerializer = persistence.NewPayloadSerializer()
+
+// WriteHistoryExport writes the export envelope as JSON
+func WriteHistoryExport(w io.Writer, export *HistoryExport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// ReadHistoryExport reads and validates an export envelope written by WriteHistoryExport
+func ReadHistoryExport(r io.Reader) (*HistoryExport, error) {
+	var export HistoryExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("decoding history export: %w", err)
+	}
+	if export.FormatVersion != HistoryExportFormatVersion {
+		return nil, fmt.Errorf("unsupported history export format version %v, expected %v", export.FormatVersion, HistoryExportFormatVersion)
+	}
+	if export.WorkflowID == "" || export.RunID == "" {
+		return nil, fmt.Errorf("history export is missing the workflow ID or run ID")
+	}
+	if len(export.Batches) == 0 {
+		return nil, fmt.Errorf("history export has no events")
+	}
+	return &export, nil
+}
+
+// EncodeHistoryBatch encodes one batch of events for a history export
+func EncodeHistoryBatch(events []*types.HistoryEvent, encoding constants.EncodingType) ([]byte, error) {
+	switch encoding {
+	case constants.EncodingTypeThriftRW:
+		blob, err := historySerializer.SerializeBatchEvents(events, encoding)
+		if err != nil {
+			return nil, err
+		}
+		return blob.Data, nil
+	case constants.EncodingTypeProto:
+		return proto.FromHistory(&types.History{Events: events}).Marshal()
+	default:
+		return nil, fmt.Errorf("unsupported history export encoding %q", encoding)
+	}
+}
+
+// DecodeHistoryBatch decodes one batch of events from a history export
+func DecodeHistoryBatch(data []byte, encoding constants.EncodingType) ([]*types.HistoryEvent, error) {
+	switch encoding {
+	case constants.EncodingTypeThriftRW:
+		return historySerializer.DeserializeBatchEvents(&persistence.DataBlob{
+			Encoding: encoding,
+			Data:     data,
+		})
+	case constants.EncodingTypeProto:
+		var history apiv1.History
+		if err := history.Unmarshal(data); err != nil {
+			return nil, err
+		}
+		return proto.ToHistory(&history).GetEvents(), nil
+	default:
+		return nil, fmt.Errorf("unsupported history export encoding %q", encoding)
+	}
+}
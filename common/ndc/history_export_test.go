@@ -0,0 +1,110 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ndc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestHistoryBatchRoundTrip(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			Version:   10,
+			Timestamp: common.Int64Ptr(1000),
+			EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &types.WorkflowType{Name: "workflow-type"},
+				TaskList:     &types.TaskList{Name: "task-list"},
+			},
+		},
+		{
+			ID:        2,
+			Version:   10,
+			Timestamp: common.Int64Ptr(1000),
+			EventType: types.EventTypeDecisionTaskScheduled.Ptr(),
+			DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{
+				TaskList: &types.TaskList{Name: "task-list"},
+			},
+		},
+	}
+
+	for _, encoding := range []constants.EncodingType{constants.EncodingTypeThriftRW, constants.EncodingTypeProto} {
+		t.Run(string(encoding), func(t *testing.T) {
+			data, err := EncodeHistoryBatch(events, encoding)
+			require.NoError(t, err)
+			decoded, err := DecodeHistoryBatch(data, encoding)
+			require.NoError(t, err)
+			assert.Equal(t, len(events), len(decoded))
+			for i := range events {
+				assert.Equal(t, events[i].ID, decoded[i].ID)
+				assert.Equal(t, events[i].Version, decoded[i].Version)
+				assert.Equal(t, events[i].GetEventType(), decoded[i].GetEventType())
+			}
+		})
+	}
+
+	_, err := EncodeHistoryBatch(events, constants.EncodingTypeGob)
+	assert.Error(t, err)
+}
+
+func TestHistoryExportReadWrite(t *testing.T) {
+	export := &HistoryExport{
+		FormatVersion: HistoryExportFormatVersion,
+		Domain:        "domain",
+		WorkflowID:    "workflow-id",
+		RunID:         "run-id",
+		EventEncoding: constants.EncodingTypeThriftRW,
+		VersionHistory: &types.VersionHistory{
+			Items: []*types.VersionHistoryItem{{EventID: 2, Version: 10}},
+		},
+		Batches: [][]byte{{1, 2, 3}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHistoryExport(&buf, export))
+	read, err := ReadHistoryExport(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, export, read)
+
+	tests := map[string]string{
+		"not json":           "{",
+		"unknown version":    `{"formatVersion": 2, "workflowId": "w", "runId": "r", "batches": ["AQ=="]}`,
+		"missing run":        `{"formatVersion": 1, "workflowId": "w", "batches": ["AQ=="]}`,
+		"missing the events": `{"formatVersion": 1, "workflowId": "w", "runId": "r"}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadHistoryExport(bytes.NewBufferString(content))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ndc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// HistoryImporter recreates a workflow run from a history export
+	HistoryImporter interface {
+		// ImportWorkflowHistory replays the exported events into the given domain through the replication path.
+		// Event versions are rewritten to the domain's failover version, so the imported run belongs to this cluster.
+		ImportWorkflowHistory(
+			ctx context.Context,
+			domainID string,
+			export *HistoryExport,
+		) error
+	}
+
+	historyImporterImpl struct {
+		domainCache          cache.DomainCache
+		historyReplicationFn nDCHistoryReplicationFn
+		logger               log.Logger
+	}
+)
+
+var _ HistoryImporter = (*historyImporterImpl)(nil)
+
+// NewHistoryImporter creates a new HistoryImporter
+func NewHistoryImporter(
+	domainCache cache.DomainCache,
+	historyReplicationFn nDCHistoryReplicationFn,
+	logger log.Logger,
+) HistoryImporter {
+
+	return &historyImporterImpl{
+		domainCache:          domainCache,
+		historyReplicationFn: historyReplicationFn,
+		logger:               logger.WithTags(tag.ComponentHistoryImporter),
+	}
+}
+
+func (n *historyImporterImpl) ImportWorkflowHistory(
+	ctx context.Context,
+	domainID string,
+	export *HistoryExport,
+) error {
+
+	domainEntry, err := n.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+	if !domainEntry.IsGlobalDomain() {
+		// replicated events need a failover version that maps to a cluster
+		return &types.BadRequestError{Message: "History can only be imported into a global domain."}
+	}
+	version := domainEntry.GetFailoverVersion()
+
+	batches := make([][]*types.HistoryEvent, 0, len(export.Batches))
+	nextEventID := constants.FirstEventID
+	for _, data := range export.Batches {
+		events, err := DecodeHistoryBatch(data, export.EventEncoding)
+		if err != nil {
+			return &types.BadRequestError{Message: fmt.Sprintf("Invalid history export: %v", err)}
+		}
+		if len(events) == 0 {
+			return &types.BadRequestError{Message: "Invalid history export: empty event batch."}
+		}
+		for _, event := range events {
+			if event.ID != nextEventID {
+				return &types.BadRequestError{
+					Message: fmt.Sprintf("Invalid history export: expected event ID %v, got %v.", nextEventID, event.ID),
+				}
+			}
+			event.Version = version
+			nextEventID++
+		}
+		batches = append(batches, events)
+	}
+	if len(batches) == 0 {
+		return &types.BadRequestError{Message: "Invalid history export: no events."}
+	}
+	if batches[0][0].GetEventType() != types.EventTypeWorkflowExecutionStarted {
+		return &types.BadRequestError{Message: "Invalid history export: history does not start with WorkflowExecutionStarted."}
+	}
+
+	// all events now share a single version, so the version history collapses into one item
+	versionHistoryItems := []*types.VersionHistoryItem{
+		{
+			EventID: nextEventID - 1,
+			Version: version,
+		},
+	}
+	for _, events := range batches {
+		blob, err := historySerializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+		if err != nil {
+			return err
+		}
+		if err := n.sendReplicationRawRequest(ctx, &types.ReplicateEventsV2Request{
+			DomainUUID: domainID,
+			WorkflowExecution: &types.WorkflowExecution{
+				WorkflowID: export.WorkflowID,
+				RunID:      export.RunID,
+			},
+			VersionHistoryItems: versionHistoryItems,
+			Events:              blob.ToInternal(),
+		}); err != nil {
+			n.logger.Error("failed to import history events",
+				tag.WorkflowDomainID(domainID),
+				tag.WorkflowID(export.WorkflowID),
+				tag.WorkflowRunID(export.RunID),
+				tag.WorkflowFirstEventID(events[0].ID),
+				tag.Error(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *historyImporterImpl) sendReplicationRawRequest(
+	ctx context.Context,
+	request *types.ReplicateEventsV2Request,
+) error {
+
+	ctx, cancel := context.WithTimeout(ctx, resendContextTimeout)
+	defer cancel()
+	return n.historyReplicationFn(ctx, request)
+}
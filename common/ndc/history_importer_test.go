@@ -0,0 +1,152 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ndc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestImportWorkflowHistory(t *testing.T) {
+	domainID := uuid.New()
+	runID := uuid.New()
+	globalDomain := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: domainID, Name: "domain"},
+		&persistence.DomainConfig{Retention: 1},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+			},
+		},
+		1234,
+	)
+	localDomain := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: domainID, Name: "domain"},
+		&persistence.DomainConfig{Retention: 1},
+		cluster.TestCurrentClusterName,
+	)
+
+	newExport := func(t *testing.T, batches ...[]*types.HistoryEvent) *HistoryExport {
+		export := &HistoryExport{
+			FormatVersion: HistoryExportFormatVersion,
+			WorkflowID:    "workflow-id",
+			RunID:         runID,
+			EventEncoding: constants.EncodingTypeProto,
+		}
+		for _, events := range batches {
+			data, err := EncodeHistoryBatch(events, export.EventEncoding)
+			require.NoError(t, err)
+			export.Batches = append(export.Batches, data)
+		}
+		return export
+	}
+	started := &types.HistoryEvent{ID: 1, Version: 7, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}}
+	scheduled := &types.HistoryEvent{ID: 2, Version: 7, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}}
+	started2 := &types.HistoryEvent{ID: 3, Version: 8, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}}
+
+	tests := map[string]struct {
+		domainEntry *cache.DomainCacheEntry
+		batches     [][]*types.HistoryEvent
+		expectedErr string
+		// expected first event ID of every replicated batch
+		expectedBatches []int64
+	}{
+		"success": {
+			domainEntry:     globalDomain,
+			batches:         [][]*types.HistoryEvent{{started, scheduled}, {started2}},
+			expectedBatches: []int64{1, 3},
+		},
+		"local domain": {
+			domainEntry: localDomain,
+			batches:     [][]*types.HistoryEvent{{started, scheduled}},
+			expectedErr: "History can only be imported into a global domain.",
+		},
+		"no events": {
+			domainEntry: globalDomain,
+			expectedErr: "Invalid history export: no events.",
+		},
+		"gap in event IDs": {
+			domainEntry: globalDomain,
+			batches:     [][]*types.HistoryEvent{{started}, {started2}},
+			expectedErr: "Invalid history export: expected event ID 2, got 3.",
+		},
+		"does not start with workflow started": {
+			domainEntry: globalDomain,
+			batches: [][]*types.HistoryEvent{{
+				{ID: 1, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+			}},
+			expectedErr: "Invalid history export: history does not start with WorkflowExecutionStarted.",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainByID(domainID).Return(tc.domainEntry, nil)
+
+			var requests []*types.ReplicateEventsV2Request
+			importer := NewHistoryImporter(
+				domainCache,
+				func(ctx context.Context, request *types.ReplicateEventsV2Request) error {
+					requests = append(requests, request)
+					return nil
+				},
+				testlogger.New(t),
+			)
+
+			err := importer.ImportWorkflowHistory(context.Background(), domainID, newExport(t, tc.batches...))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Empty(t, requests)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, requests, len(tc.expectedBatches))
+			for i, request := range requests {
+				assert.Equal(t, domainID, request.DomainUUID)
+				assert.Equal(t, &types.WorkflowExecution{WorkflowID: "workflow-id", RunID: runID}, request.WorkflowExecution)
+				assert.Equal(t, []*types.VersionHistoryItem{{EventID: 3, Version: 1234}}, request.VersionHistoryItems)
+				events, err := historySerializer.DeserializeBatchEvents(persistence.NewDataBlobFromInternal(request.Events))
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedBatches[i], events[0].ID)
+				for _, event := range events {
+					assert.Equal(t, int64(1234), event.Version)
+				}
+			}
+		})
+	}
+}
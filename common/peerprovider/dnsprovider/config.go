@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dnsprovider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/common/membership"
+)
+
+// ConfigKey is the key of DNS provider config under membership.provider
+const ConfigKey = "dns"
+
+const (
+	// RecordTypeSRV resolves hosts with their tchannel port from an SRV record
+	RecordTypeSRV = "srv"
+	// RecordTypeA resolves host addresses from an A or AAAA record, ports are taken from the config
+	RecordTypeA = "a"
+
+	defaultRefreshInterval = 10 * time.Second
+)
+
+type (
+	// Config contains the DNS peer provider config items
+	Config struct {
+		// RefreshInterval is how often records are resolved, defaults to 10s
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+		// BroadcastAddress is the address peers reach this host with, it has to match the resolved address.
+		// If not set, the listen address will be used as broadcast address.
+		BroadcastAddress string `yaml:"broadcastAddress"`
+		// Services maps a service name, e.g. cadence-frontend, to the record its hosts are resolved from
+		Services map[string]Record `yaml:"services"`
+	}
+
+	// Record describes how hosts of a service are resolved
+	Record struct {
+		// Type is either "srv" or "a", defaults to srv
+		Type string `yaml:"type"`
+		// Name is the record to resolve. SRV records are looked up by their full name,
+		// e.g. _tchannel._tcp.cadence-frontend.example.com
+		Name string `yaml:"name"`
+		// Ports are the ports hosts are listening to. Tchannel port is required for A records,
+		// for SRV records it is taken from the record itself
+		Ports membership.PortMap `yaml:"ports"`
+	}
+)
+
+func (c *Config) validate() error {
+	if len(c.Services) == 0 {
+		return fmt.Errorf("dns peer provider config missing `services` param")
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = defaultRefreshInterval
+	}
+	if c.BroadcastAddress != "" && net.ParseIP(c.BroadcastAddress) == nil {
+		return fmt.Errorf("failed parsing broadcast address %q", c.BroadcastAddress)
+	}
+
+	for service, record := range c.Services {
+		if record.Name == "" {
+			return fmt.Errorf("dns peer provider record of %q missing `name` param", service)
+		}
+		switch strings.ToLower(record.Type) {
+		case "", RecordTypeSRV:
+		case RecordTypeA:
+			if _, ok := record.Ports[membership.PortTchannel]; !ok {
+				return fmt.Errorf("dns peer provider record of %q missing tchannel port", service)
+			}
+		default:
+			return fmt.Errorf("dns peer provider record of %q has unknown type %q", service, record.Type)
+		}
+	}
+	return nil
+}
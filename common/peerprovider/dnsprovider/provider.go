@@ -0,0 +1,202 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dnsprovider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/dns"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/peerprovider"
+)
+
+type (
+	// Provider resolves hosts of every service from DNS records on an interval
+	Provider struct {
+		status    int32
+		config    *Config
+		container peerprovider.Container
+		resolver  dns.Resolver
+		members   *peerprovider.Members
+		logger    log.Logger
+		wg        sync.WaitGroup
+		ctx       context.Context
+		cancel    context.CancelFunc
+	}
+)
+
+var _ membership.PeerProvider = (*Provider)(nil)
+
+// Register registers DNS provider as the peer provider plugin
+func Register() error {
+	return peerprovider.Register(ConfigKey, func(cfg *config.YamlNode, container peerprovider.Container) (membership.PeerProvider, error) {
+		var c Config
+		if err := cfg.Decode(&c); err != nil {
+			return nil, fmt.Errorf("decoding dns peer provider config: %w", err)
+		}
+		return New(&c, container)
+	})
+}
+
+// New creates DNS provider using the default resolver
+func New(config *Config, container peerprovider.Container) (*Provider, error) {
+	return newProvider(config, container, net.DefaultResolver)
+}
+
+func newProvider(config *Config, container peerprovider.Container, resolver dns.Resolver) (*Provider, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Provider{
+		status:    common.DaemonStatusInitialized,
+		config:    config,
+		container: container,
+		resolver:  resolver,
+		members:   peerprovider.NewMembers(),
+		logger:    container.Logger.WithTags(tag.ComponentPeerProvider),
+		ctx:       ctx,
+		cancel:    cancel,
+	}, nil
+}
+
+// Start resolves records once and keeps refreshing them in background
+func (p *Provider) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&p.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	// resolve synchronously, so hashrings see the hosts right after start
+	p.refresh()
+
+	p.wg.Add(1)
+	go p.refreshLoop()
+}
+
+// Stop stops refreshing records
+func (p *Provider) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&p.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	p.cancel()
+	p.wg.Wait()
+}
+
+// GetMembers returns the last resolved hosts of a service
+func (p *Provider) GetMembers(service string) ([]membership.HostInfo, error) {
+	return p.members.GetMembers(service)
+}
+
+// WhoAmI returns address of this instance
+func (p *Provider) WhoAmI() (membership.HostInfo, error) {
+	return p.container.WhoAmI(p.config.BroadcastAddress)
+}
+
+// SelfEvict is a noop, hosts are removed once DNS stops returning them
+func (p *Provider) SelfEvict() error {
+	return nil
+}
+
+// Subscribe allows to be subscribed for host changes
+func (p *Provider) Subscribe(name string, handler func(membership.ChangedEvent)) error {
+	return p.members.Subscribe(name, handler)
+}
+
+func (p *Provider) refreshLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.refresh()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh resolves records of all services. Hosts of a service are kept as they are if its record fails to resolve,
+// so a temporary DNS failure does not empty the hashring.
+func (p *Provider) refresh() {
+	hosts := make(map[string][]membership.HostInfo, len(p.config.Services))
+	for service, record := range p.config.Services {
+		serviceHosts, err := p.resolve(record)
+		if err != nil {
+			p.logger.Error("Failed to resolve peers by DNS lookup", tag.Service(service), tag.Address(record.Name), tag.Error(err))
+			serviceHosts, _ = p.members.GetMembers(service)
+		}
+		hosts[service] = serviceHosts
+	}
+
+	if change, changed := p.members.Update(hosts); changed {
+		p.logger.Info("DNS peer provider hosts changed", tag.MembershipChangeEvent(change))
+	}
+}
+
+func (p *Provider) resolve(record Record) ([]membership.HostInfo, error) {
+	var addrs []dns.Address
+	var err error
+	if strings.ToLower(record.Type) == RecordTypeA {
+		addrs, err = dns.LookupHost(p.ctx, p.resolver, record.Name, record.Ports[membership.PortTchannel])
+	} else {
+		addrs, err = dns.LookupSRV(p.ctx, p.resolver, record.Name, p.logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]membership.HostInfo, 0, len(addrs))
+	for _, addr := range addrs {
+		// the tchannel port of SRV records is the port of the record
+		portMap := membership.PortMap{membership.PortTchannel: addr.Port}
+		for name, port := range record.Ports {
+			if name != membership.PortTchannel {
+				portMap[name] = port
+			}
+		}
+		hosts = append(hosts, membership.NewDetailedHostInfo(addr.String(), addr.String(), portMap))
+	}
+	return hosts, nil
+}
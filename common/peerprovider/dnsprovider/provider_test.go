@@ -0,0 +1,185 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dnsprovider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/peerprovider"
+)
+
+type fakeResolver struct {
+	sync.Mutex
+	hosts map[string][]string
+	srv   map[string][]*net.SRV
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.Lock()
+	defer r.Unlock()
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, fmt.Errorf("host was not resolved: %s", host)
+	}
+	return addrs, nil
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.Lock()
+	defer r.Unlock()
+	srvs, ok := r.srv[name]
+	if !ok {
+		return "", nil, fmt.Errorf("srv was not resolved: %s", name)
+	}
+	return name, srvs, nil
+}
+
+func newTestProvider(t *testing.T, resolver *fakeResolver) *Provider {
+	p, err := newProvider(&Config{
+		BroadcastAddress: "10.0.0.1",
+		Services: map[string]Record{
+			"cadence-frontend": {
+				Name:  "_tchannel._tcp.frontend.example.com",
+				Ports: membership.PortMap{membership.PortGRPC: 7833},
+			},
+			"cadence-history": {
+				Type:  RecordTypeA,
+				Name:  "history.example.com",
+				Ports: membership.PortMap{membership.PortTchannel: 7934},
+			},
+		},
+	}, peerprovider.Container{
+		Service: "cadence-frontend",
+		Logger:  testlogger.New(t),
+		Portmap: membership.PortMap{membership.PortTchannel: 7933},
+	}, resolver)
+	require.NoError(t, err)
+	return p
+}
+
+func TestProvider(t *testing.T) {
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"frontend-0.example.com": {"10.0.0.1"},
+			"frontend-1.example.com": {"10.0.0.2"},
+			"history.example.com":    {"10.0.1.1", "10.0.1.2"},
+		},
+		srv: map[string][]*net.SRV{
+			"_tchannel._tcp.frontend.example.com": {
+				{Target: "frontend-0.example.com", Port: 7933},
+				{Target: "frontend-1.example.com", Port: 7933},
+				{Target: "unknown.example.com", Port: 7933},
+			},
+		},
+	}
+	p := newTestProvider(t, resolver)
+	p.Start()
+	defer p.Stop()
+
+	frontend, err := p.GetMembers("cadence-frontend")
+	require.NoError(t, err)
+	require.Len(t, frontend, 2)
+	assert.Equal(t, "10.0.0.1:7933", frontend[0].GetAddress())
+	grpcAddress, err := frontend[0].GetNamedAddress(membership.PortGRPC)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:7833", grpcAddress)
+
+	history, err := p.GetMembers("cadence-history")
+	require.NoError(t, err)
+	assert.Equal(t, []membership.HostInfo{
+		membership.NewDetailedHostInfo("10.0.1.1:7934", "10.0.1.1:7934", membership.PortMap{membership.PortTchannel: 7934}),
+		membership.NewDetailedHostInfo("10.0.1.2:7934", "10.0.1.2:7934", membership.PortMap{membership.PortTchannel: 7934}),
+	}, history)
+
+	self, err := p.WhoAmI()
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:7933", self.GetAddress())
+
+	var event membership.ChangedEvent
+	require.NoError(t, p.Subscribe("test", func(e membership.ChangedEvent) { event = e }))
+
+	resolver.Lock()
+	resolver.hosts["history.example.com"] = []string{"10.0.1.2"}
+	delete(resolver.srv, "_tchannel._tcp.frontend.example.com")
+	resolver.Unlock()
+	p.refresh()
+
+	assert.Equal(t, []string{"10.0.1.1:7934"}, event.HostsRemoved)
+	frontend, err = p.GetMembers("cadence-frontend")
+	require.NoError(t, err)
+	assert.Len(t, frontend, 2, "hosts are kept when the record fails to resolve")
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config  Config
+		wantErr bool
+	}{
+		"srv": {
+			config: Config{Services: map[string]Record{"cadence-frontend": {Name: "_tchannel._tcp.frontend"}}},
+		},
+		"a record": {
+			config: Config{Services: map[string]Record{"cadence-frontend": {Type: "A", Name: "frontend", Ports: membership.PortMap{membership.PortTchannel: 7933}}}},
+		},
+		"no services": {
+			config:  Config{},
+			wantErr: true,
+		},
+		"missing name": {
+			config:  Config{Services: map[string]Record{"cadence-frontend": {}}},
+			wantErr: true,
+		},
+		"a record without tchannel port": {
+			config:  Config{Services: map[string]Record{"cadence-frontend": {Type: RecordTypeA, Name: "frontend"}}},
+			wantErr: true,
+		},
+		"unknown type": {
+			config:  Config{Services: map[string]Record{"cadence-frontend": {Type: "cname", Name: "frontend"}}},
+			wantErr: true,
+		},
+		"invalid broadcast address": {
+			config:  Config{BroadcastAddress: "host", Services: map[string]Record{"cadence-frontend": {Name: "frontend"}}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, defaultRefreshInterval, tt.config.RefreshInterval)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package peerprovider
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/uber/cadence/common/membership"
+)
+
+// Members keeps the last known hosts of every service and notifies subscribers about changes.
+// It is shared by peer providers which discover hosts by polling an external source.
+type Members struct {
+	mu          sync.RWMutex
+	hosts       map[string][]membership.HostInfo
+	subscribers map[string]func(membership.ChangedEvent)
+}
+
+// NewMembers creates an empty Members
+func NewMembers() *Members {
+	return &Members{
+		hosts:       map[string][]membership.HostInfo{},
+		subscribers: map[string]func(membership.ChangedEvent){},
+	}
+}
+
+// GetMembers returns the last known hosts of a service, the list is empty if none are known yet
+func (m *Members) GetMembers(service string) ([]membership.HostInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.hosts[service], nil
+}
+
+// Update replaces hosts of all services and notifies subscribers if anything has changed.
+// Returned event contains the addresses which were added or removed across all services.
+func (m *Members) Update(hosts map[string][]membership.HostInfo) (membership.ChangedEvent, bool) {
+	m.mu.Lock()
+	change := diffHosts(m.hosts, hosts)
+	m.hosts = hosts
+	m.mu.Unlock()
+
+	if len(change.HostsAdded) == 0 && len(change.HostsRemoved) == 0 {
+		return change, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, handler := range m.subscribers {
+		handler(change)
+	}
+	return change, true
+}
+
+// Subscribe allows to be subscribed for host changes
+func (m *Members) Subscribe(name string, handler func(membership.ChangedEvent)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.subscribers[name]; ok {
+		return fmt.Errorf("%q already subscribed to peer provider", name)
+	}
+	m.subscribers[name] = handler
+	return nil
+}
+
+// WhoAmI returns host info of this instance. The address is built from broadcastAddress if it is set
+// or from the address of the tchannel the service listens on, and the tchannel port from the port map.
+func (c Container) WhoAmI(broadcastAddress string) (membership.HostInfo, error) {
+	port, ok := c.Portmap[membership.PortTchannel]
+	if !ok {
+		return membership.HostInfo{}, fmt.Errorf("tchannel port is not set for %q", c.Service)
+	}
+
+	ip := broadcastAddress
+	if ip == "" {
+		if c.Channel == nil {
+			return membership.HostInfo{}, fmt.Errorf("neither broadcast address nor channel is set for %q", c.Service)
+		}
+		peerInfo := c.Channel.PeerInfo()
+		if peerInfo.IsEphemeralHostPort() {
+			return membership.HostInfo{}, fmt.Errorf("channel of %q is not listening yet", c.Service)
+		}
+		host, _, err := net.SplitHostPort(peerInfo.HostPort)
+		if err != nil {
+			return membership.HostInfo{}, fmt.Errorf("failed splitting tchannel's hostport %q, err: %w", peerInfo.HostPort, err)
+		}
+		ip = host
+	}
+
+	address := net.JoinHostPort(ip, strconv.Itoa(int(port)))
+	return membership.NewDetailedHostInfo(address, address, c.Portmap), nil
+}
+
+func diffHosts(previous, current map[string][]membership.HostInfo) membership.ChangedEvent {
+	previousAddresses := addresses(previous)
+	currentAddresses := addresses(current)
+
+	var change membership.ChangedEvent
+	for addr := range currentAddresses {
+		if _, ok := previousAddresses[addr]; !ok {
+			change.HostsAdded = append(change.HostsAdded, addr)
+		}
+	}
+	for addr := range previousAddresses {
+		if _, ok := currentAddresses[addr]; !ok {
+			change.HostsRemoved = append(change.HostsRemoved, addr)
+		}
+	}
+	sort.Strings(change.HostsAdded)
+	sort.Strings(change.HostsRemoved)
+	return change
+}
+
+func addresses(hosts map[string][]membership.HostInfo) map[string]struct{} {
+	result := map[string]struct{}{}
+	for _, serviceHosts := range hosts {
+		for _, host := range serviceHosts {
+			result[host.GetAddress()] = struct{}{}
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package peerprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/membership"
+)
+
+func TestMembersUpdate(t *testing.T) {
+	members := NewMembers()
+
+	var events []membership.ChangedEvent
+	require.NoError(t, members.Subscribe("test", func(event membership.ChangedEvent) {
+		events = append(events, event)
+	}))
+	assert.Error(t, members.Subscribe("test", func(membership.ChangedEvent) {}), "same subscriber name is rejected")
+
+	hosts, err := members.GetMembers("frontend")
+	assert.NoError(t, err)
+	assert.Empty(t, hosts)
+
+	change, changed := members.Update(map[string][]membership.HostInfo{
+		"frontend": {membership.NewHostInfo("10.0.0.1:7933"), membership.NewHostInfo("10.0.0.2:7933")},
+	})
+	assert.True(t, changed)
+	assert.Equal(t, []string{"10.0.0.1:7933", "10.0.0.2:7933"}, change.HostsAdded)
+	assert.Empty(t, change.HostsRemoved)
+
+	_, changed = members.Update(map[string][]membership.HostInfo{
+		"frontend": {membership.NewHostInfo("10.0.0.2:7933"), membership.NewHostInfo("10.0.0.1:7933")},
+	})
+	assert.False(t, changed, "order of hosts does not matter")
+
+	change, changed = members.Update(map[string][]membership.HostInfo{
+		"frontend": {membership.NewHostInfo("10.0.0.2:7933"), membership.NewHostInfo("10.0.0.3:7933")},
+	})
+	assert.True(t, changed)
+	assert.Equal(t, []string{"10.0.0.3:7933"}, change.HostsAdded)
+	assert.Equal(t, []string{"10.0.0.1:7933"}, change.HostsRemoved)
+
+	hosts, err = members.GetMembers("frontend")
+	assert.NoError(t, err)
+	assert.Equal(t, []membership.HostInfo{membership.NewHostInfo("10.0.0.2:7933"), membership.NewHostInfo("10.0.0.3:7933")}, hosts)
+	assert.Len(t, events, 2)
+}
+
+func TestContainerWhoAmI(t *testing.T) {
+	portMap := membership.PortMap{membership.PortTchannel: 7933, membership.PortGRPC: 7833}
+	container := Container{Service: "frontend", Portmap: portMap}
+
+	host, err := container.WhoAmI("10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, membership.NewDetailedHostInfo("10.0.0.1:7933", "10.0.0.1:7933", portMap), host)
+
+	_, err = container.WhoAmI("")
+	assert.Error(t, err, "channel is required without broadcast address")
+
+	_, err = Container{Service: "frontend"}.WhoAmI("10.0.0.1")
+	assert.Error(t, err, "tchannel port is required")
+}
@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package staticprovider
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/uber/cadence/common/membership"
+)
+
+// ConfigKey is the key of static provider config under membership.provider
+const ConfigKey = "static"
+
+const defaultRefreshInterval = 10 * time.Second
+
+type (
+	// Config contains the static peer provider config items
+	Config struct {
+		// File is the path to a yaml file listing hosts of every service, e.g.
+		//
+		//	cadence-frontend:
+		//	  - address: 10.0.0.1
+		//	    ports:
+		//	      tchannel: 7933
+		//	      grpc: 7833
+		//	    weight: 2
+		File string `yaml:"file"`
+		// RefreshInterval is how often the file is checked for changes, defaults to 10s
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+		// BroadcastAddress is the address peers reach this host with, it has to match the address listed in the file.
+		// If not set, the listen address will be used as broadcast address.
+		BroadcastAddress string `yaml:"broadcastAddress"`
+	}
+
+	// Host is a single host entry of the hosts file
+	Host struct {
+		// Address is an IP or a hostname of the host
+		Address string `yaml:"address"`
+		// Ports are the ports host is listening to, tchannel port is required
+		Ports membership.PortMap `yaml:"ports"`
+		// Weight of the host on the weighted hashring, defaults to 1
+		Weight int `yaml:"weight"`
+	}
+)
+
+func (c *Config) validate() error {
+	if c.File == "" {
+		return fmt.Errorf("static peer provider config missing `file` param")
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = defaultRefreshInterval
+	}
+	if c.BroadcastAddress != "" && net.ParseIP(c.BroadcastAddress) == nil {
+		return fmt.Errorf("failed parsing broadcast address %q", c.BroadcastAddress)
+	}
+	return nil
+}
+
+func (h Host) toHostInfo() (membership.HostInfo, error) {
+	if h.Address == "" {
+		return membership.HostInfo{}, fmt.Errorf("host address is empty")
+	}
+	port, ok := h.Ports[membership.PortTchannel]
+	if !ok {
+		return membership.HostInfo{}, fmt.Errorf("tchannel port is not set for host %q", h.Address)
+	}
+	address := net.JoinHostPort(h.Address, strconv.Itoa(int(port)))
+	return membership.NewDetailedHostInfo(address, address, h.Ports).WithWeight(h.Weight), nil
+}
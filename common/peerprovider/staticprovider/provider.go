@@ -0,0 +1,187 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package staticprovider
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/peerprovider"
+)
+
+// Provider reads hosts of every service from a yaml file and reloads them when the file changes
+type Provider struct {
+	status       int32
+	config       *Config
+	container    peerprovider.Container
+	members      *peerprovider.Members
+	logger       log.Logger
+	lastModified time.Time
+	shutdownCh   chan struct{}
+	shutdownWG   sync.WaitGroup
+}
+
+var _ membership.PeerProvider = (*Provider)(nil)
+
+// Register registers static provider as the peer provider plugin
+func Register() error {
+	return peerprovider.Register(ConfigKey, func(cfg *config.YamlNode, container peerprovider.Container) (membership.PeerProvider, error) {
+		var c Config
+		if err := cfg.Decode(&c); err != nil {
+			return nil, fmt.Errorf("decoding static peer provider config: %w", err)
+		}
+		return New(&c, container)
+	})
+}
+
+// New creates static provider, hosts file is read right away so misconfiguration fails the startup
+func New(config *Config, container peerprovider.Container) (*Provider, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		status:     common.DaemonStatusInitialized,
+		config:     config,
+		container:  container,
+		members:    peerprovider.NewMembers(),
+		logger:     container.Logger.WithTags(tag.ComponentPeerProvider),
+		shutdownCh: make(chan struct{}),
+	}
+	if err := p.refresh(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Start starts watching the hosts file
+func (p *Provider) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&p.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	p.shutdownWG.Add(1)
+	go p.refreshLoop()
+}
+
+// Stop stops watching the hosts file
+func (p *Provider) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&p.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	close(p.shutdownCh)
+	p.shutdownWG.Wait()
+}
+
+// GetMembers returns hosts of a service listed in the file
+func (p *Provider) GetMembers(service string) ([]membership.HostInfo, error) {
+	return p.members.GetMembers(service)
+}
+
+// WhoAmI returns address of this instance
+func (p *Provider) WhoAmI() (membership.HostInfo, error) {
+	return p.container.WhoAmI(p.config.BroadcastAddress)
+}
+
+// SelfEvict is a noop, hosts are only removed by updating the file
+func (p *Provider) SelfEvict() error {
+	return nil
+}
+
+// Subscribe allows to be subscribed for host changes
+func (p *Provider) Subscribe(name string, handler func(membership.ChangedEvent)) error {
+	return p.members.Subscribe(name, handler)
+}
+
+func (p *Provider) refreshLoop() {
+	defer p.shutdownWG.Done()
+
+	ticker := time.NewTicker(p.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.refresh(); err != nil {
+				p.logger.Error("Failed to refresh static peer provider hosts", tag.Error(err))
+			}
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+func (p *Provider) refresh() error {
+	info, err := os.Stat(p.config.File)
+	if err != nil {
+		return fmt.Errorf("failed to get status of hosts file: %w", err)
+	}
+	if !info.ModTime().After(p.lastModified) {
+		return nil
+	}
+
+	content, err := os.ReadFile(p.config.File)
+	if err != nil {
+		return fmt.Errorf("failed to read hosts file %v: %w", p.config.File, err)
+	}
+
+	var services map[string][]Host
+	if err := yaml.Unmarshal(content, &services); err != nil {
+		return fmt.Errorf("failed to decode hosts file %v: %w", p.config.File, err)
+	}
+
+	hosts := make(map[string][]membership.HostInfo, len(services))
+	for service, serviceHosts := range services {
+		for _, host := range serviceHosts {
+			hostInfo, err := host.toHostInfo()
+			if err != nil {
+				return fmt.Errorf("invalid host of service %q: %w", service, err)
+			}
+			hosts[service] = append(hosts[service], hostInfo)
+		}
+	}
+
+	p.lastModified = info.ModTime()
+	if change, changed := p.members.Update(hosts); changed {
+		p.logger.Info("Static peer provider hosts changed", tag.MembershipChangeEvent(change))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package staticprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/peerprovider"
+)
+
+const testHosts = `
+cadence-frontend:
+  - address: 10.0.0.1
+    ports:
+      tchannel: 7933
+      grpc: 7833
+cadence-history:
+  - address: 10.0.0.2
+    ports:
+      tchannel: 7934
+`
+
+func newTestProvider(t *testing.T, content string) (*Provider, string) {
+	file := filepath.Join(t.TempDir(), "hosts.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	p, err := New(&Config{File: file, BroadcastAddress: "10.0.0.1"}, peerprovider.Container{
+		Service: "cadence-frontend",
+		Logger:  testlogger.New(t),
+		Portmap: membership.PortMap{membership.PortTchannel: 7933, membership.PortGRPC: 7833},
+	})
+	require.NoError(t, err)
+	return p, file
+}
+
+func TestProvider(t *testing.T) {
+	p, file := newTestProvider(t, testHosts)
+
+	frontend, err := p.GetMembers("cadence-frontend")
+	require.NoError(t, err)
+	require.Len(t, frontend, 1)
+	assert.Equal(t, "10.0.0.1:7933", frontend[0].GetAddress())
+	grpcAddress, err := frontend[0].GetNamedAddress(membership.PortGRPC)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:7833", grpcAddress)
+
+	self, err := p.WhoAmI()
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:7933", self.GetAddress())
+
+	var event membership.ChangedEvent
+	require.NoError(t, p.Subscribe("test", func(e membership.ChangedEvent) { event = e }))
+
+	updated := testHosts + `
+  - address: 10.0.0.3
+    ports:
+      tchannel: 7934
+`
+	require.NoError(t, os.WriteFile(file, []byte(updated), 0644))
+	// make sure modification time moves forward on file systems with coarse timestamps
+	require.NoError(t, os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	require.NoError(t, p.refresh())
+
+	history, err := p.GetMembers("cadence-history")
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, []string{"10.0.0.3:7934"}, event.HostsAdded)
+}
+
+func TestProviderStartStop(t *testing.T) {
+	p, _ := newTestProvider(t, testHosts)
+	p.Start()
+	p.Start()
+	p.Stop()
+	p.Stop()
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	container := peerprovider.Container{Logger: testlogger.New(t)}
+
+	_, err := New(&Config{}, container)
+	assert.Error(t, err, "file is required")
+
+	_, err = New(&Config{File: filepath.Join(t.TempDir(), "missing.yaml")}, container)
+	assert.Error(t, err, "file has to exist")
+
+	file := filepath.Join(t.TempDir(), "hosts.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("cadence-frontend:\n  - address: 10.0.0.1\n"), 0644))
+	_, err = New(&Config{File: file}, container)
+	assert.Error(t, err, "tchannel port is required")
+}
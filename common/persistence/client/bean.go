@@ -51,6 +51,9 @@ type (
 		GetDomainReplicationQueueManager() persistence.QueueManager
 		SetDomainReplicationQueueManager(persistence.QueueManager)
 
+		GetAsyncWorkflowQueueManager() persistence.QueueManager
+		SetAsyncWorkflowQueueManager(persistence.QueueManager)
+
 		GetShardManager() persistence.ShardManager
 		SetShardManager(persistence.ShardManager)
 
@@ -70,6 +73,7 @@ type (
 		taskManager                   persistence.TaskManager
 		visibilityManager             persistence.VisibilityManager
 		domainReplicationQueueManager persistence.QueueManager
+		asyncWorkflowQueueManager     persistence.QueueManager
 		shardManager                  persistence.ShardManager
 		historyManager                persistence.HistoryManager
 		configStoreManager            persistence.ConfigStoreManager
@@ -120,6 +124,11 @@ func NewBeanFromFactory(
 		return nil, err
 	}
 
+	asyncWorkflowQueue, err := factory.NewAsyncWorkflowQueueManager()
+	if err != nil {
+		return nil, err
+	}
+
 	shardMgr, err := factory.NewShardManager()
 	if err != nil {
 		return nil, err
@@ -140,6 +149,7 @@ func NewBeanFromFactory(
 		taskMgr,
 		visibilityMgr,
 		domainReplicationQueue,
+		asyncWorkflowQueue,
 		shardMgr,
 		historyMgr,
 		configStoreMgr,
@@ -153,6 +163,7 @@ func NewBean(
 	taskManager persistence.TaskManager,
 	visibilityManager persistence.VisibilityManager,
 	domainReplicationQueueManager persistence.QueueManager,
+	asyncWorkflowQueueManager persistence.QueueManager,
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
 	configStoreManager persistence.ConfigStoreManager,
@@ -163,6 +174,7 @@ func NewBean(
 		taskManager:                   taskManager,
 		visibilityManager:             visibilityManager,
 		domainReplicationQueueManager: domainReplicationQueueManager,
+		asyncWorkflowQueueManager:     asyncWorkflowQueueManager,
 		shardManager:                  shardManager,
 		historyManager:                historyManager,
 		configStoreManager:            configStoreManager,
@@ -252,6 +264,26 @@ func (s *BeanImpl) SetDomainReplicationQueueManager(
 	s.domainReplicationQueueManager = domainReplicationQueueManager
 }
 
+// GetAsyncWorkflowQueueManager gets async workflow QueueManager
+func (s *BeanImpl) GetAsyncWorkflowQueueManager() persistence.QueueManager {
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.asyncWorkflowQueueManager
+}
+
+// SetAsyncWorkflowQueueManager sets async workflow QueueManager
+func (s *BeanImpl) SetAsyncWorkflowQueueManager(
+	asyncWorkflowQueueManager persistence.QueueManager,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.asyncWorkflowQueueManager = asyncWorkflowQueueManager
+}
+
 // GetShardManager get ShardManager
 func (s *BeanImpl) GetShardManager() persistence.ShardManager {
 
@@ -367,6 +399,7 @@ func (s *BeanImpl) Close() {
 		s.visibilityManager.Close()
 	}
 	s.domainReplicationQueueManager.Close()
+	s.asyncWorkflowQueueManager.Close()
 	s.shardManager.Close()
 	s.historyManager.Close()
 	s.executionManagerFactory.Close()
@@ -44,6 +44,7 @@ import (
 	"github.com/uber/cadence/common/persistence/wrappers/metered"
 	"github.com/uber/cadence/common/persistence/wrappers/ratelimited"
 	"github.com/uber/cadence/common/persistence/wrappers/sampled"
+	"github.com/uber/cadence/common/persistence/wrappers/tracing"
 	pnt "github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/service"
@@ -70,6 +71,8 @@ type (
 		NewVisibilityManager(params *Params, serviceConfig *service.Config) (p.VisibilityManager, error)
 		// NewDomainReplicationQueueManager returns a new queue for domain replication
 		NewDomainReplicationQueueManager() (p.QueueManager, error)
+		// NewAsyncWorkflowQueueManager returns a new queue for database backed async workflow requests
+		NewAsyncWorkflowQueueManager() (p.QueueManager, error)
 		// NewConfigStoreManager returns a new config store manager
 		NewConfigStoreManager() (p.ConfigStoreManager, error)
 	}
@@ -164,6 +167,31 @@ func NewFactory(
 	return factory
 }
 
+// NewFactoryWithRatelimiters is the same as NewFactory, but every datastore is limited by
+// the given collection's limiter for the datastore's name, instead of a local limiter built
+// from a max-QPS func.
+//
+// This is intended for collections that can load-balance limits between hosts,
+// like [github.com/uber/cadence/common/quotas/global/collection.Collection].
+func NewFactoryWithRatelimiters(
+	cfg *config.Persistence,
+	limiters quotas.ICollection,
+	clusterName string,
+	metricsClient metrics.Client,
+	logger log.Logger,
+	dc *p.DynamicConfiguration,
+) Factory {
+	factory := &factoryImpl{
+		config:        cfg,
+		metricsClient: metricsClient,
+		logger:        logger,
+		clusterName:   clusterName,
+		dc:            dc,
+	}
+	factory.init(clusterName, buildKeyedRatelimiters(cfg, limiters))
+	return factory
+}
+
 // NewTaskManager returns a new task manager
 func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	ds := f.datastores[storeTypeTask]
@@ -181,6 +209,7 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewTaskManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewTaskManager(result)
 	return result, nil
 }
 
@@ -201,6 +230,7 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewShardManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewShardManager(result)
 	return result, nil
 }
 
@@ -211,7 +241,7 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryV2ManagerImpl(store, f.logger, p.NewPayloadSerializer(), codec.NewThriftRWEncoder(), f.config.TransactionSizeLimit)
+	result := p.NewHistoryV2ManagerImpl(store, f.logger, p.NewPayloadSerializer(), codec.NewThriftRWEncoder(), f.config.TransactionSizeLimit, f.config.HistoryZstdDictionaries)
 	if errorRate := f.config.ErrorInjectionRate(); errorRate != 0 {
 		result = errorinjectors.NewHistoryManager(result, errorRate, f.logger)
 	}
@@ -221,6 +251,7 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewHistoryManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewHistoryManager(result)
 	return result, nil
 }
 
@@ -243,6 +274,7 @@ func (f *factoryImpl) NewDomainManager() (p.DomainManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewDomainManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewDomainManager(result)
 	return result, nil
 }
 
@@ -263,6 +295,7 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if f.metricsClient != nil {
 		result = metered.NewExecutionManager(result, f.metricsClient, f.logger, f.config, f.dc.PersistenceSampleLoggingRate, f.dc.EnableShardIDMetrics)
 	}
+	result = tracing.NewExecutionManager(result)
 	return result, nil
 }
 
@@ -467,13 +500,22 @@ func (f *factoryImpl) newDBVisibilityManager(
 	if f.metricsClient != nil {
 		result = metered.NewVisibilityManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewVisibilityManager(result)
 
 	return result, nil
 }
 
 func (f *factoryImpl) NewDomainReplicationQueueManager() (p.QueueManager, error) {
+	return f.newQueueManager(p.DomainReplicationQueueType)
+}
+
+func (f *factoryImpl) NewAsyncWorkflowQueueManager() (p.QueueManager, error) {
+	return f.newQueueManager(p.AsyncWorkflowQueueType)
+}
+
+func (f *factoryImpl) newQueueManager(queueType p.QueueType) (p.QueueManager, error) {
 	ds := f.datastores[storeTypeQueue]
-	store, err := ds.factory.NewQueue(p.DomainReplicationQueueType)
+	store, err := ds.factory.NewQueue(queueType)
 	if err != nil {
 		return nil, err
 	}
@@ -487,6 +529,7 @@ func (f *factoryImpl) NewDomainReplicationQueueManager() (p.QueueManager, error)
 	if f.metricsClient != nil {
 		result = metered.NewQueueManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewQueueManager(result)
 
 	return result, nil
 }
@@ -507,6 +550,7 @@ func (f *factoryImpl) NewConfigStoreManager() (p.ConfigStoreManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewConfigStoreManager(result, f.metricsClient, f.logger, f.config)
 	}
+	result = tracing.NewConfigStoreManager(result)
 
 	return result, nil
 }
@@ -616,6 +660,14 @@ func buildRatelimiters(cfg *config.Persistence, maxQPS quotas.RPSFunc) map[strin
 	return result
 }
 
+func buildKeyedRatelimiters(cfg *config.Persistence, limiters quotas.ICollection) map[string]quotas.Limiter {
+	result := make(map[string]quotas.Limiter, len(cfg.DataStores))
+	for dsName := range cfg.DataStores {
+		result[dsName] = quotas.NewKeyedLimiter(limiters, dsName)
+	}
+	return result
+}
+
 func setupPinotVisibilityManager(params *Params, resourceConfig *service.Config, logger log.Logger) (p.VisibilityManager, error) {
 	visibilityProducer, err := params.MessagingClient.NewProducer(constants.PinotVisibilityAppName)
 	if err != nil {
@@ -54,6 +54,21 @@ func (mr *MockFactoryMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockFactory)(nil).Close))
 }
 
+// NewAsyncWorkflowQueueManager mocks base method.
+func (m *MockFactory) NewAsyncWorkflowQueueManager() (persistence.QueueManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAsyncWorkflowQueueManager")
+	ret0, _ := ret[0].(persistence.QueueManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAsyncWorkflowQueueManager indicates an expected call of NewAsyncWorkflowQueueManager.
+func (mr *MockFactoryMockRecorder) NewAsyncWorkflowQueueManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAsyncWorkflowQueueManager", reflect.TypeOf((*MockFactory)(nil).NewAsyncWorkflowQueueManager))
+}
+
 // NewConfigStoreManager mocks base method.
 func (m *MockFactory) NewConfigStoreManager() (persistence.ConfigStoreManager, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +131,13 @@ func TestFactoryMethods(t *testing.T) {
 		ds.EXPECT().NewQueue(persistence.DomainReplicationQueueType).Return(nil, nil).MinTimes(1)
 		check(t, fact.NewDomainReplicationQueueManager)
 	})
+	t.Run("NewAsyncWorkflowQueueManager", func(t *testing.T) {
+		fact := makeFactory(t)
+		ds := mockDatastore(t, fact, storeTypeQueue)
+
+		ds.EXPECT().NewQueue(persistence.AsyncWorkflowQueueType).Return(nil, nil).MinTimes(1)
+		check(t, fact.NewAsyncWorkflowQueueManager)
+	})
 	t.Run("NewConfigStoreManager", func(t *testing.T) {
 		fact := makeFactory(t)
 		ds := mockDatastore(t, fact, storeTypeConfigStore)
@@ -49,6 +49,15 @@
 // execution metered wrapper is special
 //go:generate gowrap gen -g -p . -i ExecutionManager -t ./wrappers/templates/metered_execution.tmpl -o wrappers/metered/execution_generated.go
 
+// Generate tracing wrappers.
+//go:generate gowrap gen -g -p . -i ConfigStoreManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/configstore_generated.go
+//go:generate gowrap gen -g -p . -i ShardManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/shard_generated.go
+//go:generate gowrap gen -g -p . -i ExecutionManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/execution_generated.go
+//go:generate gowrap gen -g -p . -i TaskManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/task_generated.go
+//go:generate gowrap gen -g -p . -i HistoryManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/history_generated.go
+//go:generate gowrap gen -g -p . -i DomainManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/domain_generated.go
+//go:generate gowrap gen -g -p . -i QueueManager -t ./wrappers/templates/tracing.tmpl -o wrappers/tracing/queue_generated.go
+
 package persistence
 
 import (
@@ -94,6 +103,7 @@ type QueueType int
 // Negative numbers are reserved for DLQ
 const (
 	DomainReplicationQueueType QueueType = iota + 1
+	AsyncWorkflowQueueType
 )
 
 // Create Workflow Execution Mode
@@ -1337,7 +1347,7 @@ type (
 
 	// AppendHistoryNodesResponse is a response to AppendHistoryNodesRequest
 	AppendHistoryNodesResponse struct {
-		// The data blob that was persisted to database
+		// The data blob that was persisted to database, batches compressed with thriftrw_zstd are returned as thriftrw
 		DataBlob DataBlob
 	}
 
@@ -1460,6 +1470,8 @@ type (
 		BranchID string
 		ForkTime time.Time
 		Info     string
+		// ShardID is the shard the branch is stored under, nil for stores that don't partition history by shard
+		ShardID *int
 	}
 
 	// GetHistoryTreeResponse is a response to GetHistoryTreeRequest
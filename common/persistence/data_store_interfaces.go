@@ -969,6 +969,8 @@ func (d *DataBlob) GetEncoding() constants.EncodingType {
 		return constants.EncodingTypeThriftRW
 	case constants.EncodingTypeThriftRWSnappy:
 		return constants.EncodingTypeThriftRWSnappy
+	case constants.EncodingTypeThriftRWZstd:
+		return constants.EncodingTypeThriftRWZstd
 	case constants.EncodingTypeEmpty:
 		return constants.EncodingTypeEmpty
 	default:
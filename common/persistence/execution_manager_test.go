@@ -348,6 +348,62 @@ func TestExecutionManager_UpdateWorkflowExecution(t *testing.T) {
 	assert.Equal(t, stats, res.MutableStateUpdateSessionStats)
 }
 
+func TestExecutionManager_ZstdEncoding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedStore := NewMockExecutionStore(ctrl)
+
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), NewPayloadSerializer())
+
+	mockedStore.EXPECT().CreateWorkflowExecution(gomock.Any(), gomock.Any()).Return(&CreateWorkflowExecutionResponse{}, nil).Times(1)
+	_, err := manager.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
+		RangeID:             1,
+		Mode:                CreateWorkflowModeBrandNew,
+		NewWorkflowSnapshot: *sampleWorkflowSnapshot(),
+		DomainName:          testDomain,
+	})
+	assert.NoError(t, err)
+
+	var stored InternalWorkflowMutation
+	mockedStore.EXPECT().UpdateWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *InternalUpdateWorkflowExecutionRequest) error {
+		stored = req.UpdateWorkflowMutation
+		return nil
+	}).Times(1)
+	_, err = manager.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+		RangeID:                1,
+		Mode:                   UpdateWorkflowModeUpdateCurrent,
+		UpdateWorkflowMutation: *sampleWorkflowMutation(),
+		Encoding:               constants.EncodingTypeThriftRWZstd,
+		DomainName:             testDomain,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, stored.ExecutionInfo.CompletionEvent.Encoding)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, stored.ExecutionInfo.AutoResetPoints.Encoding)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, stored.UpsertActivityInfos[0].ScheduledEvent.Encoding)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, stored.UpsertChildExecutionInfos[0].InitiatedEvent.Encoding)
+
+	mockedStore.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&InternalGetWorkflowExecutionResponse{
+		State: &InternalWorkflowMutableState{
+			ExecutionInfo:       stored.ExecutionInfo,
+			ActivityInfos:       map[int64]*InternalActivityInfo{1: stored.UpsertActivityInfos[0]},
+			ChildExecutionInfos: map[int64]*InternalChildExecutionInfo{1: stored.UpsertChildExecutionInfos[0]},
+			ChecksumData:        stored.ChecksumData,
+		},
+	}, nil).Times(1)
+	resp, err := manager.GetWorkflowExecution(context.Background(), &GetWorkflowExecutionRequest{
+		DomainID:   testDomainID,
+		Execution:  types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID},
+		DomainName: testDomain,
+		RangeID:    1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, completionEvent(), resp.State.ExecutionInfo.CompletionEvent)
+	assert.Equal(t, generateResetPoints(), resp.State.ExecutionInfo.AutoResetPoints)
+	assert.Equal(t, activityScheduledEvent(), resp.State.ActivityInfos[1].ScheduledEvent)
+	assert.Equal(t, activityStartedEvent(), resp.State.ActivityInfos[1].StartedEvent)
+	assert.Equal(t, childWorkflowScheduledEvent(), resp.State.ChildExecutionInfos[1].InitiatedEvent)
+	assert.Equal(t, childWorkflowStartedEvent(), resp.State.ChildExecutionInfos[1].StartedEvent)
+}
+
 func TestSerializeWorkflowSnapshot(t *testing.T) {
 	for _, tc := range []struct {
 		name         string
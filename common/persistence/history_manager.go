@@ -62,6 +62,7 @@ type (
 		persistence            HistoryStore
 		logger                 log.Logger
 		thriftEncoder          codec.BinaryEncoder
+		zstdCodec              *historyZstdCodec
 		transactionSizeLimit   dynamicproperties.IntPropertyFn
 		serializeTokenFn       func(*historyV2PagingToken) ([]byte, error)
 		deserializeTokenFn     func([]byte, int64) (*historyV2PagingToken, error)
@@ -90,12 +91,14 @@ func NewHistoryV2ManagerImpl(
 	historySerializer PayloadSerializer,
 	binaryEncoder codec.BinaryEncoder,
 	transactionSizeLimit dynamicproperties.IntPropertyFn,
+	zstdDictionaries dynamicproperties.ListPropertyFn,
 ) HistoryManager {
 	hm := &historyV2ManagerImpl{
 		historySerializer:    historySerializer,
 		persistence:          persistence,
 		logger:               logger,
 		thriftEncoder:        binaryEncoder,
+		zstdCodec:            newHistoryZstdCodec(zstdDictionaries),
 		transactionSizeLimit: transactionSizeLimit,
 		serializeTokenFn:     serializeToken,
 		deserializeTokenFn:   deserializeToken,
@@ -258,11 +261,23 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 	}
 
 	// nodeID will be the first eventID
-	blob, err := m.historySerializer.SerializeBatchEvents(request.Events, request.Encoding)
+	encoding := request.Encoding
+	if encoding == constants.EncodingTypeThriftRWZstd {
+		encoding = constants.EncodingTypeThriftRW
+	}
+	blob, err := m.historySerializer.SerializeBatchEvents(request.Events, encoding)
 	if err != nil {
 		return nil, err
 	}
-	size := len(blob.Data)
+	// compressed batches are only used for storage, callers get the thriftrw blob which can be sent over the wire
+	persistedBlob := blob
+	if request.Encoding == constants.EncodingTypeThriftRWZstd {
+		persistedBlob, err = m.zstdCodec.compress(request.DomainName, blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	size := len(persistedBlob.Data)
 	sizeLimit := m.transactionSizeLimit()
 	if size > sizeLimit {
 		return nil, &TransactionSizeLimitError{
@@ -274,7 +289,7 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 		Info:             request.Info,
 		BranchInfo:       *thrift.ToHistoryBranch(&branch),
 		NodeID:           nodeID,
-		Events:           blob,
+		Events:           persistedBlob,
 		TransactionID:    request.TransactionID,
 		ShardID:          shardID,
 		CurrentTimeStamp: m.timeSrc.Now(),
@@ -453,8 +468,15 @@ func (m *historyV2ManagerImpl) readRawHistoryBranch(
 
 	dataBlobs := resp.History
 	dataSize := 0
-	for _, dataBlob := range resp.History {
+	for i, dataBlob := range resp.History {
 		dataSize += len(dataBlob.Data)
+		// compressed batches never leave the persistence layer, see AppendHistoryNodes
+		if dataBlob.GetEncoding() == constants.EncodingTypeThriftRWZstd {
+			dataBlobs[i], err = m.zstdCodec.decompress(request.DomainName, dataBlob)
+			if err != nil {
+				return nil, nil, 0, nil, err
+			}
+		}
 	}
 
 	token.StoreToken = resp.NextPageToken
@@ -28,6 +28,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
@@ -54,6 +55,7 @@ func setUpMocksForHistoryV2Manager(t *testing.T) (*historyV2ManagerImpl, *MockHi
 		mockSerializer,
 		mockEncoder,
 		dynamicproperties.GetIntPropertyFn(1024*10),
+		nil,
 	)
 	assert.Equal(t, "mock history store", historyManager.GetName())
 
@@ -614,6 +616,39 @@ func TestAppendHistoryNodes(t *testing.T) {
 	}
 }
 
+func TestAppendHistoryNodes_Zstd(t *testing.T) {
+	historyManager, mockStore, mockSerializer, mockEncoder := setUpMocksForHistoryV2Manager(t)
+	mockEncoder.EXPECT().
+		Decode([]byte("branch-token"), &workflow.HistoryBranch{}).DoAndReturn(func(data []byte, value *workflow.HistoryBranch) error {
+		value.TreeID = common.Ptr("tree-id")
+		value.BranchID = common.Ptr("branch-id")
+		return nil
+	}).Times(1)
+	mockSerializer.EXPECT().
+		SerializeBatchEvents(gomock.Any(), constants.EncodingTypeThriftRW).
+		Return(&DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}, nil).Times(1)
+	mockStore.EXPECT().
+		AppendHistoryNodes(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *InternalAppendHistoryNodesRequest) error {
+			assert.Equal(t, constants.EncodingTypeThriftRWZstd, request.Events.Encoding)
+			blob, err := historyManager.zstdCodec.decompress("test-domain", request.Events)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("events"), blob.Data)
+			return nil
+		}).Times(1)
+
+	resp, err := historyManager.AppendHistoryNodes(context.Background(), &AppendHistoryNodesRequest{
+		BranchToken:   []byte("branch-token"),
+		Events:        []*types.HistoryEvent{{ID: 1, Version: 1}},
+		TransactionID: 1234,
+		Encoding:      constants.EncodingTypeThriftRWZstd,
+		ShardID:       common.Ptr(10),
+		DomainName:    "test-domain",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}, resp.DataBlob)
+}
+
 func TestGetAllHistoryTreeBranches(t *testing.T) {
 	testCases := []struct {
 		name          string
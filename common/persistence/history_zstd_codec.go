@@ -0,0 +1,162 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+)
+
+type (
+	// historyZstdCodec compresses thriftrw encoded history event batches with zstd.
+	// New batches of a domain are compressed with the first dictionary configured for the domain, if any.
+	// Every zstd frame records the ID of the dictionary it was compressed with, so batches compressed
+	// with any of the configured dictionaries, or with none, can be decompressed.
+	historyZstdCodec struct {
+		dictionaries dynamicproperties.ListPropertyFn
+
+		sync.Mutex
+		// encoders are keyed by the base64 encoded dictionary, decoders by the joined list of dictionaries
+		encoders map[string]*zstd.Encoder
+		decoders map[string]*zstd.Decoder
+	}
+)
+
+func newHistoryZstdCodec(dictionaries dynamicproperties.ListPropertyFn) *historyZstdCodec {
+	return &historyZstdCodec{
+		dictionaries: dictionaries,
+		encoders:     make(map[string]*zstd.Encoder),
+		decoders:     make(map[string]*zstd.Decoder),
+	}
+}
+
+// compress returns the thriftrw_zstd copy of a thriftrw encoded history blob
+func (c *historyZstdCodec) compress(domainName string, blob *DataBlob) (*DataBlob, error) {
+	if blob.Encoding != constants.EncodingTypeThriftRW {
+		return nil, NewUnknownEncodingTypeError(blob.Encoding)
+	}
+	dictionaries, err := c.getDictionaries(domainName)
+	if err != nil {
+		return nil, NewCadenceSerializationError(err.Error())
+	}
+	dictionary := ""
+	if len(dictionaries) > 0 {
+		dictionary = dictionaries[0]
+	}
+	encoder, err := c.getEncoder(dictionary)
+	if err != nil {
+		return nil, NewCadenceSerializationError(err.Error())
+	}
+	return NewDataBlob(encoder.EncodeAll(blob.Data, nil), constants.EncodingTypeThriftRWZstd), nil
+}
+
+// decompress returns the thriftrw copy of a thriftrw_zstd encoded history blob
+func (c *historyZstdCodec) decompress(domainName string, blob *DataBlob) (*DataBlob, error) {
+	if blob.Encoding != constants.EncodingTypeThriftRWZstd {
+		return nil, NewUnknownEncodingTypeError(blob.Encoding)
+	}
+	dictionaries, err := c.getDictionaries(domainName)
+	if err != nil {
+		return nil, NewCadenceDeserializationError(err.Error())
+	}
+	decoder, err := c.getDecoder(dictionaries)
+	if err != nil {
+		return nil, NewCadenceDeserializationError(err.Error())
+	}
+	data, err := decoder.DecodeAll(blob.Data, nil)
+	if err != nil {
+		return nil, NewCadenceDeserializationError(fmt.Sprintf("history batch encoding: %q, domain: %q, error: %v", blob.Encoding, domainName, err))
+	}
+	return NewDataBlob(data, constants.EncodingTypeThriftRW), nil
+}
+
+func (c *historyZstdCodec) getDictionaries(domainName string) ([]string, error) {
+	if c.dictionaries == nil {
+		return nil, nil
+	}
+	values := c.dictionaries(dynamicproperties.DomainFilter(domainName))
+	dictionaries := make([]string, 0, len(values))
+	for _, value := range values {
+		dictionary, ok := value.(string)
+		if !ok || dictionary == "" {
+			return nil, fmt.Errorf("invalid zstd dictionary for domain %q: %v", domainName, value)
+		}
+		dictionaries = append(dictionaries, dictionary)
+	}
+	return dictionaries, nil
+}
+
+func (c *historyZstdCodec) getEncoder(dictionary string) (*zstd.Encoder, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if encoder, ok := c.encoders[dictionary]; ok {
+		return encoder, nil
+	}
+	var opts []zstd.EOption
+	if dictionary != "" {
+		dict, err := base64.StdEncoding.DecodeString(dictionary)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd dictionary: %v", err)
+		}
+		opts = append(opts, zstd.WithEncoderDict(dict))
+	}
+	encoder, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.encoders[dictionary] = encoder
+	return encoder, nil
+}
+
+func (c *historyZstdCodec) getDecoder(dictionaries []string) (*zstd.Decoder, error) {
+	key := strings.Join(dictionaries, ",")
+
+	c.Lock()
+	defer c.Unlock()
+
+	if decoder, ok := c.decoders[key]; ok {
+		return decoder, nil
+	}
+	dicts := make([][]byte, 0, len(dictionaries))
+	for _, dictionary := range dictionaries {
+		dict, err := base64.StdEncoding.DecodeString(dictionary)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd dictionary: %v", err)
+		}
+		dicts = append(dicts, dict)
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDicts(dicts...))
+	if err != nil {
+		return nil, err
+	}
+	c.decoders[key] = decoder
+	return decoder, nil
+}
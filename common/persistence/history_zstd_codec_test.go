@@ -0,0 +1,150 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestHistoryZstdCodec_RoundTrip(t *testing.T) {
+	serializer := NewPayloadSerializer()
+	codec := newHistoryZstdCodec(nil)
+
+	events := historyZstdTestEvents(20)
+	blob, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+
+	compressed, err := codec.compress("test-domain", blob)
+	require.NoError(t, err)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, compressed.Encoding)
+	assert.Less(t, len(compressed.Data), len(blob.Data))
+
+	decompressed, err := codec.decompress("test-domain", compressed)
+	require.NoError(t, err)
+	assert.Equal(t, blob, decompressed)
+
+	decoded, err := serializer.DeserializeBatchEvents(decompressed)
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+}
+
+func TestHistoryZstdCodec_Errors(t *testing.T) {
+	codec := newHistoryZstdCodec(func(opts ...dynamicproperties.FilterOption) []interface{} {
+		return []interface{}{"not base64!"}
+	})
+	blob := NewDataBlob([]byte("events"), constants.EncodingTypeThriftRW)
+
+	_, err := codec.compress("test-domain", blob)
+	assert.IsType(t, &CadenceSerializationError{}, err)
+
+	_, err = codec.decompress("test-domain", NewDataBlob([]byte("events"), constants.EncodingTypeThriftRWZstd))
+	assert.IsType(t, &CadenceDeserializationError{}, err)
+
+	_, err = codec.compress("test-domain", NewDataBlob([]byte("events"), constants.EncodingTypeJSON))
+	assert.IsType(t, &UnknownEncodingTypeError{}, err)
+
+	_, err = codec.decompress("test-domain", blob)
+	assert.IsType(t, &UnknownEncodingTypeError{}, err)
+
+	codec = newHistoryZstdCodec(func(opts ...dynamicproperties.FilterOption) []interface{} {
+		return []interface{}{123}
+	})
+	_, err = codec.compress("test-domain", blob)
+	assert.IsType(t, &CadenceSerializationError{}, err)
+
+	_, err = newHistoryZstdCodec(nil).decompress("test-domain", NewDataBlob([]byte("not zstd"), constants.EncodingTypeThriftRWZstd))
+	assert.IsType(t, &CadenceDeserializationError{}, err)
+}
+
+// BenchmarkHistoryBatchEncoding reports the stored size of the same history batch for each encoding
+func BenchmarkHistoryBatchEncoding(b *testing.B) {
+	serializer := NewPayloadSerializer()
+	codec := newHistoryZstdCodec(nil)
+	events := historyZstdTestEvents(100)
+	blob, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+	require.NoError(b, err)
+
+	encoders := map[constants.EncodingType]func() ([]byte, error){
+		constants.EncodingTypeThriftRW: func() ([]byte, error) {
+			serialized, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+			if err != nil {
+				return nil, err
+			}
+			return serialized.Data, nil
+		},
+		constants.EncodingTypeThriftRWSnappy: func() ([]byte, error) {
+			return snappy.Encode(nil, blob.Data), nil
+		},
+		constants.EncodingTypeThriftRWZstd: func() ([]byte, error) {
+			compressed, err := codec.compress("test-domain", blob)
+			if err != nil {
+				return nil, err
+			}
+			return compressed.Data, nil
+		},
+	}
+	for encoding, encode := range encoders {
+		b.Run(string(encoding), func(b *testing.B) {
+			var size int
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, err := encode()
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "bytes/batch")
+			b.ReportMetric(float64(size)/float64(len(blob.Data)), "ratio")
+		})
+	}
+}
+
+func historyZstdTestEvents(count int) []*types.HistoryEvent {
+	events := make([]*types.HistoryEvent, 0, count)
+	for i := 1; i <= count; i++ {
+		events = append(events, &types.HistoryEvent{
+			ID:        int64(i),
+			Version:   1,
+			Timestamp: common.Int64Ptr(int64(1700000000000000000 + i)),
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				ActivityID:   fmt.Sprintf("activity-%v", i),
+				ActivityType: &types.ActivityType{Name: "test-activity-type"},
+				TaskList:     &types.TaskList{Name: "test-task-list"},
+				Input:        []byte(fmt.Sprintf(`{"orderID":"order-%v","customer":"test-customer","items":["a","b","c"]}`, i)),
+			},
+		})
+	}
+	return events
+}
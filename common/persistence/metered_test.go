@@ -438,7 +438,7 @@ func TestGetAllHistoryTreeBranchesResponseEstimatePayloadSize(t *testing.T) {
 			},
 		}
 
-		assert.Equal(t, uint64(123), response.ByteSize())
+		assert.Equal(t, uint64(131), response.ByteSize())
 	})
 
 	t.Run("a bigger response emits a bigger value", func(t *testing.T) {
@@ -449,6 +449,6 @@ func TestGetAllHistoryTreeBranchesResponseEstimatePayloadSize(t *testing.T) {
 			},
 		}
 
-		assert.Equal(t, uint64(136), response.ByteSize())
+		assert.Equal(t, uint64(144), response.ByteSize())
 	})
 }
@@ -355,6 +355,8 @@ func getDecoder(encoding constants.EncodingType) (decoder, error) {
 		return newThriftDecoder(), nil
 	case constants.EncodingTypeThriftRWSnappy:
 		return newSnappyThriftDecoder(), nil
+	case constants.EncodingTypeThriftRWZstd:
+		return newZstdThriftDecoder()
 	default:
 		return nil, unsupportedEncodingError(encoding)
 	}
@@ -366,6 +368,8 @@ func getEncoder(encoding constants.EncodingType) (encoder, error) {
 		return newThriftEncoder(), nil
 	case constants.EncodingTypeThriftRWSnappy:
 		return newSnappyThriftEncoder(), nil
+	case constants.EncodingTypeThriftRWZstd:
+		return newZstdThriftEncoder()
 	default:
 		return nil, unsupportedEncodingError(encoding)
 	}
@@ -0,0 +1,116 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package serialization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/constants"
+)
+
+func TestZstdThriftRoundTrip(t *testing.T) {
+	parser, err := NewParser(constants.EncodingTypeThriftRWZstd, constants.EncodingTypeThriftRWZstd)
+	require.NoError(t, err)
+
+	shardBlob, err := parser.ShardInfoToBlob(shardInfoTestData)
+	require.NoError(t, err)
+	assert.Equal(t, constants.EncodingTypeThriftRWZstd, shardBlob.Encoding)
+	shardInfo, err := parser.ShardInfoFromBlob(shardBlob.Data, string(shardBlob.Encoding))
+	require.NoError(t, err)
+	assert.Equal(t, shardInfoTestData, shardInfo)
+
+	executionBlob, err := parser.WorkflowExecutionInfoToBlob(workflowExecutionInfoTestData)
+	require.NoError(t, err)
+	executionInfo, err := parser.WorkflowExecutionInfoFromBlob(executionBlob.Data, string(executionBlob.Encoding))
+	require.NoError(t, err)
+	assert.Equal(t, workflowExecutionInfoTestData, executionInfo)
+
+	activityBlob, err := parser.ActivityInfoToBlob(activityInfoTestData)
+	require.NoError(t, err)
+	activityInfo, err := parser.ActivityInfoFromBlob(activityBlob.Data, string(activityBlob.Encoding))
+	require.NoError(t, err)
+	assert.Equal(t, activityInfoTestData, activityInfo)
+
+	transferBlob, err := parser.TransferTaskInfoToBlob(transferTaskInfoTestData)
+	require.NoError(t, err)
+	transferInfo, err := parser.TransferTaskInfoFromBlob(transferBlob.Data, string(transferBlob.Encoding))
+	require.NoError(t, err)
+	assert.Equal(t, transferTaskInfoTestData, transferInfo)
+}
+
+func TestZstdThriftReadsExistingEncodings(t *testing.T) {
+	thriftParser, err := NewParser(constants.EncodingTypeThriftRW, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	snappyParser, err := NewParser(constants.EncodingTypeThriftRWSnappy, constants.EncodingTypeThriftRWSnappy)
+	require.NoError(t, err)
+	zstdParser, err := NewParser(
+		constants.EncodingTypeThriftRWZstd,
+		constants.EncodingTypeThriftRW,
+		constants.EncodingTypeThriftRWSnappy,
+		constants.EncodingTypeThriftRWZstd,
+	)
+	require.NoError(t, err)
+
+	for _, p := range []Parser{thriftParser, snappyParser, zstdParser} {
+		blob, err := p.WorkflowExecutionInfoToBlob(workflowExecutionInfoTestData)
+		require.NoError(t, err)
+		decoded, err := zstdParser.WorkflowExecutionInfoFromBlob(blob.Data, string(blob.Encoding))
+		require.NoError(t, err)
+		assert.Equal(t, workflowExecutionInfoTestData, decoded)
+	}
+}
+
+func TestZstdThriftDecoderInvalidData(t *testing.T) {
+	d, err := newZstdThriftDecoder()
+	require.NoError(t, err)
+
+	_, err = d.shardInfoFromBlob([]byte("invalid zstd data"))
+	assert.Error(t, err)
+}
+
+func BenchmarkWorkflowExecutionInfoEncoding(b *testing.B) {
+	for _, encoding := range []constants.EncodingType{
+		constants.EncodingTypeThriftRW,
+		constants.EncodingTypeThriftRWSnappy,
+		constants.EncodingTypeThriftRWZstd,
+	} {
+		b.Run(string(encoding), func(b *testing.B) {
+			parser, err := NewParser(encoding, encoding)
+			require.NoError(b, err)
+
+			var size int
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				blob, err := parser.WorkflowExecutionInfoToBlob(workflowExecutionInfoTestData)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(blob.Data)
+			}
+			b.ReportMetric(float64(size), "bytes/blob")
+		})
+	}
+}
@@ -23,6 +23,9 @@ package persistence
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/uber/cadence/.gen/go/config"
 	"github.com/uber/cadence/.gen/go/history"
@@ -385,6 +388,11 @@ func (t *serializerImpl) serialize(input interface{}, encodingType constants.Enc
 	switch encodingType {
 	case constants.EncodingTypeThriftRW:
 		data, err = t.thriftrwEncode(input)
+	case constants.EncodingTypeThriftRWZstd:
+		data, err = t.thriftrwEncode(input)
+		if err == nil {
+			data, err = zstdCompress(data)
+		}
 	case constants.EncodingTypeJSON, constants.EncodingTypeUnknown, constants.EncodingTypeEmpty: // For backward-compatibility
 		encodingType = constants.EncodingTypeJSON
 		data, err = json.Marshal(input)
@@ -444,6 +452,11 @@ func (t *serializerImpl) deserialize(data *DataBlob, target interface{}) error {
 	switch data.GetEncoding() {
 	case constants.EncodingTypeThriftRW:
 		err = t.thriftrwDecode(data.Data, target)
+	case constants.EncodingTypeThriftRWZstd:
+		var decompressed []byte
+		if decompressed, err = zstdDecompress(data.Data); err == nil {
+			err = t.thriftrwDecode(decompressed, target)
+		}
 	case constants.EncodingTypeJSON, constants.EncodingTypeUnknown, constants.EncodingTypeEmpty: // For backward-compatibility
 		err = json.Unmarshal(data.Data, target)
 	default:
@@ -580,3 +593,40 @@ func NewCadenceDeserializationError(msg string) *CadenceDeserializationError {
 func (e *CadenceDeserializationError) Error() string {
 	return fmt.Sprintf("cadence deserialization error: %v", e.msg)
 }
+
+// serializerZstd compresses thriftrw_zstd blobs of the serializer, like events kept in mutable state.
+// Unlike history batches compressed by the history manager they never use a dictionary,
+// so they can be read without knowing their domain, e.g. when listing executions.
+var serializerZstd struct {
+	sync.Once
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+	err     error
+}
+
+func getSerializerZstd() (*zstd.Encoder, *zstd.Decoder, error) {
+	serializerZstd.Do(func() {
+		serializerZstd.encoder, serializerZstd.err = zstd.NewWriter(nil)
+		if serializerZstd.err != nil {
+			return
+		}
+		serializerZstd.decoder, serializerZstd.err = zstd.NewReader(nil)
+	})
+	return serializerZstd.encoder, serializerZstd.decoder, serializerZstd.err
+}
+
+func zstdCompress(data []byte) ([]byte, error) {
+	encoder, _, err := getSerializerZstd()
+	if err != nil {
+		return nil, err
+	}
+	return encoder.EncodeAll(data, nil), nil
+}
+
+func zstdDecompress(data []byte) ([]byte, error) {
+	_, decoder, err := getSerializerZstd()
+	if err != nil {
+		return nil, err
+	}
+	return decoder.DecodeAll(data, nil)
+}
@@ -46,11 +46,12 @@ type testDef struct {
 
 // key is encoding type, value is whether the encoding type is supported
 var encodingTypes = map[constants.EncodingType]bool{
-	constants.EncodingTypeEmpty:    true,
-	constants.EncodingTypeUnknown:  true,
-	constants.EncodingTypeJSON:     true,
-	constants.EncodingTypeThriftRW: true,
-	constants.EncodingTypeGob:      false,
+	constants.EncodingTypeEmpty:        true,
+	constants.EncodingTypeUnknown:      true,
+	constants.EncodingTypeJSON:         true,
+	constants.EncodingTypeThriftRW:     true,
+	constants.EncodingTypeThriftRWZstd: true,
+	constants.EncodingTypeGob:          false,
 }
 
 type runnableTest struct {
@@ -500,6 +500,7 @@ func (m *sqlHistoryStore) GetAllHistoryTreeBranches(
 		resp.Branches[i].BranchID = row.BranchID.String()
 		resp.Branches[i].ForkTime = treeInfo.GetCreatedTimestamp()
 		resp.Branches[i].Info = treeInfo.GetInfo()
+		resp.Branches[i].ShardID = common.IntPtr(row.ShardID)
 	}
 	if len(rows) >= request.PageSize {
 		// there could be more
@@ -25,6 +25,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,6 +170,95 @@ func TestGetHistoryTree(t *testing.T) {
 	}
 }
 
+func TestGetAllHistoryTreeBranches(t *testing.T) {
+	forkTime := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name      string
+		req       *persistence.GetAllHistoryTreeBranchesRequest
+		mockSetup func(*sqlplugin.MockDB, *serialization.MockParser)
+		want      *persistence.GetAllHistoryTreeBranchesResponse
+		wantErr   bool
+	}{
+		{
+			name: "Success case",
+			req: &persistence.GetAllHistoryTreeBranchesRequest{
+				PageSize: 10,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().GetAllHistoryTreeBranches(gomock.Any(), &sqlplugin.HistoryTreeFilter{
+					TreeID:   serialization.UUID{},
+					BranchID: &serialization.UUID{},
+					PageSize: common.IntPtr(10),
+				}).Return([]sqlplugin.HistoryTreeRow{
+					{
+						ShardID:      3,
+						TreeID:       serialization.MustParseUUID("530ec3d3-f74b-423f-a138-3b35494fe691"),
+						BranchID:     serialization.MustParseUUID("630ec3d3-f74b-423f-a138-3b35494fe691"),
+						Data:         []byte(`aaaa`),
+						DataEncoding: "json",
+					},
+				}, nil)
+				mockParser.EXPECT().HistoryTreeInfoFromBlob([]byte(`aaaa`), "json").Return(&serialization.HistoryTreeInfo{
+					CreatedTimestamp: forkTime,
+					Info:             "branchInfo",
+				}, nil)
+			},
+			want: &persistence.GetAllHistoryTreeBranchesResponse{
+				Branches: []persistence.HistoryBranchDetail{
+					{
+						TreeID:   "530ec3d3-f74b-423f-a138-3b35494fe691",
+						BranchID: "630ec3d3-f74b-423f-a138-3b35494fe691",
+						ForkTime: forkTime,
+						Info:     "branchInfo",
+						ShardID:  common.IntPtr(3),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success case - no record",
+			req: &persistence.GetAllHistoryTreeBranchesRequest{
+				PageSize: 10,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().GetAllHistoryTreeBranches(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			want:    &persistence.GetAllHistoryTreeBranchesResponse{},
+			wantErr: false,
+		},
+		{
+			name: "Error case - invalid page token",
+			req: &persistence.GetAllHistoryTreeBranchesRequest{
+				PageSize:      10,
+				NextPageToken: []byte("invalid"),
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockParser := serialization.NewMockParser(ctrl)
+			store, err := NewHistoryV2Persistence(mockDB, nil, mockParser)
+			require.NoError(t, err, "Failed to create sql history store")
+
+			tc.mockSetup(mockDB, mockParser)
+			got, err := store.GetAllHistoryTreeBranches(context.Background(), tc.req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.want, got, "Unexpected result for test case")
+			}
+		})
+	}
+}
+
 func TestDeleteHistoryBranch(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -40,6 +40,11 @@ const (
 
 DELETE FROM history_node
 WHERE (shard_id, tree_id, branch_id, node_id, txn_id) IN (SELECT shard_id, tree_id, branch_id, node_id, txn_id FROM events_to_delete);`
+
+	getAllHistoryTreeQuery = `SELECT shard_id, tree_id, branch_id, data, data_encoding FROM history_tree
+WHERE (shard_id, tree_id, branch_id) > (?, ?, ?)
+ORDER BY shard_id, tree_id, branch_id
+LIMIT ?`
 )
 
 // DeleteFromHistoryNode deletes one or more rows from history_node table
@@ -47,3 +52,11 @@ func (mdb *DB) DeleteFromHistoryNode(ctx context.Context, filter *sqlplugin.Hist
 	dbShardID := sqlplugin.GetDBShardIDFromTreeID(filter.TreeID, mdb.GetTotalNumDBShards())
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteHistoryNodesQuery, filter.ShardID, filter.TreeID, filter.BranchID, *filter.MinNodeID, filter.PageSize)
 }
+
+// GetAllHistoryTreeBranches gets all history tree branches, paging by (shard_id, tree_id, branch_id)
+func (mdb *DB) GetAllHistoryTreeBranches(ctx context.Context, filter *sqlplugin.HistoryTreeFilter) ([]sqlplugin.HistoryTreeRow, error) {
+	var rows []sqlplugin.HistoryTreeRow
+	dbShardID := sqlplugin.GetDBShardIDFromTreeID(filter.TreeID, mdb.GetTotalNumDBShards())
+	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getAllHistoryTreeQuery, filter.ShardID, filter.TreeID, *filter.BranchID, filter.PageSize)
+	return rows, err
+}
@@ -0,0 +1,67 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package persistence
+
+// Execution manager requests define GetWorkflowID() string to set the workflow ID attribute
+// on the spans created by the tracing wrapper. The domain attribute is set with GetDomainName() string.
+
+func (r *CreateWorkflowExecutionRequest) GetWorkflowID() string {
+	if r == nil || r.NewWorkflowSnapshot.ExecutionInfo == nil {
+		return ""
+	}
+	return r.NewWorkflowSnapshot.ExecutionInfo.WorkflowID
+}
+
+func (r *UpdateWorkflowExecutionRequest) GetWorkflowID() string {
+	if r == nil || r.UpdateWorkflowMutation.ExecutionInfo == nil {
+		return ""
+	}
+	return r.UpdateWorkflowMutation.ExecutionInfo.WorkflowID
+}
+
+func (r *ConflictResolveWorkflowExecutionRequest) GetWorkflowID() string {
+	if r == nil || r.ResetWorkflowSnapshot.ExecutionInfo == nil {
+		return ""
+	}
+	return r.ResetWorkflowSnapshot.ExecutionInfo.WorkflowID
+}
+
+func (r GetWorkflowExecutionRequest) GetWorkflowID() string {
+	return r.Execution.WorkflowID
+}
+
+func (r IsWorkflowExecutionExistsRequest) GetWorkflowID() string {
+	return r.WorkflowID
+}
+
+func (r DeleteWorkflowExecutionRequest) GetWorkflowID() string {
+	return r.WorkflowID
+}
+
+func (r DeleteCurrentWorkflowExecutionRequest) GetWorkflowID() string {
+	return r.WorkflowID
+}
+
+func (r GetCurrentExecutionRequest) GetWorkflowID() string {
+	return r.WorkflowID
+}
@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/tracing"
+)
+
+func TestExecutionManager(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctrl := gomock.NewController(t)
+	wrapped := persistence.NewMockExecutionManager(ctrl)
+	manager := NewExecutionManager(wrapped)
+
+	request := &persistence.GetCurrentExecutionRequest{
+		DomainID:   "test-domain-id",
+		DomainName: "test-domain",
+		WorkflowID: "test-workflow-id",
+	}
+	wrapped.EXPECT().GetShardID().Return(3)
+	wrapped.EXPECT().GetCurrentExecution(gomock.Any(), request).Return(nil, errors.New("test error"))
+	wrapped.EXPECT().GetName().Return("test")
+
+	_, err := manager.GetCurrentExecution(context.Background(), request)
+	require.Error(t, err)
+	assert.Equal(t, "test", manager.GetName())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ExecutionManager.GetCurrentExecution", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		tracing.DomainKey.String("test-domain"),
+		tracing.WorkflowIDKey.String("test-workflow-id"),
+		tracing.ShardIDKey.Int(3),
+	}, spans[0].Attributes())
+}
@@ -0,0 +1,112 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package algorithm
+
+import (
+	"time"
+)
+
+type (
+	// burstCredit is request-weighting with a token bucket of "burst credit" per host.
+	//
+	// Running averages intentionally smooth out changes in load, which means a host that
+	// suddenly starts receiving more requests is under-weighted for a few update cycles.
+	// To reduce rejections in that case, hosts whose latest load is below an even share of
+	// the limit earn credit (in requests) for the unused portion, up to BurstCreditWindow
+	// seconds of an even share.  When a host's latest load is above its running average,
+	// credit is spent to weight it by the latest load instead, until the credit runs out.
+	//
+	// Credit is only earned while the algorithm is in use (or shadowed) for a Limit, so
+	// newly switched Limits will behave like request-weighting at first.
+	burstCredit struct {
+		limits map[Limit]*limitCredit
+	}
+
+	limitCredit struct {
+		lastUpdate time.Time
+		hosts      map[Identity]PerSecond // saved credit, in requests (i.e. rps * seconds)
+	}
+)
+
+func newBurstCredit() *burstCredit {
+	return &burstCredit{
+		limits: make(map[Limit]*limitCredit, guessNumKeys),
+	}
+}
+
+func (b *burstCredit) weights(key Limit, load map[Identity]hostLoad, _ PerSecond, snap configSnapshot) map[Identity]PerSecond {
+	lc := b.limits[key]
+	if lc == nil {
+		lc = &limitCredit{
+			lastUpdate: snap.now,
+			hosts:      make(map[Identity]PerSecond, len(load)),
+		}
+		b.limits[key] = lc
+	}
+	// time-based rather than per-call, as calls occur once per limiting host per update,
+	// and that varies with the number of hosts.
+	elapsed := PerSecond(snap.now.Sub(lc.lastUpdate).Seconds())
+	if elapsed < 0 {
+		elapsed = 0 // time went backwards, don't earn or spend
+	}
+	lc.lastUpdate = snap.now
+
+	var total PerSecond
+	for _, l := range load {
+		total += l.demand
+	}
+	even := total / PerSecond(len(load))
+	maxCredit := even * PerSecond(snap.burstAfter.Seconds())
+
+	result := make(map[Identity]PerSecond, len(load))
+	for id, l := range load {
+		credit := lc.hosts[id]
+		effective := l.demand
+		if l.latest > l.demand {
+			if credit > 0 && elapsed > 0 {
+				// spend credit to follow the increase, as fast as the credit allows
+				extra := min(l.latest-l.demand, credit/elapsed)
+				effective += extra
+				credit -= extra * elapsed
+			}
+		} else if l.latest < even {
+			// under an even share, save the unused portion
+			credit += (even - l.latest) * elapsed
+		}
+		lc.hosts[id] = max(0, min(credit, maxCredit))
+		result[id] = effective
+	}
+
+	// hosts that have been garbage collected should not keep their credit
+	for id := range lc.hosts {
+		if _, ok := load[id]; !ok {
+			delete(lc.hosts, id)
+		}
+	}
+
+	return result
+}
+
+func (b *burstCredit) forget(key Limit) {
+	delete(b.limits, key)
+}
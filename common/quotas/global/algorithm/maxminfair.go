@@ -0,0 +1,78 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package algorithm
+
+import (
+	"sort"
+)
+
+// maxMinFair computes a max-min fair share of the limit for each host.
+//
+// Algorithms are intentionally unaware of the configured RPS, so the capacity being
+// shared is estimated from the accepted RPS.  When any requests are being rejected this
+// is close to the limit, and when none are, every host is already getting what it asks
+// for and this is the same as request-weighting.
+//
+// Capacity is handed out by "water filling": every host gets an equal share,
+// hosts that need less than that keep only what they need, and the remainder is
+// split equally between the rest, repeated until nothing is left to redistribute.
+type maxMinFair struct{}
+
+func (maxMinFair) weights(_ Limit, load map[Identity]hostLoad, usedRPS PerSecond, _ configSnapshot) map[Identity]PerSecond {
+	result := make(map[Identity]PerSecond, len(load))
+
+	var total PerSecond
+	for _, l := range load {
+		total += l.demand
+	}
+	if usedRPS <= 0 || usedRPS >= total {
+		// everyone is already satisfied (or there is nothing to go on), weight by demand
+		for id, l := range load {
+			result[id] = l.demand
+		}
+		return result
+	}
+
+	ids := make([]Identity, 0, len(load))
+	for id := range load {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		// identity breaks ties so results do not depend on map iteration order
+		if load[ids[i]].demand == load[ids[j]].demand {
+			return ids[i] < ids[j]
+		}
+		return load[ids[i]].demand < load[ids[j]].demand
+	})
+
+	remaining := usedRPS
+	for i, id := range ids {
+		share := remaining / PerSecond(len(ids)-i)
+		alloc := min(load[id].demand, share)
+		result[id] = alloc
+		remaining -= alloc
+	}
+	return result
+}
+
+func (maxMinFair) forget(Limit) {}
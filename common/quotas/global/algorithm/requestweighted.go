@@ -64,6 +64,18 @@ increase in attempted usage will increase that limiter's weight on the next
 update cycle, so this is mostly intended as a tool for reducing incorrectly-rejected
 requests when a ratelimit's usage is well below its allowed limit.
 
+# Choosing a weighting algorithm
+
+All request data is collected the same way, but how it is turned into weights can
+be chosen per Limit with Config.Algorithm:
+  - "request-weighted" weights hosts by their share of all requests (the default)
+  - "max-min-fair" shares the limit evenly, but no host gets more than it requests
+  - "burst-credit" is request-weighted, but quiet hosts save credit to follow bursts quickly
+
+Config.ShadowAlgorithm computes a second algorithm for the same Limit and emits metrics
+on how far its weights diverge from the primary algorithm's, without returning them.
+This allows evaluating a new algorithm against real load before switching to it.
+
 # Dealing with expired data
 
 As user calls change, or the aggregating-host ring changes, Limit keys may become
@@ -144,6 +156,7 @@ type (
 
 		lastUpdate         time.Time // only so we know if elapsed times are exceeded, not used to compute per-second rates
 		accepted, rejected PerSecond // requests received, per second (conceptually divided by update rate)
+		latest             PerSecond // accepted+rejected from the most recent update alone, without averaging
 	}
 
 	// Identity is an arbitrary (stable) identifier for a "limiting" host.
@@ -169,6 +182,9 @@ type (
 		// data is first keyed on the limit and then on the host, as it's assumed that there will be
 		// many times more limit keys than limiting hosts, and this will reduce cardinality more rapidly.
 		usage map[Limit]map[Identity]requests
+		// weighers turn usage data into host weights, one per selectable Algorithm.
+		// all of them see the same usage data, so keys can switch between them at any time.
+		weighers map[Algorithm]weigher
 
 		clock clock.TimeSource
 	}
@@ -241,6 +257,20 @@ type (
 		// "Good" values depend on a lot of details, but >=10*UpdateInterval seems reasonably safe for a
 		// NewDataWeight of 0.5, as the latest data will be reduced to only 0.1% and may not be worth keeping.
 		GcAfter dynamicproperties.DurationPropertyFn
+
+		// Algorithm selects how weights are computed for each Limit, see [Algorithm] for the options.
+		// Optional, nil or unknown values use RequestWeightedAlgorithm.
+		Algorithm dynamicproperties.StringPropertyWithRatelimitKeyFilter
+
+		// ShadowAlgorithm selects a second Algorithm to compute for each Limit and compare against Algorithm,
+		// emitting metrics about how much they diverge.  Its weights are never returned.
+		// Optional, nil, empty, unknown, or the same value as Algorithm disables shadowing.
+		ShadowAlgorithm dynamicproperties.StringPropertyWithRatelimitKeyFilter
+
+		// BurstCreditWindow is how many seconds of an even share of a Limit a host can save up,
+		// when using BurstCreditAlgorithm.
+		// Optional, nil or zero prevents saving any credit.
+		BurstCreditWindow dynamicproperties.DurationPropertyFn
 	}
 
 	// UpdateParams contains args for calling Update.
@@ -263,6 +293,7 @@ type (
 		rate       time.Duration
 		decayAfter time.Duration
 		gcAfter    time.Duration
+		burstAfter time.Duration // burst credit window
 	}
 )
 
@@ -313,6 +344,9 @@ func (c configSnapshot) validate() error {
 	if c.gcAfter < 0 {
 		err = multierr.Append(err, fmt.Errorf("gc-after cannot be negative: %v", c.decayAfter))
 	}
+	if c.burstAfter < 0 {
+		err = multierr.Append(err, fmt.Errorf("burst credit window cannot be negative: %v", c.burstAfter))
+	}
 
 	if err != nil {
 		return multierr.Append(errors.New("bad ratelimiter config"), err)
@@ -372,7 +406,8 @@ func New(met metrics.Client, logger log.Logger, cfg Config) (RequestWeighted, er
 		logger: logger.WithTags(tag.ComponentGlobalRatelimiter),
 		usage:  make(map[Limit]map[Identity]requests, guessNumKeys), // start out relatively large
 
-		clock: clock.NewRealTimeSource(),
+		weighers: newWeighers(),
+		clock:    clock.NewRealTimeSource(),
 	}
 	_, err := i.snapshot() // validate config by just taking a snapshot
 	if err != nil {
@@ -433,6 +468,7 @@ func (a *impl) Update(p UpdateParams) error {
 				lastUpdate: snap.now,
 				accepted:   aps, // no requests == 100% weight
 				rejected:   rps, // no requests == 100% weight
+				latest:     aps + rps,
 			}
 		} else {
 			age := snap.now.Sub(prev.lastUpdate)
@@ -443,6 +479,7 @@ func (a *impl) Update(p UpdateParams) error {
 					lastUpdate: snap.now,
 					accepted:   aps, // no requests == 100% weight
 					rejected:   rps, // no requests == 100% weight
+					latest:     aps + rps,
 				}
 			} else {
 				updated++
@@ -458,6 +495,7 @@ func (a *impl) Update(p UpdateParams) error {
 					// and never recovering, despite steady and fair usage.
 					accepted: shared.SanityLogFloat(0, weighted(aps, prev.accepted*reduce, snap.weight), guessImpossibleRps, "weighted accepted rps", a.logger),
 					rejected: shared.SanityLogFloat(0, weighted(rps, prev.rejected*reduce, snap.weight), guessImpossibleRps, "weighted rejected rps", a.logger),
+					latest:   aps + rps,
 				}
 			}
 		}
@@ -476,15 +514,14 @@ func (a *impl) Update(p UpdateParams) error {
 	return nil
 }
 
-// getWeightsLocked returns the weights of observed hosts (based on ALL requests), and the total number of requests accepted per second.
-func (a *impl) getWeightsLocked(key Limit, snap configSnapshot) (weights map[Identity]HostWeight, usedRPS PerSecond, met Metrics) {
+// getLoadLocked returns the decay-adjusted load of observed hosts, and the total number of requests accepted per second.
+func (a *impl) getLoadLocked(key Limit, snap configSnapshot) (load map[Identity]hostLoad, usedRPS PerSecond, met Metrics) {
 	ir := a.usage[key]
 	if len(ir) == 0 {
 		return nil, 0, met
 	}
 
-	weights = make(map[Identity]HostWeight, len(ir))
-	total := HostWeight(0.0)
+	load = make(map[Identity]hostLoad, len(ir))
 	for id, reqs := range ir {
 		// account for missed updates
 		age := snap.now.Sub(reqs.lastUpdate)
@@ -499,21 +536,34 @@ func (a *impl) getWeightsLocked(key Limit, snap configSnapshot) (weights map[Ide
 		reduce := shared.SanityLogFloat(0, snap.missedUpdateScalar(age), 1, "missed update", a.logger)
 		// similarly: should never be zero, accepted + rejected must be nonzero or they are not inserted.
 		// this may be reduced to very low values, but still far from == 0.
-		actual := HostWeight((reqs.accepted + reqs.rejected) * reduce)
-
-		weights[id] = actual // populate with the reduced values so it doesn't have to be calculated again
-		total += actual      // keep a running total to scale all values when done
+		load[id] = hostLoad{
+			demand: (reqs.accepted + reqs.rejected) * reduce,
+			latest: reqs.latest * reduce,
+		}
 		usedRPS += reqs.accepted * reduce
 		met.HostLimits++
 	}
 
 	if len(ir) == 0 {
 		// completely empty Limit, gc it as well
-		delete(a.usage, key)
+		a.forgetLocked(key)
 		met.RemovedLimits++
 		return nil, 0, met
 	}
 
+	met.Limits = 1
+	return load, usedRPS, met
+}
+
+// weighLocked normalizes an algorithm's view of load into weights between 0 and 1 which sum to 1.
+func (a *impl) weighLocked(key Limit, algo Algorithm, load map[Identity]hostLoad, usedRPS PerSecond, snap configSnapshot) map[Identity]HostWeight {
+	raw := a.weighers[algo].weights(key, load, usedRPS, snap)
+
+	total := PerSecond(0)
+	for _, v := range raw {
+		total += v
+	}
+
 	// zeros anywhere here should not be possible - they are prevented from being inserted,
 	// and anything simply "losing weight" will only become "rather low", not zero,
 	// before enough passes have occurred to garbage collect it.
@@ -523,17 +573,41 @@ func (a *impl) getWeightsLocked(key Limit, snap configSnapshot) (weights map[Ide
 	//
 	// if gc period / weight amount is set extreme enough this is "possible",
 	// but we are unlikely to ever cause it.
-	for id := range ir {
+	weights := make(map[Identity]HostWeight, len(raw))
+	for id, v := range raw {
 		// normalize by the total.
 		// this also ensures all values are between 0 and 1 (inclusive),
 		// though zero should be impossible.
-		weights[id] = shared.SanityLogFloat(0, weights[id]/total, 1, "normalized weight", a.logger)
+		weights[id] = shared.SanityLogFloat(0, HostWeight(v/total), 1, "normalized weight", a.logger)
+	}
+	return weights
+}
+
+// getWeightsLocked returns the weights of observed hosts (based on ALL requests) for a single algorithm,
+// and the total number of requests accepted per second.
+func (a *impl) getWeightsLocked(key Limit, algo Algorithm, snap configSnapshot) (weights map[Identity]HostWeight, usedRPS PerSecond, met Metrics) {
+	load, usedRPS, met := a.getLoadLocked(key, snap)
+	if len(load) == 0 {
+		return nil, 0, met
+	}
+	return a.weighLocked(key, algo, load, usedRPS, snap), usedRPS, met
+}
+
+// forgetLocked removes all data for a limit, including any algorithm-specific state.
+func (a *impl) forgetLocked(key Limit) {
+	delete(a.usage, key)
+	for _, w := range a.weighers {
+		w.forget(key)
 	}
-	met.Limits = 1
-	return weights, usedRPS, met
 }
 
 func (a *impl) HostUsage(host Identity, limits []Limit) (usage map[Limit]HostUsage, err error) {
+	// read algorithm config before locking, as dynamic config can be comparatively slow
+	algos := make([]selected, len(limits))
+	for i, lim := range limits {
+		algos[i] = a.cfg.selectFor(lim)
+	}
+
 	a.mut.Lock()
 	once := newOnce()
 	defer once.Do(a.mut.Unlock)
@@ -544,16 +618,18 @@ func (a *impl) HostUsage(host Identity, limits []Limit) (usage map[Limit]HostUsa
 	}
 
 	var cumulative Metrics
+	var shadowed []divergence
 	usage = make(map[Limit]HostUsage, len(limits))
-	for _, lim := range limits {
-		hosts, used, met := a.getWeightsLocked(lim, snap)
+	for i, lim := range limits {
+		load, used, met := a.getLoadLocked(lim, snap)
 
 		cumulative.Limits += met.Limits // always 1 or 0
 		cumulative.HostLimits += met.HostLimits
 		cumulative.RemovedLimits += met.RemovedLimits // always 0 or 1 (opposite Limits)
 		cumulative.RemovedHostLimits += met.RemovedHostLimits
 
-		if len(hosts) > 0 {
+		if len(load) > 0 {
+			hosts := a.weighLocked(lim, algos[i].primary, load, used, snap)
 			usage[lim] = HostUsage{
 				// limit is known, has some usage on at least one host.
 				// usage has an "upper limit" because it is only the accepted RPS, not all requests received.
@@ -562,6 +638,14 @@ func (a *impl) HostUsage(host Identity, limits []Limit) (usage map[Limit]HostUsa
 				// zeros are interpreted as "unknown", the same as "not present".
 				Weight: shared.SanityLogFloat(0, hosts[host], 1, "computed weight", a.logger),
 			}
+
+			if algos[i].shadow != "" {
+				shadow := a.weighLocked(lim, algos[i].shadow, load, used, snap)
+				shadowed = append(shadowed, divergence{
+					selected: algos[i],
+					amount:   math.Abs(float64(hosts[host] - shadow[host])),
+				})
+			}
 		}
 	}
 
@@ -571,6 +655,13 @@ func (a *impl) HostUsage(host Identity, limits []Limit) (usage map[Limit]HostUsa
 	a.scope.RecordHistogramValue(metrics.GlobalRatelimiterHostLimitsQueried, float64(cumulative.HostLimits))
 	a.scope.RecordHistogramValue(metrics.GlobalRatelimiterRemovedLimits, float64(cumulative.RemovedLimits))
 	a.scope.RecordHistogramValue(metrics.GlobalRatelimiterRemovedHostLimits, float64(cumulative.RemovedHostLimits))
+	a.scope.RecordHistogramValue(metrics.GlobalRatelimiterShadowedLimits, float64(len(shadowed)))
+	for _, d := range shadowed {
+		a.scope.Tagged(
+			metrics.GlobalRatelimiterAlgorithmTag(string(d.primary)),
+			metrics.GlobalRatelimiterShadowAlgorithmTag(string(d.shadow)),
+		).RecordHistogramValue(metrics.GlobalRatelimiterShadowDivergence, d.amount)
+	}
 
 	return usage, nil
 }
@@ -599,7 +690,7 @@ func (a *impl) GC() (Metrics, error) {
 
 		// clean up stale limits
 		if len(dat) == 0 {
-			delete(a.usage, lim)
+			a.forgetLocked(lim)
 			m.RemovedLimits++
 		} else {
 			m.Limits++
@@ -619,6 +710,9 @@ func (a *impl) snapshot() (configSnapshot, error) {
 		decayAfter: a.cfg.DecayAfter(),
 		gcAfter:    a.cfg.GcAfter(),
 	}
+	if a.cfg.BurstCreditWindow != nil {
+		snap.burstAfter = a.cfg.BurstCreditWindow()
+	}
 	return snap, snap.validate()
 }
 
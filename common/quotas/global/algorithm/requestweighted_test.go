@@ -94,10 +94,11 @@ func newForTest(t testlogger.TestingT, snap configSnapshot, validate bool) (*imp
 	} else {
 		// need to build by hand, New returns nil on err
 		agg = &impl{
-			cfg:   cfg,
-			scope: metrics.NewNoopMetricsClient().Scope(metrics.GlobalRatelimiterAggregator),
-			usage: make(map[Limit]map[Identity]requests),
-			clock: nil,
+			cfg:      cfg,
+			scope:    metrics.NewNoopMetricsClient().Scope(metrics.GlobalRatelimiterAggregator),
+			usage:    make(map[Limit]map[Identity]requests),
+			weighers: newWeighers(),
+			clock:    nil,
 		}
 	}
 
@@ -200,6 +201,7 @@ func TestEmitsMetrics(t *testing.T) {
 		"host_limits_queried": {2: 1}, // two hosts have data for that limit
 		"removed_limits":      {0: 1}, // none removed
 		"removed_host_limits": {0: 1}, // none removed
+		"shadowed_limits":     {0: 1}, // shadowing is not configured
 	})
 }
 
@@ -463,7 +465,7 @@ func TestRapidlyCoalesces(t *testing.T) {
 	key := Limit("start workflow")
 	h1, h2, h3 := Identity("one"), Identity("two"), Identity("three")
 
-	weights, used, met := agg.getWeightsLocked(key, snapshot())
+	weights, used, met := agg.getWeightsLocked(key, RequestWeightedAlgorithm, snapshot())
 	assert.Zero(t, weights, "should have no weights")
 	assert.Zero(t, used, "should have no used RPS")
 	assert.Zero(t, met, "should have processed no data while calculating")
@@ -493,7 +495,7 @@ func TestRapidlyCoalesces(t *testing.T) {
 	// which feels pretty reasonable: after ~10 seconds (3s updates), the oldest data only has ~10% weight.
 	const target = 10 + 200 + 999
 	for i := 0; i < 4; i++ {
-		weights, used, met = agg.getWeightsLocked(key, snapshot())
+		weights, used, met = agg.getWeightsLocked(key, RequestWeightedAlgorithm, snapshot())
 		t.Log("used:", used, "of actual:", target)
 		t.Log("weights so far:", weights)
 		t.Log("calculation metrics:", met)
@@ -501,7 +503,7 @@ func TestRapidlyCoalesces(t *testing.T) {
 		push(h2, 200, 200)
 		push(h3, 999, 999)
 	}
-	weights, used, met = agg.getWeightsLocked(key, snapshot())
+	weights, used, met = agg.getWeightsLocked(key, RequestWeightedAlgorithm, snapshot())
 	t.Log("used:", used, "of actual:", target)
 	t.Log("weights so far:", weights)
 	t.Log("calculation metrics:", met)
@@ -651,7 +653,7 @@ func TestSimulate(t *testing.T) {
 
 	snap, err := agg.snapshot()
 	require.NoError(t, err)
-	weights, used, met := agg.getWeightsLocked(start, snap)
+	weights, used, met := agg.getWeightsLocked(start, RequestWeightedAlgorithm, snap)
 	assert.Zero(t, weights, "should have no weights")
 	assert.Zero(t, used, "should have no used RPS")
 	assert.Zero(t, met, "should have processed no data while calculating")
@@ -0,0 +1,125 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package algorithm
+
+// Algorithm is the name of a weighting algorithm, as used in dynamic config.
+//
+// All algorithms share the same running-average request data, and only differ in
+// how that data is turned into per-host weights.  This means a Limit can be moved
+// between algorithms (or shadowed by one) at any time, without a warm-up period.
+type Algorithm string
+
+const (
+	// RequestWeightedAlgorithm weights hosts by their share of all requests received,
+	// accepted or rejected.  This is the original, and default, algorithm.
+	RequestWeightedAlgorithm Algorithm = "request-weighted"
+	// MaxMinFairAlgorithm gives every host an equal share of the limit, capped at what it
+	// actually requests, and redistributes the unused remainder between the busier hosts.
+	MaxMinFairAlgorithm Algorithm = "max-min-fair"
+	// BurstCreditAlgorithm is RequestWeightedAlgorithm, but hosts using less than an even share
+	// save up credit, which is spent to follow sudden increases in load without waiting for
+	// the running average to catch up.
+	BurstCreditAlgorithm Algorithm = "burst-credit"
+)
+
+type (
+	// weigher computes the relative weight of each host for a single Limit.
+	//
+	// Returned values do not need to be normalized, they are scaled so they sum to 1
+	// by the caller.  They must be positive, and must contain every host in load.
+	//
+	// Weighers are called while holding the aggregator's lock, so they do not need
+	// to synchronize any state they keep.
+	weigher interface {
+		// weights is only called with at least one host in load, and all loads are positive.
+		weights(key Limit, load map[Identity]hostLoad, usedRPS PerSecond, snap configSnapshot) map[Identity]PerSecond
+		// forget is called when a Limit is garbage collected, to clean up any per-Limit state.
+		forget(key Limit)
+	}
+
+	// hostLoad is a host's decay-adjusted request data for a single Limit.
+	hostLoad struct {
+		demand PerSecond // running average of accepted + rejected requests
+		latest PerSecond // accepted + rejected requests in the most recent update
+	}
+
+	// selected holds the dynamic-config-selected algorithms for a Limit.
+	// shadow is empty when it should not be computed.
+	selected struct {
+		primary, shadow Algorithm
+	}
+
+	// divergence is how much a shadow algorithm's weight differed from the primary one.
+	divergence struct {
+		selected
+		amount float64
+	}
+
+	requestWeighted struct{}
+)
+
+func newWeighers() map[Algorithm]weigher {
+	return map[Algorithm]weigher{
+		RequestWeightedAlgorithm: requestWeighted{},
+		MaxMinFairAlgorithm:      maxMinFair{},
+		BurstCreditAlgorithm:     newBurstCredit(),
+	}
+}
+
+func (a Algorithm) valid() bool {
+	switch a {
+	case RequestWeightedAlgorithm, MaxMinFairAlgorithm, BurstCreditAlgorithm:
+		return true
+	default:
+		return false
+	}
+}
+
+// selectFor returns the algorithms to use for a Limit.
+//
+// Bad values are intentionally not errors, as an aggregator cannot do anything useful
+// with a failed request: they fall back to request-weighting and no shadow instead.
+func (c Config) selectFor(key Limit) selected {
+	sel := selected{primary: RequestWeightedAlgorithm}
+	if c.Algorithm != nil {
+		if primary := Algorithm(c.Algorithm(string(key))); primary.valid() {
+			sel.primary = primary
+		}
+	}
+	if c.ShadowAlgorithm != nil {
+		if shadow := Algorithm(c.ShadowAlgorithm(string(key))); shadow.valid() && shadow != sel.primary {
+			sel.shadow = shadow
+		}
+	}
+	return sel
+}
+
+func (requestWeighted) weights(_ Limit, load map[Identity]hostLoad, _ PerSecond, _ configSnapshot) map[Identity]PerSecond {
+	result := make(map[Identity]PerSecond, len(load))
+	for id, l := range load {
+		result[id] = l.demand
+	}
+	return result
+}
+
+func (requestWeighted) forget(Limit) {}
@@ -0,0 +1,167 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package algorithm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/metrics"
+)
+
+func TestSelectFor(t *testing.T) {
+	byKey := func(m map[string]string) dynamicproperties.StringPropertyWithRatelimitKeyFilter {
+		return func(key string) string {
+			return m[key]
+		}
+	}
+	cfg := Config{
+		Algorithm: byKey(map[string]string{
+			"fair":    string(MaxMinFairAlgorithm),
+			"burst":   string(BurstCreditAlgorithm),
+			"unknown": "not an algorithm",
+			"same":    string(MaxMinFairAlgorithm),
+		}),
+		ShadowAlgorithm: byKey(map[string]string{
+			"fair":    string(BurstCreditAlgorithm),
+			"unknown": "also not an algorithm",
+			"same":    string(MaxMinFairAlgorithm),
+		}),
+	}
+	assert.Equal(t, selected{primary: MaxMinFairAlgorithm, shadow: BurstCreditAlgorithm}, cfg.selectFor("fair"))
+	assert.Equal(t, selected{primary: BurstCreditAlgorithm}, cfg.selectFor("burst"))
+	assert.Equal(t, selected{primary: RequestWeightedAlgorithm}, cfg.selectFor("unknown"), "bad values should fall back to defaults")
+	assert.Equal(t, selected{primary: MaxMinFairAlgorithm}, cfg.selectFor("same"), "shadowing the primary algorithm is pointless")
+	assert.Equal(t, selected{primary: RequestWeightedAlgorithm}, Config{}.selectFor("any"), "nil config should use defaults")
+}
+
+func TestMaxMinFair(t *testing.T) {
+	load := map[Identity]hostLoad{
+		"small":  {demand: 10},
+		"medium": {demand: 40},
+		"large":  {demand: 150},
+	}
+	t.Run("unsaturated", func(t *testing.T) {
+		// nothing is rejected, so everyone gets what they ask for
+		weights := maxMinFair{}.weights("key", load, 200, configSnapshot{})
+		assert.Equal(t, map[Identity]PerSecond{"small": 10, "medium": 40, "large": 150}, weights)
+	})
+	t.Run("saturated", func(t *testing.T) {
+		// 100 rps to share: small keeps 10, then 90 split between medium (keeps 40) and large (gets the remaining 50)
+		weights := maxMinFair{}.weights("key", load, 100, configSnapshot{})
+		assert.InDeltaMapValues(t, map[Identity]float64{"small": 10, "medium": 40, "large": 50}, floaty(weights), 0.0001)
+	})
+	t.Run("heavily saturated", func(t *testing.T) {
+		// 30 rps to share: small keeps 10, then the others split 20 evenly
+		weights := maxMinFair{}.weights("key", load, 30, configSnapshot{})
+		assert.InDeltaMapValues(t, map[Identity]float64{"small": 10, "medium": 10, "large": 10}, floaty(weights), 0.0001)
+	})
+}
+
+func TestBurstCredit(t *testing.T) {
+	start := time.Now()
+	snapAt := func(elapsed time.Duration) configSnapshot {
+		return configSnapshot{now: start.Add(elapsed), burstAfter: 10 * time.Second}
+	}
+	b := newBurstCredit()
+
+	// a quiet host and a busy one: even share is 50 rps, quiet host saves 40 rps of unused share per second
+	steady := map[Identity]hostLoad{
+		"quiet": {demand: 10, latest: 10},
+		"busy":  {demand: 90, latest: 90},
+	}
+	weights := b.weights("key", steady, 100, snapAt(0))
+	assert.Equal(t, map[Identity]PerSecond{"quiet": 10, "busy": 90}, weights, "should match request-weighting without credit")
+	weights = b.weights("key", steady, 100, snapAt(time.Second))
+	assert.Equal(t, map[Identity]PerSecond{"quiet": 10, "busy": 90}, weights, "saving credit should not change weights")
+	assert.Equal(t, PerSecond(40), b.limits["key"].hosts["quiet"])
+	assert.Zero(t, b.limits["key"].hosts["busy"])
+
+	// saving is capped at the burst window worth of even share: 10s * 50rps
+	b.weights("key", steady, 100, snapAt(time.Minute))
+	assert.Equal(t, PerSecond(500), b.limits["key"].hosts["quiet"])
+
+	// the quiet host suddenly spikes, its running average lags behind but credit lets it follow the latest load
+	spiking := map[Identity]hostLoad{
+		"quiet": {demand: 20, latest: 110},
+		"busy":  {demand: 90, latest: 90},
+	}
+	weights = b.weights("key", spiking, 100, snapAt(time.Minute+time.Second))
+	assert.Equal(t, map[Identity]PerSecond{"quiet": 110, "busy": 90}, weights)
+	assert.Equal(t, PerSecond(500-90), b.limits["key"].hosts["quiet"], "should have spent the extra 90 rps for 1 second")
+
+	// removed hosts and limits lose their credit
+	weights = b.weights("key", map[Identity]hostLoad{"busy": {demand: 90, latest: 90}}, 100, snapAt(time.Minute+2*time.Second))
+	assert.Equal(t, map[Identity]PerSecond{"busy": 90}, weights)
+	assert.NotContains(t, b.limits["key"].hosts, Identity("quiet"))
+	b.forget("key")
+	assert.Empty(t, b.limits)
+}
+
+func TestShadowAlgorithm(t *testing.T) {
+	agg, _ := newValid(t, defaultConfig(time.Second))
+	agg.cfg.Algorithm = func(string) string { return string(RequestWeightedAlgorithm) }
+	agg.cfg.ShadowAlgorithm = func(string) string { return string(MaxMinFairAlgorithm) }
+	ts := tally.NewTestScope("test", nil)
+	agg.scope = metrics.NewClient(ts, metrics.History).Scope(metrics.GlobalRatelimiterAggregator)
+
+	key := Limit("key")
+	h1, h2 := Identity("host 1"), Identity("host 2")
+	// 40 rps accepted of 100 rps requested, so max-min-fair and request-weighted disagree:
+	// h1 is weighted at 0.2 by request-weighting, but is fully satisfied by max-min-fair's 20 rps.
+	require.NoError(t, agg.Update(UpdateParams{ID: h1, Load: map[Limit]Requests{key: {Accepted: 10, Rejected: 10}}, Elapsed: time.Second}))
+	require.NoError(t, agg.Update(UpdateParams{ID: h2, Load: map[Limit]Requests{key: {Accepted: 30, Rejected: 50}}, Elapsed: time.Second}))
+
+	usage, err := agg.HostUsage(h1, []Limit{key})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, float64(usage[key].Weight), 0.0001, "primary algorithm weight should be returned")
+
+	var found bool
+	for _, h := range ts.Snapshot().Histograms() {
+		if !strings.HasSuffix(h.Name(), "global_ratelimiter_shadow_divergence") {
+			continue
+		}
+		found = true
+		assert.Equal(t, string(RequestWeightedAlgorithm), h.Tags()["global_ratelimit_algorithm"])
+		assert.Equal(t, string(MaxMinFairAlgorithm), h.Tags()["global_ratelimit_shadow_algorithm"])
+		// max-min-fair weight is 20/40 = 0.5, so they differ by 0.3
+		assert.Equal(t, map[float64]int64{0.3: 1}, nonZero(h.Values()))
+	}
+	assert.True(t, found, "divergence should have been recorded")
+}
+
+func nonZero(m map[float64]int64) map[float64]int64 {
+	result := make(map[float64]int64)
+	for k, v := range m {
+		if v != 0 {
+			result[k] = v
+		}
+	}
+	return result
+}
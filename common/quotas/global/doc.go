@@ -38,9 +38,9 @@ At a very high level, this will:
 The exact logic that the "aggregating" hosts use is intentionally hidden from the
 "limiting" hosts, so it can be changed without changing how they enforce limits.
 
-The weight-calculation algorithm will (eventually) be controlled by a dynamicconfig value,
-to allow shadowing and experimenting with different algorithms at runtime, or disabling
-the global logic altogether.
+The weight-calculation algorithm is controlled per key by dynamicconfig values,
+to allow shadowing and experimenting with different algorithms at runtime.  Disabling
+the global logic altogether is controlled by the limiting hosts' key modes.
 
 See sub-packages for implementation details.
 
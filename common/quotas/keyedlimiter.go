@@ -0,0 +1,67 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package quotas
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/common/clock"
+)
+
+// keyedLimiter is a Limiter which looks up its key in an ICollection on every call.
+type keyedLimiter struct {
+	collection ICollection
+	key        string
+}
+
+var _ Limiter = keyedLimiter{}
+
+// NewKeyedLimiter returns a Limiter that uses collection.For(key) for every call.
+//
+// This is intended for collections that may change which limiter is returned for a key
+// over time (e.g. the global ratelimiter collection, which can switch modes or garbage
+// collect idle keys), where holding on to a single returned Limiter would miss those changes.
+func NewKeyedLimiter(collection ICollection, key string) Limiter {
+	return keyedLimiter{
+		collection: collection,
+		key:        key,
+	}
+}
+
+func (k keyedLimiter) Allow() bool {
+	return k.collection.For(k.key).Allow()
+}
+
+func (k keyedLimiter) Wait(ctx context.Context) error {
+	return k.collection.For(k.key).Wait(ctx)
+}
+
+func (k keyedLimiter) Reserve() clock.Reservation {
+	return k.collection.For(k.key).Reserve()
+}
+
+func (k keyedLimiter) Limit() rate.Limit {
+	return k.collection.For(k.key).Limit()
+}
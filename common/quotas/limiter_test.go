@@ -238,6 +238,19 @@ func TestDynamicRateLimiter_Wait(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestKeyedLimiter(t *testing.T) {
+	collection := NewCollection(newStubFactory(t, 1))
+	limiter := NewKeyedLimiter(collection, defaultDomain)
+
+	assert.Equal(t, rate.Limit(1), limiter.Limit())
+	assert.True(t, limiter.Allow(), "first should work")
+	assert.False(t, limiter.Allow(), "second should be limited")
+	rsv := limiter.Reserve()
+	assert.False(t, rsv.Allow(), "reservation should share the same limiter")
+	rsv.Used(false)
+	assert.True(t, collection.For("other").Allow(), "other keys should not be affected")
+}
+
 func newFixedRpsMultiStageRateLimiter(t testing.TB, globalRps float64, domainRps int) Policy {
 	return NewMultiStageRateLimiter(
 		NewDynamicRateLimiter(func() float64 {
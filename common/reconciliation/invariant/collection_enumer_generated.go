@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStale"
+const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStaleCollectionReconciliation"
 
-var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70}
+var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70, 94}
 
-const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstale"
+const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstalecollectionreconciliation"
 
 func (i Collection) String() string {
 	if i < 0 || i >= Collection(len(_CollectionIndex)-1) {
@@ -28,9 +28,10 @@ func _CollectionNoOp() {
 	_ = x[CollectionHistory-(1)]
 	_ = x[CollectionDomain-(2)]
 	_ = x[CollectionStale-(3)]
+	_ = x[CollectionReconciliation-(4)]
 }
 
-var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale}
+var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale, CollectionReconciliation}
 
 var _CollectionNameToValueMap = map[string]Collection{
 	_CollectionName[0:22]:       CollectionMutableState,
@@ -41,6 +42,8 @@ var _CollectionNameToValueMap = map[string]Collection{
 	_CollectionLowerName[39:55]: CollectionDomain,
 	_CollectionName[55:70]:      CollectionStale,
 	_CollectionLowerName[55:70]: CollectionStale,
+	_CollectionName[70:94]:      CollectionReconciliation,
+	_CollectionLowerName[70:94]: CollectionReconciliation,
 }
 
 var _CollectionNames = []string{
@@ -48,6 +51,7 @@ var _CollectionNames = []string{
 	_CollectionName[22:39],
 	_CollectionName[39:55],
 	_CollectionName[55:70],
+	_CollectionName[70:94],
 }
 
 // CollectionString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,135 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// ChecksumVerifier verifies the stored checksum of mutable state, returning checksum.ErrMismatch on mismatch
+	ChecksumVerifier func(*persistence.WorkflowMutableState) error
+
+	mutableStateChecksumValid struct {
+		pr               persistence.Retryer
+		dc               cache.DomainCache
+		verify           ChecksumVerifier
+		invalidateBefore time.Time
+	}
+)
+
+// NewMutableStateChecksumValid returns an invariant checking that mutable state matches its stored checksum.
+// The checksum payload is owned by the history service, so it is verified through the provided verifier.
+// Checksums of executions last updated before invalidateBefore are ignored, the same as by the history service
+// with MutableStateChecksumInvalidateBefore. A zero invalidateBefore does not ignore any checksum.
+//
+// Like the history service, which only logs checksum mismatches, this invariant only reports them and never fixes them.
+func NewMutableStateChecksumValid(
+	pr persistence.Retryer, dc cache.DomainCache, verify ChecksumVerifier, invalidateBefore time.Time,
+) Invariant {
+	return &mutableStateChecksumValid{
+		pr:               pr,
+		dc:               dc,
+		verify:           verify,
+		invalidateBefore: invalidateBefore,
+	}
+}
+
+func (m *mutableStateChecksumValid) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, m.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	_, state, _, checkResult := getConcreteExecutionState(ctx, m.Name(), execution, m.pr, m.dc)
+	if checkResult != nil {
+		return *checkResult
+	}
+	if len(state.Checksum.Value) == 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   m.Name(),
+			Info:            "determined execution was healthy because it has no checksum",
+		}
+	}
+	if !m.invalidateBefore.IsZero() && state.ExecutionInfo.LastUpdatedTimestamp.Before(m.invalidateBefore) {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   m.Name(),
+			Info:            "determined execution was healthy because its checksum was invalidated",
+		}
+	}
+	if err := m.verify(state); err != nil {
+		if errors.Is(err, checksum.ErrMismatch) {
+			return CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   m.Name(),
+				Info:            "mutable state does not match its checksum",
+				InfoDetails:     err.Error(),
+			}
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   m.Name(),
+			Info:            "failed to verify mutable state checksum",
+			InfoDetails:     err.Error(),
+		}
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   m.Name(),
+	}
+}
+
+// Fix never changes the execution, a checksum mismatch does not tell which part of mutable state is wrong
+func (m *mutableStateChecksumValid) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, m.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, m, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	return FixResult{
+		FixResultType: FixResultTypeSkipped,
+		InvariantName: m.Name(),
+		CheckResult:   *checkResult,
+		Info:          "skipped fix because checksum mismatches are only reported",
+	}
+}
+
+func (m *mutableStateChecksumValid) Name() Name {
+	return MutableStateChecksumValid
+}
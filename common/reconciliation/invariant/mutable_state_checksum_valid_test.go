@@ -0,0 +1,143 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestMutableStateChecksumValid_Check(t *testing.T) {
+	stateWithChecksum := &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10},
+		Checksum:      checksum.Checksum{Version: 1, Flavor: checksum.FlavorIEEECRC32OverThriftBinary, Value: []byte{1}},
+	}
+
+	invalidateBefore := time.Unix(1000, 0)
+	stateWithInvalidatedChecksum := &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10, LastUpdatedTimestamp: invalidateBefore.Add(-time.Second)},
+		Checksum:      stateWithChecksum.Checksum,
+	}
+	stateWithChecksum.ExecutionInfo.LastUpdatedTimestamp = invalidateBefore
+
+	testCases := []struct {
+		name           string
+		state          *persistence.WorkflowMutableState
+		verifyErr      error
+		expectedResult CheckResult
+	}{
+		{
+			name:  "no checksum",
+			state: &persistence.WorkflowMutableState{ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   MutableStateChecksumValid,
+				Info:            "determined execution was healthy because it has no checksum",
+			},
+		},
+		{
+			name:      "checksum invalidated",
+			state:     stateWithInvalidatedChecksum,
+			verifyErr: checksum.ErrMismatch,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   MutableStateChecksumValid,
+				Info:            "determined execution was healthy because its checksum was invalidated",
+			},
+		},
+		{
+			name:      "checksum mismatch",
+			state:     stateWithChecksum,
+			verifyErr: checksum.ErrMismatch,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   MutableStateChecksumValid,
+				Info:            "mutable state does not match its checksum",
+				InfoDetails:     checksum.ErrMismatch.Error(),
+			},
+		},
+		{
+			name:      "failed to verify",
+			state:     stateWithChecksum,
+			verifyErr: errors.New("invalid checksum payload version 2"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   MutableStateChecksumValid,
+				Info:            "failed to verify mutable state checksum",
+				InfoDetails:     "invalid checksum payload version 2",
+			},
+		},
+		{
+			name:  "checksum matches",
+			state: stateWithChecksum,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   MutableStateChecksumValid,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			retryer := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{State: tc.state}, nil)
+			verify := func(state *persistence.WorkflowMutableState) error {
+				assert.Equal(t, tc.state, state)
+				return tc.verifyErr
+			}
+
+			result := NewMutableStateChecksumValid(retryer, domainCache, verify, invalidateBefore).Check(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestMutableStateChecksumValid_Fix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	retryer := persistence.NewMockRetryer(ctrl)
+	domainCache := cache.NewMockDomainCache(ctrl)
+	domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+	retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{State: &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10},
+		Checksum:      checksum.Checksum{Value: []byte{1}},
+	}}, nil)
+	verify := func(*persistence.WorkflowMutableState) error { return checksum.ErrMismatch }
+
+	// no DeleteWorkflowExecution is expected, mismatches are only reported
+	result := NewMutableStateChecksumValid(retryer, domainCache, verify, time.Time{}).Fix(context.Background(), getClosedConcreteExecution())
+	assert.Equal(t, FixResultTypeSkipped, result.FixResultType)
+	assert.Equal(t, MutableStateChecksumValid, result.InvariantName)
+	assert.Equal(t, CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+}
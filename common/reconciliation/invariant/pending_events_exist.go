@@ -0,0 +1,165 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	pendingEventsExist struct {
+		pr persistence.Retryer
+		dc cache.DomainCache
+	}
+)
+
+// NewPendingEventsExist returns an invariant checking that pending activities, children, timers and signals
+// in mutable state have their scheduled events in history
+func NewPendingEventsExist(
+	pr persistence.Retryer, dc cache.DomainCache,
+) Invariant {
+	return &pendingEventsExist{
+		pr: pr,
+		dc: dc,
+	}
+}
+
+func (p *pendingEventsExist) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, p.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, state, domainName, checkResult := getConcreteExecutionState(ctx, p.Name(), execution, p.pr, p.dc)
+	if checkResult != nil {
+		return *checkResult
+	}
+
+	expected := make(map[int64]types.EventType)
+	for scheduleID := range state.ActivityInfos {
+		expected[scheduleID] = types.EventTypeActivityTaskScheduled
+	}
+	for _, timerInfo := range state.TimerInfos {
+		expected[timerInfo.StartedID] = types.EventTypeTimerStarted
+	}
+	for initiatedID := range state.ChildExecutionInfos {
+		expected[initiatedID] = types.EventTypeStartChildWorkflowExecutionInitiated
+	}
+	for initiatedID := range state.SignalInfos {
+		expected[initiatedID] = types.EventTypeSignalExternalWorkflowExecutionInitiated
+	}
+	if len(expected) == 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   p.Name(),
+		}
+	}
+
+	err := iterateHistoryEvents(ctx, p.pr, concreteExecution, state, domainName, func(event *types.HistoryEvent) {
+		if eventType, ok := expected[event.ID]; ok && event.GetEventType() == eventType {
+			delete(expected, event.ID)
+		}
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   p.Name(),
+				Info:            "concrete execution exists but history does not exist",
+				InfoDetails:     err.Error(),
+			}
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   p.Name(),
+			Info:            "failed to read history",
+			InfoDetails:     err.Error(),
+		}
+	}
+	if len(expected) > 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   p.Name(),
+			Info:            "pending events in mutable state are missing from history",
+			InfoDetails:     missingEventsDetails(expected),
+		}
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   p.Name(),
+	}
+}
+
+// Fix deletes closed executions. Pending activities, children, timers and signals are usually left on open executions,
+// whose mutable state is owned by history and cannot be changed here, so Fix only reports them as failed to be fixed
+// for an operator to terminate or reset.
+func (p *pendingEventsExist) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, p.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, p, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	if ExecutionOpen(execution) {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: p.Name(),
+			CheckResult:   *checkResult,
+			Info:          "open execution has to be terminated or reset to fix its pending events",
+			InfoDetails:   checkResult.InfoDetails,
+		}
+	}
+	return deleteClosedExecution(ctx, p.Name(), execution, *checkResult, p.pr, p.dc)
+}
+
+func (p *pendingEventsExist) Name() Name {
+	return PendingEventsExist
+}
+
+// missingEventsDetails lists the missing events ordered by ID, e.g. "5:ActivityTaskScheduled, 7:TimerStarted"
+func missingEventsDetails(missing map[int64]types.EventType) string {
+	eventIDs := make([]int64, 0, len(missing))
+	for eventID := range missing {
+		eventIDs = append(eventIDs, eventID)
+	}
+	slices.Sort(eventIDs)
+	details := make([]string, 0, len(eventIDs))
+	for _, eventID := range eventIDs {
+		details = append(details, fmt.Sprintf("%v:%v", eventID, missing[eventID]))
+	}
+	return strings.Join(details, ", ")
+}
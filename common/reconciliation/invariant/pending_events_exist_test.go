@@ -0,0 +1,212 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestPendingEventsExist_Check(t *testing.T) {
+	pendingState := &persistence.WorkflowMutableState{
+		ExecutionInfo:       &persistence.WorkflowExecutionInfo{NextEventID: 10},
+		ActivityInfos:       map[int64]*persistence.ActivityInfo{5: {ScheduleID: 5}},
+		TimerInfos:          map[string]*persistence.TimerInfo{"timer": {StartedID: 6}},
+		ChildExecutionInfos: map[int64]*persistence.ChildExecutionInfo{7: {InitiatedID: 7}},
+		SignalInfos:         map[int64]*persistence.SignalInfo{8: {InitiatedID: 8}},
+	}
+	allEvents := []*types.HistoryEvent{
+		{ID: 5, EventType: types.EventTypeActivityTaskScheduled.Ptr()},
+		{ID: 6, EventType: types.EventTypeTimerStarted.Ptr()},
+		{ID: 7, EventType: types.EventTypeStartChildWorkflowExecutionInitiated.Ptr()},
+		{ID: 8, EventType: types.EventTypeSignalExternalWorkflowExecutionInitiated.Ptr()},
+	}
+
+	testCases := []struct {
+		name           string
+		getExecResp    *persistence.GetWorkflowExecutionResponse
+		getExecErr     error
+		historyPages   []*persistence.ReadHistoryBranchResponse
+		historyErr     error
+		expectedResult CheckResult
+	}{
+		{
+			name:       "execution no longer exists",
+			getExecErr: &types.EntityNotExistsError{},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   PendingEventsExist,
+				Info:            "determined execution was healthy because concrete execution no longer exists",
+			},
+		},
+		{
+			name:       "failed to get execution",
+			getExecErr: errors.New("persistence error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   PendingEventsExist,
+				Info:            "failed to get concrete execution",
+				InfoDetails:     "persistence error",
+			},
+		},
+		{
+			name: "nothing pending",
+			getExecResp: &persistence.GetWorkflowExecutionResponse{State: &persistence.WorkflowMutableState{
+				ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10},
+			}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   PendingEventsExist,
+			},
+		},
+		{
+			name:         "all pending events exist across pages",
+			getExecResp:  &persistence.GetWorkflowExecutionResponse{State: pendingState},
+			historyPages: []*persistence.ReadHistoryBranchResponse{{HistoryEvents: allEvents[:2], NextPageToken: []byte("next")}, {HistoryEvents: allEvents[2:]}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   PendingEventsExist,
+			},
+		},
+		{
+			name:        "pending events missing or of wrong type",
+			getExecResp: &persistence.GetWorkflowExecutionResponse{State: pendingState},
+			historyPages: []*persistence.ReadHistoryBranchResponse{{HistoryEvents: []*types.HistoryEvent{
+				allEvents[0],
+				{ID: 6, EventType: types.EventTypeTimerFired.Ptr()},
+				allEvents[3],
+			}}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   PendingEventsExist,
+				Info:            "pending events in mutable state are missing from history",
+				InfoDetails:     "6:TimerStarted, 7:StartChildWorkflowExecutionInitiated",
+			},
+		},
+		{
+			name:        "history does not exist",
+			getExecResp: &persistence.GetWorkflowExecutionResponse{State: pendingState},
+			historyErr:  &types.EntityNotExistsError{Message: "history not found"},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   PendingEventsExist,
+				Info:            "concrete execution exists but history does not exist",
+				InfoDetails:     "history not found",
+			},
+		},
+		{
+			name:        "failed to read history",
+			getExecResp: &persistence.GetWorkflowExecutionResponse{State: pendingState},
+			historyErr:  errors.New("persistence error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   PendingEventsExist,
+				Info:            "failed to read history",
+				InfoDetails:     "persistence error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			retryer := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(tc.getExecResp, tc.getExecErr)
+			expectHistoryPages(retryer, tc.historyPages, tc.historyErr)
+
+			result := NewPendingEventsExist(retryer, domainCache).Check(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPendingEventsExist_Fix(t *testing.T) {
+	testCases := []struct {
+		name           string
+		execution      *entity.ConcreteExecution
+		expectDelete   bool
+		expectedResult FixResultType
+		expectedInfo   string
+	}{
+		{
+			name:           "closed execution is deleted",
+			execution:      getClosedConcreteExecution(),
+			expectDelete:   true,
+			expectedResult: FixResultTypeFixed,
+		},
+		{
+			name:           "open execution is reported",
+			execution:      getOpenConcreteExecution(),
+			expectedResult: FixResultTypeFailed,
+			expectedInfo:   "open execution has to be terminated or reset to fix its pending events",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			retryer := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{State: &persistence.WorkflowMutableState{
+				ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10},
+				ActivityInfos: map[int64]*persistence.ActivityInfo{5: {ScheduleID: 5}},
+			}}, nil)
+			expectHistoryPages(retryer, []*persistence.ReadHistoryBranchResponse{{}}, nil)
+			if tc.expectDelete {
+				retryer.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil)
+				retryer.EXPECT().DeleteCurrentWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			result := NewPendingEventsExist(retryer, domainCache).Fix(context.Background(), tc.execution)
+			assert.Equal(t, tc.expectedResult, result.FixResultType)
+			assert.Equal(t, tc.expectedInfo, result.Info)
+			assert.Equal(t, PendingEventsExist, result.InvariantName)
+			assert.Equal(t, CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+		})
+	}
+}
+
+// expectHistoryPages sets up reading the history branch page by page, or failing with err
+func expectHistoryPages(retryer *persistence.MockRetryer, pages []*persistence.ReadHistoryBranchResponse, err error) {
+	if err != nil {
+		retryer.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(nil, err)
+		return
+	}
+	var calls []any
+	for _, page := range pages {
+		calls = append(calls, retryer.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(page, nil))
+	}
+	gomock.InOrder(calls...)
+}
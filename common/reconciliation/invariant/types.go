@@ -57,6 +57,15 @@ const (
 	// implying a failed cleanup / lost timers / etc of some kind.
 	StaleWorkflow Name = "stale_workflow"
 
+	// PendingEventsExist asserts that the scheduled events of pending activities, children, timers
+	// and signals in mutable state exist in history
+	PendingEventsExist Name = "pending_events_exist"
+	// VersionHistoriesConsistent asserts that the last item of the current version history
+	// matches the last event persisted in history
+	VersionHistoriesConsistent Name = "version_histories_consistent"
+	// MutableStateChecksumValid asserts that mutable state matches its stored checksum
+	MutableStateChecksumValid Name = "mutable_state_checksum_valid"
+
 	// CollectionMutableState is the collection of invariants relating to mutable state
 	CollectionMutableState Collection = 0
 	// CollectionHistory is the collection  of invariants relating to history
@@ -65,6 +74,8 @@ const (
 	CollectionDomain Collection = 2
 	// CollectionStale contains the stale workflow scanner
 	CollectionStale Collection = 3
+	// CollectionReconciliation is the collection of invariants relating to consistency between mutable state and history
+	CollectionReconciliation Collection = 4
 )
 
 type (
@@ -25,9 +25,17 @@ package invariant
 import (
 	"context"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// historyBranchPageSize is the number of event batches read per page when reading a whole history branch
+	historyBranchPageSize = 100
 )
 
 func checkBeforeFix(
@@ -55,6 +63,30 @@ func checkBeforeFix(
 	return nil, &checkResult
 }
 
+// deleteClosedExecution deletes a corrupted execution if it is closed. Open executions are skipped,
+// as deleting them would lose in-flight workflows, they have to be repaired by resetting or terminating them.
+func deleteClosedExecution(
+	ctx context.Context,
+	name Name,
+	execution interface{},
+	checkResult CheckResult,
+	pr persistence.Retryer,
+	dc cache.DomainCache,
+) FixResult {
+	if ExecutionOpen(execution) {
+		return FixResult{
+			FixResultType: FixResultTypeSkipped,
+			InvariantName: name,
+			CheckResult:   checkResult,
+			Info:          "skipped fix because execution is open",
+		}
+	}
+	fixResult := DeleteExecution(ctx, execution, pr, dc)
+	fixResult.CheckResult = checkResult
+	fixResult.InvariantName = name
+	return *fixResult
+}
+
 // Open returns true if workflow state is open false if workflow is closed
 func Open(state int) bool {
 	return state == persistence.WorkflowStateCreated || state == persistence.WorkflowStateRunning
@@ -154,3 +186,95 @@ func validateFixContext(
 
 	return nil
 }
+
+// getConcreteExecutionState returns the mutable state of a concrete execution along with its domain name.
+// A non-nil CheckResult is returned when the execution could not be loaded, or no longer exists.
+func getConcreteExecutionState(
+	ctx context.Context,
+	invariantName Name,
+	execution interface{},
+	pr persistence.Retryer,
+	dc cache.DomainCache,
+) (*entity.ConcreteExecution, *persistence.WorkflowMutableState, string, *CheckResult) {
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return nil, nil, "", &CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   invariantName,
+			Info:            "failed to check: expected concrete execution",
+		}
+	}
+	domainName, err := dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return nil, nil, "", &CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   invariantName,
+			Info:            "failed to check: expected DomainName",
+			InfoDetails:     err.Error(),
+		}
+	}
+	resp, err := pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, nil, "", &CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   invariantName,
+				Info:            "determined execution was healthy because concrete execution no longer exists",
+			}
+		}
+		return nil, nil, "", &CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   invariantName,
+			Info:            "failed to get concrete execution",
+			InfoDetails:     err.Error(),
+		}
+	}
+	return concreteExecution, resp.State, domainName, nil
+}
+
+// iterateHistoryEvents calls fn for every event of the current branch of an execution, in order
+func iterateHistoryEvents(
+	ctx context.Context,
+	pr persistence.Retryer,
+	concreteExecution *entity.ConcreteExecution,
+	state *persistence.WorkflowMutableState,
+	domainName string,
+	fn func(*types.HistoryEvent),
+) error {
+	branchToken := concreteExecution.BranchToken
+	if state.VersionHistories != nil {
+		currentVersionHistory, err := state.VersionHistories.GetCurrentVersionHistory()
+		if err != nil {
+			return err
+		}
+		branchToken = currentVersionHistory.GetBranchToken()
+	}
+	req := &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  constants.FirstEventID,
+		MaxEventID:  state.ExecutionInfo.NextEventID,
+		PageSize:    historyBranchPageSize,
+		ShardID:     common.IntPtr(concreteExecution.ShardID),
+		DomainName:  domainName,
+	}
+	for {
+		resp, err := pr.ReadHistoryBranch(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, event := range resp.HistoryEvents {
+			fn(event)
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+}
@@ -0,0 +1,159 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	versionHistoriesConsistent struct {
+		pr persistence.Retryer
+		dc cache.DomainCache
+	}
+)
+
+// NewVersionHistoriesConsistent returns an invariant checking that the last item of the current version history
+// matches the last event persisted in history
+func NewVersionHistoriesConsistent(
+	pr persistence.Retryer, dc cache.DomainCache,
+) Invariant {
+	return &versionHistoriesConsistent{
+		pr: pr,
+		dc: dc,
+	}
+}
+
+func (v *versionHistoriesConsistent) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, v.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, state, domainName, checkResult := getConcreteExecutionState(ctx, v.Name(), execution, v.pr, v.dc)
+	if checkResult != nil {
+		return *checkResult
+	}
+	if state.VersionHistories == nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   v.Name(),
+			Info:            "determined execution was healthy because it has no version histories",
+		}
+	}
+	currentVersionHistory, err := state.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   v.Name(),
+			Info:            "current version history does not exist",
+			InfoDetails:     err.Error(),
+		}
+	}
+	lastItem, err := currentVersionHistory.GetLastItem()
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   v.Name(),
+			Info:            "current version history is empty",
+			InfoDetails:     err.Error(),
+		}
+	}
+	if lastItem.EventID != state.ExecutionInfo.NextEventID-1 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   v.Name(),
+			Info:            "version histories last item does not match next event ID",
+			InfoDetails:     fmt.Sprintf("last item event ID: %v, next event ID: %v", lastItem.EventID, state.ExecutionInfo.NextEventID),
+		}
+	}
+
+	var lastEvent *types.HistoryEvent
+	err = iterateHistoryEvents(ctx, v.pr, concreteExecution, state, domainName, func(event *types.HistoryEvent) {
+		lastEvent = event
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   v.Name(),
+				Info:            "concrete execution exists but history does not exist",
+				InfoDetails:     err.Error(),
+			}
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   v.Name(),
+			Info:            "failed to read history",
+			InfoDetails:     err.Error(),
+		}
+	}
+	if lastEvent == nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   v.Name(),
+			Info:            "concrete execution exists but got empty history",
+		}
+	}
+	if lastEvent.ID != lastItem.EventID || lastEvent.Version != lastItem.Version {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   v.Name(),
+			Info:            "version histories last item does not match last event in history",
+			InfoDetails: fmt.Sprintf(
+				"last item event ID: %v, version: %v, last event ID: %v, version: %v",
+				lastItem.EventID, lastItem.Version, lastEvent.ID, lastEvent.Version,
+			),
+		}
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   v.Name(),
+	}
+}
+
+func (v *versionHistoriesConsistent) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, v.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, v, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	return deleteClosedExecution(ctx, v.Name(), execution, *checkResult, v.pr, v.dc)
+}
+
+func (v *versionHistoriesConsistent) Name() Name {
+	return VersionHistoriesConsistent
+}
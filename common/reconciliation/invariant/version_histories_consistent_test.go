@@ -0,0 +1,161 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestVersionHistoriesConsistent_Check(t *testing.T) {
+	stateWithLastItem := func(eventID, version, nextEventID int64) *persistence.WorkflowMutableState {
+		return &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: nextEventID},
+			VersionHistories: persistence.NewVersionHistories(persistence.NewVersionHistory(branchToken, []*persistence.VersionHistoryItem{
+				persistence.NewVersionHistoryItem(eventID, version),
+			})),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		state          *persistence.WorkflowMutableState
+		historyPages   []*persistence.ReadHistoryBranchResponse
+		expectedResult CheckResult
+	}{
+		{
+			name:  "no version histories",
+			state: &persistence.WorkflowMutableState{ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: 10}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "determined execution was healthy because it has no version histories",
+			},
+		},
+		{
+			name: "empty current version history",
+			state: &persistence.WorkflowMutableState{
+				ExecutionInfo:    &persistence.WorkflowExecutionInfo{NextEventID: 10},
+				VersionHistories: persistence.NewVersionHistories(persistence.NewVersionHistory(branchToken, nil)),
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "current version history is empty",
+				InfoDetails:     "version history is empty",
+			},
+		},
+		{
+			name:  "last item does not match next event ID",
+			state: stateWithLastItem(8, 1, 10),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "version histories last item does not match next event ID",
+				InfoDetails:     "last item event ID: 8, next event ID: 10",
+			},
+		},
+		{
+			name:         "empty history",
+			state:        stateWithLastItem(9, 1, 10),
+			historyPages: []*persistence.ReadHistoryBranchResponse{{}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "concrete execution exists but got empty history",
+			},
+		},
+		{
+			name:  "last event version does not match",
+			state: stateWithLastItem(9, 1, 10),
+			historyPages: []*persistence.ReadHistoryBranchResponse{{HistoryEvents: []*types.HistoryEvent{
+				{ID: 8, Version: 1},
+				{ID: 9, Version: 2},
+			}}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "version histories last item does not match last event in history",
+				InfoDetails:     "last item event ID: 9, version: 1, last event ID: 9, version: 2",
+			},
+		},
+		{
+			name:  "last event is missing",
+			state: stateWithLastItem(9, 1, 10),
+			historyPages: []*persistence.ReadHistoryBranchResponse{{HistoryEvents: []*types.HistoryEvent{
+				{ID: 7, Version: 1},
+			}}},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VersionHistoriesConsistent,
+				Info:            "version histories last item does not match last event in history",
+				InfoDetails:     "last item event ID: 9, version: 1, last event ID: 7, version: 1",
+			},
+		},
+		{
+			name:  "consistent",
+			state: stateWithLastItem(9, 1, 10),
+			historyPages: []*persistence.ReadHistoryBranchResponse{
+				{HistoryEvents: []*types.HistoryEvent{{ID: 8, Version: 1}}, NextPageToken: []byte("next")},
+				{HistoryEvents: []*types.HistoryEvent{{ID: 9, Version: 1}}},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VersionHistoriesConsistent,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			retryer := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{State: tc.state}, nil)
+			expectHistoryPages(retryer, tc.historyPages, nil)
+
+			result := NewVersionHistoriesConsistent(retryer, domainCache).Check(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestVersionHistoriesConsistent_Fix_Healthy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	retryer := persistence.NewMockRetryer(ctrl)
+	domainCache := cache.NewMockDomainCache(ctrl)
+	domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+	retryer.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{})
+
+	result := NewVersionHistoriesConsistent(retryer, domainCache).Fix(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, FixResultTypeSkipped, result.FixResultType)
+	assert.Equal(t, VersionHistoriesConsistent, result.InvariantName)
+}
@@ -0,0 +1,189 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package replicationstatus collects the replication status of history shards from their replication queues
+package replicationstatus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/yarpc"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+const defaultConcurrency = 20
+
+type (
+	// QueueDescriber describes history replication queues, it is implemented by the history client
+	QueueDescriber interface {
+		DescribeReplicationQueue(context.Context, *types.DescribeReplicationQueueRequest, ...yarpc.CallOption) (*types.ReplicationShardStatus, error)
+	}
+
+	// Request is the request to Collect
+	Request struct {
+		// Domain limits the per domain status to a single domain, all domains are reported if empty
+		Domain         string
+		RemoteClusters []string
+		ShardIDs       []int32
+		// DLQSizes are replication DLQ sizes per shard, keyed by remote cluster name,
+		// the DLQ size is unknown for remote clusters missing from the map
+		DLQSizes map[string]map[int32]int64
+		// Concurrency is the number of shards described in parallel
+		Concurrency int
+	}
+)
+
+// Collect describes the replication queue of every shard for every remote cluster,
+// and aggregates pending replication tasks by domain
+func Collect(ctx context.Context, client QueueDescriber, request Request) (*types.GetReplicationStatusResponse, error) {
+	concurrency := request.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		mu     sync.Mutex
+		shards []*types.ReplicationShardStatus
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for _, remoteCluster := range request.RemoteClusters {
+		for _, shardID := range request.ShardIDs {
+			g.Go(func() error {
+				status, err := describeShard(gctx, client, shardID, remoteCluster)
+				if err != nil {
+					return err
+				}
+				if dlqSizes, ok := request.DLQSizes[remoteCluster]; ok {
+					status.DLQSize = common.Int64Ptr(dlqSizes[shardID])
+				}
+				status.Domains = filterDomain(status.Domains, request.Domain)
+
+				mu.Lock()
+				defer mu.Unlock()
+				shards = append(shards, status)
+				return nil
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].RemoteCluster != shards[j].RemoteCluster {
+			return shards[i].RemoteCluster < shards[j].RemoteCluster
+		}
+		return shards[i].ShardID < shards[j].ShardID
+	})
+	return &types.GetReplicationStatusResponse{
+		Shards:  shards,
+		Domains: aggregateDomains(shards),
+	}, nil
+}
+
+// DLQSizes returns the replication DLQ sizes per shard of tasks from the source cluster
+func DLQSizes(counts map[types.HistoryDLQCountKey]int64, sourceCluster string) map[int32]int64 {
+	sizes := make(map[int32]int64)
+	for key, count := range counts {
+		if key.SourceCluster == sourceCluster {
+			sizes[key.ShardID] += count
+		}
+	}
+	return sizes
+}
+
+func describeShard(ctx context.Context, client QueueDescriber, shardID int32, remoteCluster string) (*types.ReplicationShardStatus, error) {
+	status, err := client.DescribeReplicationQueue(ctx, &types.DescribeReplicationQueueRequest{
+		ShardID:     shardID,
+		ClusterName: remoteCluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, fmt.Errorf("unexpected empty replication queue status of shard %v", shardID)
+	}
+	return status, nil
+}
+
+func filterDomain(domains []*types.ReplicationDomainStatus, domain string) []*types.ReplicationDomainStatus {
+	if domain == "" {
+		return domains
+	}
+	for _, status := range domains {
+		if status.Domain == domain {
+			return []*types.ReplicationDomainStatus{status}
+		}
+	}
+	return nil
+}
+
+func aggregateDomains(shards []*types.ReplicationShardStatus) []*types.ReplicationDomainStatus {
+	type domainKey struct {
+		domain        string
+		remoteCluster string
+	}
+	aggregated := make(map[domainKey]*types.ReplicationDomainStatus)
+	for _, shard := range shards {
+		for _, status := range shard.Domains {
+			key := domainKey{domain: status.Domain, remoteCluster: status.RemoteCluster}
+			total, ok := aggregated[key]
+			if !ok {
+				total = &types.ReplicationDomainStatus{
+					Domain:                         status.Domain,
+					RemoteCluster:                  status.RemoteCluster,
+					EstimatedCatchUpInMilliseconds: common.Int64Ptr(0),
+				}
+				aggregated[key] = total
+			}
+			total.PendingTasks += status.PendingTasks
+			if status.LagInMilliseconds > total.LagInMilliseconds {
+				total.LagInMilliseconds = status.LagInMilliseconds
+			}
+			// shards replicate in parallel, so the domain caught up once its slowest shard caught up
+			if status.EstimatedCatchUpInMilliseconds == nil {
+				total.EstimatedCatchUpInMilliseconds = nil
+			} else if total.EstimatedCatchUpInMilliseconds != nil && *status.EstimatedCatchUpInMilliseconds > *total.EstimatedCatchUpInMilliseconds {
+				total.EstimatedCatchUpInMilliseconds = status.EstimatedCatchUpInMilliseconds
+			}
+		}
+	}
+
+	domains := make([]*types.ReplicationDomainStatus, 0, len(aggregated))
+	for _, status := range aggregated {
+		domains = append(domains, status)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Domain != domains[j].Domain {
+			return domains[i].Domain < domains[j].Domain
+		}
+		return domains[i].RemoteCluster < domains[j].RemoteCluster
+	})
+	return domains
+}
@@ -0,0 +1,128 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replicationstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+type fakeQueueDescriber map[string]map[int32]*types.ReplicationShardStatus
+
+func (f fakeQueueDescriber) DescribeReplicationQueue(_ context.Context, request *types.DescribeReplicationQueueRequest, _ ...yarpc.CallOption) (*types.ReplicationShardStatus, error) {
+	status, ok := f[request.ClusterName][request.ShardID]
+	if !ok {
+		return nil, errors.New("shard not found")
+	}
+	// Collect sets fields of the status, the history client returns a new one for every call
+	copied := *status
+	return &copied, nil
+}
+
+func TestCollect(t *testing.T) {
+	client := fakeQueueDescriber{
+		"cluster-b": {
+			0: {
+				ShardID:                        0,
+				RemoteCluster:                  "cluster-b",
+				PendingTasks:                   3,
+				LagInMilliseconds:              500,
+				EstimatedCatchUpInMilliseconds: common.Int64Ptr(300),
+				Domains: []*types.ReplicationDomainStatus{
+					{Domain: "domain-a", RemoteCluster: "cluster-b", PendingTasks: 1, LagInMilliseconds: 500, EstimatedCatchUpInMilliseconds: common.Int64Ptr(100)},
+					{Domain: "domain-b", RemoteCluster: "cluster-b", PendingTasks: 2, LagInMilliseconds: 400, EstimatedCatchUpInMilliseconds: common.Int64Ptr(300)},
+				},
+			},
+			1: {
+				ShardID:           1,
+				RemoteCluster:     "cluster-b",
+				PendingTasks:      1,
+				LagInMilliseconds: 900,
+				Domains: []*types.ReplicationDomainStatus{
+					{Domain: "domain-a", RemoteCluster: "cluster-b", PendingTasks: 1, LagInMilliseconds: 900},
+				},
+			},
+		},
+		"cluster-c": {
+			0: {ShardID: 0, RemoteCluster: "cluster-c", EstimatedCatchUpInMilliseconds: common.Int64Ptr(0)},
+			1: {ShardID: 1, RemoteCluster: "cluster-c", EstimatedCatchUpInMilliseconds: common.Int64Ptr(0)},
+		},
+	}
+
+	resp, err := Collect(context.Background(), client, Request{
+		RemoteClusters: []string{"cluster-c", "cluster-b"},
+		ShardIDs:       []int32{1, 0},
+		DLQSizes:       map[string]map[int32]int64{"cluster-b": {1: 7}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Shards, 4)
+	assert.Equal(t, "cluster-b", resp.Shards[0].RemoteCluster)
+	assert.Equal(t, int32(0), resp.Shards[0].ShardID)
+	assert.Equal(t, common.Int64Ptr(0), resp.Shards[0].DLQSize)
+	assert.Equal(t, common.Int64Ptr(7), resp.Shards[1].DLQSize)
+	assert.Nil(t, resp.Shards[2].DLQSize)
+	assert.Equal(t, "cluster-c", resp.Shards[3].RemoteCluster)
+
+	assert.Equal(t, []*types.ReplicationDomainStatus{
+		{Domain: "domain-a", RemoteCluster: "cluster-b", PendingTasks: 2, LagInMilliseconds: 900},
+		{Domain: "domain-b", RemoteCluster: "cluster-b", PendingTasks: 2, LagInMilliseconds: 400, EstimatedCatchUpInMilliseconds: common.Int64Ptr(300)},
+	}, resp.Domains)
+
+	resp, err = Collect(context.Background(), client, Request{
+		Domain:         "domain-b",
+		RemoteClusters: []string{"cluster-b"},
+		ShardIDs:       []int32{0, 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Shards, 2)
+	assert.Len(t, resp.Shards[0].Domains, 1)
+	assert.Empty(t, resp.Shards[1].Domains)
+	assert.Equal(t, []*types.ReplicationDomainStatus{
+		{Domain: "domain-b", RemoteCluster: "cluster-b", PendingTasks: 2, LagInMilliseconds: 400, EstimatedCatchUpInMilliseconds: common.Int64Ptr(300)},
+	}, resp.Domains)
+
+	_, err = Collect(context.Background(), client, Request{
+		RemoteClusters: []string{"cluster-d"},
+		ShardIDs:       []int32{0},
+	})
+	assert.Error(t, err)
+}
+
+func TestDLQSizes(t *testing.T) {
+	counts := map[types.HistoryDLQCountKey]int64{
+		{ShardID: 1, SourceCluster: "cluster-a"}: 3,
+		{ShardID: 2, SourceCluster: "cluster-a"}: 4,
+		{ShardID: 1, SourceCluster: "cluster-c"}: 5,
+	}
+	assert.Equal(t, map[int32]int64{1: 3, 2: 4}, DLQSizes(counts, "cluster-a"))
+	assert.Empty(t, DLQSizes(nil, "cluster-a"))
+}
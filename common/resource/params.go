@@ -94,5 +94,6 @@ type (
 		// NewPersistenceBeanFn can be used to override the default persistence bean creation in unit tests to avoid DB setup
 		NewPersistenceBeanFn  func(persistenceClient.Factory, *persistenceClient.Params, *service.Config) (persistenceClient.Bean, error)
 		DiagnosticsInvariants []invariant.Invariant
+		DiagnosticsRuleFiles  []string
 	}
 )
@@ -21,6 +21,7 @@
 package resource
 
 import (
+	"context"
 	"math/rand"
 	"sync/atomic"
 	"time"
@@ -59,6 +60,8 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	persistenceClient "github.com/uber/cadence/common/persistence/client"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/quotas/global/collection"
 	qrpc "github.com/uber/cadence/common/quotas/global/rpc"
 	"github.com/uber/cadence/common/quotas/permember"
 	"github.com/uber/cadence/common/rpc"
@@ -147,6 +150,7 @@ type Impl struct {
 	asyncWorkflowQueueProvider queue.Provider
 
 	ratelimiterAggregatorClient qrpc.Client
+	persistenceRatelimiters     *collection.Collection // nil unless global persistence ratelimiting is configured
 }
 
 var _ Resource = (*Impl)(nil)
@@ -191,25 +195,69 @@ func New(
 		return nil, err
 	}
 
+	var historyRawClient history.Client
+	if params.HistoryClientFn != nil {
+		logger.Debug("Using history client from HistoryClientFn")
+		historyRawClient = params.HistoryClientFn()
+	} else {
+		logger.Debug("Using history client from bean")
+		historyRawClient = clientBean.GetHistoryClient()
+	}
+
+	ratelimiterAggs := qrpc.New(
+		historyRawClient, // no retries, will retry internally if needed
+		clientBean.GetHistoryPeers(),
+		logger,
+		params.MetricsClient,
+	)
+
+	persistenceMaxQPS := func() float64 {
+		return permember.PerMember(
+			serviceName,
+			float64(serviceConfig.PersistenceGlobalMaxQPS()),
+			float64(serviceConfig.PersistenceMaxQPS()),
+			membershipResolver,
+		)
+	}
+	var persistenceFactory persistenceClient.Factory
+	var persistenceRatelimiters *collection.Collection
+	if serviceConfig.PersistenceGlobalRatelimiterMode != nil {
+		persistenceRatelimiters, err = newPersistenceRatelimiters(
+			serviceName,
+			serviceConfig,
+			persistenceMaxQPS,
+			membershipResolver,
+			ratelimiterAggs,
+			logger,
+			params.MetricsClient,
+		)
+		if err != nil {
+			return nil, err
+		}
+		persistenceFactory = persistenceClient.NewFactoryWithRatelimiters(
+			&params.PersistenceConfig,
+			persistenceRatelimiters,
+			params.ClusterMetadata.GetCurrentClusterName(),
+			params.MetricsClient,
+			logger,
+			persistence.NewDynamicConfiguration(dynamicCollection),
+		)
+	} else {
+		persistenceFactory = persistenceClient.NewFactory(
+			&params.PersistenceConfig,
+			persistenceMaxQPS,
+			params.ClusterMetadata.GetCurrentClusterName(),
+			params.MetricsClient,
+			logger,
+			persistence.NewDynamicConfiguration(dynamicCollection),
+		)
+	}
+
 	newPersistenceBeanFn := persistenceClient.NewBeanFromFactory
 	if params.NewPersistenceBeanFn != nil {
 		newPersistenceBeanFn = params.NewPersistenceBeanFn
 	}
-	persistenceBean, err := newPersistenceBeanFn(persistenceClient.NewFactory(
-		&params.PersistenceConfig,
-		func() float64 {
-			return permember.PerMember(
-				serviceName,
-				float64(serviceConfig.PersistenceGlobalMaxQPS()),
-				float64(serviceConfig.PersistenceMaxQPS()),
-				membershipResolver,
-			)
-		},
-		params.ClusterMetadata.GetCurrentClusterName(),
-		params.MetricsClient,
-		logger,
-		persistence.NewDynamicConfiguration(dynamicCollection),
-	), &persistenceClient.Params{
+	persistenceBean, err := newPersistenceBeanFn(persistenceFactory, &persistenceClient.Params{
 		PersistenceConfig: params.PersistenceConfig,
 		MetricsClient:     params.MetricsClient,
 		MessagingClient:   params.MessagingClient,
@@ -288,14 +336,6 @@ func New(
 		)
 	}
 
-	var historyRawClient history.Client
-	if params.HistoryClientFn != nil {
-		logger.Debug("Using history client from HistoryClientFn")
-		historyRawClient = params.HistoryClientFn()
-	} else {
-		logger.Debug("Using history client from bean")
-		historyRawClient = clientBean.GetHistoryClient()
-	}
 	historyClient := retryable.NewHistoryClient(
 		historyRawClient,
 		common.CreateHistoryServiceRetryPolicy(),
@@ -335,13 +375,6 @@ func New(
 		return nil, err
 	}
 
-	ratelimiterAggs := qrpc.New(
-		historyRawClient, // no retries, will retry internally if needed
-		clientBean.GetHistoryPeers(),
-		logger,
-		params.MetricsClient,
-	)
-
 	impl = &Impl{
 		status: common.DaemonStatusInitialized,
 
@@ -411,6 +444,7 @@ func New(
 		asyncWorkflowQueueProvider: params.AsyncWorkflowQueueProvider,
 
 		ratelimiterAggregatorClient: ratelimiterAggs,
+		persistenceRatelimiters:     persistenceRatelimiters,
 	}
 	return impl, nil
 }
@@ -453,6 +487,14 @@ func (h *Impl) Start() {
 	if h.isolationGroupConfigStore != nil {
 		h.isolationGroupConfigStore.Start()
 	}
+
+	if h.persistenceRatelimiters != nil {
+		startCtx, cancel := context.WithTimeout(context.Background(), time.Second) // should take nearly no time at all
+		defer cancel()
+		if err := h.persistenceRatelimiters.OnStart(startCtx); err != nil {
+			h.logger.WithTags(tag.Error(err)).Fatal("fail to start persistence global ratelimiter collection")
+		}
+	}
 	// The service is now started up
 	h.logger.Info("service started")
 	// seed the random generator once for this service
@@ -480,6 +522,13 @@ func (h *Impl) Stop() {
 	h.rpcFactory.Stop()
 
 	h.runtimeMetricsReporter.Stop()
+	if h.persistenceRatelimiters != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second) // should take nearly no time at all
+		defer cancel()
+		if err := h.persistenceRatelimiters.OnStop(stopCtx); err != nil {
+			h.logger.WithTags(tag.Error(err)).Error("failed to stop persistence global ratelimiter collection")
+		}
+	}
 	h.persistenceBean.Close()
 	if h.isolationGroupConfigStore != nil {
 		h.isolationGroupConfigStore.Stop()
@@ -756,3 +805,49 @@ func ensureGetAllIsolationGroupsFnIsSet(params *Params) {
 		params.GetIsolationGroups = func() []string { return []string{} }
 	}
 }
+
+// newPersistenceRatelimiters builds a global ratelimiter collection for persistence requests,
+// keyed by datastore name, so PersistenceGlobalMaxQPS can be shared between hosts by load
+// rather than divided evenly.
+func newPersistenceRatelimiters(
+	serviceName string,
+	serviceConfig *service.Config,
+	perHostQPS quotas.RPSFunc,
+	resolver membership.Resolver,
+	aggs qrpc.Client,
+	logger log.Logger,
+	metricsClient metrics.Client,
+) (*collection.Collection, error) {
+	create := func() *quotas.Collection {
+		return quotas.NewCollection(persistenceLimiterFactory(perHostQPS))
+	}
+	return collection.New(
+		service.ShortName(serviceName)+"-persistence",
+		// local and global-fallback collections must not be shared, see collection.New
+		create(),
+		create(),
+		serviceConfig.GlobalRatelimiterUpdateInterval,
+		func(string) int {
+			if globalQPS := serviceConfig.PersistenceGlobalMaxQPS(); globalQPS > 0 {
+				return globalQPS
+			}
+			// no global limit configured, so the effective limit is the sum of all per-host limits
+			memberCount, err := resolver.MemberCount(serviceName)
+			if err != nil || memberCount < 1 {
+				memberCount = 1
+			}
+			return serviceConfig.PersistenceMaxQPS() * memberCount
+		},
+		serviceConfig.PersistenceGlobalRatelimiterMode,
+		aggs,
+		logger,
+		metricsClient,
+	)
+}
+
+// persistenceLimiterFactory creates the same limiter for every datastore.
+type persistenceLimiterFactory quotas.RPSFunc
+
+func (f persistenceLimiterFactory) GetLimiter(string) quotas.Limiter {
+	return quotas.NewDynamicRateLimiter(quotas.RPSFunc(f))
+}
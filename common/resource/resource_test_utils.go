@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
+	"github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/common/activecluster"
 	"github.com/uber/cadence/common/archiver"
 	"github.com/uber/cadence/common/archiver/provider"
@@ -84,13 +85,14 @@ type (
 
 		// internal services clients
 
-		SDKClient            *publicservicetest.MockClient
-		FrontendClient       *frontend.MockClient
-		MatchingClient       *matching.MockClient
-		HistoryClient        *history.MockClient
-		RemoteAdminClient    *admin.MockClient
-		RemoteFrontendClient *frontend.MockClient
-		ClientBean           *client.MockBean
+		SDKClient             *publicservicetest.MockClient
+		FrontendClient        *frontend.MockClient
+		MatchingClient        *matching.MockClient
+		HistoryClient         *history.MockClient
+		RemoteAdminClient     *admin.MockClient
+		RemoteAdminJSONClient *json.MockAdminClient
+		RemoteFrontendClient  *frontend.MockClient
+		ClientBean            *client.MockBean
 
 		// persistence clients
 
@@ -136,12 +138,14 @@ func NewTest(
 	matchingClient := matching.NewMockClient(controller)
 	historyClient := history.NewMockClient(controller)
 	remoteAdminClient := admin.NewMockClient(controller)
+	remoteAdminJSONClient := json.NewMockAdminClient(controller)
 	remoteFrontendClient := frontend.NewMockClient(controller)
 	clientBean := client.NewMockBean(controller)
 	clientBean.EXPECT().GetFrontendClient().Return(frontendClient).AnyTimes()
 	clientBean.EXPECT().GetMatchingClient(gomock.Any()).Return(matchingClient, nil).AnyTimes()
 	clientBean.EXPECT().GetHistoryClient().Return(historyClient).AnyTimes()
 	clientBean.EXPECT().GetRemoteAdminClient(gomock.Any()).Return(remoteAdminClient, nil).AnyTimes()
+	clientBean.EXPECT().GetRemoteAdminJSONClient(gomock.Any()).Return(remoteAdminJSONClient, nil).AnyTimes()
 	clientBean.EXPECT().GetRemoteFrontendClient(gomock.Any()).Return(remoteFrontendClient, nil).AnyTimes()
 
 	metadataMgr := &mocks.MetadataManager{}
@@ -160,6 +164,7 @@ func NewTest(
 	persistenceBean.EXPECT().GetHistoryManager().Return(historyMgr).AnyTimes()
 	persistenceBean.EXPECT().GetShardManager().Return(shardMgr).AnyTimes()
 	persistenceBean.EXPECT().GetExecutionManager(gomock.Any()).Return(executionMgr, nil).AnyTimes()
+	persistenceBean.EXPECT().GetAsyncWorkflowQueueManager().Return(persistence.NewMockQueueManager(controller)).AnyTimes()
 
 	isolationGroupMock := isolationgroup.NewMockState(controller)
 	isolationGroupMock.EXPECT().Stop().AnyTimes()
@@ -198,13 +203,14 @@ func NewTest(
 
 		// internal services clients
 
-		SDKClient:            publicservicetest.NewMockClient(oldgomock.NewController(t)),
-		FrontendClient:       frontendClient,
-		MatchingClient:       matchingClient,
-		HistoryClient:        historyClient,
-		RemoteAdminClient:    remoteAdminClient,
-		RemoteFrontendClient: remoteFrontendClient,
-		ClientBean:           clientBean,
+		SDKClient:             publicservicetest.NewMockClient(oldgomock.NewController(t)),
+		FrontendClient:        frontendClient,
+		MatchingClient:        matchingClient,
+		HistoryClient:         historyClient,
+		RemoteAdminClient:     remoteAdminClient,
+		RemoteAdminJSONClient: remoteAdminJSONClient,
+		RemoteFrontendClient:  remoteFrontendClient,
+		ClientBean:            clientBean,
 
 		// persistence clients
 
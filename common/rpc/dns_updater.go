@@ -24,12 +24,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"go.uber.org/yarpc/api/peer"
 
+	"github.com/uber/cadence/common/dns"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 )
@@ -38,7 +40,8 @@ type (
 	dnsUpdater struct {
 		interval     time.Duration
 		dnsAddress   string
-		port         string
+		port         uint16
+		resolver     dns.Resolver
 		currentPeers map[string]struct{}
 		list         peer.List
 		logger       log.Logger
@@ -61,13 +64,18 @@ func newDNSUpdater(list peer.List, dnsPort string, interval time.Duration, logge
 	if len(ss) != 2 {
 		return nil, fmt.Errorf("incorrect DNS:Port format")
 	}
+	port, err := strconv.ParseUint(ss[1], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect DNS:Port format")
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	return &dnsUpdater{
 		interval:     interval,
 		logger:       logger,
 		list:         list,
 		dnsAddress:   ss[0],
-		port:         ss[1],
+		port:         uint16(port),
+		resolver:     net.DefaultResolver,
 		currentPeers: make(map[string]struct{}),
 		ctx:          ctx,
 		cancel:       cancel,
@@ -120,15 +128,13 @@ func (d *dnsUpdater) Stop() {
 }
 
 func (d *dnsUpdater) refresh() (*dnsRefreshResult, error) {
-	resolver := net.DefaultResolver
-	ips, err := resolver.LookupHost(d.ctx, d.dnsAddress)
+	addrs, err := dns.LookupHost(d.ctx, d.resolver, d.dnsAddress, d.port)
 	if err != nil {
 		return nil, err
 	}
 	newPeers := map[string]struct{}{}
-	for _, ip := range ips {
-		adr := fmt.Sprintf("%v:%v", ip, d.port)
-		newPeers[adr] = struct{}{}
+	for _, addr := range addrs {
+		newPeers[addr.String()] = struct{}{}
 	}
 
 	updates := peer.ListUpdates{
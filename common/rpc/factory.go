@@ -27,6 +27,7 @@ import (
 	"net"
 	nethttp "net/http"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/grpc"
@@ -64,6 +65,7 @@ type FactoryImpl struct {
 	ctx            context.Context
 	cancelFn       context.CancelFunc
 	peerLister     PeerLister
+	httpHandler    *atomic.Pointer[nethttp.Handler]
 }
 
 // NewFactory builds a new rpc.Factory
@@ -110,19 +112,9 @@ func NewFactory(logger log.Logger, p Params) Factory {
 		logger.Info("Listening for GRPC requests", tag.Address(p.GRPCAddress))
 	}
 	// Create http inbound if configured
+	httpHandler := &atomic.Pointer[nethttp.Handler]{}
 	if p.HTTP != nil {
-		interceptor := func(handler nethttp.Handler) nethttp.Handler {
-			return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
-				procedure := r.Header.Get(yarpchttp.ProcedureHeader)
-				if _, found := p.HTTP.Procedures[procedure]; found {
-					handler.ServeHTTP(w, r)
-					return
-				}
-				nethttp.NotFound(w, r)
-			})
-		}
-
-		inboundOptions := []yarpchttp.InboundOption{yarpchttp.Interceptor(interceptor)}
+		inboundOptions := []yarpchttp.InboundOption{yarpchttp.Interceptor(newHTTPInterceptor(p.HTTP, httpHandler))}
 
 		if p.HTTP.TLS != nil {
 			inboundOptions = append(inboundOptions,
@@ -163,6 +155,26 @@ func NewFactory(logger log.Logger, p Params) Factory {
 		logger:         logger,
 		ctx:            ctx,
 		cancelFn:       cancel,
+		httpHandler:    httpHandler,
+	}
+}
+
+// newHTTPInterceptor only lets through yarpc requests for the configured procedures.
+// Requests that are not yarpc requests are served by the gateway handler, if the gateway is enabled and set.
+func newHTTPInterceptor(p *httpParams, gateway *atomic.Pointer[nethttp.Handler]) func(nethttp.Handler) nethttp.Handler {
+	return func(handler nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			procedure := r.Header.Get(yarpchttp.ProcedureHeader)
+			if _, found := p.Procedures[procedure]; found {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			if gatewayHandler := gateway.Load(); p.Gateway && procedure == "" && gatewayHandler != nil {
+				(*gatewayHandler).ServeHTTP(w, r)
+				return
+			}
+			nethttp.NotFound(w, r)
+		})
 	}
 }
 
@@ -171,6 +183,11 @@ func (d *FactoryImpl) GetDispatcher() *yarpc.Dispatcher {
 	return d.dispatcher
 }
 
+// SetHTTPHandler sets the handler serving gateway requests on the HTTP inbound
+func (d *FactoryImpl) SetHTTPHandler(handler nethttp.Handler) {
+	d.httpHandler.Store(&handler)
+}
+
 // GetTChannel GetChannel returns Tchannel Channel used by Ringpop
 func (d *FactoryImpl) GetTChannel() tchannel.Channel {
 	return d.channel
@@ -10,6 +10,7 @@
 package rpc
 
 import (
+	http "net/http"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -85,6 +86,18 @@ func (mr *MockFactoryMockRecorder) GetTChannel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTChannel", reflect.TypeOf((*MockFactory)(nil).GetTChannel))
 }
 
+// SetHTTPHandler mocks base method.
+func (m *MockFactory) SetHTTPHandler(arg0 http.Handler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHTTPHandler", arg0)
+}
+
+// SetHTTPHandler indicates an expected call of SetHTTPHandler.
+func (mr *MockFactoryMockRecorder) SetHTTPHandler(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHTTPHandler", reflect.TypeOf((*MockFactory)(nil).SetHTTPHandler), arg0)
+}
+
 // Start mocks base method.
 func (m *MockFactory) Start(arg0 PeerLister) error {
 	m.ctrl.T.Helper()
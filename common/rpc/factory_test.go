@@ -22,13 +22,17 @@ package rpc
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
 	"go.uber.org/mock/gomock"
+	yarpchttp "go.uber.org/yarpc/transport/http"
 
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/membership"
@@ -184,3 +188,76 @@ func TestStartStop(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPInterceptor(t *testing.T) {
+	yarpcHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	var gatewayHandler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	tests := []struct {
+		desc       string
+		gateway    bool
+		setHandler bool
+		procedure  string
+		wantStatus int
+	}{
+		{
+			desc:       "allowed procedure",
+			procedure:  "allowed",
+			wantStatus: http.StatusOK,
+		},
+		{
+			desc:       "not allowed procedure",
+			procedure:  "not-allowed",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:       "gateway request with gateway disabled",
+			setHandler: true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:       "gateway request without gateway handler",
+			gateway:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:       "gateway request",
+			gateway:    true,
+			setHandler: true,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			desc:       "not allowed procedure with gateway enabled",
+			gateway:    true,
+			setHandler: true,
+			procedure:  "not-allowed",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			handler := &atomic.Pointer[http.Handler]{}
+			if tc.setHandler {
+				handler.Store(&gatewayHandler)
+			}
+			interceptor := newHTTPInterceptor(&httpParams{
+				Procedures: map[string]struct{}{"allowed": {}},
+				Gateway:    tc.gateway,
+			}, handler)
+
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.procedure != "" {
+				r.Header.Set(yarpchttp.ProcedureHeader, tc.procedure)
+			}
+			w := httptest.NewRecorder()
+			interceptor(yarpcHandler).ServeHTTP(w, r)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
@@ -61,6 +61,7 @@ type httpParams struct {
 	Procedures map[string]struct{}
 	TLS        *tls.Config
 	Mode       yarpctls.Mode
+	Gateway    bool
 }
 
 // NewParams creates parameters for rpc.Factory from the given config
@@ -121,6 +122,7 @@ func NewParams(serviceName string, config *config.Config, dc *dynamicconfig.Coll
 		http = &httpParams{
 			Address:    net.JoinHostPort(listenIP.String(), strconv.Itoa(int(serviceConfig.RPC.HTTP.Port))),
 			Procedures: procedureMap,
+			Gateway:    serviceConfig.RPC.HTTP.Gateway,
 		}
 
 		if serviceConfig.RPC.HTTP.TLS.Enabled {
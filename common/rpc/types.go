@@ -23,6 +23,8 @@
 package rpc
 
 import (
+	"net/http"
+
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/tchannel"
 
@@ -35,6 +37,7 @@ type Factory interface {
 	GetMaxMessageSize() int
 	Start(PeerLister) error
 	GetTChannel() tchannel.Channel
+	SetHTTPHandler(http.Handler)
 	Stop() error
 }
 
@@ -32,6 +32,13 @@ type (
 		PersistenceGlobalMaxQPS dynamicproperties.IntPropertyFn
 		ThrottledLoggerMaxRPS   dynamicproperties.IntPropertyFn
 
+		// PersistenceGlobalRatelimiterMode selects how PersistenceGlobalMaxQPS is shared between hosts, per datastore.
+		// When nil, the global limit is divided evenly between all hosts of the service.
+		PersistenceGlobalRatelimiterMode dynamicproperties.StringPropertyWithRatelimitKeyFilter `yaml:"-" json:"-"`
+		// GlobalRatelimiterUpdateInterval controls how frequently global ratelimiter usage is submitted to aggregators.
+		// Required if PersistenceGlobalRatelimiterMode is set.
+		GlobalRatelimiterUpdateInterval dynamicproperties.DurationPropertyFn `yaml:"-" json:"-"`
+
 		// WriteVisibilityStoreName is the write mode of visibility
 		WriteVisibilityStoreName dynamicproperties.StringPropertyFn
 		// EnableLogCustomerQueryParameter is to enable log customer parameters
@@ -0,0 +1,120 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// Attributes set on the spans created by cadence
+const (
+	DomainKey     = attribute.Key("cadence.domain")
+	DomainIDKey   = attribute.Key("cadence.domain_id")
+	WorkflowIDKey = attribute.Key("cadence.workflow_id")
+	RunIDKey      = attribute.Key("cadence.run_id")
+	ShardIDKey    = attribute.Key("cadence.shard_id")
+	TaskListKey   = attribute.Key("cadence.task_list")
+)
+
+type (
+	domainGetter            interface{ GetDomain() string }
+	domainNameGetter        interface{ GetDomainName() string }
+	domainUUIDGetter        interface{ GetDomainUUID() string }
+	domainIDGetter          interface{ GetDomainID() string }
+	workflowIDGetter        interface{ GetWorkflowID() string }
+	runIDGetter             interface{ GetRunID() string }
+	workflowExecutionGetter interface {
+		GetWorkflowExecution() *types.WorkflowExecution
+	}
+	executionGetter interface {
+		GetExecution() *types.WorkflowExecution
+	}
+	shardIDGetter  interface{ GetShardID() int32 }
+	taskListGetter interface{ GetTaskList() *types.TaskList }
+)
+
+// RequestAttributes returns the span attributes of a request.
+// The attributes are read with the getters defined by the request,
+// e.g. the domain attribute is set if the request defines GetDomain() string.
+func RequestAttributes(request any) []attribute.KeyValue {
+	if request == nil {
+		return nil
+	}
+	// getters with value receivers panic on nil pointers
+	if v := reflect.ValueOf(request); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if r, ok := request.(domainGetter); ok && r.GetDomain() != "" {
+		attrs = append(attrs, DomainKey.String(r.GetDomain()))
+	} else if r, ok := request.(domainNameGetter); ok && r.GetDomainName() != "" {
+		attrs = append(attrs, DomainKey.String(r.GetDomainName()))
+	}
+	if r, ok := request.(domainUUIDGetter); ok && r.GetDomainUUID() != "" {
+		attrs = append(attrs, DomainIDKey.String(r.GetDomainUUID()))
+	} else if r, ok := request.(domainIDGetter); ok && r.GetDomainID() != "" {
+		attrs = append(attrs, DomainIDKey.String(r.GetDomainID()))
+	}
+
+	var execution *types.WorkflowExecution
+	if r, ok := request.(workflowExecutionGetter); ok {
+		execution = r.GetWorkflowExecution()
+	} else if r, ok := request.(executionGetter); ok {
+		execution = r.GetExecution()
+	}
+	if execution != nil {
+		attrs = append(attrs, WorkflowExecutionAttributes(execution)...)
+	} else {
+		if r, ok := request.(workflowIDGetter); ok && r.GetWorkflowID() != "" {
+			attrs = append(attrs, WorkflowIDKey.String(r.GetWorkflowID()))
+		}
+		if r, ok := request.(runIDGetter); ok && r.GetRunID() != "" {
+			attrs = append(attrs, RunIDKey.String(r.GetRunID()))
+		}
+	}
+
+	if r, ok := request.(shardIDGetter); ok {
+		attrs = append(attrs, ShardIDKey.Int(int(r.GetShardID())))
+	}
+	if r, ok := request.(taskListGetter); ok && r.GetTaskList().GetName() != "" {
+		attrs = append(attrs, TaskListKey.String(r.GetTaskList().GetName()))
+	}
+	return attrs
+}
+
+// WorkflowExecutionAttributes returns the span attributes of a workflow execution
+func WorkflowExecutionAttributes(execution *types.WorkflowExecution) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if execution.GetWorkflowID() != "" {
+		attrs = append(attrs, WorkflowIDKey.String(execution.GetWorkflowID()))
+	}
+	if execution.GetRunID() != "" {
+		attrs = append(attrs, RunIDKey.String(execution.GetRunID()))
+	}
+	return attrs
+}
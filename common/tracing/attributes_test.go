@@ -0,0 +1,100 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestRequestAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		request any
+		want    []attribute.KeyValue
+	}{
+		{
+			name:    "nil request",
+			request: nil,
+		},
+		{
+			name:    "nil pointer request",
+			request: (*types.StartWorkflowExecutionRequest)(nil),
+		},
+		{
+			name: "domain and workflow ID",
+			request: &types.StartWorkflowExecutionRequest{
+				Domain:     "test-domain",
+				WorkflowID: "test-workflow",
+			},
+			want: []attribute.KeyValue{
+				DomainKey.String("test-domain"),
+				WorkflowIDKey.String("test-workflow"),
+			},
+		},
+		{
+			name: "workflow execution",
+			request: &types.SignalWorkflowExecutionRequest{
+				Domain: "test-domain",
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow",
+					RunID:      "test-run",
+				},
+			},
+			want: []attribute.KeyValue{
+				DomainKey.String("test-domain"),
+				WorkflowIDKey.String("test-workflow"),
+				RunIDKey.String("test-run"),
+			},
+		},
+		{
+			name: "domain UUID, execution and task list",
+			request: &types.AddDecisionTaskRequest{
+				DomainUUID: "test-domain-id",
+				Execution:  &types.WorkflowExecution{WorkflowID: "test-workflow"},
+				TaskList:   &types.TaskList{Name: "test-tasklist"},
+			},
+			want: []attribute.KeyValue{
+				DomainIDKey.String("test-domain-id"),
+				WorkflowIDKey.String("test-workflow"),
+				TaskListKey.String("test-tasklist"),
+			},
+		},
+		{
+			name:    "shard ID",
+			request: &types.CloseShardRequest{ShardID: 3},
+			want: []attribute.KeyValue{
+				ShardIDKey.Int(3),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RequestAttributes(tt.request))
+		})
+	}
+}
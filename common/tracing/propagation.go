@@ -0,0 +1,78 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/yarpc"
+)
+
+// propagator propagates the span context as W3C trace context headers
+var propagator = propagation.TraceContext{}
+
+// Inject returns opts with the call options which propagate the span context of ctx to the callee
+func Inject(ctx context.Context, opts []yarpc.CallOption) []yarpc.CallOption {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return opts
+	}
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+	// copy the options to not modify the slice of the caller
+	result := make([]yarpc.CallOption, 0, len(opts)+len(keys))
+	result = append(result, opts...)
+	for _, key := range keys {
+		result = append(result, yarpc.WithHeader(key, carrier.Get(key)))
+	}
+	return result
+}
+
+// Extract returns ctx with the span context propagated by the caller of the inbound call handled in ctx
+func Extract(ctx context.Context) context.Context {
+	call := yarpc.CallFromContext(ctx)
+	if call == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, callCarrier{call: call})
+}
+
+// callCarrier reads the propagated span context from the headers of an inbound call
+type callCarrier struct {
+	call *yarpc.Call
+}
+
+func (c callCarrier) Get(key string) string {
+	return c.call.Header(key)
+}
+
+func (c callCarrier) Set(string, string) {}
+
+func (c callCarrier) Keys() []string {
+	return c.call.HeaderNames()
+}
@@ -0,0 +1,98 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package tracing instruments cadence with OpenTelemetry spans.
+//
+// Spans are created with the global tracer provider, so they are only recorded
+// once a tracer provider is registered with Register. Until then the wrappers
+// which create spans are cheap no-ops.
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc"
+)
+
+// TracerName is the instrumentation name of the spans created by cadence
+const TracerName = "github.com/uber/cadence"
+
+var registerOnce sync.Once
+
+// Tracer returns the tracer which is used to create cadence spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Register sets the global tracer provider and propagator.
+// The provider can only be registered once per process, so services which run in the
+// same process share the provider of the service which registered it first.
+// It returns false if a provider was already registered.
+func Register(provider trace.TracerProvider) bool {
+	registered := false
+	registerOnce.Do(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+		registered = true
+	})
+	return registered
+}
+
+// StartServerSpan starts the span of an inbound call, continuing the trace propagated by the caller
+func StartServerSpan(ctx context.Context, tracer trace.Tracer, name string, request any) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(Extract(ctx), name, trace.WithSpanKind(trace.SpanKindServer))
+	if span.IsRecording() {
+		span.SetAttributes(RequestAttributes(request)...)
+	}
+	return ctx, span
+}
+
+// StartClientSpan starts the span of an outbound call, the returned call options propagate the span to the callee
+func StartClientSpan(ctx context.Context, tracer trace.Tracer, name string, request any, opts []yarpc.CallOption) (context.Context, trace.Span, []yarpc.CallOption) {
+	ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(RequestAttributes(request)...)
+	}
+	return ctx, span, Inject(ctx, opts)
+}
+
+// StartSpan starts the span of an operation within the service, e.g. a persistence call
+func StartSpan(ctx context.Context, tracer trace.Tracer, name string, request any) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+	if span.IsRecording() {
+		span.SetAttributes(RequestAttributes(request)...)
+	}
+	return ctx, span
+}
+
+// EndSpan marks the span as failed if err is not nil and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,104 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/yarpctest"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func newTestTracer(t *testing.T) (trace.Tracer, *tracetest.SpanRecorder) {
+	otel.SetTextMapPropagator(propagator)
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator()) })
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return provider.Tracer(TracerName), recorder
+}
+
+func TestStartClientSpan(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+
+	opts := []yarpc.CallOption{yarpc.WithHeader("key", "value")}
+	ctx, span, outOpts := StartClientSpan(context.Background(), tracer, "Frontend.StartWorkflowExecution", &types.StartWorkflowExecutionRequest{Domain: "test-domain"}, opts)
+	EndSpan(span, errors.New("test error"))
+
+	assert.Len(t, opts, 1, "caller options must not be modified")
+	assert.Len(t, outOpts, 2, "trace context header must be added")
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Frontend.StartWorkflowExecution", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), DomainKey.String("test-domain"))
+}
+
+func TestStartServerSpan(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+
+	parentCtx, parent := tracer.Start(context.Background(), "parent")
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(parentCtx, carrier)
+	parent.End()
+
+	ctx := yarpctest.ContextWithCall(context.Background(), &yarpctest.Call{Headers: carrier})
+	_, span := StartServerSpan(ctx, tracer, "History.StartWorkflowExecution", nil)
+	EndSpan(span, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, trace.SpanKindServer, spans[1].SpanKind())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+}
+
+func TestInjectWithoutSpan(t *testing.T) {
+	newTestTracer(t)
+
+	opts := []yarpc.CallOption{yarpc.WithHeader("key", "value")}
+	result := Inject(context.Background(), opts)
+	require.Len(t, result, 1)
+	assert.True(t, &opts[0] == &result[0], "options must be returned as is")
+}
+
+func TestExtractWithoutCall(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, Extract(ctx))
+}
@@ -0,0 +1,66 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracingfx
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/tracing"
+)
+
+// Module registers the tracer provider configured in the tracing config section for fx application.
+var Module = fx.Module("tracingfx",
+	fx.Invoke(register))
+
+type registerParams struct {
+	fx.In
+
+	Lifecycle       fx.Lifecycle
+	Config          config.Config
+	Logger          log.Logger
+	ServiceFullName string `name:"service-full-name"`
+}
+
+func register(params registerParams) error {
+	cfg := params.Config.Tracing
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	provider, err := tracing.NewTracerProvider(context.Background(), &cfg, params.ServiceFullName)
+	if err != nil {
+		return err
+	}
+	if !tracing.Register(provider) {
+		params.Logger.Info("Tracer provider is already registered, spans are exported by the registered provider")
+		return provider.Shutdown(context.Background())
+	}
+	params.Logger.Info("Registered tracer provider", tag.Value(cfg.Exporter))
+	params.Lifecycle.Append(fx.StopHook(provider.Shutdown))
+	return nil
+}
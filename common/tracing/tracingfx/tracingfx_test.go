@@ -0,0 +1,58 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracingfx
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/service"
+)
+
+func TestModule(t *testing.T) {
+	for name, cfg := range map[string]config.Tracing{
+		"disabled": {},
+		"file": {
+			Exporter: config.TracingExporterFile,
+			File:     config.FileTracing{Path: filepath.Join(t.TempDir(), "spans.json")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fxApp := fxtest.New(t,
+				testlogger.Module(t),
+				fx.Provide(fx.Annotated{
+					Target: func() string { return service.Frontend },
+					Name:   "service-full-name"},
+					func() config.Config {
+						return config.Config{Tracing: cfg}
+					}),
+				Module)
+			fxApp.RequireStart().RequireStop()
+		})
+	}
+}
@@ -232,6 +232,163 @@ func (v *ResendReplicationTasksRequest) GetRemoteCluster() (o string) {
 	return
 }
 
+// ImportWorkflowExecutionRequest is an internal type (TBD...)
+type ImportWorkflowExecutionRequest struct {
+	Domain string `json:"domain,omitempty"`
+	// HistoryExport is the content of a file written by the admin workflow export command
+	HistoryExport []byte `json:"historyExport,omitempty"`
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *ImportWorkflowExecutionRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetHistoryExport is an internal getter (TBD...)
+func (v *ImportWorkflowExecutionRequest) GetHistoryExport() (o []byte) {
+	if v != nil {
+		return v.HistoryExport
+	}
+	return
+}
+
+// ImportWorkflowExecutionResponse is an internal type (TBD...)
+type ImportWorkflowExecutionResponse struct {
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+}
+
+// GetWorkflowExecution is an internal getter (TBD...)
+func (v *ImportWorkflowExecutionResponse) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil {
+		return v.WorkflowExecution
+	}
+	return
+}
+
+// GetReplicationStatusRequest is an internal type (TBD...)
+type GetReplicationStatusRequest struct {
+	// Domain limits the status to a single domain, all domains are reported if empty
+	Domain string `json:"domain,omitempty"`
+	// RemoteCluster limits the status to a single remote cluster, all remote clusters are reported if empty
+	RemoteCluster string `json:"remoteCluster,omitempty"`
+	// ShardIDs limits the status to the given shards, all shards are reported if empty
+	ShardIDs []int32 `json:"shardIDs,omitempty"`
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *GetReplicationStatusRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetRemoteCluster is an internal getter (TBD...)
+func (v *GetReplicationStatusRequest) GetRemoteCluster() (o string) {
+	if v != nil {
+		return v.RemoteCluster
+	}
+	return
+}
+
+// GetShardIDs is an internal getter (TBD...)
+func (v *GetReplicationStatusRequest) GetShardIDs() (o []int32) {
+	if v != nil {
+		return v.ShardIDs
+	}
+	return
+}
+
+// DescribeReplicationQueueRequest is the request of the history DescribeReplicationQueue API,
+// cadence-idl does not define it, it is sent as JSON, see client/wrappers/json
+type DescribeReplicationQueueRequest struct {
+	ShardID     int32  `json:"shardID,omitempty"`
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+// GetShardID is an internal getter (TBD...)
+func (v *DescribeReplicationQueueRequest) GetShardID() (o int32) {
+	if v != nil {
+		return v.ShardID
+	}
+	return
+}
+
+// GetClusterName is an internal getter (TBD...)
+func (v *DescribeReplicationQueueRequest) GetClusterName() (o string) {
+	if v != nil {
+		return v.ClusterName
+	}
+	return
+}
+
+// GetReplicationStatusResponse is an internal type (TBD...)
+type GetReplicationStatusResponse struct {
+	Shards  []*ReplicationShardStatus  `json:"shards,omitempty"`
+	Domains []*ReplicationDomainStatus `json:"domains,omitempty"`
+}
+
+// ReplicationShardStatus is the status of replication from a shard of the current cluster to a remote cluster
+type ReplicationShardStatus struct {
+	ShardID       int32  `json:"shardID"`
+	RemoteCluster string `json:"remoteCluster,omitempty"`
+	// LastReplicatedTaskID is the ID of the last replication task acknowledged by the remote cluster
+	LastReplicatedTaskID int64 `json:"lastReplicatedTaskID"`
+	// MaxTaskID is the ID of the last replication task that is available for replication
+	MaxTaskID    int64 `json:"maxTaskID"`
+	PendingTasks int64 `json:"pendingTasks"`
+	// PendingTasksTruncated is set when not all pending tasks were scanned, pending task counts are then lower bounds
+	PendingTasksTruncated bool `json:"pendingTasksTruncated,omitempty"`
+	// LagInMilliseconds is the age of the oldest pending replication task
+	LagInMilliseconds int64 `json:"lagInMilliseconds"`
+	// TasksPerSecond is the recent rate at which replication tasks were sent to the remote cluster
+	TasksPerSecond float64 `json:"tasksPerSecond"`
+	// EstimatedCatchUpInMilliseconds is not set when the replication rate is unknown
+	EstimatedCatchUpInMilliseconds *int64 `json:"estimatedCatchUpInMilliseconds,omitempty"`
+	// DLQSize is the number of replication tasks from this shard in the DLQ of the remote cluster,
+	// it is not set when the remote cluster could not be reached
+	DLQSize *int64                     `json:"dlqSize,omitempty"`
+	Domains []*ReplicationDomainStatus `json:"domains,omitempty"`
+}
+
+// GetEstimatedCatchUpInMilliseconds is an internal getter (TBD...)
+func (v *ReplicationShardStatus) GetEstimatedCatchUpInMilliseconds() (o int64) {
+	if v != nil && v.EstimatedCatchUpInMilliseconds != nil {
+		return *v.EstimatedCatchUpInMilliseconds
+	}
+	return
+}
+
+// GetDLQSize is an internal getter (TBD...)
+func (v *ReplicationShardStatus) GetDLQSize() (o int64) {
+	if v != nil && v.DLQSize != nil {
+		return *v.DLQSize
+	}
+	return
+}
+
+// ReplicationDomainStatus is the status of replication of a domain to a remote cluster
+type ReplicationDomainStatus struct {
+	Domain        string `json:"domain,omitempty"`
+	RemoteCluster string `json:"remoteCluster,omitempty"`
+	PendingTasks  int64  `json:"pendingTasks"`
+	// LagInMilliseconds is the age of the oldest pending replication task of the domain
+	LagInMilliseconds int64 `json:"lagInMilliseconds"`
+	// EstimatedCatchUpInMilliseconds is not set when the replication rate is unknown
+	EstimatedCatchUpInMilliseconds *int64 `json:"estimatedCatchUpInMilliseconds,omitempty"`
+}
+
+// GetEstimatedCatchUpInMilliseconds is an internal getter (TBD...)
+func (v *ReplicationDomainStatus) GetEstimatedCatchUpInMilliseconds() (o int64) {
+	if v != nil && v.EstimatedCatchUpInMilliseconds != nil {
+		return *v.EstimatedCatchUpInMilliseconds
+	}
+	return
+}
+
 // RingInfo is an internal type (TBD...)
 type RingInfo struct {
 	Role        string      `json:"role,omitempty"`
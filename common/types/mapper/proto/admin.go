@@ -602,6 +602,8 @@ func ToAdminReadDLQMessagesResponse(t *adminv1.ReadDLQMessagesResponse) *types.R
 	}
 }
 
+// FromAdminReapplyEventsRequest drops ReapplyFilter, cadence-idl does not define it.
+// Requests using it are sent as JSON, see client/wrappers/json.
 func FromAdminReapplyEventsRequest(t *types.ReapplyEventsRequest) *adminv1.ReapplyEventsRequest {
 	if t == nil {
 		return nil
@@ -2840,6 +2840,8 @@ func ToResetStickyTaskListRequest(t *apiv1.ResetStickyTaskListRequest) *types.Re
 	}
 }
 
+// FromResetWorkflowExecutionRequest drops ResetType, ResetTimestamp, BadBinaryChecksum, DryRun and ReapplyFilter,
+// cadence-idl does not define them. Requests using them are sent as JSON, see client/wrappers/json.
 func FromResetWorkflowExecutionRequest(t *types.ResetWorkflowExecutionRequest) *apiv1.ResetWorkflowExecutionRequest {
 	if t == nil {
 		return nil
@@ -2868,6 +2870,7 @@ func ToResetWorkflowExecutionRequest(t *apiv1.ResetWorkflowExecutionRequest) *ty
 	}
 }
 
+// FromResetWorkflowExecutionResponse drops DryRunResult, which is only returned to requests sent as JSON
 func FromResetWorkflowExecutionResponse(t *types.ResetWorkflowExecutionResponse) *apiv1.ResetWorkflowExecutionResponse {
 	if t == nil {
 		return nil
@@ -4015,7 +4015,8 @@ func ToQueryWorkflowResponse(t *shared.QueryWorkflowResponse) *types.QueryWorkfl
 	}
 }
 
-// FromAdminReapplyEventsRequest converts internal ReapplyEventsRequest type to thrift
+// FromAdminReapplyEventsRequest converts internal ReapplyEventsRequest type to thrift.
+// ReapplyFilter is dropped, cadence-idl does not define it. Requests using it are sent as JSON, see client/wrappers/json.
 func FromAdminReapplyEventsRequest(t *types.ReapplyEventsRequest) *shared.ReapplyEventsRequest {
 	if t == nil {
 		return nil
@@ -4527,7 +4528,9 @@ func ToResetStickyTaskListResponse(t *shared.ResetStickyTaskListResponse) *types
 	return &types.ResetStickyTaskListResponse{}
 }
 
-// FromResetWorkflowExecutionRequest converts internal ResetWorkflowExecutionRequest type to thrift
+// FromResetWorkflowExecutionRequest converts internal ResetWorkflowExecutionRequest type to thrift.
+// ResetType, ResetTimestamp, BadBinaryChecksum, DryRun and ReapplyFilter are dropped, cadence-idl does not define them.
+// Requests using them are sent as JSON, see client/wrappers/json.
 func FromResetWorkflowExecutionRequest(t *types.ResetWorkflowExecutionRequest) *shared.ResetWorkflowExecutionRequest {
 	if t == nil {
 		return nil
@@ -4557,7 +4560,8 @@ func ToResetWorkflowExecutionRequest(t *shared.ResetWorkflowExecutionRequest) *t
 	}
 }
 
-// FromResetWorkflowExecutionResponse converts internal ResetWorkflowExecutionResponse type to thrift
+// FromResetWorkflowExecutionResponse converts internal ResetWorkflowExecutionResponse type to thrift.
+// DryRunResult is dropped, it is only returned to requests sent as JSON.
 func FromResetWorkflowExecutionResponse(t *types.ResetWorkflowExecutionResponse) *shared.ResetWorkflowExecutionResponse {
 	if t == nil {
 		return nil
@@ -530,6 +530,9 @@ type ReadDLQMessagesRequest struct {
 	InclusiveEndMessageID *int64   `json:"inclusiveEndMessageID,omitempty"`
 	MaximumPageSize       int32    `json:"maximumPageSize,omitempty"`
 	NextPageToken         []byte   `json:"nextPageToken,omitempty"`
+	// IncludeRedriveStatus returns the automatic re-drive status of replication DLQ messages,
+	// it is not defined by cadence-idl and only served as JSON
+	IncludeRedriveStatus bool `json:"includeRedriveStatus,omitempty"`
 }
 
 // GetType is an internal getter (TBD...)
@@ -580,12 +583,42 @@ func (v *ReadDLQMessagesRequest) GetNextPageToken() (o []byte) {
 	return
 }
 
+// GetIncludeRedriveStatus is an internal getter (TBD...)
+func (v *ReadDLQMessagesRequest) GetIncludeRedriveStatus() (o bool) {
+	if v != nil {
+		return v.IncludeRedriveStatus
+	}
+	return
+}
+
 // ReadDLQMessagesResponse is an internal type (TBD...)
 type ReadDLQMessagesResponse struct {
 	Type                 *DLQType               `json:"type,omitempty"`
 	ReplicationTasks     []*ReplicationTask     `json:"replicationTasks,omitempty"`
 	ReplicationTasksInfo []*ReplicationTaskInfo `json:"replicationTasksInfo,omitempty"`
 	NextPageToken        []byte                 `json:"nextPageToken,omitempty"`
+	// RedriveStatuses are set for the returned messages whose automatic re-drive failed, if IncludeRedriveStatus was requested
+	RedriveStatuses []*ReplicationDLQRedriveStatus `json:"redriveStatuses,omitempty"`
+}
+
+// ReplicationDLQRedriveStatus is the automatic re-drive status of a replication DLQ message.
+// It is kept in memory by the history host owning the shard, and starts over when the shard moves to another host.
+type ReplicationDLQRedriveStatus struct {
+	TaskID      int64  `json:"taskID"`
+	Attempts    int32  `json:"attempts"`
+	ErrorClass  string `json:"errorClass,omitempty"`
+	LastError   string `json:"lastError,omitempty"`
+	Quarantined bool   `json:"quarantined,omitempty"`
+	// NextAttemptTimestamp is not set for quarantined messages, which are no longer re-driven automatically
+	NextAttemptTimestamp *int64 `json:"nextAttemptTimestamp,omitempty"`
+}
+
+// GetNextAttemptTimestamp is an internal getter (TBD...)
+func (v *ReplicationDLQRedriveStatus) GetNextAttemptTimestamp() (o int64) {
+	if v != nil && v.NextAttemptTimestamp != nil {
+		return *v.NextAttemptTimestamp
+	}
+	return
 }
 
 // ReplicationMessages is an internal type (TBD...)
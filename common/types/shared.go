@@ -1615,6 +1615,65 @@ func (v *DeprecateDomainRequest) GetName() (o string) {
 	return
 }
 
+// DescribeAsyncRequestRequest is an internal type (TBD...)
+type DescribeAsyncRequestRequest struct {
+	Domain    string `json:"domain,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *DescribeAsyncRequestRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetRequestID is an internal getter (TBD...)
+func (v *DescribeAsyncRequestRequest) GetRequestID() (o string) {
+	if v != nil {
+		return v.RequestID
+	}
+	return
+}
+
+// DescribeAsyncRequestResponse is an internal type (TBD...)
+type DescribeAsyncRequestResponse struct {
+	// Status is one of pending, started, duplicate, succeeded or failed
+	Status      string `json:"status,omitempty"`
+	RequestType string `json:"requestType,omitempty"`
+	// WorkflowExecution has a RunID only for started and duplicate start requests
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	// Reason is set for failed requests
+	Reason               string `json:"reason,omitempty"`
+	LastUpdatedTimestamp *int64 `json:"lastUpdatedTimestamp,omitempty"`
+	ExpirationTimestamp  *int64 `json:"expirationTimestamp,omitempty"`
+}
+
+// GetStatus is an internal getter (TBD...)
+func (v *DescribeAsyncRequestResponse) GetStatus() (o string) {
+	if v != nil {
+		return v.Status
+	}
+	return
+}
+
+// GetWorkflowExecution is an internal getter (TBD...)
+func (v *DescribeAsyncRequestResponse) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+	return
+}
+
+// GetReason is an internal getter (TBD...)
+func (v *DescribeAsyncRequestResponse) GetReason() (o string) {
+	if v != nil {
+		return v.Reason
+	}
+	return
+}
+
 // DescribeDomainRequest is an internal type (TBD...)
 type DescribeDomainRequest struct {
 	Name *string `json:"name,omitempty"`
@@ -4785,6 +4844,9 @@ type ReapplyEventsRequest struct {
 	DomainName        string             `json:"domainName,omitempty"`
 	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
 	Events            *DataBlob          `json:"events,omitempty"`
+	// ReapplyFilter narrows down the signals to reapply. Like ResetWorkflowExecutionRequest.ReapplyFilter,
+	// cadence-idl does not define it and requests using it are sent as JSON.
+	ReapplyFilter *EventReapplyFilter `json:"reapplyFilter,omitempty"`
 }
 
 // GetDomainName is an internal getter (TBD...)
@@ -4811,6 +4873,14 @@ func (v *ReapplyEventsRequest) GetEvents() (o *DataBlob) {
 	return
 }
 
+// GetReapplyFilter is an internal getter (TBD...)
+func (v *ReapplyEventsRequest) GetReapplyFilter() (o *EventReapplyFilter) {
+	if v != nil {
+		return v.ReapplyFilter
+	}
+	return
+}
+
 // RecordActivityTaskHeartbeatByIDRequest is an internal type (TBD...)
 type RecordActivityTaskHeartbeatByIDRequest struct {
 	Domain     string `json:"domain,omitempty"`
@@ -5271,6 +5341,24 @@ func (v *RequestCancelWorkflowExecutionRequest) ByteSize() uint64 {
 	return 0
 }
 
+// RequestCancelWorkflowExecutionAsyncRequest is an internal type (TBD...)
+type RequestCancelWorkflowExecutionAsyncRequest struct {
+	*RequestCancelWorkflowExecutionRequest
+}
+
+// RequestCancelWorkflowExecutionAsyncResponse is an internal type (TBD...)
+type RequestCancelWorkflowExecutionAsyncResponse struct {
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// GetRequestID is an internal getter (TBD...)
+func (v *RequestCancelWorkflowExecutionAsyncResponse) GetRequestID() (o string) {
+	if v != nil {
+		return v.RequestID
+	}
+	return
+}
+
 // ResetPointInfo is an internal type (TBD...)
 type ResetPointInfo struct {
 	BinaryChecksum           string `json:"binaryChecksum,omitempty"`
@@ -5424,6 +5512,16 @@ type ResetWorkflowExecutionRequest struct {
 	DecisionFinishEventID int64              `json:"decisionFinishEventId,omitempty"`
 	RequestID             string             `json:"requestId,omitempty"`
 	SkipSignalReapply     bool               `json:"skipSignalReapply,omitempty"`
+	// ResetType and ResetTimestamp let the history service choose DecisionFinishEventID, which must be left empty then.
+	// cadence-idl does not define them, nor BadBinaryChecksum and DryRun: requests using them are sent as JSON,
+	// see client/wrappers/json.
+	ResetType         *ResetType `json:"resetType,omitempty"`
+	ResetTimestamp    *int64     `json:"resetTimestamp,omitempty"`
+	BadBinaryChecksum string     `json:"badBinaryChecksum,omitempty"`
+	DryRun            bool       `json:"dryRun,omitempty"`
+	// ReapplyFilter narrows down the signals reapplied after the reset point, ignored with SkipSignalReapply.
+	// Same as ResetType, requests using it are sent as JSON.
+	ReapplyFilter *EventReapplyFilter `json:"reapplyFilter,omitempty"`
 }
 
 // GetDomain is an internal getter (TBD...)
@@ -5474,6 +5572,174 @@ func (v *ResetWorkflowExecutionRequest) GetSkipSignalReapply() (o bool) {
 	return
 }
 
+// GetResetType is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionRequest) GetResetType() (o ResetType) {
+	if v != nil && v.ResetType != nil {
+		return *v.ResetType
+	}
+	return
+}
+
+// GetResetTimestamp is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionRequest) GetResetTimestamp() (o int64) {
+	if v != nil && v.ResetTimestamp != nil {
+		return *v.ResetTimestamp
+	}
+	return
+}
+
+// GetBadBinaryChecksum is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionRequest) GetBadBinaryChecksum() (o string) {
+	if v != nil {
+		return v.BadBinaryChecksum
+	}
+	return
+}
+
+// GetDryRun is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionRequest) GetDryRun() (o bool) {
+	if v != nil {
+		return v.DryRun
+	}
+	return
+}
+
+// GetReapplyFilter is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionRequest) GetReapplyFilter() (o *EventReapplyFilter) {
+	if v != nil {
+		return v.ReapplyFilter
+	}
+	return
+}
+
+// EventReapplyFilter selects the signals to reapply when a workflow is reset.
+// Empty fields do not filter anything.
+type EventReapplyFilter struct {
+	IncludeSignalNames []string `json:"includeSignalNames,omitempty"`
+	ExcludeSignalNames []string `json:"excludeSignalNames,omitempty"`
+	SignalIdentities   []string `json:"signalIdentities,omitempty"`
+	// SignalsBefore only keeps signals received strictly before this timestamp, in unix nanos
+	SignalsBefore *int64 `json:"signalsBefore,omitempty"`
+}
+
+// GetIncludeSignalNames is an internal getter (TBD...)
+func (v *EventReapplyFilter) GetIncludeSignalNames() (o []string) {
+	if v != nil {
+		return v.IncludeSignalNames
+	}
+	return
+}
+
+// GetExcludeSignalNames is an internal getter (TBD...)
+func (v *EventReapplyFilter) GetExcludeSignalNames() (o []string) {
+	if v != nil {
+		return v.ExcludeSignalNames
+	}
+	return
+}
+
+// GetSignalIdentities is an internal getter (TBD...)
+func (v *EventReapplyFilter) GetSignalIdentities() (o []string) {
+	if v != nil {
+		return v.SignalIdentities
+	}
+	return
+}
+
+// GetSignalsBefore is an internal getter (TBD...)
+func (v *EventReapplyFilter) GetSignalsBefore() (o int64) {
+	if v != nil && v.SignalsBefore != nil {
+		return *v.SignalsBefore
+	}
+	return
+}
+
+// ResetType is the point in a workflow's history to reset to, resolved by the server
+type ResetType int32
+
+// Ptr is a helper function for getting pointer value
+func (e ResetType) Ptr() *ResetType {
+	return &e
+}
+
+// String returns a readable string representation of ResetType.
+func (e ResetType) String() string {
+	w := int32(e)
+	switch w {
+	case 0:
+		return "LAST_DECISION_COMPLETED"
+	case 1:
+		return "FIRST_DECISION_COMPLETED"
+	case 2:
+		return "LAST_CONTINUED_AS_NEW"
+	case 3:
+		return "BAD_BINARY"
+	case 4:
+		return "DECISION_COMPLETED_TIME"
+	case 5:
+		return "FIRST_DECISION_SCHEDULED"
+	case 6:
+		return "LAST_DECISION_SCHEDULED"
+	}
+	return fmt.Sprintf("ResetType(%d)", w)
+}
+
+// UnmarshalText parses enum value from string representation
+func (e *ResetType) UnmarshalText(value []byte) error {
+	switch s := strings.ToUpper(string(value)); s {
+	case "LAST_DECISION_COMPLETED":
+		*e = ResetTypeLastDecisionCompleted
+		return nil
+	case "FIRST_DECISION_COMPLETED":
+		*e = ResetTypeFirstDecisionCompleted
+		return nil
+	case "LAST_CONTINUED_AS_NEW":
+		*e = ResetTypeLastContinuedAsNew
+		return nil
+	case "BAD_BINARY":
+		*e = ResetTypeBadBinary
+		return nil
+	case "DECISION_COMPLETED_TIME":
+		*e = ResetTypeDecisionCompletedTime
+		return nil
+	case "FIRST_DECISION_SCHEDULED":
+		*e = ResetTypeFirstDecisionScheduled
+		return nil
+	case "LAST_DECISION_SCHEDULED":
+		*e = ResetTypeLastDecisionScheduled
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "ResetType", err)
+		}
+		*e = ResetType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes ResetType to text.
+func (e ResetType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+const (
+	// ResetTypeLastDecisionCompleted resets to the last DecisionTaskCompleted event
+	ResetTypeLastDecisionCompleted ResetType = iota
+	// ResetTypeFirstDecisionCompleted resets to the first DecisionTaskCompleted event
+	ResetTypeFirstDecisionCompleted
+	// ResetTypeLastContinuedAsNew resets to the last DecisionTaskCompleted event of the run that continued as new into this one
+	ResetTypeLastContinuedAsNew
+	// ResetTypeBadBinary resets to the auto-reset point of ResetWorkflowExecutionRequest.BadBinaryChecksum
+	ResetTypeBadBinary
+	// ResetTypeDecisionCompletedTime resets to the first DecisionTaskCompleted event at or after ResetWorkflowExecutionRequest.ResetTimestamp
+	ResetTypeDecisionCompletedTime
+	// ResetTypeFirstDecisionScheduled resets to the first DecisionTaskScheduled event
+	ResetTypeFirstDecisionScheduled
+	// ResetTypeLastDecisionScheduled resets to the last DecisionTaskScheduled event
+	ResetTypeLastDecisionScheduled
+)
+
 // Size returns the approximate memory used in bytes
 func (v *ResetWorkflowExecutionRequest) ByteSize() uint64 {
 	return 0
@@ -5482,6 +5748,9 @@ func (v *ResetWorkflowExecutionRequest) ByteSize() uint64 {
 // ResetWorkflowExecutionResponse is an internal type (TBD...)
 type ResetWorkflowExecutionResponse struct {
 	RunID string `json:"runId,omitempty"`
+	// DryRunResult is only set for dry-run requests, which do not create a new run.
+	// Like ResetWorkflowExecutionRequest.DryRun, it is only carried by the JSON procedures.
+	DryRunResult *ResetWorkflowDryRunResult `json:"dryRunResult,omitempty"`
 }
 
 // GetRunID is an internal getter (TBD...)
@@ -5492,6 +5761,58 @@ func (v *ResetWorkflowExecutionResponse) GetRunID() (o string) {
 	return
 }
 
+// GetDryRunResult is an internal getter (TBD...)
+func (v *ResetWorkflowExecutionResponse) GetDryRunResult() (o *ResetWorkflowDryRunResult) {
+	if v != nil && v.DryRunResult != nil {
+		return v.DryRunResult
+	}
+	return
+}
+
+// ResetWorkflowDryRunResult describes what a reset would do, without doing it
+type ResetWorkflowDryRunResult struct {
+	BaseRunID             string `json:"baseRunId,omitempty"`
+	DecisionFinishEventID int64  `json:"decisionFinishEventId,omitempty"`
+	// DecisionFinishEvent is the event the reset would replace, nil when it is not written yet
+	DecisionFinishEvent *HistoryEvent `json:"decisionFinishEvent,omitempty"`
+	// ReappliedEvents are the events of the base run and its continued-as-new runs that would be reapplied after reset
+	ReappliedEvents []*HistoryEvent `json:"reappliedEvents,omitempty"`
+	// FailedActivities are the activities in flight at the reset point, which would be failed
+	FailedActivities []*PendingActivityInfo `json:"failedActivities,omitempty"`
+}
+
+// GetBaseRunID is an internal getter (TBD...)
+func (v *ResetWorkflowDryRunResult) GetBaseRunID() (o string) {
+	if v != nil {
+		return v.BaseRunID
+	}
+	return
+}
+
+// GetDecisionFinishEventID is an internal getter (TBD...)
+func (v *ResetWorkflowDryRunResult) GetDecisionFinishEventID() (o int64) {
+	if v != nil {
+		return v.DecisionFinishEventID
+	}
+	return
+}
+
+// GetReappliedEvents is an internal getter (TBD...)
+func (v *ResetWorkflowDryRunResult) GetReappliedEvents() (o []*HistoryEvent) {
+	if v != nil && v.ReappliedEvents != nil {
+		return v.ReappliedEvents
+	}
+	return
+}
+
+// GetFailedActivities is an internal getter (TBD...)
+func (v *ResetWorkflowDryRunResult) GetFailedActivities() (o []*PendingActivityInfo) {
+	if v != nil && v.FailedActivities != nil {
+		return v.FailedActivities
+	}
+	return
+}
+
 // Size returns the approximate memory used in bytes
 func (v *ResetWorkflowExecutionResponse) ByteSize() uint64 {
 	return 0
@@ -6363,6 +6684,24 @@ type SignalWithStartWorkflowExecutionAsyncRequest struct {
 type SignalWithStartWorkflowExecutionAsyncResponse struct {
 }
 
+// SignalWorkflowExecutionAsyncRequest is an internal type (TBD...)
+type SignalWorkflowExecutionAsyncRequest struct {
+	*SignalWorkflowExecutionRequest
+}
+
+// SignalWorkflowExecutionAsyncResponse is an internal type (TBD...)
+type SignalWorkflowExecutionAsyncResponse struct {
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// GetRequestID is an internal getter (TBD...)
+func (v *SignalWorkflowExecutionAsyncResponse) GetRequestID() (o string) {
+	if v != nil {
+		return v.RequestID
+	}
+	return
+}
+
 // SignalWorkflowExecutionRequest is an internal type (TBD...)
 type SignalWorkflowExecutionRequest struct {
 	Domain            string             `json:"domain,omitempty"`
@@ -7170,6 +7509,34 @@ func (v *TerminateWorkflowExecutionRequest) GetFirstExecutionRunID() (o string)
 	return
 }
 
+// TerminateWorkflowExecutionAsyncRequest is an internal type (TBD...)
+type TerminateWorkflowExecutionAsyncRequest struct {
+	*TerminateWorkflowExecutionRequest
+	// RequestID identifies the request when polling for its outcome, it is generated if empty
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// GetRequestID is an internal getter (TBD...)
+func (v *TerminateWorkflowExecutionAsyncRequest) GetRequestID() (o string) {
+	if v != nil {
+		return v.RequestID
+	}
+	return
+}
+
+// TerminateWorkflowExecutionAsyncResponse is an internal type (TBD...)
+type TerminateWorkflowExecutionAsyncResponse struct {
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// GetRequestID is an internal getter (TBD...)
+func (v *TerminateWorkflowExecutionAsyncResponse) GetRequestID() (o string) {
+	if v != nil {
+		return v.RequestID
+	}
+	return
+}
+
 // TimeoutType is an internal type (TBD...)
 type TimeoutType int32
 
@@ -21,6 +21,7 @@
 package admin
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -49,6 +50,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/ndc"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/replicationstatus"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
@@ -1315,6 +1317,139 @@ func (adh *adminHandlerImpl) ResendReplicationTasks(
 	)
 }
 
+// GetReplicationStatus returns the replication lag of every shard and domain to remote clusters
+func (adh *adminHandlerImpl) GetReplicationStatus(
+	ctx context.Context,
+	request *types.GetReplicationStatusRequest,
+) (_ *types.GetReplicationStatusResponse, err error) {
+	defer func() { log.CapturePanic(recover(), adh.GetLogger(), &err) }()
+	scope, sw := adh.startRequestProfile(ctx, metrics.AdminGetReplicationStatusScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(validate.ErrRequestNotSet, scope)
+	}
+	if request.GetDomain() != "" {
+		if _, err := adh.GetDomainCache().GetDomain(request.GetDomain()); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	remoteClusters := make([]string, 0)
+	for clusterName := range adh.GetClusterMetadata().GetRemoteClusterInfo() {
+		if request.GetRemoteCluster() == "" || request.GetRemoteCluster() == clusterName {
+			remoteClusters = append(remoteClusters, clusterName)
+		}
+	}
+	if len(remoteClusters) == 0 {
+		return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Unknown remote cluster %v.", request.GetRemoteCluster())}, scope)
+	}
+
+	shardIDs := request.GetShardIDs()
+	if len(shardIDs) == 0 {
+		for shardID := 0; shardID < adh.numberOfHistoryShards; shardID++ {
+			shardIDs = append(shardIDs, int32(shardID))
+		}
+	}
+	for _, shardID := range shardIDs {
+		if shardID < 0 || int(shardID) >= adh.numberOfHistoryShards {
+			return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Invalid shard ID %v.", shardID)}, scope)
+		}
+	}
+
+	currentCluster := adh.GetClusterMetadata().GetCurrentClusterName()
+	dlqSizes := make(map[string]map[int32]int64)
+	for _, clusterName := range remoteClusters {
+		counts, err := adh.countRemoteReplicationDLQMessages(ctx, clusterName)
+		if err != nil {
+			adh.GetLogger().Warn("Failed to count replication DLQ messages of remote cluster.", tag.ClusterName(clusterName), tag.Error(err))
+			continue
+		}
+		dlqSizes[clusterName] = replicationstatus.DLQSizes(counts, currentCluster)
+	}
+
+	resp, err := replicationstatus.Collect(ctx, adh.GetHistoryClient(), replicationstatus.Request{
+		Domain:         request.GetDomain(),
+		RemoteClusters: remoteClusters,
+		ShardIDs:       shardIDs,
+		DLQSizes:       dlqSizes,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+func (adh *adminHandlerImpl) countRemoteReplicationDLQMessages(
+	ctx context.Context,
+	clusterName string,
+) (map[types.HistoryDLQCountKey]int64, error) {
+	adminClient, err := adh.GetRemoteAdminClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := adminClient.CountDLQMessages(ctx, &types.CountDLQMessagesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.History, nil
+}
+
+// ImportWorkflowExecution recreates a workflow run from a history export through the replication path
+func (adh *adminHandlerImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *types.ImportWorkflowExecutionRequest,
+) (_ *types.ImportWorkflowExecutionResponse, err error) {
+	defer func() { log.CapturePanic(recover(), adh.GetLogger(), &err) }()
+	scope, sw := adh.startRequestProfile(ctx, metrics.AdminImportWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(validate.ErrRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(validate.ErrDomainNotSet, scope)
+	}
+	export, err := ndc.ReadHistoryExport(bytes.NewReader(request.GetHistoryExport()))
+	if err != nil {
+		return nil, adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+	}
+	domainEntry, err := adh.GetDomainCache().GetDomain(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	importer := ndc.NewHistoryImporter(
+		adh.GetDomainCache(),
+		func(ctx context.Context, request *types.ReplicateEventsV2Request) error {
+			return adh.GetHistoryClient().ReplicateEventsV2(ctx, request)
+		},
+		adh.GetLogger(),
+	)
+	if err := importer.ImportWorkflowHistory(ctx, domainID, export); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	// replicated runs only get standby tasks, regenerate them now that the domain owning the run is active here
+	workflowExecution := &types.WorkflowExecution{
+		WorkflowID: export.WorkflowID,
+		RunID:      export.RunID,
+	}
+	if err := adh.GetHistoryClient().RefreshWorkflowTasks(ctx, &types.HistoryRefreshWorkflowTasksRequest{
+		DomainUIID: domainID,
+		Request: &types.RefreshWorkflowTasksRequest{
+			Domain:    request.GetDomain(),
+			Execution: workflowExecution,
+		},
+	}); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &types.ImportWorkflowExecutionResponse{
+		WorkflowExecution: workflowExecution,
+	}, nil
+}
+
 func (adh *adminHandlerImpl) GetCrossClusterTasks(
 	ctx context.Context,
 	request *types.GetCrossClusterTasksRequest,
@@ -21,6 +21,7 @@
 package admin
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -36,6 +37,7 @@ import (
 	"github.com/uber-go/tally"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
@@ -43,7 +45,9 @@ import (
 	"github.com/uber/cadence/common/asyncworkflow/queueconfigapi"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/domain"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
@@ -53,6 +57,7 @@ import (
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/ndc"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
@@ -2381,6 +2386,132 @@ func Test_ResendReplicationTasks(t *testing.T) {
 	}
 }
 
+func Test_ImportWorkflowExecution(t *testing.T) {
+	domainID := uuid.New()
+	runID := uuid.New()
+	events := []*types.HistoryEvent{
+		{ID: 1, Version: 10, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, Version: 10, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+	}
+	batch, err := ndc.EncodeHistoryBatch(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	var export bytes.Buffer
+	require.NoError(t, ndc.WriteHistoryExport(&export, &ndc.HistoryExport{
+		FormatVersion: ndc.HistoryExportFormatVersion,
+		Domain:        "source-domain",
+		WorkflowID:    "test-workflow-id",
+		RunID:         runID,
+		EventEncoding: constants.EncodingTypeThriftRW,
+		Batches:       [][]byte{batch},
+	}))
+	domainEntry := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: domainID, Name: "test-domain"},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{},
+		0,
+	)
+
+	tests := map[string]struct {
+		input         *types.ImportWorkflowExecutionRequest
+		hcHandlerFunc func(mock *history.MockClient)
+		dcHandlerFunc func(mock *cache.MockDomainCache)
+		expected      *types.ImportWorkflowExecutionResponse
+		wantErr       bool
+	}{
+		"nil request": {
+			input:   nil,
+			wantErr: true,
+		},
+		"missing domain": {
+			input: &types.ImportWorkflowExecutionRequest{
+				HistoryExport: export.Bytes(),
+			},
+			wantErr: true,
+		},
+		"invalid export": {
+			input: &types.ImportWorkflowExecutionRequest{
+				Domain:        "test-domain",
+				HistoryExport: []byte("{}"),
+			},
+			wantErr: true,
+		},
+		"replication error": {
+			input: &types.ImportWorkflowExecutionRequest{
+				Domain:        "test-domain",
+				HistoryExport: export.Bytes(),
+			},
+			hcHandlerFunc: func(mock *history.MockClient) {
+				mock.EXPECT().ReplicateEventsV2(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			dcHandlerFunc: func(mock *cache.MockDomainCache) {
+				mock.EXPECT().GetDomain("test-domain").Return(domainEntry, nil)
+				mock.EXPECT().GetDomainByID(domainID).Return(domainEntry, nil)
+			},
+			wantErr: true,
+		},
+		"normal request": {
+			input: &types.ImportWorkflowExecutionRequest{
+				Domain:        "test-domain",
+				HistoryExport: export.Bytes(),
+			},
+			hcHandlerFunc: func(mock *history.MockClient) {
+				mock.EXPECT().ReplicateEventsV2(gomock.Any(), gomock.Any()).Return(nil)
+				mock.EXPECT().RefreshWorkflowTasks(gomock.Any(), &types.HistoryRefreshWorkflowTasksRequest{
+					DomainUIID: domainID,
+					Request: &types.RefreshWorkflowTasksRequest{
+						Domain: "test-domain",
+						Execution: &types.WorkflowExecution{
+							WorkflowID: "test-workflow-id",
+							RunID:      runID,
+						},
+					},
+				}).Return(nil)
+			},
+			dcHandlerFunc: func(mock *cache.MockDomainCache) {
+				mock.EXPECT().GetDomain("test-domain").Return(domainEntry, nil)
+				mock.EXPECT().GetDomainByID(domainID).Return(domainEntry, nil)
+			},
+			expected: &types.ImportWorkflowExecutionResponse{
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow-id",
+					RunID:      runID,
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			hcMock := history.NewMockClient(ctrl)
+			dcMock := cache.NewMockDomainCache(ctrl)
+			if tt.hcHandlerFunc != nil {
+				tt.hcHandlerFunc(hcMock)
+			}
+			if tt.dcHandlerFunc != nil {
+				tt.dcHandlerFunc(dcMock)
+			}
+
+			handler := adminHandlerImpl{
+				Resource: &resource.Test{
+					Logger:        testlogger.New(t),
+					MetricsClient: metrics.NewNoopMetricsClient(),
+					HistoryClient: hcMock,
+					DomainCache:   dcMock,
+				},
+			}
+
+			resp, err := handler.ImportWorkflowExecution(context.Background(), tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, resp)
+			}
+		})
+	}
+}
+
 func Test_GetCrossClusterTasks(t *testing.T) {
 	tests := map[string]struct {
 		input         *types.GetCrossClusterTasksRequest
@@ -2952,3 +3083,114 @@ func TestUpdateTaskListPartitionConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetReplicationStatus(t *testing.T) {
+	shardStatus := &types.ReplicationShardStatus{
+		ShardID:                        0,
+		RemoteCluster:                  cluster.TestAlternativeClusterName,
+		LastReplicatedTaskID:           10,
+		MaxTaskID:                      20,
+		PendingTasks:                   2,
+		LagInMilliseconds:              1500,
+		TasksPerSecond:                 4,
+		EstimatedCatchUpInMilliseconds: common.Int64Ptr(500),
+		Domains: []*types.ReplicationDomainStatus{
+			{
+				Domain:                         "test-domain",
+				RemoteCluster:                  cluster.TestAlternativeClusterName,
+				PendingTasks:                   2,
+				LagInMilliseconds:              1500,
+				EstimatedCatchUpInMilliseconds: common.Int64Ptr(500),
+			},
+		},
+	}
+	// the history client returns a new status for every call, GetReplicationStatus sets its DLQ size
+	describeShard := func() *types.ReplicationShardStatus {
+		status := *shardStatus
+		return &status
+	}
+	describeRequest := &types.DescribeReplicationQueueRequest{
+		ShardID:     0,
+		ClusterName: cluster.TestAlternativeClusterName,
+	}
+
+	tests := map[string]struct {
+		input           *types.GetReplicationStatusRequest
+		mockFn          func(hc *history.MockClient, ac *admin.MockClient)
+		expectedDLQSize *int64
+		wantErr         bool
+	}{
+		"nil request": {
+			input:   nil,
+			mockFn:  func(hc *history.MockClient, ac *admin.MockClient) {},
+			wantErr: true,
+		},
+		"unknown remote cluster": {
+			input:   &types.GetReplicationStatusRequest{RemoteCluster: "unknown-cluster"},
+			mockFn:  func(hc *history.MockClient, ac *admin.MockClient) {},
+			wantErr: true,
+		},
+		"invalid shard": {
+			input:   &types.GetReplicationStatusRequest{RemoteCluster: cluster.TestAlternativeClusterName, ShardIDs: []int32{1}},
+			mockFn:  func(hc *history.MockClient, ac *admin.MockClient) {},
+			wantErr: true,
+		},
+		"success": {
+			input: &types.GetReplicationStatusRequest{RemoteCluster: cluster.TestAlternativeClusterName},
+			mockFn: func(hc *history.MockClient, ac *admin.MockClient) {
+				ac.EXPECT().CountDLQMessages(gomock.Any(), &types.CountDLQMessagesRequest{}).Return(&types.CountDLQMessagesResponse{
+					History: map[types.HistoryDLQCountKey]int64{
+						{ShardID: 0, SourceCluster: cluster.TestCurrentClusterName}: 3,
+					},
+				}, nil)
+				hc.EXPECT().DescribeReplicationQueue(gomock.Any(), describeRequest).Return(describeShard(), nil)
+			},
+			expectedDLQSize: common.Int64Ptr(3),
+		},
+		"remote cluster unavailable": {
+			input: &types.GetReplicationStatusRequest{RemoteCluster: cluster.TestAlternativeClusterName},
+			mockFn: func(hc *history.MockClient, ac *admin.MockClient) {
+				ac.EXPECT().CountDLQMessages(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+				hc.EXPECT().DescribeReplicationQueue(gomock.Any(), describeRequest).Return(describeShard(), nil)
+			},
+		},
+		"history error": {
+			input: &types.GetReplicationStatusRequest{RemoteCluster: cluster.TestAlternativeClusterName},
+			mockFn: func(hc *history.MockClient, ac *admin.MockClient) {
+				ac.EXPECT().CountDLQMessages(gomock.Any(), gomock.Any()).Return(&types.CountDLQMessagesResponse{}, nil)
+				hc.EXPECT().DescribeReplicationQueue(gomock.Any(), describeRequest).Return(nil, errors.New("history error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, td := range tests {
+		t.Run(name, func(t *testing.T) {
+			goMock := gomock.NewController(t)
+			hcMock := history.NewMockClient(goMock)
+			acMock := admin.NewMockClient(goMock)
+			td.mockFn(hcMock, acMock)
+			handler := adminHandlerImpl{
+				Resource: &resource.Test{
+					Logger:            testlogger.New(t),
+					MetricsClient:     metrics.NewNoopMetricsClient(),
+					ClusterMetadata:   cluster.TestActiveClusterMetadata,
+					HistoryClient:     hcMock,
+					RemoteAdminClient: acMock,
+				},
+				numberOfHistoryShards: 1,
+			}
+
+			resp, err := handler.GetReplicationStatus(context.Background(), td.input)
+			if td.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Shards, 1)
+			assert.Equal(t, int64(2), resp.Shards[0].PendingTasks)
+			assert.Equal(t, td.expectedDLQSize, resp.Shards[0].DLQSize)
+			assert.Equal(t, shardStatus.Domains, resp.Domains)
+		})
+	}
+}
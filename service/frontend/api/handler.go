@@ -35,6 +35,8 @@ import (
 	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/client"
@@ -44,6 +46,7 @@ import (
 	"github.com/uber/cadence/common/elasticsearch/validator"
 	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/isolationgroup"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -86,6 +89,9 @@ type (
 		producerManager           ProducerManager
 		thriftrwEncoder           codec.BinaryEncoder
 		requestValidator          RequestValidator
+		largePayloadResolver      largepayload.Resolver
+		// statusStore is nil if the status of async requests is not recorded
+		statusStore requeststatus.Store
 	}
 
 	getHistoryContinuationToken struct {
@@ -118,6 +124,11 @@ func NewWorkflowHandler(
 	versionChecker client.VersionChecker,
 	domainHandler domain.Handler,
 ) *WorkflowHandler {
+	var statusStore requeststatus.Store
+	// the status of async requests can only be described if a blobstore is configured
+	if blobstoreClient := resource.GetBlobstoreClient(); blobstoreClient != nil {
+		statusStore = requeststatus.NewBlobstoreStore(blobstoreClient, resource.GetTimeSource(), config.AsyncRequestStatusTTL)
+	}
 	return &WorkflowHandler{
 		Resource:        resource,
 		config:          config,
@@ -146,9 +157,12 @@ func NewWorkflowHandler(
 			resource.GetAsyncWorkflowQueueProvider(),
 			resource.GetLogger(),
 			resource.GetMetricsClient(),
+			resource.GetPersistenceBean().GetAsyncWorkflowQueueManager(),
 		),
-		thriftrwEncoder:  codec.NewThriftRWEncoder(),
-		requestValidator: NewRequestValidator(resource.GetLogger(), resource.GetMetricsClient(), config),
+		thriftrwEncoder:      codec.NewThriftRWEncoder(),
+		requestValidator:     NewRequestValidator(resource.GetLogger(), resource.GetMetricsClient(), config),
+		largePayloadResolver: largepayload.NewResolver(resource.GetBlobstoreClient(), config.LargePayloadOffloadThreshold),
+		statusStore:          statusStore,
 	}
 }
 
@@ -370,12 +384,21 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return nil, nil
 	}
 
+	var execution types.WorkflowExecution
+	if matchingResp.WorkflowExecution != nil {
+		execution = *matchingResp.WorkflowExecution
+	}
+	input, err := wh.largePayloadResolver.Resolve(ctx, domainID, domainName, execution, matchingResp.Input)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.PollForActivityTaskResponse{
 		TaskToken:                       matchingResp.TaskToken,
 		WorkflowExecution:               matchingResp.WorkflowExecution,
 		ActivityID:                      matchingResp.ActivityID,
 		ActivityType:                    matchingResp.ActivityType,
-		Input:                           matchingResp.Input,
+		Input:                           input,
 		ScheduledTimestamp:              matchingResp.ScheduledTimestamp,
 		ScheduleToCloseTimeoutSeconds:   matchingResp.ScheduleToCloseTimeoutSeconds,
 		StartedTimestamp:                matchingResp.StartedTimestamp,
@@ -828,6 +851,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return validate.ErrIdentityTooLong
 	}
 
+	if err := largepayload.ValidatePayloads(completeRequest.Result); err != nil {
+		return err
+	}
+
 	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
 
@@ -930,6 +957,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 		return err
 	}
 
+	if err := largepayload.ValidatePayloads(completeRequest.Result); err != nil {
+		return err
+	}
+
 	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
 
@@ -1400,6 +1431,10 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 		return nil, err
 	}
 
+	if err := largepayload.ValidateDecisions(completeRequest.Decisions); err != nil {
+		return nil, err
+	}
+
 	histResp, err := wh.GetHistoryClient().RespondDecisionTaskCompleted(ctx, &types.HistoryRespondDecisionTaskCompletedRequest{
 		DomainUUID:      taskToken.DomainID,
 		CompleteRequest: completeRequest},
@@ -1599,39 +1634,66 @@ func (wh *WorkflowHandler) StartWorkflowExecutionAsync(
 		return nil, err
 	}
 
-	producer, err := wh.producerManager.GetProducerByDomain(startRequest.GetDomain())
+	requestType := sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest
+	message, err := wh.newAsyncRequestMessage(requestType, thrift.FromStartWorkflowExecutionAsyncRequest(startRequest))
 	if err != nil {
 		return nil, err
 	}
-
-	// Serialize the message to be sent to the queue.
-	// Avoid JSON because json encoding of requests excludes PII fields such as input. JSON encoded request are logged by acccess controlled api layer for audit purposes.
-	payload, err := wh.thriftrwEncoder.Encode(thrift.FromStartWorkflowExecutionAsyncRequest(startRequest))
+	// the status of the request is tracked under the request ID of the start request
+	err = wh.publishAsyncRequest(
+		ctx,
+		scope,
+		startRequest.GetDomain(),
+		requestType.String(),
+		startRequest.StartWorkflowExecutionRequest.GetRequestID(),
+		startRequest.GetWorkflowID(),
+		message,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode StartWorkflowExecutionAsyncRequest: %v", err)
+		return nil, err
 	}
-	scope.RecordTimer(metrics.AsyncRequestPayloadSize, time.Duration(len(payload)))
+	return &types.StartWorkflowExecutionAsyncResponse{}, nil
+}
 
-	// propagate the headers from the context to the message
-	header := &shared.Header{
-		Fields: make(map[string][]byte),
+// DescribeAsyncRequest returns the status of a request enqueued by one of the async APIs
+func (wh *WorkflowHandler) DescribeAsyncRequest(
+	ctx context.Context,
+	request *types.DescribeAsyncRequestRequest,
+) (resp *types.DescribeAsyncRequestResponse, retError error) {
+	if wh.isShuttingDown() {
+		return nil, validate.ErrShuttingDown
 	}
-	for k, v := range yarpc.CallFromContext(ctx).OriginalHeaders() {
-		header.Fields[k] = []byte(v)
+	if request == nil {
+		return nil, validate.ErrRequestNotSet
+	}
+	if request.GetDomain() == "" {
+		return nil, validate.ErrDomainNotSet
+	}
+	if request.GetRequestID() == "" {
+		return nil, validate.ErrRequestIDNotSet
+	}
+	if _, err := wh.GetDomainCache().GetDomainID(request.GetDomain()); err != nil {
+		return nil, err
 	}
-	messageType := sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest
-	message := &sqlblobs.AsyncRequestMessage{
-		PartitionKey: common.StringPtr(startRequest.GetWorkflowID()),
-		Type:         &messageType,
-		Header:       header,
-		Encoding:     common.StringPtr(string(constants.EncodingTypeThriftRW)),
-		Payload:      payload,
+	if wh.statusStore == nil {
+		return nil, &types.BadRequestError{Message: "The status of async requests is not recorded because no blobstore is configured."}
 	}
-	err = producer.Publish(ctx, message)
+
+	status, err := wh.statusStore.Get(ctx, request.GetDomain(), request.GetRequestID())
 	if err != nil {
 		return nil, err
 	}
-	return &types.StartWorkflowExecutionAsyncResponse{}, nil
+	return &types.DescribeAsyncRequestResponse{
+		Status:      string(status.Status),
+		RequestType: status.RequestType,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: status.WorkflowID,
+			RunID:      status.RunID,
+		},
+		Reason:               status.Reason,
+		LastUpdatedTimestamp: common.Int64Ptr(status.UpdatedTime.UnixNano()),
+		ExpirationTimestamp:  common.Int64Ptr(status.ExpirationTime.UnixNano()),
+	}, nil
 }
 
 // StartWorkflowExecution - Creates a new workflow execution
@@ -1787,6 +1849,9 @@ func (wh *WorkflowHandler) validateStartWorkflowExecutionRequest(ctx context.Con
 		return err
 	}
 	wh.GetLogger().Debug("Start workflow execution request domain", tag.WorkflowDomainName(domainName))
+	if err := largepayload.ValidatePayloads(startRequest.Input); err != nil {
+		return err
+	}
 	domainID, err := wh.GetDomainCache().GetDomainID(domainName)
 	if err != nil {
 		return err
@@ -2095,21 +2160,44 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		return validate.ErrShuttingDown
 	}
 
+	scope := getMetricsScopeWithDomain(metrics.FrontendSignalWorkflowExecutionScope, signalRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
+	domainID, err := wh.validateSignalWorkflowExecutionRequest(signalRequest, scope)
+	if err != nil {
+		return err
+	}
+
+	domainName := signalRequest.GetDomain()
+	isolationGroup := wh.getIsolationGroup(ctx, domainName)
+	if !wh.isIsolationGroupHealthy(ctx, domainName, isolationGroup) {
+		return &types.BadRequestError{fmt.Sprintf("Domain %s is drained from isolation group %s.", domainName, isolationGroup)}
+	}
+
+	err = wh.GetHistoryClient().SignalWorkflowExecution(ctx, &types.HistorySignalWorkflowExecutionRequest{
+		DomainUUID:    domainID,
+		SignalRequest: signalRequest,
+	})
+	if err != nil {
+		return wh.normalizeVersionedErrors(ctx, err)
+	}
+
+	return nil
+}
+
+func (wh *WorkflowHandler) validateSignalWorkflowExecutionRequest(signalRequest *types.SignalWorkflowExecutionRequest, scope metrics.Scope) (string, error) {
 	if signalRequest == nil {
-		return validate.ErrRequestNotSet
+		return "", validate.ErrRequestNotSet
 	}
 
 	domainName := signalRequest.GetDomain()
 	wfExecution := signalRequest.GetWorkflowExecution()
 
 	if domainName == "" {
-		return validate.ErrDomainNotSet
+		return "", validate.ErrDomainNotSet
 	}
 	if err := validate.CheckExecution(wfExecution); err != nil {
-		return err
+		return "", err
 	}
 
-	scope := getMetricsScopeWithDomain(metrics.FrontendSignalWorkflowExecutionScope, signalRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
 	idLengthWarnLimit := wh.config.MaxIDLengthWarnLimit()
 	if !common.IsValidIDLength(
 		domainName,
@@ -2120,11 +2208,11 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		domainName,
 		wh.GetLogger(),
 		tag.IDTypeDomainName) {
-		return validate.ErrDomainTooLong
+		return "", validate.ErrDomainTooLong
 	}
 
 	if signalRequest.GetSignalName() == "" {
-		return validate.ErrSignalNameNotSet
+		return "", validate.ErrSignalNameNotSet
 	}
 
 	if !common.IsValidIDLength(
@@ -2136,7 +2224,7 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		domainName,
 		wh.GetLogger(),
 		tag.IDTypeSignalName) {
-		return validate.ErrSignalNameTooLong
+		return "", validate.ErrSignalNameTooLong
 	}
 
 	if !common.IsValidIDLength(
@@ -2148,12 +2236,16 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		domainName,
 		wh.GetLogger(),
 		tag.IDTypeRequestID) {
-		return validate.ErrRequestIDTooLong
+		return "", validate.ErrRequestIDTooLong
+	}
+
+	if err := largepayload.ValidatePayloads(signalRequest.Input); err != nil {
+		return "", err
 	}
 
 	domainID, err := wh.GetDomainCache().GetDomainID(domainName)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
@@ -2170,25 +2262,146 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		wh.GetLogger(),
 		tag.BlobSizeViolationOperation("SignalWorkflowExecution"),
 	); err != nil {
-		return err
+		return "", err
 	}
 
-	isolationGroup := wh.getIsolationGroup(ctx, domainName)
-	if !wh.isIsolationGroupHealthy(ctx, domainName, isolationGroup) {
-		return &types.BadRequestError{fmt.Sprintf("Domain %s is drained from isolation group %s.", domainName, isolationGroup)}
+	return domainID, nil
+}
+
+// SignalWorkflowExecutionAsync enqueues a signal to the async queue of the domain.
+// The outcome can be polled using the request ID of the response.
+func (wh *WorkflowHandler) SignalWorkflowExecutionAsync(
+	ctx context.Context,
+	signalRequest *types.SignalWorkflowExecutionAsyncRequest,
+) (resp *types.SignalWorkflowExecutionAsyncResponse, retError error) {
+	if wh.isShuttingDown() {
+		return nil, validate.ErrShuttingDown
+	}
+	if signalRequest == nil {
+		return nil, validate.ErrRequestNotSet
+	}
+	scope := getMetricsScopeWithDomain(metrics.FrontendSignalWorkflowExecutionAsyncScope, signalRequest.SignalWorkflowExecutionRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
+	// validate request before pushing to queue
+	if _, err := wh.validateSignalWorkflowExecutionRequest(signalRequest.SignalWorkflowExecutionRequest, scope); err != nil {
+		return nil, err
+	}
+	// the request ID also deduplicates the signal when the request is consumed more than once
+	if signalRequest.RequestID == "" {
+		signalRequest.RequestID = uuid.New().String()
 	}
 
-	err = wh.GetHistoryClient().SignalWorkflowExecution(ctx, &types.HistorySignalWorkflowExecutionRequest{
-		DomainUUID:    domainID,
-		SignalRequest: signalRequest,
+	requestType := asyncrequest.TypeSignalWorkflowExecution
+	message, err := wh.newAsyncRequestEnvelopeMessage(requestType, signalRequest.RequestID, thrift.FromSignalWorkflowExecutionRequest(signalRequest.SignalWorkflowExecutionRequest))
+	if err != nil {
+		return nil, err
+	}
+	err = wh.publishAsyncRequest(
+		ctx,
+		scope,
+		signalRequest.GetDomain(),
+		string(requestType),
+		signalRequest.RequestID,
+		signalRequest.GetWorkflowExecution().GetWorkflowID(),
+		message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &types.SignalWorkflowExecutionAsyncResponse{RequestID: signalRequest.RequestID}, nil
+}
+
+// publishAsyncRequest enqueues a request to the async queue of the domain, the headers of the call are propagated to the message.
+// The request is recorded as pending before it is published, so the outcome recorded by the consumer always overwrites it.
+func (wh *WorkflowHandler) publishAsyncRequest(
+	ctx context.Context,
+	scope metrics.Scope,
+	domainName string,
+	requestType string,
+	requestID string,
+	workflowID string,
+	message *sqlblobs.AsyncRequestMessage,
+) error {
+	producer, err := wh.producerManager.GetProducerByDomain(domainName)
+	if err != nil {
+		return err
+	}
+	err = wh.recordAsyncRequestStatus(ctx, domainName, requestID, &requeststatus.RequestStatus{
+		Status:      requeststatus.StatusPending,
+		RequestType: requestType,
+		WorkflowID:  workflowID,
 	})
 	if err != nil {
-		return wh.normalizeVersionedErrors(ctx, err)
+		return err
 	}
+	scope.RecordTimer(metrics.AsyncRequestPayloadSize, time.Duration(len(message.Payload)))
 
+	header := &shared.Header{
+		Fields: make(map[string][]byte),
+	}
+	for k, v := range yarpc.CallFromContext(ctx).OriginalHeaders() {
+		header.Fields[k] = []byte(v)
+	}
+	message.PartitionKey = common.StringPtr(workflowID)
+	message.Header = header
+	if err := producer.Publish(ctx, message); err != nil {
+		// the request is never consumed, failing to record it leaves the pending status until it expires
+		if recordErr := wh.recordAsyncRequestStatus(ctx, domainName, requestID, &requeststatus.RequestStatus{
+			Status:      requeststatus.StatusFailed,
+			RequestType: requestType,
+			WorkflowID:  workflowID,
+			Reason:      err.Error(),
+		}); recordErr != nil {
+			wh.GetLogger().Warn("Failed to record async request status", tag.WorkflowDomainName(domainName), tag.WorkflowID(workflowID), tag.Error(recordErr))
+		}
+		return err
+	}
 	return nil
 }
 
+// recordAsyncRequestStatus records the status of an async request, it is a no-op if statuses are not recorded or the request has no ID
+func (wh *WorkflowHandler) recordAsyncRequestStatus(ctx context.Context, domainName, requestID string, status *requeststatus.RequestStatus) error {
+	if wh.statusStore == nil || requestID == "" {
+		return nil
+	}
+	return wh.statusStore.Record(ctx, domainName, requestID, status)
+}
+
+// newAsyncRequestMessage returns the message of a request type defined by sqlblobs.AsyncRequestType
+func (wh *WorkflowHandler) newAsyncRequestMessage(requestType sqlblobs.AsyncRequestType, request codec.ThriftObject) (*sqlblobs.AsyncRequestMessage, error) {
+	// Serialize the message to be sent to the queue.
+	// Avoid JSON because json encoding of requests excludes PII fields such as input. JSON encoded request are logged by acccess controlled api layer for audit purposes.
+	payload, err := wh.thriftrwEncoder.Encode(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v: %v", requestType, err)
+	}
+	return &sqlblobs.AsyncRequestMessage{
+		Type:     requestType.Ptr(),
+		Encoding: common.StringPtr(string(constants.EncodingTypeThriftRW)),
+		Payload:  payload,
+	}, nil
+}
+
+// newAsyncRequestEnvelopeMessage returns the message of a request type not defined by sqlblobs.AsyncRequestType,
+// the type and ID of the request are carried in the payload.
+func (wh *WorkflowHandler) newAsyncRequestEnvelopeMessage(requestType asyncrequest.Type, requestID string, request codec.ThriftObject) (*sqlblobs.AsyncRequestMessage, error) {
+	encodedRequest, err := wh.thriftrwEncoder.Encode(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v: %v", requestType, err)
+	}
+	payload, err := asyncrequest.Encode(&asyncrequest.Envelope{
+		Type:      requestType,
+		RequestID: requestID,
+		Request:   encodedRequest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v: %v", requestType, err)
+	}
+	return &sqlblobs.AsyncRequestMessage{
+		Encoding: common.StringPtr(asyncrequest.EnvelopeEncoding),
+		Payload:  payload,
+	}, nil
+}
+
 func (wh *WorkflowHandler) SignalWithStartWorkflowExecutionAsync(
 	ctx context.Context,
 	signalWithStartRequest *types.SignalWithStartWorkflowExecutionAsyncRequest,
@@ -2202,35 +2415,21 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecutionAsync(
 	if err != nil {
 		return nil, err
 	}
-	producer, err := wh.producerManager.GetProducerByDomain(signalWithStartRequest.GetDomain())
+	requestType := sqlblobs.AsyncRequestTypeSignalWithStartWorkflowExecutionAsyncRequest
+	message, err := wh.newAsyncRequestMessage(requestType, thrift.FromSignalWithStartWorkflowExecutionAsyncRequest(signalWithStartRequest))
 	if err != nil {
 		return nil, err
 	}
-
-	// Serialize the message to be sent to the queue.
-	// Avoid JSON because json encoding of requests excludes PII fields such as input. JSON encoded request are logged by acccess controlled api layer for audit purposes.
-	payload, err := wh.thriftrwEncoder.Encode(thrift.FromSignalWithStartWorkflowExecutionAsyncRequest(signalWithStartRequest))
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode SignalWithStartWorkflowExecutionAsyncRequest: %v", err)
-	}
-	scope.RecordTimer(metrics.AsyncRequestPayloadSize, time.Duration(len(payload)))
-
-	// propagate the headers from the context to the message
-	header := &shared.Header{
-		Fields: map[string][]byte{},
-	}
-	for k, v := range yarpc.CallFromContext(ctx).OriginalHeaders() {
-		header.Fields[k] = []byte(v)
-	}
-	messageType := sqlblobs.AsyncRequestTypeSignalWithStartWorkflowExecutionAsyncRequest
-	message := &sqlblobs.AsyncRequestMessage{
-		PartitionKey: common.StringPtr(signalWithStartRequest.GetWorkflowID()),
-		Type:         &messageType,
-		Header:       header,
-		Encoding:     common.StringPtr(string(constants.EncodingTypeThriftRW)),
-		Payload:      payload,
-	}
-	err = producer.Publish(ctx, message)
+	// the status of the request is tracked under the request ID of the signal with start request
+	err = wh.publishAsyncRequest(
+		ctx,
+		scope,
+		signalWithStartRequest.GetDomain(),
+		requestType.String(),
+		signalWithStartRequest.SignalWithStartWorkflowExecutionRequest.GetRequestID(),
+		signalWithStartRequest.GetWorkflowID(),
+		message,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -2381,6 +2580,10 @@ func (wh *WorkflowHandler) validateSignalWithStartWorkflowExecutionRequest(ctx c
 		return err
 	}
 
+	if err := largepayload.ValidatePayloads(signalWithStartRequest.Input, signalWithStartRequest.SignalInput); err != nil {
+		return err
+	}
+
 	domainID, err := wh.GetDomainCache().GetDomainID(domainName)
 	if err != nil {
 		return err
@@ -2464,6 +2667,57 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(
 	return nil
 }
 
+// TerminateWorkflowExecutionAsync enqueues a termination to the async queue of the domain.
+// The outcome can be polled using the request ID of the response.
+func (wh *WorkflowHandler) TerminateWorkflowExecutionAsync(
+	ctx context.Context,
+	terminateRequest *types.TerminateWorkflowExecutionAsyncRequest,
+) (resp *types.TerminateWorkflowExecutionAsyncResponse, retError error) {
+	if wh.isShuttingDown() {
+		return nil, validate.ErrShuttingDown
+	}
+
+	if terminateRequest == nil || terminateRequest.TerminateWorkflowExecutionRequest == nil {
+		return nil, validate.ErrRequestNotSet
+	}
+
+	domainName := terminateRequest.GetDomain()
+	if domainName == "" {
+		return nil, validate.ErrDomainNotSet
+	}
+	if err := validate.CheckExecution(terminateRequest.GetWorkflowExecution()); err != nil {
+		return nil, err
+	}
+	if _, err := wh.GetDomainCache().GetDomainID(domainName); err != nil {
+		return nil, err
+	}
+
+	requestID := terminateRequest.GetRequestID()
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	scope := getMetricsScopeWithDomain(metrics.FrontendTerminateWorkflowExecutionAsyncScope, terminateRequest.TerminateWorkflowExecutionRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
+	requestType := asyncrequest.TypeTerminateWorkflowExecution
+	message, err := wh.newAsyncRequestEnvelopeMessage(requestType, requestID, thrift.FromTerminateWorkflowExecutionRequest(terminateRequest.TerminateWorkflowExecutionRequest))
+	if err != nil {
+		return nil, err
+	}
+	err = wh.publishAsyncRequest(
+		ctx,
+		scope,
+		domainName,
+		string(requestType),
+		requestID,
+		terminateRequest.GetWorkflowExecution().GetWorkflowID(),
+		message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &types.TerminateWorkflowExecutionAsyncResponse{RequestID: requestID}, nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution to the nextFirstEventID
 // in the history and immediately terminating the current execution instance.
 func (wh *WorkflowHandler) ResetWorkflowExecution(
@@ -2541,6 +2795,57 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 	return nil
 }
 
+// RequestCancelWorkflowExecutionAsync enqueues a cancellation request to the async queue of the domain.
+// The outcome can be polled using the request ID of the response.
+func (wh *WorkflowHandler) RequestCancelWorkflowExecutionAsync(
+	ctx context.Context,
+	cancelRequest *types.RequestCancelWorkflowExecutionAsyncRequest,
+) (resp *types.RequestCancelWorkflowExecutionAsyncResponse, retError error) {
+	if wh.isShuttingDown() {
+		return nil, validate.ErrShuttingDown
+	}
+
+	if cancelRequest == nil || cancelRequest.RequestCancelWorkflowExecutionRequest == nil {
+		return nil, validate.ErrRequestNotSet
+	}
+
+	domainName := cancelRequest.GetDomain()
+	if domainName == "" {
+		return nil, validate.ErrDomainNotSet
+	}
+	if err := validate.CheckExecution(cancelRequest.GetWorkflowExecution()); err != nil {
+		return nil, err
+	}
+	if _, err := wh.GetDomainCache().GetDomainID(domainName); err != nil {
+		return nil, err
+	}
+
+	// the request ID also deduplicates the cancellation when the request is consumed more than once
+	if cancelRequest.RequestID == "" {
+		cancelRequest.RequestID = uuid.New().String()
+	}
+
+	scope := getMetricsScopeWithDomain(metrics.FrontendRequestCancelWorkflowExecutionAsyncScope, cancelRequest.RequestCancelWorkflowExecutionRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
+	requestType := asyncrequest.TypeRequestCancelWorkflowExecution
+	message, err := wh.newAsyncRequestEnvelopeMessage(requestType, cancelRequest.RequestID, thrift.FromRequestCancelWorkflowExecutionRequest(cancelRequest.RequestCancelWorkflowExecutionRequest))
+	if err != nil {
+		return nil, err
+	}
+	err = wh.publishAsyncRequest(
+		ctx,
+		scope,
+		domainName,
+		string(requestType),
+		cancelRequest.RequestID,
+		cancelRequest.GetWorkflowExecution().GetWorkflowID(),
+		message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &types.RequestCancelWorkflowExecutionAsyncResponse{RequestID: cancelRequest.RequestID}, nil
+}
+
 // RestartWorkflowExecution - retrieves info for an existing workflow then restarts it
 func (wh *WorkflowHandler) RestartWorkflowExecution(ctx context.Context, request *types.RestartWorkflowExecutionRequest) (resp *types.RestartWorkflowExecutionResponse, retError error) {
 	if wh.isShuttingDown() {
@@ -2756,6 +3061,10 @@ func (wh *WorkflowHandler) getRawHistory(
 
 	var encoding *types.EncodingType
 	for _, data := range resp.HistoryEventBlobs {
+		data, err = wh.resolveRawHistoryBatch(ctx, domainID, domainName, execution, data)
+		if err != nil {
+			return nil, nil, err
+		}
 		switch data.Encoding {
 		case constants.EncodingTypeJSON:
 			encoding = types.EncodingTypeJSON.Ptr()
@@ -2793,6 +3102,28 @@ func (wh *WorkflowHandler) getRawHistory(
 	return rawHistory, resp.NextPageToken, nil
 }
 
+// resolveRawHistoryBatch returns the batch with references to offloaded payloads resolved,
+// batches without references are returned as they are
+func (wh *WorkflowHandler) resolveRawHistoryBatch(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	execution types.WorkflowExecution,
+	batch *persistence.DataBlob,
+) (*persistence.DataBlob, error) {
+	if !largepayload.ContainsReference(batch.Data) {
+		return batch, nil
+	}
+	historyEvents, err := wh.GetPayloadSerializer().DeserializeBatchEvents(batch)
+	if err != nil {
+		return nil, err
+	}
+	if err := wh.largePayloadResolver.ResolveEvents(ctx, domainID, domainName, execution, historyEvents); err != nil {
+		return nil, err
+	}
+	return wh.GetPayloadSerializer().SerializeBatchEvents(historyEvents, batch.Encoding)
+}
+
 func (wh *WorkflowHandler) getHistory(
 	ctx context.Context,
 	scope metrics.Scope,
@@ -2827,6 +3158,10 @@ func (wh *WorkflowHandler) getHistory(
 
 	scope.RecordTimer(metrics.HistorySize, time.Duration(size))
 
+	if err := wh.largePayloadResolver.ResolveEvents(ctx, domainID, domainName, execution, historyEvents); err != nil {
+		return nil, nil, err
+	}
+
 	isLastPage := len(nextPageToken) == 0
 	if err := verifyHistoryIsComplete(
 		historyEvents,
@@ -38,11 +38,15 @@ import (
 	"go.uber.org/yarpc/yarpctest"
 
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver"
 	"github.com/uber/cadence/common/archiver/provider"
+	"github.com/uber/cadence/common/asyncworkflow/asyncrequest"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/client"
 	"github.com/uber/cadence/common/cluster"
@@ -51,6 +55,7 @@ import (
 	dc "github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/isolationgroup"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
@@ -444,6 +449,106 @@ func (s *workflowHandlerSuite) TestPollForActivityTask() {
 	}
 }
 
+func (s *workflowHandlerSuite) TestPollForActivityTask_ResolvesLargePayload() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.LargePayloadOffloadThreshold = dynamicproperties.GetIntPropertyFilteredByDomain(1)
+	wh := s.getWorkflowHandler(config)
+
+	input := []byte(`{"key": "large value"}`)
+	events := []*types.HistoryEvent{
+		{
+			ID:        5,
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				Input: input,
+			},
+		},
+	}
+	execution := types.WorkflowExecution{WorkflowID: "wid", RunID: "0d00698f-08e1-4d36-a3e2-3bf109f5d2b6"}
+	s.mockResource.BlobstoreClient.On("Put", mock.Anything, mock.Anything).Return(&blobstore.PutResponse{}, nil).Once()
+	offloader := largepayload.NewOffloader(s.mockResource.BlobstoreClient, config.LargePayloadOffloadThreshold)
+	s.NoError(offloader.OffloadEvents(context.Background(), s.testDomainID, s.testDomain, execution, events))
+	reference := events[0].ActivityTaskScheduledEventAttributes.Input
+	s.True(largepayload.IsReference(reference))
+
+	s.mockResource.BlobstoreClient.On("Get", mock.Anything, mock.Anything).
+		Return(&blobstore.GetResponse{Blob: blobstore.Blob{Body: input}}, nil).Once()
+	s.mockDomainCache.EXPECT().GetDomainID(s.testDomain).Return(s.testDomainID, nil)
+	s.mockMatchingClient.EXPECT().PollForActivityTask(gomock.Any(), gomock.Any()).Return(&types.MatchingPollForActivityTaskResponse{
+		TaskToken:         []byte("token"),
+		WorkflowExecution: &execution,
+		ActivityID:        "1",
+		Input:             reference,
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	resp, err := wh.PollForActivityTask(ctx, &types.PollForActivityTaskRequest{
+		Domain: s.testDomain,
+		TaskList: &types.TaskList{
+			Name: "task-list",
+		},
+	})
+	s.NoError(err)
+	s.Equal(input, resp.Input)
+}
+
+func (s *workflowHandlerSuite) TestPollForActivityTask_DoesNotResolveReferencesOfOtherRuns() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.LargePayloadOffloadThreshold = dynamicproperties.GetIntPropertyFilteredByDomain(1)
+	wh := s.getWorkflowHandler(config)
+
+	events := []*types.HistoryEvent{
+		{
+			ID:        5,
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				Input: []byte("large value"),
+			},
+		},
+	}
+	otherExecution := types.WorkflowExecution{WorkflowID: "wid", RunID: "c4b9ea1a-1d6e-4c6a-9f2c-91f0a53e9b4d"}
+	s.mockResource.BlobstoreClient.On("Put", mock.Anything, mock.Anything).Return(&blobstore.PutResponse{}, nil).Once()
+	offloader := largepayload.NewOffloader(s.mockResource.BlobstoreClient, config.LargePayloadOffloadThreshold)
+	s.NoError(offloader.OffloadEvents(context.Background(), s.testDomainID, s.testDomain, otherExecution, events))
+	reference := events[0].ActivityTaskScheduledEventAttributes.Input
+
+	s.mockDomainCache.EXPECT().GetDomainID(s.testDomain).Return(s.testDomainID, nil)
+	s.mockMatchingClient.EXPECT().PollForActivityTask(gomock.Any(), gomock.Any()).Return(&types.MatchingPollForActivityTaskResponse{
+		TaskToken:         []byte("token"),
+		WorkflowExecution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "0d00698f-08e1-4d36-a3e2-3bf109f5d2b6"},
+		ActivityID:        "1",
+		Input:             reference,
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	resp, err := wh.PollForActivityTask(ctx, &types.PollForActivityTaskRequest{
+		Domain: s.testDomain,
+		TaskList: &types.TaskList{
+			Name: "task-list",
+		},
+	})
+	s.NoError(err)
+	s.Equal(reference, resp.Input)
+	s.mockResource.BlobstoreClient.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (s *workflowHandlerSuite) TestSignalWorkflowExecution_RejectsReservedPrefix() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	wh := s.getWorkflowHandler(config)
+
+	err := wh.SignalWorkflowExecution(context.Background(), &types.SignalWorkflowExecutionRequest{
+		Domain: s.testDomain,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: testWorkflowID,
+		},
+		SignalName: "signal",
+		Input:      []byte("\x00cadence-large-payload:largepayload_key"),
+	})
+	s.Equal(largepayload.ErrReservedPrefix, err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_RequestIdNotSet() {
 	config := s.newConfig(dc.NewInMemoryClient())
 	config.UserRPS = dynamicproperties.GetIntPropertyFn(10)
@@ -3408,15 +3513,16 @@ var describeDomainResponseServer = &types.DescribeDomainResponse{
 func TestStartWorkflowExecutionAsync(t *testing.T) {
 	testCases := []struct {
 		name       string
-		setupMocks func(*MockProducerManager)
+		setupMocks func(*MockProducerManager, *requeststatus.MockStore)
 		request    *types.StartWorkflowExecutionAsyncRequest
 		wantErr    bool
 	}{
 		{
 			name: "Success case",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockProducer := &mocks.KafkaProducer{}
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "StartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
 				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil)
 			},
 			request: &types.StartWorkflowExecutionAsyncRequest{
@@ -3440,7 +3546,7 @@ func TestStartWorkflowExecutionAsync(t *testing.T) {
 		},
 		{
 			name: "Error case - failed to get async queue producer",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(nil, errors.New("test-error"))
 			},
 			request: &types.StartWorkflowExecutionAsyncRequest{
@@ -3462,12 +3568,40 @@ func TestStartWorkflowExecutionAsync(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Error case - failed to record pending status",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), gomock.Any()).Return(errors.New("test-error"))
+			},
+			request: &types.StartWorkflowExecutionAsyncRequest{
+				StartWorkflowExecutionRequest: &types.StartWorkflowExecutionRequest{
+					Domain:     "test-domain",
+					WorkflowID: "test-workflow-id",
+					WorkflowType: &types.WorkflowType{
+						Name: "test-workflow-type",
+					},
+					TaskList: &types.TaskList{
+						Name: "test-task-list",
+					},
+					Input:                               []byte("test-input"),
+					ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(60),
+					TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+					Identity:                            "test-identity",
+					RequestID:                           uuid.New(),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Error case - failed to publish message",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockProducer := &mocks.KafkaProducer{}
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "StartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
 				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(errors.New("test-error"))
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusFailed, RequestType: "StartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id", Reason: "test-error"}).Return(nil)
 			},
 			request: &types.StartWorkflowExecutionAsyncRequest{
 				StartWorkflowExecutionRequest: &types.StartWorkflowExecutionRequest{
@@ -3510,8 +3644,10 @@ func TestStartWorkflowExecutionAsync(t *testing.T) {
 			)
 			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
 			wh.producerManager = mockProducerManager
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+			wh.statusStore = mockStatusStore
 
-			tc.setupMocks(mockProducerManager)
+			tc.setupMocks(mockProducerManager, mockStatusStore)
 
 			_, err := wh.StartWorkflowExecutionAsync(context.Background(), tc.request)
 			if tc.wantErr {
@@ -3526,15 +3662,16 @@ func TestStartWorkflowExecutionAsync(t *testing.T) {
 func TestSignalWithStartWorkflowExecutionAsync(t *testing.T) {
 	testCases := []struct {
 		name       string
-		setupMocks func(*MockProducerManager)
+		setupMocks func(*MockProducerManager, *requeststatus.MockStore)
 		request    *types.SignalWithStartWorkflowExecutionAsyncRequest
 		wantErr    bool
 	}{
 		{
 			name: "Success case",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockProducer := &mocks.KafkaProducer{}
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "SignalWithStartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
 				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil)
 			},
 			request: &types.SignalWithStartWorkflowExecutionAsyncRequest{
@@ -3559,7 +3696,7 @@ func TestSignalWithStartWorkflowExecutionAsync(t *testing.T) {
 		},
 		{
 			name: "Error case - failed to get async queue producer",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(nil, errors.New("test-error"))
 			},
 			request: &types.SignalWithStartWorkflowExecutionAsyncRequest{
@@ -3584,10 +3721,12 @@ func TestSignalWithStartWorkflowExecutionAsync(t *testing.T) {
 		},
 		{
 			name: "Error case - failed to publish message",
-			setupMocks: func(mockQueue *MockProducerManager) {
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore) {
 				mockProducer := &mocks.KafkaProducer{}
 				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "SignalWithStartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
 				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(errors.New("test-error"))
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusFailed, RequestType: "SignalWithStartWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id", Reason: "test-error"}).Return(nil)
 			},
 			request: &types.SignalWithStartWorkflowExecutionAsyncRequest{
 				SignalWithStartWorkflowExecutionRequest: &types.SignalWithStartWorkflowExecutionRequest{
@@ -3631,8 +3770,10 @@ func TestSignalWithStartWorkflowExecutionAsync(t *testing.T) {
 			)
 			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
 			wh.producerManager = mockProducerManager
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+			wh.statusStore = mockStatusStore
 
-			tc.setupMocks(mockProducerManager)
+			tc.setupMocks(mockProducerManager, mockStatusStore)
 
 			_, err := wh.SignalWithStartWorkflowExecutionAsync(context.Background(), tc.request)
 			if tc.wantErr {
@@ -3644,6 +3785,403 @@ func TestSignalWithStartWorkflowExecutionAsync(t *testing.T) {
 	}
 }
 
+func TestSignalWorkflowExecutionAsync(t *testing.T) {
+	validRequest := func() *types.SignalWorkflowExecutionAsyncRequest {
+		return &types.SignalWorkflowExecutionAsyncRequest{
+			SignalWorkflowExecutionRequest: &types.SignalWorkflowExecutionRequest{
+				Domain: "test-domain",
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow-id",
+				},
+				SignalName: "test-signal-name",
+				Input:      []byte("test-input"),
+				Identity:   "test-identity",
+			},
+		}
+	}
+	testCases := []struct {
+		name       string
+		setupMocks func(*MockProducerManager, *requeststatus.MockStore, *resource.Test)
+		request    *types.SignalWorkflowExecutionAsyncRequest
+		wantErr    bool
+	}{
+		{
+			name: "Success case",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain("test-domain").Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "SignalWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
+				mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(msg *sqlblobs.AsyncRequestMessage) bool {
+					envelope, err := asyncrequest.Decode(msg.GetPayload())
+					return err == nil &&
+						msg.GetEncoding() == asyncrequest.EnvelopeEncoding &&
+						envelope.Type == asyncrequest.TypeSignalWorkflowExecution &&
+						msg.GetPartitionKey() == "test-workflow-id" &&
+						envelope.RequestID != ""
+				})).Return(nil)
+			},
+			request: validRequest(),
+			wantErr: false,
+		},
+		{
+			name:       "Error case - request not set",
+			setupMocks: func(*MockProducerManager, *requeststatus.MockStore, *resource.Test) {},
+			request:    &types.SignalWorkflowExecutionAsyncRequest{},
+			wantErr:    true,
+		},
+		{
+			name: "Error case - failed to get async queue producer",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(nil, errors.New("test-error"))
+			},
+			request: validRequest(),
+			wantErr: true,
+		},
+		{
+			name: "Error case - failed to publish message",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "SignalWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
+				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(errors.New("test-error"))
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusFailed, RequestType: "SignalWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id", Reason: "test-error"}).Return(nil)
+			},
+			request: validRequest(),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockResource := resource.NewTest(t, mockCtrl, metrics.Frontend)
+			mockVersionChecker := client.NewMockVersionChecker(mockCtrl)
+			mockProducerManager := NewMockProducerManager(mockCtrl)
+
+			cfg := frontendcfg.NewConfig(
+				dc.NewCollection(
+					dc.NewInMemoryClient(),
+					mockResource.GetLogger(),
+				),
+				numHistoryShards,
+				false,
+				"hostname",
+				mockResource.GetLogger(),
+			)
+			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
+			wh.producerManager = mockProducerManager
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+			wh.statusStore = mockStatusStore
+
+			tc.setupMocks(mockProducerManager, mockStatusStore, mockResource)
+
+			resp, err := wh.SignalWorkflowExecutionAsync(context.Background(), tc.request)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, resp.GetRequestID())
+			}
+		})
+	}
+}
+
+func TestRequestCancelWorkflowExecutionAsync(t *testing.T) {
+	validRequest := func() *types.RequestCancelWorkflowExecutionAsyncRequest {
+		return &types.RequestCancelWorkflowExecutionAsyncRequest{
+			RequestCancelWorkflowExecutionRequest: &types.RequestCancelWorkflowExecutionRequest{
+				Domain: "test-domain",
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow-id",
+				},
+				RequestID: "test-request-id",
+			},
+		}
+	}
+	testCases := []struct {
+		name       string
+		setupMocks func(*MockProducerManager, *requeststatus.MockStore, *resource.Test)
+		request    *types.RequestCancelWorkflowExecutionAsyncRequest
+		wantErr    bool
+	}{
+		{
+			name: "Success case",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain("test-domain").Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "RequestCancelWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
+				mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(msg *sqlblobs.AsyncRequestMessage) bool {
+					envelope, err := asyncrequest.Decode(msg.GetPayload())
+					return err == nil &&
+						msg.GetEncoding() == asyncrequest.EnvelopeEncoding &&
+						envelope.Type == asyncrequest.TypeRequestCancelWorkflowExecution &&
+						msg.GetPartitionKey() == "test-workflow-id" &&
+						envelope.RequestID == "test-request-id"
+				})).Return(nil)
+			},
+			request: validRequest(),
+			wantErr: false,
+		},
+		{
+			name:       "Error case - execution not set",
+			setupMocks: func(*MockProducerManager, *requeststatus.MockStore, *resource.Test) {},
+			request: &types.RequestCancelWorkflowExecutionAsyncRequest{
+				RequestCancelWorkflowExecutionRequest: &types.RequestCancelWorkflowExecutionRequest{
+					Domain: "test-domain",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - get domain ID error",
+			setupMocks: func(_ *MockProducerManager, _ *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("", errors.New("get-domain-id-error"))
+			},
+			request: validRequest(),
+			wantErr: true,
+		},
+		{
+			name: "Error case - failed to publish message",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "RequestCancelWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
+				mockProducer.On("Publish", mock.Anything, mock.Anything).Return(errors.New("test-error"))
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", "test-request-id", &requeststatus.RequestStatus{Status: requeststatus.StatusFailed, RequestType: "RequestCancelWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id", Reason: "test-error"}).Return(nil)
+			},
+			request: validRequest(),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockResource := resource.NewTest(t, mockCtrl, metrics.Frontend)
+			mockVersionChecker := client.NewMockVersionChecker(mockCtrl)
+			mockProducerManager := NewMockProducerManager(mockCtrl)
+
+			cfg := frontendcfg.NewConfig(
+				dc.NewCollection(
+					dc.NewInMemoryClient(),
+					mockResource.GetLogger(),
+				),
+				numHistoryShards,
+				false,
+				"hostname",
+				mockResource.GetLogger(),
+			)
+			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
+			wh.producerManager = mockProducerManager
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+			wh.statusStore = mockStatusStore
+
+			tc.setupMocks(mockProducerManager, mockStatusStore, mockResource)
+
+			resp, err := wh.RequestCancelWorkflowExecutionAsync(context.Background(), tc.request)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "test-request-id", resp.GetRequestID())
+			}
+		})
+	}
+}
+
+func TestTerminateWorkflowExecutionAsync(t *testing.T) {
+	validRequest := func() *types.TerminateWorkflowExecutionAsyncRequest {
+		return &types.TerminateWorkflowExecutionAsyncRequest{
+			TerminateWorkflowExecutionRequest: &types.TerminateWorkflowExecutionRequest{
+				Domain: "test-domain",
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow-id",
+				},
+				Reason: "test-reason",
+			},
+		}
+	}
+	testCases := []struct {
+		name       string
+		setupMocks func(*MockProducerManager, *requeststatus.MockStore, *resource.Test)
+		request    *types.TerminateWorkflowExecutionAsyncRequest
+		wantErr    bool
+	}{
+		{
+			name: "Success case",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockProducer := &mocks.KafkaProducer{}
+				mockQueue.EXPECT().GetProducerByDomain("test-domain").Return(mockProducer, nil)
+				mockStatusStore.EXPECT().Record(gomock.Any(), "test-domain", gomock.Any(), &requeststatus.RequestStatus{Status: requeststatus.StatusPending, RequestType: "TerminateWorkflowExecutionAsyncRequest", WorkflowID: "test-workflow-id"}).Return(nil)
+				mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(msg *sqlblobs.AsyncRequestMessage) bool {
+					envelope, err := asyncrequest.Decode(msg.GetPayload())
+					return err == nil &&
+						msg.GetEncoding() == asyncrequest.EnvelopeEncoding &&
+						envelope.Type == asyncrequest.TypeTerminateWorkflowExecution &&
+						msg.GetPartitionKey() == "test-workflow-id" &&
+						envelope.RequestID != ""
+				})).Return(nil)
+			},
+			request: validRequest(),
+			wantErr: false,
+		},
+		{
+			name:       "Error case - domain not set",
+			setupMocks: func(*MockProducerManager, *requeststatus.MockStore, *resource.Test) {},
+			request: &types.TerminateWorkflowExecutionAsyncRequest{
+				TerminateWorkflowExecutionRequest: &types.TerminateWorkflowExecutionRequest{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - failed to get async queue producer",
+			setupMocks: func(mockQueue *MockProducerManager, mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID(gomock.Any()).Return("test-domain-id", nil)
+				mockQueue.EXPECT().GetProducerByDomain(gomock.Any()).Return(nil, errors.New("test-error"))
+			},
+			request: validRequest(),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockResource := resource.NewTest(t, mockCtrl, metrics.Frontend)
+			mockVersionChecker := client.NewMockVersionChecker(mockCtrl)
+			mockProducerManager := NewMockProducerManager(mockCtrl)
+
+			cfg := frontendcfg.NewConfig(
+				dc.NewCollection(
+					dc.NewInMemoryClient(),
+					mockResource.GetLogger(),
+				),
+				numHistoryShards,
+				false,
+				"hostname",
+				mockResource.GetLogger(),
+			)
+			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
+			wh.producerManager = mockProducerManager
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+			wh.statusStore = mockStatusStore
+
+			tc.setupMocks(mockProducerManager, mockStatusStore, mockResource)
+
+			resp, err := wh.TerminateWorkflowExecutionAsync(context.Background(), tc.request)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, resp.GetRequestID())
+			}
+		})
+	}
+}
+
+func TestDescribeAsyncRequest(t *testing.T) {
+	updatedTime := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name       string
+		setupMocks func(*requeststatus.MockStore, *resource.Test)
+		noStore    bool
+		request    *types.DescribeAsyncRequestRequest
+		want       *types.DescribeAsyncRequestResponse
+		err        error
+	}{
+		{
+			name: "Success case",
+			setupMocks: func(mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID("test-domain").Return("test-domain-id", nil)
+				mockStatusStore.EXPECT().Get(gomock.Any(), "test-domain", "test-request-id").Return(&requeststatus.RequestStatus{
+					Status:         requeststatus.StatusStarted,
+					RequestType:    "StartWorkflowExecutionAsyncRequest",
+					WorkflowID:     "test-workflow-id",
+					RunID:          "test-run-id",
+					UpdatedTime:    updatedTime,
+					ExpirationTime: updatedTime.Add(time.Hour),
+				}, nil)
+			},
+			request: &types.DescribeAsyncRequestRequest{Domain: "test-domain", RequestID: "test-request-id"},
+			want: &types.DescribeAsyncRequestResponse{
+				Status:      "started",
+				RequestType: "StartWorkflowExecutionAsyncRequest",
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: "test-workflow-id",
+					RunID:      "test-run-id",
+				},
+				LastUpdatedTimestamp: common.Int64Ptr(updatedTime.UnixNano()),
+				ExpirationTimestamp:  common.Int64Ptr(updatedTime.Add(time.Hour).UnixNano()),
+			},
+		},
+		{
+			name:       "Error case - request ID not set",
+			setupMocks: func(*requeststatus.MockStore, *resource.Test) {},
+			request:    &types.DescribeAsyncRequestRequest{Domain: "test-domain"},
+			err:        validate.ErrRequestIDNotSet,
+		},
+		{
+			name: "Error case - status is not recorded",
+			setupMocks: func(_ *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID("test-domain").Return("test-domain-id", nil)
+			},
+			noStore: true,
+			request: &types.DescribeAsyncRequestRequest{Domain: "test-domain", RequestID: "test-request-id"},
+			err:     &types.BadRequestError{Message: "The status of async requests is not recorded because no blobstore is configured."},
+		},
+		{
+			name: "Error case - unknown request",
+			setupMocks: func(mockStatusStore *requeststatus.MockStore, mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainID("test-domain").Return("test-domain-id", nil)
+				mockStatusStore.EXPECT().Get(gomock.Any(), "test-domain", "test-request-id").Return(nil, &types.EntityNotExistsError{Message: "not recorded"})
+			},
+			request: &types.DescribeAsyncRequestRequest{Domain: "test-domain", RequestID: "test-request-id"},
+			err:     &types.EntityNotExistsError{Message: "not recorded"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockResource := resource.NewTest(t, mockCtrl, metrics.Frontend)
+			mockVersionChecker := client.NewMockVersionChecker(mockCtrl)
+			mockStatusStore := requeststatus.NewMockStore(mockCtrl)
+
+			cfg := frontendcfg.NewConfig(
+				dc.NewCollection(
+					dc.NewInMemoryClient(),
+					mockResource.GetLogger(),
+				),
+				numHistoryShards,
+				false,
+				"hostname",
+				mockResource.GetLogger(),
+			)
+			wh := NewWorkflowHandler(mockResource, cfg, mockVersionChecker, nil)
+			wh.statusStore = mockStatusStore
+			if tc.noStore {
+				wh.statusStore = nil
+			}
+
+			tc.setupMocks(mockStatusStore, mockResource)
+
+			resp, err := wh.DescribeAsyncRequest(context.Background(), tc.request)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, resp)
+			}
+		})
+	}
+}
+
 func TestRequestCancelWorkflowExecution(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -85,6 +85,21 @@ func (mr *MockHandlerMockRecorder) DeprecateDomain(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeprecateDomain", reflect.TypeOf((*MockHandler)(nil).DeprecateDomain), arg0, arg1)
 }
 
+// DescribeAsyncRequest mocks base method.
+func (m *MockHandler) DescribeAsyncRequest(arg0 context.Context, arg1 *types.DescribeAsyncRequestRequest) (*types.DescribeAsyncRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAsyncRequest", arg0, arg1)
+	ret0, _ := ret[0].(*types.DescribeAsyncRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAsyncRequest indicates an expected call of DescribeAsyncRequest.
+func (mr *MockHandlerMockRecorder) DescribeAsyncRequest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAsyncRequest", reflect.TypeOf((*MockHandler)(nil).DescribeAsyncRequest), arg0, arg1)
+}
+
 // DescribeDomain mocks base method.
 func (m *MockHandler) DescribeDomain(arg0 context.Context, arg1 *types.DescribeDomainRequest) (*types.DescribeDomainResponse, error) {
 	m.ctrl.T.Helper()
@@ -427,6 +442,21 @@ func (mr *MockHandlerMockRecorder) RequestCancelWorkflowExecution(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCancelWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).RequestCancelWorkflowExecution), arg0, arg1)
 }
 
+// RequestCancelWorkflowExecutionAsync mocks base method.
+func (m *MockHandler) RequestCancelWorkflowExecutionAsync(arg0 context.Context, arg1 *types.RequestCancelWorkflowExecutionAsyncRequest) (*types.RequestCancelWorkflowExecutionAsyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCancelWorkflowExecutionAsync", arg0, arg1)
+	ret0, _ := ret[0].(*types.RequestCancelWorkflowExecutionAsyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestCancelWorkflowExecutionAsync indicates an expected call of RequestCancelWorkflowExecutionAsync.
+func (mr *MockHandlerMockRecorder) RequestCancelWorkflowExecutionAsync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCancelWorkflowExecutionAsync", reflect.TypeOf((*MockHandler)(nil).RequestCancelWorkflowExecutionAsync), arg0, arg1)
+}
+
 // ResetStickyTaskList mocks base method.
 func (m *MockHandler) ResetStickyTaskList(arg0 context.Context, arg1 *types.ResetStickyTaskListRequest) (*types.ResetStickyTaskListResponse, error) {
 	m.ctrl.T.Helper()
@@ -658,6 +688,21 @@ func (mr *MockHandlerMockRecorder) SignalWorkflowExecution(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignalWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).SignalWorkflowExecution), arg0, arg1)
 }
 
+// SignalWorkflowExecutionAsync mocks base method.
+func (m *MockHandler) SignalWorkflowExecutionAsync(arg0 context.Context, arg1 *types.SignalWorkflowExecutionAsyncRequest) (*types.SignalWorkflowExecutionAsyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignalWorkflowExecutionAsync", arg0, arg1)
+	ret0, _ := ret[0].(*types.SignalWorkflowExecutionAsyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignalWorkflowExecutionAsync indicates an expected call of SignalWorkflowExecutionAsync.
+func (mr *MockHandlerMockRecorder) SignalWorkflowExecutionAsync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignalWorkflowExecutionAsync", reflect.TypeOf((*MockHandler)(nil).SignalWorkflowExecutionAsync), arg0, arg1)
+}
+
 // StartWorkflowExecution mocks base method.
 func (m *MockHandler) StartWorkflowExecution(arg0 context.Context, arg1 *types.StartWorkflowExecutionRequest) (*types.StartWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -702,6 +747,21 @@ func (mr *MockHandlerMockRecorder) TerminateWorkflowExecution(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).TerminateWorkflowExecution), arg0, arg1)
 }
 
+// TerminateWorkflowExecutionAsync mocks base method.
+func (m *MockHandler) TerminateWorkflowExecutionAsync(arg0 context.Context, arg1 *types.TerminateWorkflowExecutionAsyncRequest) (*types.TerminateWorkflowExecutionAsyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateWorkflowExecutionAsync", arg0, arg1)
+	ret0, _ := ret[0].(*types.TerminateWorkflowExecutionAsyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TerminateWorkflowExecutionAsync indicates an expected call of TerminateWorkflowExecutionAsync.
+func (mr *MockHandlerMockRecorder) TerminateWorkflowExecutionAsync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflowExecutionAsync", reflect.TypeOf((*MockHandler)(nil).TerminateWorkflowExecutionAsync), arg0, arg1)
+}
+
 // UpdateDomain mocks base method.
 func (m *MockHandler) UpdateDomain(arg0 context.Context, arg1 *types.UpdateDomainRequest) (*types.UpdateDomainResponse, error) {
 	m.ctrl.T.Helper()
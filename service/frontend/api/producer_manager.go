@@ -34,6 +34,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -49,6 +50,7 @@ type (
 		provider      queue.Provider
 		logger        log.Logger
 		metricsClient metrics.Client
+		queueManager  persistence.QueueManager
 
 		producerCache cache.Cache
 	}
@@ -59,12 +61,14 @@ func NewProducerManager(
 	provider queue.Provider,
 	logger log.Logger,
 	metricsClient metrics.Client,
+	queueManager persistence.QueueManager,
 ) ProducerManager {
 	return &producerManagerImpl{
 		domainCache:   domainCache,
 		provider:      provider,
 		logger:        logger,
 		metricsClient: metricsClient,
+		queueManager:  queueManager,
 		producerCache: cache.New(&cache.Options{
 			TTL:             time.Minute * 5,
 			InitialCapacity: 5,
@@ -107,7 +111,7 @@ func (q *producerManagerImpl) GetProducerByDomain(
 		return val.(messaging.Producer), nil
 	}
 
-	producer, err := queue.CreateProducer(&provider.Params{Logger: q.logger, MetricsClient: q.metricsClient})
+	producer, err := queue.CreateProducer(&provider.Params{Logger: q.logger, MetricsClient: q.metricsClient, QueueManager: q.queueManager})
 	if err != nil {
 		return nil, err
 	}
@@ -237,6 +237,7 @@ func TestGetProducerByDomain(t *testing.T) {
 				mockProvider,
 				log.NewNoop(),
 				metrics.NewNoopMetricsClient(),
+				nil,
 			)
 			producerManager.(*producerManagerImpl).producerCache = mockProducerCache
 
@@ -65,8 +65,9 @@ type Config struct {
 	Lockdown                          dynamicproperties.BoolPropertyFnWithDomainFilter
 
 	// global ratelimiter config, uses GlobalDomain*RPS for RPS configuration
-	GlobalRatelimiterKeyMode        dynamicproperties.StringPropertyWithRatelimitKeyFilter
-	GlobalRatelimiterUpdateInterval dynamicproperties.DurationPropertyFn
+	GlobalRatelimiterKeyMode         dynamicproperties.StringPropertyWithRatelimitKeyFilter
+	PersistenceGlobalRatelimiterMode dynamicproperties.StringPropertyWithRatelimitKeyFilter
+	GlobalRatelimiterUpdateInterval  dynamicproperties.DurationPropertyFn
 
 	// isolation configuration
 	EnableTasklistIsolation dynamicproperties.BoolPropertyFnWithDomainFilter
@@ -90,6 +91,12 @@ type Config struct {
 	BlobSizeLimitError dynamicproperties.IntPropertyFnWithDomainFilter
 	BlobSizeLimitWarn  dynamicproperties.IntPropertyFnWithDomainFilter
 
+	// LargePayloadOffloadThreshold enables resolving references to offloaded payloads when positive
+	LargePayloadOffloadThreshold dynamicproperties.IntPropertyFnWithDomainFilter
+
+	// AsyncRequestStatusTTL is how long the status of an async request is kept
+	AsyncRequestStatusTTL dynamicproperties.DurationPropertyFnWithDomainFilter
+
 	ThrottledLogRPS dynamicproperties.IntPropertyFn
 
 	// Domain specific config
@@ -151,6 +158,7 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		GlobalDomainVisibilityRPS:                   dc.GetIntPropertyFilteredByDomain(dynamicproperties.FrontendGlobalDomainVisibilityRPS),
 		GlobalDomainAsyncRPS:                        dc.GetIntPropertyFilteredByDomain(dynamicproperties.FrontendGlobalDomainAsyncRPS),
 		GlobalRatelimiterKeyMode:                    dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.FrontendGlobalRatelimiterMode),
+		PersistenceGlobalRatelimiterMode:            dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.PersistenceGlobalRatelimiterMode),
 		GlobalRatelimiterUpdateInterval:             dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
 		MaxIDLengthWarnLimit:                        dc.GetIntProperty(dynamicproperties.MaxIDLengthWarnLimit),
 		DomainNameMaxLength:                         dc.GetIntPropertyFilteredByDomain(dynamicproperties.DomainNameMaxLength),
@@ -165,6 +173,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		DisableListVisibilityByFilter:               dc.GetBoolPropertyFilteredByDomain(dynamicproperties.DisableListVisibilityByFilter),
 		BlobSizeLimitError:                          dc.GetIntPropertyFilteredByDomain(dynamicproperties.BlobSizeLimitError),
 		BlobSizeLimitWarn:                           dc.GetIntPropertyFilteredByDomain(dynamicproperties.BlobSizeLimitWarn),
+		LargePayloadOffloadThreshold:                dc.GetIntPropertyFilteredByDomain(dynamicproperties.LargePayloadOffloadThreshold),
+		AsyncRequestStatusTTL:                       dc.GetDurationPropertyFilteredByDomain(dynamicproperties.AsyncWorkflowRequestStatusTTL),
 		ThrottledLogRPS:                             dc.GetIntProperty(dynamicproperties.FrontendThrottledLogRPS),
 		ShutdownDrainDuration:                       dc.GetDurationProperty(dynamicproperties.FrontendShutdownDrainDuration),
 		WarmupDuration:                              dc.GetDurationProperty(dynamicproperties.FrontendWarmupDuration),
@@ -106,7 +106,10 @@ func TestNewConfig(t *testing.T) {
 		"EnableTasklistIsolation":                     {dynamicproperties.EnableTasklistIsolation, true},
 		"GlobalRatelimiterKeyMode":                    {dynamicproperties.FrontendGlobalRatelimiterMode, "disabled"},
 		"GlobalRatelimiterUpdateInterval":             {dynamicproperties.GlobalRatelimiterUpdateInterval, 3 * time.Second},
+		"PersistenceGlobalRatelimiterMode":            {dynamicproperties.PersistenceGlobalRatelimiterMode, "local"},
 		"PinotOptimizedQueryColumns":                  {dynamicproperties.PinotOptimizedQueryColumns, map[string]interface{}{"foo": "bar"}},
+		"AsyncRequestStatusTTL":                       {dynamicproperties.AsyncWorkflowRequestStatusTTL, time.Duration(45)},
+		"LargePayloadOffloadThreshold":                {dynamicproperties.LargePayloadOffloadThreshold, 46},
 	}
 	domainFields := map[string]configTestCase{
 		"MaxBadBinaryCount":      {dynamicproperties.FrontendMaxBadBinaries, 40},
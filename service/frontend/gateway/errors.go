@@ -0,0 +1,113 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/yarpc/encoding/protobuf"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common/log/tag"
+)
+
+// statusClientClosedRequest is not defined by net/http, it is the de facto status for cancelled requests
+const statusClientClosedRequest = 499
+
+// errorResponse is the JSON body of failed gateway requests
+type errorResponse struct {
+	// Code is the yarpc error code, e.g. "not-found"
+	Code string `json:"code"`
+	// Message is the error message
+	Message string `json:"message,omitempty"`
+	// Type is the name of the API error type, e.g. "EntityNotExistsError", if the error has one
+	Type string `json:"type,omitempty"`
+	// Details are the fields of the API error type
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	status := yarpcerrors.FromError(err)
+	response := errorResponse{
+		Code:    status.Code().String(),
+		Message: status.Message(),
+	}
+	for _, detail := range protobuf.GetErrorDetails(err) {
+		message, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+		var details bytes.Buffer
+		if err := marshaler.Marshal(&details, message); err != nil {
+			g.logger.Warn("failed to encode gateway error details", tag.Error(err))
+			continue
+		}
+		name := proto.MessageName(message)
+		response.Type = name[strings.LastIndex(name, ".")+1:]
+		response.Details = details.Bytes()
+		break
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		g.logger.Error("failed to encode gateway error", tag.Error(err))
+		body = []byte(`{"code":"internal"}`)
+	}
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(httpStatus(status.Code()))
+	w.Write(body)
+}
+
+// httpStatus maps yarpc error codes to HTTP status codes, following the mapping of yarpc HTTP transport
+func httpStatus(code yarpcerrors.Code) int {
+	switch code {
+	case yarpcerrors.CodeOK:
+		return http.StatusOK
+	case yarpcerrors.CodeInvalidArgument, yarpcerrors.CodeFailedPrecondition, yarpcerrors.CodeOutOfRange:
+		return http.StatusBadRequest
+	case yarpcerrors.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case yarpcerrors.CodePermissionDenied:
+		return http.StatusForbidden
+	case yarpcerrors.CodeNotFound:
+		return http.StatusNotFound
+	case yarpcerrors.CodeAlreadyExists, yarpcerrors.CodeAborted:
+		return http.StatusConflict
+	case yarpcerrors.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case yarpcerrors.CodeCancelled:
+		return statusClientClosedRequest
+	case yarpcerrors.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case yarpcerrors.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case yarpcerrors.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
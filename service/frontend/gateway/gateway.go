@@ -0,0 +1,232 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package gateway serves the public frontend API as JSON over HTTP.
+//
+// Requests are decoded into the proto requests of the gRPC API and handed to the same grpc.APIHandler
+// that is registered on the dispatcher, so they go through the same inbound middleware, mappers and handler
+// wrappers (version check, rate limiting, metrics, redirection, authorization) as gRPC requests do.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	yarpchttp "go.uber.org/yarpc/transport/http"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/service/frontend/wrappers/grpc"
+)
+
+const (
+	// Prefix is the path all gateway routes are served under
+	Prefix = "/api/v1"
+	// OpenAPIPath is the path the OpenAPI spec of the gateway routes is served at
+	OpenAPIPath = Prefix + "/openapi.json"
+
+	contentTypeJSON = "application/json"
+	encodingJSON    = "json"
+	defaultCaller   = "cadence-http-gateway"
+	defaultTimeout  = 30 * time.Second
+	maxBodySize     = 4 * 1024 * 1024
+
+	// headers understood by the gateway, in addition to cadence-* headers that are forwarded as is
+	callerHeaderName        = "Rpc-Caller"
+	timeoutHeaderName       = "Context-TTL-MS"
+	authorizationHeaderName = "Authorization"
+	bearerPrefix            = "Bearer "
+	cadenceHeaderPrefix     = "cadence-"
+)
+
+var (
+	marshaler   = &jsonpb.Marshaler{}
+	unmarshaler = &jsonpb.Unmarshaler{}
+)
+
+// Gateway is a http.Handler serving the public API routes
+type Gateway struct {
+	handler grpc.APIHandler
+	inbound middleware.UnaryInbound
+	logger  log.Logger
+	mux     *http.ServeMux
+}
+
+// New creates a gateway serving the routes of the public API on top of the given handler.
+// Requests are passed through the given inbound middleware, which should be the unary inbound middleware of the dispatcher.
+func New(handler grpc.APIHandler, inbound middleware.UnaryInbound, logger log.Logger) *Gateway {
+	g := &Gateway{
+		handler: handler,
+		inbound: inbound,
+		logger:  logger.WithTags(tag.ComponentGateway),
+		mux:     http.NewServeMux(),
+	}
+	for _, r := range routes {
+		g.mux.Handle(r.method+" "+Prefix+r.path, g.serve(r))
+	}
+
+	spec, err := newSpec(routes)
+	if err != nil {
+		// spec is built from static route definitions, it can only fail on programming error
+		g.logger.Fatal("failed to build OpenAPI spec", tag.Error(err))
+	}
+	g.mux.HandleFunc(http.MethodGet+" "+OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.Write(spec)
+	})
+	return g
+}
+
+// ServeHTTP implements http.Handler
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) serve(rt route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, timeout, err := inboundRequest(r, rt.procedure)
+		if err != nil {
+			g.writeError(w, yarpcerrors.InvalidArgumentErrorf("%v", err))
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		var response proto.Message
+		handler := middleware.ApplyUnaryInbound(unaryHandlerFunc(func(ctx context.Context, request *transport.Request, _ transport.ResponseWriter) error {
+			// set up the call the same way yarpc encodings do, so handler wrappers can read the caller and headers of the request
+			ctx, call := encoding.NewInboundCall(ctx)
+			if err := call.ReadFromRequest(request); err != nil {
+				return yarpcerrors.InvalidArgumentErrorf("%v", err)
+			}
+			var err error
+			response, err = rt.handle(ctx, g.handler, r)
+			return err
+		}), g.inbound)
+		if err := handler.Handle(ctx, request, discardResponseWriter{}); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, http.StatusOK, response)
+	})
+}
+
+// inboundRequest builds the yarpc request the inbound middleware sees for the http request, along with its timeout
+func inboundRequest(r *http.Request, procedure string) (*transport.Request, time.Duration, error) {
+	timeout := defaultTimeout
+	if ttl := r.Header.Get(timeoutHeaderName); ttl != "" {
+		ms, err := strconv.Atoi(ttl)
+		if err != nil || ms <= 0 {
+			return nil, 0, fmt.Errorf("invalid %s header %q", timeoutHeaderName, ttl)
+		}
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	headers := transport.NewHeaders()
+	for name, values := range r.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, cadenceHeaderPrefix) && len(values) > 0 {
+			headers = headers.With(name, values[0])
+		}
+	}
+	if _, ok := headers.Get(common.AuthorizationTokenHeaderName); !ok {
+		if token, ok := strings.CutPrefix(r.Header.Get(authorizationHeaderName), bearerPrefix); ok {
+			headers = headers.With(common.AuthorizationTokenHeaderName, token)
+		}
+	}
+
+	caller := r.Header.Get(callerHeaderName)
+	if caller == "" {
+		caller = defaultCaller
+	}
+
+	return &transport.Request{
+		Caller:    caller,
+		Service:   service.Frontend,
+		Transport: yarpchttp.TransportName,
+		Encoding:  encodingJSON,
+		Procedure: procedure,
+		Headers:   headers,
+	}, timeout, nil
+}
+
+type unaryHandlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f unaryHandlerFunc) Handle(ctx context.Context, request *transport.Request, resw transport.ResponseWriter) error {
+	return f(ctx, request, resw)
+}
+
+// discardResponseWriter is passed to the inbound middleware, responses are written by the gateway itself
+type discardResponseWriter struct{}
+
+func (discardResponseWriter) Write(p []byte) (int, error)  { return len(p), nil }
+func (discardResponseWriter) AddHeaders(transport.Headers) {}
+func (discardResponseWriter) SetApplicationError()         {}
+
+// decodeBody decodes JSON body of the request into the proto request, empty body leaves request as is
+func decodeBody(r *http.Request, request proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return yarpcerrors.InvalidArgumentErrorf("failed to read request body: %v", err)
+	}
+	if len(body) > maxBodySize {
+		return yarpcerrors.InvalidArgumentErrorf("request body is larger than %d bytes", maxBodySize)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(body), request); err != nil {
+		return yarpcerrors.InvalidArgumentErrorf("invalid request body: %v", err)
+	}
+	return nil
+}
+
+func (g *Gateway) write(w http.ResponseWriter, status int, response proto.Message) {
+	var body bytes.Buffer
+	if v := reflect.ValueOf(response); !v.IsValid() || v.IsNil() {
+		// handlers may return no response for successful calls
+		body.WriteString("{}")
+	} else if err := marshaler.Marshal(&body, response); err != nil {
+		g.logger.Error("failed to encode gateway response", tag.Error(err))
+		status = http.StatusInternalServerError
+		body.Reset()
+		body.WriteString(`{"code":"internal","message":"failed to encode response"}`)
+	}
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
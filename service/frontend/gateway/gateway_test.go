@@ -0,0 +1,276 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/isolationgroup"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/rpc"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/frontend/api"
+	"github.com/uber/cadence/service/frontend/wrappers/grpc"
+)
+
+func setupGateway(t *testing.T) (*Gateway, *api.MockHandler) {
+	ctrl := gomock.NewController(t)
+	handler := api.NewMockHandler(ctrl)
+	return New(grpc.NewAPIHandler(handler), nil, testlogger.New(t)), handler
+}
+
+func serve(g *Gateway, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+	return w
+}
+
+func TestSignalWorkflowExecution(t *testing.T) {
+	g, handler := setupGateway(t)
+	handler.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *types.SignalWorkflowExecutionRequest) error {
+			assert.Equal(t, "test-domain", request.Domain)
+			assert.Equal(t, &types.WorkflowExecution{WorkflowID: "test-workflow", RunID: "test-run"}, request.WorkflowExecution)
+			assert.Equal(t, "test-signal", request.SignalName)
+			assert.Equal(t, "test-identity", request.Identity)
+
+			call := yarpc.CallFromContext(ctx)
+			assert.Equal(t, "uber.cadence.api.v1.WorkflowAPI::SignalWorkflowExecution", call.Procedure())
+			assert.Equal(t, defaultCaller, call.Caller())
+			assert.Equal(t, "test-token", call.Header(common.AuthorizationTokenHeaderName))
+			assert.Equal(t, "test-client", call.Header(common.ClientImplHeaderName))
+			return nil
+		})
+
+	w := serve(g, http.MethodPost, "/api/v1/domains/test-domain/workflows/test-workflow/signal?runId=test-run",
+		`{"domain": "ignored", "signalName": "test-signal", "identity": "test-identity"}`,
+		map[string]string{
+			"Authorization":             "Bearer test-token",
+			common.ClientImplHeaderName: "test-client",
+		})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, contentTypeJSON, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{}`, w.Body.String())
+}
+
+func TestStartWorkflowExecution(t *testing.T) {
+	g, handler := setupGateway(t)
+	handler.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *types.StartWorkflowExecutionRequest) (*types.StartWorkflowExecutionResponse, error) {
+			assert.Equal(t, "test-domain", request.Domain)
+			assert.Equal(t, "test-workflow", request.WorkflowID)
+			assert.Equal(t, &types.WorkflowType{Name: "test-type"}, request.WorkflowType)
+			assert.Equal(t, &types.TaskList{Name: "test-tasklist"}, request.TaskList)
+			assert.Equal(t, int32(60), *request.ExecutionStartToCloseTimeoutSeconds)
+			return &types.StartWorkflowExecutionResponse{RunID: "test-run"}, nil
+		})
+
+	w := serve(g, http.MethodPost, "/api/v1/domains/test-domain/workflows/test-workflow",
+		`{"workflowType": {"name": "test-type"}, "taskList": {"name": "test-tasklist"}, "executionStartToCloseTimeout": "60s"}`, nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"runId": "test-run"}`, w.Body.String())
+}
+
+func TestDescribeDomain(t *testing.T) {
+	g, handler := setupGateway(t)
+	handler.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("test-domain")}).
+		Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: "test-domain"}}, nil)
+
+	w := serve(g, http.MethodGet, "/api/v1/domains/test-domain", "", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"test-domain"`)
+}
+
+func TestListDomains(t *testing.T) {
+	g, handler := setupGateway(t)
+	handler.EXPECT().ListDomains(gomock.Any(), &types.ListDomainsRequest{PageSize: 10, NextPageToken: []byte{0xfb, 0xff}}).
+		Return(&types.ListDomainsResponse{NextPageToken: []byte{0xfb, 0xff}}, nil)
+
+	// token is "+/8=" in standard base64, "+" is decoded as space in query strings
+	w := serve(g, http.MethodGet, "/api/v1/domains?pageSize=10&nextPageToken=+/8=", "", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"nextPageToken": "+/8="}`, w.Body.String())
+}
+
+func TestInboundMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := api.NewMockHandler(ctrl)
+	inbound := yarpc.UnaryInboundMiddleware(&rpc.PinotComparatorMiddleware{}, &rpc.InboundMetricsMiddleware{}, &rpc.ClientPartitionConfigMiddleware{}, &rpc.ForwardPartitionConfigMiddleware{})
+	g := New(grpc.NewAPIHandler(handler), inbound, testlogger.New(t))
+
+	handler.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *types.DescribeDomainRequest) (*types.DescribeDomainResponse, error) {
+			assert.ElementsMatch(t, []metrics.Tag{
+				metrics.CallerTag("test-caller"),
+				metrics.TransportTag("http"),
+			}, metrics.GetContextTags(ctx))
+			assert.Equal(t, "test-zone", isolationgroup.IsolationGroupFromContext(ctx))
+			assert.Equal(t, map[string]string{isolationgroup.GroupKey: "test-zone"}, isolationgroup.ConfigFromContext(ctx))
+			assert.Equal(t, "test-caller", yarpc.CallFromContext(ctx).Caller())
+			return &types.DescribeDomainResponse{}, nil
+		})
+
+	w := serve(g, http.MethodGet, "/api/v1/domains/test-domain", "", map[string]string{
+		callerHeaderName:                      "test-caller",
+		common.ClientIsolationGroupHeaderName: "test-zone",
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		desc       string
+		method     string
+		target     string
+		body       string
+		headers    map[string]string
+		mockFn     func(*api.MockHandler)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			desc:   "entity not exists",
+			method: http.MethodGet,
+			target: "/api/v1/domains/test-domain",
+			mockFn: func(h *api.MockHandler) {
+				h.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+					Return(nil, &types.EntityNotExistsError{Message: "domain does not exist", CurrentCluster: "cluster0"})
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not-found", "message": "domain does not exist", "type": "EntityNotExistsError", "details": {"currentCluster": "cluster0"}}`,
+		},
+		{
+			desc:   "bad request",
+			method: http.MethodPost,
+			target: "/api/v1/domains/test-domain/workflows/test-workflow/terminate",
+			mockFn: func(h *api.MockHandler) {
+				h.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+					Return(&types.BadRequestError{Message: "bad request"})
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "invalid-argument", "message": "bad request"}`,
+		},
+		{
+			desc:   "service busy",
+			method: http.MethodPost,
+			target: "/api/v1/domains/test-domain/workflows/test-workflow/cancel",
+			mockFn: func(h *api.MockHandler) {
+				h.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any()).
+					Return(&types.ServiceBusyError{Message: "busy", Reason: "rps"})
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"code": "resource-exhausted", "message": "busy", "type": "ServiceBusyError", "details": {"reason": "rps"}}`,
+		},
+		{
+			desc:       "invalid body",
+			method:     http.MethodPost,
+			target:     "/api/v1/domains/test-domain/workflows/test-workflow/signal",
+			body:       `{"unknownField": 1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc:       "invalid query parameter",
+			method:     http.MethodGet,
+			target:     "/api/v1/domains/test-domain/workflows?pageSize=many",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "invalid-argument", "message": "invalid pageSize parameter \"many\""}`,
+		},
+		{
+			desc:       "invalid timeout",
+			method:     http.MethodGet,
+			target:     "/api/v1/health",
+			headers:    map[string]string{timeoutHeaderName: "-1"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			desc:       "unknown route",
+			method:     http.MethodGet,
+			target:     "/api/v1/unknown",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:       "method not allowed",
+			method:     http.MethodDelete,
+			target:     "/api/v1/domains/test-domain",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, handler := setupGateway(t)
+			if tc.mockFn != nil {
+				tc.mockFn(handler)
+			}
+
+			w := serve(g, tc.method, tc.target, tc.body, tc.headers)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			if tc.wantBody != "" {
+				assert.JSONEq(t, tc.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	for code, status := range map[string]int{
+		"invalid-argument":   http.StatusBadRequest,
+		"not-found":          http.StatusNotFound,
+		"already-exists":     http.StatusConflict,
+		"permission-denied":  http.StatusForbidden,
+		"unauthenticated":    http.StatusUnauthorized,
+		"resource-exhausted": http.StatusTooManyRequests,
+		"cancelled":          statusClientClosedRequest,
+		"deadline-exceeded":  http.StatusGatewayTimeout,
+		"unimplemented":      http.StatusNotImplemented,
+		"unavailable":        http.StatusServiceUnavailable,
+		"internal":           http.StatusInternalServerError,
+		"unknown":            http.StatusInternalServerError,
+	} {
+		var c yarpcerrors.Code
+		require.NoError(t, c.UnmarshalText([]byte(code)))
+		assert.Equal(t, status, httpStatus(c), code)
+	}
+}
@@ -0,0 +1,323 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	openAPIVersion  = "3.0.3"
+	gogoTypesPath   = "github.com/gogo/protobuf/types"
+	schemaRefPrefix = "#/components/schemas/"
+	errorSchemaName = "Error"
+)
+
+// schema is an OpenAPI schema object
+type schema map[string]any
+
+var (
+	pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+	pathParamDescriptions = map[string]string{
+		"domain":     "Name of the domain",
+		"workflowId": "Workflow ID",
+		"taskList":   "Name of the task list",
+	}
+)
+
+// newSpec generates OpenAPI spec of the routes. Request and response schemas are derived from the
+// generated proto structs, following the proto JSON mapping used to encode and decode them.
+func newSpec(routes []route) ([]byte, error) {
+	b := &schemaBuilder{schemas: map[string]schema{}}
+	b.schemas[errorSchemaName] = errorSchema()
+
+	paths := map[string]map[string]any{}
+	var tags []string
+	for _, r := range routes {
+		operation := map[string]any{
+			"operationId": r.operation,
+			"summary":     r.summary,
+			"tags":        []string{r.api},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "Success",
+					"content":     jsonContent(b.ref(r.response)),
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content":     jsonContent(schema{"$ref": schemaRefPrefix + errorSchemaName}),
+				},
+			},
+		}
+
+		var params []schema
+		for _, match := range pathParamPattern.FindAllStringSubmatch(r.path, -1) {
+			params = append(params, schema{
+				"name":        match[1],
+				"in":          "path",
+				"required":    true,
+				"description": pathParamDescriptions[match[1]],
+				"schema":      schema{"type": "string"},
+			})
+		}
+		for _, p := range r.params {
+			params = append(params, schema{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"schema":      kindSchema(p.kind),
+			})
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		if r.request != nil {
+			operation["requestBody"] = map[string]any{
+				"description": "Fields set from the path are ignored in the body",
+				"content":     jsonContent(b.ref(r.request)),
+			}
+		}
+
+		if paths[r.path] == nil {
+			paths[r.path] = map[string]any{}
+		}
+		method := strings.ToLower(r.method)
+		if _, ok := paths[r.path][method]; ok {
+			return nil, fmt.Errorf("duplicate route %s %s", r.method, r.path)
+		}
+		paths[r.path][method] = operation
+
+		if !slices.Contains(tags, r.api) {
+			tags = append(tags, r.api)
+		}
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var tagObjects []schema
+	for _, t := range tags {
+		tagObjects = append(tagObjects, schema{"name": t})
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":       "Cadence API",
+			"version":     "v1",
+			"description": "JSON over HTTP gateway of the Cadence frontend API. Authorization token is accepted either as a bearer token or in the cadence-authorization header.",
+		},
+		"servers": []schema{{"url": Prefix}},
+		"tags":    tagObjects,
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": b.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": schema{"type": "http", "scheme": "bearer"},
+			},
+		},
+		// authorization is optional, it depends on the authorizer configured in the cluster
+		"security": []map[string][]string{{"bearerAuth": {}}, {}},
+	}, "", "  ")
+}
+
+func jsonContent(s schema) map[string]any {
+	return map[string]any{contentTypeJSON: map[string]any{"schema": s}}
+}
+
+func errorSchema() schema {
+	return schema{
+		"type":     "object",
+		"required": []string{"code"},
+		"properties": map[string]schema{
+			"code":    {"type": "string", "description": "yarpc error code, e.g. not-found"},
+			"message": {"type": "string"},
+			"type":    {"type": "string", "description": "Name of the API error type, e.g. EntityNotExistsError"},
+			"details": {"type": "object", "description": "Fields of the API error type"},
+		},
+	}
+}
+
+// schemaBuilder collects schemas of proto messages as OpenAPI components
+type schemaBuilder struct {
+	schemas map[string]schema
+	err     error
+}
+
+// ref returns a reference to the schema of the message, adding it to components if needed
+func (b *schemaBuilder) ref(t reflect.Type) schema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if _, ok := b.schemas[name]; !ok {
+		// register first, so recursive messages refer to it instead of recursing infinitely
+		properties := map[string]schema{}
+		b.schemas[name] = schema{"type": "object", "properties": properties}
+		b.properties(t, properties)
+	}
+	return schema{"$ref": schemaRefPrefix + name}
+}
+
+func (b *schemaBuilder) properties(t reflect.Type, properties map[string]schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if oneof := field.Tag.Get("protobuf_oneof"); oneof != "" {
+			b.oneofProperties(t, oneof, properties)
+			continue
+		}
+		tag := field.Tag.Get("protobuf")
+		if tag == "" {
+			// XXX_ fields of generated structs
+			continue
+		}
+		name, fieldSchema := b.field(field.Type, tag)
+		properties[name] = fieldSchema
+	}
+}
+
+// oneofProperties adds fields of all oneof options, jsonpb encodes the one that is set as a regular field
+func (b *schemaBuilder) oneofProperties(t reflect.Type, oneof string, properties map[string]schema) {
+	message, ok := reflect.New(t).Interface().(interface{ XXX_OneofWrappers() []interface{} })
+	if !ok {
+		b.err = fmt.Errorf("%s has oneof %s without wrappers", t.Name(), oneof)
+		return
+	}
+	for _, wrapper := range message.XXX_OneofWrappers() {
+		field := reflect.TypeOf(wrapper).Elem().Field(0)
+		name, fieldSchema := b.field(field.Type, field.Tag.Get("protobuf"))
+		fieldSchema["description"] = "Only one of the fields of " + oneof + " can be set"
+		properties[name] = fieldSchema
+	}
+}
+
+// field returns JSON name and schema of a proto field with the given struct tag
+func (b *schemaBuilder) field(t reflect.Type, tag string) (string, schema) {
+	var name, jsonName, enum string
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case strings.HasPrefix(part, "name="):
+			name = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "json="):
+			jsonName = strings.TrimPrefix(part, "json=")
+		case strings.HasPrefix(part, "enum="):
+			enum = strings.TrimPrefix(part, "enum=")
+		}
+	}
+	if jsonName == "" {
+		jsonName = name
+	}
+
+	if enum != "" {
+		if t.Kind() == reflect.Slice {
+			return jsonName, schema{"type": "array", "items": enumSchema(enum)}
+		}
+		return jsonName, enumSchema(enum)
+	}
+	return jsonName, b.typeSchema(t)
+}
+
+func (b *schemaBuilder) typeSchema(t reflect.Type) schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().PkgPath() == gogoTypesPath {
+			return wellKnownSchema(t.Elem())
+		}
+		return b.ref(t)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return schema{"type": "string", "format": "byte"}
+		}
+		return schema{"type": "array", "items": b.typeSchema(t.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": b.typeSchema(t.Elem())}
+	default:
+		return kindSchema(t.Kind())
+	}
+}
+
+func kindSchema(kind reflect.Kind) schema {
+	switch kind {
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return schema{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		// proto JSON mapping encodes 64-bit integers as strings
+		return schema{"type": "string", "format": "int64"}
+	case reflect.Float32:
+		return schema{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return schema{"type": "number", "format": "double"}
+	default:
+		return schema{"type": "string"}
+	}
+}
+
+func enumSchema(name string) schema {
+	values := proto.EnumValueMap(name)
+	names := make([]string, 0, len(values))
+	for n := range values {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool { return values[names[i]] < values[names[j]] })
+	return schema{"type": "string", "enum": names}
+}
+
+// wellKnownSchema returns schemas of well known types, which have special JSON encoding
+func wellKnownSchema(t reflect.Type) schema {
+	switch t.Name() {
+	case "Duration":
+		return schema{"type": "string", "description": "Duration in seconds with up to nine fractional digits, e.g. 1.5s"}
+	case "Timestamp":
+		return schema{"type": "string", "format": "date-time"}
+	case "FieldMask":
+		return schema{"type": "string", "description": "Comma separated field paths, e.g. description,workflowExecutionRetentionPeriod"}
+	case "BoolValue":
+		return kindSchema(reflect.Bool)
+	case "Int32Value", "UInt32Value":
+		return kindSchema(reflect.Int32)
+	case "Int64Value", "UInt64Value":
+		return kindSchema(reflect.Int64)
+	case "FloatValue":
+		return kindSchema(reflect.Float32)
+	case "DoubleValue":
+		return kindSchema(reflect.Float64)
+	case "BytesValue":
+		return schema{"type": "string", "format": "byte"}
+	case "StringValue":
+		return kindSchema(reflect.String)
+	default:
+		return schema{}
+	}
+}
@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpec struct {
+	Paths      map[string]map[string]testOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]testSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type testOperation struct {
+	OperationID string `json:"operationId"`
+	Parameters  []struct {
+		Name string `json:"name"`
+		In   string `json:"in"`
+	} `json:"parameters"`
+	RequestBody *struct{} `json:"requestBody"`
+}
+
+type testSchema struct {
+	Type       string                `json:"type"`
+	Format     string                `json:"format"`
+	Ref        string                `json:"$ref"`
+	Enum       []string              `json:"enum"`
+	Properties map[string]testSchema `json:"properties"`
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	g, _ := setupGateway(t)
+	w := serve(g, http.MethodGet, OpenAPIPath, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, contentTypeJSON, w.Header().Get("Content-Type"))
+
+	var spec testSpec
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+
+	for _, r := range routes {
+		operation, ok := spec.Paths[r.path][strings.ToLower(r.method)]
+		require.True(t, ok, "missing route %s %s", r.method, r.path)
+		assert.Equal(t, r.operation, operation.OperationID)
+		assert.Equal(t, r.method != http.MethodGet, operation.RequestBody != nil, r.operation)
+		assert.Len(t, operation.Parameters, strings.Count(r.path, "{")+len(r.params), r.operation)
+	}
+
+	signal := spec.Components.Schemas["SignalWorkflowExecutionRequest"]
+	assert.Equal(t, "string", signal.Properties["signalName"].Type)
+	assert.Equal(t, "#/components/schemas/WorkflowExecution", signal.Properties["workflowExecution"].Ref)
+	assert.Contains(t, spec.Components.Schemas, "WorkflowExecution")
+
+	assert.NotContains(t, spec.Components.Schemas, "DescribeTaskListRequest", "GET requests have no body schema")
+
+	start := spec.Components.Schemas["StartWorkflowExecutionRequest"]
+	assert.Equal(t, "string", start.Properties["executionStartToCloseTimeout"].Type)
+	assert.Equal(t, "string", start.Properties["delayStart"].Type)
+
+	signalWithStart := spec.Components.Schemas["SignalWithStartWorkflowExecutionRequest"]
+	assert.Equal(t, "#/components/schemas/StartWorkflowExecutionRequest", signalWithStart.Properties["startRequest"].Ref)
+
+	history := spec.Components.Schemas["GetWorkflowExecutionHistoryResponse"]
+	assert.Equal(t, "byte", history.Properties["nextPageToken"].Format)
+
+	describeDomain := spec.Components.Schemas["DescribeDomainResponse"]
+	assert.Contains(t, describeDomain.Properties, "domain")
+	domain := spec.Components.Schemas["Domain"]
+	assert.Equal(t, []string{"DOMAIN_STATUS_INVALID", "DOMAIN_STATUS_REGISTERED", "DOMAIN_STATUS_DEPRECATED", "DOMAIN_STATUS_DELETED"}, domain.Properties["status"].Enum)
+}
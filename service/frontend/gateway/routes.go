@@ -0,0 +1,362 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/service/frontend/wrappers/grpc"
+)
+
+// proto services of the public API, used for procedure names and to group routes in the spec
+const (
+	domainAPI     = "DomainAPI"
+	workflowAPI   = "WorkflowAPI"
+	visibilityAPI = "VisibilityAPI"
+	metaAPI       = "MetaAPI"
+
+	procedurePrefix = "uber.cadence.api.v1."
+)
+
+type (
+	// route is a single HTTP route of the gateway, mapped to one method of the gRPC API
+	route struct {
+		method    string
+		path      string
+		summary   string
+		api       string
+		operation string
+		procedure string
+		params    []param
+		// request is the proto request type, it is decoded from the body of the requests with a body
+		request  reflect.Type
+		response reflect.Type
+		handle   func(context.Context, grpc.APIHandler, *http.Request) (proto.Message, error)
+	}
+
+	// param is a query parameter of a route
+	param struct {
+		name        string
+		description string
+		kind        reflect.Kind
+	}
+)
+
+var (
+	runIDParam         = param{name: "runId", description: "Run ID of the workflow execution, latest run if not set", kind: reflect.String}
+	pageSizeParam      = param{name: "pageSize", description: "Maximum number of items in the response", kind: reflect.Int32}
+	nextPageTokenParam = param{name: "nextPageToken", description: "Token from the previous page, base64 encoded", kind: reflect.String}
+	queryParam         = param{name: "query", description: "Visibility query, e.g. WorkflowType = 'MyWorkflow'", kind: reflect.String}
+)
+
+var routes = []route{
+	// Meta
+	newRoute(metaAPI, http.MethodGet, "/health", "Health check of the frontend service",
+		grpc.APIHandler.Health, nil),
+	newRoute(workflowAPI, http.MethodGet, "/cluster", "Describe the cluster",
+		grpc.APIHandler.GetClusterInfo, nil),
+	newRoute(visibilityAPI, http.MethodGet, "/search-attributes", "List search attributes allowed in visibility queries",
+		grpc.APIHandler.GetSearchAttributes, nil),
+
+	// Domains
+	newRoute(domainAPI, http.MethodGet, "/domains", "List domains",
+		grpc.APIHandler.ListDomains,
+		func(r *http.Request, req *apiv1.ListDomainsRequest) (err error) {
+			if req.PageSize, err = queryInt32(r, pageSizeParam.name); err != nil {
+				return err
+			}
+			req.NextPageToken, err = queryToken(r, nextPageTokenParam.name)
+			return err
+		},
+		pageSizeParam, nextPageTokenParam),
+	newRoute(domainAPI, http.MethodPost, "/domains", "Register a domain",
+		grpc.APIHandler.RegisterDomain, nil),
+	newRoute(domainAPI, http.MethodGet, "/domains/{domain}", "Describe a domain",
+		grpc.APIHandler.DescribeDomain,
+		func(r *http.Request, req *apiv1.DescribeDomainRequest) error {
+			req.DescribeBy = &apiv1.DescribeDomainRequest_Name{Name: r.PathValue("domain")}
+			return nil
+		}),
+	newRoute(domainAPI, http.MethodPatch, "/domains/{domain}", "Update a domain, fields to update are listed in updateMask",
+		grpc.APIHandler.UpdateDomain,
+		func(r *http.Request, req *apiv1.UpdateDomainRequest) error {
+			req.Name = r.PathValue("domain")
+			return nil
+		}),
+	newRoute(domainAPI, http.MethodPost, "/domains/{domain}/deprecate", "Deprecate a domain",
+		grpc.APIHandler.DeprecateDomain,
+		func(r *http.Request, req *apiv1.DeprecateDomainRequest) error {
+			req.Name = r.PathValue("domain")
+			return nil
+		}),
+
+	// Task lists
+	newRoute(workflowAPI, http.MethodGet, "/domains/{domain}/task-lists", "List task lists of a domain",
+		grpc.APIHandler.GetTaskListsByDomain,
+		func(r *http.Request, req *apiv1.GetTaskListsByDomainRequest) error {
+			req.Domain = r.PathValue("domain")
+			return nil
+		}),
+	newRoute(workflowAPI, http.MethodGet, "/domains/{domain}/task-lists/{taskList}", "Describe pollers and backlog of a task list",
+		grpc.APIHandler.DescribeTaskList,
+		func(r *http.Request, req *apiv1.DescribeTaskListRequest) (err error) {
+			req.Domain = r.PathValue("domain")
+			req.TaskList = &apiv1.TaskList{Name: r.PathValue("taskList")}
+			if req.TaskListType, err = queryTaskListType(r, "type"); err != nil {
+				return err
+			}
+			req.IncludeTaskListStatus, err = queryBool(r, "includeStatus")
+			return err
+		},
+		param{name: "type", description: "Task list type: decision (default) or activity", kind: reflect.String},
+		param{name: "includeStatus", description: "Include status of the task list", kind: reflect.Bool}),
+
+	// Workflows
+	newRoute(visibilityAPI, http.MethodGet, "/domains/{domain}/workflows", "List workflow executions matching the query",
+		grpc.APIHandler.ListWorkflowExecutions,
+		func(r *http.Request, req *apiv1.ListWorkflowExecutionsRequest) (err error) {
+			req.Domain = r.PathValue("domain")
+			req.Query = r.URL.Query().Get(queryParam.name)
+			if req.PageSize, err = queryInt32(r, pageSizeParam.name); err != nil {
+				return err
+			}
+			req.NextPageToken, err = queryToken(r, nextPageTokenParam.name)
+			return err
+		},
+		queryParam, pageSizeParam, nextPageTokenParam),
+	newRoute(visibilityAPI, http.MethodGet, "/domains/{domain}/workflow-count", "Count workflow executions matching the query",
+		grpc.APIHandler.CountWorkflowExecutions,
+		func(r *http.Request, req *apiv1.CountWorkflowExecutionsRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.Query = r.URL.Query().Get(queryParam.name)
+			return nil
+		},
+		queryParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}", "Start a workflow execution",
+		grpc.APIHandler.StartWorkflowExecution,
+		func(r *http.Request, req *apiv1.StartWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowId = r.PathValue("workflowId")
+			return nil
+		}),
+	newRoute(workflowAPI, http.MethodGet, "/domains/{domain}/workflows/{workflowId}", "Describe a workflow execution",
+		grpc.APIHandler.DescribeWorkflowExecution,
+		func(r *http.Request, req *apiv1.DescribeWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodGet, "/domains/{domain}/workflows/{workflowId}/history", "Get history of a workflow execution",
+		grpc.APIHandler.GetWorkflowExecutionHistory,
+		func(r *http.Request, req *apiv1.GetWorkflowExecutionHistoryRequest) (err error) {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			if req.PageSize, err = queryInt32(r, pageSizeParam.name); err != nil {
+				return err
+			}
+			if req.NextPageToken, err = queryToken(r, nextPageTokenParam.name); err != nil {
+				return err
+			}
+			req.WaitForNewEvent, err = queryBool(r, "waitForNewEvent")
+			return err
+		},
+		runIDParam, pageSizeParam, nextPageTokenParam,
+		param{name: "waitForNewEvent", description: "Long poll until a new event is added to the history", kind: reflect.Bool}),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/signal", "Signal a workflow execution",
+		grpc.APIHandler.SignalWorkflowExecution,
+		func(r *http.Request, req *apiv1.SignalWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/signal-with-start", "Signal a workflow execution, starting it if it is not running",
+		grpc.APIHandler.SignalWithStartWorkflowExecution,
+		func(r *http.Request, req *apiv1.SignalWithStartWorkflowExecutionRequest) error {
+			if req.StartRequest == nil {
+				req.StartRequest = &apiv1.StartWorkflowExecutionRequest{}
+			}
+			req.StartRequest.Domain = r.PathValue("domain")
+			req.StartRequest.WorkflowId = r.PathValue("workflowId")
+			return nil
+		}),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/query", "Query a workflow execution",
+		grpc.APIHandler.QueryWorkflow,
+		func(r *http.Request, req *apiv1.QueryWorkflowRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/cancel", "Request cancellation of a workflow execution",
+		grpc.APIHandler.RequestCancelWorkflowExecution,
+		func(r *http.Request, req *apiv1.RequestCancelWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/terminate", "Terminate a workflow execution",
+		grpc.APIHandler.TerminateWorkflowExecution,
+		func(r *http.Request, req *apiv1.TerminateWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/reset", "Reset a workflow execution to a decision task",
+		grpc.APIHandler.ResetWorkflowExecution,
+		func(r *http.Request, req *apiv1.ResetWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+	newRoute(workflowAPI, http.MethodPost, "/domains/{domain}/workflows/{workflowId}/restart", "Restart a closed workflow execution",
+		grpc.APIHandler.RestartWorkflowExecution,
+		func(r *http.Request, req *apiv1.RestartWorkflowExecutionRequest) error {
+			req.Domain = r.PathValue("domain")
+			req.WorkflowExecution = workflowExecution(r, req.WorkflowExecution)
+			return nil
+		},
+		runIDParam),
+}
+
+// newRoute creates a route calling the given gRPC API method.
+// Request is decoded from the body (for methods with a body), then bind sets fields from path and query parameters,
+// so the values in the path always take precedence over the ones in the body.
+func newRoute[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message](
+	api, method, path, summary string,
+	call func(grpc.APIHandler, context.Context, PReq) (Resp, error),
+	bind func(*http.Request, PReq) error,
+	params ...param,
+) route {
+	requestType := reflect.TypeOf((*Req)(nil)).Elem()
+	operation := strings.TrimSuffix(requestType.Name(), "Request")
+	hasBody := method != http.MethodGet
+
+	rt := route{
+		method:    method,
+		path:      path,
+		summary:   summary,
+		api:       api,
+		operation: operation,
+		procedure: procedurePrefix + api + "::" + operation,
+		params:    params,
+		response:  reflect.TypeOf((*Resp)(nil)).Elem(),
+		handle: func(ctx context.Context, handler grpc.APIHandler, r *http.Request) (proto.Message, error) {
+			request := PReq(new(Req))
+			if hasBody {
+				if err := decodeBody(r, request); err != nil {
+					return nil, err
+				}
+			}
+			if bind != nil {
+				if err := bind(r, request); err != nil {
+					return nil, err
+				}
+			}
+			return call(handler, ctx, request)
+		},
+	}
+	if hasBody {
+		rt.request = reflect.TypeOf(PReq(nil))
+	}
+	return rt
+}
+
+// workflowExecution takes workflow ID from the path and run ID from the query, falling back to the run ID in the body
+func workflowExecution(r *http.Request, execution *apiv1.WorkflowExecution) *apiv1.WorkflowExecution {
+	runID := r.URL.Query().Get(runIDParam.name)
+	if runID == "" {
+		runID = execution.GetRunId()
+	}
+	return &apiv1.WorkflowExecution{
+		WorkflowId: r.PathValue("workflowId"),
+		RunId:      runID,
+	}
+}
+
+func queryInt32(r *http.Request, name string) (int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, yarpcerrors.InvalidArgumentErrorf("invalid %s parameter %q", name, value)
+	}
+	return int32(i), nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, yarpcerrors.InvalidArgumentErrorf("invalid %s parameter %q", name, value)
+	}
+	return b, nil
+}
+
+// tokenReplacer normalizes URL safe base64 and '+' decoded as space in query strings to standard base64
+var tokenReplacer = strings.NewReplacer(" ", "+", "-", "+", "_", "/")
+
+// queryToken decodes page tokens, which are base64 encoded the same way as bytes fields in JSON responses
+func queryToken(r *http.Request, name string) ([]byte, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	token, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(tokenReplacer.Replace(value), "="))
+	if err != nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("invalid %s parameter %q", name, value)
+	}
+	return token, nil
+}
+
+func queryTaskListType(r *http.Request, name string) (apiv1.TaskListType, error) {
+	switch value := r.URL.Query().Get(name); strings.ToLower(value) {
+	case "", "decision":
+		return apiv1.TaskListType_TASK_LIST_TYPE_DECISION, nil
+	case "activity":
+		return apiv1.TaskListType_TASK_LIST_TYPE_ACTIVITY, nil
+	default:
+		return apiv1.TaskListType_TASK_LIST_TYPE_INVALID, yarpcerrors.InvalidArgumentErrorf("invalid %s parameter %q", name, value)
+	}
+}
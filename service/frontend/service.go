@@ -42,12 +42,15 @@ import (
 	"github.com/uber/cadence/service/frontend/admin"
 	"github.com/uber/cadence/service/frontend/api"
 	"github.com/uber/cadence/service/frontend/config"
+	"github.com/uber/cadence/service/frontend/gateway"
 	"github.com/uber/cadence/service/frontend/wrappers/accesscontrolled"
 	"github.com/uber/cadence/service/frontend/wrappers/clusterredirection"
 	"github.com/uber/cadence/service/frontend/wrappers/grpc"
+	"github.com/uber/cadence/service/frontend/wrappers/json"
 	"github.com/uber/cadence/service/frontend/wrappers/metered"
 	"github.com/uber/cadence/service/frontend/wrappers/ratelimited"
 	"github.com/uber/cadence/service/frontend/wrappers/thrift"
+	"github.com/uber/cadence/service/frontend/wrappers/tracing"
 	"github.com/uber/cadence/service/frontend/wrappers/versioncheck"
 )
 
@@ -90,6 +93,9 @@ func NewService(
 			PersistenceGlobalMaxQPS: serviceConfig.PersistenceGlobalMaxQPS,
 			ThrottledLoggerMaxRPS:   serviceConfig.ThrottledLogRPS,
 
+			PersistenceGlobalRatelimiterMode: serviceConfig.PersistenceGlobalRatelimiterMode,
+			GlobalRatelimiterUpdateInterval:  serviceConfig.GlobalRatelimiterUpdateInterval,
+
 			WriteVisibilityStoreName:        nil, // frontend service never write
 			EnableLogCustomerQueryParameter: serviceConfig.EnableLogCustomerQueryParameter,
 			ReadVisibilityStoreName:         serviceConfig.ReadVisibilityStoreName,
@@ -162,6 +168,7 @@ func (s *Service) Start() {
 		handler = clusterredirection.NewAPIHandler(handler, s, s.config, *s.params.ClusterRedirectionPolicy)
 	}
 	handler = accesscontrolled.NewAPIHandler(handler, s, s.params.Authorizer, s.params.AuthorizationConfig)
+	handler = tracing.NewAPIHandler(handler)
 
 	// Register the latest (most decorated) handler
 	thriftHandler := thrift.NewAPIHandler(handler)
@@ -170,8 +177,16 @@ func (s *Service) Start() {
 	grpcHandler := grpc.NewAPIHandler(handler)
 	grpcHandler.Register(s.GetDispatcher())
 
+	// APIs not defined in cadence-idl yet are served as JSON
+	jsonHandler := json.NewAPIHandler(handler)
+	jsonHandler.Register(s.GetDispatcher())
+
+	// JSON over HTTP gateway is served by the HTTP inbound, if enabled in rpc config
+	s.params.RPCFactory.SetHTTPHandler(gateway.New(grpcHandler, s.GetDispatcher().InboundMiddleware().Unary, s.GetLogger()))
+
 	s.adminHandler = admin.NewHandler(s, s.params, s.config, dh)
 	s.adminHandler = accesscontrolled.NewAdminHandler(s.adminHandler, s, s.params.Authorizer, s.params.AuthorizationConfig)
+	s.adminHandler = tracing.NewAdminHandler(s.adminHandler)
 
 	adminThriftHandler := thrift.NewAdminHandler(s.adminHandler)
 	adminThriftHandler.Register(s.GetDispatcher())
@@ -179,6 +194,10 @@ func (s *Service) Start() {
 	adminGRPCHandler := grpc.NewAdminHandler(s.adminHandler)
 	adminGRPCHandler.Register(s.GetDispatcher())
 
+	// admin APIs not defined in cadence-idl yet are served as JSON
+	adminJSONHandler := json.NewAdminHandler(s.adminHandler)
+	adminJSONHandler.Register(s.GetDispatcher())
+
 	// must start resource first
 	s.Resource.Start()
 
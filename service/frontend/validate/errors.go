@@ -38,6 +38,7 @@ var (
 	ErrWorkflowIDNotSet                           = &types.BadRequestError{Message: "WorkflowId is not set on request."}
 	ErrActivityIDNotSet                           = &types.BadRequestError{Message: "ActivityID is not set on request."}
 	ErrSignalNameNotSet                           = &types.BadRequestError{Message: "SignalName is not set on request."}
+	ErrRequestIDNotSet                            = &types.BadRequestError{Message: "RequestID is not set on request."}
 	ErrInvalidRunID                               = &types.BadRequestError{Message: "Invalid RunId."}
 	ErrInvalidNextPageToken                       = &types.BadRequestError{Message: "Invalid NextPageToken."}
 	ErrNextPageTokenRunIDMismatch                 = &types.BadRequestError{Message: "RunID in the request does not match the NextPageToken."}
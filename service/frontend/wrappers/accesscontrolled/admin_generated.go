@@ -309,6 +309,22 @@ func (a *adminHandler) GetReplicationMessages(ctx context.Context, gp1 *types.Ge
 	return a.handler.GetReplicationMessages(ctx, gp1)
 }
 
+func (a *adminHandler) GetReplicationStatus(ctx context.Context, gp1 *types.GetReplicationStatusRequest) (gp2 *types.GetReplicationStatusResponse, err error) {
+	attr := &authorization.Attributes{
+		APIName:     "GetReplicationStatus",
+		Permission:  authorization.PermissionAdmin,
+		RequestBody: authorization.NewFilteredRequestBody(gp1),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+	return a.handler.GetReplicationStatus(ctx, gp1)
+}
+
 func (a *adminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, gp1 *types.GetWorkflowExecutionRawHistoryV2Request) (gp2 *types.GetWorkflowExecutionRawHistoryV2Response, err error) {
 	attr := &authorization.Attributes{
 		APIName:     "GetWorkflowExecutionRawHistoryV2",
@@ -325,6 +341,22 @@ func (a *adminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, gp1
 	return a.handler.GetWorkflowExecutionRawHistoryV2(ctx, gp1)
 }
 
+func (a *adminHandler) ImportWorkflowExecution(ctx context.Context, ip1 *types.ImportWorkflowExecutionRequest) (ip2 *types.ImportWorkflowExecutionResponse, err error) {
+	attr := &authorization.Attributes{
+		APIName:     "ImportWorkflowExecution",
+		Permission:  authorization.PermissionAdmin,
+		RequestBody: authorization.NewFilteredRequestBody(ip1),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+	return a.handler.ImportWorkflowExecution(ctx, ip1)
+}
+
 func (a *adminHandler) ListDynamicConfig(ctx context.Context, lp1 *types.ListDynamicConfigRequest) (lp2 *types.ListDynamicConfigResponse, err error) {
 	attr := &authorization.Attributes{
 		APIName:     "ListDynamicConfig",
@@ -106,6 +106,10 @@ func (handler *clusterRedirectionHandler) DeprecateDomain(ctx context.Context, d
 	return handler.frontendHandler.DeprecateDomain(ctx, dp1)
 }
 
+func (handler *clusterRedirectionHandler) DescribeAsyncRequest(ctx context.Context, dp1 *types.DescribeAsyncRequestRequest) (dp2 *types.DescribeAsyncRequestResponse, err error) {
+	return handler.frontendHandler.DescribeAsyncRequest(ctx, dp1)
+}
+
 func (handler *clusterRedirectionHandler) DescribeDomain(ctx context.Context, dp1 *types.DescribeDomainRequest) (dp2 *types.DescribeDomainResponse, err error) {
 	return handler.frontendHandler.DescribeDomain(ctx, dp1)
 }
@@ -783,6 +787,10 @@ func (handler *clusterRedirectionHandler) RequestCancelWorkflowExecution(ctx con
 	return err
 }
 
+func (handler *clusterRedirectionHandler) RequestCancelWorkflowExecutionAsync(ctx context.Context, rp1 *types.RequestCancelWorkflowExecutionAsyncRequest) (rp2 *types.RequestCancelWorkflowExecutionAsyncResponse, err error) {
+	return handler.frontendHandler.RequestCancelWorkflowExecutionAsync(ctx, rp1)
+}
+
 func (handler *clusterRedirectionHandler) ResetStickyTaskList(ctx context.Context, rp1 *types.ResetStickyTaskListRequest) (rp2 *types.ResetStickyTaskListResponse, err error) {
 	var (
 		apiName                   = "ResetStickyTaskList"
@@ -1442,6 +1450,10 @@ func (handler *clusterRedirectionHandler) SignalWorkflowExecution(ctx context.Co
 	return err
 }
 
+func (handler *clusterRedirectionHandler) SignalWorkflowExecutionAsync(ctx context.Context, sp1 *types.SignalWorkflowExecutionAsyncRequest) (sp2 *types.SignalWorkflowExecutionAsyncResponse, err error) {
+	return handler.frontendHandler.SignalWorkflowExecutionAsync(ctx, sp1)
+}
+
 func (handler *clusterRedirectionHandler) StartWorkflowExecution(ctx context.Context, sp1 *types.StartWorkflowExecutionRequest) (sp2 *types.StartWorkflowExecutionResponse, err error) {
 	var (
 		apiName                   = "StartWorkflowExecution"
@@ -1562,6 +1574,10 @@ func (handler *clusterRedirectionHandler) TerminateWorkflowExecution(ctx context
 	return err
 }
 
+func (handler *clusterRedirectionHandler) TerminateWorkflowExecutionAsync(ctx context.Context, tp1 *types.TerminateWorkflowExecutionAsyncRequest) (tp2 *types.TerminateWorkflowExecutionAsyncResponse, err error) {
+	return handler.frontendHandler.TerminateWorkflowExecutionAsync(ctx, tp1)
+}
+
 func (handler *clusterRedirectionHandler) UpdateDomain(ctx context.Context, up1 *types.UpdateDomainRequest) (up2 *types.UpdateDomainResponse, err error) {
 	return handler.frontendHandler.UpdateDomain(ctx, up1)
 }
@@ -0,0 +1,108 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+	adminHandler "github.com/uber/cadence/service/frontend/admin"
+)
+
+func TestAdminJSONHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	h := adminHandler.NewMockHandler(ctrl)
+	jh := NewAdminHandler(h)
+	ctx := context.Background()
+	internalErr := &types.InternalServiceError{Message: "test"}
+
+	t.Run("GetReplicationStatus", func(t *testing.T) {
+		request := &types.GetReplicationStatusRequest{RemoteCluster: "cluster-b"}
+		response := &types.GetReplicationStatusResponse{Shards: []*types.ReplicationShardStatus{{ShardID: 1, RemoteCluster: "cluster-b"}}}
+
+		h.EXPECT().GetReplicationStatus(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.GetReplicationStatus(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().GetReplicationStatus(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.GetReplicationStatus(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+
+	t.Run("ImportWorkflowExecution", func(t *testing.T) {
+		request := &types.ImportWorkflowExecutionRequest{Domain: "domain"}
+		response := &types.ImportWorkflowExecutionResponse{WorkflowExecution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"}}
+
+		h.EXPECT().ImportWorkflowExecution(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.ImportWorkflowExecution(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().ImportWorkflowExecution(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.ImportWorkflowExecution(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+	t.Run("ReadDLQMessages", func(t *testing.T) {
+		request := &types.ReadDLQMessagesRequest{Type: types.DLQTypeReplication.Ptr(), ShardID: 1, SourceCluster: "cluster-b", IncludeRedriveStatus: true}
+		response := &types.ReadDLQMessagesResponse{
+			Type:            types.DLQTypeReplication.Ptr(),
+			RedriveStatuses: []*types.ReplicationDLQRedriveStatus{{TaskID: 1, Attempts: 2, ErrorClass: "bad-request", Quarantined: true}},
+		}
+
+		h.EXPECT().ReadDLQMessages(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.ReadDLQMessages(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().ReadDLQMessages(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.ReadDLQMessages(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+	t.Run("ReapplyEvents", func(t *testing.T) {
+		request := &types.ReapplyEventsRequest{
+			DomainName:        "domain",
+			WorkflowExecution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"},
+			ReapplyFilter:     &types.EventReapplyFilter{IncludeSignalNames: []string{"signal"}},
+		}
+
+		h.EXPECT().ReapplyEvents(ctx, request).Return(nil).Times(1)
+		resp, err := jh.ReapplyEvents(ctx, request)
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+
+		h.EXPECT().ReapplyEvents(ctx, request).Return(internalErr).Times(1)
+		resp, err = jh.ReapplyEvents(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+}
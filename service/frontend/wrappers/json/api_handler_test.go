@@ -0,0 +1,132 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package json
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	jsonclient "github.com/uber/cadence/client/wrappers/json"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+	"github.com/uber/cadence/service/frontend/api"
+)
+
+func TestAPIJSONHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	h := api.NewMockHandler(ctrl)
+	jh := NewAPIHandler(h)
+	ctx := context.Background()
+	internalErr := &types.InternalServiceError{Message: "test"}
+	execution := &types.WorkflowExecution{WorkflowID: "wid"}
+
+	t.Run("DescribeAsyncRequest", func(t *testing.T) {
+		request := &types.DescribeAsyncRequestRequest{Domain: "domain", RequestID: "request-id"}
+		response := &types.DescribeAsyncRequestResponse{Status: "Completed", WorkflowExecution: execution}
+
+		h.EXPECT().DescribeAsyncRequest(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.DescribeAsyncRequest(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().DescribeAsyncRequest(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.DescribeAsyncRequest(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+
+	t.Run("RequestCancelWorkflowExecutionAsync", func(t *testing.T) {
+		request := &types.RequestCancelWorkflowExecutionAsyncRequest{
+			RequestCancelWorkflowExecutionRequest: &types.RequestCancelWorkflowExecutionRequest{Domain: "domain", WorkflowExecution: execution},
+		}
+		response := &types.RequestCancelWorkflowExecutionAsyncResponse{RequestID: "request-id"}
+
+		h.EXPECT().RequestCancelWorkflowExecutionAsync(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.RequestCancelWorkflowExecutionAsync(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().RequestCancelWorkflowExecutionAsync(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.RequestCancelWorkflowExecutionAsync(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+
+	t.Run("ResetWorkflowExecution", func(t *testing.T) {
+		request := &types.ResetWorkflowExecutionRequest{
+			Domain:            "domain",
+			WorkflowExecution: execution,
+			ResetType:         types.ResetTypeLastDecisionCompleted.Ptr(),
+			DryRun:            true,
+		}
+		response := &types.ResetWorkflowExecutionResponse{DryRunResult: &types.ResetWorkflowDryRunResult{BaseRunID: "rid", DecisionFinishEventID: 4}}
+
+		h.EXPECT().ResetWorkflowExecution(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.ResetWorkflowExecution(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().ResetWorkflowExecution(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.ResetWorkflowExecution(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+
+	t.Run("SignalWorkflowExecutionAsync", func(t *testing.T) {
+		request := &types.SignalWorkflowExecutionAsyncRequest{
+			SignalWorkflowExecutionRequest: &types.SignalWorkflowExecutionRequest{Domain: "domain", WorkflowExecution: execution, SignalName: "signal", Input: []byte("input")},
+		}
+		response := &types.SignalWorkflowExecutionAsyncResponse{RequestID: "request-id"}
+
+		h.EXPECT().SignalWorkflowExecutionAsync(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.SignalWorkflowExecutionAsync(ctx, jsonclient.NewSignalWorkflowExecutionAsyncRequest(request))
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().SignalWorkflowExecutionAsync(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.SignalWorkflowExecutionAsync(ctx, jsonclient.NewSignalWorkflowExecutionAsyncRequest(request))
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+
+	t.Run("TerminateWorkflowExecutionAsync", func(t *testing.T) {
+		request := &types.TerminateWorkflowExecutionAsyncRequest{
+			TerminateWorkflowExecutionRequest: &types.TerminateWorkflowExecutionRequest{Domain: "domain", WorkflowExecution: execution},
+		}
+		response := &types.TerminateWorkflowExecutionAsyncResponse{RequestID: "request-id"}
+
+		h.EXPECT().TerminateWorkflowExecutionAsync(ctx, request).Return(response, nil).Times(1)
+		resp, err := jh.TerminateWorkflowExecutionAsync(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, response, resp)
+
+		h.EXPECT().TerminateWorkflowExecutionAsync(ctx, request).Return(nil, internalErr).Times(1)
+		resp, err = jh.TerminateWorkflowExecutionAsync(ctx, request)
+		assert.Nil(t, resp)
+		assert.Equal(t, internalErr, proto.ToError(err))
+	})
+}
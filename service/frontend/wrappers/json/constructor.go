@@ -0,0 +1,127 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package json serves the frontend and admin APIs that cadence-idl does not define yet
+// as JSON encoded yarpc procedures of the internal types. Clients are in client/wrappers/json.
+package json
+
+import (
+	"context"
+
+	"go.uber.org/yarpc"
+	yarpcjson "go.uber.org/yarpc/encoding/json"
+
+	jsonclient "github.com/uber/cadence/client/wrappers/json"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+	"github.com/uber/cadence/service/frontend/admin"
+	"github.com/uber/cadence/service/frontend/api"
+)
+
+type (
+	AdminHandler struct {
+		h admin.Handler
+	}
+
+	APIHandler struct {
+		h api.Handler
+	}
+)
+
+func NewAdminHandler(h admin.Handler) AdminHandler {
+	return AdminHandler{h}
+}
+
+func (j AdminHandler) Register(dispatcher *yarpc.Dispatcher) {
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.AdminGetReplicationStatusProcedure, j.GetReplicationStatus))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.AdminImportWorkflowExecutionProcedure, j.ImportWorkflowExecution))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.AdminReadDLQMessagesProcedure, j.ReadDLQMessages))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.AdminReapplyEventsProcedure, j.ReapplyEvents))
+}
+
+func (j AdminHandler) GetReplicationStatus(ctx context.Context, request *types.GetReplicationStatusRequest) (*types.GetReplicationStatusResponse, error) {
+	response, err := j.h.GetReplicationStatus(ctx, request)
+	return response, fromError(err)
+}
+
+func (j AdminHandler) ImportWorkflowExecution(ctx context.Context, request *types.ImportWorkflowExecutionRequest) (*types.ImportWorkflowExecutionResponse, error) {
+	response, err := j.h.ImportWorkflowExecution(ctx, request)
+	return response, fromError(err)
+}
+
+func (j AdminHandler) ReadDLQMessages(ctx context.Context, request *types.ReadDLQMessagesRequest) (*types.ReadDLQMessagesResponse, error) {
+	response, err := j.h.ReadDLQMessages(ctx, request)
+	return response, fromError(err)
+}
+
+// ReapplyEvents has no response, yarpc JSON procedures still have to return a struct
+func (j AdminHandler) ReapplyEvents(ctx context.Context, request *types.ReapplyEventsRequest) (*struct{}, error) {
+	if err := j.h.ReapplyEvents(ctx, request); err != nil {
+		return nil, fromError(err)
+	}
+	return &struct{}{}, nil
+}
+
+func NewAPIHandler(h api.Handler) APIHandler {
+	return APIHandler{h}
+}
+
+func (j APIHandler) Register(dispatcher *yarpc.Dispatcher) {
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.APIDescribeAsyncRequestProcedure, j.DescribeAsyncRequest))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.APIRequestCancelWorkflowExecutionAsyncProcedure, j.RequestCancelWorkflowExecutionAsync))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.APIResetWorkflowExecutionProcedure, j.ResetWorkflowExecution))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.APISignalWorkflowExecutionAsyncProcedure, j.SignalWorkflowExecutionAsync))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.APITerminateWorkflowExecutionAsyncProcedure, j.TerminateWorkflowExecutionAsync))
+}
+
+func (j APIHandler) DescribeAsyncRequest(ctx context.Context, request *types.DescribeAsyncRequestRequest) (*types.DescribeAsyncRequestResponse, error) {
+	response, err := j.h.DescribeAsyncRequest(ctx, request)
+	return response, fromError(err)
+}
+
+func (j APIHandler) RequestCancelWorkflowExecutionAsync(ctx context.Context, request *types.RequestCancelWorkflowExecutionAsyncRequest) (*types.RequestCancelWorkflowExecutionAsyncResponse, error) {
+	response, err := j.h.RequestCancelWorkflowExecutionAsync(ctx, request)
+	return response, fromError(err)
+}
+
+func (j APIHandler) ResetWorkflowExecution(ctx context.Context, request *types.ResetWorkflowExecutionRequest) (*types.ResetWorkflowExecutionResponse, error) {
+	response, err := j.h.ResetWorkflowExecution(ctx, request)
+	return response, fromError(err)
+}
+
+func (j APIHandler) SignalWorkflowExecutionAsync(ctx context.Context, request *jsonclient.SignalWorkflowExecutionAsyncRequest) (*types.SignalWorkflowExecutionAsyncResponse, error) {
+	response, err := j.h.SignalWorkflowExecutionAsync(ctx, request.ToInternal())
+	return response, fromError(err)
+}
+
+func (j APIHandler) TerminateWorkflowExecutionAsync(ctx context.Context, request *types.TerminateWorkflowExecutionAsyncRequest) (*types.TerminateWorkflowExecutionAsyncResponse, error) {
+	response, err := j.h.TerminateWorkflowExecutionAsync(ctx, request)
+	return response, fromError(err)
+}
+
+// fromError maps errors to yarpc statuses the same way as for gRPC, proto.ToError maps them back on the client
+func fromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return proto.FromError(err)
+}
@@ -130,6 +130,12 @@ func toCountWorkflowExecutionsRequestTags(req *types.CountWorkflowExecutionsRequ
 	}
 }
 
+func toDescribeAsyncRequestRequestTags(req *types.DescribeAsyncRequestRequest) []tag.Tag {
+	return []tag.Tag{
+		tag.WorkflowDomainName(req.GetDomain()),
+	}
+}
+
 func toDescribeTaskListRequestTags(req *types.DescribeTaskListRequest) []tag.Tag {
 	return []tag.Tag{
 		tag.WorkflowDomainName(req.GetDomain()),
@@ -249,6 +255,14 @@ func toRequestCancelWorkflowExecutionRequestTags(req *types.RequestCancelWorkflo
 	}
 }
 
+func toRequestCancelWorkflowExecutionAsyncRequestTags(req *types.RequestCancelWorkflowExecutionAsyncRequest) []tag.Tag {
+	return []tag.Tag{
+		tag.WorkflowDomainName(req.GetDomain()),
+		tag.WorkflowID(req.GetWorkflowExecution().GetWorkflowID()),
+		tag.WorkflowRunID(req.GetWorkflowExecution().GetRunID()),
+	}
+}
+
 func toResetStickyTaskListRequestTags(req *types.ResetStickyTaskListRequest) []tag.Tag {
 	return []tag.Tag{
 		tag.WorkflowDomainName(req.GetDomain()),
@@ -324,6 +338,15 @@ func toSignalWorkflowExecutionRequestTags(req *types.SignalWorkflowExecutionRequ
 	}
 }
 
+func toSignalWorkflowExecutionAsyncRequestTags(req *types.SignalWorkflowExecutionAsyncRequest) []tag.Tag {
+	return []tag.Tag{
+		tag.WorkflowDomainName(req.GetDomain()),
+		tag.WorkflowID(req.GetWorkflowExecution().GetWorkflowID()),
+		tag.WorkflowRunID(req.GetWorkflowExecution().GetRunID()),
+		tag.WorkflowSignalName(req.GetSignalName()),
+	}
+}
+
 func toStartWorkflowExecutionRequestTags(req *types.StartWorkflowExecutionRequest) []tag.Tag {
 	return []tag.Tag{
 		tag.WorkflowDomainName(req.GetDomain()),
@@ -350,6 +373,14 @@ func toTerminateWorkflowExecutionRequestTags(req *types.TerminateWorkflowExecuti
 	}
 }
 
+func toTerminateWorkflowExecutionAsyncRequestTags(req *types.TerminateWorkflowExecutionAsyncRequest) []tag.Tag {
+	return []tag.Tag{
+		tag.WorkflowDomainName(req.GetDomain()),
+		tag.WorkflowID(req.GetWorkflowExecution().GetWorkflowID()),
+		tag.WorkflowRunID(req.GetWorkflowExecution().GetRunID()),
+	}
+}
+
 func toScanWorkflowExecutionsRequestTags(req *types.ListWorkflowExecutionsRequest) []tag.Tag {
 	return []tag.Tag{
 		tag.WorkflowDomainName(req.GetDomain()),
@@ -0,0 +1,87 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/tracing"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/frontend/admin"
+	"github.com/uber/cadence/service/frontend/api"
+)
+
+func TestAPIHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctrl := gomock.NewController(t)
+	mockHandler := api.NewMockHandler(ctrl)
+	handler := NewAPIHandler(mockHandler)
+
+	request := &types.StartWorkflowExecutionRequest{Domain: "test-domain", WorkflowID: "test-workflow"}
+	mockHandler.EXPECT().StartWorkflowExecution(gomock.Any(), request).Return(&types.StartWorkflowExecutionResponse{RunID: "test-run"}, nil)
+	resp, err := handler.StartWorkflowExecution(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "test-run", resp.RunID)
+
+	mockHandler.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(nil, errors.New("test error"))
+	_, err = handler.DescribeDomain(context.Background(), &types.DescribeDomainRequest{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "Frontend.StartWorkflowExecution", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), tracing.DomainKey.String("test-domain"))
+	assert.Contains(t, spans[0].Attributes(), tracing.WorkflowIDKey.String("test-workflow"))
+	assert.Equal(t, "Frontend.DescribeDomain", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestAdminHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctrl := gomock.NewController(t)
+	mockHandler := admin.NewMockHandler(ctrl)
+	handler := NewAdminHandler(mockHandler)
+
+	mockHandler.EXPECT().CloseShard(gomock.Any(), &types.CloseShardRequest{ShardID: 1}).Return(nil)
+	require.NoError(t, handler.CloseShard(context.Background(), &types.CloseShardRequest{ShardID: 1}))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Admin.CloseShard", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), tracing.ShardIDKey.Int(1))
+}
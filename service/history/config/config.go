@@ -227,6 +227,7 @@ type Config struct {
 	HistorySizeLimitWarn             dynamicproperties.IntPropertyFnWithDomainFilter
 	HistoryCountLimitError           dynamicproperties.IntPropertyFnWithDomainFilter
 	HistoryCountLimitWarn            dynamicproperties.IntPropertyFnWithDomainFilter
+	LargePayloadOffloadThreshold     dynamicproperties.IntPropertyFnWithDomainFilter
 	PendingActivitiesCountLimitError dynamicproperties.IntPropertyFn
 	PendingActivitiesCountLimitWarn  dynamicproperties.IntPropertyFn
 	PendingActivityValidationEnabled dynamicproperties.BoolPropertyFn
@@ -271,6 +272,12 @@ type Config struct {
 	ReplicationTaskProcessorStartWaitJitterCoefficient dynamicproperties.FloatPropertyFnWithShardIDFilter
 	ReplicationTaskProcessorHostQPS                    dynamicproperties.FloatPropertyFn
 	ReplicationTaskProcessorShardQPS                   dynamicproperties.FloatPropertyFn
+	EnableReplicationDLQRedrive                        dynamicproperties.BoolPropertyFnWithShardIDFilter
+	ReplicationDLQRedriveInterval                      dynamicproperties.DurationPropertyFnWithShardIDFilter
+	ReplicationDLQRedriveBatchSize                     dynamicproperties.IntPropertyFnWithShardIDFilter
+	ReplicationDLQRedriveMaxAttempts                   dynamicproperties.IntPropertyFnWithShardIDFilter
+	ReplicationDLQRedriveInitialBackoff                dynamicproperties.DurationPropertyFnWithShardIDFilter
+	ReplicationDLQRedriveMaxBackoff                    dynamicproperties.DurationPropertyFnWithShardIDFilter
 	ReplicationTaskGenerationQPS                       dynamicproperties.FloatPropertyFn
 	EnableReplicationTaskGeneration                    dynamicproperties.BoolPropertyFnWithDomainIDAndWorkflowIDFilter
 	EnableRecordWorkflowExecutionUninitialized         dynamicproperties.BoolPropertyFnWithDomainFilter
@@ -330,6 +337,13 @@ type Config struct {
 	GlobalRatelimiterUpdateInterval dynamicproperties.DurationPropertyFn
 	GlobalRatelimiterDecayAfter     dynamicproperties.DurationPropertyFn
 	GlobalRatelimiterGCAfter        dynamicproperties.DurationPropertyFn
+	// aggregator-side weighting algorithm selection
+	GlobalRatelimiterAlgorithm         dynamicproperties.StringPropertyWithRatelimitKeyFilter
+	GlobalRatelimiterShadowAlgorithm   dynamicproperties.StringPropertyWithRatelimitKeyFilter
+	GlobalRatelimiterBurstCreditWindow dynamicproperties.DurationPropertyFn
+	// limiter-side global ratelimiter modes, for task scheduling and persistence
+	GlobalRatelimiterKeyMode         dynamicproperties.StringPropertyWithRatelimitKeyFilter
+	PersistenceGlobalRatelimiterMode dynamicproperties.StringPropertyWithRatelimitKeyFilter
 
 	// HostName for machine running the service
 	HostName string
@@ -501,6 +515,7 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 		HistorySizeLimitWarn:             dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistorySizeLimitWarn),
 		HistoryCountLimitError:           dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitError),
 		HistoryCountLimitWarn:            dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitWarn),
+		LargePayloadOffloadThreshold:     dc.GetIntPropertyFilteredByDomain(dynamicproperties.LargePayloadOffloadThreshold),
 		PendingActivitiesCountLimitError: dc.GetIntProperty(dynamicproperties.PendingActivitiesCountLimitError),
 		PendingActivitiesCountLimitWarn:  dc.GetIntProperty(dynamicproperties.PendingActivitiesCountLimitWarn),
 		PendingActivityValidationEnabled: dc.GetBoolProperty(dynamicproperties.EnablePendingActivityValidation),
@@ -538,6 +553,12 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 		ReplicationTaskProcessorStartWaitJitterCoefficient: dc.GetFloat64PropertyFilteredByShardID(dynamicproperties.ReplicationTaskProcessorStartWaitJitterCoefficient),
 		ReplicationTaskProcessorHostQPS:                    dc.GetFloat64Property(dynamicproperties.ReplicationTaskProcessorHostQPS),
 		ReplicationTaskProcessorShardQPS:                   dc.GetFloat64Property(dynamicproperties.ReplicationTaskProcessorShardQPS),
+		EnableReplicationDLQRedrive:                        dc.GetBoolPropertyFilteredByShardID(dynamicproperties.EnableReplicationDLQRedrive),
+		ReplicationDLQRedriveInterval:                      dc.GetDurationPropertyFilteredByShardID(dynamicproperties.ReplicationDLQRedriveInterval),
+		ReplicationDLQRedriveBatchSize:                     dc.GetIntPropertyFilteredByShardID(dynamicproperties.ReplicationDLQRedriveBatchSize),
+		ReplicationDLQRedriveMaxAttempts:                   dc.GetIntPropertyFilteredByShardID(dynamicproperties.ReplicationDLQRedriveMaxAttempts),
+		ReplicationDLQRedriveInitialBackoff:                dc.GetDurationPropertyFilteredByShardID(dynamicproperties.ReplicationDLQRedriveInitialBackoff),
+		ReplicationDLQRedriveMaxBackoff:                    dc.GetDurationPropertyFilteredByShardID(dynamicproperties.ReplicationDLQRedriveMaxBackoff),
 		ReplicationTaskGenerationQPS:                       dc.GetFloat64Property(dynamicproperties.ReplicationTaskGenerationQPS),
 		EnableReplicationTaskGeneration:                    dc.GetBoolPropertyFilteredByDomainIDAndWorkflowID(dynamicproperties.EnableReplicationTaskGeneration),
 		EnableRecordWorkflowExecutionUninitialized:         dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableRecordWorkflowExecutionUninitialized),
@@ -579,10 +600,15 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 		EnableStrongIdempotency:            dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableStrongIdempotency),
 		EnableStrongIdempotencySanityCheck: dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableStrongIdempotencySanityCheck),
 
-		GlobalRatelimiterNewDataWeight:  dc.GetFloat64Property(dynamicproperties.HistoryGlobalRatelimiterNewDataWeight),
-		GlobalRatelimiterUpdateInterval: dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
-		GlobalRatelimiterDecayAfter:     dc.GetDurationProperty(dynamicproperties.HistoryGlobalRatelimiterDecayAfter),
-		GlobalRatelimiterGCAfter:        dc.GetDurationProperty(dynamicproperties.HistoryGlobalRatelimiterGCAfter),
+		GlobalRatelimiterNewDataWeight:     dc.GetFloat64Property(dynamicproperties.HistoryGlobalRatelimiterNewDataWeight),
+		GlobalRatelimiterUpdateInterval:    dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
+		GlobalRatelimiterDecayAfter:        dc.GetDurationProperty(dynamicproperties.HistoryGlobalRatelimiterDecayAfter),
+		GlobalRatelimiterGCAfter:           dc.GetDurationProperty(dynamicproperties.HistoryGlobalRatelimiterGCAfter),
+		GlobalRatelimiterAlgorithm:         dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.HistoryGlobalRatelimiterAlgorithm),
+		GlobalRatelimiterShadowAlgorithm:   dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.HistoryGlobalRatelimiterShadowAlgorithm),
+		GlobalRatelimiterBurstCreditWindow: dc.GetDurationProperty(dynamicproperties.HistoryGlobalRatelimiterBurstCreditWindow),
+		GlobalRatelimiterKeyMode:           dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.HistoryGlobalRatelimiterMode),
+		PersistenceGlobalRatelimiterMode:   dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.PersistenceGlobalRatelimiterMode),
 
 		HostName: hostname,
 	}
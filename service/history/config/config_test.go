@@ -193,6 +193,7 @@ func TestNewConfig(t *testing.T) {
 		"HistorySizeLimitWarn":                                 {dynamicproperties.HistorySizeLimitWarn, 73},
 		"HistoryCountLimitError":                               {dynamicproperties.HistoryCountLimitError, 74},
 		"HistoryCountLimitWarn":                                {dynamicproperties.HistoryCountLimitWarn, 75},
+		"LargePayloadOffloadThreshold":                         {dynamicproperties.LargePayloadOffloadThreshold, 100},
 		"PendingActivitiesCountLimitError":                     {dynamicproperties.PendingActivitiesCountLimitError, 76},
 		"PendingActivitiesCountLimitWarn":                      {dynamicproperties.PendingActivitiesCountLimitWarn, 77},
 		"PendingActivityValidationEnabled":                     {dynamicproperties.EnablePendingActivityValidation, true},
@@ -225,6 +226,12 @@ func TestNewConfig(t *testing.T) {
 		"ReplicationTaskProcessorStartWaitJitterCoefficient":   {dynamicproperties.ReplicationTaskProcessorStartWaitJitterCoefficient, 11.0},
 		"ReplicationTaskProcessorHostQPS":                      {dynamicproperties.ReplicationTaskProcessorHostQPS, 12.0},
 		"ReplicationTaskProcessorShardQPS":                     {dynamicproperties.ReplicationTaskProcessorShardQPS, 13.0},
+		"EnableReplicationDLQRedrive":                          {dynamicproperties.EnableReplicationDLQRedrive, true},
+		"ReplicationDLQRedriveInterval":                        {dynamicproperties.ReplicationDLQRedriveInterval, time.Second},
+		"ReplicationDLQRedriveBatchSize":                       {dynamicproperties.ReplicationDLQRedriveBatchSize, 101},
+		"ReplicationDLQRedriveMaxAttempts":                     {dynamicproperties.ReplicationDLQRedriveMaxAttempts, 102},
+		"ReplicationDLQRedriveInitialBackoff":                  {dynamicproperties.ReplicationDLQRedriveInitialBackoff, time.Second},
+		"ReplicationDLQRedriveMaxBackoff":                      {dynamicproperties.ReplicationDLQRedriveMaxBackoff, time.Second},
 		"ReplicationTaskGenerationQPS":                         {dynamicproperties.ReplicationTaskGenerationQPS, 14.0},
 		"EnableReplicationTaskGeneration":                      {dynamicproperties.EnableReplicationTaskGeneration, true},
 		"EnableRecordWorkflowExecutionUninitialized":           {dynamicproperties.EnableRecordWorkflowExecutionUninitialized, true},
@@ -260,6 +267,11 @@ func TestNewConfig(t *testing.T) {
 		"GlobalRatelimiterUpdateInterval":                      {dynamicproperties.GlobalRatelimiterUpdateInterval, time.Second},
 		"GlobalRatelimiterDecayAfter":                          {dynamicproperties.HistoryGlobalRatelimiterDecayAfter, time.Second},
 		"GlobalRatelimiterGCAfter":                             {dynamicproperties.HistoryGlobalRatelimiterGCAfter, time.Second},
+		"GlobalRatelimiterAlgorithm":                           {dynamicproperties.HistoryGlobalRatelimiterAlgorithm, "max-min-fair"},
+		"GlobalRatelimiterShadowAlgorithm":                     {dynamicproperties.HistoryGlobalRatelimiterShadowAlgorithm, "burst-credit"},
+		"GlobalRatelimiterBurstCreditWindow":                   {dynamicproperties.HistoryGlobalRatelimiterBurstCreditWindow, 5 * time.Second},
+		"GlobalRatelimiterKeyMode":                             {dynamicproperties.HistoryGlobalRatelimiterMode, "local"},
+		"PersistenceGlobalRatelimiterMode":                     {dynamicproperties.PersistenceGlobalRatelimiterMode, "global"},
 		"TaskSchedulerGlobalDomainRPS":                         {dynamicproperties.TaskSchedulerGlobalDomainRPS, 97},
 		"TaskSchedulerEnableRateLimiterShadowMode":             {dynamicproperties.TaskSchedulerEnableRateLimiterShadowMode, false},
 		"TaskSchedulerEnableRateLimiter":                       {dynamicproperties.TaskSchedulerEnableRateLimiter, true},
@@ -360,6 +372,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn("domain")
 		case dynamicproperties.BoolPropertyFnWithShardIDFilter:
 			return fn(0)
+		case dynamicproperties.StringPropertyWithRatelimitKeyFilter:
+			return fn("task-scheduler:domain")
 		case func() []string:
 			return fn()
 		default:
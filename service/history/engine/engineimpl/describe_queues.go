@@ -44,6 +44,22 @@ func (e *historyEngineImpl) DescribeTimerQueue(
 	return e.describeQueue(ctx, persistence.HistoryTaskCategoryTimer, clusterName)
 }
 
+// DescribeReplicationQueue returns the status of replication from the shard to the remote cluster
+func (e *historyEngineImpl) DescribeReplicationQueue(
+	ctx context.Context,
+	clusterName string,
+) (*types.ReplicationShardStatus, error) {
+	if _, ok := e.shard.GetClusterMetadata().GetRemoteClusterInfo()[clusterName]; !ok {
+		return nil, &types.BadRequestError{Message: fmt.Sprintf("Unknown remote cluster %v.", clusterName)}
+	}
+	status, err := e.replicationAckManager.Status(ctx, clusterName, e.shard.GetDomainCache().GetDomainName)
+	if err != nil {
+		return nil, err
+	}
+	status.ShardID = int32(e.shard.GetShardID())
+	return status, nil
+}
+
 func (e *historyEngineImpl) describeQueue(
 	ctx context.Context,
 	category persistence.HistoryTaskCategory,
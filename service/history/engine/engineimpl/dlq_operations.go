@@ -46,12 +46,20 @@ func (e *historyEngineImpl) ReadDLQMessages(
 	if err != nil {
 		return nil, err
 	}
-	return &types.ReadDLQMessagesResponse{
+	response := &types.ReadDLQMessagesResponse{
 		Type:                 request.GetType().Ptr(),
 		ReplicationTasks:     tasks,
 		ReplicationTasksInfo: taskInfo,
 		NextPageToken:        token,
-	}, nil
+	}
+	if request.GetIncludeRedriveStatus() {
+		taskIDs := make([]int64, 0, len(taskInfo))
+		for _, info := range taskInfo {
+			taskIDs = append(taskIDs, info.TaskID)
+		}
+		response.RedriveStatuses = e.replicationDLQHandler.GetRedriveStatuses(request.GetSourceCluster(), taskIDs)
+	}
+	return response, nil
 }
 
 func (e *historyEngineImpl) PurgeDLQMessages(
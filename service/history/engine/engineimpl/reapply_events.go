@@ -42,6 +42,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 	workflowID string,
 	runID string,
 	reapplyEvents []*types.HistoryEvent,
+	filter *types.EventReapplyFilter,
 ) error {
 
 	domainEntry, err := e.getActiveDomainByID(domainUUID)
@@ -147,6 +148,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 					ndc.EventsReapplicationResetWorkflowReason,
 					toReapplyEvents,
 					false,
+					filter,
 				); err != nil {
 					return nil, err
 				}
@@ -167,6 +169,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 				mutableState,
 				toReapplyEvents,
 				runID,
+				filter,
 			)
 			if err != nil {
 				e.logger.Error("failed to re-apply stale events", tag.Error(err))
@@ -44,6 +44,26 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	workflowID := request.WorkflowExecution.GetWorkflowID()
 	baseRunID := request.WorkflowExecution.GetRunID()
 
+	if request.ResetType != nil || request.ResetTimestamp != nil {
+		if request.GetDecisionFinishEventID() != 0 {
+			return nil, &types.BadRequestError{
+				Message: "Decision finish ID cannot be set together with reset type or reset timestamp.",
+			}
+		}
+		resolvedRunID, decisionFinishEventID, err := e.workflowResetter.ResolveResetPoint(ctx, domainID, workflowID, baseRunID, request)
+		if err != nil {
+			return nil, err
+		}
+		resolvedRequest := *request
+		resolvedRequest.WorkflowExecution = &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      resolvedRunID,
+		}
+		resolvedRequest.DecisionFinishEventID = decisionFinishEventID
+		request = &resolvedRequest
+		baseRunID = resolvedRunID
+	}
+
 	baseContext, baseReleaseFn, err := e.executionCache.GetOrCreateWorkflowExecution(
 		ctx,
 		domainID,
@@ -121,7 +141,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	}
 
 	// dedup by requestID
-	if currentMutableState.GetExecutionInfo().CreateRequestID == request.GetRequestID() {
+	if !request.GetDryRun() && currentMutableState.GetExecutionInfo().CreateRequestID == request.GetRequestID() {
 		e.logger.Info("Duplicated reset request",
 			tag.WorkflowID(workflowID),
 			tag.WorkflowRunID(currentRunID),
@@ -153,6 +173,26 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		baseCurrentBranchToken = baseCurrentVersionHistory.GetBranchToken()
 	}
 
+	if request.GetDryRun() {
+		result, err := e.workflowResetter.DryRunResetWorkflow(
+			ctx,
+			domainID,
+			workflowID,
+			baseRunID,
+			baseCurrentBranchToken,
+			baseRebuildLastEventID,
+			baseNextEventID,
+			request.GetSkipSignalReapply(),
+			request.GetReapplyFilter(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ResetWorkflowExecutionResponse{
+			DryRunResult: result,
+		}, nil
+	}
+
 	if err := e.workflowResetter.ResetWorkflow(
 		ctx,
 		domainID,
@@ -176,6 +216,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		request.GetReason(),
 		nil,
 		request.GetSkipSignalReapply(),
+		request.GetReapplyFilter(),
 	); err != nil {
 		if t, ok := persistence.AsDuplicateRequestError(err); ok {
 			if t.RequestType == persistence.WorkflowRequestTypeReset {
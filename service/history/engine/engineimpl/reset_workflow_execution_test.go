@@ -153,6 +153,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(nil).Times(1)
 				},
 			},
@@ -219,6 +220,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(nil).Times(1)
 				},
 			},
@@ -397,6 +399,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(nil).Times(1)
 				},
 			},
@@ -440,6 +443,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(&persistence.DuplicateRequestError{
 						RequestType: persistence.WorkflowRequestTypeReset,
 						RunID:       "errorID",
@@ -488,6 +492,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(&persistence.DuplicateRequestError{
 						RequestType: persistence.WorkflowRequestTypeStart,
 						RunID:       "errorID",
@@ -499,6 +504,119 @@ func TestResetWorkflowExecution(t *testing.T) {
 				RunID:       "errorID",
 			},
 		},
+		{
+			name: "Success using reset type",
+			request: func() *types.HistoryResetWorkflowExecutionRequest {
+				request := resetExecutionRequest(latestExecution, 0)
+				request.ResetRequest.ResetType = types.ResetTypeLastDecisionCompleted.Ptr()
+				return request
+			}(),
+			init: []InitFn{
+				withCurrentExecution(latestExecution),
+				withState(latestExecution, &persistence.WorkflowMutableState{
+					ExecutionInfo: &persistence.WorkflowExecutionInfo{
+						DomainID:    constants.TestDomainID,
+						WorkflowID:  constants.TestWorkflowID,
+						RunID:       latestRunID,
+						NextEventID: 100,
+						BranchToken: []byte("branch token"),
+					},
+					ReplicationState: &persistence.ReplicationState{
+						CurrentVersion: 1337,
+					},
+					ExecutionStats: &persistence.ExecutionStats{HistorySize: 1},
+				}),
+				func(t *testing.T, engine *testdata.EngineForTest) {
+					ctrl := gomock.NewController(t)
+					mockResetter := reset.NewMockWorkflowResetter(ctrl)
+					engine.Engine.(*historyEngineImpl).workflowResetter = mockResetter
+
+					mockResetter.EXPECT().ResolveResetPoint(
+						gomock.Any(), // Context
+						gomock.Eq(constants.TestDomainID),
+						gomock.Eq(constants.TestWorkflowID),
+						gomock.Eq(latestExecution.RunID),
+						gomock.Any(),
+					).Return(latestExecution.RunID, int64(100), nil).Times(1)
+					mockResetter.EXPECT().ResetWorkflow(
+						gomock.Any(), // Context
+						gomock.Eq(constants.TestDomainID),
+						gomock.Eq(constants.TestWorkflowID),
+						gomock.Eq(latestExecution.RunID),
+						gomock.Eq([]byte("branch token")),
+						gomock.Eq(int64(99)),   // resolved DecisionFinishEventID - 1
+						gomock.Eq(int64(1337)), // CurrentVersion
+						gomock.Eq(int64(100)),  // NextEventID
+						gomock.Any(),           // random uuid
+						gomock.Eq(testRequestID),
+						&workflowMatcher{latestExecution},
+						gomock.Eq(testRequestReason),
+						gomock.Nil(),
+						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
+					).Return(nil).Times(1)
+				},
+			},
+		},
+		{
+			name: "Reset type together with DecisionFinishEventId",
+			request: func() *types.HistoryResetWorkflowExecutionRequest {
+				request := resetExecutionRequest(latestExecution, 100)
+				request.ResetRequest.ResetType = types.ResetTypeLastDecisionCompleted.Ptr()
+				return request
+			}(),
+			expectedErr: &types.BadRequestError{Message: "Decision finish ID cannot be set together with reset type or reset timestamp."},
+		},
+		{
+			name: "Dry run",
+			request: func() *types.HistoryResetWorkflowExecutionRequest {
+				request := resetExecutionRequest(latestExecution, 100)
+				request.ResetRequest.DryRun = true
+				return request
+			}(),
+			init: []InitFn{
+				withCurrentExecution(latestExecution),
+				withState(latestExecution, &persistence.WorkflowMutableState{
+					ExecutionInfo: &persistence.WorkflowExecutionInfo{
+						DomainID:    constants.TestDomainID,
+						WorkflowID:  constants.TestWorkflowID,
+						RunID:       latestRunID,
+						NextEventID: 100,
+						BranchToken: []byte("branch token"),
+					},
+					ReplicationState: &persistence.ReplicationState{
+						CurrentVersion: 1337,
+					},
+					ExecutionStats: &persistence.ExecutionStats{HistorySize: 1},
+				}),
+				func(t *testing.T, engine *testdata.EngineForTest) {
+					ctrl := gomock.NewController(t)
+					mockResetter := reset.NewMockWorkflowResetter(ctrl)
+					engine.Engine.(*historyEngineImpl).workflowResetter = mockResetter
+
+					mockResetter.EXPECT().DryRunResetWorkflow(
+						gomock.Any(), // Context
+						gomock.Eq(constants.TestDomainID),
+						gomock.Eq(constants.TestWorkflowID),
+						gomock.Eq(latestExecution.RunID),
+						gomock.Eq([]byte("branch token")),
+						gomock.Eq(int64(99)),  // Request.DecisionFinishEventID - 1
+						gomock.Eq(int64(100)), // NextEventID
+						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
+					).Return(&types.ResetWorkflowDryRunResult{
+						BaseRunID:             latestExecution.RunID,
+						DecisionFinishEventID: 100,
+					}, nil).Times(1)
+				},
+			},
+			expected: &types.ResetWorkflowExecutionResponse{
+				DryRunResult: &types.ResetWorkflowDryRunResult{
+					BaseRunID:             latestExecution.RunID,
+					DecisionFinishEventID: 100,
+				},
+			},
+		},
 		{
 			name:    "Reset returns Err",
 			request: resetExecutionRequest(latestExecution, 100),
@@ -537,6 +655,7 @@ func TestResetWorkflowExecution(t *testing.T) {
 						gomock.Eq(testRequestReason),
 						gomock.Nil(),
 						gomock.Eq(testRequestSkipSignalReapply),
+						gomock.Nil(),
 					).Return(&types.BadRequestError{
 						Message: "didn't work",
 					}).Times(1)
@@ -559,7 +678,9 @@ func TestResetWorkflowExecution(t *testing.T) {
 			eft.Engine.Stop()
 
 			if testCase.expectedErr == nil {
-				if assert.NotNil(t, result) {
+				if testCase.expected != nil && testCase.expected.DryRunResult != nil {
+					assert.Equal(t, testCase.expected, result)
+				} else if assert.NotNil(t, result) {
 					assert.NotEmpty(t, result.RunID)
 				}
 				assert.NoError(t, err)
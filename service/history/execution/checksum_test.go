@@ -0,0 +1,65 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestVerifyWorkflowMutableStateChecksum(t *testing.T) {
+	state := &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			State:       persistence.WorkflowStateRunning,
+			NextEventID: 10,
+		},
+		VersionHistories: persistence.NewVersionHistories(persistence.NewVersionHistory([]byte{1}, []*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(9, 1),
+		})),
+		ActivityInfos: map[int64]*persistence.ActivityInfo{5: {ScheduleID: 5}},
+		TimerInfos:    map[string]*persistence.TimerInfo{"timer": {StartedID: 6}},
+	}
+	csum, err := checksum.GenerateCRC32(newChecksumPayload(
+		state.ExecutionInfo,
+		state.VersionHistories,
+		state.TimerInfos,
+		state.ActivityInfos,
+		state.ChildExecutionInfos,
+		state.SignalInfos,
+		state.RequestCancelInfos,
+	), mutableStateChecksumPayloadV1)
+	require.NoError(t, err)
+	state.Checksum = csum
+	assert.NoError(t, VerifyWorkflowMutableStateChecksum(state))
+
+	state.ActivityInfos[7] = &persistence.ActivityInfo{ScheduleID: 7}
+	assert.ErrorIs(t, VerifyWorkflowMutableStateChecksum(state), checksum.ErrMismatch)
+
+	state.Checksum.Version = mutableStateChecksumPayloadV1 + 1
+	assert.ErrorContains(t, VerifyWorkflowMutableStateChecksum(state), "invalid checksum payload version")
+}
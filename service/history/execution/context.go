@@ -1407,6 +1407,7 @@ func (c *contextImpl) ReapplyEvents(
 			workflowID,
 			runID,
 			reapplyEvents,
+			nil,
 		)
 	}
 
@@ -2873,7 +2873,7 @@ func TestReapplyEvents(t *testing.T) {
 					{
 						EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
 					},
-				}).Return(nil)
+				}, nil).Return(nil)
 			},
 			wantErr: false,
 		},
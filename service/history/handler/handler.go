@@ -44,6 +44,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/quotas/global/algorithm"
+	"github.com/uber/cadence/common/quotas/global/collection"
 	"github.com/uber/cadence/common/quotas/global/rpc"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/proto"
@@ -85,6 +86,9 @@ type (
 		workflowIDCache         workflowcache.WFCache
 		ratelimitAggregator     algorithm.RequestWeighted
 		queueFactories          []queue.Factory
+
+		// limiter-side global ratelimiter collection for queueTaskProcessor
+		taskRateLimiterCollection *collection.Collection
 	}
 )
 
@@ -152,13 +156,23 @@ func (h *handlerImpl) Start() {
 	if err != nil {
 		h.GetLogger().Fatal("Creating priority task processor failed", tag.Error(err))
 	}
-	taskRateLimiter := task.NewRateLimiter(
+	taskRateLimiter, taskRateLimiterCollection, err := task.NewGlobalRateLimiter(
 		h.GetLogger(),
 		h.GetMetricsClient(),
 		h.GetDomainCache(),
 		h.config,
 		h.controller,
+		h.GetRatelimiterAggregatorsClient(),
 	)
+	if err != nil {
+		h.GetLogger().Fatal("Creating task rate limiter failed", tag.Error(err))
+	}
+	startCtx, cancel := context.WithTimeout(context.Background(), time.Second) // should take nearly no time at all
+	defer cancel()
+	if err := taskRateLimiterCollection.OnStart(startCtx); err != nil {
+		h.GetLogger().Fatal("Starting task rate limiter global ratelimiter collection failed", tag.Error(err))
+	}
+	h.taskRateLimiterCollection = taskRateLimiterCollection
 	h.queueTaskProcessor = task.NewRateLimitedProcessor(taskProcessor, taskRateLimiter)
 	h.queueTaskProcessor.Start()
 
@@ -204,6 +218,14 @@ func (h *handlerImpl) Stop() {
 	h.queueTaskProcessor.Stop()
 	h.historyEventNotifier.Stop()
 	h.failoverCoordinator.Stop()
+
+	if h.taskRateLimiterCollection != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second) // should take nearly no time at all
+		defer cancel()
+		if err := h.taskRateLimiterCollection.OnStop(stopCtx); err != nil {
+			h.GetLogger().Error("failed to stop task rate limiter global ratelimiter collection", tag.Error(err))
+		}
+	}
 }
 
 // PrepareToStop starts graceful traffic drain in preparation for shutdown
@@ -844,6 +866,30 @@ func (h *handlerImpl) DescribeQueue(
 	return resp, nil
 }
 
+// DescribeReplicationQueue returns the status of replication from the shard to the remote cluster
+func (h *handlerImpl) DescribeReplicationQueue(
+	ctx context.Context,
+	request *types.DescribeReplicationQueueRequest,
+) (resp *types.ReplicationShardStatus, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryDescribeReplicationQueueScope)
+	defer sw.Stop()
+
+	engine, err := h.controller.GetEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return nil, h.error(err, scope, "", "", "")
+	}
+
+	resp, err = engine.DescribeReplicationQueue(ctx, request.GetClusterName())
+	if err != nil {
+		return nil, h.error(err, scope, "", "", "")
+	}
+	return resp, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *handlerImpl) DescribeMutableState(
 	ctx context.Context,
@@ -1847,6 +1893,7 @@ func (h *handlerImpl) ReapplyEvents(
 		execution.GetWorkflowID(),
 		execution.GetRunID(),
 		historyEvents,
+		request.GetRequest().GetReapplyFilter(),
 	); err != nil {
 		return h.error(err, scope, domainID, workflowID, runID)
 	}
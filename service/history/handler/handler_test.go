@@ -1412,6 +1412,61 @@ func (s *handlerSuite) TestDescribeQueue() {
 	}
 }
 
+func (s *handlerSuite) TestDescribeReplicationQueue() {
+	testInput := map[string]struct {
+		request       *types.DescribeReplicationQueueRequest
+		expectedError bool
+		mockFn        func()
+	}{
+		"getEngine error": {
+			request: &types.DescribeReplicationQueueRequest{
+				ShardID: 0,
+			},
+			expectedError: true,
+			mockFn: func() {
+				s.mockShardController.EXPECT().GetEngineForShard(0).Return(nil, errors.New("error")).Times(1)
+			},
+		},
+		"engine error": {
+			request: &types.DescribeReplicationQueueRequest{
+				ShardID:     0,
+				ClusterName: "unknown",
+			},
+			expectedError: true,
+			mockFn: func() {
+				s.mockShardController.EXPECT().GetEngineForShard(0).Return(s.mockEngine, nil).Times(1)
+				s.mockEngine.EXPECT().DescribeReplicationQueue(gomock.Any(), "unknown").Return(nil, &types.BadRequestError{}).Times(1)
+			},
+		},
+		"success": {
+			request: &types.DescribeReplicationQueueRequest{
+				ShardID:     0,
+				ClusterName: "standby",
+			},
+			expectedError: false,
+			mockFn: func() {
+				s.mockShardController.EXPECT().GetEngineForShard(0).Return(s.mockEngine, nil).Times(1)
+				s.mockEngine.EXPECT().DescribeReplicationQueue(gomock.Any(), "standby").Return(&types.ReplicationShardStatus{RemoteCluster: "standby"}, nil).Times(1)
+			},
+		},
+	}
+
+	for name, input := range testInput {
+		s.Run(name, func() {
+			input.mockFn()
+			resp, err := s.handler.DescribeReplicationQueue(context.Background(), input.request)
+			if input.expectedError {
+				s.Nil(resp)
+				s.Error(err)
+			} else {
+				s.NotNil(resp)
+				s.NoError(err)
+			}
+		})
+
+	}
+}
+
 func (s *handlerSuite) TestDescribeMutableState() {
 	validInput := &types.DescribeMutableStateRequest{
 		DomainUUID: testDomainID,
@@ -3096,7 +3151,7 @@ func (s *handlerSuite) TestReapplyEvents() {
 			expectedError: true,
 			mockFn: func() {
 				s.mockShardController.EXPECT().GetEngine(testWorkflowID).Return(s.mockEngine, nil).Times(1)
-				s.mockEngine.EXPECT().ReapplyEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error")).Times(1)
+				s.mockEngine.EXPECT().ReapplyEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error")).Times(1)
 			},
 		},
 		"success": {
@@ -3104,7 +3159,7 @@ func (s *handlerSuite) TestReapplyEvents() {
 			expectedError: false,
 			mockFn: func() {
 				s.mockShardController.EXPECT().GetEngine(testWorkflowID).Return(s.mockEngine, nil).Times(1)
-				s.mockEngine.EXPECT().ReapplyEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				s.mockEngine.EXPECT().ReapplyEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 		},
 	}
@@ -117,6 +117,21 @@ func (mr *MockHandlerMockRecorder) DescribeQueue(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueue", reflect.TypeOf((*MockHandler)(nil).DescribeQueue), arg0, arg1)
 }
 
+// DescribeReplicationQueue mocks base method.
+func (m *MockHandler) DescribeReplicationQueue(arg0 context.Context, arg1 *types.DescribeReplicationQueueRequest) (*types.ReplicationShardStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeReplicationQueue", arg0, arg1)
+	ret0, _ := ret[0].(*types.ReplicationShardStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReplicationQueue indicates an expected call of DescribeReplicationQueue.
+func (mr *MockHandlerMockRecorder) DescribeReplicationQueue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReplicationQueue", reflect.TypeOf((*MockHandler)(nil).DescribeReplicationQueue), arg0, arg1)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockHandler) DescribeWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDescribeWorkflowExecutionRequest) (*types.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
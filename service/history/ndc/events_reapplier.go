@@ -30,16 +30,19 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/execution"
+	"github.com/uber/cadence/service/history/reset"
 )
 
 type (
 	// EventsReapplier handles event re-application
 	EventsReapplier interface {
+		// ReapplyEvents reapplies the events passing the filter, a nil filter reapplies every eligible event
 		ReapplyEvents(
 			ctx ctx.Context,
 			msBuilder execution.MutableState,
 			historyEvents []*types.HistoryEvent,
 			runID string,
+			filter *types.EventReapplyFilter,
 		) ([]*types.HistoryEvent, error)
 	}
 
@@ -68,12 +71,17 @@ func (r *eventsReapplierImpl) ReapplyEvents(
 	msBuilder execution.MutableState,
 	historyEvents []*types.HistoryEvent,
 	runID string,
+	filter *types.EventReapplyFilter,
 ) ([]*types.HistoryEvent, error) {
 
 	var reappliedEvents []*types.HistoryEvent
 	for _, event := range historyEvents {
 		switch event.GetEventType() {
 		case types.EventTypeWorkflowExecutionSignaled:
+			if !reset.ShouldReapplyEvent(filter, event) {
+				r.metricsClient.IncCounter(metrics.HistoryReapplyEventsScope, metrics.EventReapplySkippedCount)
+				continue
+			}
 			dedupResource := definition.NewEventReappliedID(runID, event.ID, event.Version)
 			if msBuilder.IsResourceDuplicated(dedupResource) {
 				// skip already applied event
@@ -106,7 +106,7 @@ func (s *eventReapplicationSuite) TestReapplyEvents_AppliedEvent() {
 		{EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
 		event,
 	}
-	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID)
+	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID, nil)
 	s.NoError(err)
 	s.Equal(1, len(appliedEvent))
 }
@@ -130,7 +130,7 @@ func (s *eventReapplicationSuite) TestReapplyEvents_Noop() {
 		{EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
 		event,
 	}
-	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID)
+	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID, nil)
 	s.NoError(err)
 	s.Equal(0, len(appliedEvent))
 }
@@ -182,11 +182,61 @@ func (s *eventReapplicationSuite) TestReapplyEvents_PartialAppliedEvent() {
 		event1,
 		event2,
 	}
-	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID)
+	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID, nil)
 	s.NoError(err)
 	s.Equal(1, len(appliedEvent))
 }
 
+func (s *eventReapplicationSuite) TestReapplyEvents_FilteredEvent() {
+	runID := uuid.New()
+	workflowExecution := &persistence.WorkflowExecutionInfo{
+		DomainID: uuid.New(),
+	}
+	goodEvent := &types.HistoryEvent{
+		ID:        1,
+		EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+		WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+			Identity:   "test",
+			SignalName: "good-signal",
+			Input:      []byte{},
+		},
+	}
+	badEvent := &types.HistoryEvent{
+		ID:        2,
+		EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+		WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+			Identity:   "test",
+			SignalName: "bad-signal",
+			Input:      []byte{},
+		},
+	}
+	attr := goodEvent.WorkflowExecutionSignaledEventAttributes
+
+	msBuilderCurrent := execution.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().IsWorkflowExecutionRunning().Return(true)
+	msBuilderCurrent.EXPECT().GetExecutionInfo().Return(workflowExecution).AnyTimes()
+	msBuilderCurrent.EXPECT().AddWorkflowExecutionSignaled(
+		attr.GetSignalName(),
+		attr.GetInput(),
+		attr.GetIdentity(),
+		"",
+	).Return(goodEvent, nil).Times(1)
+	dedupResource := definition.NewEventReappliedID(runID, goodEvent.ID, goodEvent.Version)
+	msBuilderCurrent.EXPECT().IsResourceDuplicated(dedupResource).Return(false).Times(1)
+	msBuilderCurrent.EXPECT().UpdateDuplicatedResource(dedupResource).Times(1)
+	events := []*types.HistoryEvent{
+		{EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
+		goodEvent,
+		badEvent,
+	}
+	filter := &types.EventReapplyFilter{
+		ExcludeSignalNames: []string{"bad-signal"},
+	}
+	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID, filter)
+	s.NoError(err)
+	s.Equal([]*types.HistoryEvent{goodEvent}, appliedEvent)
+}
+
 func (s *eventReapplicationSuite) TestReapplyEvents_Error() {
 	runID := uuid.New()
 	workflowExecution := &persistence.WorkflowExecutionInfo{
@@ -220,7 +270,7 @@ func (s *eventReapplicationSuite) TestReapplyEvents_Error() {
 		{EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
 		event,
 	}
-	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID)
+	appliedEvent, err := s.reapplication.ReapplyEvents(context.Background(), msBuilderCurrent, events, runID, nil)
 	s.Error(err)
 	s.Equal(0, len(appliedEvent))
 }
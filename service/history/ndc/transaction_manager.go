@@ -312,6 +312,7 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 				targetWorkflow.GetMutableState(),
 				targetWorkflowEvents.Events,
 				targetWorkflow.GetMutableState().GetExecutionInfo().RunID,
+				nil,
 			); err != nil {
 				return 0, execution.TransactionPolicyActive, err
 			}
@@ -369,6 +370,7 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 			EventsReapplicationResetWorkflowReason,
 			targetWorkflowEvents.Events,
 			false,
+			nil,
 		); err != nil {
 			return 0, execution.TransactionPolicyActive, err
 		}
@@ -74,6 +74,11 @@ type (
 			pageSize int,
 			pageToken []byte,
 		) ([]byte, error)
+		// GetRedriveStatuses returns the re-drive status of the given messages whose automatic re-drive failed
+		GetRedriveStatuses(
+			sourceCluster string,
+			taskIDs []int64,
+		) []*types.ReplicationDLQRedriveStatus
 	}
 
 	dlqHandlerImpl struct {
@@ -82,11 +87,17 @@ type (
 		logger        log.Logger
 		metricsClient metrics.Client
 		done          chan struct{}
+		ctx           context.Context
+		cancelCtx     context.CancelFunc
 		status        int32
 		timeSource    clock.TimeSource
 
 		mu           sync.Mutex
 		latestCounts map[string]int64
+
+		redriveMu         sync.Mutex
+		redriveStates     map[string]map[int64]*dlqRedriveState
+		redriveReadLevels map[string]int64
 	}
 )
 
@@ -102,13 +113,18 @@ func NewDLQHandler(
 		panic("Failed to initialize replication DLQ handler due to nil task executors")
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return &dlqHandlerImpl{
-		shard:         shard,
-		taskExecutors: taskExecutors,
-		logger:        shard.GetLogger(),
-		metricsClient: shard.GetMetricsClient(),
-		done:          make(chan struct{}),
-		timeSource:    clock.NewRealTimeSource(),
+		shard:             shard,
+		taskExecutors:     taskExecutors,
+		logger:            shard.GetLogger(),
+		metricsClient:     shard.GetMetricsClient(),
+		done:              make(chan struct{}),
+		ctx:               ctx,
+		cancelCtx:         cancel,
+		timeSource:        clock.NewRealTimeSource(),
+		redriveStates:     make(map[string]map[int64]*dlqRedriveState),
+		redriveReadLevels: make(map[string]int64),
 	}
 }
 
@@ -119,6 +135,7 @@ func (r *dlqHandlerImpl) Start() {
 	}
 
 	go r.emitDLQSizeMetricsLoop()
+	go r.redriveLoop()
 	r.logger.Info("DLQ handler started.")
 }
 
@@ -130,6 +147,7 @@ func (r *dlqHandlerImpl) Stop() {
 
 	r.logger.Debug("DLQ handler shutting down.")
 	close(r.done)
+	r.cancelCtx()
 }
 
 func (r *dlqHandlerImpl) GetMessageCount(ctx context.Context, forceFetch bool) (map[string]int64, error) {
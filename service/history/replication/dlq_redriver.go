@@ -0,0 +1,417 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	dlqRedriveJitterCoefficient = 0.1
+	// dlqRedriveMaxPagesPerPass bounds the DLQ pages scanned by a single re-drive pass,
+	// the next pass continues from where the previous one stopped
+	dlqRedriveMaxPagesPerPass = 10
+
+	redriveErrorBadRequest        = "bad-request"
+	redriveErrorDataInconsistency = "data-inconsistency"
+	redriveErrorEntityNotExists   = "entity-not-exists"
+	redriveErrorDomainNotActive   = "domain-not-active"
+	redriveErrorServiceBusy       = "service-busy"
+	redriveErrorTimeout           = "timeout"
+	redriveErrorInternal          = "internal"
+	redriveErrorUnknown           = "unknown"
+)
+
+type (
+	// dlqRedriveState is the in-memory re-drive bookkeeping of a single DLQ message.
+	// It is not persisted, so attempts start over when the shard moves to another host.
+	// Operators read it along with the DLQ messages, see GetRedriveStatuses.
+	dlqRedriveState struct {
+		attempts    int
+		nextAttempt time.Time
+		errorClass  string
+		lastError   error
+		quarantined bool
+	}
+)
+
+// classifyRedriveError returns the error class used for metrics and logs
+// and whether re-driving the message again may succeed
+func classifyRedriveError(err error) (string, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return redriveErrorTimeout, true
+	}
+
+	switch err := err.(type) {
+	case *types.BadRequestError:
+		return redriveErrorBadRequest, false
+	case *types.InternalDataInconsistencyError:
+		return redriveErrorDataInconsistency, false
+	case *types.EntityNotExistsError:
+		return redriveErrorEntityNotExists, true
+	case *types.DomainNotActiveError:
+		return redriveErrorDomainNotActive, true
+	case *types.ServiceBusyError, *types.LimitExceededError:
+		return redriveErrorServiceBusy, true
+	case *persistence.TimeoutError:
+		return redriveErrorTimeout, true
+	case *types.InternalServiceError:
+		return redriveErrorInternal, true
+	case *yarpcerrors.Status:
+		if err.Code() == yarpcerrors.CodeDeadlineExceeded {
+			return redriveErrorTimeout, true
+		}
+	}
+	return redriveErrorUnknown, true
+}
+
+func (r *dlqHandlerImpl) redriveLoop() {
+	getInterval := func() time.Duration {
+		return backoff.JitDuration(
+			r.shard.GetConfig().ReplicationDLQRedriveInterval(r.shard.GetShardID()),
+			dlqRedriveJitterCoefficient,
+		)
+	}
+
+	timer := r.timeSource.NewTimer(getInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.Chan():
+			if r.shard.GetConfig().EnableReplicationDLQRedrive(r.shard.GetShardID()) {
+				r.redrivePass()
+			}
+			timer.Reset(getInterval())
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// redrivePass runs a single re-drive pass, which is canceled when the handler stops
+// and must finish before the next pass is due
+func (r *dlqHandlerImpl) redrivePass() {
+	ctx, cancel := context.WithTimeout(r.ctx, r.shard.GetConfig().ReplicationDLQRedriveInterval(r.shard.GetShardID()))
+	defer cancel()
+
+	r.redrive(ctx)
+}
+
+func (r *dlqHandlerImpl) redrive(ctx context.Context) {
+	for sourceCluster := range r.taskExecutors {
+		if err := r.redriveMessages(ctx, sourceCluster); err != nil {
+			r.logger.Warn("Failed to re-drive replication DLQ messages.", tag.SourceCluster(sourceCluster), tag.Error(err))
+		}
+	}
+
+	r.redriveMu.Lock()
+	quarantined := 0
+	for _, states := range r.redriveStates {
+		for _, state := range states {
+			if state.quarantined {
+				quarantined++
+			}
+		}
+	}
+	r.redriveMu.Unlock()
+
+	r.metricsClient.Scope(
+		metrics.ReplicationDLQStatsScope,
+		metrics.InstanceTag(strconv.Itoa(r.shard.GetShardID())),
+	).UpdateGauge(metrics.ReplicationDLQQuarantineSize, float64(quarantined))
+}
+
+// redriveMessages retries the DLQ messages of the source cluster whose backoff has elapsed.
+// Messages that are replayed successfully are removed from the DLQ, quarantined messages stay
+// in the DLQ for manual inspection and are no longer retried automatically.
+// Each call scans the DLQ from where the previous call stopped, and starts over once it reaches the end.
+func (r *dlqHandlerImpl) redriveMessages(ctx context.Context, sourceCluster string) error {
+	executor, ok := r.taskExecutors[sourceCluster]
+	if !ok {
+		return errInvalidCluster
+	}
+
+	shardID := r.shard.GetShardID()
+	batchSize := r.shard.GetConfig().ReplicationDLQRedriveBatchSize(shardID)
+	now := r.timeSource.Now()
+
+	readLevel := r.redriveReadLevel(sourceCluster)
+	lastTaskID := readLevel - 1
+	reachedEnd := false
+	var dueTasks []*types.ReplicationTaskInfo
+	seen := make(map[int64]struct{})
+	var pageToken []byte
+scan:
+	for page := 0; page < dlqRedriveMaxPagesPerPass; page++ {
+		resp, err := r.shard.GetExecutionManager().GetReplicationTasksFromDLQ(
+			ctx,
+			&persistence.GetReplicationTasksFromDLQRequest{
+				SourceClusterName: sourceCluster,
+				ReadLevel:         readLevel,
+				MaxReadLevel:      math.MaxInt64,
+				BatchSize:         batchSize,
+				NextPageToken:     pageToken,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range resp.Tasks {
+			taskInfo, err := task.ToInternalReplicationTaskInfo()
+			if err != nil {
+				return err
+			}
+			seen[taskInfo.TaskID] = struct{}{}
+			lastTaskID = taskInfo.TaskID
+			if r.isRedriveDue(sourceCluster, taskInfo.TaskID, now) {
+				dueTasks = append(dueTasks, taskInfo)
+				if len(dueTasks) >= batchSize {
+					break scan
+				}
+			}
+		}
+
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			reachedEnd = true
+			break
+		}
+	}
+
+	// drop the state of messages removed from the scanned range by merge or purge
+	nextReadLevel := lastTaskID + 1
+	if reachedEnd {
+		r.pruneRedriveStates(sourceCluster, seen, readLevel, math.MaxInt64)
+		nextReadLevel = defaultBeginningMessageID + 1
+	} else {
+		r.pruneRedriveStates(sourceCluster, seen, readLevel, lastTaskID)
+	}
+	if len(dueTasks) == 0 {
+		r.setRedriveReadLevel(sourceCluster, nextReadLevel)
+		return nil
+	}
+
+	remoteAdminClient, err := r.shard.GetService().GetClientBean().GetRemoteAdminClient(sourceCluster)
+	if err != nil {
+		return err
+	}
+	response, err := remoteAdminClient.GetDLQReplicationMessages(
+		ctx,
+		&types.GetDLQReplicationMessagesRequest{
+			TaskInfos: dueTasks,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	replicationTasks := make(map[int64]*types.ReplicationTask, len(response.ReplicationTasks))
+	for _, task := range response.ReplicationTasks {
+		replicationTasks[task.SourceTaskID] = task
+	}
+
+	for _, taskInfo := range dueTasks {
+		scope := r.redriveMetricsScope(sourceCluster, taskInfo.DomainID)
+		scope.IncCounter(metrics.ReplicationDLQRedriveAttempts)
+
+		// same as merge: if the task no longer exists in the source cluster there is nothing to replay
+		if task, ok := replicationTasks[taskInfo.TaskID]; ok {
+			if _, err := executor.execute(task, true); err != nil {
+				scope.IncCounter(metrics.ReplicationDLQRedriveFailures)
+				r.recordRedriveFailure(sourceCluster, taskInfo, err)
+				continue
+			}
+		}
+
+		if err := r.shard.GetExecutionManager().DeleteReplicationTaskFromDLQ(
+			ctx,
+			&persistence.DeleteReplicationTaskFromDLQRequest{
+				SourceClusterName: sourceCluster,
+				TaskID:            taskInfo.TaskID,
+			},
+		); err != nil {
+			return err
+		}
+		scope.IncCounter(metrics.ReplicationDLQRedriveSuccess)
+		r.clearRedriveState(sourceCluster, taskInfo.TaskID)
+	}
+	// the checkpoint only moves once the due messages were handled, failed passes retry the same range
+	r.setRedriveReadLevel(sourceCluster, nextReadLevel)
+	return nil
+}
+
+func (r *dlqHandlerImpl) redriveReadLevel(sourceCluster string) int64 {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	if readLevel, ok := r.redriveReadLevels[sourceCluster]; ok {
+		return readLevel
+	}
+	return defaultBeginningMessageID + 1
+}
+
+func (r *dlqHandlerImpl) setRedriveReadLevel(sourceCluster string, readLevel int64) {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	r.redriveReadLevels[sourceCluster] = readLevel
+}
+
+func (r *dlqHandlerImpl) isRedriveDue(sourceCluster string, taskID int64, now time.Time) bool {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	state, ok := r.redriveStates[sourceCluster][taskID]
+	if !ok {
+		return true
+	}
+	return !state.quarantined && !now.Before(state.nextAttempt)
+}
+
+func (r *dlqHandlerImpl) recordRedriveFailure(sourceCluster string, taskInfo *types.ReplicationTaskInfo, err error) {
+	shardID := r.shard.GetShardID()
+	errorClass, retryable := classifyRedriveError(err)
+
+	r.redriveMu.Lock()
+	states, ok := r.redriveStates[sourceCluster]
+	if !ok {
+		states = make(map[int64]*dlqRedriveState)
+		r.redriveStates[sourceCluster] = states
+	}
+	state, ok := states[taskInfo.TaskID]
+	if !ok {
+		state = &dlqRedriveState{}
+		states[taskInfo.TaskID] = state
+	}
+	state.attempts++
+	state.errorClass = errorClass
+	state.lastError = err
+	if !retryable || state.attempts >= r.shard.GetConfig().ReplicationDLQRedriveMaxAttempts(shardID) {
+		state.quarantined = true
+	} else {
+		policy := backoff.NewExponentialRetryPolicy(r.shard.GetConfig().ReplicationDLQRedriveInitialBackoff(shardID))
+		policy.SetMaximumInterval(r.shard.GetConfig().ReplicationDLQRedriveMaxBackoff(shardID))
+		policy.SetExpirationInterval(backoff.NoInterval)
+		delay := policy.ComputeNextDelay(0, state.attempts-1)
+		if delay < 0 {
+			delay = 0
+		}
+		state.nextAttempt = r.timeSource.Now().Add(delay)
+	}
+	attempts, quarantined := state.attempts, state.quarantined
+	r.redriveMu.Unlock()
+
+	tags := []tag.Tag{
+		tag.SourceCluster(sourceCluster),
+		tag.TaskID(taskInfo.TaskID),
+		tag.WorkflowDomainID(taskInfo.DomainID),
+		tag.WorkflowID(taskInfo.WorkflowID),
+		tag.WorkflowRunID(taskInfo.RunID),
+		tag.AttemptCount(attempts),
+		tag.Reason(errorClass),
+		tag.Error(err),
+	}
+	if !quarantined {
+		r.logger.Warn("Failed to re-drive replication DLQ message, will retry.", tags...)
+		return
+	}
+
+	r.logger.Error("Quarantined replication DLQ message after failed re-drive.", tags...)
+	r.redriveMetricsScope(sourceCluster, taskInfo.DomainID).Tagged(
+		metrics.ReasonTag(errorClass),
+	).IncCounter(metrics.ReplicationDLQRedriveQuarantined)
+}
+
+func (r *dlqHandlerImpl) GetRedriveStatuses(sourceCluster string, taskIDs []int64) []*types.ReplicationDLQRedriveStatus {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	var statuses []*types.ReplicationDLQRedriveStatus
+	for _, taskID := range taskIDs {
+		state, ok := r.redriveStates[sourceCluster][taskID]
+		if !ok {
+			continue
+		}
+		status := &types.ReplicationDLQRedriveStatus{
+			TaskID:      taskID,
+			Attempts:    int32(state.attempts),
+			ErrorClass:  state.errorClass,
+			Quarantined: state.quarantined,
+		}
+		if state.lastError != nil {
+			status.LastError = state.lastError.Error()
+		}
+		if !state.quarantined {
+			status.NextAttemptTimestamp = common.Int64Ptr(state.nextAttempt.UnixNano())
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (r *dlqHandlerImpl) clearRedriveState(sourceCluster string, taskID int64) {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	delete(r.redriveStates[sourceCluster], taskID)
+}
+
+// pruneRedriveStates drops the state of messages in the scanned range [minTaskID, maxTaskID] which were not seen
+func (r *dlqHandlerImpl) pruneRedriveStates(sourceCluster string, seen map[int64]struct{}, minTaskID, maxTaskID int64) {
+	r.redriveMu.Lock()
+	defer r.redriveMu.Unlock()
+
+	for taskID := range r.redriveStates[sourceCluster] {
+		if taskID < minTaskID || taskID > maxTaskID {
+			continue
+		}
+		if _, ok := seen[taskID]; !ok {
+			delete(r.redriveStates[sourceCluster], taskID)
+		}
+	}
+}
+
+func (r *dlqHandlerImpl) redriveMetricsScope(sourceCluster string, domainID string) metrics.Scope {
+	// an unresolved domain name is reported as unknown
+	domainName, _ := r.shard.GetDomainCache().GetDomainName(domainID)
+	return r.metricsClient.Scope(
+		metrics.ReplicationDLQStatsScope,
+		metrics.SourceClusterTag(sourceCluster),
+		metrics.DomainTag(domainName),
+	)
+}
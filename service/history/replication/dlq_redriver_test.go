@@ -0,0 +1,268 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestClassifyRedriveError(t *testing.T) {
+	tests := []struct {
+		err           error
+		wantClass     string
+		wantRetryable bool
+	}{
+		{err: &types.BadRequestError{}, wantClass: redriveErrorBadRequest, wantRetryable: false},
+		{err: &types.InternalDataInconsistencyError{}, wantClass: redriveErrorDataInconsistency, wantRetryable: false},
+		{err: &types.EntityNotExistsError{}, wantClass: redriveErrorEntityNotExists, wantRetryable: true},
+		{err: &types.DomainNotActiveError{}, wantClass: redriveErrorDomainNotActive, wantRetryable: true},
+		{err: &types.ServiceBusyError{}, wantClass: redriveErrorServiceBusy, wantRetryable: true},
+		{err: &types.LimitExceededError{}, wantClass: redriveErrorServiceBusy, wantRetryable: true},
+		{err: &persistence.TimeoutError{}, wantClass: redriveErrorTimeout, wantRetryable: true},
+		{err: context.DeadlineExceeded, wantClass: redriveErrorTimeout, wantRetryable: true},
+		{err: yarpcerrors.DeadlineExceededErrorf("timeout"), wantClass: redriveErrorTimeout, wantRetryable: true},
+		{err: &types.InternalServiceError{}, wantClass: redriveErrorInternal, wantRetryable: true},
+		{err: errors.New("some error"), wantClass: redriveErrorUnknown, wantRetryable: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.wantClass, func(t *testing.T) {
+			class, retryable := classifyRedriveError(tc.err)
+			assert.Equal(t, tc.wantClass, class)
+			assert.Equal(t, tc.wantRetryable, retryable)
+		})
+	}
+}
+
+func (s *dlqHandlerSuite) setupRedrive(taskIDs ...int64) {
+	s.config.ReplicationDLQRedriveBatchSize = dynamicproperties.GetIntPropertyFilteredByShardID(10)
+	s.config.ReplicationDLQRedriveMaxAttempts = dynamicproperties.GetIntPropertyFilteredByShardID(2)
+	s.config.ReplicationDLQRedriveInitialBackoff = dynamicproperties.GetDurationPropertyFnFilteredByShardID(time.Minute)
+	s.config.ReplicationDLQRedriveMaxBackoff = dynamicproperties.GetDurationPropertyFnFilteredByShardID(time.Hour)
+	s.messageHandler.timeSource = clock.NewMockedTimeSource()
+
+	tasks := make([]persistence.Task, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		tasks = append(tasks, &persistence.HistoryReplicationTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{
+				DomainID:   "domainID",
+				WorkflowID: "workflowID",
+				RunID:      "runID",
+			},
+			TaskData: persistence.TaskData{
+				TaskID: taskID,
+			},
+		})
+	}
+	if len(tasks) > 0 {
+		s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.Anything).
+			Return(&persistence.GetHistoryTasksResponse{Tasks: tasks}, nil)
+	}
+	s.mockClientBean.EXPECT().GetRemoteAdminClient(s.sourceCluster).Return(s.adminClient, nil).AnyTimes()
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName("domainID").Return("domain", nil).AnyTimes()
+}
+
+func (s *dlqHandlerSuite) expectHydration(taskIDs ...int64) {
+	replicationTasks := make([]*types.ReplicationTask, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		replicationTasks = append(replicationTasks, &types.ReplicationTask{
+			TaskType:     types.ReplicationTaskTypeHistory.Ptr(),
+			SourceTaskID: taskID,
+		})
+	}
+	s.adminClient.EXPECT().
+		GetDLQReplicationMessages(gomock.Any(), gomock.Any()).
+		Return(&types.GetDLQReplicationMessagesResponse{ReplicationTasks: replicationTasks}, nil).
+		Times(1)
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_Success() {
+	s.setupRedrive(1)
+	s.expectHydration(1)
+	s.executionManager.On("DeleteReplicationTaskFromDLQ", mock.Anything, &persistence.DeleteReplicationTaskFromDLQRequest{
+		SourceClusterName: s.sourceCluster,
+		TaskID:            1,
+	}).Return(nil).Times(1)
+
+	err := s.messageHandler.redriveMessages(context.Background(), s.sourceCluster)
+
+	s.NoError(err)
+	s.Len(s.taskExecutor.executedTasks, 1)
+	s.Empty(s.messageHandler.redriveStates[s.sourceCluster])
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_BackoffThenQuarantine() {
+	s.setupRedrive(1)
+	s.taskExecutor.err = &types.InternalServiceError{Message: "transient"}
+	mockTimeSource := s.messageHandler.timeSource.(clock.MockedTimeSource)
+
+	s.expectHydration(1)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	state := s.messageHandler.redriveStates[s.sourceCluster][1]
+	s.Equal(1, state.attempts)
+	s.Equal(redriveErrorInternal, state.errorClass)
+	s.False(state.quarantined)
+
+	// backoff has not elapsed yet, the message is not retried
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 1)
+
+	mockTimeSource.Advance(time.Minute)
+	s.expectHydration(1)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Equal(2, state.attempts)
+	s.True(state.quarantined)
+
+	// quarantined messages are never retried automatically
+	mockTimeSource.Advance(time.Hour)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 2)
+	s.executionManager.AssertNotCalled(s.T(), "DeleteReplicationTaskFromDLQ", mock.Anything, mock.Anything)
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_PermanentErrorIsQuarantinedImmediately() {
+	s.setupRedrive(1)
+	s.taskExecutor.err = &types.BadRequestError{Message: "bad task"}
+	s.expectHydration(1)
+
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+
+	state := s.messageHandler.redriveStates[s.sourceCluster][1]
+	s.Equal(1, state.attempts)
+	s.Equal(redriveErrorBadRequest, state.errorClass)
+	s.Equal(s.taskExecutor.err, state.lastError)
+	s.True(state.quarantined)
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_PrunesRemovedMessages() {
+	s.setupRedrive(2)
+	s.messageHandler.redriveStates[s.sourceCluster] = map[int64]*dlqRedriveState{
+		1: {attempts: 2, quarantined: true},
+		2: {attempts: 2, quarantined: true},
+	}
+
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+
+	s.NotContains(s.messageHandler.redriveStates[s.sourceCluster], int64(1))
+	s.Contains(s.messageHandler.redriveStates[s.sourceCluster], int64(2))
+	s.Empty(s.taskExecutor.executedTasks)
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_ResumesFromCheckpoint() {
+	s.setupRedrive()
+	s.config.ReplicationDLQRedriveBatchSize = dynamicproperties.GetIntPropertyFilteredByShardID(1)
+	s.taskExecutor.err = &types.BadRequestError{Message: "bad task"}
+	readDLQ := func(readLevel int64, taskIDs ...int64) {
+		tasks := make([]persistence.Task, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			tasks = append(tasks, &persistence.HistoryReplicationTask{
+				WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: "domainID", WorkflowID: "workflowID", RunID: "runID"},
+				TaskData:           persistence.TaskData{TaskID: taskID},
+			})
+		}
+		s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.MatchedBy(func(req *persistence.GetReplicationTasksFromDLQRequest) bool {
+			return req.ReadLevel == readLevel
+		})).Return(&persistence.GetHistoryTasksResponse{Tasks: tasks}, nil).Once()
+	}
+
+	// the first pass stops after the first due message
+	readDLQ(defaultBeginningMessageID+1, 1, 2)
+	s.expectHydration(1)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Equal(int64(2), s.messageHandler.redriveReadLevels[s.sourceCluster])
+
+	// the second pass continues after it
+	readDLQ(2, 2)
+	s.expectHydration(2)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Equal(int64(3), s.messageHandler.redriveReadLevels[s.sourceCluster])
+	s.Len(s.taskExecutor.executedTasks, 2)
+
+	// the third pass reaches the end of the DLQ and the next one starts over
+	readDLQ(3)
+	s.NoError(s.messageHandler.redriveMessages(context.Background(), s.sourceCluster))
+	s.Equal(int64(defaultBeginningMessageID+1), s.messageHandler.redriveReadLevels[s.sourceCluster])
+}
+
+func (s *dlqHandlerSuite) TestRedrivePass_HasDeadlineAndStops() {
+	s.setupRedrive()
+	s.config.ReplicationDLQRedriveInterval = dynamicproperties.GetDurationPropertyFnFilteredByShardID(time.Minute)
+	s.executionManager.On("GetReplicationTasksFromDLQ", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	}), mock.Anything).Return(&persistence.GetHistoryTasksResponse{}, nil)
+
+	s.messageHandler.redrivePass()
+
+	s.messageHandler.Start()
+	s.messageHandler.Stop()
+	s.Error(s.messageHandler.ctx.Err())
+}
+
+func (s *dlqHandlerSuite) TestGetRedriveStatuses() {
+	nextAttempt := time.Unix(1700000000, 0)
+	s.messageHandler.redriveStates[s.sourceCluster] = map[int64]*dlqRedriveState{
+		1: {attempts: 1, nextAttempt: nextAttempt, errorClass: redriveErrorInternal, lastError: &types.InternalServiceError{Message: "transient"}},
+		2: {attempts: 1, errorClass: redriveErrorBadRequest, lastError: &types.BadRequestError{Message: "bad task"}, quarantined: true},
+	}
+
+	statuses := s.messageHandler.GetRedriveStatuses(s.sourceCluster, []int64{1, 2, 3})
+
+	s.Equal([]*types.ReplicationDLQRedriveStatus{
+		{
+			TaskID:               1,
+			Attempts:             1,
+			ErrorClass:           redriveErrorInternal,
+			LastError:            "transient",
+			NextAttemptTimestamp: common.Int64Ptr(nextAttempt.UnixNano()),
+		},
+		{
+			TaskID:      2,
+			Attempts:    1,
+			ErrorClass:  redriveErrorBadRequest,
+			LastError:   "bad task",
+			Quarantined: true,
+		},
+	}, statuses)
+	s.Empty(s.messageHandler.GetRedriveStatuses("otherCluster", []int64{1, 2}))
+}
+
+func (s *dlqHandlerSuite) TestRedriveMessages_InvalidCluster() {
+	err := s.messageHandler.redriveMessages(context.Background(), "invalidCluster")
+
+	s.Equal(errInvalidCluster, err)
+}
@@ -0,0 +1,187 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	statusScanBatchSize = 1000
+	statusMaxScanTasks  = 10000
+	throughputWindow    = time.Minute
+)
+
+type (
+	// throughputTracker tracks the rate at which replication tasks are sent to each polling cluster
+	throughputTracker struct {
+		sync.Mutex
+		clusters map[string]*clusterThroughput
+	}
+
+	clusterThroughput struct {
+		windowStart    time.Time
+		tasks          int64
+		tasksPerSecond float64
+		hasRate        bool
+	}
+
+	domainPendingTasks struct {
+		tasks    int64
+		oldest   time.Time
+		position int64
+	}
+)
+
+func newThroughputTracker() *throughputTracker {
+	return &throughputTracker{
+		clusters: make(map[string]*clusterThroughput),
+	}
+}
+
+func (t *throughputTracker) record(cluster string, tasks int, now time.Time) {
+	t.Lock()
+	defer t.Unlock()
+
+	c, ok := t.clusters[cluster]
+	if !ok {
+		t.clusters[cluster] = &clusterThroughput{windowStart: now, tasks: int64(tasks)}
+		return
+	}
+	c.tasks += int64(tasks)
+	if elapsed := now.Sub(c.windowStart); elapsed >= throughputWindow {
+		c.tasksPerSecond = float64(c.tasks) / elapsed.Seconds()
+		c.hasRate = true
+		c.windowStart = now
+		c.tasks = 0
+	}
+}
+
+func (t *throughputTracker) rate(cluster string, now time.Time) float64 {
+	t.Lock()
+	defer t.Unlock()
+
+	c, ok := t.clusters[cluster]
+	if !ok {
+		return 0
+	}
+	elapsed := now.Sub(c.windowStart)
+	switch {
+	case elapsed >= throughputWindow:
+		// the cluster stopped polling, so the rate decays with the time since the window started
+		return float64(c.tasks) / elapsed.Seconds()
+	case c.hasRate:
+		return c.tasksPerSecond
+	case elapsed > 0:
+		return float64(c.tasks) / elapsed.Seconds()
+	default:
+		return 0
+	}
+}
+
+// Status returns the status of replication from the shard to the remote cluster,
+// pending tasks are broken down by domain with domain names resolved by domainName
+func (t *TaskAckManager) Status(
+	ctx context.Context,
+	remoteCluster string,
+	domainName func(domainID string) (string, error),
+) (*types.ReplicationShardStatus, error) {
+	now := t.timeSource.Now()
+	ackLevel := t.ackLevels.GetQueueClusterAckLevel(persistence.HistoryTaskCategoryReplication, remoteCluster).GetTaskID()
+	maxReadLevel := t.ackLevels.UpdateIfNeededAndGetQueueMaxReadLevel(persistence.HistoryTaskCategoryReplication, remoteCluster).GetTaskID()
+
+	status := &types.ReplicationShardStatus{
+		RemoteCluster:        remoteCluster,
+		LastReplicatedTaskID: ackLevel,
+		MaxTaskID:            maxReadLevel,
+		TasksPerSecond:       t.throughput.rate(remoteCluster, now),
+	}
+
+	domains := make(map[string]*domainPendingTasks)
+	var domainIDs []string
+	readLevel := ackLevel
+	for {
+		tasks, hasMore, err := t.reader.Read(ctx, readLevel, maxReadLevel, statusScanBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			if status.PendingTasks == 0 {
+				status.LagInMilliseconds = now.Sub(task.GetVisibilityTimestamp()).Milliseconds()
+			}
+			status.PendingTasks++
+
+			domain, ok := domains[task.GetDomainID()]
+			if !ok {
+				domain = &domainPendingTasks{oldest: task.GetVisibilityTimestamp()}
+				domains[task.GetDomainID()] = domain
+				domainIDs = append(domainIDs, task.GetDomainID())
+			}
+			domain.tasks++
+			domain.position = status.PendingTasks
+			readLevel = task.GetTaskID()
+		}
+		if !hasMore || len(tasks) == 0 {
+			break
+		}
+		if status.PendingTasks >= statusMaxScanTasks {
+			status.PendingTasksTruncated = true
+			break
+		}
+	}
+	status.EstimatedCatchUpInMilliseconds = estimateCatchUp(status.PendingTasks, status.TasksPerSecond)
+
+	for _, domainID := range domainIDs {
+		name, err := domainName(domainID)
+		if err != nil {
+			return nil, err
+		}
+		domain := domains[domainID]
+		status.Domains = append(status.Domains, &types.ReplicationDomainStatus{
+			Domain:            name,
+			RemoteCluster:     remoteCluster,
+			PendingTasks:      domain.tasks,
+			LagInMilliseconds: now.Sub(domain.oldest).Milliseconds(),
+			// replication is in order, so the domain caught up once its last pending task is replicated
+			EstimatedCatchUpInMilliseconds: estimateCatchUp(domain.position, status.TasksPerSecond),
+		})
+	}
+	return status, nil
+}
+
+// estimateCatchUp returns the time needed to replicate the given number of tasks, or nil if it can't be estimated
+func estimateCatchUp(tasks int64, tasksPerSecond float64) *int64 {
+	if tasks == 0 {
+		return new(int64)
+	}
+	if tasksPerSecond <= 0 {
+		return nil
+	}
+	estimate := time.Duration(float64(tasks) / tasksPerSecond * float64(time.Second)).Milliseconds()
+	return &estimate
+}
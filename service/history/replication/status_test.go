@@ -0,0 +1,196 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestThroughputTracker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newThroughputTracker()
+	assert.Zero(t, tracker.rate(testClusterA, now))
+
+	tracker.record(testClusterA, 10, now)
+	assert.Equal(t, float64(5), tracker.rate(testClusterA, now.Add(2*time.Second)))
+
+	tracker.record(testClusterA, 110, now.Add(throughputWindow))
+	assert.Equal(t, float64(2), tracker.rate(testClusterA, now.Add(throughputWindow+time.Second)))
+
+	// the rate decays when the cluster stops polling
+	assert.Zero(t, tracker.rate(testClusterA, now.Add(3*throughputWindow)))
+	assert.Zero(t, tracker.rate(testClusterB, now))
+}
+
+func TestTaskAckManager_Status(t *testing.T) {
+	now := time.Unix(1000, 0)
+	otherDomainID := "other-domain-id"
+	task := func(domainID string, taskID int64, age time.Duration) persistence.Task {
+		return &persistence.HistoryReplicationTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: domainID},
+			TaskData: persistence.TaskData{
+				TaskID:              taskID,
+				VisibilityTimestamp: now.Add(-age),
+			},
+		}
+	}
+	domainNames := map[string]string{testDomainID: testDomainName, otherDomainID: "other-domain"}
+	domainName := func(domainID string) (string, error) {
+		if name, ok := domainNames[domainID]; ok {
+			return name, nil
+		}
+		return "", errors.New("domain not found")
+	}
+
+	tests := []struct {
+		name         string
+		reader       taskReader
+		throughput   float64
+		domainName   func(string) (string, error)
+		expectStatus *types.ReplicationShardStatus
+		expectErr    bool
+	}{
+		{
+			name:       "caught up",
+			reader:     fakeTaskReader{},
+			throughput: 1,
+			domainName: domainName,
+			expectStatus: &types.ReplicationShardStatus{
+				RemoteCluster:                  testClusterA,
+				LastReplicatedTaskID:           10,
+				MaxTaskID:                      20,
+				TasksPerSecond:                 1,
+				EstimatedCatchUpInMilliseconds: common.Int64Ptr(0),
+			},
+		},
+		{
+			name: "pending tasks of multiple domains",
+			reader: fakeTaskReader{
+				task(testDomainID, 11, 4*time.Second),
+				task(otherDomainID, 12, 3*time.Second),
+				task(testDomainID, 13, 2*time.Second),
+				task(otherDomainID, 15, time.Second),
+			},
+			throughput: 2,
+			domainName: domainName,
+			expectStatus: &types.ReplicationShardStatus{
+				RemoteCluster:                  testClusterA,
+				LastReplicatedTaskID:           10,
+				MaxTaskID:                      20,
+				PendingTasks:                   4,
+				LagInMilliseconds:              4000,
+				TasksPerSecond:                 2,
+				EstimatedCatchUpInMilliseconds: common.Int64Ptr(2000),
+				Domains: []*types.ReplicationDomainStatus{
+					{
+						Domain:                         testDomainName,
+						RemoteCluster:                  testClusterA,
+						PendingTasks:                   2,
+						LagInMilliseconds:              4000,
+						EstimatedCatchUpInMilliseconds: common.Int64Ptr(1500),
+					},
+					{
+						Domain:                         "other-domain",
+						RemoteCluster:                  testClusterA,
+						PendingTasks:                   2,
+						LagInMilliseconds:              3000,
+						EstimatedCatchUpInMilliseconds: common.Int64Ptr(2000),
+					},
+				},
+			},
+		},
+		{
+			name:       "unknown rate",
+			reader:     fakeTaskReader{task(testDomainID, 11, time.Second)},
+			domainName: domainName,
+			expectStatus: &types.ReplicationShardStatus{
+				RemoteCluster:        testClusterA,
+				LastReplicatedTaskID: 10,
+				MaxTaskID:            20,
+				PendingTasks:         1,
+				LagInMilliseconds:    1000,
+				Domains: []*types.ReplicationDomainStatus{
+					{
+						Domain:            testDomainName,
+						RemoteCluster:     testClusterA,
+						PendingTasks:      1,
+						LagInMilliseconds: 1000,
+					},
+				},
+			},
+		},
+		{
+			name:       "read error",
+			reader:     fakeTaskReader(nil),
+			domainName: domainName,
+			expectErr:  true,
+		},
+		{
+			name:       "domain name error",
+			reader:     fakeTaskReader{task("unknown-domain-id", 11, time.Second)},
+			domainName: domainName,
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeSource := clock.NewMockedTimeSourceAt(now)
+			ackManager := TaskAckManager{
+				ackLevels: &fakeAckLevelStore{
+					remote:    map[string]persistence.HistoryTaskKey{testClusterA: persistence.NewImmediateTaskKey(10)},
+					readLevel: 20,
+				},
+				reader:     tt.reader,
+				timeSource: timeSource,
+				throughput: newThroughputTracker(),
+			}
+			if tt.throughput > 0 {
+				ackManager.throughput.clusters[testClusterA] = &clusterThroughput{
+					windowStart:    now,
+					tasksPerSecond: tt.throughput,
+					hasRate:        true,
+				}
+			}
+
+			status, err := ackManager.Status(context.Background(), testClusterA, tt.domainName)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, status)
+		})
+	}
+}
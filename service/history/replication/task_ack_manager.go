@@ -59,6 +59,7 @@ type (
 
 		dynamicTaskBatchSizer DynamicTaskBatchSizer
 		timeSource            clock.TimeSource
+		throughput            *throughputTracker
 	}
 
 	ackLevelStore interface {
@@ -99,6 +100,7 @@ func NewTaskAckManager(
 		maxReplicationMessagesSize: config.MaxResponseSize,
 		replicationMessagesSizeFn:  replicationMessagesSizeFn,
 		dynamicTaskBatchSizer:      dynamicTaskBatchSizer,
+		throughput:                 newThroughputTracker(),
 	}
 }
 
@@ -196,6 +198,7 @@ func (t *TaskAckManager) getTasks(ctx context.Context, pollingCluster string, la
 	t.scope.RecordTimer(metrics.ReplicationTasksReturnedDiff, time.Duration(len(taskInfos)-len(msgs.ReplicationTasks)))
 
 	t.ackLevel(pollingCluster, lastReadTaskID)
+	t.throughput.record(pollingCluster, len(msgs.ReplicationTasks), t.timeSource.Now())
 
 	t.logger.Debug(
 		"Get replication tasks",
@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reset
+
+import (
+	"slices"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// ShouldReapplyEvent returns whether an event passes the reapply filter.
+// The filter only narrows down signals, other events and a nil filter always pass.
+func ShouldReapplyEvent(
+	filter *types.EventReapplyFilter,
+	event *types.HistoryEvent,
+) bool {
+
+	if filter == nil || event.GetEventType() != types.EventTypeWorkflowExecutionSignaled {
+		return true
+	}
+
+	attr := event.GetWorkflowExecutionSignaledEventAttributes()
+	if len(filter.GetIncludeSignalNames()) > 0 && !slices.Contains(filter.GetIncludeSignalNames(), attr.GetSignalName()) {
+		return false
+	}
+	if slices.Contains(filter.GetExcludeSignalNames(), attr.GetSignalName()) {
+		return false
+	}
+	if len(filter.GetSignalIdentities()) > 0 && !slices.Contains(filter.GetSignalIdentities(), attr.GetIdentity()) {
+		return false
+	}
+	if filter.SignalsBefore != nil && event.GetTimestamp() >= filter.GetSignalsBefore() {
+		return false
+	}
+	return true
+}
+
+func filterReapplyEvents(
+	filter *types.EventReapplyFilter,
+	events []*types.HistoryEvent,
+) []*types.HistoryEvent {
+
+	if filter == nil {
+		return events
+	}
+	filtered := make([]*types.HistoryEvent, 0, len(events))
+	for _, event := range events {
+		if ShouldReapplyEvent(filter, event) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
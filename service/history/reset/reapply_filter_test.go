@@ -0,0 +1,117 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestShouldReapplyEvent(t *testing.T) {
+	signal := &types.HistoryEvent{
+		ID:        5,
+		Timestamp: common.Int64Ptr(100),
+		EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+		WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "signal",
+			Identity:   "worker",
+		},
+	}
+
+	tests := map[string]struct {
+		filter   *types.EventReapplyFilter
+		event    *types.HistoryEvent
+		expected bool
+	}{
+		"nil filter": {
+			filter:   nil,
+			event:    signal,
+			expected: true,
+		},
+		"empty filter": {
+			filter:   &types.EventReapplyFilter{},
+			event:    signal,
+			expected: true,
+		},
+		"non signal event": {
+			filter: &types.EventReapplyFilter{IncludeSignalNames: []string{"other-signal"}},
+			event: &types.HistoryEvent{
+				EventType: types.EventTypeWorkflowExecutionCancelRequested.Ptr(),
+			},
+			expected: true,
+		},
+		"included signal name": {
+			filter:   &types.EventReapplyFilter{IncludeSignalNames: []string{"other-signal", "signal"}},
+			event:    signal,
+			expected: true,
+		},
+		"signal name not included": {
+			filter:   &types.EventReapplyFilter{IncludeSignalNames: []string{"other-signal"}},
+			event:    signal,
+			expected: false,
+		},
+		"excluded signal name": {
+			filter:   &types.EventReapplyFilter{ExcludeSignalNames: []string{"signal"}},
+			event:    signal,
+			expected: false,
+		},
+		"exclude wins over include": {
+			filter: &types.EventReapplyFilter{
+				IncludeSignalNames: []string{"signal"},
+				ExcludeSignalNames: []string{"signal"},
+			},
+			event:    signal,
+			expected: false,
+		},
+		"matching identity": {
+			filter:   &types.EventReapplyFilter{SignalIdentities: []string{"worker"}},
+			event:    signal,
+			expected: true,
+		},
+		"other identity": {
+			filter:   &types.EventReapplyFilter{SignalIdentities: []string{"other-worker"}},
+			event:    signal,
+			expected: false,
+		},
+		"received before timestamp": {
+			filter:   &types.EventReapplyFilter{SignalsBefore: common.Int64Ptr(101)},
+			event:    signal,
+			expected: true,
+		},
+		"received at timestamp": {
+			filter:   &types.EventReapplyFilter{SignalsBefore: common.Int64Ptr(100)},
+			event:    signal,
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ShouldReapplyEvent(tc.filter, tc.event))
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/execution"
+)
+
+type (
+	runSnapshot struct {
+		nextEventID     int64
+		branchToken     []byte
+		autoResetPoints *types.ResetPoints
+	}
+
+	eventMatcher func(event *types.HistoryEvent) bool
+)
+
+// ResolveResetPoint finds the base run and the decision finish event ID a reset request
+// refers to through its ResetType or ResetTimestamp
+func (r *workflowResetterImpl) ResolveResetPoint(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	runID string,
+	request *types.ResetWorkflowExecutionRequest,
+) (string, int64, error) {
+
+	resetType := request.GetResetType()
+	if request.ResetType == nil {
+		// a timestamp alone means the first decision completed at or after it
+		resetType = types.ResetTypeDecisionCompletedTime
+	}
+
+	run, err := r.getRunSnapshot(ctx, domainID, workflowID, runID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var decisionFinishEventID int64
+	switch resetType {
+	case types.ResetTypeLastDecisionCompleted:
+		decisionFinishEventID, err = r.findLastEventID(ctx, domainID, run, isEventType(types.EventTypeDecisionTaskCompleted))
+	case types.ResetTypeFirstDecisionCompleted:
+		decisionFinishEventID, err = r.findFirstEventID(ctx, domainID, run, isEventType(types.EventTypeDecisionTaskCompleted))
+	case types.ResetTypeDecisionCompletedTime:
+		if request.ResetTimestamp == nil {
+			return "", 0, &types.BadRequestError{Message: "ResetTimestamp is required for reset type DECISION_COMPLETED_TIME."}
+		}
+		decisionFinishEventID, err = r.findFirstEventID(ctx, domainID, run, func(event *types.HistoryEvent) bool {
+			return event.GetEventType() == types.EventTypeDecisionTaskCompleted && event.GetTimestamp() >= request.GetResetTimestamp()
+		})
+	case types.ResetTypeFirstDecisionScheduled:
+		decisionFinishEventID, err = r.findFirstEventID(ctx, domainID, run, isEventType(types.EventTypeDecisionTaskScheduled))
+		// decision finish event ID is exclusive, so reset right after the scheduled event
+		decisionFinishEventID++
+	case types.ResetTypeLastDecisionScheduled:
+		decisionFinishEventID, err = r.findLastEventID(ctx, domainID, run, isEventType(types.EventTypeDecisionTaskScheduled))
+		decisionFinishEventID++
+	case types.ResetTypeBadBinary:
+		if request.GetBadBinaryChecksum() == "" {
+			return "", 0, &types.BadRequestError{Message: "BadBinaryChecksum is required for reset type BAD_BINARY."}
+		}
+		_, point := execution.FindAutoResetPoint(r.shard.GetTimeSource(), &types.BadBinaries{
+			Binaries: map[string]*types.BadBinaryInfo{
+				request.GetBadBinaryChecksum(): {},
+			},
+		}, run.autoResetPoints)
+		if point != nil {
+			decisionFinishEventID = point.GetFirstDecisionCompletedID()
+		}
+	case types.ResetTypeLastContinuedAsNew:
+		// this reset type changes the base run to the one that continued as new into this run
+		runID, err = r.getContinuedExecutionRunID(ctx, domainID, run)
+		if err != nil {
+			return "", 0, err
+		}
+		if run, err = r.getRunSnapshot(ctx, domainID, workflowID, runID); err != nil {
+			return "", 0, err
+		}
+		decisionFinishEventID, err = r.findLastEventID(ctx, domainID, run, isEventType(types.EventTypeDecisionTaskCompleted))
+	default:
+		return "", 0, &types.BadRequestError{Message: fmt.Sprintf("Unknown reset type %v.", resetType)}
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if decisionFinishEventID <= constants.FirstEventID {
+		return "", 0, &types.BadRequestError{
+			Message: fmt.Sprintf("Cannot find a decision to reset to for reset type %v.", resetType),
+		}
+	}
+	return runID, decisionFinishEventID, nil
+}
+
+// DryRunResetWorkflow reports what ResetWorkflow would do with the same arguments, without writing anything
+func (r *workflowResetterImpl) DryRunResetWorkflow(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	baseRunID string,
+	baseBranchToken []byte,
+	baseRebuildLastEventID int64,
+	baseNextEventID int64,
+	skipSignalReapply bool,
+	reapplyFilter *types.EventReapplyFilter,
+) (*types.ResetWorkflowDryRunResult, error) {
+
+	result := &types.ResetWorkflowDryRunResult{
+		BaseRunID:             baseRunID,
+		DecisionFinishEventID: baseRebuildLastEventID + 1,
+	}
+
+	// replay the base run up to the reset point, the same way the state rebuilder would,
+	// to find the activities and child workflows that would still be in flight
+	scheduledActivities := make(map[int64]*types.HistoryEvent)
+	startedActivities := make(map[int64]*types.HistoryEvent)
+	pendingChildren := make(map[int64]struct{})
+	if err := r.iterateEvents(ctx, domainID, constants.FirstEventID, baseNextEventID, baseBranchToken, func(event *types.HistoryEvent) {
+		if event.ID == result.DecisionFinishEventID {
+			result.DecisionFinishEvent = event
+		}
+		if event.ID > baseRebuildLastEventID {
+			return
+		}
+		switch event.GetEventType() {
+		case types.EventTypeActivityTaskScheduled:
+			scheduledActivities[event.ID] = event
+		case types.EventTypeActivityTaskStarted:
+			startedActivities[event.GetActivityTaskStartedEventAttributes().GetScheduledEventID()] = event
+		case types.EventTypeActivityTaskCompleted:
+			delete(startedActivities, event.GetActivityTaskCompletedEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskFailed:
+			delete(startedActivities, event.GetActivityTaskFailedEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskTimedOut:
+			delete(startedActivities, event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskCanceled:
+			delete(startedActivities, event.GetActivityTaskCanceledEventAttributes().GetScheduledEventID())
+		case types.EventTypeStartChildWorkflowExecutionInitiated:
+			pendingChildren[event.ID] = struct{}{}
+		case types.EventTypeStartChildWorkflowExecutionFailed:
+			delete(pendingChildren, event.GetStartChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionCompleted:
+			delete(pendingChildren, event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionFailed:
+			delete(pendingChildren, event.GetChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionCanceled:
+			delete(pendingChildren, event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionTimedOut:
+			delete(pendingChildren, event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionTerminated:
+			delete(pendingChildren, event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventID())
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(pendingChildren) > 0 {
+		// same as closePendingDecisionTask
+		return nil, &types.BadRequestError{
+			Message: "Can not reset workflow with pending child workflows",
+		}
+	}
+
+	// only started activities are failed by failInflightActivity, scheduled ones are left to be dispatched again
+	for scheduleID, started := range startedActivities {
+		scheduled, ok := scheduledActivities[scheduleID]
+		if !ok {
+			continue
+		}
+		attributes := scheduled.GetActivityTaskScheduledEventAttributes()
+		activity := &types.PendingActivityInfo{
+			ActivityID:           attributes.GetActivityID(),
+			ActivityType:         attributes.GetActivityType(),
+			State:                types.PendingActivityStateStarted.Ptr(),
+			ScheduleID:           scheduleID,
+			ScheduledTimestamp:   scheduled.Timestamp,
+			LastStartedTimestamp: started.Timestamp,
+		}
+		if startedAttributes := started.ActivityTaskStartedEventAttributes; startedAttributes != nil {
+			activity.Attempt = startedAttributes.Attempt
+			activity.StartedWorkerIdentity = startedAttributes.Identity
+		}
+		result.FailedActivities = append(result.FailedActivities, activity)
+	}
+	sort.Slice(result.FailedActivities, func(i, j int) bool {
+		return result.FailedActivities[i].ScheduleID < result.FailedActivities[j].ScheduleID
+	})
+
+	if !skipSignalReapply {
+		if err := r.iterateResetAndContinueAsNewWorkflowEvents(
+			ctx,
+			domainID,
+			workflowID,
+			baseBranchToken,
+			baseRebuildLastEventID+1,
+			baseNextEventID,
+			func(events []*types.HistoryEvent) error {
+				for _, event := range filterReapplyEvents(reapplyFilter, events) {
+					// only signals are reapplied, see reapplyEvents
+					if event.GetEventType() == types.EventTypeWorkflowExecutionSignaled {
+						result.ReappliedEvents = append(result.ReappliedEvents, event)
+					}
+				}
+				return nil
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *workflowResetterImpl) getRunSnapshot(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	runID string,
+) (_ runSnapshot, retError error) {
+
+	context, release, err := r.executionCache.GetOrCreateWorkflowExecution(
+		ctx,
+		domainID,
+		types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+	)
+	if err != nil {
+		return runSnapshot{}, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.LoadWorkflowExecution(ctx)
+	if err != nil {
+		// no matter what error happen, we need to retry
+		return runSnapshot{}, err
+	}
+
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return runSnapshot{}, err
+	}
+	return runSnapshot{
+		nextEventID:     mutableState.GetNextEventID(),
+		branchToken:     branchToken,
+		autoResetPoints: mutableState.GetExecutionInfo().AutoResetPoints,
+	}, nil
+}
+
+func (r *workflowResetterImpl) getContinuedExecutionRunID(
+	ctx context.Context,
+	domainID string,
+	run runSnapshot,
+) (string, error) {
+
+	var runID string
+	if err := r.iterateEvents(ctx, domainID, constants.FirstEventID, constants.FirstEventID+1, run.branchToken, func(event *types.HistoryEvent) {
+		runID = event.GetWorkflowExecutionStartedEventAttributes().GetContinuedExecutionRunID()
+	}); err != nil {
+		return "", err
+	}
+	if runID == "" {
+		return "", &types.BadRequestError{Message: "Cannot reset to LAST_CONTINUED_AS_NEW, the workflow did not continue as new."}
+	}
+	return runID, nil
+}
+
+func (r *workflowResetterImpl) findFirstEventID(
+	ctx context.Context,
+	domainID string,
+	run runSnapshot,
+	match eventMatcher,
+) (int64, error) {
+
+	var eventID int64
+	err := r.iterateEvents(ctx, domainID, constants.FirstEventID, run.nextEventID, run.branchToken, func(event *types.HistoryEvent) {
+		if eventID == 0 && match(event) {
+			eventID = event.ID
+		}
+	})
+	return eventID, err
+}
+
+func (r *workflowResetterImpl) findLastEventID(
+	ctx context.Context,
+	domainID string,
+	run runSnapshot,
+	match eventMatcher,
+) (int64, error) {
+
+	var eventID int64
+	err := r.iterateEvents(ctx, domainID, constants.FirstEventID, run.nextEventID, run.branchToken, func(event *types.HistoryEvent) {
+		if match(event) {
+			eventID = event.ID
+		}
+	})
+	return eventID, err
+}
+
+func (r *workflowResetterImpl) iterateEvents(
+	ctx context.Context,
+	domainID string,
+	firstEventID int64,
+	nextEventID int64,
+	branchToken []byte,
+	fn func(event *types.HistoryEvent),
+) error {
+
+	_, err := r.iterateWorkflowEvents(ctx, domainID, firstEventID, nextEventID, branchToken, func(events []*types.HistoryEvent) error {
+		for _, event := range events {
+			fn(event)
+		}
+		return nil
+	})
+	return err
+}
+
+func isEventType(eventType types.EventType) eventMatcher {
+	return func(event *types.HistoryEvent) bool {
+		return event.GetEventType() == eventType
+	}
+}
@@ -0,0 +1,326 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reset
+
+import (
+	"context"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/execution"
+)
+
+const testResetDomainName = "test-domain"
+
+func (s *workflowResetterSuite) TestResolveResetPoint() {
+	branchToken := []byte("some random branch token")
+	events := s.decisionEvents()
+
+	testCases := []struct {
+		name        string
+		request     *types.ResetWorkflowExecutionRequest
+		autoReset   *types.ResetPoints
+		skipHistory bool
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			name:       "last decision completed",
+			request:    &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeLastDecisionCompleted.Ptr()},
+			expectedID: 7,
+		},
+		{
+			name:       "first decision completed",
+			request:    &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeFirstDecisionCompleted.Ptr()},
+			expectedID: 4,
+		},
+		{
+			name:       "first decision scheduled",
+			request:    &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeFirstDecisionScheduled.Ptr()},
+			expectedID: 3,
+		},
+		{
+			name:       "last decision scheduled",
+			request:    &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeLastDecisionScheduled.Ptr()},
+			expectedID: 6,
+		},
+		{
+			name:       "timestamp without reset type",
+			request:    &types.ResetWorkflowExecutionRequest{ResetTimestamp: common.Int64Ptr(150)},
+			expectedID: 7,
+		},
+		{
+			name:        "timestamp after the last decision",
+			request:     &types.ResetWorkflowExecutionRequest{ResetTimestamp: common.Int64Ptr(1000)},
+			expectedErr: &types.BadRequestError{Message: "Cannot find a decision to reset to for reset type DECISION_COMPLETED_TIME."},
+		},
+		{
+			name: "bad binary",
+			request: &types.ResetWorkflowExecutionRequest{
+				ResetType:         types.ResetTypeBadBinary.Ptr(),
+				BadBinaryChecksum: "bad-checksum",
+			},
+			skipHistory: true,
+			autoReset: &types.ResetPoints{
+				Points: []*types.ResetPointInfo{
+					{BinaryChecksum: "good-checksum", FirstDecisionCompletedID: 4, Resettable: true},
+					{BinaryChecksum: "bad-checksum", FirstDecisionCompletedID: 7, Resettable: true},
+				},
+			},
+			expectedID: 7,
+		},
+		{
+			name:        "bad binary without checksum",
+			request:     &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeBadBinary.Ptr()},
+			skipHistory: true,
+			expectedErr: &types.BadRequestError{Message: "BadBinaryChecksum is required for reset type BAD_BINARY."},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			defer s.TearDownTest()
+			s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return(testResetDomainName, nil).AnyTimes()
+			s.putRun(s.baseRunID, 9, branchToken, tc.autoReset)
+			if !tc.skipHistory {
+				s.expectEvents(branchToken, constants.FirstEventID, 9, events)
+			}
+
+			runID, decisionFinishEventID, err := s.workflowResetter.ResolveResetPoint(
+				context.Background(),
+				s.domainID,
+				s.workflowID,
+				s.baseRunID,
+				tc.request,
+			)
+			if tc.expectedErr != nil {
+				s.Equal(tc.expectedErr, err)
+				return
+			}
+			s.NoError(err)
+			s.Equal(s.baseRunID, runID)
+			s.Equal(tc.expectedID, decisionFinishEventID)
+		})
+	}
+}
+
+func (s *workflowResetterSuite) TestResolveResetPoint_LastContinuedAsNew() {
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return(testResetDomainName, nil).AnyTimes()
+	previousRunID := uuid.New()
+	branchToken := []byte("some random branch token")
+	previousBranchToken := []byte("some random previous branch token")
+
+	s.putRun(s.currentRunID, 3, branchToken, nil)
+	s.expectEvents(branchToken, constants.FirstEventID, constants.FirstEventID+1, []*types.HistoryEvent{
+		{
+			ID:        1,
+			EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				ContinuedExecutionRunID: previousRunID,
+			},
+		},
+	})
+	s.putRun(previousRunID, 9, previousBranchToken, nil)
+	s.expectEvents(previousBranchToken, constants.FirstEventID, 9, s.decisionEvents())
+
+	runID, decisionFinishEventID, err := s.workflowResetter.ResolveResetPoint(
+		context.Background(),
+		s.domainID,
+		s.workflowID,
+		s.currentRunID,
+		&types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeLastContinuedAsNew.Ptr()},
+	)
+	s.NoError(err)
+	s.Equal(previousRunID, runID)
+	s.Equal(int64(7), decisionFinishEventID)
+}
+
+func (s *workflowResetterSuite) TestDryRunResetWorkflow() {
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return(testResetDomainName, nil).AnyTimes()
+	branchToken := []byte("some random branch token")
+	events := []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+		{ID: 3, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+		{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+		{
+			ID:        5,
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				ActivityID:   "started-activity",
+				ActivityType: &types.ActivityType{Name: "activity-type"},
+			},
+		},
+		{
+			ID:        6,
+			EventType: types.EventTypeActivityTaskScheduled.Ptr(),
+			ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+				ActivityID:   "scheduled-activity",
+				ActivityType: &types.ActivityType{Name: "activity-type"},
+			},
+		},
+		{
+			ID:        7,
+			EventType: types.EventTypeActivityTaskStarted.Ptr(),
+			ActivityTaskStartedEventAttributes: &types.ActivityTaskStartedEventAttributes{
+				ScheduledEventID: 5,
+				Identity:         "worker",
+				Attempt:          2,
+			},
+		},
+		{
+			ID:        8,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				SignalName: "signal-before-reset",
+			},
+		},
+		{ID: 9, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+		{ID: 10, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+		{ID: 11, EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+		{
+			ID:        12,
+			EventType: types.EventTypeActivityTaskCompleted.Ptr(),
+			ActivityTaskCompletedEventAttributes: &types.ActivityTaskCompletedEventAttributes{
+				ScheduledEventID: 5,
+			},
+		},
+		{
+			ID:        13,
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				SignalName: "signal-after-reset",
+			},
+		},
+	}
+	s.expectEvents(branchToken, constants.FirstEventID, 14, events)
+	s.expectEvents(branchToken, 11, 14, events[10:])
+
+	result, err := s.workflowResetter.DryRunResetWorkflow(
+		context.Background(),
+		s.domainID,
+		s.workflowID,
+		s.baseRunID,
+		branchToken,
+		10,
+		14,
+		false,
+		nil,
+	)
+	s.NoError(err)
+	s.Equal(s.baseRunID, result.BaseRunID)
+	s.Equal(int64(11), result.DecisionFinishEventID)
+	s.Equal(events[10], result.DecisionFinishEvent)
+	s.Equal([]*types.HistoryEvent{events[12]}, result.ReappliedEvents)
+	s.Equal([]*types.PendingActivityInfo{
+		{
+			ActivityID:            "started-activity",
+			ActivityType:          &types.ActivityType{Name: "activity-type"},
+			State:                 types.PendingActivityStateStarted.Ptr(),
+			ScheduleID:            5,
+			Attempt:               2,
+			StartedWorkerIdentity: "worker",
+		},
+	}, result.FailedActivities)
+}
+
+func (s *workflowResetterSuite) TestDryRunResetWorkflow_PendingChildWorkflow() {
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return(testResetDomainName, nil).AnyTimes()
+	branchToken := []byte("some random branch token")
+	events := []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+		{ID: 3, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+		{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+		{
+			ID:        5,
+			EventType: types.EventTypeStartChildWorkflowExecutionInitiated.Ptr(),
+			StartChildWorkflowExecutionInitiatedEventAttributes: &types.StartChildWorkflowExecutionInitiatedEventAttributes{},
+		},
+		{ID: 6, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+	}
+	s.expectEvents(branchToken, constants.FirstEventID, 7, events)
+
+	_, err := s.workflowResetter.DryRunResetWorkflow(
+		context.Background(),
+		s.domainID,
+		s.workflowID,
+		s.baseRunID,
+		branchToken,
+		6,
+		7,
+		true,
+		nil,
+	)
+	s.Equal(&types.BadRequestError{Message: "Can not reset workflow with pending child workflows"}, err)
+}
+
+// decisionEvents returns the history of a run with two completed decisions, at event 4 and 7
+func (s *workflowResetterSuite) decisionEvents() []*types.HistoryEvent {
+	return []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+		{ID: 3, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+		{ID: 4, Timestamp: common.Int64Ptr(100), EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+		{ID: 5, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+		{ID: 6, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+		{ID: 7, Timestamp: common.Int64Ptr(200), EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+		{ID: 8, EventType: types.EventTypeActivityTaskScheduled.Ptr(), ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{}},
+	}
+}
+
+func (s *workflowResetterSuite) putRun(runID string, nextEventID int64, branchToken []byte, autoResetPoints *types.ResetPoints) {
+	mutableState := execution.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNextEventID().Return(nextEventID).AnyTimes()
+	mutableState.EXPECT().GetCurrentBranchToken().Return(branchToken, nil).AnyTimes()
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{AutoResetPoints: autoResetPoints}).AnyTimes()
+	executionContext := execution.NewMockContext(s.controller)
+	executionContext.EXPECT().Lock(gomock.Any()).Return(nil).AnyTimes()
+	executionContext.EXPECT().Unlock().AnyTimes()
+	executionContext.EXPECT().LoadWorkflowExecution(gomock.Any()).Return(mutableState, nil).AnyTimes()
+	executionContext.EXPECT().ByteSize().Return(uint64(1)).AnyTimes()
+	_, _ = s.workflowResetter.executionCache.PutIfNotExist(definition.NewWorkflowIdentifier(s.domainID, s.workflowID, runID), executionContext)
+}
+
+func (s *workflowResetterSuite) expectEvents(branchToken []byte, firstEventID, nextEventID int64, events []*types.HistoryEvent) {
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.Anything, &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    firstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      execution.NDCDefaultPageSize,
+		NextPageToken: nil,
+		ShardID:       common.IntPtr(s.mockShard.GetShardID()),
+		DomainName:    testResetDomainName,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History:       []*types.History{{Events: events}},
+		NextPageToken: nil,
+	}, nil)
+}
@@ -57,7 +57,29 @@ type (
 			resetReason string,
 			additionalReapplyEvents []*types.HistoryEvent,
 			skipSignalReapply bool,
+			reapplyFilter *types.EventReapplyFilter,
 		) error
+		// ResolveResetPoint returns the base run ID and decision finish event ID for a request
+		// using ResetType or ResetTimestamp instead of DecisionFinishEventID
+		ResolveResetPoint(
+			ctx context.Context,
+			domainID string,
+			workflowID string,
+			runID string,
+			request *types.ResetWorkflowExecutionRequest,
+		) (string, int64, error)
+		// DryRunResetWorkflow describes the outcome of ResetWorkflow without persisting anything
+		DryRunResetWorkflow(
+			ctx context.Context,
+			domainID string,
+			workflowID string,
+			baseRunID string,
+			baseBranchToken []byte,
+			baseRebuildLastEventID int64,
+			baseNextEventID int64,
+			skipSignalReapply bool,
+			reapplyFilter *types.EventReapplyFilter,
+		) (*types.ResetWorkflowDryRunResult, error)
 	}
 
 	workflowResetterImpl struct {
@@ -109,6 +131,7 @@ func (r *workflowResetterImpl) ResetWorkflow(
 	resetReason string,
 	additionalReapplyEvents []*types.HistoryEvent,
 	skipSignalReapply bool,
+	reapplyFilter *types.EventReapplyFilter,
 ) (retError error) {
 
 	domainEntry, err := r.domainCache.GetDomainByID(domainID)
@@ -145,6 +168,7 @@ func (r *workflowResetterImpl) ResetWorkflow(
 		resetReason,
 		additionalReapplyEvents,
 		skipSignalReapply,
+		reapplyFilter,
 	)
 	if err != nil {
 		return err
@@ -174,6 +198,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 	resetReason string,
 	additionalReapplyEvents []*types.HistoryEvent,
 	skipSignalReapply bool,
+	reapplyFilter *types.EventReapplyFilter,
 ) (execution.Workflow, error) {
 
 	resetWorkflow, err := r.replayResetWorkflow(
@@ -235,6 +260,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 			baseBranchToken,
 			baseRebuildLastEventID+1,
 			baseNextEventID,
+			reapplyFilter,
 		); err != nil {
 			return nil, err
 		}
@@ -462,6 +488,30 @@ func (r *workflowResetterImpl) reapplyResetAndContinueAsNewWorkflowEvents(
 	baseBranchToken []byte,
 	baseRebuildNextEventID int64,
 	baseNextEventID int64,
+	reapplyFilter *types.EventReapplyFilter,
+) error {
+
+	return r.iterateResetAndContinueAsNewWorkflowEvents(
+		ctx,
+		domainID,
+		workflowID,
+		baseBranchToken,
+		baseRebuildNextEventID,
+		baseNextEventID,
+		func(events []*types.HistoryEvent) error {
+			return r.reapplyEvents(resetMutableState, filterReapplyEvents(reapplyFilter, events))
+		},
+	)
+}
+
+func (r *workflowResetterImpl) iterateResetAndContinueAsNewWorkflowEvents(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	baseBranchToken []byte,
+	baseRebuildNextEventID int64,
+	baseNextEventID int64,
+	fn func(events []*types.HistoryEvent) error,
 ) error {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -471,57 +521,31 @@ func (r *workflowResetterImpl) reapplyResetAndContinueAsNewWorkflowEvents(
 	var err error
 
 	// first special handling the remaining events for base workflow
-	if nextRunID, err = r.reapplyWorkflowEvents(
+	if nextRunID, err = r.iterateWorkflowEvents(
 		ctx,
-		resetMutableState,
+		domainID,
 		baseRebuildNextEventID,
 		baseNextEventID,
 		baseBranchToken,
+		fn,
 	); err != nil {
 		return err
 	}
 
-	getNextEventIDBranchToken := func(runID string) (nextEventID int64, branchToken []byte, retError error) {
-		context, release, err := r.executionCache.GetOrCreateWorkflowExecution(
-			ctx,
-			domainID,
-			types.WorkflowExecution{
-				WorkflowID: workflowID,
-				RunID:      runID,
-			},
-		)
-		if err != nil {
-			return 0, nil, err
-		}
-		defer func() { release(retError) }()
-
-		mutableState, err := context.LoadWorkflowExecution(ctx)
-		if err != nil {
-			// no matter what error happen, we need to retry
-			return 0, nil, err
-		}
-
-		nextEventID = mutableState.GetNextEventID()
-		branchToken, err = mutableState.GetCurrentBranchToken()
-		if err != nil {
-			return 0, nil, err
-		}
-		return nextEventID, branchToken, nil
-	}
-
 	// second for remaining continue as new workflow, reapply eligible events
 	for len(nextRunID) != 0 {
-		nextWorkflowNextEventID, nextWorkflowBranchToken, err := getNextEventIDBranchToken(nextRunID)
+		nextWorkflow, err := r.getRunSnapshot(ctx, domainID, workflowID, nextRunID)
 		if err != nil {
 			return err
 		}
 
-		if nextRunID, err = r.reapplyWorkflowEvents(
+		if nextRunID, err = r.iterateWorkflowEvents(
 			ctx,
-			resetMutableState,
+			domainID,
 			constants.FirstEventID,
-			nextWorkflowNextEventID,
-			nextWorkflowBranchToken,
+			nextWorkflow.nextEventID,
+			nextWorkflow.branchToken,
+			fn,
 		); err != nil {
 			return err
 		}
@@ -537,6 +561,27 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	branchToken []byte,
 ) (string, error) {
 
+	return r.iterateWorkflowEvents(
+		ctx,
+		mutableState.GetExecutionInfo().DomainID,
+		firstEventID,
+		nextEventID,
+		branchToken,
+		func(events []*types.HistoryEvent) error {
+			return r.reapplyEvents(mutableState, events)
+		},
+	)
+}
+
+func (r *workflowResetterImpl) iterateWorkflowEvents(
+	ctx context.Context,
+	domainID string,
+	firstEventID int64,
+	nextEventID int64,
+	branchToken []byte,
+	fn func(events []*types.HistoryEvent) error,
+) (string, error) {
+
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
 	//  from visibility for better coverage of events eligible for re-application.
 	//  after the above change, this API do not have to return the continue as new run ID
@@ -546,7 +591,6 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 		// and the decision task is the latest event in the workflow.
 		return "", nil
 	}
-	domainID := mutableState.GetExecutionInfo().DomainID
 	iter := collection.NewPagingIterator(r.getPaginationFn(
 		ctx,
 		firstEventID,
@@ -564,7 +608,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 			return "", err
 		}
 		lastEvents = batch.(*types.History).Events
-		if err := r.reapplyEvents(mutableState, lastEvents); err != nil {
+		if err := fn(lastEvents); err != nil {
 			return "", err
 		}
 	}
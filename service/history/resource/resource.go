@@ -111,6 +111,9 @@ func New(
 			PersistenceGlobalMaxQPS: config.PersistenceGlobalMaxQPS,
 			ThrottledLoggerMaxRPS:   config.ThrottledLogRPS,
 
+			PersistenceGlobalRatelimiterMode: config.PersistenceGlobalRatelimiterMode,
+			GlobalRatelimiterUpdateInterval:  config.GlobalRatelimiterUpdateInterval,
+
 			ReadVisibilityStoreName:         nil, // history service never read,
 			WriteVisibilityStoreName:        config.WriteVisibilityStoreName,
 			EnableLogCustomerQueryParameter: nil, // log customer parameter will be done in front-end
@@ -150,6 +153,10 @@ func New(
 			UpdateInterval: config.GlobalRatelimiterUpdateInterval,
 			DecayAfter:     config.GlobalRatelimiterDecayAfter,
 			GcAfter:        config.GlobalRatelimiterGCAfter,
+
+			Algorithm:         config.GlobalRatelimiterAlgorithm,
+			ShadowAlgorithm:   config.GlobalRatelimiterShadowAlgorithm,
+			BurstCreditWindow: config.GlobalRatelimiterBurstCreditWindow,
 		},
 	)
 	if err != nil {
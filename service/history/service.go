@@ -36,8 +36,10 @@ import (
 	"github.com/uber/cadence/service/history/resource"
 	"github.com/uber/cadence/service/history/workflowcache"
 	"github.com/uber/cadence/service/history/wrappers/grpc"
+	"github.com/uber/cadence/service/history/wrappers/json"
 	"github.com/uber/cadence/service/history/wrappers/ratelimited"
 	"github.com/uber/cadence/service/history/wrappers/thrift"
+	"github.com/uber/cadence/service/history/wrappers/tracing"
 )
 
 const (
@@ -115,6 +117,7 @@ func (s *Service) Start() {
 		wfIDCache,
 		s.Resource.GetLogger(),
 	)
+	s.handler = tracing.NewHistoryHandler(s.handler, s.config.NumberOfShards)
 
 	thriftHandler := thrift.NewThriftHandler(s.handler)
 	thriftHandler.Register(s.GetDispatcher())
@@ -122,6 +125,9 @@ func (s *Service) Start() {
 	grpcHandler := grpc.NewGRPCHandler(s.handler)
 	grpcHandler.Register(s.GetDispatcher())
 
+	jsonHandler := json.NewJSONHandler(s.handler)
+	jsonHandler.Register(s.GetDispatcher())
+
 	// must start resource first
 	s.Resource.Start()
 	s.handler.Start()
@@ -38,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -127,6 +128,8 @@ type (
 		throttledLogger      log.Logger
 		engine               engine.Engine
 
+		largePayloadOffloader largepayload.Offloader
+
 		sync.RWMutex
 		lastUpdated                  time.Time
 		shardInfo                    *persistence.ShardInfo
@@ -953,6 +956,10 @@ func (s *contextImpl) AppendHistoryV2Events(
 		return nil, err
 	}
 
+	if err := s.largePayloadOffloader.OffloadEvents(ctx, domainID, domainName, execution, request.Events); err != nil {
+		return nil, err
+	}
+
 	// NOTE: do not use generateNextTransferTaskIDLocked since
 	// generateNextTransferTaskIDLocked is not guarded by lock
 	transactionID, err := s.GenerateTaskID()
@@ -1605,6 +1612,7 @@ func acquireShard(
 		logger:                         shardItem.logger,
 		throttledLogger:                shardItem.throttledLogger,
 		previousShardOwnerWasDifferent: ownershipChanged,
+		largePayloadOffloader:          largepayload.NewOffloader(shardItem.GetBlobstoreClient(), shardItem.config.LargePayloadOffloadThreshold),
 	}
 
 	// TODO remove once migrated to global event cache
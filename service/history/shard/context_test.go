@@ -40,6 +40,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
@@ -133,6 +134,7 @@ func (s *contextTestSuite) newContext() *contextImpl {
 		remoteClusterCurrentTime:     make(map[string]time.Time),
 		failoverLevels:               make(map[persistence.HistoryTaskCategory]map[string]persistence.FailoverLevel),
 		eventsCache:                  eventsCache,
+		largePayloadOffloader:        largepayload.NewOffloader(nil, config.LargePayloadOffloadThreshold),
 	}
 
 	s.Require().True(testMaxTransferSequenceNumber < (1<<context.config.RangeSizeBits), "bad config value")
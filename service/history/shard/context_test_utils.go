@@ -27,6 +27,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
@@ -58,6 +60,12 @@ func NewTestContext(
 	shardInfo = shardInfo.ToNilSafeCopy()
 	shardInfo.ClusterTransferAckLevel = map[string]int64{resource.ClusterMetadata.GetCurrentClusterName(): 3, "standby": 2}
 
+	// tests without a config never offload payloads
+	offloadThreshold := dynamicproperties.GetIntPropertyFilteredByDomain(0)
+	if config != nil {
+		offloadThreshold = config.LargePayloadOffloadThreshold
+	}
+
 	shard := &contextImpl{
 		Resource:                     resource,
 		shardID:                      shardInfo.ShardID,
@@ -75,6 +83,7 @@ func NewTestContext(
 		failoverLevels:               make(map[persistence.HistoryTaskCategory]map[string]persistence.FailoverLevel),
 		remoteClusterCurrentTime:     make(map[string]time.Time),
 		eventsCache:                  eventsCache,
+		largePayloadOffloader:        largepayload.NewOffloader(resource.GetBlobstoreClient(), offloadThreshold),
 	}
 	return &TestContext{
 		contextImpl:     shard,
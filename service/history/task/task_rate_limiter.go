@@ -34,6 +34,8 @@ import (
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/quotas/global/collection"
+	"github.com/uber/cadence/common/quotas/global/rpc"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/shard"
 )
@@ -61,20 +63,64 @@ func NewRateLimiter(
 	config *config.Config,
 	controller shard.Controller,
 ) RateLimiter {
+	return newRateLimiter(logger, metricsClient, domainCache, config, newShardedLimiters(config, controller))
+}
+
+// NewGlobalRateLimiter is the same as NewRateLimiter, but per-domain limits can be load-balanced
+// between hosts by the global ratelimiter, controlled per domain by HistoryGlobalRatelimiterMode.
+//
+// The returned collection must be started and stopped by the caller.
+func NewGlobalRateLimiter(
+	logger log.Logger,
+	metricsClient metrics.Client,
+	domainCache cache.DomainCache,
+	config *config.Config,
+	controller shard.Controller,
+	aggs rpc.Client,
+) (RateLimiter, *collection.Collection, error) {
+	limiters, err := collection.New(
+		"task-scheduler",
+		// local and global-fallback collections must not be shared, see collection.New
+		newShardedLimiters(config, controller),
+		newShardedLimiters(config, controller),
+		config.GlobalRatelimiterUpdateInterval,
+		config.TaskSchedulerGlobalDomainRPS,
+		config.GlobalRatelimiterKeyMode,
+		aggs,
+		logger,
+		metricsClient,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newRateLimiter(logger, metricsClient, domainCache, config, limiters), limiters, nil
+}
+
+// newShardedLimiters divides TaskSchedulerGlobalDomainRPS by the fraction of shards owned by this host.
+func newShardedLimiters(config *config.Config, controller shard.Controller) *quotas.Collection {
 	rps := func(domain string) int {
 		totalShards := float64(config.NumberOfShards)
 		totalRPS := float64(config.TaskSchedulerGlobalDomainRPS(domain))
 		numShards := float64(controller.NumShards())
 		return int(totalRPS * numShards / totalShards)
 	}
-	limiterFactory := dynamicquotas.NewSimpleDynamicRateLimiterFactory(rps)
+	return quotas.NewCollection(dynamicquotas.NewSimpleDynamicRateLimiterFactory(rps))
+}
+
+func newRateLimiter(
+	logger log.Logger,
+	metricsClient metrics.Client,
+	domainCache cache.DomainCache,
+	config *config.Config,
+	limiters quotas.ICollection,
+) RateLimiter {
 	return &taskRateLimiterImpl{
 		logger:           logger,
 		metricsScope:     metricsClient.Scope(metrics.TaskSchedulerRateLimiterScope),
 		domainCache:      domainCache,
 		enabled:          config.TaskSchedulerEnableRateLimiter,
 		enableShadowMode: config.TaskSchedulerEnableRateLimiterShadowMode,
-		limiters:         quotas.NewCollection(limiterFactory),
+		limiters:         limiters,
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/quotas/global/rpc"
 	ctask "github.com/uber/cadence/common/task"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/shard"
@@ -187,6 +188,44 @@ func TestRateLimiterRPS(t *testing.T) {
 	assert.Equal(t, 50, int(l))
 }
 
+func TestGlobalRateLimiterRPS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockShardController := shard.NewMockController(ctrl)
+	mockShardController.EXPECT().NumShards().Return(8).AnyTimes()
+	dynamicClient := dynamicconfig.NewInMemoryClient()
+	require.NoError(t, dynamicClient.UpdateValue(dynamicproperties.TaskSchedulerGlobalDomainRPS, 100))
+	config := config.New(
+		dynamicconfig.NewCollection(
+			dynamicClient,
+			testlogger.New(t),
+		),
+		16,
+		1024,
+		false,
+		"hostname",
+	)
+
+	rateLimiter, limiters, err := NewGlobalRateLimiter(
+		testlogger.New(t),
+		metrics.NewNoopMetricsClient(),
+		cache.NewMockDomainCache(ctrl),
+		config,
+		mockShardController,
+		rpc.NewMockClient(ctrl),
+	)
+	require.NoError(t, err)
+	r, ok := rateLimiter.(*taskRateLimiterImpl)
+	require.True(t, ok, "rate limiter type assertion failure")
+	assert.Equal(t, limiters, r.limiters)
+
+	// keys are "disabled" by default, which behaves like the sharded local limiter
+	assert.Equal(t, 50, int(r.limiters.For("test-domain").Limit()))
+
+	// "local" uses a limiter with the same configuration
+	require.NoError(t, dynamicClient.UpdateValue(dynamicproperties.HistoryGlobalRatelimiterMode, "local"))
+	assert.Equal(t, 50, int(r.limiters.For("test-domain").Limit()))
+}
+
 func TestRateLimiterAllow(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -26,10 +26,13 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	persistenceutils "github.com/uber/cadence/common/persistence/persistence-utils"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
@@ -38,6 +41,10 @@ import (
 	"github.com/uber/cadence/service/worker/archiver"
 )
 
+const (
+	largePayloadCleanupPageSize = 1000
+)
+
 var (
 	taskRetryPolicy = common.CreateTaskProcessingRetryPolicy()
 )
@@ -153,6 +160,10 @@ func (t *timerTaskExecutorBase) deleteWorkflow(
 	msBuilder execution.MutableState,
 ) error {
 
+	if err := t.deleteLargePayloads(ctx, task, msBuilder); err != nil {
+		return err
+	}
+
 	if err := t.deleteWorkflowHistory(ctx, task, msBuilder); err != nil {
 		return err
 	}
@@ -326,6 +337,56 @@ func (t *timerTaskExecutorBase) deleteWorkflowHistory(
 	return t.throttleRetry.Do(ctx, op)
 }
 
+// deleteLargePayloads deletes the blobs offloaded for the workflow history, it is skipped when the history
+// is archived so that references in the archived history can still be resolved
+func (t *timerTaskExecutorBase) deleteLargePayloads(
+	ctx context.Context,
+	task *persistence.DeleteHistoryEventTask,
+	msBuilder execution.MutableState,
+) error {
+	domainName, err := t.shard.GetDomainCache().GetDomainName(task.DomainID)
+	if err != nil {
+		return err
+	}
+	if t.config.LargePayloadOffloadThreshold(domainName) <= 0 {
+		return nil
+	}
+	branchToken, err := msBuilder.GetCurrentBranchToken()
+	if err != nil {
+		return err
+	}
+
+	offloader := largepayload.NewOffloader(t.shard.GetService().GetBlobstoreClient(), t.config.LargePayloadOffloadThreshold)
+	request := &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  constants.FirstEventID,
+		MaxEventID:  msBuilder.GetNextEventID(),
+		PageSize:    largePayloadCleanupPageSize,
+		ShardID:     common.IntPtr(t.shard.GetShardID()),
+		DomainName:  domainName,
+	}
+	for {
+		historyEvents, _, nextPageToken, err := persistenceutils.ReadFullPageV2Events(ctx, t.shard.GetHistoryManager(), request)
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			// history was deleted by a previous attempt
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		op := func(ctx context.Context) error {
+			return offloader.DeleteEventPayloads(ctx, task.DomainID, getWorkflowExecution(task), historyEvents)
+		}
+		if err := t.throttleRetry.Do(ctx, op); err != nil {
+			return err
+		}
+		if len(nextPageToken) == 0 {
+			return nil
+		}
+		request.NextPageToken = nextPageToken
+	}
+}
+
 func (t *timerTaskExecutorBase) deleteWorkflowVisibility(
 	ctx context.Context,
 	task *persistence.DeleteHistoryEventTask,
@@ -31,13 +31,17 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/largepayload"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/history/execution"
 	executioncache "github.com/uber/cadence/service/history/execution"
 	"github.com/uber/cadence/service/history/shard"
@@ -146,6 +150,60 @@ func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_NoErr() {
 	s.NoError(err)
 }
 
+func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_DeletesLargePayloads() {
+	task := &persistence.DeleteHistoryEventTask{
+		WorkflowIdentifier: persistence.WorkflowIdentifier{
+			DomainID:   constants.TestDomainID,
+			WorkflowID: constants.TestWorkflowID,
+			RunID:      constants.TestRunID,
+		},
+		TaskData: persistence.TaskData{
+			TaskID:              12345,
+			VisibilityTimestamp: time.Now(),
+		},
+	}
+	executionInfo := types.WorkflowExecution{
+		WorkflowID: task.WorkflowID,
+		RunID:      task.RunID,
+	}
+	wfContext := execution.NewContext(task.DomainID, executionInfo, s.mockShard, s.mockExecutionManager, log.NewNoop())
+	s.timerQueueTaskExecutorBase.config.LargePayloadOffloadThreshold = dynamicproperties.GetIntPropertyFilteredByDomain(1)
+	blobstoreClient := s.mockShard.Resource.BlobstoreClient
+	defer blobstoreClient.AssertExpectations(s.T())
+
+	historyEvents := []*types.HistoryEvent{
+		{
+			ID:        1,
+			EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				Input: []byte("large input"),
+			},
+		},
+	}
+	blobstoreClient.On("Put", mock.Anything, mock.Anything).Return(&blobstore.PutResponse{}, nil).Once()
+	offloader := largepayload.NewOffloader(blobstoreClient, s.timerQueueTaskExecutorBase.config.LargePayloadOffloadThreshold)
+	s.NoError(offloader.OffloadEvents(context.Background(), task.DomainID, constants.TestDomainName, executionInfo, historyEvents))
+	s.True(largepayload.IsReference(historyEvents[0].WorkflowExecutionStartedEventAttributes.Input))
+
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return(constants.TestDomainName, nil).AnyTimes()
+	s.mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{1, 2, 3}, nil).Times(2)
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(2)).Times(1)
+	s.mockHistoryV2Manager.On("ReadHistoryBranch", mock.Anything, mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: historyEvents,
+	}, nil).Once()
+	blobstoreClient.On("Exists", mock.Anything, mock.Anything).Return(&blobstore.ExistsResponse{Exists: true}, nil).Once()
+	blobstoreClient.On("Delete", mock.Anything, mock.Anything).Return(&blobstore.DeleteResponse{}, nil).Once()
+
+	s.mockExecutionManager.On("DeleteCurrentWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionManager.On("DeleteActiveClusterSelectionPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockVisibilityManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := s.timerQueueTaskExecutorBase.deleteWorkflow(context.Background(), task, wfContext, s.mockMutableState)
+	s.NoError(err)
+}
+
 func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_NoErr_InlineArchivalFailed() {
 	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats(gomock.Any()).Return(&persistence.ExecutionStats{
 		HistorySize: 1024,
@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package json serves the history APIs that cadence-idl does not define, or that take fields it does not define,
+// as JSON encoded yarpc procedures of the internal types. The client is in client/wrappers/json.
+package json
+
+import (
+	"context"
+
+	"go.uber.org/yarpc"
+	yarpcjson "go.uber.org/yarpc/encoding/json"
+
+	jsonclient "github.com/uber/cadence/client/wrappers/json"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/proto"
+	"github.com/uber/cadence/service/history/handler"
+)
+
+type JSONHandler struct {
+	h handler.Handler
+}
+
+func NewJSONHandler(h handler.Handler) JSONHandler {
+	return JSONHandler{h}
+}
+
+func (j JSONHandler) Register(dispatcher *yarpc.Dispatcher) {
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.HistoryDescribeReplicationQueueProcedure, j.DescribeReplicationQueue))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.HistoryReadDLQMessagesProcedure, j.ReadDLQMessages))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.HistoryReapplyEventsProcedure, j.ReapplyEvents))
+	dispatcher.Register(yarpcjson.Procedure(jsonclient.HistoryResetWorkflowExecutionProcedure, j.ResetWorkflowExecution))
+}
+
+func (j JSONHandler) DescribeReplicationQueue(ctx context.Context, request *types.DescribeReplicationQueueRequest) (*types.ReplicationShardStatus, error) {
+	response, err := j.h.DescribeReplicationQueue(ctx, request)
+	return response, fromError(err)
+}
+
+func (j JSONHandler) ReadDLQMessages(ctx context.Context, request *types.ReadDLQMessagesRequest) (*types.ReadDLQMessagesResponse, error) {
+	response, err := j.h.ReadDLQMessages(ctx, request)
+	return response, fromError(err)
+}
+
+// ReapplyEvents has no response, yarpc JSON procedures still have to return a struct
+func (j JSONHandler) ReapplyEvents(ctx context.Context, request *types.HistoryReapplyEventsRequest) (*struct{}, error) {
+	if err := j.h.ReapplyEvents(ctx, request); err != nil {
+		return nil, fromError(err)
+	}
+	return &struct{}{}, nil
+}
+
+func (j JSONHandler) ResetWorkflowExecution(ctx context.Context, request *types.HistoryResetWorkflowExecutionRequest) (*types.ResetWorkflowExecutionResponse, error) {
+	response, err := j.h.ResetWorkflowExecution(ctx, request)
+	return response, fromError(err)
+}
+
+// fromError maps errors to yarpc statuses the same way as for gRPC, proto.ToError maps them back on the client
+func fromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return proto.FromError(err)
+}
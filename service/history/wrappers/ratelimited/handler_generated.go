@@ -62,6 +62,10 @@ func (h *historyHandler) DescribeQueue(ctx context.Context, dp1 *types.DescribeQ
 	return h.wrapped.DescribeQueue(ctx, dp1)
 }
 
+func (h *historyHandler) DescribeReplicationQueue(ctx context.Context, dp1 *types.DescribeReplicationQueueRequest) (rp1 *types.ReplicationShardStatus, err error) {
+	return h.wrapped.DescribeReplicationQueue(ctx, dp1)
+}
+
 func (h *historyHandler) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 
 	if hp1 == nil {
@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/tracing"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/handler"
+)
+
+const (
+	testDomainID       = "test-domain-id"
+	testWorkflowID     = "test-workflow-id"
+	testNumberOfShards = 16
+)
+
+func TestHistoryHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctrl := gomock.NewController(t)
+	mockHandler := handler.NewMockHandler(ctrl)
+	h := NewHistoryHandler(mockHandler, testNumberOfShards)
+
+	request := &types.HistoryStartWorkflowExecutionRequest{
+		DomainUUID:   testDomainID,
+		StartRequest: &types.StartWorkflowExecutionRequest{WorkflowID: testWorkflowID},
+	}
+	mockHandler.EXPECT().StartWorkflowExecution(gomock.Any(), request).Return(&types.StartWorkflowExecutionResponse{}, nil)
+	_, err := h.StartWorkflowExecution(context.Background(), request)
+	require.NoError(t, err)
+
+	mockHandler.EXPECT().StartWorkflowExecution(gomock.Any(), nil).Return(nil, nil)
+	_, err = h.StartWorkflowExecution(context.Background(), nil)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "History.StartWorkflowExecution", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), tracing.DomainIDKey.String(testDomainID))
+	assert.Contains(t, spans[0].Attributes(), tracing.WorkflowIDKey.String(testWorkflowID))
+	assert.Contains(t, spans[0].Attributes(), tracing.ShardIDKey.Int(common.WorkflowIDToHistoryShard(testWorkflowID, testNumberOfShards)))
+	assert.Empty(t, spans[1].Attributes())
+}
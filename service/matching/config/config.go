@@ -104,6 +104,12 @@ type (
 		MaxTimeBetweenTaskDeletes time.Duration
 
 		EnableTasklistOwnershipGuard dynamicproperties.BoolPropertyFn
+
+		// global ratelimiter configuration, for task list dispatch and persistence
+		EnableGlobalRatelimiter          dynamicproperties.BoolPropertyFn
+		GlobalRatelimiterKeyMode         dynamicproperties.StringPropertyWithRatelimitKeyFilter
+		GlobalRatelimiterUpdateInterval  dynamicproperties.DurationPropertyFn
+		PersistenceGlobalRatelimiterMode dynamicproperties.StringPropertyWithRatelimitKeyFilter
 	}
 
 	ForwarderConfig struct {
@@ -230,5 +236,9 @@ func NewConfig(dc *dynamicconfig.Collection, hostName string, getIsolationGroups
 		AllIsolationGroups:                        getIsolationGroups,
 		EnableStandbyTaskCompletion:               dc.GetBoolPropertyFilteredByTaskListInfo(dynamicproperties.MatchingEnableStandbyTaskCompletion),
 		EnableClientAutoConfig:                    dc.GetBoolPropertyFilteredByTaskListInfo(dynamicproperties.MatchingEnableClientAutoConfig),
+		EnableGlobalRatelimiter:                   dc.GetBoolProperty(dynamicproperties.MatchingEnableGlobalRatelimiter),
+		GlobalRatelimiterKeyMode:                  dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.MatchingGlobalRatelimiterMode),
+		GlobalRatelimiterUpdateInterval:           dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
+		PersistenceGlobalRatelimiterMode:          dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.PersistenceGlobalRatelimiterMode),
 	}
 }
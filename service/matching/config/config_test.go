@@ -101,6 +101,10 @@ func TestNewConfig(t *testing.T) {
 		"IsolationGroupHasPollersSustainedDuration": {dynamicproperties.MatchingIsolationGroupHasPollersSustainedDuration, time.Duration(39)},
 		"IsolationGroupNoPollersSustainedDuration":  {dynamicproperties.MatchingIsolationGroupNoPollersSustainedDuration, time.Duration(40)},
 		"IsolationGroupsPerPartition":               {dynamicproperties.MatchingIsolationGroupsPerPartition, 41},
+		"EnableGlobalRatelimiter":                   {dynamicproperties.MatchingEnableGlobalRatelimiter, true},
+		"GlobalRatelimiterKeyMode":                  {dynamicproperties.MatchingGlobalRatelimiterMode, "local"},
+		"GlobalRatelimiterUpdateInterval":           {dynamicproperties.GlobalRatelimiterUpdateInterval, time.Duration(42)},
+		"PersistenceGlobalRatelimiterMode":          {dynamicproperties.PersistenceGlobalRatelimiterMode, "global"},
 	}
 	client := dynamicconfig.NewInMemoryClient()
 	for fieldName, expected := range fields {
@@ -171,6 +175,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn()
 		case dynamicproperties.FloatPropertyFnWithTaskListInfoFilters:
 			return fn("domain", "tasklist", int(types.TaskListTypeDecision))
+		case dynamicproperties.StringPropertyWithRatelimitKeyFilter:
+			return fn("tasklist-dispatch:domain/tasklist/0")
 		case func() []string:
 			return fn()
 		default:
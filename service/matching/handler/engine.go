@@ -98,6 +98,7 @@ type (
 		isolationState              isolationgroup.State
 		timeSource                  clock.TimeSource
 		failoverNotificationVersion int64
+		dispatchLimiters            *tasklist.DispatchLimiters // nil unless dispatch is globally ratelimited
 	}
 
 	// HistoryInfo consists of two integer regarding the history size and history count
@@ -134,6 +135,7 @@ func NewEngine(
 	resolver membership.Resolver,
 	isolationState isolationgroup.State,
 	timeSource clock.TimeSource,
+	dispatchLimiters *tasklist.DispatchLimiters,
 ) Engine {
 
 	e := &matchingEngineImpl{
@@ -154,6 +156,7 @@ func NewEngine(
 		membershipResolver:   resolver,
 		isolationState:       isolationState,
 		timeSource:           timeSource,
+		dispatchLimiters:     dispatchLimiters,
 	}
 
 	e.shutdownCompletion.Add(1)
@@ -247,6 +250,7 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *tasklist.Identifier, t
 		e.timeSource,
 		e.timeSource.Now(),
 		e.historyService,
+		e.dispatchLimiters,
 	)
 	if err != nil {
 		e.taskListsLock.Unlock()
@@ -182,6 +182,7 @@ func (s *matchingEngineSuite) newMatchingEngine(
 		s.mockMembershipResolver,
 		s.isolationState,
 		s.mockTimeSource,
+		nil,
 	).(*matchingEngineImpl)
 }
 
@@ -231,7 +232,8 @@ func (s *matchingEngineSuite) TestOnlyUnloadMatchingInstance() {
 		s.matchingEngine.config,
 		s.matchingEngine.timeSource,
 		s.matchingEngine.timeSource.Now(),
-		s.matchingEngine.historyService)
+		s.matchingEngine.historyService,
+		nil)
 	s.Require().NoError(err)
 
 	// try to unload a different tlm instance with the same taskListID
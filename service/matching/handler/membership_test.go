@@ -130,6 +130,7 @@ func TestGetTaskListManager_OwnerShip(t *testing.T) {
 				resolverMock,
 				nil,
 				mockTimeSource,
+				nil,
 			).(*matchingEngineImpl)
 
 			resolverMock.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(
@@ -21,28 +21,33 @@
 package matching
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/service/matching/config"
 	"github.com/uber/cadence/service/matching/handler"
+	"github.com/uber/cadence/service/matching/tasklist"
 	"github.com/uber/cadence/service/matching/wrappers/grpc"
 	"github.com/uber/cadence/service/matching/wrappers/thrift"
+	"github.com/uber/cadence/service/matching/wrappers/tracing"
 )
 
 // Service represents the cadence-matching service
 type Service struct {
 	resource.Resource
 
-	status  int32
-	handler handler.Handler
-	stopC   chan struct{}
-	config  *config.Config
+	status           int32
+	handler          handler.Handler
+	stopC            chan struct{}
+	config           *config.Config
+	dispatchLimiters *tasklist.DispatchLimiters
 }
 
 // NewService builds a new cadence-matching service
@@ -68,6 +73,9 @@ func NewService(
 			PersistenceGlobalMaxQPS:  serviceConfig.PersistenceGlobalMaxQPS,
 			ThrottledLoggerMaxRPS:    serviceConfig.ThrottledLogRPS,
 			IsErrorRetryableFunction: common.IsServiceTransientError,
+
+			PersistenceGlobalRatelimiterMode: serviceConfig.PersistenceGlobalRatelimiterMode,
+			GlobalRatelimiterUpdateInterval:  serviceConfig.GlobalRatelimiterUpdateInterval,
 			// matching doesn't need visibility config as it never read or write visibility
 		},
 	)
@@ -92,6 +100,14 @@ func (s *Service) Start() {
 	logger := s.GetLogger()
 	logger.Info("matching starting")
 
+	if s.config.EnableGlobalRatelimiter() {
+		dispatchLimiters, err := tasklist.NewDispatchLimiters(s.config, s.GetRatelimiterAggregatorsClient(), logger, s.GetMetricsClient())
+		if err != nil {
+			logger.Fatal("failed to create tasklist dispatch ratelimiters", tag.Error(err))
+		}
+		s.dispatchLimiters = dispatchLimiters
+	}
+
 	engine := handler.NewEngine(
 		s.GetTaskManager(),
 		s.GetClusterMetadata(),
@@ -104,9 +120,11 @@ func (s *Service) Start() {
 		s.GetMembershipResolver(),
 		s.GetIsolationGroupState(),
 		s.GetTimeSource(),
+		s.dispatchLimiters,
 	)
 
 	s.handler = handler.NewHandler(engine, s.config, s.GetDomainCache(), s.GetMetricsClient(), s.GetLogger(), s.GetThrottledLogger())
+	s.handler = tracing.NewMatchingHandler(s.handler)
 
 	thriftHandler := thrift.NewThriftHandler(s.handler)
 	thriftHandler.Register(s.GetDispatcher())
@@ -116,6 +134,15 @@ func (s *Service) Start() {
 
 	// must start base service first
 	s.Resource.Start()
+
+	if s.dispatchLimiters != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := s.dispatchLimiters.OnStart(ctx); err != nil {
+			logger.Fatal("failed to start tasklist dispatch ratelimiters", tag.Error(err))
+		}
+		cancel()
+	}
+
 	s.handler.Start()
 
 	logger.Info("matching started")
@@ -138,6 +165,15 @@ func (s *Service) Stop() {
 	close(s.stopC)
 
 	s.handler.Stop()
+
+	if s.dispatchLimiters != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := s.dispatchLimiters.OnStop(ctx); err != nil {
+			s.GetLogger().Error("failed to stop tasklist dispatch ratelimiters", tag.Error(err))
+		}
+		cancel()
+	}
+
 	s.Resource.Stop()
 
 	s.GetLogger().Info("matching stopped")
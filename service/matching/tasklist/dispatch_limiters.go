@@ -0,0 +1,201 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tasklist
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/quotas/global/collection"
+	"github.com/uber/cadence/common/quotas/global/rpc"
+	"github.com/uber/cadence/service/matching/config"
+)
+
+const dispatchLimitersName = "tasklist-dispatch"
+
+type (
+	// DispatchLimiters shares task list dispatch ratelimits across matching hosts
+	// through the global ratelimiter. Whether a task list partition uses it is controlled
+	// by config.GlobalRatelimiterKeyMode, keyed by "tasklist-dispatch:<domain-id>/<partition name>/<type>".
+	DispatchLimiters struct {
+		local      *limiterRegistry
+		fallback   *limiterRegistry
+		collection *collection.Collection
+	}
+
+	// limiterRegistry exposes the taskListLimiter of each owned task list partition as a quotas.ICollection.
+	// A partition can be briefly registered twice while it is being reloaded, the latest registration is used.
+	limiterRegistry struct {
+		sync.RWMutex
+		limiters map[string][]*taskListLimiter
+	}
+
+	// registeredLimiter looks up the limiter of a key on every call, as the collection keeps the limiters
+	// it gets from the registry while partitions are unloaded and reloaded with new limiters.
+	registeredLimiter struct {
+		registry *limiterRegistry
+		key      string
+	}
+)
+
+// unregisteredLimiter is used when a partition is not loaded, nothing should be dispatched through it
+var unregisteredLimiter = clock.NewRatelimiter(0, 0)
+
+// NewDispatchLimiters creates the global ratelimiter collection used for task list dispatch
+func NewDispatchLimiters(
+	cfg *config.Config,
+	aggs rpc.Client,
+	logger log.Logger,
+	metricsClient metrics.Client,
+) (*DispatchLimiters, error) {
+	d := &DispatchLimiters{
+		local:    newLimiterRegistry(),
+		fallback: newLimiterRegistry(),
+	}
+	c, err := collection.New(
+		dispatchLimitersName,
+		d.local,
+		d.fallback,
+		cfg.GlobalRatelimiterUpdateInterval,
+		d.targetRPS,
+		cfg.GlobalRatelimiterKeyMode,
+		aggs,
+		logger,
+		metricsClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tasklist dispatch ratelimiters: %w", err)
+	}
+	d.collection = c
+	return d, nil
+}
+
+// OnStart starts the background aggregator updates
+func (d *DispatchLimiters) OnStart(ctx context.Context) error {
+	return d.collection.OnStart(ctx)
+}
+
+// OnStop stops the background aggregator updates
+func (d *DispatchLimiters) OnStop(ctx context.Context) error {
+	return d.collection.OnStop(ctx)
+}
+
+// register adds the limiters of a task list and returns the limiter that should be used for dispatch
+func (d *DispatchLimiters) register(key string, local, fallback *taskListLimiter) quotas.Limiter {
+	d.local.add(key, local)
+	d.fallback.add(key, fallback)
+	return quotas.NewKeyedLimiter(d.collection, key)
+}
+
+// unregister removes the limiters of a task list that is being unloaded
+func (d *DispatchLimiters) unregister(key string, local, fallback *taskListLimiter) {
+	d.local.remove(key, local)
+	d.fallback.remove(key, fallback)
+}
+
+// targetRPS is the share of the task list dispatch rate reported by pollers that belongs to the partition,
+// the same rate its local limiter allows, which the global ratelimiter divides between the hosts owning it.
+func (d *DispatchLimiters) targetRPS(key string) int {
+	l := d.local.get(key)
+	if l == nil {
+		return 0
+	}
+	return int(math.Ceil(float64(l.Limit())))
+}
+
+func dispatchLimiterKey(taskList *Identifier) string {
+	return fmt.Sprintf("%s/%s/%d", taskList.GetDomainID(), taskList.GetName(), taskList.GetType())
+}
+
+func newLimiterRegistry() *limiterRegistry {
+	return &limiterRegistry{
+		limiters: make(map[string][]*taskListLimiter),
+	}
+}
+
+func (r *limiterRegistry) For(key string) quotas.Limiter {
+	return &registeredLimiter{registry: r, key: key}
+}
+
+func (r *limiterRegistry) get(key string) *taskListLimiter {
+	r.RLock()
+	defer r.RUnlock()
+	if ls := r.limiters[key]; len(ls) > 0 {
+		return ls[len(ls)-1]
+	}
+	return nil
+}
+
+func (r *limiterRegistry) add(key string, l *taskListLimiter) {
+	r.Lock()
+	defer r.Unlock()
+	r.limiters[key] = append(r.limiters[key], l)
+}
+
+func (r *limiterRegistry) remove(key string, l *taskListLimiter) {
+	r.Lock()
+	defer r.Unlock()
+	ls := r.limiters[key]
+	for i := range ls {
+		if ls[i] == l {
+			ls = append(ls[:i], ls[i+1:]...)
+			break
+		}
+	}
+	if len(ls) == 0 {
+		delete(r.limiters, key)
+		return
+	}
+	r.limiters[key] = ls
+}
+
+func (l *registeredLimiter) limiter() quotas.Limiter {
+	if tl := l.registry.get(l.key); tl != nil {
+		return tl
+	}
+	return unregisteredLimiter
+}
+
+func (l *registeredLimiter) Allow() bool {
+	return l.limiter().Allow()
+}
+
+func (l *registeredLimiter) Wait(ctx context.Context) error {
+	return l.limiter().Wait(ctx)
+}
+
+func (l *registeredLimiter) Reserve() clock.Reservation {
+	return l.limiter().Reserve()
+}
+
+func (l *registeredLimiter) Limit() rate.Limit {
+	return l.limiter().Limit()
+}
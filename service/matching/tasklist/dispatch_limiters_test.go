@@ -0,0 +1,117 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tasklist
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/service/matching/config"
+)
+
+func TestLimiterRegistry(t *testing.T) {
+	newLimiter := func(rps float64) *taskListLimiter {
+		return newTaskListLimiter(clock.NewMockedTimeSource(), metrics.NoopScope, &config.TaskListConfig{
+			TaskDispatchRPSTTL: time.Second,
+			TaskDispatchRPS:    rps,
+			MinTaskThrottlingBurstSize: func() int {
+				return 1
+			},
+		}, func() int { return 1 })
+	}
+	const key = "domain-id/tasklist/0"
+	registry := newLimiterRegistry()
+	first, second := newLimiter(10), newLimiter(20)
+
+	// the collection keeps limiters it got from the registry, so they must follow (re)registrations
+	limiter := registry.For(key)
+	assert.Equal(t, rate.Limit(0), limiter.Limit(), "unknown keys should not allow any dispatch")
+	assert.False(t, limiter.Allow())
+
+	registry.add(key, first)
+	assert.Equal(t, rate.Limit(10), limiter.Limit())
+
+	// partition reloaded before the old one is unloaded
+	registry.add(key, second)
+	assert.Equal(t, rate.Limit(20), limiter.Limit(), "latest registered limiter should be used")
+
+	registry.remove(key, first)
+	assert.Equal(t, rate.Limit(20), limiter.Limit())
+	assert.True(t, limiter.Allow())
+
+	registry.remove(key, second)
+	assert.Nil(t, registry.get(key))
+	assert.Empty(t, registry.limiters)
+	assert.Equal(t, rate.Limit(0), limiter.Limit())
+
+	registry.add(key, first)
+	assert.Equal(t, rate.Limit(10), limiter.Limit(), "reloaded partition should not be blocked by the unloaded one")
+}
+
+func TestDispatchLimiterKey(t *testing.T) {
+	root, err := NewIdentifier("domain-id", "tasklist", 0)
+	assert.NoError(t, err)
+	partition, err := NewIdentifier("domain-id", "/__cadence_sys/tasklist/2", 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "domain-id/tasklist/0", dispatchLimiterKey(root))
+	assert.Equal(t, "domain-id//__cadence_sys/tasklist/2/0", dispatchLimiterKey(partition), "partitions should be limited separately")
+}
+
+func TestDispatchLimitersTargetRPS(t *testing.T) {
+	const partitions = 4
+	d := &DispatchLimiters{
+		local:    newLimiterRegistry(),
+		fallback: newLimiterRegistry(),
+	}
+	total := 0
+	for i := 0; i < partitions; i++ {
+		name := "tasklist"
+		if i > 0 {
+			name = fmt.Sprintf("/__cadence_sys/tasklist/%d", i)
+		}
+		id, err := NewIdentifier("domain-id", name, 0)
+		assert.NoError(t, err)
+		limiter := newTaskListLimiter(clock.NewMockedTimeSource(), metrics.NoopScope, &config.TaskListConfig{
+			TaskDispatchRPSTTL: time.Second,
+			TaskDispatchRPS:    100,
+			MinTaskThrottlingBurstSize: func() int {
+				return 1
+			},
+		}, func() int { return partitions })
+		d.register(dispatchLimiterKey(id), limiter, limiter)
+
+		target := d.targetRPS(dispatchLimiterKey(id))
+		assert.Equal(t, 25, target, "each partition should get its share of the task list rate")
+		assert.Equal(t, rate.Limit(target), limiter.Limit(), "global and local limits of a partition should match")
+		total += target
+	}
+	assert.Equal(t, 100, total, "partitions together should not be allowed more than the task list rate")
+	assert.Equal(t, 0, d.targetRPS("domain-id/unknown/0"))
+}
@@ -49,6 +49,7 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/stats"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/matching/config"
@@ -127,6 +128,10 @@ type (
 		qpsTracker     stats.QPSTrackerGroup
 		adaptiveScaler AdaptiveScaler
 
+		// fallbackLimiter and dispatchLimiters are only set when dispatch is globally ratelimited
+		fallbackLimiter  *taskListLimiter
+		dispatchLimiters *DispatchLimiters
+
 		partitionConfigLock sync.RWMutex
 		partitionConfig     *types.TaskListPartitionConfig
 		historyService      history.Client
@@ -156,6 +161,7 @@ func NewManager(
 	timeSource clock.TimeSource,
 	createTime time.Time,
 	historyService history.Client,
+	dispatchLimiters *DispatchLimiters,
 ) (Manager, error) {
 	domainName, err := domainCache.GetDomainName(taskList.GetDomainID())
 	if err != nil {
@@ -236,7 +242,13 @@ func NewManager(
 		return taskListConfig.NumReadPartitions()
 	}
 	tlMgr.limiter = newTaskListLimiter(timeSource, tlMgr.scope, taskListConfig, numReadPartitionsFn)
-	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.scope, isolationGroups, tlMgr.logger, taskList, taskListKind, tlMgr.limiter).(*taskMatcherImpl)
+	var limiter quotas.Limiter = tlMgr.limiter
+	if dispatchLimiters != nil {
+		tlMgr.dispatchLimiters = dispatchLimiters
+		tlMgr.fallbackLimiter = newTaskListLimiter(timeSource, tlMgr.scope, taskListConfig, numReadPartitionsFn)
+		limiter = dispatchLimiters.register(dispatchLimiterKey(taskList), tlMgr.limiter, tlMgr.fallbackLimiter)
+	}
+	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.scope, isolationGroups, tlMgr.logger, taskList, taskListKind, limiter).(*taskMatcherImpl)
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.taskReader = newTaskReader(tlMgr, isolationGroups)
 	tlMgr.taskCompleter = newTaskCompleter(tlMgr, historyServiceOperationRetryPolicy)
@@ -306,6 +318,9 @@ func (c *taskListManagerImpl) Stop() {
 		return
 	}
 	c.closeCallback(c)
+	if c.dispatchLimiters != nil {
+		c.dispatchLimiters.unregister(dispatchLimiterKey(c.taskListID), c.limiter, c.fallbackLimiter)
+	}
 	if c.adaptiveScaler != nil {
 		c.adaptiveScaler.Stop()
 	}
@@ -676,6 +691,9 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 	if maxDispatchPerSecond != nil {
 		rps = *maxDispatchPerSecond
 		c.limiter.ReportLimit(rps)
+		if c.fallbackLimiter != nil {
+			c.fallbackLimiter.ReportLimit(rps)
+		}
 	}
 	c.pollers.StartPoll(pollerID, cancel, &poller.Info{
 		Identity:       identity,
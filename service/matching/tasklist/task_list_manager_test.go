@@ -104,6 +104,7 @@ func setupMocksForTaskListManager(t *testing.T, taskListID *Identifier, taskList
 		deps.mockTimeSource,
 		deps.mockTimeSource.Now(),
 		mockHistoryService,
+		nil,
 	)
 	require.NoError(t, err)
 	return tlm.(*taskListManagerImpl), deps
@@ -253,6 +254,7 @@ func createTestTaskListManagerWithConfig(t *testing.T, logger log.Logger, contro
 		timeSource,
 		timeSource.Now(),
 		mockHistoryService,
+		nil,
 	)
 	if err != nil {
 		logger.Fatal("error when createTestTaskListManager", tag.Error(err))
@@ -881,6 +883,7 @@ func TestTaskListManagerGetTaskBatch(t *testing.T) {
 		timeSource,
 		timeSource.Now(),
 		mockHistoryService,
+		nil,
 	)
 	assert.NoError(t, err)
 	tlm := tlMgr.(*taskListManagerImpl)
@@ -952,6 +955,7 @@ func TestTaskListManagerGetTaskBatch(t *testing.T) {
 		timeSource,
 		timeSource.Now(),
 		mockHistoryService,
+		nil,
 	)
 	assert.NoError(t, err)
 	tlm = tlMgr.(*taskListManagerImpl)
@@ -1011,6 +1015,7 @@ func TestTaskListReaderPumpAdvancesAckLevelAfterEmptyReads(t *testing.T) {
 		timeSource,
 		timeSource.Now(),
 		mockHistoryService,
+		nil,
 	)
 	require.NoError(t, err)
 	tlm := tlMgr.(*taskListManagerImpl)
@@ -1158,6 +1163,7 @@ func TestTaskExpiryAndCompletion(t *testing.T) {
 				timeSource,
 				timeSource.Now(),
 				mockHistoryService,
+				nil,
 			)
 			assert.NoError(t, err)
 			tlm := tlMgr.(*taskListManagerImpl)
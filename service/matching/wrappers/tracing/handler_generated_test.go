@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/tracing"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/matching/handler"
+)
+
+func TestMatchingHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctrl := gomock.NewController(t)
+	mockHandler := handler.NewMockHandler(ctrl)
+	h := NewMatchingHandler(mockHandler)
+
+	request := &types.AddDecisionTaskRequest{
+		DomainUUID: "test-domain-id",
+		Execution:  &types.WorkflowExecution{WorkflowID: "test-workflow-id", RunID: "test-run-id"},
+		TaskList:   &types.TaskList{Name: "test-tasklist"},
+	}
+	mockHandler.EXPECT().AddDecisionTask(gomock.Any(), request).Return(&types.AddDecisionTaskResponse{}, nil)
+	_, err := h.AddDecisionTask(context.Background(), request)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Matching.AddDecisionTask", spans[0].Name())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		tracing.DomainIDKey.String("test-domain-id"),
+		tracing.WorkflowIDKey.String("test-workflow-id"),
+		tracing.RunIDKey.String("test-run-id"),
+		tracing.TaskListKey.String("test-tasklist"),
+	}, spans[0].Attributes())
+}
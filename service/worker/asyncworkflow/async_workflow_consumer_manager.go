@@ -31,12 +31,15 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/asyncworkflow/queue"
 	"github.com/uber/cadence/common/asyncworkflow/queue/provider"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -71,6 +74,28 @@ func WithEmitConsumerCountMetrifFn(fn func(int)) ConsumerManagerOptions {
 	}
 }
 
+// WithQueueManager sets the persistence queue used by database backed async workflow queues
+func WithQueueManager(queueManager persistence.QueueManager) ConsumerManagerOptions {
+	return func(c *ConsumerManager) {
+		c.queueManager = queueManager
+	}
+}
+
+// WithMembershipResolver sets the resolver used by database backed async workflow queues
+// to pick the worker consuming the queue
+func WithMembershipResolver(resolver membership.Resolver) ConsumerManagerOptions {
+	return func(c *ConsumerManager) {
+		c.membershipResolver = resolver
+	}
+}
+
+// WithStatusStore sets the store recording the outcome of consumed requests
+func WithStatusStore(store requeststatus.Store) ConsumerManagerOptions {
+	return func(c *ConsumerManager) {
+		c.statusStore = store
+	}
+}
+
 func NewConsumerManager(
 	logger log.Logger,
 	metricsClient metrics.Client,
@@ -112,6 +137,9 @@ type ConsumerManager struct {
 	domainCache               cache.DomainCache
 	queueProvider             queue.Provider
 	frontendClient            frontend.Client
+	queueManager              persistence.QueueManager
+	membershipResolver        membership.Resolver
+	statusStore               requeststatus.Store
 	refreshInterval           time.Duration
 	shutdownTimeout           time.Duration
 	ctx                       context.Context
@@ -220,9 +248,12 @@ func (c *ConsumerManager) refreshConsumers() {
 
 		c.logger.Info("Starting consumer", tag.WorkflowDomainName(domain.GetInfo().Name), tag.AsyncWFQueueID(queue.ID()))
 		consumer, err := queue.CreateConsumer(&provider.Params{
-			Logger:         c.logger,
-			MetricsClient:  c.metricsClient,
-			FrontendClient: c.frontendClient,
+			Logger:             c.logger,
+			MetricsClient:      c.metricsClient,
+			FrontendClient:     c.frontendClient,
+			QueueManager:       c.queueManager,
+			MembershipResolver: c.membershipResolver,
+			StatusStore:        c.statusStore,
 		})
 		if err != nil {
 			c.logger.Error("Failed to create consumer", tag.Error(err), tag.WorkflowDomainName(domain.GetInfo().Name), tag.AsyncWFQueueID(queue.ID()))
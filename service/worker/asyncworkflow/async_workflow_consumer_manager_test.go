@@ -39,6 +39,7 @@ import (
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
@@ -399,6 +400,52 @@ func TestConsumerManagerEnabledDisabled(t *testing.T) {
 	}
 }
 
+func TestConsumerManagerDatabaseQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDomainCache := cache.NewMockDomainCache(ctrl)
+	mockQueueProvider := queue.NewMockProvider(ctrl)
+	mockQueueManager := persistence.NewMockQueueManager(ctrl)
+	mockResolver := membership.NewMockResolver(ctrl)
+
+	dwc := domainWithConfig{
+		name: "domain1",
+		asyncWFCfg: types.AsyncWorkflowConfiguration{
+			Enabled:   true,
+			QueueType: "database",
+		},
+	}
+	mockDomainCache.EXPECT().GetAllDomain().Return(toDomainCacheEntries([]domainWithConfig{dwc}))
+	queueMock := provider.NewMockQueue(ctrl)
+	queueMock.EXPECT().ID().Return("database").AnyTimes()
+	mockQueueProvider.EXPECT().GetQueue("database", nil).Return(queueMock, nil)
+	mockConsumer := provider.NewMockConsumer(ctrl)
+	mockConsumer.EXPECT().Start().Return(nil)
+	queueMock.EXPECT().CreateConsumer(gomock.Any()).DoAndReturn(func(p *provider.Params) (provider.Consumer, error) {
+		if p.QueueManager != mockQueueManager {
+			t.Error("queue manager is not passed to the consumer")
+		}
+		if p.MembershipResolver != mockResolver {
+			t.Error("membership resolver is not passed to the consumer")
+		}
+		return mockConsumer, nil
+	})
+
+	cm := NewConsumerManager(
+		testlogger.New(t),
+		metrics.NewNoopMetricsClient(),
+		mockDomainCache,
+		mockQueueProvider,
+		nil,
+		WithQueueManager(mockQueueManager),
+		WithMembershipResolver(mockResolver),
+	)
+	cm.refreshConsumers()
+
+	if diff := cmpQueueIDs(cm.activeConsumers, []string{"database"}); diff != "" {
+		t.Errorf("Active consumers mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func toDomainCacheEntries(domains []domainWithConfig) map[string]*cache.DomainCacheEntry {
 	result := make(map[string]*cache.DomainCacheEntry, len(domains))
 	for _, d := range domains {
@@ -68,9 +68,31 @@ func (w *dw) identifyIssues(ctx context.Context, info identifyIssuesParams) ([]i
 		result = append(result, issues...)
 	}
 
+	for _, name := range w.enabledPluginsFor(info.Domain) {
+		issues, err := w.plugins[name].Check(ctx, invariant.InvariantCheckInput{
+			WorkflowExecutionHistory: history,
+			Domain:                   info.Domain,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("plugin %v: %w", name, err)
+		}
+		for _, issue := range issues {
+			issue.Plugin = name
+			result = append(result, issue)
+		}
+	}
+
 	return result, nil
 }
 
+// enabledPluginsFor returns the names of the plugins enabled for a domain
+func (w *dw) enabledPluginsFor(domain string) []string {
+	if w.enabledPlugins == nil || len(w.plugins) == 0 {
+		return nil
+	}
+	return w.plugins.Enabled(w.enabledPlugins(domain))
+}
+
 func (w *dw) getWorkflowExecutionHistory(ctx context.Context, execution *types.WorkflowExecution, domain string) (*types.GetWorkflowExecutionHistoryResponse, error) {
 	frontendClient := w.clientBean.GetFrontendClient()
 	var nextPageToken []byte
@@ -119,10 +141,20 @@ type rootCauseIssuesParams struct {
 func (w *dw) rootCauseIssues(ctx context.Context, info rootCauseIssuesParams) ([]invariant.InvariantRootCauseResult, error) {
 	result := make([]invariant.InvariantRootCauseResult, 0)
 
+	var builtinIssues []invariant.InvariantCheckResult
+	pluginIssues := make(map[string][]invariant.InvariantCheckResult)
+	for _, issue := range info.Issues {
+		if issue.Plugin == "" {
+			builtinIssues = append(builtinIssues, issue)
+		} else {
+			pluginIssues[issue.Plugin] = append(pluginIssues[issue.Plugin], issue)
+		}
+	}
+
 	for _, inv := range w.invariants {
 		rootCause, err := inv.RootCause(ctx, invariant.InvariantRootCauseInput{
 			Domain: info.Domain,
-			Issues: info.Issues,
+			Issues: builtinIssues,
 		})
 		if err != nil {
 			return nil, err
@@ -130,6 +162,24 @@ func (w *dw) rootCauseIssues(ctx context.Context, info rootCauseIssuesParams) ([
 		result = append(result, rootCause...)
 	}
 
+	// plugins only see their own issues, as their issue IDs and types are not coordinated with anything else
+	for _, name := range w.enabledPluginsFor(info.Domain) {
+		if len(pluginIssues[name]) == 0 {
+			continue
+		}
+		rootCauses, err := w.plugins[name].RootCause(ctx, invariant.InvariantRootCauseInput{
+			Domain: info.Domain,
+			Issues: pluginIssues[name],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("plugin %v: %w", name, err)
+		}
+		for _, rc := range rootCauses {
+			rc.Plugin = name
+			result = append(result, rc)
+		}
+	}
+
 	return result, nil
 }
 
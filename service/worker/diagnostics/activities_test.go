@@ -40,6 +40,7 @@ import (
 	"github.com/uber/cadence/service/worker/diagnostics/invariant"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/rule"
 )
 
 const (
@@ -118,6 +119,47 @@ func Test__rootCauseIssues(t *testing.T) {
 	require.Equal(t, expectedRootCause, result)
 }
 
+func Test__plugins(t *testing.T) {
+	dwtest := testDiagnosticWorkflow(t)
+	enabled, err := rule.NewInvariant(rule.Rule{
+		Name:        "generic-activity-failure",
+		Description: "activity failed with a generic error",
+		RootCause:   "activity code returned a generic error",
+		Reason:      "Generic$",
+	})
+	require.NoError(t, err)
+	disabled, err := rule.NewInvariant(rule.Rule{
+		Name:        "disabled",
+		Description: "matches everything, but is not enabled",
+	})
+	require.NoError(t, err)
+	dwtest.plugins = invariant.Plugins{"generic-activity-failure": enabled, "disabled": disabled}
+	dwtest.enabledPlugins = func(domain string) map[string]interface{} {
+		require.Equal(t, "test-domain", domain)
+		return map[string]interface{}{"generic-activity-failure": true, "disabled": false}
+	}
+
+	issues, err := dwtest.identifyIssues(context.Background(), identifyIssuesParams{
+		Execution: &types.WorkflowExecution{WorkflowID: "123", RunID: "abc"},
+		Domain:    "test-domain",
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 3) // two built-in, one plugin
+	pluginIssue := issues[2]
+	require.Equal(t, "generic-activity-failure", pluginIssue.Plugin)
+	require.Equal(t, "generic-activity-failure", pluginIssue.InvariantType)
+	require.Equal(t, "activity failed with a generic error", pluginIssue.Reason)
+	require.JSONEq(t, `{"EventIDs":[4]}`, string(pluginIssue.Metadata))
+
+	rootCauses, err := dwtest.rootCauseIssues(context.Background(), rootCauseIssuesParams{Domain: "test-domain", Issues: issues})
+	require.NoError(t, err)
+	require.Contains(t, rootCauses, invariant.InvariantRootCauseResult{
+		IssueID:   0,
+		RootCause: "activity code returned a generic error",
+		Plugin:    "generic-activity-failure",
+	})
+}
+
 func Test__emit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	dwtest := testDiagnosticWorkflow(t)
@@ -0,0 +1,209 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+func (w *dw) listWorkflowsForBatch(ctx context.Context, params listWorkflowsForBatchParams) (*listWorkflowsForBatchResult, error) {
+	resp, err := w.clientBean.GetFrontendClient().ScanWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+		Domain:        params.Domain,
+		PageSize:      int32(params.PageSize),
+		NextPageToken: params.NextPageToken,
+		Query:         params.Query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &listWorkflowsForBatchResult{
+		Executions:    make([]*types.WorkflowExecution, 0, len(resp.GetExecutions())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, info := range resp.GetExecutions() {
+		result.Executions = append(result.Executions, info.Execution)
+	}
+	return result, nil
+}
+
+// diagnoseWorkflowsBatch diagnoses a page of workflows concurrently, writes the details to the blobstore
+// and returns a partial report with the counts for this page.
+// Workflows which fail to be diagnosed are counted and reported, rather than failing the whole page.
+func (w *dw) diagnoseWorkflowsBatch(ctx context.Context, params diagnoseWorkflowsBatchParams) (*DiagnosticsBatchReport, error) {
+	details := make([]*BatchWorkflowDetails, len(params.Executions))
+	sem := make(chan struct{}, max(params.Concurrency, 1))
+	var diagnosed atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i, execution := range params.Executions {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				details[i] = w.diagnoseForBatch(ctx, params.Domain, execution)
+				diagnosed.Add(1)
+			}()
+		}
+		wg.Wait()
+	}()
+	// the slowest diagnoses of a page can take longer than the heartbeat timeout
+	heartbeatUntil(done, batchHeartbeatInterval, func() {
+		activity.RecordHeartbeat(ctx, int(diagnosed.Load()))
+	})
+
+	report := &DiagnosticsBatchReport{}
+	body := &bytes.Buffer{}
+	for _, d := range details {
+		report.add(d)
+		data, err := json.Marshal(d)
+		if err != nil {
+			return nil, err
+		}
+		body.Write(data)
+		body.WriteByte('\n')
+	}
+	report.summarize()
+
+	if w.blobstoreClient == nil {
+		w.logger.Warn("blobstore client is not provided, skipping writing batch diagnostics details", tag.WorkflowDomainName(params.Domain))
+		return report, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, _contextTimeout)
+	defer cancel()
+	_, err := w.blobstoreClient.Put(ctx, &blobstore.PutRequest{
+		Key: params.DetailsKey,
+		Blob: blobstore.Blob{
+			Tags: map[string]string{"domain": params.Domain},
+			Body: body.Bytes(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.DetailsKeys = []string{params.DetailsKey}
+	return report, nil
+}
+
+// heartbeatUntil calls heartbeat every interval until done is closed
+func heartbeatUntil(done <-chan struct{}, interval time.Duration, heartbeat func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			heartbeat()
+		}
+	}
+}
+
+func (w *dw) diagnoseForBatch(ctx context.Context, domain string, execution *types.WorkflowExecution) *BatchWorkflowDetails {
+	ctx, cancel := context.WithTimeout(ctx, _contextTimeout)
+	defer cancel()
+	details := &BatchWorkflowDetails{Execution: execution}
+	issues, err := w.identifyIssues(ctx, identifyIssuesParams{Execution: execution, Domain: domain})
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+	rootCauses, err := w.rootCauseIssues(ctx, rootCauseIssuesParams{Domain: domain, Issues: issues})
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+	result, err := buildDiagnosticsResult(issues, rootCauses)
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+	details.Result = result
+	details.issues, details.rootCauses = issues, rootCauses
+	return details
+}
+
+// add counts the issues and root causes of a single workflow
+func (r *DiagnosticsBatchReport) add(details *BatchWorkflowDetails) {
+	if details.Error != "" {
+		r.WorkflowsFailed++
+		return
+	}
+	r.WorkflowsDiagnosed++
+	if len(details.issues) > 0 {
+		r.WorkflowsWithIssues++
+	}
+	for _, issue := range details.issues {
+		r.addIssue(issue.InvariantType, issue.Reason, activityTypeOf(issue.Metadata), issue.Plugin, 1)
+	}
+	for _, rc := range details.rootCauses {
+		r.addRootCause(rc.RootCause.String(), rootCauseActivityType(rc, details.issues), rc.Plugin, 1)
+	}
+}
+
+// activityTypeOf extracts the activity type from issue metadata, for the invariants which report it
+func activityTypeOf(metadata []byte) string {
+	var data struct {
+		ActivityType string
+	}
+	if err := json.Unmarshal(metadata, &data); err != nil {
+		return ""
+	}
+	return data.ActivityType
+}
+
+// rootCauseActivityType attributes a root cause to an activity type through its issue.
+// Issue IDs are only unique per invariant, so this is only done when the matching issues agree.
+func rootCauseActivityType(rc invariant.InvariantRootCauseResult, issues []invariant.InvariantCheckResult) string {
+	activityType := ""
+	for _, issue := range issues {
+		if issue.IssueID != rc.IssueID || issue.Plugin != rc.Plugin {
+			continue
+		}
+		issueActivityType := activityTypeOf(issue.Metadata)
+		if issueActivityType == "" {
+			continue
+		}
+		if activityType != "" && activityType != issueActivityType {
+			return ""
+		}
+		activityType = issueActivityType
+	}
+	return activityType
+}
@@ -0,0 +1,204 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
+)
+
+func Test__listWorkflowsForBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClientBean := client.NewMockBean(ctrl)
+	mockFrontendClient := frontend.NewMockClient(ctrl)
+	mockClientBean.EXPECT().GetFrontendClient().Return(mockFrontendClient)
+	mockFrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), &types.ListWorkflowExecutionsRequest{
+		Domain:        "test-domain",
+		PageSize:      10,
+		NextPageToken: []byte("token"),
+		Query:         "WorkflowType='test'",
+	}).Return(&types.ListWorkflowExecutionsResponse{
+		Executions:    []*types.WorkflowExecutionInfo{{Execution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"}}},
+		NextPageToken: []byte("next"),
+	}, nil)
+	dwtest := &dw{clientBean: mockClientBean}
+
+	result, err := dwtest.listWorkflowsForBatch(context.Background(), listWorkflowsForBatchParams{
+		Domain:        "test-domain",
+		Query:         "WorkflowType='test'",
+		PageSize:      10,
+		NextPageToken: []byte("token"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, &listWorkflowsForBatchResult{
+		Executions:    []*types.WorkflowExecution{{WorkflowID: "wid", RunID: "rid"}},
+		NextPageToken: []byte("next"),
+	}, result)
+}
+
+func Test__diagnoseWorkflowsBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClientBean := client.NewMockBean(ctrl)
+	mockFrontendClient := frontend.NewMockClient(ctrl)
+	mockClientBean.EXPECT().GetFrontendClient().Return(mockFrontendClient).AnyTimes()
+	mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *types.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*types.GetWorkflowExecutionHistoryResponse, error) {
+			if req.Execution.GetWorkflowID() == "deleted" {
+				return nil, &types.EntityNotExistsError{}
+			}
+			return testWorkflowExecutionHistoryResponse(), nil
+		}).Times(3)
+	var written []byte
+	mockBlobstore := &blobstore.MockClient{}
+	mockBlobstore.On("Put", mock.Anything, mock.MatchedBy(func(req *blobstore.PutRequest) bool {
+		written = req.Blob.Body
+		return req.Key == "key"
+	})).Return(&blobstore.PutResponse{}, nil).Once()
+	dwtest := &dw{
+		clientBean:      mockClientBean,
+		blobstoreClient: mockBlobstore,
+		logger:          testlogger.New(t),
+		invariants:      []invariant.Invariant{failure.NewInvariant(), retry.NewInvariant()},
+	}
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivityWithOptions(dwtest.diagnoseWorkflowsBatch, activity.RegisterOptions{Name: diagnoseWorkflowsBatchActivity})
+	value, err := env.ExecuteActivity(diagnoseWorkflowsBatchActivity, diagnoseWorkflowsBatchParams{
+		Domain:      "test-domain",
+		Executions:  []*types.WorkflowExecution{{WorkflowID: "wid1"}, {WorkflowID: "deleted"}, {WorkflowID: "wid2"}},
+		Concurrency: 2,
+		DetailsKey:  "key",
+	})
+	require.NoError(t, err)
+	var report DiagnosticsBatchReport
+	require.NoError(t, value.Get(&report))
+	mockBlobstore.AssertExpectations(t)
+
+	require.Equal(t, 2, report.WorkflowsDiagnosed)
+	require.Equal(t, 2, report.WorkflowsWithIssues)
+	require.Equal(t, 1, report.WorkflowsFailed)
+	require.Equal(t, []string{"key"}, report.DetailsKeys)
+	require.Contains(t, report.Issues, &BatchIssueSummary{
+		InvariantType: failure.ActivityFailed.String(),
+		Reason:        failure.GenericError.String(),
+		ActivityType:  "test-activity",
+		Count:         2,
+		Percentage:    100,
+	})
+
+	var details []*BatchWorkflowDetails
+	scanner := bufio.NewScanner(bytes.NewReader(written))
+	for scanner.Scan() {
+		var d BatchWorkflowDetails
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &d))
+		details = append(details, &d)
+	}
+	require.Len(t, details, 3)
+	require.Equal(t, "wid1", details[0].Execution.GetWorkflowID())
+	require.NotNil(t, details[0].Result)
+	require.Equal(t, "deleted", details[1].Execution.GetWorkflowID())
+	require.NotEmpty(t, details[1].Error)
+}
+
+func Test__diagnoseWorkflowsBatch_BlobstoreError(t *testing.T) {
+	mockBlobstore := &blobstore.MockClient{}
+	mockBlobstore.On("Put", mock.Anything, mock.Anything).Return(nil, errors.New("mockErr"))
+	dwtest := &dw{blobstoreClient: mockBlobstore, logger: testlogger.New(t)}
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivityWithOptions(dwtest.diagnoseWorkflowsBatch, activity.RegisterOptions{Name: diagnoseWorkflowsBatchActivity})
+	_, err := env.ExecuteActivity(diagnoseWorkflowsBatchActivity, diagnoseWorkflowsBatchParams{Domain: "test-domain", DetailsKey: "key"})
+	require.ErrorContains(t, err, "mockErr")
+}
+
+func Test__heartbeatUntil(t *testing.T) {
+	done := make(chan struct{})
+	heartbeats := make(chan struct{})
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		heartbeatUntil(done, time.Millisecond, func() {
+			heartbeats <- struct{}{}
+		})
+	}()
+
+	// keeps heartbeating while the diagnoses are running
+	for i := 0; i < 3; i++ {
+		select {
+		case <-heartbeats:
+		case <-time.After(time.Second):
+			t.Fatal("expected a heartbeat while waiting")
+		}
+	}
+
+	close(done)
+	for {
+		select {
+		case <-heartbeats:
+		case <-returned:
+			return
+		case <-time.After(time.Second):
+			t.Fatal("expected heartbeating to stop once done")
+		}
+	}
+}
+
+func Test__rootCauseActivityType(t *testing.T) {
+	metadata := func(activityType string) []byte {
+		data, err := json.Marshal(failure.FailureIssuesMetadata{ActivityType: activityType})
+		require.NoError(t, err)
+		return data
+	}
+	issues := []invariant.InvariantCheckResult{
+		{IssueID: 0, Metadata: metadata("act1")},
+		{IssueID: 1, Metadata: metadata("act1")},
+		{IssueID: 1, Metadata: metadata("act2")},
+		{IssueID: 2, Metadata: []byte("{}")},
+	}
+	require.Equal(t, "act1", rootCauseActivityType(invariant.InvariantRootCauseResult{IssueID: 0}, issues))
+	require.Equal(t, "", rootCauseActivityType(invariant.InvariantRootCauseResult{IssueID: 1}, issues))
+	require.Equal(t, "", rootCauseActivityType(invariant.InvariantRootCauseResult{IssueID: 2}, issues))
+	require.Equal(t, "", rootCauseActivityType(invariant.InvariantRootCauseResult{IssueID: 0, Plugin: "custom"}, issues))
+}
@@ -0,0 +1,317 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	// DiagnosticsBatchWorkflowTypeName is the workflow type diagnosing all workflows matching a visibility query
+	DiagnosticsBatchWorkflowTypeName = "diagnostics-batch-workflow"
+	// DiagnosticsTaskListName is the tasklist diagnostics workflows run on, in the system domain
+	DiagnosticsTaskListName = tasklist
+	// QueryDiagnosticsBatchReport returns the current DiagnosticsBatchReport of a batch workflow
+	QueryDiagnosticsBatchReport = "query-diagnostics-batch-report"
+
+	listWorkflowsForBatchActivity  = "listWorkflowsForBatchDiagnostics"
+	diagnoseWorkflowsBatchActivity = "diagnoseWorkflowsBatch"
+
+	defaultBatchPageSize    = 100
+	defaultBatchConcurrency = 10
+	// batchPagesPerRun bounds the history size of the batch workflow, which continues as new after this many pages
+	batchPagesPerRun = 50
+	// batchTopFindings is how many of the most common root causes are highlighted in the report
+	batchTopFindings = 5
+	// batchHeartbeatInterval is well within the heartbeat timeout of the batch activities
+	batchHeartbeatInterval = 10 * time.Second
+)
+
+// DiagnosticsBatchWorkflowInput is the input of the batch diagnostics workflow
+type DiagnosticsBatchWorkflowInput struct {
+	Domain      string
+	Query       string
+	PageSize    int
+	Concurrency int
+	// MaxWorkflows limits how many workflows are diagnosed, 0 means no limit
+	MaxWorkflows int
+
+	// NextPageToken and Report carry the progress over when continuing as new
+	NextPageToken []byte
+	Report        *DiagnosticsBatchReport
+}
+
+// DiagnosticsBatchReport aggregates the diagnostics of all workflows matching the query
+type DiagnosticsBatchReport struct {
+	Domain    string
+	Query     string
+	Completed bool
+
+	WorkflowsDiagnosed  int
+	WorkflowsWithIssues int
+	// WorkflowsFailed could not be diagnosed, e.g. because their history was deleted
+	WorkflowsFailed int
+
+	Issues     []*BatchIssueSummary
+	RootCauses []*BatchRootCauseSummary
+	// Findings are human-readable highlights of the most common root causes
+	Findings []string
+
+	// DetailsKeys are the blobstore keys of the per-workflow diagnostics, one BatchWorkflowDetails per line
+	DetailsKeys []string
+}
+
+// BatchIssueSummary counts an issue across workflows
+type BatchIssueSummary struct {
+	InvariantType string
+	Reason        string
+	ActivityType  string
+	Plugin        string
+	Count         int
+	// Percentage is the share of issues of the same invariant type
+	Percentage float64
+}
+
+// BatchRootCauseSummary counts a root cause across workflows
+type BatchRootCauseSummary struct {
+	RootCause    string
+	ActivityType string
+	Plugin       string
+	Count        int
+	// Percentage is the share of all root causes
+	Percentage float64
+}
+
+// BatchWorkflowDetails are the diagnostics of a single workflow, written to the blobstore
+type BatchWorkflowDetails struct {
+	Execution *types.WorkflowExecution
+	Result    *DiagnosticsWorkflowResult `json:",omitempty"`
+	Error     string                     `json:",omitempty"`
+
+	// raw results, used to aggregate the report
+	issues     []invariant.InvariantCheckResult
+	rootCauses []invariant.InvariantRootCauseResult
+}
+
+type listWorkflowsForBatchParams struct {
+	Domain        string
+	Query         string
+	PageSize      int
+	NextPageToken []byte
+}
+
+type listWorkflowsForBatchResult struct {
+	Executions    []*types.WorkflowExecution
+	NextPageToken []byte
+}
+
+type diagnoseWorkflowsBatchParams struct {
+	Domain      string
+	Executions  []*types.WorkflowExecution
+	Concurrency int
+	// DetailsKey is the blobstore key to write the per-workflow diagnostics to
+	DetailsKey string
+}
+
+// DiagnosticsBatchWorkflow diagnoses every workflow matching a visibility query, page by page,
+// and aggregates the results into a report which can be queried while it runs.
+func (w *dw) DiagnosticsBatchWorkflow(ctx workflow.Context, params DiagnosticsBatchWorkflowInput) (*DiagnosticsBatchReport, error) {
+	report := params.Report
+	if report == nil {
+		report = &DiagnosticsBatchReport{Domain: params.Domain, Query: params.Query}
+	}
+	err := workflow.SetQueryHandler(ctx, QueryDiagnosticsBatchReport, func() (*DiagnosticsBatchReport, error) {
+		return report, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultBatchPageSize
+	}
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		HeartbeatTimeout:       time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			ExpirationInterval: 30 * time.Minute,
+		},
+	})
+	workflowID := workflow.GetInfo(ctx).WorkflowExecution.ID
+
+	nextPageToken := params.NextPageToken
+	for page := 0; page < batchPagesPerRun; page++ {
+		limit := pageSize
+		if params.MaxWorkflows > 0 {
+			limit = min(limit, params.MaxWorkflows-report.WorkflowsDiagnosed-report.WorkflowsFailed)
+			if limit <= 0 {
+				break
+			}
+		}
+
+		var listed listWorkflowsForBatchResult
+		err := workflow.ExecuteActivity(activityCtx, listWorkflowsForBatchActivity, listWorkflowsForBatchParams{
+			Domain:        params.Domain,
+			Query:         params.Query,
+			PageSize:      limit,
+			NextPageToken: nextPageToken,
+		}).Get(ctx, &listed)
+		if err != nil {
+			return nil, fmt.Errorf("ListWorkflows: %w", err)
+		}
+
+		if len(listed.Executions) > 0 {
+			var pageReport DiagnosticsBatchReport
+			err = workflow.ExecuteActivity(activityCtx, diagnoseWorkflowsBatchActivity, diagnoseWorkflowsBatchParams{
+				Domain:      params.Domain,
+				Executions:  listed.Executions,
+				Concurrency: concurrency,
+				DetailsKey:  batchDetailsKey(workflowID, report),
+			}).Get(ctx, &pageReport)
+			if err != nil {
+				return nil, fmt.Errorf("DiagnoseWorkflows: %w", err)
+			}
+			report.merge(&pageReport)
+		}
+
+		nextPageToken = listed.NextPageToken
+		if len(nextPageToken) == 0 {
+			report.Completed = true
+			return report, nil
+		}
+	}
+
+	if params.MaxWorkflows > 0 && report.WorkflowsDiagnosed+report.WorkflowsFailed >= params.MaxWorkflows {
+		report.Completed = true
+		return report, nil
+	}
+	params.NextPageToken = nextPageToken
+	params.Report = report
+	return nil, workflow.NewContinueAsNewError(ctx, DiagnosticsBatchWorkflowTypeName, params)
+}
+
+// batchDetailsKey returns a unique blobstore key for the next page of details, as keys are flat for file based blobstores
+func batchDetailsKey(workflowID string, report *DiagnosticsBatchReport) string {
+	return fmt.Sprintf("%v_%v.diagnostics", workflowID, len(report.DetailsKeys))
+}
+
+// merge adds the counts of a partial report, and refreshes the percentages and findings
+func (r *DiagnosticsBatchReport) merge(other *DiagnosticsBatchReport) {
+	r.WorkflowsDiagnosed += other.WorkflowsDiagnosed
+	r.WorkflowsWithIssues += other.WorkflowsWithIssues
+	r.WorkflowsFailed += other.WorkflowsFailed
+	r.DetailsKeys = append(r.DetailsKeys, other.DetailsKeys...)
+
+	for _, issue := range other.Issues {
+		r.addIssue(issue.InvariantType, issue.Reason, issue.ActivityType, issue.Plugin, issue.Count)
+	}
+	for _, rc := range other.RootCauses {
+		r.addRootCause(rc.RootCause, rc.ActivityType, rc.Plugin, rc.Count)
+	}
+	r.summarize()
+}
+
+func (r *DiagnosticsBatchReport) addIssue(invariantType, reason, activityType, plugin string, count int) {
+	for _, issue := range r.Issues {
+		if issue.InvariantType == invariantType && issue.Reason == reason && issue.ActivityType == activityType && issue.Plugin == plugin {
+			issue.Count += count
+			return
+		}
+	}
+	r.Issues = append(r.Issues, &BatchIssueSummary{
+		InvariantType: invariantType,
+		Reason:        reason,
+		ActivityType:  activityType,
+		Plugin:        plugin,
+		Count:         count,
+	})
+}
+
+func (r *DiagnosticsBatchReport) addRootCause(rootCause, activityType, plugin string, count int) {
+	for _, rc := range r.RootCauses {
+		if rc.RootCause == rootCause && rc.ActivityType == activityType && rc.Plugin == plugin {
+			rc.Count += count
+			return
+		}
+	}
+	r.RootCauses = append(r.RootCauses, &BatchRootCauseSummary{
+		RootCause:    rootCause,
+		ActivityType: activityType,
+		Plugin:       plugin,
+		Count:        count,
+	})
+}
+
+// summarize computes percentages, sorts by count, and highlights the most common root causes
+func (r *DiagnosticsBatchReport) summarize() {
+	issuesByType := make(map[string]int)
+	for _, issue := range r.Issues {
+		issuesByType[issue.InvariantType] += issue.Count
+	}
+	for _, issue := range r.Issues {
+		issue.Percentage = percentage(issue.Count, issuesByType[issue.InvariantType])
+	}
+	sort.SliceStable(r.Issues, func(i, j int) bool { return r.Issues[i].Count > r.Issues[j].Count })
+
+	totalRootCauses := 0
+	for _, rc := range r.RootCauses {
+		totalRootCauses += rc.Count
+	}
+	for _, rc := range r.RootCauses {
+		rc.Percentage = percentage(rc.Count, totalRootCauses)
+	}
+	sort.SliceStable(r.RootCauses, func(i, j int) bool { return r.RootCauses[i].Count > r.RootCauses[j].Count })
+
+	r.Findings = nil
+	for _, rc := range r.RootCauses[:min(batchTopFindings, len(r.RootCauses))] {
+		finding := fmt.Sprintf("%.0f%% of root causes (%d): %v", rc.Percentage, rc.Count, rc.RootCause)
+		if rc.ActivityType != "" {
+			finding += fmt.Sprintf(", on activity type %v", rc.ActivityType)
+		}
+		r.Findings = append(r.Findings, finding)
+	}
+}
+
+func percentage(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
@@ -0,0 +1,150 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type diagnosticsBatchWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+	dw          *dw
+}
+
+func TestDiagnosticsBatchWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(diagnosticsBatchWorkflowTestSuite))
+}
+
+func (s *diagnosticsBatchWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	s.dw = &dw{}
+	s.workflowEnv.RegisterWorkflowWithOptions(s.dw.DiagnosticsBatchWorkflow, workflow.RegisterOptions{Name: DiagnosticsBatchWorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(s.dw.listWorkflowsForBatch, activity.RegisterOptions{Name: listWorkflowsForBatchActivity})
+	s.workflowEnv.RegisterActivityWithOptions(s.dw.diagnoseWorkflowsBatch, activity.RegisterOptions{Name: diagnoseWorkflowsBatchActivity})
+}
+
+func (s *diagnosticsBatchWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *diagnosticsBatchWorkflowTestSuite) TestWorkflow() {
+	executions := []*types.WorkflowExecution{{WorkflowID: "wid1", RunID: "rid1"}, {WorkflowID: "wid2", RunID: "rid2"}}
+	s.workflowEnv.OnActivity(listWorkflowsForBatchActivity, mock.Anything, mock.MatchedBy(func(p listWorkflowsForBatchParams) bool {
+		return p.NextPageToken == nil
+	})).Return(&listWorkflowsForBatchResult{Executions: executions[:1], NextPageToken: []byte("token")}, nil).Once()
+	s.workflowEnv.OnActivity(listWorkflowsForBatchActivity, mock.Anything, mock.MatchedBy(func(p listWorkflowsForBatchParams) bool {
+		return string(p.NextPageToken) == "token"
+	})).Return(&listWorkflowsForBatchResult{Executions: executions[1:]}, nil).Once()
+	s.workflowEnv.OnActivity(diagnoseWorkflowsBatchActivity, mock.Anything, mock.Anything).Return(func(_ context.Context, p diagnoseWorkflowsBatchParams) (*DiagnosticsBatchReport, error) {
+		return &DiagnosticsBatchReport{
+			WorkflowsDiagnosed:  1,
+			WorkflowsWithIssues: 1,
+			RootCauses:          []*BatchRootCauseSummary{{RootCause: "MISSING_HEARTBEAT", ActivityType: "act", Count: 1}},
+			DetailsKeys:         []string{p.DetailsKey},
+		}, nil
+	}).Twice()
+
+	s.workflowEnv.ExecuteWorkflow(DiagnosticsBatchWorkflowTypeName, DiagnosticsBatchWorkflowInput{Domain: "test-domain", Query: "WorkflowType='test'"})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var report DiagnosticsBatchReport
+	s.NoError(s.workflowEnv.GetWorkflowResult(&report))
+	s.True(report.Completed)
+	s.Equal("test-domain", report.Domain)
+	s.Equal(2, report.WorkflowsDiagnosed)
+	s.Equal(2, report.WorkflowsWithIssues)
+	s.Equal([]*BatchRootCauseSummary{{RootCause: "MISSING_HEARTBEAT", ActivityType: "act", Count: 2, Percentage: 100}}, report.RootCauses)
+	s.Equal([]string{"100% of root causes (2): MISSING_HEARTBEAT, on activity type act"}, report.Findings)
+	s.Len(report.DetailsKeys, 2)
+	s.NotEqual(report.DetailsKeys[0], report.DetailsKeys[1])
+
+	queryFuture, err := s.workflowEnv.QueryWorkflow(QueryDiagnosticsBatchReport)
+	s.NoError(err)
+	var queried DiagnosticsBatchReport
+	s.NoError(queryFuture.Get(&queried))
+	s.Equal(report, queried)
+}
+
+func (s *diagnosticsBatchWorkflowTestSuite) TestWorkflow_MaxWorkflows() {
+	s.workflowEnv.OnActivity(listWorkflowsForBatchActivity, mock.Anything, mock.MatchedBy(func(p listWorkflowsForBatchParams) bool {
+		return p.PageSize == 1
+	})).Return(&listWorkflowsForBatchResult{Executions: []*types.WorkflowExecution{{WorkflowID: "wid1"}}, NextPageToken: []byte("token")}, nil).Once()
+	s.workflowEnv.OnActivity(diagnoseWorkflowsBatchActivity, mock.Anything, mock.Anything).Return(&DiagnosticsBatchReport{WorkflowsFailed: 1}, nil).Once()
+
+	s.workflowEnv.ExecuteWorkflow(DiagnosticsBatchWorkflowTypeName, DiagnosticsBatchWorkflowInput{Domain: "test-domain", MaxWorkflows: 1})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var report DiagnosticsBatchReport
+	s.NoError(s.workflowEnv.GetWorkflowResult(&report))
+	s.True(report.Completed)
+	s.Equal(1, report.WorkflowsFailed)
+}
+
+func (s *diagnosticsBatchWorkflowTestSuite) TestWorkflow_Error() {
+	s.workflowEnv.OnActivity(listWorkflowsForBatchActivity, mock.Anything, mock.Anything).Return(nil, errors.New("mockErr"))
+	s.workflowEnv.ExecuteWorkflow(DiagnosticsBatchWorkflowTypeName, DiagnosticsBatchWorkflowInput{Domain: "test-domain"})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.ErrorContains(s.workflowEnv.GetWorkflowError(), "ListWorkflows")
+}
+
+func Test__batchReportSummarize(t *testing.T) {
+	report := &DiagnosticsBatchReport{}
+	report.merge(&DiagnosticsBatchReport{
+		WorkflowsDiagnosed: 10,
+		Issues: []*BatchIssueSummary{
+			{InvariantType: "ACTIVITY_TIMEOUT", Reason: "HEARTBEAT", ActivityType: "act", Count: 3},
+			{InvariantType: "ACTIVITY_TIMEOUT", Reason: "START_TO_CLOSE", ActivityType: "act", Count: 1},
+			{InvariantType: "ACTIVITY_FAILED", Reason: "GENERIC_ERROR", Count: 2},
+		},
+		RootCauses: []*BatchRootCauseSummary{
+			{RootCause: "MISSING_HEARTBEAT", ActivityType: "act", Count: 3},
+			{RootCause: "HEARTBEAT_TIMEOUT", Count: 4},
+		},
+	})
+	report.merge(&DiagnosticsBatchReport{
+		WorkflowsDiagnosed: 5,
+		RootCauses:         []*BatchRootCauseSummary{{RootCause: "MISSING_HEARTBEAT", ActivityType: "act", Count: 3}},
+	})
+
+	assert.Equal(t, 15, report.WorkflowsDiagnosed)
+	assert.Equal(t, []*BatchIssueSummary{
+		{InvariantType: "ACTIVITY_TIMEOUT", Reason: "HEARTBEAT", ActivityType: "act", Count: 3, Percentage: 75},
+		{InvariantType: "ACTIVITY_FAILED", Reason: "GENERIC_ERROR", Count: 2, Percentage: 100},
+		{InvariantType: "ACTIVITY_TIMEOUT", Reason: "START_TO_CLOSE", ActivityType: "act", Count: 1, Percentage: 25},
+	}, report.Issues)
+	assert.Equal(t, []string{
+		"60% of root causes (6): MISSING_HEARTBEAT, on activity type act",
+		"40% of root causes (4): HEARTBEAT_TIMEOUT",
+	}, report.Findings)
+}
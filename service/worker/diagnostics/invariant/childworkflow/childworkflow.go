@@ -0,0 +1,95 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package childworkflow
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+// ChildWorkflow is an invariant that will be used to identify child workflows which failed to start
+type ChildWorkflow invariant.Invariant
+
+type childWorkflow struct{}
+
+func NewInvariant() ChildWorkflow {
+	return &childWorkflow{}
+}
+
+func (c *childWorkflow) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	issueID := 0
+	attempts := make(map[string]int)
+	for _, event := range events {
+		attr := event.StartChildWorkflowExecutionFailedEventAttributes
+		if attr == nil {
+			continue
+		}
+		attempts[attr.WorkflowID]++
+		reason := ""
+		if attr.Cause != nil {
+			reason = attr.Cause.String()
+		}
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: ChildWorkflowStartFailed.String(),
+			Reason:        reason,
+			Metadata: invariant.MarshalData(StartFailedMetadata{
+				Domain:           attr.Domain,
+				WorkflowID:       attr.WorkflowID,
+				WorkflowType:     attr.WorkflowType.GetName(),
+				InitiatedEventID: attr.InitiatedEventID,
+				FailedEventID:    event.ID,
+				Attempts:         attempts[attr.WorkflowID],
+			}),
+		})
+		issueID++
+	}
+	return result, nil
+}
+
+func (c *childWorkflow) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		if issue.InvariantType != ChildWorkflowStartFailed.String() ||
+			issue.Reason != types.ChildWorkflowExecutionFailedCauseWorkflowAlreadyRunning.String() {
+			continue
+		}
+		var metadata StartFailedMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result = append(result, invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: invariant.RootCauseTypeChildWorkflowIDConflict,
+			Metadata: invariant.MarshalData(ChildWorkflowRootCauseMetadata{
+				EventIDs: []int64{metadata.InitiatedEventID, metadata.FailedEventID},
+			}),
+		})
+	}
+	return result, nil
+}
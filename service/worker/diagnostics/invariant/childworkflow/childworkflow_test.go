@@ -0,0 +1,116 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package childworkflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testDomain = "test-domain"
+)
+
+func Test__Check(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{ID: 1, WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		startFailed(3, 2),
+		startFailed(5, 4),
+	}
+	inv := NewInvariant()
+	result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+		WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: events}},
+		Domain:                   testDomain,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: ChildWorkflowStartFailed.String(),
+			Reason:        "WORKFLOW_ALREADY_RUNNING",
+			Metadata: invariant.MarshalData(StartFailedMetadata{
+				Domain:           testDomain,
+				WorkflowID:       "child-wid",
+				WorkflowType:     "child-type",
+				InitiatedEventID: 2,
+				FailedEventID:    3,
+				Attempts:         1,
+			}),
+		},
+		{
+			IssueID:       1,
+			InvariantType: ChildWorkflowStartFailed.String(),
+			Reason:        "WORKFLOW_ALREADY_RUNNING",
+			Metadata: invariant.MarshalData(StartFailedMetadata{
+				Domain:           testDomain,
+				WorkflowID:       "child-wid",
+				WorkflowType:     "child-type",
+				InitiatedEventID: 4,
+				FailedEventID:    5,
+				Attempts:         2,
+			}),
+		},
+	}, result)
+}
+
+func Test__RootCause(t *testing.T) {
+	inv := NewInvariant()
+	result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+		Domain: testDomain,
+		Issues: []invariant.InvariantCheckResult{
+			{
+				IssueID:       0,
+				InvariantType: ChildWorkflowStartFailed.String(),
+				Reason:        "WORKFLOW_ALREADY_RUNNING",
+				Metadata:      invariant.MarshalData(StartFailedMetadata{InitiatedEventID: 2, FailedEventID: 3}),
+			},
+			{IssueID: 1, InvariantType: "some other invariant", Reason: "WORKFLOW_ALREADY_RUNNING"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantRootCauseResult{
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeChildWorkflowIDConflict,
+			Metadata:  invariant.MarshalData(ChildWorkflowRootCauseMetadata{EventIDs: []int64{2, 3}}),
+		},
+	}, result)
+}
+
+func startFailed(id, initiatedID int64) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID: id,
+		StartChildWorkflowExecutionFailedEventAttributes: &types.StartChildWorkflowExecutionFailedEventAttributes{
+			Domain:           testDomain,
+			WorkflowID:       "child-wid",
+			WorkflowType:     &types.WorkflowType{Name: "child-type"},
+			Cause:            types.ChildWorkflowExecutionFailedCauseWorkflowAlreadyRunning.Ptr(),
+			InitiatedEventID: initiatedID,
+		},
+	}
+}
@@ -0,0 +1,172 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+// maxDetailsLength limits how much of the failure details are reported
+const maxDetailsLength = 1024
+
+// Decision is an invariant that will be used to identify decision task failures, including non-determinism, in the workflow execution history
+type Decision invariant.Invariant
+
+type decision struct{}
+
+func NewInvariant() Decision {
+	return &decision{}
+}
+
+func (d *decision) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	issueID := 0
+
+	// failures are grouped until a decision completes, as they are retries of the same decision
+	var failed []*types.HistoryEvent
+	flush := func(stuck bool) {
+		if len(failed) == 0 {
+			return
+		}
+		last := failed[len(failed)-1].DecisionTaskFailedEventAttributes
+		metadata := DecisionFailureMetadata{
+			Identity:       last.Identity,
+			BinaryChecksum: last.BinaryChecksum,
+			Details:        truncate(string(last.Details)),
+			Stuck:          stuck,
+		}
+		nonDeterministic := false
+		for _, event := range failed {
+			metadata.FailedEventIDs = append(metadata.FailedEventIDs, event.ID)
+			nonDeterministic = nonDeterministic || isNonDeterministic(event.DecisionTaskFailedEventAttributes)
+		}
+		invariantType := DecisionTaskFailed
+		if nonDeterministic {
+			invariantType = NonDeterministicDecision
+		}
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: invariantType.String(),
+			Reason:        last.GetCause().String(),
+			Metadata:      invariant.MarshalData(metadata),
+		})
+		issueID++
+		failed = nil
+	}
+
+	for _, event := range events {
+		if event.DecisionTaskCompletedEventAttributes != nil {
+			flush(false)
+		}
+		if attr := event.DecisionTaskFailedEventAttributes; attr != nil && !isBenignCause(attr.GetCause()) {
+			failed = append(failed, event)
+		}
+	}
+	flush(!invariant.IsWorkflowClosed(events))
+	return result, nil
+}
+
+func (d *decision) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		if issue.InvariantType != DecisionTaskFailed.String() && issue.InvariantType != NonDeterministicDecision.String() {
+			continue
+		}
+		var metadata DecisionFailureMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		rootCause := rootCauseFor(issue)
+		if rootCause == "" {
+			continue
+		}
+		result = append(result, invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: rootCause,
+			Metadata: invariant.MarshalData(DecisionRootCauseMetadata{
+				EventIDs:       metadata.FailedEventIDs,
+				BinaryChecksum: metadata.BinaryChecksum,
+			}),
+		})
+	}
+	return result, nil
+}
+
+func rootCauseFor(issue invariant.InvariantCheckResult) invariant.RootCause {
+	if issue.InvariantType == NonDeterministicDecision.String() {
+		return invariant.RootCauseTypeNonDeterminism
+	}
+	switch {
+	case issue.Reason == types.DecisionTaskFailedCauseBadBinary.String():
+		// the binary was marked as bad by the operator, which is intentional
+		return ""
+	case strings.HasPrefix(issue.Reason, "BAD_"), issue.Reason == types.DecisionTaskFailedCauseStartTimerDuplicateID.String():
+		return invariant.RootCauseTypeBadDecisionAttributes
+	case issue.Reason == types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String():
+		return invariant.RootCauseTypeWorkflowWorkerFailure
+	case issue.Reason == types.DecisionTaskFailedCauseUnhandledDecision.String():
+		return invariant.RootCauseTypeDecisionRaceWithNewEvents
+	}
+	return ""
+}
+
+// isBenignCause returns true for failures caused by the server rather than workflow code, which are retried transparently
+func isBenignCause(cause types.DecisionTaskFailedCause) bool {
+	switch cause {
+	case types.DecisionTaskFailedCauseResetStickyTasklist,
+		types.DecisionTaskFailedCauseForceCloseDecision,
+		types.DecisionTaskFailedCauseFailoverCloseDecision,
+		types.DecisionTaskFailedCauseResetWorkflow:
+		return true
+	}
+	return false
+}
+
+// isNonDeterministic detects the non-determinism errors reported by the client libraries, which are
+// sent as unhandled worker failures with the error in the details or reason
+func isNonDeterministic(attr *types.DecisionTaskFailedEventAttributes) bool {
+	if attr.GetCause() != types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure {
+		return false
+	}
+	for _, s := range []string{string(attr.Details), common.StringDefault(attr.Reason)} {
+		s = strings.ReplaceAll(strings.ToLower(s), "-", "")
+		if strings.Contains(s, "nondeterministic") || strings.Contains(s, "nondeterminism") {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string) string {
+	if len(s) > maxDetailsLength {
+		return s[:maxDetailsLength]
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testDomain = "test-domain"
+)
+
+func Test__Check(t *testing.T) {
+	testCases := []struct {
+		name           string
+		events         []*types.HistoryEvent
+		expectedResult []invariant.InvariantCheckResult
+	}{
+		{
+			name: "recovered failure and stuck non-determinism",
+			events: []*types.HistoryEvent{
+				decisionFailed(1, types.DecisionTaskFailedCauseBadScheduleActivityAttributes, "missing activity type"),
+				{ID: 2, DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+				decisionFailed(3, types.DecisionTaskFailedCauseResetStickyTasklist, ""),
+				decisionFailed(4, types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure, "panic: nondeterministic workflow: history event is ActivityTaskScheduled"),
+				decisionFailed(5, types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure, "panic: nondeterministic workflow: history event is ActivityTaskScheduled"),
+			},
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: DecisionTaskFailed.String(),
+					Reason:        "BAD_SCHEDULE_ACTIVITY_ATTRIBUTES",
+					Metadata: invariant.MarshalData(DecisionFailureMetadata{
+						FailedEventIDs: []int64{1},
+						Identity:       "localhost",
+						BinaryChecksum: "abc",
+						Details:        "missing activity type",
+					}),
+				},
+				{
+					IssueID:       1,
+					InvariantType: NonDeterministicDecision.String(),
+					Reason:        "WORKFLOW_WORKER_UNHANDLED_FAILURE",
+					Metadata: invariant.MarshalData(DecisionFailureMetadata{
+						FailedEventIDs: []int64{4, 5},
+						Identity:       "localhost",
+						BinaryChecksum: "abc",
+						Details:        "panic: nondeterministic workflow: history event is ActivityTaskScheduled",
+						Stuck:          true,
+					}),
+				},
+			},
+		},
+		{
+			name: "failures before the workflow closed are not stuck",
+			events: []*types.HistoryEvent{
+				decisionFailed(1, types.DecisionTaskFailedCauseUnhandledDecision, ""),
+				{ID: 2, WorkflowExecutionTerminatedEventAttributes: &types.WorkflowExecutionTerminatedEventAttributes{}},
+			},
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: DecisionTaskFailed.String(),
+					Reason:        "UNHANDLED_DECISION",
+					Metadata: invariant.MarshalData(DecisionFailureMetadata{
+						FailedEventIDs: []int64{1},
+						Identity:       "localhost",
+						BinaryChecksum: "abc",
+					}),
+				},
+			},
+		},
+		{
+			name: "no failures",
+			events: []*types.HistoryEvent{
+				{ID: 1, DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+			},
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := NewInvariant()
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: tc.events}},
+				Domain:                   testDomain,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func Test__RootCause(t *testing.T) {
+	issue := func(id int, invariantType DecisionIssueType, cause string, eventIDs ...int64) invariant.InvariantCheckResult {
+		return invariant.InvariantCheckResult{
+			IssueID:       id,
+			InvariantType: invariantType.String(),
+			Reason:        cause,
+			Metadata:      invariant.MarshalData(DecisionFailureMetadata{FailedEventIDs: eventIDs, BinaryChecksum: "abc"}),
+		}
+	}
+	rootCause := func(id int, rc invariant.RootCause, eventIDs ...int64) invariant.InvariantRootCauseResult {
+		return invariant.InvariantRootCauseResult{
+			IssueID:   id,
+			RootCause: rc,
+			Metadata:  invariant.MarshalData(DecisionRootCauseMetadata{EventIDs: eventIDs, BinaryChecksum: "abc"}),
+		}
+	}
+	inv := NewInvariant()
+	result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+		Domain: testDomain,
+		Issues: []invariant.InvariantCheckResult{
+			issue(0, NonDeterministicDecision, "WORKFLOW_WORKER_UNHANDLED_FAILURE", 4, 5),
+			issue(1, DecisionTaskFailed, "BAD_START_TIMER_ATTRIBUTES", 7),
+			issue(2, DecisionTaskFailed, "WORKFLOW_WORKER_UNHANDLED_FAILURE", 9),
+			issue(3, DecisionTaskFailed, "UNHANDLED_DECISION", 11),
+			issue(4, DecisionTaskFailed, "BAD_BINARY", 13),
+			{IssueID: 5, InvariantType: "some other invariant"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantRootCauseResult{
+		rootCause(0, invariant.RootCauseTypeNonDeterminism, 4, 5),
+		rootCause(1, invariant.RootCauseTypeBadDecisionAttributes, 7),
+		rootCause(2, invariant.RootCauseTypeWorkflowWorkerFailure, 9),
+		rootCause(3, invariant.RootCauseTypeDecisionRaceWithNewEvents, 11),
+	}, result)
+}
+
+func decisionFailed(id int64, cause types.DecisionTaskFailedCause, details string) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID: id,
+		DecisionTaskFailedEventAttributes: &types.DecisionTaskFailedEventAttributes{
+			Cause:          cause.Ptr(),
+			Details:        []byte(details),
+			Identity:       "localhost",
+			BinaryChecksum: "abc",
+		},
+	}
+}
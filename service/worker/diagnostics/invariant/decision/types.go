@@ -0,0 +1,51 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decision
+
+type DecisionIssueType string
+
+const (
+	DecisionTaskFailed       DecisionIssueType = "Decision task failed"
+	NonDeterministicDecision DecisionIssueType = "Decision task failed due to non-determinism"
+)
+
+func (d DecisionIssueType) String() string {
+	return string(d)
+}
+
+// DecisionFailureMetadata describes a run of consecutive decision task failures
+type DecisionFailureMetadata struct {
+	// FailedEventIDs are the DecisionTaskFailed events, in order
+	FailedEventIDs []int64
+	Identity       string
+	BinaryChecksum string
+	Details        string
+	// Stuck is set if no decision completed after the failures, i.e. the workflow is still retrying the decision
+	Stuck bool
+}
+
+// DecisionRootCauseMetadata points to the events related to a root cause
+type DecisionRootCauseMetadata struct {
+	EventIDs       []int64
+	BinaryChecksum string
+}
@@ -0,0 +1,191 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package historysize
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	// topEvents is how many of the largest events, or most frequent event types, are reported
+	topEvents = 5
+	// largeEventsRatio is the share of the history size the largest events must account for to be the root cause
+	largeEventsRatio = 0.5
+)
+
+// HistorySize is an invariant that will be used to identify workflows nearing the history size or count limits
+type HistorySize invariant.Invariant
+
+type historySize struct {
+	serializer      persistence.PayloadSerializer
+	countLimitWarn  dynamicproperties.IntPropertyFnWithDomainFilter
+	countLimitError dynamicproperties.IntPropertyFnWithDomainFilter
+	sizeLimitWarn   dynamicproperties.IntPropertyFnWithDomainFilter
+	sizeLimitError  dynamicproperties.IntPropertyFnWithDomainFilter
+}
+
+// Params are the per-domain history limits, which should match the ones enforced by the history service
+type Params struct {
+	CountLimitWarn  dynamicproperties.IntPropertyFnWithDomainFilter
+	CountLimitError dynamicproperties.IntPropertyFnWithDomainFilter
+	SizeLimitWarn   dynamicproperties.IntPropertyFnWithDomainFilter
+	SizeLimitError  dynamicproperties.IntPropertyFnWithDomainFilter
+}
+
+func NewInvariant(p Params) HistorySize {
+	return &historySize{
+		serializer:      persistence.NewPayloadSerializer(),
+		countLimitWarn:  p.CountLimitWarn,
+		countLimitError: p.CountLimitError,
+		sizeLimitWarn:   p.SizeLimitWarn,
+		sizeLimitError:  p.SizeLimitError,
+	}
+}
+
+func (h *historySize) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	issueID := 0
+
+	countWarn, countError := h.countLimitWarn(params.Domain), h.countLimitError(params.Domain)
+	if count := len(events); count >= countWarn {
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: HistoryCountLimit.String(),
+			Reason:        limitStatus(count, countError).String(),
+			Metadata: invariant.MarshalData(HistoryLimitMetadata{
+				Current:         count,
+				WarnLimit:       countWarn,
+				ErrorLimit:      countError,
+				EventTypeCounts: eventTypeCounts(events),
+			}),
+		})
+		issueID++
+	}
+
+	// the size is estimated from the events serialized one by one, as batches are not available here
+	sizes := make([]EventSize, 0, len(events))
+	total := 0
+	for _, event := range events {
+		blob, err := h.serializer.SerializeBatchEvents([]*types.HistoryEvent{event}, constants.EncodingTypeThriftRW)
+		if err != nil {
+			return nil, err
+		}
+		total += len(blob.Data)
+		sizes = append(sizes, EventSize{EventID: event.ID, EventType: event.GetEventType().String(), Size: len(blob.Data)})
+	}
+	sizeWarn, sizeError := h.sizeLimitWarn(params.Domain), h.sizeLimitError(params.Domain)
+	if total >= sizeWarn {
+		sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Size > sizes[j].Size })
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: HistorySizeLimit.String(),
+			Reason:        limitStatus(total, sizeError).String(),
+			Metadata: invariant.MarshalData(HistoryLimitMetadata{
+				Current:       total,
+				WarnLimit:     sizeWarn,
+				ErrorLimit:    sizeError,
+				LargestEvents: sizes[:min(topEvents, len(sizes))],
+			}),
+		})
+		issueID++
+	}
+	return result, nil
+}
+
+func (h *historySize) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		if issue.InvariantType != HistoryCountLimit.String() && issue.InvariantType != HistorySizeLimit.String() {
+			continue
+		}
+		var metadata HistoryLimitMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		rootCause := invariant.RootCauseTypeTooManyHistoryEvents
+		var eventIDs []int64
+		if issue.InvariantType == HistorySizeLimit.String() {
+			largest := 0
+			for _, event := range metadata.LargestEvents {
+				largest += event.Size
+				eventIDs = append(eventIDs, event.EventID)
+			}
+			if float64(largest) >= largeEventsRatio*float64(metadata.Current) {
+				rootCause = invariant.RootCauseTypeLargeHistoryEvents
+			} else {
+				eventIDs = nil
+			}
+		}
+		if rootCause == invariant.RootCauseTypeTooManyHistoryEvents && len(metadata.EventTypeCounts) > 0 {
+			// point at the range of the most frequent event type, which is usually what keeps the history growing
+			top := metadata.EventTypeCounts[0]
+			eventIDs = []int64{top.FirstEventID, top.LastEventID}
+		}
+		result = append(result, invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: rootCause,
+			Metadata:  invariant.MarshalData(HistoryRootCauseMetadata{EventIDs: eventIDs}),
+		})
+	}
+	return result, nil
+}
+
+func limitStatus(current, errorLimit int) LimitStatus {
+	if current >= errorLimit {
+		return ErrorLimitReached
+	}
+	return WarnLimitExceeded
+}
+
+func eventTypeCounts(events []*types.HistoryEvent) []EventTypeCount {
+	byType := make(map[types.EventType]*EventTypeCount)
+	for _, event := range events {
+		count, ok := byType[event.GetEventType()]
+		if !ok {
+			count = &EventTypeCount{EventType: event.GetEventType().String(), FirstEventID: event.ID}
+			byType[event.GetEventType()] = count
+		}
+		count.Count++
+		count.LastEventID = event.ID
+	}
+	result := make([]EventTypeCount, 0, len(byType))
+	for _, count := range byType {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].FirstEventID < result[j].FirstEventID
+	})
+	return result[:min(topEvents, len(result))]
+}
@@ -0,0 +1,157 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package historysize
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testDomain = "test-domain"
+)
+
+func Test__Check(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(), WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{Input: []byte(strings.Repeat("a", 1000))}},
+		{ID: 3, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(), WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{}},
+	}
+	testCases := []struct {
+		name          string
+		params        Params
+		expectedTypes []string
+		expectedCause []string
+	}{
+		{
+			name:   "below limits",
+			params: limits(10, 20, 10000, 20000),
+		},
+		{
+			name:          "count warn limit exceeded",
+			params:        limits(3, 20, 10000, 20000),
+			expectedTypes: []string{HistoryCountLimit.String()},
+			expectedCause: []string{WarnLimitExceeded.String()},
+		},
+		{
+			name:          "count and size error limits reached",
+			params:        limits(1, 2, 100, 200),
+			expectedTypes: []string{HistoryCountLimit.String(), HistorySizeLimit.String()},
+			expectedCause: []string{ErrorLimitReached.String(), ErrorLimitReached.String()},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := NewInvariant(tc.params)
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: events}},
+				Domain:                   testDomain,
+			})
+			require.NoError(t, err)
+			require.Len(t, result, len(tc.expectedTypes))
+			for i, issue := range result {
+				require.Equal(t, i, issue.IssueID)
+				require.Equal(t, tc.expectedTypes[i], issue.InvariantType)
+				require.Equal(t, tc.expectedCause[i], issue.Reason)
+				var metadata HistoryLimitMetadata
+				require.NoError(t, json.Unmarshal(issue.Metadata, &metadata))
+				if issue.InvariantType == HistoryCountLimit.String() {
+					require.Equal(t, 3, metadata.Current)
+					require.Equal(t, EventTypeCount{EventType: "WorkflowExecutionSignaled", Count: 2, FirstEventID: 2, LastEventID: 3}, metadata.EventTypeCounts[0])
+				} else {
+					require.Greater(t, metadata.Current, 1000)
+					require.Len(t, metadata.LargestEvents, 3)
+					require.Equal(t, int64(2), metadata.LargestEvents[0].EventID)
+				}
+			}
+		})
+	}
+}
+
+func Test__RootCause(t *testing.T) {
+	inv := NewInvariant(limits(1, 2, 100, 200))
+	result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+		Domain: testDomain,
+		Issues: []invariant.InvariantCheckResult{
+			{
+				IssueID:       0,
+				InvariantType: HistoryCountLimit.String(),
+				Metadata: invariant.MarshalData(HistoryLimitMetadata{
+					EventTypeCounts: []EventTypeCount{{EventType: "TimerFired", Count: 100, FirstEventID: 5, LastEventID: 500}},
+				}),
+			},
+			{
+				IssueID:       1,
+				InvariantType: HistorySizeLimit.String(),
+				Metadata: invariant.MarshalData(HistoryLimitMetadata{
+					Current:       1000,
+					LargestEvents: []EventSize{{EventID: 7, Size: 600}, {EventID: 9, Size: 10}},
+				}),
+			},
+			{
+				IssueID:       2,
+				InvariantType: HistorySizeLimit.String(),
+				Metadata: invariant.MarshalData(HistoryLimitMetadata{
+					Current:       1000,
+					LargestEvents: []EventSize{{EventID: 7, Size: 100}},
+				}),
+			},
+			{IssueID: 3, InvariantType: "some other invariant"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantRootCauseResult{
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeTooManyHistoryEvents,
+			Metadata:  invariant.MarshalData(HistoryRootCauseMetadata{EventIDs: []int64{5, 500}}),
+		},
+		{
+			IssueID:   1,
+			RootCause: invariant.RootCauseTypeLargeHistoryEvents,
+			Metadata:  invariant.MarshalData(HistoryRootCauseMetadata{EventIDs: []int64{7, 9}}),
+		},
+		{
+			IssueID:   2,
+			RootCause: invariant.RootCauseTypeTooManyHistoryEvents,
+			Metadata:  invariant.MarshalData(HistoryRootCauseMetadata{}),
+		},
+	}, result)
+}
+
+func limits(countWarn, countError, sizeWarn, sizeError int) Params {
+	return Params{
+		CountLimitWarn:  dynamicproperties.GetIntPropertyFilteredByDomain(countWarn),
+		CountLimitError: dynamicproperties.GetIntPropertyFilteredByDomain(countError),
+		SizeLimitWarn:   dynamicproperties.GetIntPropertyFilteredByDomain(sizeWarn),
+		SizeLimitError:  dynamicproperties.GetIntPropertyFilteredByDomain(sizeError),
+	}
+}
@@ -0,0 +1,74 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package historysize
+
+type HistoryLimitType string
+
+const (
+	HistoryCountLimit HistoryLimitType = "Workflow history event count is nearing the limit"
+	HistorySizeLimit  HistoryLimitType = "Workflow history size is nearing the limit"
+)
+
+func (h HistoryLimitType) String() string {
+	return string(h)
+}
+
+type LimitStatus string
+
+const (
+	WarnLimitExceeded LimitStatus = "History has exceeded the warning limit, the workflow will be terminated once it reaches the error limit"
+	ErrorLimitReached LimitStatus = "History has reached the error limit, the workflow will be terminated"
+)
+
+func (l LimitStatus) String() string {
+	return string(l)
+}
+
+// HistoryLimitMetadata includes the current value and the limits configured for the domain
+type HistoryLimitMetadata struct {
+	Current    int
+	WarnLimit  int
+	ErrorLimit int
+	// LargestEvents are the events contributing the most to the history size, only set for size issues
+	LargestEvents []EventSize
+	// EventTypeCounts are the most frequent event types, only set for count issues
+	EventTypeCounts []EventTypeCount
+}
+
+type EventSize struct {
+	EventID   int64
+	EventType string
+	Size      int
+}
+
+type EventTypeCount struct {
+	EventType    string
+	Count        int
+	FirstEventID int64
+	LastEventID  int64
+}
+
+// HistoryRootCauseMetadata points to the events related to a root cause
+type HistoryRootCauseMetadata struct {
+	EventIDs []int64
+}
@@ -35,6 +35,8 @@ type InvariantCheckResult struct {
 	InvariantType string
 	Reason        string
 	Metadata      []byte
+	// Plugin is the name of the plugin that found the issue, empty for built-in invariants
+	Plugin string
 }
 
 // InvariantRootCauseResult is the root cause for the issues identified in the invariant check
@@ -42,6 +44,8 @@ type InvariantRootCauseResult struct {
 	IssueID   int
 	RootCause RootCause
 	Metadata  []byte
+	// Plugin is the name of the plugin that found the root cause, empty for built-in invariants
+	Plugin string
 }
 
 type InvariantCheckInput struct {
@@ -66,6 +70,19 @@ const (
 	RootCauseTypeServiceSidePanic                      RootCause = "There is a panic in the activity/workflow that is causing a failure"
 	RootCauseTypeServiceSideCustomError                RootCause = "Customised error returned by the activity/workflow"
 	RootCauseTypeBlobSizeLimit                         RootCause = "Workflow has exceeded the blob size limits configured for the domain"
+	RootCauseTypeNonDeterminism                        RootCause = "Workflow code is non-deterministic, replaying the history produced different decisions. Check workflow code changes deployed with the reported binary checksum"
+	RootCauseTypeBadDecisionAttributes                 RootCause = "Workflow code returned a decision with invalid attributes"
+	RootCauseTypeWorkflowWorkerFailure                 RootCause = "Workflow worker failed to process the decision task, e.g. due to a panic in the workflow code"
+	RootCauseTypeDecisionRaceWithNewEvents             RootCause = "Decision tasks keep failing because new events arrive while they are being processed"
+	RootCauseTypeLargeHistoryEvents                    RootCause = "A few large events account for most of the history size. Consider reducing the size of inputs, results and details"
+	RootCauseTypeTooManyHistoryEvents                  RootCause = "Workflow has accumulated too many events. Consider using continue-as-new periodically"
+	RootCauseTypeDecisionNotPickedUp                   RootCause = "A decision task is scheduled but has not been picked up. Check there are workers polling the tasklist"
+	RootCauseTypeDecisionInProgress                    RootCause = "A decision task has started but not completed. The worker may be stuck or blocked in workflow code"
+	RootCauseTypeDecisionFailing                       RootCause = "Decision tasks are failing, so new events cannot be processed"
+	RootCauseTypeChildWorkflowIDConflict               RootCause = "A workflow with the child workflow ID is already running. Use a unique workflow ID or check the workflow ID reuse policy"
+	RootCauseTypeTimerWrongUnit                        RootCause = "Timer duration is unreasonably large. Check that it was not set in the wrong unit"
+	RootCauseTypeTimerOutlivesWorkflow                 RootCause = "Timer fires after the workflow execution timeout, so the workflow will time out first"
+	RootCauseTypeLongTimer                             RootCause = "Workflow is waiting on a timer far in the future. Check this is expected"
 )
 
 func (r RootCause) String() string {
@@ -0,0 +1,89 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	pluginsLock sync.RWMutex
+	plugins     = map[string]Invariant{}
+)
+
+// RegisterPlugin registers a custom invariant under a unique name, which is used to
+// enable it per domain through dynamic config.  It is intended to be called from init().
+//
+// Unlike the built-in invariants, plugins only run for domains they are enabled for.
+// Issues and root causes they return are reported separately from the built-in ones,
+// so their InvariantType, Reason and RootCause values are free-form.
+func RegisterPlugin(name string, plugin Invariant) {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+	if _, ok := plugins[name]; ok {
+		panic("diagnostics invariant plugin " + name + " already registered")
+	}
+	plugins[name] = plugin
+}
+
+// RegisteredPlugins returns all registered plugins, keyed by name.
+func RegisteredPlugins() map[string]Invariant {
+	pluginsLock.RLock()
+	defer pluginsLock.RUnlock()
+	result := make(map[string]Invariant, len(plugins))
+	for name, plugin := range plugins {
+		result[name] = plugin
+	}
+	return result
+}
+
+// Plugins is a set of named invariants which can be enabled per domain
+type Plugins map[string]Invariant
+
+// NewPlugins combines registered plugins with additional ones, e.g. loaded from rule files.
+// Names must be unique across both.
+func NewPlugins(additional map[string]Invariant) (Plugins, error) {
+	result := Plugins(RegisteredPlugins())
+	for name, plugin := range additional {
+		if _, ok := result[name]; ok {
+			return nil, fmt.Errorf("duplicate diagnostics invariant plugin name %q", name)
+		}
+		result[name] = plugin
+	}
+	return result, nil
+}
+
+// Enabled returns the names of the plugins enabled in a dynamic config map, sorted so results are stable.
+// Values must be true for the plugin to be enabled.
+func (p Plugins) Enabled(config map[string]interface{}) []string {
+	var names []string
+	for name := range p {
+		if enabled, ok := config[name].(bool); ok && enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
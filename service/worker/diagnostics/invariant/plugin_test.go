@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInvariant struct{}
+
+func (fakeInvariant) Check(context.Context, InvariantCheckInput) ([]InvariantCheckResult, error) {
+	return nil, nil
+}
+
+func (fakeInvariant) RootCause(context.Context, InvariantRootCauseInput) ([]InvariantRootCauseResult, error) {
+	return nil, nil
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	RegisterPlugin("test-registered", fakeInvariant{})
+	t.Cleanup(func() {
+		pluginsLock.Lock()
+		defer pluginsLock.Unlock()
+		delete(plugins, "test-registered")
+	})
+
+	assert.Contains(t, RegisteredPlugins(), "test-registered")
+	assert.Panics(t, func() { RegisterPlugin("test-registered", fakeInvariant{}) })
+
+	p, err := NewPlugins(map[string]Invariant{"test-additional": fakeInvariant{}})
+	require.NoError(t, err)
+	assert.Contains(t, p, "test-registered")
+	assert.Contains(t, p, "test-additional")
+
+	_, err = NewPlugins(map[string]Invariant{"test-registered": fakeInvariant{}})
+	assert.ErrorContains(t, err, "duplicate")
+}
+
+func TestPluginsEnabled(t *testing.T) {
+	p := Plugins{"b": fakeInvariant{}, "a": fakeInvariant{}, "c": fakeInvariant{}, "d": fakeInvariant{}}
+	assert.Equal(t, []string{"a", "b"}, p.Enabled(map[string]interface{}{
+		"b":       true,
+		"a":       true,
+		"c":       false,
+		"d":       "true", // not a bool, ignored
+		"missing": true,
+	}))
+	assert.Empty(t, p.Enabled(nil))
+}
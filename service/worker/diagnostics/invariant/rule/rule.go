@@ -0,0 +1,203 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+type rule struct {
+	name           string
+	description    string
+	rootCause      invariant.RootCause
+	eventTypes     map[types.EventType]struct{}
+	reason         *regexp.Regexp
+	details        *regexp.Regexp
+	identity       *regexp.Regexp
+	signalName     *regexp.Regexp
+	minOccurrences int
+}
+
+// LoadFiles reads rule files and returns an invariant for each rule, keyed by rule name
+func LoadFiles(paths []string) (map[string]invariant.Invariant, error) {
+	result := make(map[string]invariant.Invariant)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read diagnostics rule file %v: %w", path, err)
+		}
+		var file File
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse diagnostics rule file %v: %w", path, err)
+		}
+		for _, r := range file.Rules {
+			inv, err := NewInvariant(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule in diagnostics rule file %v: %w", path, err)
+			}
+			if _, ok := result[r.Name]; ok {
+				return nil, fmt.Errorf("duplicate diagnostics rule name %q in %v", r.Name, path)
+			}
+			result[r.Name] = inv
+		}
+	}
+	return result, nil
+}
+
+// NewInvariant validates a rule and returns an invariant which checks it
+func NewInvariant(r Rule) (invariant.Invariant, error) {
+	if r.Name == "" {
+		return nil, fmt.Errorf("rule name is required")
+	}
+	if r.Description == "" {
+		return nil, fmt.Errorf("rule %q: description is required", r.Name)
+	}
+	result := &rule{
+		name:           r.Name,
+		description:    r.Description,
+		rootCause:      invariant.RootCause(r.RootCause),
+		minOccurrences: max(r.MinOccurrences, 1),
+	}
+	if len(r.EventTypes) > 0 {
+		result.eventTypes = make(map[types.EventType]struct{}, len(r.EventTypes))
+		for _, name := range r.EventTypes {
+			var eventType types.EventType
+			if err := eventType.UnmarshalText([]byte(name)); err != nil {
+				return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+			}
+			result.eventTypes[eventType] = struct{}{}
+		}
+	}
+	var err error
+	for _, m := range []struct {
+		field   string
+		pattern string
+		target  **regexp.Regexp
+	}{
+		{"reason", r.Reason, &result.reason},
+		{"details", r.Details, &result.details},
+		{"identity", r.Identity, &result.identity},
+		{"signalName", r.SignalName, &result.signalName},
+	} {
+		if m.pattern == "" {
+			continue
+		}
+		if *m.target, err = regexp.Compile(m.pattern); err != nil {
+			return nil, fmt.Errorf("rule %q: invalid %v pattern: %w", r.Name, m.field, err)
+		}
+	}
+	return result, nil
+}
+
+func (r *rule) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	var eventIDs []int64
+	for _, event := range params.WorkflowExecutionHistory.GetHistory().GetEvents() {
+		if r.matches(event) {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+	if len(eventIDs) < r.minOccurrences {
+		return nil, nil
+	}
+	return []invariant.InvariantCheckResult{{
+		IssueID:       0,
+		InvariantType: r.name,
+		Reason:        r.description,
+		Metadata:      invariant.MarshalData(IssueMetadata{EventIDs: eventIDs}),
+	}}, nil
+}
+
+func (r *rule) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	if r.rootCause == "" {
+		return result, nil
+	}
+	for _, issue := range params.Issues {
+		if issue.InvariantType == r.name {
+			result = append(result, invariant.InvariantRootCauseResult{
+				IssueID:   issue.IssueID,
+				RootCause: r.rootCause,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (r *rule) matches(event *types.HistoryEvent) bool {
+	if r.eventTypes != nil {
+		if _, ok := r.eventTypes[event.GetEventType()]; !ok {
+			return false
+		}
+	}
+	f := fieldsOf(event)
+	return matchOptional(r.reason, f.reason) &&
+		matchOptional(r.details, f.details) &&
+		matchOptional(r.identity, f.identity) &&
+		matchOptional(r.signalName, f.signalName)
+}
+
+func matchOptional(pattern *regexp.Regexp, value string) bool {
+	return pattern == nil || pattern.MatchString(value)
+}
+
+type eventFields struct {
+	reason, details, identity, signalName string
+}
+
+// fieldsOf extracts the values rules can match on, for the event types that have them
+func fieldsOf(event *types.HistoryEvent) eventFields {
+	switch {
+	case event.ActivityTaskFailedEventAttributes != nil:
+		attr := event.ActivityTaskFailedEventAttributes
+		return eventFields{reason: common.StringDefault(attr.Reason), details: string(attr.Details), identity: attr.Identity}
+	case event.ActivityTaskTimedOutEventAttributes != nil:
+		attr := event.ActivityTaskTimedOutEventAttributes
+		return eventFields{reason: common.StringDefault(attr.LastFailureReason), details: string(attr.LastFailureDetails)}
+	case event.DecisionTaskFailedEventAttributes != nil:
+		attr := event.DecisionTaskFailedEventAttributes
+		return eventFields{reason: common.StringDefault(attr.Reason), details: string(attr.Details), identity: attr.Identity}
+	case event.WorkflowExecutionFailedEventAttributes != nil:
+		attr := event.WorkflowExecutionFailedEventAttributes
+		return eventFields{reason: common.StringDefault(attr.Reason), details: string(attr.Details)}
+	case event.ChildWorkflowExecutionFailedEventAttributes != nil:
+		attr := event.ChildWorkflowExecutionFailedEventAttributes
+		return eventFields{reason: common.StringDefault(attr.Reason), details: string(attr.Details)}
+	case event.WorkflowExecutionTerminatedEventAttributes != nil:
+		attr := event.WorkflowExecutionTerminatedEventAttributes
+		return eventFields{reason: attr.GetReason(), details: string(attr.Details), identity: attr.GetIdentity()}
+	case event.WorkflowExecutionSignaledEventAttributes != nil:
+		attr := event.WorkflowExecutionSignaledEventAttributes
+		return eventFields{details: string(attr.Input), identity: attr.GetIdentity(), signalName: attr.GetSignalName()}
+	default:
+		return eventFields{}
+	}
+}
@@ -0,0 +1,131 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const testRuleFile = `
+rules:
+  - name: card-declined
+    description: Payment processor declined the card
+    rootCause: The card was declined, retrying will not help
+    eventTypes: [ActivityTaskFailed]
+    reason: "^PaymentDeclined"
+    details: "insufficient funds"
+    minOccurrences: 2
+  - name: manual-terminate
+    description: Workflow was terminated by an operator
+    eventTypes: [WorkflowExecutionTerminated]
+    identity: "^ops-"
+`
+
+func TestLoadFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testRuleFile), 0644))
+
+	invariants, err := LoadFiles([]string{path})
+	require.NoError(t, err)
+	assert.Len(t, invariants, 2)
+	assert.Contains(t, invariants, "card-declined")
+	assert.Contains(t, invariants, "manual-terminate")
+
+	_, err = LoadFiles([]string{path, path})
+	assert.ErrorContains(t, err, "duplicate diagnostics rule name")
+
+	_, err = LoadFiles([]string{filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestNewInvariantValidation(t *testing.T) {
+	_, err := NewInvariant(Rule{Description: "no name"})
+	assert.Error(t, err)
+	_, err = NewInvariant(Rule{Name: "no-description"})
+	assert.Error(t, err)
+	_, err = NewInvariant(Rule{Name: "bad-type", Description: "x", EventTypes: []string{"NotAnEvent"}})
+	assert.Error(t, err)
+	_, err = NewInvariant(Rule{Name: "bad-regex", Description: "x", Reason: "("})
+	assert.ErrorContains(t, err, "invalid reason pattern")
+}
+
+func TestCheckAndRootCause(t *testing.T) {
+	inv, err := NewInvariant(Rule{
+		Name:           "card-declined",
+		Description:    "Payment processor declined the card",
+		RootCause:      "The card was declined",
+		EventTypes:     []string{"ActivityTaskFailed"},
+		Reason:         "^PaymentDeclined",
+		Details:        "insufficient funds",
+		MinOccurrences: 2,
+	})
+	require.NoError(t, err)
+
+	declined := func(id int64, reason string) *types.HistoryEvent {
+		return &types.HistoryEvent{
+			ID:        id,
+			EventType: types.EventTypeActivityTaskFailed.Ptr(),
+			ActivityTaskFailedEventAttributes: &types.ActivityTaskFailedEventAttributes{
+				Reason:  common.StringPtr(reason),
+				Details: []byte("insufficient funds"),
+			},
+		}
+	}
+	history := func(events ...*types.HistoryEvent) invariant.InvariantCheckInput {
+		return invariant.InvariantCheckInput{
+			WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{
+				History: &types.History{Events: events},
+			},
+		}
+	}
+
+	issues, err := inv.Check(context.Background(), history(declined(5, "PaymentDeclined"), declined(7, "Timeout")))
+	require.NoError(t, err)
+	assert.Empty(t, issues, "only one event matches, below minOccurrences")
+
+	issues, err = inv.Check(context.Background(), history(declined(5, "PaymentDeclined"), declined(7, "Timeout"), declined(9, "PaymentDeclined: card")))
+	require.NoError(t, err)
+	assert.Equal(t, []invariant.InvariantCheckResult{{
+		IssueID:       0,
+		InvariantType: "card-declined",
+		Reason:        "Payment processor declined the card",
+		Metadata:      invariant.MarshalData(IssueMetadata{EventIDs: []int64{5, 9}}),
+	}}, issues)
+
+	rootCauses, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{Issues: issues})
+	require.NoError(t, err)
+	assert.Equal(t, []invariant.InvariantRootCauseResult{{
+		IssueID:   0,
+		RootCause: "The card was declined",
+	}}, rootCauses)
+}
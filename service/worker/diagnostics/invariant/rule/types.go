@@ -0,0 +1,65 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rule
+
+// File is the format of a declarative diagnostics rule file, e.g.
+//
+//	rules:
+//	  - name: card-declined
+//	    description: Payment processor declined the card
+//	    rootCause: The card was declined, retrying the activity will not help
+//	    eventTypes: [ActivityTaskFailed]
+//	    reason: "^PaymentDeclined"
+//	    details: "insufficient funds"
+//	    minOccurrences: 1
+type File struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule matches a pattern of history events.  All configured matchers must match an event
+// for it to count, and an issue is reported once at least MinOccurrences events match.
+type Rule struct {
+	// Name uniquely identifies the rule, and is used to enable it per domain
+	Name string `yaml:"name"`
+	// Description is reported as the reason of the issue
+	Description string `yaml:"description"`
+	// RootCause is reported as the root cause of the issue, if set
+	RootCause string `yaml:"rootCause"`
+	// EventTypes limits matching to these history event types, e.g. ActivityTaskFailed
+	EventTypes []string `yaml:"eventTypes"`
+	// Reason is a regular expression matched against the failure or termination reason of an event
+	Reason string `yaml:"reason"`
+	// Details is a regular expression matched against the failure details, or signal input, of an event
+	Details string `yaml:"details"`
+	// Identity is a regular expression matched against the identity recorded on an event
+	Identity string `yaml:"identity"`
+	// SignalName is a regular expression matched against the signal name of WorkflowExecutionSignaled events
+	SignalName string `yaml:"signalName"`
+	// MinOccurrences is how many events must match to report an issue, defaults to 1
+	MinOccurrences int `yaml:"minOccurrences"`
+}
+
+// IssueMetadata is the metadata reported for issues found by a rule
+type IssueMetadata struct {
+	EventIDs []int64
+}
@@ -0,0 +1,151 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signal
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+// minSignalAge avoids reporting signals which a decision is about to process
+const minSignalAge = time.Minute
+
+// Signal is an invariant that will be used to identify open workflows blocked on signals which have not been processed
+type Signal invariant.Invariant
+
+type signal struct {
+	timeSource clock.TimeSource
+}
+
+func NewInvariant() Signal {
+	return &signal{
+		timeSource: clock.NewRealTimeSource(),
+	}
+}
+
+func (s *signal) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	if invariant.IsWorkflowClosed(events) {
+		return result, nil
+	}
+
+	var signals []*types.HistoryEvent
+	var lastDecision *types.HistoryEvent
+	for _, event := range events {
+		switch {
+		case event.DecisionTaskCompletedEventAttributes != nil:
+			signals = nil
+			lastDecision = event
+		case event.DecisionTaskScheduledEventAttributes != nil,
+			event.DecisionTaskStartedEventAttributes != nil,
+			event.DecisionTaskFailedEventAttributes != nil,
+			event.DecisionTaskTimedOutEventAttributes != nil:
+			lastDecision = event
+		case event.WorkflowExecutionSignaledEventAttributes != nil:
+			signals = append(signals, event)
+		}
+	}
+	if len(signals) == 0 {
+		return result, nil
+	}
+	age := s.timeSource.Now().Sub(time.Unix(0, signals[0].GetTimestamp()))
+	if age < minSignalAge {
+		return result, nil
+	}
+
+	metadata := UnprocessedSignalsMetadata{OldestSignalAge: age}
+	names := make(map[string]struct{})
+	for _, event := range signals {
+		metadata.SignalEventIDs = append(metadata.SignalEventIDs, event.ID)
+		names[event.WorkflowExecutionSignaledEventAttributes.GetSignalName()] = struct{}{}
+	}
+	for name := range names {
+		metadata.SignalNames = append(metadata.SignalNames, name)
+	}
+	sort.Strings(metadata.SignalNames)
+
+	// signals are reset on every completed decision, so any decision event left is still pending
+	state := DecisionNotScheduled
+	if lastDecision != nil && lastDecision.DecisionTaskCompletedEventAttributes == nil {
+		metadata.LastDecisionEventID = lastDecision.ID
+		state = decisionState(lastDecision)
+	}
+	result = append(result, invariant.InvariantCheckResult{
+		IssueID:       0,
+		InvariantType: UnprocessedSignals.String(),
+		Reason:        state.String(),
+		Metadata:      invariant.MarshalData(metadata),
+	})
+	return result, nil
+}
+
+func (s *signal) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		if issue.InvariantType != UnprocessedSignals.String() {
+			continue
+		}
+		var metadata UnprocessedSignalsMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		var rootCause invariant.RootCause
+		switch issue.Reason {
+		case DecisionScheduled.String():
+			rootCause = invariant.RootCauseTypeDecisionNotPickedUp
+		case DecisionStarted.String():
+			rootCause = invariant.RootCauseTypeDecisionInProgress
+		case DecisionFailed.String():
+			rootCause = invariant.RootCauseTypeDecisionFailing
+		default:
+			continue
+		}
+		result = append(result, invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: rootCause,
+			Metadata: invariant.MarshalData(SignalRootCauseMetadata{
+				EventIDs: append([]int64{metadata.LastDecisionEventID}, metadata.SignalEventIDs...),
+			}),
+		})
+	}
+	return result, nil
+}
+
+func decisionState(event *types.HistoryEvent) DecisionState {
+	switch {
+	case event.DecisionTaskScheduledEventAttributes != nil:
+		return DecisionScheduled
+	case event.DecisionTaskStartedEventAttributes != nil:
+		return DecisionStarted
+	case event.DecisionTaskFailedEventAttributes != nil, event.DecisionTaskTimedOutEventAttributes != nil:
+		return DecisionFailed
+	}
+	return DecisionNotScheduled
+}
@@ -0,0 +1,174 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testDomain = "test-domain"
+)
+
+var testNow = time.Unix(1700000000, 0)
+
+func Test__Check(t *testing.T) {
+	testCases := []struct {
+		name           string
+		events         []*types.HistoryEvent
+		expectedResult []invariant.InvariantCheckResult
+	}{
+		{
+			name: "signals waiting on a scheduled decision",
+			events: []*types.HistoryEvent{
+				{ID: 1, WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+				{ID: 2, DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+				signaled(3, "b", time.Hour),
+				signaled(4, "a", time.Hour),
+				signaled(5, "b", time.Hour),
+				{ID: 6, DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+			},
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: UnprocessedSignals.String(),
+					Reason:        DecisionScheduled.String(),
+					Metadata: invariant.MarshalData(UnprocessedSignalsMetadata{
+						SignalEventIDs:      []int64{3, 4, 5},
+						SignalNames:         []string{"a", "b"},
+						OldestSignalAge:     time.Hour,
+						LastDecisionEventID: 6,
+					}),
+				},
+			},
+		},
+		{
+			name: "signal received during a started decision",
+			events: []*types.HistoryEvent{
+				{ID: 1, DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{}},
+				signaled(2, "a", time.Hour),
+			},
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: UnprocessedSignals.String(),
+					Reason:        DecisionStarted.String(),
+					Metadata: invariant.MarshalData(UnprocessedSignalsMetadata{
+						SignalEventIDs:      []int64{2},
+						SignalNames:         []string{"a"},
+						OldestSignalAge:     time.Hour,
+						LastDecisionEventID: 1,
+					}),
+				},
+			},
+		},
+		{
+			name: "signals processed by a decision",
+			events: []*types.HistoryEvent{
+				signaled(1, "a", time.Hour),
+				{ID: 2, DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{}},
+			},
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name: "recent signal",
+			events: []*types.HistoryEvent{
+				signaled(1, "a", time.Second),
+			},
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name: "closed workflow",
+			events: []*types.HistoryEvent{
+				signaled(1, "a", time.Hour),
+				{ID: 2, WorkflowExecutionTerminatedEventAttributes: &types.WorkflowExecutionTerminatedEventAttributes{}},
+			},
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := &signal{timeSource: clock.NewMockedTimeSourceAt(testNow)}
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: tc.events}},
+				Domain:                   testDomain,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func Test__RootCause(t *testing.T) {
+	issue := func(id int, state DecisionState) invariant.InvariantCheckResult {
+		return invariant.InvariantCheckResult{
+			IssueID:       id,
+			InvariantType: UnprocessedSignals.String(),
+			Reason:        state.String(),
+			Metadata:      invariant.MarshalData(UnprocessedSignalsMetadata{SignalEventIDs: []int64{3, 4}, LastDecisionEventID: 5}),
+		}
+	}
+	rootCause := func(id int, rc invariant.RootCause) invariant.InvariantRootCauseResult {
+		return invariant.InvariantRootCauseResult{
+			IssueID:   id,
+			RootCause: rc,
+			Metadata:  invariant.MarshalData(SignalRootCauseMetadata{EventIDs: []int64{5, 3, 4}}),
+		}
+	}
+	inv := NewInvariant()
+	result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+		Domain: testDomain,
+		Issues: []invariant.InvariantCheckResult{
+			issue(0, DecisionScheduled),
+			issue(1, DecisionStarted),
+			issue(2, DecisionFailed),
+			issue(3, DecisionNotScheduled),
+			{IssueID: 4, InvariantType: "some other invariant"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantRootCauseResult{
+		rootCause(0, invariant.RootCauseTypeDecisionNotPickedUp),
+		rootCause(1, invariant.RootCauseTypeDecisionInProgress),
+		rootCause(2, invariant.RootCauseTypeDecisionFailing),
+	}, result)
+}
+
+func signaled(id int64, name string, age time.Duration) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID:        id,
+		Timestamp: common.Int64Ptr(testNow.Add(-age).UnixNano()),
+		WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+			SignalName: name,
+		},
+	}
+}
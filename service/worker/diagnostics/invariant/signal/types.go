@@ -0,0 +1,63 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signal
+
+import "time"
+
+type SignalIssueType string
+
+const (
+	UnprocessedSignals SignalIssueType = "Workflow has signals that have not been processed by a decision"
+)
+
+func (s SignalIssueType) String() string {
+	return string(s)
+}
+
+type DecisionState string
+
+const (
+	DecisionNotScheduled DecisionState = "No decision task has been scheduled since the signals were received"
+	DecisionScheduled    DecisionState = "A decision task is scheduled but has not started"
+	DecisionStarted      DecisionState = "A decision task has started but has not completed"
+	DecisionFailed       DecisionState = "The last decision task failed or timed out"
+)
+
+func (d DecisionState) String() string {
+	return string(d)
+}
+
+// UnprocessedSignalsMetadata describes the signals received after the last completed decision
+type UnprocessedSignalsMetadata struct {
+	SignalEventIDs []int64
+	SignalNames    []string
+	// OldestSignalAge is how long the oldest unprocessed signal has been waiting
+	OldestSignalAge time.Duration
+	// LastDecisionEventID is the latest decision task event, if any
+	LastDecisionEventID int64
+}
+
+// SignalRootCauseMetadata points to the events related to a root cause
+type SignalRootCauseMetadata struct {
+	EventIDs []int64
+}
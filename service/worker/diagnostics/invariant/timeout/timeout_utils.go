@@ -92,6 +92,7 @@ func getActivityTaskMetadata(e *types.HistoryEvent, events []*types.HistoryEvent
 				return ActivityTimeoutMetadata{}, fmt.Errorf("unknown timeout type")
 			}
 			return ActivityTimeoutMetadata{
+				ActivityType:      attr.ActivityType.GetName(),
 				TimeoutType:       timeoutType.Ptr(),
 				ConfiguredTimeout: time.Duration(configuredTimeout) * time.Second,
 				TimeElapsed:       timeElapsed,
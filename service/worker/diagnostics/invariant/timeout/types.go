@@ -55,6 +55,7 @@ type ChildWfTimeoutMetadata struct {
 }
 
 type ActivityTimeoutMetadata struct {
+	ActivityType      string
 	TimeoutType       *types.TimeoutType
 	ConfiguredTimeout time.Duration
 	TimeElapsed       time.Duration
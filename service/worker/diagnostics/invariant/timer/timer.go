@@ -0,0 +1,172 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package timer
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	// DefaultThreshold is how far in the future a timer must fire to be reported, if not configured
+	DefaultThreshold = 30 * 24 * time.Hour
+	// wrongUnitThreshold is long enough that the timer was almost certainly set in milliseconds or nanoseconds instead of seconds
+	wrongUnitThreshold = 100 * 365 * 24 * time.Hour
+)
+
+// Timer is an invariant that will be used to identify pending timers which fire far in the future
+type Timer invariant.Invariant
+
+type timer struct {
+	threshold time.Duration
+}
+
+type Params struct {
+	// Threshold is how far in the future a timer must fire to be reported, defaults to DefaultThreshold
+	Threshold time.Duration
+}
+
+func NewInvariant(p Params) Timer {
+	threshold := p.Threshold
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &timer{
+		threshold: threshold,
+	}
+}
+
+func (t *timer) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	if invariant.IsWorkflowClosed(events) {
+		return result, nil
+	}
+	workflowTimeout, workflowDeadline := getWorkflowTimeout(events)
+
+	pending := make(map[string]*types.HistoryEvent)
+	var order []string
+	for _, event := range events {
+		switch {
+		case event.TimerStartedEventAttributes != nil:
+			pending[event.TimerStartedEventAttributes.TimerID] = event
+			order = append(order, event.TimerStartedEventAttributes.TimerID)
+		case event.TimerFiredEventAttributes != nil:
+			delete(pending, event.TimerFiredEventAttributes.TimerID)
+		case event.TimerCanceledEventAttributes != nil:
+			delete(pending, event.TimerCanceledEventAttributes.TimerID)
+		}
+	}
+
+	issueID := 0
+	for _, timerID := range order {
+		event, ok := pending[timerID]
+		if !ok {
+			continue
+		}
+		delete(pending, timerID) // timer IDs can be reused, only report each pending one once
+		startToFire := toDuration(event.TimerStartedEventAttributes.GetStartToFireTimeoutSeconds())
+		fireTime := time.Unix(0, event.GetTimestamp()).Add(startToFire)
+		var reason TimerReason
+		switch {
+		case workflowTimeout > 0 && fireTime.After(workflowDeadline):
+			reason = TimerExceedsWorkflowTimeout
+		case startToFire > t.threshold:
+			reason = TimerExceedsThreshold
+		default:
+			continue
+		}
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: TimerFarInFuture.String(),
+			Reason:        reason.String(),
+			Metadata: invariant.MarshalData(TimerMetadata{
+				TimerID:            timerID,
+				StartedEventID:     event.ID,
+				StartToFireTimeout: startToFire,
+				FireTime:           fireTimeOrZero(fireTime, startToFire),
+				WorkflowTimeout:    workflowTimeout,
+			}),
+		})
+		issueID++
+	}
+	return result, nil
+}
+
+func (t *timer) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		if issue.InvariantType != TimerFarInFuture.String() {
+			continue
+		}
+		var metadata TimerMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		rootCause := invariant.RootCauseTypeLongTimer
+		switch {
+		case metadata.StartToFireTimeout >= wrongUnitThreshold:
+			rootCause = invariant.RootCauseTypeTimerWrongUnit
+		case issue.Reason == TimerExceedsWorkflowTimeout.String():
+			rootCause = invariant.RootCauseTypeTimerOutlivesWorkflow
+		}
+		result = append(result, invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: rootCause,
+			Metadata:  invariant.MarshalData(TimerRootCauseMetadata{EventIDs: []int64{metadata.StartedEventID}}),
+		})
+	}
+	return result, nil
+}
+
+// getWorkflowTimeout returns the execution timeout, and the time the workflow will time out at
+func getWorkflowTimeout(events []*types.HistoryEvent) (time.Duration, time.Time) {
+	for _, event := range events {
+		if attr := event.WorkflowExecutionStartedEventAttributes; attr != nil {
+			timeout := time.Duration(attr.GetExecutionStartToCloseTimeoutSeconds()) * time.Second
+			return timeout, time.Unix(0, event.GetTimestamp()).Add(timeout)
+		}
+	}
+	return 0, time.Time{}
+}
+
+// toDuration converts seconds to a duration, capping values which would overflow it
+func toDuration(seconds int64) time.Duration {
+	if seconds > int64(math.MaxInt64/time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// fireTimeOrZero omits fire times of timers set in the wrong unit, which are past what can be serialized
+func fireTimeOrZero(fireTime time.Time, startToFire time.Duration) time.Time {
+	if startToFire >= wrongUnitThreshold {
+		return time.Time{}
+	}
+	return fireTime
+}
@@ -0,0 +1,144 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package timer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testDomain = "test-domain"
+)
+
+var testStart = time.Unix(1700000000, 0)
+
+func Test__Check(t *testing.T) {
+	day := 24 * time.Hour
+	events := []*types.HistoryEvent{
+		{
+			ID:        1,
+			Timestamp: common.Int64Ptr(testStart.UnixNano()),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32((365 * day).Seconds())),
+			},
+		},
+		timerStarted(2, "short", time.Hour),
+		timerStarted(3, "long", 60*day),
+		timerStarted(4, "fired", 60*day),
+		{ID: 5, TimerFiredEventAttributes: &types.TimerFiredEventAttributes{TimerID: "fired"}},
+		timerStarted(6, "outlives", 400*day),
+	}
+	inv := NewInvariant(Params{})
+	result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+		WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: events}},
+		Domain:                   testDomain,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: TimerFarInFuture.String(),
+			Reason:        TimerExceedsThreshold.String(),
+			Metadata: invariant.MarshalData(TimerMetadata{
+				TimerID:            "long",
+				StartedEventID:     3,
+				StartToFireTimeout: 60 * day,
+				FireTime:           testStart.Add(60 * day),
+				WorkflowTimeout:    365 * day,
+			}),
+		},
+		{
+			IssueID:       1,
+			InvariantType: TimerFarInFuture.String(),
+			Reason:        TimerExceedsWorkflowTimeout.String(),
+			Metadata: invariant.MarshalData(TimerMetadata{
+				TimerID:            "outlives",
+				StartedEventID:     6,
+				StartToFireTimeout: 400 * day,
+				FireTime:           testStart.Add(400 * day),
+				WorkflowTimeout:    365 * day,
+			}),
+		},
+	}, result)
+
+	closed := append(events, &types.HistoryEvent{ID: 7, WorkflowExecutionCompletedEventAttributes: &types.WorkflowExecutionCompletedEventAttributes{}})
+	result, err = inv.Check(context.Background(), invariant.InvariantCheckInput{
+		WorkflowExecutionHistory: &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: closed}},
+		Domain:                   testDomain,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func Test__RootCause(t *testing.T) {
+	issue := func(id int, reason TimerReason, startToFire time.Duration) invariant.InvariantCheckResult {
+		return invariant.InvariantCheckResult{
+			IssueID:       id,
+			InvariantType: TimerFarInFuture.String(),
+			Reason:        reason.String(),
+			Metadata:      invariant.MarshalData(TimerMetadata{StartedEventID: int64(id + 10), StartToFireTimeout: startToFire}),
+		}
+	}
+	rootCause := func(id int, rc invariant.RootCause) invariant.InvariantRootCauseResult {
+		return invariant.InvariantRootCauseResult{
+			IssueID:   id,
+			RootCause: rc,
+			Metadata:  invariant.MarshalData(TimerRootCauseMetadata{EventIDs: []int64{int64(id + 10)}}),
+		}
+	}
+	inv := NewInvariant(Params{Threshold: time.Hour})
+	result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+		Domain: testDomain,
+		Issues: []invariant.InvariantCheckResult{
+			issue(0, TimerExceedsThreshold, 2*time.Hour),
+			issue(1, TimerExceedsWorkflowTimeout, 2*time.Hour),
+			issue(2, TimerExceedsWorkflowTimeout, toDuration(1000*365*24*3600)),
+			{IssueID: 3, InvariantType: "some other invariant"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []invariant.InvariantRootCauseResult{
+		rootCause(0, invariant.RootCauseTypeLongTimer),
+		rootCause(1, invariant.RootCauseTypeTimerOutlivesWorkflow),
+		rootCause(2, invariant.RootCauseTypeTimerWrongUnit),
+	}, result)
+}
+
+func timerStarted(id int64, timerID string, startToFire time.Duration) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID:        id,
+		Timestamp: common.Int64Ptr(testStart.UnixNano()),
+		TimerStartedEventAttributes: &types.TimerStartedEventAttributes{
+			TimerID:                   timerID,
+			StartToFireTimeoutSeconds: common.Int64Ptr(int64(startToFire.Seconds())),
+		},
+	}
+}
@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package timer
+
+import "time"
+
+type TimerIssueType string
+
+const (
+	TimerFarInFuture TimerIssueType = "Timer is scheduled far in the future"
+)
+
+func (t TimerIssueType) String() string {
+	return string(t)
+}
+
+type TimerReason string
+
+const (
+	TimerExceedsThreshold       TimerReason = "Timer fires later than the configured threshold"
+	TimerExceedsWorkflowTimeout TimerReason = "Timer fires after the workflow execution timeout"
+)
+
+func (t TimerReason) String() string {
+	return string(t)
+}
+
+// TimerMetadata includes the details of a pending timer
+type TimerMetadata struct {
+	TimerID            string
+	StartedEventID     int64
+	StartToFireTimeout time.Duration
+	FireTime           time.Time
+	WorkflowTimeout    time.Duration
+}
+
+// TimerRootCauseMetadata points to the events related to a root cause
+type TimerRootCauseMetadata struct {
+	EventIDs []int64
+}
@@ -33,12 +33,15 @@ import (
 	"go.uber.org/cadence/workflow"
 
 	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/rule"
 )
 
 type DiagnosticsWorkflow interface {
@@ -56,6 +59,12 @@ type dw struct {
 	worker          worker.Worker
 	invariants      []invariant.Invariant
 	clusterMetadata cluster.Metadata
+	blobstoreClient blobstore.Client
+
+	// plugins are custom invariants, which only run for domains they are enabled for
+	plugins        invariant.Plugins
+	ruleFiles      []string
+	enabledPlugins dynamicproperties.MapPropertyFnWithDomainFilter
 }
 
 type Params struct {
@@ -67,6 +76,12 @@ type Params struct {
 	TallyScope      tally.Scope
 	Invariants      []invariant.Invariant
 	ClusterMetadata cluster.Metadata
+	// BlobstoreClient stores per workflow details of batch diagnostics, they are not written if it is nil
+	BlobstoreClient blobstore.Client
+	// RuleFiles are declarative rule files to load as plugins, in addition to registered ones
+	RuleFiles []string
+	// EnabledPlugins is a map of plugin names to whether they are enabled, per domain
+	EnabledPlugins dynamicproperties.MapPropertyFnWithDomainFilter
 }
 
 // New creates a new diagnostics workflow.
@@ -80,11 +95,23 @@ func New(params Params) DiagnosticsWorkflow {
 		logger:          params.Logger,
 		invariants:      params.Invariants,
 		clusterMetadata: params.ClusterMetadata,
+		blobstoreClient: params.BlobstoreClient,
+		ruleFiles:       params.RuleFiles,
+		enabledPlugins:  params.EnabledPlugins,
 	}
 }
 
 // Start starts the worker
 func (w *dw) Start() error {
+	rules, err := rule.LoadFiles(w.ruleFiles)
+	if err != nil {
+		return err
+	}
+	w.plugins, err = invariant.NewPlugins(rules)
+	if err != nil {
+		return err
+	}
+
 	workerOpts := worker.Options{
 		MetricsScope:                     w.tallyScope,
 		BackgroundActivityContext:        context.Background(),
@@ -98,6 +125,9 @@ func (w *dw) Start() error {
 	newWorker.RegisterActivityWithOptions(w.identifyIssues, activity.RegisterOptions{Name: identifyIssuesActivity})
 	newWorker.RegisterActivityWithOptions(w.rootCauseIssues, activity.RegisterOptions{Name: rootCauseIssuesActivity})
 	newWorker.RegisterActivityWithOptions(w.emitUsageLogs, activity.RegisterOptions{Name: emitUsageLogsActivity})
+	newWorker.RegisterWorkflowWithOptions(w.DiagnosticsBatchWorkflow, workflow.RegisterOptions{Name: DiagnosticsBatchWorkflowTypeName})
+	newWorker.RegisterActivityWithOptions(w.listWorkflowsForBatch, activity.RegisterOptions{Name: listWorkflowsForBatchActivity})
+	newWorker.RegisterActivityWithOptions(w.diagnoseWorkflowsBatch, activity.RegisterOptions{Name: diagnoseWorkflowsBatchActivity})
 	w.worker = newWorker
 	return newWorker.Start()
 }
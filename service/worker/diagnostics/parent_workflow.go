@@ -37,9 +37,15 @@ const (
 	emitUsageLogsActivity      = "emitUsageLogs"
 	queryDiagnosticsReport     = "query-diagnostics-report"
 
-	issueTypeTimeouts = "Timeout"
-	issueTypeFailures = "Failure"
-	issueTypeRetry    = "Retry"
+	issueTypeTimeouts      = "Timeout"
+	issueTypeFailures      = "Failure"
+	issueTypeRetry         = "Retry"
+	issueTypeDecisions     = "Decision"
+	issueTypeHistorySize   = "HistorySize"
+	issueTypeSignals       = "Signal"
+	issueTypeChildWorkflow = "ChildWorkflow"
+	issueTypeTimers        = "Timer"
+	issueTypeCustom        = "Custom"
 )
 
 type DiagnosticsStarterWorkflowInput struct {
@@ -126,5 +132,23 @@ func getIssueType(result DiagnosticsWorkflowResult) string {
 	if result.Retries != nil {
 		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeRetry)
 	}
+	if result.Decisions != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeDecisions)
+	}
+	if result.HistorySize != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeHistorySize)
+	}
+	if result.Signals != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeSignals)
+	}
+	if result.ChildWorkflows != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeChildWorkflow)
+	}
+	if result.Timers != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeTimers)
+	}
+	if result.Custom != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeCustom)
+	}
 	return issueType
 }
@@ -52,9 +52,15 @@ type DiagnosticsWorkflowInput struct {
 }
 
 type DiagnosticsWorkflowResult struct {
-	Timeouts *timeoutDiagnostics
-	Failures *failureDiagnostics
-	Retries  *retryDiagnostics
+	Timeouts       *timeoutDiagnostics
+	Failures       *failureDiagnostics
+	Retries        *retryDiagnostics
+	Decisions      *decisionDiagnostics
+	HistorySize    *historySizeDiagnostics
+	Signals        *signalDiagnostics
+	ChildWorkflows *childWorkflowDiagnostics
+	Timers         *timerDiagnostics
+	Custom         *customDiagnostics
 }
 
 type timeoutDiagnostics struct {
@@ -107,15 +113,33 @@ type retryIssuesResult struct {
 	Metadata      retry.RetryMetadata
 }
 
+// customDiagnostics holds the results of plugin invariants, whose metadata is passed through as-is
+type customDiagnostics struct {
+	Issues    []*customIssuesResult
+	RootCause []*customRootCauseResult
+}
+
+type customIssuesResult struct {
+	IssueID       int
+	Plugin        string
+	InvariantType string
+	Reason        string
+	Metadata      json.RawMessage
+}
+
+type customRootCauseResult struct {
+	IssueID       int
+	Plugin        string
+	RootCauseType string
+	Metadata      json.RawMessage
+}
+
 func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflowInput) (*DiagnosticsWorkflowResult, error) {
 	scope := w.metricsClient.Scope(metrics.DiagnosticsWorkflowScope, metrics.DomainTag(params.Domain))
 	scope.IncCounter(metrics.DiagnosticsWorkflowStartedCount)
 	sw := scope.StartTimer(metrics.DiagnosticsWorkflowExecutionLatency)
 	defer sw.Stop()
 
-	var timeoutsResult *timeoutDiagnostics
-	var failureResult *failureDiagnostics
-	var retryResult *retryDiagnostics
 	var checkResult []invariant.InvariantCheckResult
 	var rootCauseResult []invariant.InvariantRootCauseResult
 
@@ -145,6 +169,31 @@ func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflo
 		return nil, fmt.Errorf("RootCauseIssues: %w", err)
 	}
 
+	result, err := buildDiagnosticsResult(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, err
+	}
+
+	scope.IncCounter(metrics.DiagnosticsWorkflowSuccess)
+	return result, nil
+}
+
+// buildDiagnosticsResult groups the issues and root causes found for a workflow into the diagnostics report
+func buildDiagnosticsResult(checkResult []invariant.InvariantCheckResult, rootCauseResult []invariant.InvariantRootCauseResult) (*DiagnosticsWorkflowResult, error) {
+	var timeoutsResult *timeoutDiagnostics
+	var failureResult *failureDiagnostics
+	var retryResult *retryDiagnostics
+	var customResult *customDiagnostics
+
+	customIssues, customRootCause := retrieveCustomResults(checkResult, rootCauseResult)
+	if len(customIssues) > 0 {
+		customResult = &customDiagnostics{
+			Issues:    customIssues,
+			RootCause: customRootCause,
+		}
+	}
+	checkResult, rootCauseResult = builtinResults(checkResult, rootCauseResult)
+
 	timeoutIssues, err := retrieveTimeoutIssues(checkResult)
 	if err != nil {
 		return nil, fmt.Errorf("RetrieveTimeoutIssues: %w", err)
@@ -191,14 +240,90 @@ func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflo
 		}
 	}
 
-	scope.IncCounter(metrics.DiagnosticsWorkflowSuccess)
+	decisionResult, err := retrieveDecisionDiagnostics(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveDecisionDiagnostics: %w", err)
+	}
+
+	historySizeResult, err := retrieveHistorySizeDiagnostics(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveHistorySizeDiagnostics: %w", err)
+	}
+
+	signalResult, err := retrieveSignalDiagnostics(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveSignalDiagnostics: %w", err)
+	}
+
+	childWorkflowResult, err := retrieveChildWorkflowDiagnostics(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveChildWorkflowDiagnostics: %w", err)
+	}
+
+	timerResult, err := retrieveTimerDiagnostics(checkResult, rootCauseResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveTimerDiagnostics: %w", err)
+	}
+
 	return &DiagnosticsWorkflowResult{
-		Timeouts: timeoutsResult,
-		Failures: failureResult,
-		Retries:  retryResult,
+		Timeouts:       timeoutsResult,
+		Failures:       failureResult,
+		Retries:        retryResult,
+		Decisions:      decisionResult,
+		HistorySize:    historySizeResult,
+		Signals:        signalResult,
+		ChildWorkflows: childWorkflowResult,
+		Timers:         timerResult,
+		Custom:         customResult,
 	}, nil
 }
 
+// builtinResults filters out plugin results, so that a plugin reusing a built-in invariant type is not mistaken for it
+func builtinResults(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) ([]invariant.InvariantCheckResult, []invariant.InvariantRootCauseResult) {
+	builtinIssues := make([]invariant.InvariantCheckResult, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Plugin == "" {
+			builtinIssues = append(builtinIssues, issue)
+		}
+	}
+	builtinRootCause := make([]invariant.InvariantRootCauseResult, 0, len(rootCause))
+	for _, rc := range rootCause {
+		if rc.Plugin == "" {
+			builtinRootCause = append(builtinRootCause, rc)
+		}
+	}
+	return builtinIssues, builtinRootCause
+}
+
+func retrieveCustomResults(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) ([]*customIssuesResult, []*customRootCauseResult) {
+	issuesResult := make([]*customIssuesResult, 0)
+	for _, issue := range issues {
+		if issue.Plugin == "" {
+			continue
+		}
+		issuesResult = append(issuesResult, &customIssuesResult{
+			IssueID:       issue.IssueID,
+			Plugin:        issue.Plugin,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      issue.Metadata,
+		})
+	}
+	rootCauseResult := make([]*customRootCauseResult, 0)
+	for _, rc := range rootCause {
+		if rc.Plugin == "" {
+			continue
+		}
+		rootCauseResult = append(rootCauseResult, &customRootCauseResult{
+			IssueID:       rc.IssueID,
+			Plugin:        rc.Plugin,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      rc.Metadata,
+		})
+	}
+	return issuesResult, rootCauseResult
+}
+
 func retrieveTimeoutIssues(issues []invariant.InvariantCheckResult) ([]*timeoutIssuesResult, error) {
 	result := make([]*timeoutIssuesResult, 0)
 	for _, issue := range issues {
@@ -0,0 +1,328 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"encoding/json"
+
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/childworkflow"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/historysize"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/signal"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/timer"
+)
+
+type decisionDiagnostics struct {
+	Issues    []*decisionIssuesResult
+	RootCause []*decisionRootCauseResult
+}
+
+type decisionIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Metadata      *decision.DecisionFailureMetadata
+}
+
+type decisionRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *decision.DecisionRootCauseMetadata
+}
+
+type historySizeDiagnostics struct {
+	Issues    []*historySizeIssuesResult
+	RootCause []*historySizeRootCauseResult
+}
+
+type historySizeIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Metadata      *historysize.HistoryLimitMetadata
+}
+
+type historySizeRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *historysize.HistoryRootCauseMetadata
+}
+
+type signalDiagnostics struct {
+	Issues    []*signalIssuesResult
+	RootCause []*signalRootCauseResult
+}
+
+type signalIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Metadata      *signal.UnprocessedSignalsMetadata
+}
+
+type signalRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *signal.SignalRootCauseMetadata
+}
+
+type childWorkflowDiagnostics struct {
+	Issues    []*childWorkflowIssuesResult
+	RootCause []*childWorkflowRootCauseResult
+}
+
+type childWorkflowIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Metadata      *childworkflow.StartFailedMetadata
+}
+
+type childWorkflowRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *childworkflow.ChildWorkflowRootCauseMetadata
+}
+
+type timerDiagnostics struct {
+	Issues    []*timerIssuesResult
+	RootCause []*timerRootCauseResult
+}
+
+type timerIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Metadata      *timer.TimerMetadata
+}
+
+type timerRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *timer.TimerRootCauseMetadata
+}
+
+func retrieveDecisionDiagnostics(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) (*decisionDiagnostics, error) {
+	result := &decisionDiagnostics{}
+	for _, issue := range issues {
+		if issue.InvariantType != decision.DecisionTaskFailed.String() && issue.InvariantType != decision.NonDeterministicDecision.String() {
+			continue
+		}
+		var metadata decision.DecisionFailureMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, &decisionIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      &metadata,
+		})
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	for _, rc := range rootCause {
+		if !rootCauseIn(rc.RootCause,
+			invariant.RootCauseTypeNonDeterminism,
+			invariant.RootCauseTypeBadDecisionAttributes,
+			invariant.RootCauseTypeWorkflowWorkerFailure,
+			invariant.RootCauseTypeDecisionRaceWithNewEvents) {
+			continue
+		}
+		var metadata decision.DecisionRootCauseMetadata
+		if err := json.Unmarshal(rc.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.RootCause = append(result.RootCause, &decisionRootCauseResult{
+			IssueID:       rc.IssueID,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveHistorySizeDiagnostics(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) (*historySizeDiagnostics, error) {
+	result := &historySizeDiagnostics{}
+	for _, issue := range issues {
+		if issue.InvariantType != historysize.HistoryCountLimit.String() && issue.InvariantType != historysize.HistorySizeLimit.String() {
+			continue
+		}
+		var metadata historysize.HistoryLimitMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, &historySizeIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      &metadata,
+		})
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	for _, rc := range rootCause {
+		if !rootCauseIn(rc.RootCause, invariant.RootCauseTypeLargeHistoryEvents, invariant.RootCauseTypeTooManyHistoryEvents) {
+			continue
+		}
+		var metadata historysize.HistoryRootCauseMetadata
+		if err := json.Unmarshal(rc.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.RootCause = append(result.RootCause, &historySizeRootCauseResult{
+			IssueID:       rc.IssueID,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveSignalDiagnostics(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) (*signalDiagnostics, error) {
+	result := &signalDiagnostics{}
+	for _, issue := range issues {
+		if issue.InvariantType != signal.UnprocessedSignals.String() {
+			continue
+		}
+		var metadata signal.UnprocessedSignalsMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, &signalIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      &metadata,
+		})
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	for _, rc := range rootCause {
+		if !rootCauseIn(rc.RootCause,
+			invariant.RootCauseTypeDecisionNotPickedUp,
+			invariant.RootCauseTypeDecisionInProgress,
+			invariant.RootCauseTypeDecisionFailing) {
+			continue
+		}
+		var metadata signal.SignalRootCauseMetadata
+		if err := json.Unmarshal(rc.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.RootCause = append(result.RootCause, &signalRootCauseResult{
+			IssueID:       rc.IssueID,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveChildWorkflowDiagnostics(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) (*childWorkflowDiagnostics, error) {
+	result := &childWorkflowDiagnostics{}
+	for _, issue := range issues {
+		if issue.InvariantType != childworkflow.ChildWorkflowStartFailed.String() {
+			continue
+		}
+		var metadata childworkflow.StartFailedMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, &childWorkflowIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      &metadata,
+		})
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	for _, rc := range rootCause {
+		if rc.RootCause != invariant.RootCauseTypeChildWorkflowIDConflict {
+			continue
+		}
+		var metadata childworkflow.ChildWorkflowRootCauseMetadata
+		if err := json.Unmarshal(rc.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.RootCause = append(result.RootCause, &childWorkflowRootCauseResult{
+			IssueID:       rc.IssueID,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveTimerDiagnostics(issues []invariant.InvariantCheckResult, rootCause []invariant.InvariantRootCauseResult) (*timerDiagnostics, error) {
+	result := &timerDiagnostics{}
+	for _, issue := range issues {
+		if issue.InvariantType != timer.TimerFarInFuture.String() {
+			continue
+		}
+		var metadata timer.TimerMetadata
+		if err := json.Unmarshal(issue.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, &timerIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Metadata:      &metadata,
+		})
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	for _, rc := range rootCause {
+		if !rootCauseIn(rc.RootCause,
+			invariant.RootCauseTypeTimerWrongUnit,
+			invariant.RootCauseTypeTimerOutlivesWorkflow,
+			invariant.RootCauseTypeLongTimer) {
+			continue
+		}
+		var metadata timer.TimerRootCauseMetadata
+		if err := json.Unmarshal(rc.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+		result.RootCause = append(result.RootCause, &timerRootCauseResult{
+			IssueID:       rc.IssueID,
+			RootCauseType: rc.RootCause.String(),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func rootCauseIn(rootCause invariant.RootCause, candidates ...invariant.RootCause) bool {
+	for _, rc := range candidates {
+		if rc == rootCause {
+			return true
+		}
+	}
+	return false
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
@@ -442,3 +443,97 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveRetryIssues() {
 	s.NoError(err)
 	s.ElementsMatch(retryIssues, result)
 }
+
+func (s *diagnosticsWorkflowTestSuite) Test__retrieveCustomResults() {
+	issues := []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: failure.ActivityFailed.String(),
+			Reason:        failure.GenericError.String(),
+		},
+		{
+			IssueID:       0,
+			InvariantType: failure.ActivityFailed.String(), // plugins may reuse built-in names
+			Reason:        "custom reason",
+			Metadata:      []byte(`{"EventIDs":[4]}`),
+			Plugin:        "test-plugin",
+		},
+	}
+	rootCause := []invariant.InvariantRootCauseResult{
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeServiceSideIssue,
+		},
+		{
+			IssueID:   0,
+			RootCause: "custom root cause",
+			Plugin:    "test-plugin",
+		},
+	}
+
+	customIssues, customRootCause := retrieveCustomResults(issues, rootCause)
+	s.Equal([]*customIssuesResult{{
+		IssueID:       0,
+		Plugin:        "test-plugin",
+		InvariantType: failure.ActivityFailed.String(),
+		Reason:        "custom reason",
+		Metadata:      []byte(`{"EventIDs":[4]}`),
+	}}, customIssues)
+	s.Equal([]*customRootCauseResult{{
+		IssueID:       0,
+		Plugin:        "test-plugin",
+		RootCauseType: "custom root cause",
+	}}, customRootCause)
+
+	builtinIssues, builtinRootCause := builtinResults(issues, rootCause)
+	s.Equal(issues[:1], builtinIssues)
+	s.Equal(rootCause[:1], builtinRootCause)
+}
+
+func (s *diagnosticsWorkflowTestSuite) Test__retrieveDecisionDiagnostics() {
+	metadata := decision.DecisionFailureMetadata{FailedEventIDs: []int64{4, 5}, BinaryChecksum: "abc", Stuck: true}
+	rootCauseMetadata := decision.DecisionRootCauseMetadata{EventIDs: []int64{4, 5}, BinaryChecksum: "abc"}
+	issues := []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: decision.NonDeterministicDecision.String(),
+			Reason:        "WORKFLOW_WORKER_UNHANDLED_FAILURE",
+			Metadata:      invariant.MarshalData(metadata),
+		},
+		{
+			IssueID:       0,
+			InvariantType: failure.ActivityFailed.String(),
+			Reason:        failure.GenericError.String(),
+		},
+	}
+	rootCause := []invariant.InvariantRootCauseResult{
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeNonDeterminism,
+			Metadata:  invariant.MarshalData(rootCauseMetadata),
+		},
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeServiceSideIssue,
+		},
+	}
+	result, err := retrieveDecisionDiagnostics(issues, rootCause)
+	s.NoError(err)
+	s.Equal(&decisionDiagnostics{
+		Issues: []*decisionIssuesResult{{
+			IssueID:       0,
+			InvariantType: decision.NonDeterministicDecision.String(),
+			Reason:        "WORKFLOW_WORKER_UNHANDLED_FAILURE",
+			Metadata:      &metadata,
+		}},
+		RootCause: []*decisionRootCauseResult{{
+			IssueID:       0,
+			RootCauseType: invariant.RootCauseTypeNonDeterminism.String(),
+			Metadata:      &rootCauseMetadata,
+		}},
+	}, result)
+
+	result, err = retrieveDecisionDiagnostics(issues[1:], rootCause[1:])
+	s.NoError(err)
+	s.Nil(result)
+}
@@ -0,0 +1,546 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package failovermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/replicationstatus"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// FailoverPlanWorkflowTypeName is the workflow type name of failover plans
+	FailoverPlanWorkflowTypeName = "cadence-sys-failoverPlan-workflow"
+	// FailoverPlanWorkflowIDPrefix is prepended to the plan name to build the plan workflow ID
+	FailoverPlanWorkflowIDPrefix = "cadence-failover-plan-"
+	preflightActivityName        = "cadence-sys-failoverPlan-preflight-activity"
+
+	// AbortSignal signal name for abort
+	AbortSignal = "abort"
+
+	// PlanScheduled state, the plan is waiting for its start time
+	PlanScheduled = "scheduled"
+	// PlanPreflightFailed state, the pre-flight checks failed at start time and no domain was failed over
+	PlanPreflightFailed = "preflight-failed"
+
+	// PreflightCheckDomains checks that the plan selects at least one domain
+	PreflightCheckDomains = "domains"
+	// PreflightCheckReplicationLag checks the replication lag from source to target cluster of the selected domains
+	PreflightCheckReplicationLag = "replication-lag"
+	// PreflightCheckDLQ checks that the replication DLQ of the target cluster is empty
+	PreflightCheckDLQ = "dlq"
+	// PreflightCheckTargetCluster checks that all services of the target cluster have members
+	PreflightCheckTargetCluster = "target-cluster-health"
+
+	defaultMaxReplicationLag = time.Minute
+	defaultFailoverTimeout   = 20 * time.Minute
+
+	errMsgPlanNameIsEmpty = "plan name is empty"
+)
+
+var targetClusterRoles = []string{service.Frontend, service.History, service.Matching}
+
+type (
+	// FailoverPlanParams is the arg for FailoverPlanWorkflow
+	FailoverPlanParams struct {
+		// Name of the plan, unique among running plans
+		Name string
+		// TargetCluster is the destination of failover
+		TargetCluster string
+		// SourceCluster is from which cluster the domains are active before failover
+		SourceCluster string
+		// Domains candidates to be failover, all domains managed by cadence are candidates if empty
+		Domains []string
+		// DomainQuery further filters the candidate domains, see ValidateDomainQuery
+		DomainQuery string
+		// StartTime is when the failover is executed, it is executed right away if zero or in the past
+		StartTime time.Time
+		// MaxReplicationLag is the max replication lag of selected domains allowed by pre-flight checks
+		MaxReplicationLag time.Duration
+		// BatchFailoverSize is number of domains to failover in one batch
+		BatchFailoverSize int
+		// BatchFailoverWaitTimeInSeconds is the waiting time between batch failover
+		BatchFailoverWaitTimeInSeconds int
+		// GracefulFailoverTimeoutInSeconds
+		GracefulFailoverTimeoutInSeconds *int32
+		// FailoverTimeout is the execution timeout of the failover workflow
+		FailoverTimeout time.Duration
+	}
+
+	// PreflightActivityParams params for pre-flight activity
+	PreflightActivityParams struct {
+		TargetCluster     string
+		SourceCluster     string
+		Domains           []string
+		DomainQuery       string
+		MaxReplicationLag time.Duration
+	}
+
+	// PreflightCheck is the result of a single pre-flight check
+	PreflightCheck struct {
+		Name    string
+		Passed  bool
+		Message string
+	}
+
+	// PreflightResult is the result of pre-flight activity
+	PreflightResult struct {
+		CheckedAt time.Time
+		Domains   []string
+		Checks    []*PreflightCheck
+		Passed    bool
+	}
+
+	// FailoverPlanResult is the result of FailoverPlanWorkflow
+	FailoverPlanResult struct {
+		State     string
+		Preflight *PreflightResult
+		Failover  *FailoverResult
+	}
+
+	// PlanQueryResult for failover plan progress
+	PlanQueryResult struct {
+		Name          string
+		State         string
+		TargetCluster string
+		SourceCluster string
+		StartTime     time.Time
+		DomainQuery   string
+		Preflight     *PreflightResult
+		Failover      *FailoverResult
+		AbortReason   string
+		Operator      string
+	}
+
+	domainQueryTerm struct {
+		field string
+		value string
+	}
+)
+
+// GetFailoverPlanWorkflowID returns the workflow ID of the failover plan with the given name
+func GetFailoverPlanWorkflowID(name string) string {
+	return FailoverPlanWorkflowIDPrefix + name
+}
+
+// FailoverPlanWorkflow runs pre-flight checks for a failover, waits for the scheduled start time
+// and executes the failover through FailoverWorkflow. It can be paused, resumed and aborted with signals.
+func FailoverPlanWorkflow(ctx workflow.Context, params *FailoverPlanParams) (*FailoverPlanResult, error) {
+	if err := validatePlanParams(params); err != nil {
+		return nil, err
+	}
+
+	state := PlanScheduled
+	paused := false
+	aborted := false
+	abortReason := ""
+	var preflight *PreflightResult
+	var failoverResult *FailoverResult
+	operator := getOperator(ctx)
+	err := workflow.SetQueryHandler(ctx, QueryType, func(input []byte) (*PlanQueryResult, error) {
+		return &PlanQueryResult{
+			Name:          params.Name,
+			State:         state,
+			TargetCluster: params.TargetCluster,
+			SourceCluster: params.SourceCluster,
+			StartTime:     params.StartTime,
+			DomainQuery:   params.DomainQuery,
+			Preflight:     preflight,
+			Failover:      failoverResult,
+			AbortReason:   abortReason,
+			Operator:      operator,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pauseCh := workflow.GetSignalChannel(ctx, PauseSignal)
+	resumeCh := workflow.GetSignalChannel(ctx, ResumeSignal)
+	abortCh := workflow.GetSignalChannel(ctx, AbortSignal)
+	addSignalHandlers := func(selector workflow.Selector, onPause, onResume func()) {
+		selector.AddReceive(pauseCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			paused = true
+			onPause()
+		})
+		selector.AddReceive(resumeCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			paused = false
+			onResume()
+		})
+		selector.AddReceive(abortCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, &abortReason)
+			aborted = true
+		})
+	}
+	abortedResult := func() *FailoverPlanResult {
+		state = WorkflowAborted
+		return &FailoverPlanResult{State: state, Preflight: preflight, Failover: failoverResult}
+	}
+
+	ao := workflow.WithActivityOptions(ctx, getPreflightActivityOptions())
+	preflightParams := &PreflightActivityParams{
+		TargetCluster:     params.TargetCluster,
+		SourceCluster:     params.SourceCluster,
+		Domains:           params.Domains,
+		DomainQuery:       params.DomainQuery,
+		MaxReplicationLag: params.MaxReplicationLag,
+	}
+	// pre-validate the plan when it is created, so that problems are visible before the start time
+	if err := workflow.ExecuteActivity(ao, preflightActivityName, preflightParams).Get(ctx, &preflight); err != nil {
+		return nil, err
+	}
+
+	// wait for the start time, the timer is not armed while the plan is paused
+	for {
+		if aborted {
+			return abortedResult(), nil
+		}
+		wait := params.StartTime.Sub(workflow.Now(ctx))
+		if !paused && wait <= 0 {
+			break
+		}
+
+		selector := workflow.NewSelector(ctx)
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		if !paused {
+			selector.AddFuture(workflow.NewTimer(timerCtx, wait), func(workflow.Future) {})
+		}
+		addSignalHandlers(selector, func() { state = WorkflowPaused }, func() { state = PlanScheduled })
+		selector.Select(ctx)
+		cancelTimer()
+	}
+
+	// the final gate, conditions may have changed since the plan was created
+	state = WorkflowRunning
+	if err := workflow.ExecuteActivity(ao, preflightActivityName, preflightParams).Get(ctx, &preflight); err != nil {
+		return nil, err
+	}
+	if !preflight.Passed {
+		state = PlanPreflightFailed
+		return &FailoverPlanResult{State: state, Preflight: preflight}, nil
+	}
+
+	childCtx, cancelChild := workflow.WithCancel(ctx)
+	childCtx = workflow.WithChildOptions(childCtx, workflow.ChildWorkflowOptions{
+		WorkflowID:                   FailoverWorkflowID,
+		TaskList:                     TaskListName,
+		ExecutionStartToCloseTimeout: params.FailoverTimeout,
+		TaskStartToCloseTimeout:      time.Minute,
+		WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+		Memo:                         map[string]interface{}{constants.MemoKeyForOperator: operator},
+	})
+	childFuture := workflow.ExecuteChildWorkflow(childCtx, FailoverWorkflowTypeName, &FailoverParams{
+		TargetCluster:                    params.TargetCluster,
+		SourceCluster:                    params.SourceCluster,
+		BatchFailoverSize:                params.BatchFailoverSize,
+		BatchFailoverWaitTimeInSeconds:   params.BatchFailoverWaitTimeInSeconds,
+		Domains:                          preflight.Domains,
+		GracefulFailoverTimeoutInSeconds: params.GracefulFailoverTimeoutInSeconds,
+	})
+
+	var childErr error
+	done := false
+	for !done && !aborted {
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(childFuture, func(f workflow.Future) {
+			childErr = f.Get(ctx, &failoverResult)
+			done = true
+		})
+		addSignalHandlers(
+			selector,
+			func() {
+				state = WorkflowPaused
+				childFuture.SignalChildWorkflow(ctx, PauseSignal, nil)
+			},
+			func() {
+				state = WorkflowRunning
+				childFuture.SignalChildWorkflow(ctx, ResumeSignal, nil)
+			},
+		)
+		selector.Select(ctx)
+	}
+	if aborted {
+		// domains already failed over stay in the target cluster, use rollback to move them back
+		cancelChild()
+		return abortedResult(), nil
+	}
+	cancelChild()
+	if childErr != nil {
+		return nil, childErr
+	}
+
+	state = WorkflowCompleted
+	return &FailoverPlanResult{State: state, Preflight: preflight, Failover: failoverResult}, nil
+}
+
+func validatePlanParams(params *FailoverPlanParams) error {
+	if params == nil {
+		return errors.New(errMsgParamsIsNil)
+	}
+	if len(params.Name) == 0 {
+		return errors.New(errMsgPlanNameIsEmpty)
+	}
+	if params.MaxReplicationLag <= 0 {
+		params.MaxReplicationLag = defaultMaxReplicationLag
+	}
+	if params.FailoverTimeout <= 0 {
+		params.FailoverTimeout = defaultFailoverTimeout
+	}
+	if params.BatchFailoverSize <= 0 {
+		params.BatchFailoverSize = defaultBatchFailoverSize
+	}
+	if params.BatchFailoverWaitTimeInSeconds <= 0 {
+		params.BatchFailoverWaitTimeInSeconds = defaultBatchFailoverWaitTimeInSeconds
+	}
+	if err := ValidateDomainQuery(params.DomainQuery); err != nil {
+		return err
+	}
+	return validateTargetAndSourceCluster(params.TargetCluster, params.SourceCluster)
+}
+
+func getPreflightActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Second,
+		StartToCloseTimeout:    5 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    2 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    1 * time.Minute,
+			ExpirationInterval: 10 * time.Minute,
+			NonRetriableErrorReasons: []string{
+				errMsgParamsIsNil,
+				errMsgTargetClusterIsEmpty,
+				errMsgSourceClusterIsEmpty,
+				errMsgTargetClusterIsSameAsSource},
+		},
+	}
+}
+
+// ValidateDomainQuery returns an error if the domain selection query of a failover plan is invalid.
+// A query is a comma separated list of field=value terms that all need to match: name=<glob> matches
+// the domain name and data.<key>=<glob> matches a domain data entry, eg name=payments-*,data.tier=1
+func ValidateDomainQuery(query string) error {
+	_, err := parseDomainQuery(query)
+	return err
+}
+
+func parseDomainQuery(query string) ([]domainQueryTerm, error) {
+	var terms []domainQueryTerm
+	for _, raw := range strings.Split(query, ",") {
+		raw = strings.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+		field, value, ok := strings.Cut(raw, "=")
+		field = strings.TrimSpace(field)
+		value = strings.TrimSpace(value)
+		if !ok || len(value) == 0 {
+			return nil, fmt.Errorf("invalid domain query term %q, expected field=value", raw)
+		}
+		if field != "name" && (!strings.HasPrefix(field, "data.") || len(field) == len("data.")) {
+			return nil, fmt.Errorf("invalid domain query field %q, expected name or data.<key>", field)
+		}
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("invalid domain query pattern %q: %w", value, err)
+		}
+		terms = append(terms, domainQueryTerm{field: field, value: value})
+	}
+	return terms, nil
+}
+
+func matchDomainQuery(domain *types.DescribeDomainResponse, terms []domainQueryTerm) bool {
+	for _, term := range terms {
+		var actual string
+		if term.field == "name" {
+			actual = domain.GetDomainInfo().GetName()
+		} else {
+			value, ok := domain.GetDomainInfo().GetData()[strings.TrimPrefix(term.field, "data.")]
+			if !ok {
+				return false
+			}
+			actual = value
+		}
+		if matched, _ := path.Match(term.value, actual); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// PreflightActivity selects the domains of a failover plan and checks that the failover is safe to run
+func PreflightActivity(ctx context.Context, params *PreflightActivityParams) (*PreflightResult, error) {
+	if params == nil {
+		return nil, errors.New(errMsgParamsIsNil)
+	}
+	if err := validateTargetAndSourceCluster(params.TargetCluster, params.SourceCluster); err != nil {
+		return nil, err
+	}
+	terms, err := parseDomainQuery(params.DomainQuery)
+	if err != nil {
+		return nil, err
+	}
+	allDomains, err := getAllDomains(ctx, params.Domains)
+	if err != nil {
+		return nil, err
+	}
+	var domains []string
+	for _, domain := range allDomains {
+		if shouldFailover(domain, params.SourceCluster) && matchDomainQuery(domain, terms) {
+			domains = append(domains, domain.GetDomainInfo().GetName())
+		}
+	}
+
+	result := &PreflightResult{
+		CheckedAt: time.Now(),
+		Domains:   domains,
+		Checks: []*PreflightCheck{
+			checkDomains(domains),
+			checkReplicationLag(ctx, params, domains),
+			checkDLQ(ctx, params),
+			checkTargetCluster(ctx, params.TargetCluster),
+		},
+		Passed: true,
+	}
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	return result, nil
+}
+
+func checkDomains(domains []string) *PreflightCheck {
+	if len(domains) == 0 {
+		return &PreflightCheck{Name: PreflightCheckDomains, Message: "no domain managed by cadence matches the plan"}
+	}
+	return &PreflightCheck{Name: PreflightCheckDomains, Passed: true, Message: fmt.Sprintf("%d domains selected", len(domains))}
+}
+
+func checkReplicationLag(ctx context.Context, params *PreflightActivityParams, domains []string) *PreflightCheck {
+	check := &PreflightCheck{Name: PreflightCheckReplicationLag}
+	// replication tasks to the target cluster are pending in the source cluster
+	adminClient, err := getRemoteAdminJSONClient(ctx, params.SourceCluster)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	status, err := adminClient.GetReplicationStatus(ctx, &types.GetReplicationStatusRequest{
+		RemoteCluster: params.TargetCluster,
+	})
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to get replication status: %v", err)
+		return check
+	}
+
+	selected := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		selected[domain] = struct{}{}
+	}
+	var lagging []string
+	for _, domain := range status.Domains {
+		if _, ok := selected[domain.Domain]; !ok {
+			continue
+		}
+		lag := time.Duration(domain.LagInMilliseconds) * time.Millisecond
+		if lag > params.MaxReplicationLag {
+			lagging = append(lagging, fmt.Sprintf("%s (%v)", domain.Domain, lag))
+		}
+	}
+	if len(lagging) > 0 {
+		check.Message = fmt.Sprintf("replication lag exceeds %v: %s", params.MaxReplicationLag, strings.Join(lagging, ", "))
+		return check
+	}
+	check.Passed = true
+	check.Message = fmt.Sprintf("replication lag of selected domains is within %v", params.MaxReplicationLag)
+	return check
+}
+
+func checkDLQ(ctx context.Context, params *PreflightActivityParams) *PreflightCheck {
+	check := &PreflightCheck{Name: PreflightCheckDLQ}
+	adminClient, err := getRemoteAdminClient(ctx, params.TargetCluster)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	counts, err := adminClient.CountDLQMessages(ctx, &types.CountDLQMessagesRequest{ForceFetch: true})
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to count DLQ messages: %v", err)
+		return check
+	}
+	var total int64
+	for _, size := range replicationstatus.DLQSizes(counts.History, params.SourceCluster) {
+		total += size
+	}
+	if total > 0 {
+		check.Message = fmt.Sprintf("%d replication tasks from %s are in the DLQ of %s", total, params.SourceCluster, params.TargetCluster)
+		return check
+	}
+	check.Passed = true
+	check.Message = "replication DLQ is empty"
+	return check
+}
+
+func checkTargetCluster(ctx context.Context, targetCluster string) *PreflightCheck {
+	check := &PreflightCheck{Name: PreflightCheckTargetCluster}
+	adminClient, err := getRemoteAdminClient(ctx, targetCluster)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	resp, err := adminClient.DescribeCluster(ctx)
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to describe cluster: %v", err)
+		return check
+	}
+	members := make(map[string]int32)
+	if resp.MembershipInfo != nil {
+		for _, ring := range resp.MembershipInfo.Rings {
+			members[ring.Role] = ring.MemberCount
+		}
+	}
+	var missing []string
+	for _, role := range targetClusterRoles {
+		if members[role] == 0 {
+			missing = append(missing, role)
+		}
+	}
+	if len(missing) > 0 {
+		check.Message = fmt.Sprintf("no members for %s", strings.Join(missing, ", "))
+		return check
+	}
+	check.Passed = true
+	check.Message = "all services have members"
+	return check
+}
@@ -0,0 +1,283 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package failovermanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/types"
+)
+
+type failoverPlanWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+}
+
+func TestFailoverPlanWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(failoverPlanWorkflowTestSuite))
+}
+
+func (s *failoverPlanWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	s.workflowEnv.RegisterWorkflowWithOptions(FailoverPlanWorkflow, workflow.RegisterOptions{Name: FailoverPlanWorkflowTypeName})
+	s.workflowEnv.RegisterWorkflowWithOptions(FailoverWorkflow, workflow.RegisterOptions{Name: FailoverWorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(PreflightActivity, activity.RegisterOptions{Name: preflightActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(GetDomainsActivity, activity.RegisterOptions{Name: getDomainsActivityName})
+}
+
+func (s *failoverPlanWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *failoverPlanWorkflowTestSuite) planParams() *FailoverPlanParams {
+	return &FailoverPlanParams{
+		Name:          "dr-drill",
+		TargetCluster: "t",
+		SourceCluster: "s",
+		StartTime:     s.workflowEnv.Now().Add(time.Hour),
+	}
+}
+
+func (s *failoverPlanWorkflowTestSuite) queryState() *PlanQueryResult {
+	value, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var result PlanQueryResult
+	s.NoError(value.Get(&result))
+	return &result
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestValidatePlanParams() {
+	s.Error(validatePlanParams(nil))
+	params := &FailoverPlanParams{TargetCluster: "t", SourceCluster: "s"}
+	s.Error(validatePlanParams(params))
+	params.Name = "plan"
+	s.NoError(validatePlanParams(params))
+	s.Equal(defaultMaxReplicationLag, params.MaxReplicationLag)
+	s.Equal(defaultFailoverTimeout, params.FailoverTimeout)
+	params.DomainQuery = "tier=1"
+	s.Error(validatePlanParams(params))
+	params.DomainQuery = ""
+	params.SourceCluster = "t"
+	s.Error(validatePlanParams(params))
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestWorkflow_ExecutesFailoverAtStartTime() {
+	params := s.planParams()
+	preflight := &PreflightResult{Domains: []string{"d1"}, Passed: true}
+	var preflightTimes []time.Time
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *PreflightActivityParams) (*PreflightResult, error) {
+			preflightTimes = append(preflightTimes, s.workflowEnv.Now())
+			return preflight, nil
+		}).Times(2)
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return([]string{"d1"}, nil).Once()
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(&FailoverActivityResult{SuccessDomains: []string{"d1"}}, nil).Once()
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.Equal(PlanScheduled, s.queryState().State)
+	}, time.Minute)
+
+	s.workflowEnv.ExecuteWorkflow(FailoverPlanWorkflowTypeName, params)
+
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result FailoverPlanResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(WorkflowCompleted, result.State)
+	s.Equal([]string{"d1"}, result.Failover.SuccessDomains)
+	s.Len(preflightTimes, 2)
+	s.False(preflightTimes[1].Before(params.StartTime))
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestWorkflow_PreflightFailedAtStartTime() {
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(&PreflightResult{Passed: true}, nil).Once()
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(&PreflightResult{
+		Checks: []*PreflightCheck{{Name: PreflightCheckDLQ, Message: "3 replication tasks in DLQ"}},
+	}, nil).Once()
+
+	s.workflowEnv.ExecuteWorkflow(FailoverPlanWorkflowTypeName, s.planParams())
+
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result FailoverPlanResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PlanPreflightFailed, result.State)
+	s.Nil(result.Failover)
+	s.Equal(PreflightCheckDLQ, result.Preflight.Checks[0].Name)
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestWorkflow_AbortBeforeStartTime() {
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(&PreflightResult{Passed: true}, nil).Once()
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(AbortSignal, "drill cancelled")
+	}, time.Minute)
+
+	s.workflowEnv.ExecuteWorkflow(FailoverPlanWorkflowTypeName, s.planParams())
+
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result FailoverPlanResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(WorkflowAborted, result.State)
+	s.Equal("drill cancelled", s.queryState().AbortReason)
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestWorkflow_PauseDelaysStart() {
+	params := s.planParams()
+	var failoverTime time.Time
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(&PreflightResult{Domains: []string{"d1"}, Passed: true}, nil).Times(2)
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return([]string{"d1"}, nil).Once()
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *FailoverActivityParams) (*FailoverActivityResult, error) {
+			failoverTime = s.workflowEnv.Now()
+			return &FailoverActivityResult{SuccessDomains: []string{"d1"}}, nil
+		}).Once()
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(PauseSignal, nil)
+	}, time.Minute)
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.Equal(WorkflowPaused, s.queryState().State)
+		s.workflowEnv.SignalWorkflow(ResumeSignal, nil)
+	}, 3*time.Hour)
+
+	s.workflowEnv.ExecuteWorkflow(FailoverPlanWorkflowTypeName, params)
+
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.NoError(s.workflowEnv.GetWorkflowError())
+	s.True(failoverTime.After(params.StartTime.Add(time.Hour)))
+}
+
+func (s *failoverPlanWorkflowTestSuite) TestWorkflow_PreflightActivityError() {
+	s.workflowEnv.OnActivity(preflightActivityName, mock.Anything, mock.Anything).Return(nil, errors.New("mockErr"))
+
+	s.workflowEnv.ExecuteWorkflow(FailoverPlanWorkflowTypeName, s.planParams())
+
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.Error(s.workflowEnv.GetWorkflowError())
+}
+
+func TestDomainQuery(t *testing.T) {
+	domain := &types.DescribeDomainResponse{
+		DomainInfo: &types.DomainInfo{
+			Name: "payments-us",
+			Data: map[string]string{"tier": "1"},
+		},
+	}
+	tests := []struct {
+		query     string
+		wantErr   bool
+		wantMatch bool
+	}{
+		{query: "", wantMatch: true},
+		{query: "name=payments-*", wantMatch: true},
+		{query: "name=payments-*, data.tier=1", wantMatch: true},
+		{query: "name=payments-*,data.tier=2", wantMatch: false},
+		{query: "data.owner=*", wantMatch: false},
+		{query: "tier=1", wantErr: true},
+		{query: "data.=1", wantErr: true},
+		{query: "name", wantErr: true},
+		{query: "name=[", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			terms, err := parseDomainQuery(tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Error(t, ValidateDomainQuery(tc.query))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatch, matchDomainQuery(domain, terms))
+		})
+	}
+}
+
+func (s *failoverWorkflowTestSuite) TestPreflightActivity() {
+	t := s.T()
+	s.activityEnv.RegisterActivityWithOptions(PreflightActivity, activity.RegisterOptions{Name: preflightActivityName})
+	env, mockResource := s.prepareTestActivityEnv()
+
+	managed := map[string]string{constants.DomainDataKeyForManagedFailover: "true"}
+	mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&types.ListDomainsResponse{
+		Domains: []*types.DescribeDomainResponse{
+			{
+				DomainInfo:               &types.DomainInfo{Name: "d1", Data: managed},
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{ActiveClusterName: "c1", Clusters: clusters},
+				IsGlobalDomain:           true,
+			},
+			{
+				DomainInfo:               &types.DomainInfo{Name: "d2", Data: managed},
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{ActiveClusterName: "c2", Clusters: clusters},
+				IsGlobalDomain:           true,
+			},
+		},
+	}, nil)
+	mockResource.RemoteAdminJSONClient.EXPECT().GetReplicationStatus(gomock.Any(), &types.GetReplicationStatusRequest{RemoteCluster: "c2"}).Return(&types.GetReplicationStatusResponse{
+		Domains: []*types.ReplicationDomainStatus{{Domain: "d1", PendingTasks: 10, LagInMilliseconds: (2 * time.Minute).Milliseconds()}},
+	}, nil)
+	mockResource.RemoteAdminClient.EXPECT().CountDLQMessages(gomock.Any(), &types.CountDLQMessagesRequest{ForceFetch: true}).Return(&types.CountDLQMessagesResponse{
+		History: map[types.HistoryDLQCountKey]int64{{ShardID: 0, SourceCluster: "c1"}: 3},
+	}, nil)
+	mockResource.RemoteAdminClient.EXPECT().DescribeCluster(gomock.Any()).Return(&types.DescribeClusterResponse{
+		MembershipInfo: &types.MembershipInfo{
+			Rings: []*types.RingInfo{
+				{Role: service.Frontend, MemberCount: 1},
+				{Role: service.History, MemberCount: 1},
+			},
+		},
+	}, nil)
+
+	actResult, err := env.ExecuteActivity(preflightActivityName, &PreflightActivityParams{
+		TargetCluster:     "c2",
+		SourceCluster:     "c1",
+		MaxReplicationLag: time.Minute,
+	})
+	require.NoError(t, err)
+	var result PreflightResult
+	require.NoError(t, actResult.Get(&result))
+
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{"d1"}, result.Domains)
+	passed := make(map[string]bool)
+	for _, check := range result.Checks {
+		passed[check.Name] = check.Passed
+	}
+	assert.Equal(t, map[string]bool{
+		PreflightCheckDomains:        true,
+		PreflightCheckReplicationLag: false,
+		PreflightCheckDLQ:            false,
+		PreflightCheckTargetCluster:  false,
+	}, passed)
+}
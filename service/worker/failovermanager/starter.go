@@ -99,9 +99,11 @@ func (s *FailoverManager) Start() error {
 	failoverWorker := worker.New(s.svcClient, constants.SystemLocalDomainName, TaskListName, workerOpts)
 	failoverWorker.RegisterWorkflowWithOptions(FailoverWorkflow, workflow.RegisterOptions{Name: FailoverWorkflowTypeName})
 	failoverWorker.RegisterWorkflowWithOptions(RebalanceWorkflow, workflow.RegisterOptions{Name: RebalanceWorkflowTypeName})
+	failoverWorker.RegisterWorkflowWithOptions(FailoverPlanWorkflow, workflow.RegisterOptions{Name: FailoverPlanWorkflowTypeName})
 	failoverWorker.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
 	failoverWorker.RegisterActivityWithOptions(GetDomainsActivity, activity.RegisterOptions{Name: getDomainsActivityName})
 	failoverWorker.RegisterActivityWithOptions(GetDomainsForRebalanceActivity, activity.RegisterOptions{Name: getRebalanceDomainsActivityName})
+	failoverWorker.RegisterActivityWithOptions(PreflightActivity, activity.RegisterOptions{Name: preflightActivityName})
 	s.worker = failoverWorker
 	return failoverWorker.Start()
 }
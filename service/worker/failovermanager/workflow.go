@@ -33,7 +33,9 @@ import (
 	"go.uber.org/cadence/workflow"
 	"go.uber.org/zap"
 
+	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
+	jsonclient "github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/types"
@@ -402,6 +404,16 @@ func getRemoteClient(ctx context.Context, clusterName string) (frontend.Client,
 	return manager.clientBean.GetRemoteFrontendClient(clusterName)
 }
 
+func getRemoteAdminClient(ctx context.Context, clusterName string) (admin.Client, error) {
+	manager := ctx.Value(failoverManagerContextKey).(*FailoverManager)
+	return manager.clientBean.GetRemoteAdminClient(clusterName)
+}
+
+func getRemoteAdminJSONClient(ctx context.Context, clusterName string) (jsonclient.AdminClient, error) {
+	manager := ctx.Value(failoverManagerContextKey).(*FailoverManager)
+	return manager.clientBean.GetRemoteAdminJSONClient(clusterName)
+}
+
 func getAllDomains(ctx context.Context, targetDomains []string) ([]*types.DescribeDomainResponse, error) {
 	feClient := getClient(ctx)
 	var res []*types.DescribeDomainResponse
@@ -120,7 +120,7 @@ func concreteExecutionScannerManager(
 	collections := ParseCollections(params.ScannerConfig)
 
 	var ivs []invariant.Invariant
-	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop()) {
+	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop(), ParseChecksumInvalidateBefore(params.ScannerConfig)) {
 		ivs = append(ivs, fn(pr, domainCache))
 	}
 
@@ -159,8 +159,9 @@ func concreteExecutionFixerManager(_ context.Context, pr persistence.Retryer, pa
 		}
 	}
 
+	// checksum mismatches are never fixed, so checksums do not have to be invalidated for the fixer
 	var ivs []invariant.Invariant
-	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop()) {
+	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop(), time.Time{}) {
 		ivs = append(ivs, fn(pr, domainCache))
 	}
 	return invariant.NewInvariantManager(ivs)
@@ -179,6 +180,12 @@ func concreteExecutionCustomScannerConfig(ctx shardscanner.ScannerContext) shard
 	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsScannerInvariantCollectionStale)() {
 		res[invariant.CollectionStale.String()] = strconv.FormatBool(true)
 	}
+	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsScannerInvariantCollectionReconciliation)() {
+		res[invariant.CollectionReconciliation.String()] = strconv.FormatBool(true)
+	}
+	if invalidateBefore := ctx.Config.DynamicCollection.GetFloat64Property(dynamicproperties.MutableStateChecksumInvalidateBefore)(); invalidateBefore > 0 {
+		res[ChecksumInvalidateBeforeConfigKey] = strconv.FormatInt(int64(invalidateBefore), 10)
+	}
 
 	return res
 }
@@ -197,6 +204,9 @@ func concreteExecutionCustomFixerConfig(ctx shardscanner.FixerContext) shardscan
 	res[invariant.CollectionStale.String()] = strconv.FormatBool(
 		ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsFixerInvariantCollectionStale)(),
 	)
+	res[invariant.CollectionReconciliation.String()] = strconv.FormatBool(
+		ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsFixerInvariantCollectionReconciliation)(),
+	)
 
 	return res
 }
@@ -26,6 +26,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,10 +34,12 @@ import (
 	"go.uber.org/cadence/testsuite"
 	"go.uber.org/cadence/workflow"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/reconciliation/invariant"
@@ -450,7 +453,8 @@ func Test_concreteExecutionCustomScannerConfig(t *testing.T) {
 
 	collection := dynamicconfig.NewCollection(mockClient, log.NewNoop())
 
-	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(3)
+	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(4)
+	mockClient.EXPECT().GetFloatValue(dynamicproperties.MutableStateChecksumInvalidateBefore, gomock.Any()).Return(1000.0, nil)
 
 	ctx := shardscanner.ScannerContext{
 		Config: &shardscanner.ScannerConfig{
@@ -461,10 +465,17 @@ func Test_concreteExecutionCustomScannerConfig(t *testing.T) {
 	cfg := concreteExecutionCustomScannerConfig(ctx)
 
 	assert.NotNil(t, cfg)
-	assert.Len(t, cfg, 3)
+	assert.Len(t, cfg, 5)
 	assert.Equal(t, "true", cfg[invariant.CollectionHistory.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionMutableState.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionStale.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionReconciliation.String()])
+	assert.Equal(t, "1000", cfg[ChecksumInvalidateBeforeConfigKey])
+	assert.Equal(t, time.Unix(1000, 0), ParseChecksumInvalidateBefore(cfg))
+	assert.Equal(t, []invariant.Collection{invariant.CollectionReconciliation}, ParseCollections(shardscanner.CustomScannerConfig{
+		invariant.CollectionReconciliation.String(): "true",
+		ChecksumInvalidateBeforeConfigKey:           "1000",
+	}))
 }
 
 func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
@@ -472,7 +483,7 @@ func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
 
 	collection := dynamicconfig.NewCollection(mockClient, log.NewNoop())
 
-	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(3)
+	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(4)
 
 	ctx := shardscanner.FixerContext{
 		Config: &shardscanner.ScannerConfig{
@@ -483,10 +494,11 @@ func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
 	cfg := concreteExecutionCustomFixerConfig(ctx)
 
 	assert.NotNil(t, cfg)
-	assert.Len(t, cfg, 3)
+	assert.Len(t, cfg, 4)
 	assert.Equal(t, "true", cfg[invariant.CollectionHistory.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionMutableState.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionStale.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionReconciliation.String()])
 }
 
 func TestConcreteExecutionConfig(t *testing.T) {
@@ -498,3 +510,18 @@ func TestConcreteExecutionConfig(t *testing.T) {
 
 	assert.NotNil(t, cfg)
 }
+
+func TestConcreteExecutionType_ToInvariants_Reconciliation(t *testing.T) {
+	mockRetryer := persistence.NewMockRetryer(gomock.NewController(t))
+
+	var names []invariant.Name
+	for _, fn := range ConcreteExecutionType.ToInvariants([]invariant.Collection{invariant.CollectionReconciliation}, zap.NewNop(), time.Time{}) {
+		names = append(names, fn(mockRetryer, nil).Name())
+	}
+
+	assert.Equal(t, []invariant.Name{
+		invariant.PendingEventsExist,
+		invariant.VersionHistoriesConsistent,
+		invariant.MutableStateChecksumValid,
+	}, names)
+}
@@ -112,7 +112,7 @@ func currentExecutionScannerManager(
 	logger.Info("Creating invariant manager for current execution scanner", zap.Any("Params", params))
 	var ivs []invariant.Invariant
 	collections := ParseCollections(params.ScannerConfig)
-	for _, fn := range CurrentExecutionType.ToInvariants(collections, zap.NewNop()) {
+	for _, fn := range CurrentExecutionType.ToInvariants(collections, zap.NewNop(), time.Time{}) {
 		ivs = append(ivs, fn(pr, domainCache))
 	}
 	return invariant.NewInvariantManager(ivs)
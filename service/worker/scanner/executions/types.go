@@ -27,6 +27,7 @@ package executions
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -36,9 +37,14 @@ import (
 	"github.com/uber/cadence/common/reconciliation/entity"
 	"github.com/uber/cadence/common/reconciliation/fetcher"
 	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/service/history/execution"
 	"github.com/uber/cadence/service/worker/scanner/shardscanner"
 )
 
+// ChecksumInvalidateBeforeConfigKey is the scanner config key of history's MutableStateChecksumInvalidateBefore
+// in epoch seconds, it is passed with the invariant collections so checksums invalidated by history are not verified.
+const ChecksumInvalidateBeforeConfigKey = "MutableStateChecksumInvalidateBefore"
+
 const (
 	// ConcreteExecutionType concrete execution entity
 	ConcreteExecutionType ScanType = iota
@@ -93,7 +99,8 @@ func (st ScanType) ToExecutionFetcher() ExecutionFetcher {
 }
 
 // ToInvariants returns list of invariants to be checked depending on scan type.
-func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.Logger) []InvariantFactory {
+// Mutable state checksums of executions last updated before checksumInvalidateBefore are not verified, zero verifies all of them.
+func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.Logger, checksumInvalidateBefore time.Time) []InvariantFactory {
 	var fns []InvariantFactory
 	switch st {
 	case ConcreteExecutionType:
@@ -109,6 +116,15 @@ func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.
 				})
 			case invariant.CollectionMutableState:
 				fns = append(fns, invariant.NewOpenCurrentExecution)
+			case invariant.CollectionReconciliation:
+				fns = append(
+					fns,
+					invariant.NewPendingEventsExist,
+					invariant.NewVersionHistoriesConsistent,
+					func(pr persistence.Retryer, dc cache.DomainCache) invariant.Invariant {
+						return invariant.NewMutableStateChecksumValid(pr, dc, execution.VerifyWorkflowMutableStateChecksum, checksumInvalidateBefore)
+					},
+				)
 			}
 		}
 		return fns
@@ -125,6 +141,15 @@ func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.
 	}
 }
 
+// ParseChecksumInvalidateBefore returns the MutableStateChecksumInvalidateBefore passed in scanner config, if any
+func ParseChecksumInvalidateBefore(params shardscanner.CustomScannerConfig) time.Time {
+	epochSecs, err := strconv.ParseInt(params[ChecksumInvalidateBeforeConfigKey], 10, 64)
+	if err != nil || epochSecs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(epochSecs, 0)
+}
+
 // ParseCollections converts string based map to list of collections
 func ParseCollections(params shardscanner.CustomScannerConfig) []invariant.Collection {
 	var collections []invariant.Collection
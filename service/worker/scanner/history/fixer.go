@@ -0,0 +1,178 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/cadence/activity"
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// FixerHeartbeatDetails is the heartbeat detail for HistoryFixerActivity
+	FixerHeartbeatDetails struct {
+		// NextReportIndex is the index of the next report to process
+		NextReportIndex int
+		SkipCount       int
+		ErrorCount      int
+		SuccCount       int
+	}
+
+	// Fixer deletes the orphaned history branches reported by a dry-run of the Scavenger
+	Fixer struct {
+		db              p.HistoryManager
+		client          history.Client
+		blobstoreClient blobstore.Client
+		hbd             FixerHeartbeatDetails
+		limiter         *rate.Limiter
+		metrics         metrics.Client
+		logger          log.Logger
+		isInTest        bool
+		domainCache     cache.DomainCache
+	}
+)
+
+// NewFixer returns an instance of history fixer
+// Calling the Run() method on the returned object will go through
+// the given dry-run reports and, for each reported branch, the fixer will
+//   - describe the corresponding workflow execution again, skipping the branch if it exists
+//   - delete the history branch, if there are no workflow execution
+func NewFixer(
+	db p.HistoryManager,
+	rps int,
+	client history.Client,
+	blobstoreClient blobstore.Client,
+	hbd FixerHeartbeatDetails,
+	metricsClient metrics.Client,
+	logger log.Logger,
+	domainCache cache.DomainCache,
+) *Fixer {
+	return &Fixer{
+		db:              db,
+		client:          client,
+		blobstoreClient: blobstoreClient,
+		hbd:             hbd,
+		limiter:         rate.NewLimiter(rate.Limit(rps), rps),
+		metrics:         metricsClient,
+		logger:          logger,
+		domainCache:     domainCache,
+	}
+}
+
+// Run runs the fixer over the given reports
+func (f *Fixer) Run(ctx context.Context, reportKeys []string) (FixerHeartbeatDetails, error) {
+	for f.hbd.NextReportIndex < len(reportKeys) {
+		key := reportKeys[f.hbd.NextReportIndex]
+		orphans, err := f.readReport(ctx, key)
+		if err != nil {
+			f.logger.Error("failed to read history scavenger report", tag.Error(err), tag.Key(key))
+			return f.hbd, err
+		}
+
+		for _, orphan := range orphans {
+			if err := f.limiter.Wait(ctx); err != nil {
+				return f.hbd, err
+			}
+			f.fix(ctx, orphan)
+			if !f.isInTest {
+				activity.RecordHeartbeat(ctx, f.hbd)
+			}
+		}
+
+		f.hbd.NextReportIndex++
+		if !f.isInTest {
+			activity.RecordHeartbeat(ctx, f.hbd)
+		}
+	}
+	return f.hbd, nil
+}
+
+func (f *Fixer) fix(ctx context.Context, orphan OrphanedBranch) {
+	tags := []tag.Tag{
+		tag.WorkflowDomainID(orphan.DomainID),
+		tag.WorkflowID(orphan.WorkflowID),
+		tag.WorkflowRunID(orphan.RunID),
+		tag.WorkflowTreeID(orphan.TreeID),
+		tag.WorkflowBranchID(orphan.BranchID),
+	}
+
+	// the report may be stale by now, so make sure the branch is still garbage before deleting it
+	exists, err := workflowExists(ctx, f.client, orphan.DomainID, orphan.WorkflowID, orphan.RunID)
+	if err != nil {
+		f.logger.Error("encounter error when describing the mutable state", append(tags, tag.Error(err))...)
+		f.metrics.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerErrorCount)
+		f.hbd.ErrorCount++
+		return
+	}
+	if exists {
+		f.logger.Warn("skipped reported history branch, workflow execution exists", tags...)
+		f.metrics.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerSkipCount)
+		f.hbd.SkipCount++
+		return
+	}
+
+	err = deleteHistoryBranch(ctx, f.db, f.domainCache, orphan.DomainID, orphan.TreeID, orphan.BranchID, orphan.ShardID)
+	if err != nil {
+		f.logger.Error("encounter error when deleting garbage history branch", append(tags, tag.Error(err))...)
+		f.metrics.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerErrorCount)
+		f.hbd.ErrorCount++
+		return
+	}
+	f.logger.Info("deleted history garbage", tags...)
+	f.metrics.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerSuccessCount)
+	f.hbd.SuccCount++
+}
+
+func (f *Fixer) readReport(ctx context.Context, key string) ([]OrphanedBranch, error) {
+	resp, err := f.blobstoreClient.Get(ctx, &blobstore.GetRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []OrphanedBranch
+	scanner := bufio.NewScanner(bytes.NewReader(resp.Blob.Body))
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var orphan OrphanedBranch
+		if err := json.Unmarshal(scanner.Bytes(), &orphan); err != nil {
+			return nil, fmt.Errorf("malformed report line: %w", err)
+		}
+		orphans = append(orphans, orphan)
+	}
+	return orphans, scanner.Err()
+}
@@ -0,0 +1,144 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	FixerTestSuite struct {
+		suite.Suite
+		db              *mocks.HistoryV2Manager
+		client          *history.MockClient
+		blobstoreClient *blobstore.MockClient
+		mockCache       *cache.MockDomainCache
+		fixer           *Fixer
+	}
+)
+
+func TestFixerTestSuite(t *testing.T) {
+	suite.Run(t, new(FixerTestSuite))
+}
+
+func (s *FixerTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.db = &mocks.HistoryV2Manager{}
+	s.client = history.NewMockClient(controller)
+	s.blobstoreClient = &blobstore.MockClient{}
+	s.mockCache = cache.NewMockDomainCache(controller)
+	s.fixer = NewFixer(
+		s.db,
+		100,
+		s.client,
+		s.blobstoreClient,
+		FixerHeartbeatDetails{},
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		testlogger.New(s.T()),
+		s.mockCache,
+	)
+	s.fixer.isInTest = true
+}
+
+func (s *FixerTestSuite) TestRun() {
+	s.blobstoreClient.On("Get", mock.Anything, &blobstore.GetRequest{Key: "report_0"}).Return(&blobstore.GetResponse{
+		Blob: blobstore.Blob{Body: []byte(
+			`{"DomainID":"domainID1","WorkflowID":"workflowID1","RunID":"runID1","TreeID":"treeID1","BranchID":"branchID1","ShardID":3}` + "\n" +
+				`{"DomainID":"domainID2","WorkflowID":"workflowID2","RunID":"runID2","TreeID":"treeID2","BranchID":"branchID2","ShardID":4}` + "\n",
+		)},
+	}, nil).Once()
+	s.blobstoreClient.On("Get", mock.Anything, &blobstore.GetRequest{Key: "report_1"}).Return(&blobstore.GetResponse{
+		Blob: blobstore.Blob{Body: []byte(
+			`{"DomainID":"domainID3","WorkflowID":"workflowID3","RunID":"runID3","TreeID":"treeID3","BranchID":"branchID3"}` + "\n",
+		)},
+	}, nil).Once()
+
+	// the first workflow is still gone, the second one exists again and the third one fails to describe
+	s.client.EXPECT().DescribeMutableState(gomock.Any(), &types.DescribeMutableStateRequest{
+		DomainUUID: "domainID1",
+		Execution:  &types.WorkflowExecution{WorkflowID: "workflowID1", RunID: "runID1"},
+	}).Return(nil, &types.EntityNotExistsError{})
+	s.client.EXPECT().DescribeMutableState(gomock.Any(), &types.DescribeMutableStateRequest{
+		DomainUUID: "domainID2",
+		Execution:  &types.WorkflowExecution{WorkflowID: "workflowID2", RunID: "runID2"},
+	}).Return(&types.DescribeMutableStateResponse{}, nil)
+	s.client.EXPECT().DescribeMutableState(gomock.Any(), &types.DescribeMutableStateRequest{
+		DomainUUID: "domainID3",
+		Execution:  &types.WorkflowExecution{WorkflowID: "workflowID3", RunID: "runID3"},
+	}).Return(nil, errors.New("unavailable"))
+
+	s.mockCache.EXPECT().GetDomainName("domainID1").Return("domainName1", nil)
+	branchToken, err := p.NewHistoryBranchTokenByBranchID("treeID1", "branchID1")
+	s.NoError(err)
+	s.db.On("DeleteHistoryBranch", mock.Anything, &p.DeleteHistoryBranchRequest{
+		BranchToken: branchToken,
+		ShardID:     common.IntPtr(3),
+		DomainName:  "domainName1",
+	}).Return(nil).Once()
+
+	hbd, err := s.fixer.Run(context.Background(), []string{"report_0", "report_1"})
+	s.NoError(err)
+	s.Equal(FixerHeartbeatDetails{
+		NextReportIndex: 2,
+		SkipCount:       1,
+		ErrorCount:      1,
+		SuccCount:       1,
+	}, hbd)
+	s.db.AssertExpectations(s.T())
+}
+
+func (s *FixerTestSuite) TestRun_ResumesFromHeartbeat() {
+	s.fixer.hbd = FixerHeartbeatDetails{NextReportIndex: 1, SuccCount: 5}
+
+	hbd, err := s.fixer.Run(context.Background(), []string{"report_0"})
+	s.NoError(err)
+	s.Equal(FixerHeartbeatDetails{NextReportIndex: 1, SuccCount: 5}, hbd)
+	s.blobstoreClient.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (s *FixerTestSuite) TestRun_MalformedReport() {
+	s.blobstoreClient.On("Get", mock.Anything, &blobstore.GetRequest{Key: "report_0"}).Return(&blobstore.GetResponse{
+		Blob: blobstore.Blob{Body: []byte("not json\n")},
+	}, nil).Once()
+
+	hbd, err := s.fixer.Run(context.Background(), []string{"report_0"})
+	s.Error(err)
+	s.Equal(0, hbd.NextReportIndex)
+}
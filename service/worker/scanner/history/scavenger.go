@@ -21,7 +21,11 @@
 package history
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/cadence/activity"
@@ -29,7 +33,9 @@ import (
 
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -46,6 +52,31 @@ type (
 		SkipCount     int
 		ErrorCount    int
 		SuccCount     int
+		// OrphanCount is the number of orphaned branches reported in dry-run mode
+		OrphanCount int
+		// ReportKeys are the blobstore keys of the orphan reports written in dry-run mode
+		ReportKeys []string
+	}
+
+	// DryRunParams makes the Scavenger report orphaned history branches to the blobstore instead of deleting them
+	DryRunParams struct {
+		Enabled         bool
+		BlobstoreClient blobstore.Client
+		// ReportPrefix is prepended to the key of every report, it must be unique for each scan
+		ReportPrefix string
+	}
+
+	// OrphanedBranch is a history branch whose workflow execution no longer exists.
+	// Dry-run reports contain one JSON encoded OrphanedBranch per line.
+	OrphanedBranch struct {
+		DomainID    string
+		WorkflowID  string
+		RunID       string
+		TreeID      string
+		BranchID    string
+		ShardID     *int
+		ForkTime    time.Time
+		SizeInBytes int64
 	}
 
 	// Scavenger is the type that holds the state for history scavenger daemon
@@ -60,6 +91,10 @@ type (
 		logger                     log.Logger
 		isInTest                   bool
 		domainCache                cache.DomainCache
+		dryRun                     DryRunParams
+
+		orphansLock sync.Mutex
+		orphans     []OrphanedBranch
 	}
 
 	taskDetail struct {
@@ -68,6 +103,8 @@ type (
 		runID      string
 		treeID     string
 		branchID   string
+		shardID    *int
+		forkTime   time.Time
 
 		// passing along the current heartbeat details to make heartbeat within a task so that it won't timeout
 		hbd ScavengerHeartbeatDetails
@@ -96,6 +133,9 @@ func getHistoryCleanupThreshold(maxWorkflowRetentionInDays int) time.Duration {
 // each branch, the scavenger will attempt
 //   - describe the corresponding workflow execution
 //   - deletion of history itself, if there are no workflow execution
+//
+// When dryRun is enabled, the orphaned branches are written to the blobstore
+// with their sizes instead of being deleted, see HistoryFixerWorkflow.
 func NewScavenger(
 	db p.HistoryManager,
 	rps int,
@@ -105,6 +145,7 @@ func NewScavenger(
 	logger log.Logger,
 	maxWorkflowRetentionInDays dynamicproperties.IntPropertyFn,
 	domainCache cache.DomainCache,
+	dryRun DryRunParams,
 ) *Scavenger {
 
 	rateLimiter := rate.NewLimiter(rate.Limit(rps), rps)
@@ -119,6 +160,7 @@ func NewScavenger(
 		metrics:                    metricsClient,
 		logger:                     logger,
 		domainCache:                domainCache,
+		dryRun:                     dryRun,
 	}
 }
 
@@ -168,6 +210,8 @@ func (s *Scavenger) Run(ctx context.Context) (ScavengerHeartbeatDetails, error)
 				runID:      rid,
 				treeID:     br.TreeID,
 				branchID:   br.BranchID,
+				shardID:    br.ShardID,
+				forkTime:   br.ForkTime,
 
 				hbd: s.hbd,
 			}
@@ -197,6 +241,11 @@ func (s *Scavenger) Run(ctx context.Context) (ScavengerHeartbeatDetails, error)
 			}
 		}
 
+		// the report must be persisted before moving on, so a retried activity re-scans the page instead of losing it
+		if err := s.flushReport(ctx); err != nil {
+			return s.hbd, err
+		}
+
 		s.hbd.CurrentPage++
 		s.hbd.NextPageToken = resp.NextPageToken
 		s.hbd.SuccCount += succCount
@@ -241,64 +290,191 @@ func (s *Scavenger) startTaskProcessor(
 
 			// this checks if the mutableState still exists
 			// if not then the history branch is garbage, we need to delete the history branch
-			_, err = s.client.DescribeMutableState(ctx, &types.DescribeMutableStateRequest{
-				DomainUUID: task.domainID,
-				Execution: &types.WorkflowExecution{
-					WorkflowID: task.workflowID,
-					RunID:      task.runID,
-				},
-			})
-
+			exists, err := workflowExists(ctx, s.client, task.domainID, task.workflowID, task.runID)
 			if err != nil {
-				if _, ok := err.(*types.EntityNotExistsError); ok {
-					// deleting history branch
-					var branchToken []byte
-					branchToken, err = p.NewHistoryBranchTokenByBranchID(task.treeID, task.branchID)
-					if err != nil {
-						respCh <- err
-						s.logger.Error("encounter error when creating branch token",
-							getTaskLoggingTags(err, task)...)
-						continue
-					}
-					domainName, err := s.domainCache.GetDomainName(task.domainID)
-					if err != nil {
-						respCh <- err
-						s.logger.Error("Unexpected: Encountered error while fetching domain name",
-							getTaskLoggingTags(err, task)...)
-						continue
-					}
-					err = s.db.DeleteHistoryBranch(ctx, &p.DeleteHistoryBranchRequest{
-						BranchToken: branchToken,
-						// This is a required argument but it is not needed for Cassandra.
-						// Since this scanner is only for Cassandra,
-						// we can fill any number here to let to code go through
-						ShardID:    common.IntPtr(1),
-						DomainName: domainName,
-					})
-					if err != nil {
-						respCh <- err
-						s.logger.Error("encounter error when deleting garbage history branch",
-							getTaskLoggingTags(err, task)...)
-					} else {
-						// deleted garbage
-						s.logger.Info("deleted history garbage",
-							getTaskLoggingTags(nil, task)...)
-
-						respCh <- nil
-					}
-				} else {
-					s.logger.Error("encounter error when describing the mutable state",
-						getTaskLoggingTags(err, task)...)
-					respCh <- err
-				}
-			} else {
+				s.logger.Error("encounter error when describing the mutable state",
+					getTaskLoggingTags(err, task)...)
+				respCh <- err
+				continue
+			}
+			if exists {
 				// no garbage
 				respCh <- nil
+				continue
+			}
+
+			if s.dryRun.Enabled {
+				respCh <- s.reportOrphanedBranch(ctx, task)
+				continue
+			}
+
+			err = deleteHistoryBranch(ctx, s.db, s.domainCache, task.domainID, task.treeID, task.branchID, task.shardID)
+			if err != nil {
+				s.logger.Error("encounter error when deleting garbage history branch",
+					getTaskLoggingTags(err, task)...)
+			} else {
+				// deleted garbage
+				s.logger.Info("deleted history garbage",
+					getTaskLoggingTags(nil, task)...)
 			}
+			respCh <- err
 		}
 	}
 }
 
+func (s *Scavenger) reportOrphanedBranch(ctx context.Context, task taskDetail) error {
+	size, err := s.getBranchSize(ctx, task)
+	if err != nil {
+		s.logger.Error("encounter error when reading size of garbage history branch",
+			getTaskLoggingTags(err, task)...)
+		return err
+	}
+
+	s.orphansLock.Lock()
+	defer s.orphansLock.Unlock()
+	s.orphans = append(s.orphans, OrphanedBranch{
+		DomainID:    task.domainID,
+		WorkflowID:  task.workflowID,
+		RunID:       task.runID,
+		TreeID:      task.treeID,
+		BranchID:    task.branchID,
+		ShardID:     task.shardID,
+		ForkTime:    task.forkTime,
+		SizeInBytes: size,
+	})
+	return nil
+}
+
+func (s *Scavenger) getBranchSize(ctx context.Context, task taskDetail) (int64, error) {
+	branchToken, err := p.NewHistoryBranchTokenByBranchID(task.treeID, task.branchID)
+	if err != nil {
+		return 0, err
+	}
+	domainName, err := s.domainCache.GetDomainName(task.domainID)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	request := &p.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  constants.FirstEventID,
+		MaxEventID:  constants.EndEventID,
+		PageSize:    pageSize,
+		ShardID:     shardIDOrDefault(task.shardID),
+		DomainName:  domainName,
+	}
+	for {
+		resp, err := s.db.ReadRawHistoryBranch(ctx, request)
+		if err != nil {
+			if _, ok := err.(*types.EntityNotExistsError); ok {
+				// the branch has no events left
+				return size, nil
+			}
+			return 0, err
+		}
+		size += int64(resp.Size)
+		if len(resp.NextPageToken) == 0 {
+			return size, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// flushReport writes the orphaned branches found on the current page to the blobstore
+func (s *Scavenger) flushReport(ctx context.Context) error {
+	s.orphansLock.Lock()
+	orphans := s.orphans
+	s.orphans = nil
+	s.orphansLock.Unlock()
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	for _, orphan := range orphans {
+		data, err := json.Marshal(orphan)
+		if err != nil {
+			return err
+		}
+		body.Write(data)
+		body.WriteByte('\n')
+	}
+
+	key := fmt.Sprintf("%v_%v.orphans", s.dryRun.ReportPrefix, s.hbd.CurrentPage)
+	if _, err := s.dryRun.BlobstoreClient.Put(ctx, &blobstore.PutRequest{
+		Key:  key,
+		Blob: blobstore.Blob{Body: body.Bytes()},
+	}); err != nil {
+		s.logger.Error("failed to write history scavenger report", tag.Error(err), tag.Key(key))
+		return err
+	}
+
+	s.logger.Info("wrote history scavenger report", tag.Key(key), tag.Counter(len(orphans)))
+	s.hbd.OrphanCount += len(orphans)
+	s.hbd.ReportKeys = append(s.hbd.ReportKeys, key)
+	return nil
+}
+
+// workflowExists checks if the mutable state of a workflow execution still exists
+func workflowExists(
+	ctx context.Context,
+	client history.Client,
+	domainID string,
+	workflowID string,
+	runID string,
+) (bool, error) {
+	_, err := client.DescribeMutableState(ctx, &types.DescribeMutableStateRequest{
+		DomainUUID: domainID,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func deleteHistoryBranch(
+	ctx context.Context,
+	db p.HistoryManager,
+	domainCache cache.DomainCache,
+	domainID string,
+	treeID string,
+	branchID string,
+	shardID *int,
+) error {
+	branchToken, err := p.NewHistoryBranchTokenByBranchID(treeID, branchID)
+	if err != nil {
+		return fmt.Errorf("failed to create branch token: %w", err)
+	}
+	domainName, err := domainCache.GetDomainName(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to get domain name: %w", err)
+	}
+	return db.DeleteHistoryBranch(ctx, &p.DeleteHistoryBranchRequest{
+		BranchToken: branchToken,
+		ShardID:     shardIDOrDefault(shardID),
+		DomainName:  domainName,
+	})
+}
+
+// shardIDOrDefault returns the shard of a branch as listed by the store.
+// The shard is a required argument but it is not needed for Cassandra, which doesn't list it,
+// so we can fill any number there to let the code go through.
+func shardIDOrDefault(shardID *int) *int {
+	if shardID == nil {
+		return common.IntPtr(1)
+	}
+	return shardID
+}
+
 func getTaskLoggingTags(err error, task taskDetail) []tag.Tag {
 	if err != nil {
 		return []tag.Tag{
@@ -22,7 +22,9 @@ package history
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,7 +35,9 @@ import (
 
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
@@ -68,7 +72,7 @@ func (s *ScavengerTestSuite) createTestScavenger(rps int) (*mocks.HistoryV2Manag
 	controller := gomock.NewController(s.T())
 	workflowClient := history.NewMockClient(controller)
 	maxWorkflowRetentionInDays := dynamicproperties.GetIntPropertyFn(dynamicproperties.MaxRetentionDays.DefaultInt())
-	scvgr := NewScavenger(db, rps, workflowClient, ScavengerHeartbeatDetails{}, s.metric, s.logger, maxWorkflowRetentionInDays, s.mockCache)
+	scvgr := NewScavenger(db, rps, workflowClient, ScavengerHeartbeatDetails{}, s.metric, s.logger, maxWorkflowRetentionInDays, s.mockCache, DryRunParams{})
 	scvgr.isInTest = true
 	return db, workflowClient, scvgr
 }
@@ -372,6 +376,111 @@ func (s *ScavengerTestSuite) TestDeletingBranchesTwoPages() {
 	s.Equal(0, len(hbd.NextPageToken))
 }
 
+func (s *ScavengerTestSuite) TestDryRunReportsBranches() {
+	db, client, scvgr := s.createTestScavenger(100)
+	blobstoreClient := &blobstore.MockClient{}
+	scvgr.dryRun = DryRunParams{
+		Enabled:         true,
+		BlobstoreClient: blobstoreClient,
+		ReportPrefix:    "history_scanner_runID",
+	}
+	forkTime := time.Now().Add(-getHistoryCleanupThreshold(dynamicproperties.MaxRetentionDays.DefaultInt()) * 2).UTC()
+	db.On("GetAllHistoryTreeBranches", mock.Anything, &p.GetAllHistoryTreeBranchesRequest{
+		PageSize: pageSize,
+	}).Return(&p.GetAllHistoryTreeBranchesResponse{
+		Branches: []p.HistoryBranchDetail{
+			{
+				TreeID:   "treeID1",
+				BranchID: "branchID1",
+				ForkTime: forkTime,
+				Info:     p.BuildHistoryGarbageCleanupInfo("domainID1", "workflowID1", "runID1"),
+				ShardID:  common.IntPtr(7),
+			},
+			{
+				TreeID:   "treeID2",
+				BranchID: "branchID2",
+				ForkTime: forkTime,
+				Info:     p.BuildHistoryGarbageCleanupInfo("domainID2", "workflowID2", "runID2"),
+				ShardID:  common.IntPtr(8),
+			},
+		},
+	}, nil).Once()
+
+	client.EXPECT().DescribeMutableState(gomock.Any(), &types.DescribeMutableStateRequest{
+		DomainUUID: "domainID1",
+		Execution: &types.WorkflowExecution{
+			WorkflowID: "workflowID1",
+			RunID:      "runID1",
+		},
+	}).Return(nil, &types.EntityNotExistsError{})
+	client.EXPECT().DescribeMutableState(gomock.Any(), &types.DescribeMutableStateRequest{
+		DomainUUID: "domainID2",
+		Execution: &types.WorkflowExecution{
+			WorkflowID: "workflowID2",
+			RunID:      "runID2",
+		},
+	}).Return(nil, nil)
+	domainName := "test-domainName"
+	s.mockCache.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+	branchToken1, err := p.NewHistoryBranchTokenByBranchID("treeID1", "branchID1")
+	s.Nil(err)
+	db.On("ReadRawHistoryBranch", mock.Anything, &p.ReadHistoryBranchRequest{
+		BranchToken: branchToken1,
+		MinEventID:  constants.FirstEventID,
+		MaxEventID:  constants.EndEventID,
+		PageSize:    pageSize,
+		ShardID:     common.IntPtr(7),
+		DomainName:  domainName,
+	}).Return(&p.ReadRawHistoryBranchResponse{
+		Size:          100,
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	db.On("ReadRawHistoryBranch", mock.Anything, &p.ReadHistoryBranchRequest{
+		BranchToken:   branchToken1,
+		MinEventID:    constants.FirstEventID,
+		MaxEventID:    constants.EndEventID,
+		PageSize:      pageSize,
+		NextPageToken: []byte("next"),
+		ShardID:       common.IntPtr(7),
+		DomainName:    domainName,
+	}).Return(&p.ReadRawHistoryBranchResponse{
+		Size: 23,
+	}, nil).Once()
+
+	var report []byte
+	blobstoreClient.On("Put", mock.Anything, mock.MatchedBy(func(req *blobstore.PutRequest) bool {
+		return req.Key == "history_scanner_runID_0.orphans"
+	})).Run(func(args mock.Arguments) {
+		report = args.Get(1).(*blobstore.PutRequest).Blob.Body
+	}).Return(&blobstore.PutResponse{}, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hbd, err := scvgr.Run(ctx)
+	s.Nil(err)
+	s.Equal(2, hbd.SuccCount)
+	s.Equal(0, hbd.ErrorCount)
+	s.Equal(1, hbd.OrphanCount)
+	s.Equal([]string{"history_scanner_runID_0.orphans"}, hbd.ReportKeys)
+	db.AssertNotCalled(s.T(), "DeleteHistoryBranch", mock.Anything, mock.Anything)
+	blobstoreClient.AssertExpectations(s.T())
+
+	lines := strings.Split(strings.TrimSpace(string(report)), "\n")
+	s.Len(lines, 1)
+	var orphan OrphanedBranch
+	s.NoError(json.Unmarshal([]byte(lines[0]), &orphan))
+	s.Equal(OrphanedBranch{
+		DomainID:    "domainID1",
+		WorkflowID:  "workflowID1",
+		RunID:       "runID1",
+		TreeID:      "treeID1",
+		BranchID:    "branchID1",
+		ShardID:     common.IntPtr(7),
+		ForkTime:    forkTime,
+		SizeInBytes: 123,
+	}, orphan)
+}
+
 func (s *ScavengerTestSuite) TestMixesTwoPages() {
 	db, client, scvgr := s.createTestScavenger(100)
 	db.On("GetAllHistoryTreeBranches", mock.Anything, &p.GetAllHistoryTreeBranchesRequest{
@@ -67,6 +67,9 @@ type (
 		ClusterMetadata cluster.Metadata
 		// HistoryScannerEnabled indicates if history scanner should be started as part of scanner
 		HistoryScannerEnabled dynamicproperties.BoolPropertyFn
+		// HistoryScannerDryRun indicates if history scanner should only report orphaned history branches
+		// to the blobstore, so they can be reviewed and deleted later by the history fixer
+		HistoryScannerDryRun dynamicproperties.BoolPropertyFn
 		// ShardScanners is a list of shard scanner configs
 		ShardScanners              []*shardscanner.ScannerConfig
 		MaxWorkflowRetentionInDays dynamicproperties.IntPropertyFn
@@ -153,6 +156,8 @@ func (s *Scanner) Start() error {
 			ctx,
 			historyScannerWFStartOptions,
 			historyScannerWFTypeName)
+		// the history fixer is started on demand by operators, on the same task list as the scanner
+		ctx = NewScannerContext(ctx, historyFixerWFTypeName, s.context)
 		workerTaskListNames = append(workerTaskListNames, historyScannerTaskListName)
 	}
 
@@ -22,6 +22,7 @@ package scanner
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.uber.org/cadence"
@@ -50,6 +51,16 @@ const (
 	historyScannerWFTypeName     = "cadence-sys-history-scanner-workflow"
 	historyScannerTaskListName   = "cadence-sys-history-scanner-tasklist-0"
 	historyScavengerActivityName = "cadence-sys-history-scanner-scvg-activity"
+	historyFixerWFTypeName       = "cadence-sys-history-fixer-workflow"
+	historyFixerActivityName     = "cadence-sys-history-fixer-activity"
+)
+
+type (
+	// HistoryFixerWorkflowParams are the params of the history fixer workflow
+	HistoryFixerWorkflowParams struct {
+		// ReportKeys are the blobstore keys of the reports written by a dry-run of the history scanner
+		ReportKeys []string
+	}
 )
 
 var (
@@ -89,6 +100,8 @@ func init() {
 
 	workflow.RegisterWithOptions(HistoryScannerWorkflow, workflow.RegisterOptions{Name: historyScannerWFTypeName})
 	activity.RegisterWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
+	workflow.RegisterWithOptions(HistoryFixerWorkflow, workflow.RegisterOptions{Name: historyFixerWFTypeName})
+	activity.RegisterWithOptions(HistoryFixerActivity, activity.RegisterOptions{Name: historyFixerActivityName})
 
 	workflow.RegisterWithOptions(executions.ConcreteScannerWorkflow, workflow.RegisterOptions{Name: executions.ConcreteExecutionsScannerWFTypeName})
 	workflow.RegisterWithOptions(executions.CurrentScannerWorkflow, workflow.RegisterOptions{Name: executions.CurrentExecutionsScannerWFTypeName})
@@ -108,15 +121,35 @@ func TaskListScannerWorkflow(
 }
 
 // HistoryScannerWorkflow is the workflow that runs the history scanner background daemon
+// In dry-run mode, the returned details contain the keys of the orphan reports to pass to the history fixer
 func HistoryScannerWorkflow(
 	ctx workflow.Context,
-) error {
+) (history.ScavengerHeartbeatDetails, error) {
 
+	var result history.ScavengerHeartbeatDetails
 	future := workflow.ExecuteActivity(
 		workflow.WithActivityOptions(ctx, activityOptions),
 		historyScavengerActivityName,
 	)
-	return future.Get(ctx, nil)
+	err := future.Get(ctx, &result)
+	return result, err
+}
+
+// HistoryFixerWorkflow is the workflow that deletes the orphaned history branches
+// reported by a dry-run of the history scanner, once an operator approved them
+func HistoryFixerWorkflow(
+	ctx workflow.Context,
+	params HistoryFixerWorkflowParams,
+) (history.FixerHeartbeatDetails, error) {
+
+	var result history.FixerHeartbeatDetails
+	future := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, activityOptions),
+		historyFixerActivityName,
+		params,
+	)
+	err := future.Get(ctx, &result)
+	return result, err
 }
 
 // HistoryScavengerActivity is the activity that runs history scavenger
@@ -138,6 +171,10 @@ func HistoryScavengerActivity(
 			res.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
 		}
 	}
+	dryRun := ctx.cfg.HistoryScannerDryRun()
+	if dryRun && res.GetBlobstoreClient() == nil {
+		return history.ScavengerHeartbeatDetails{}, fmt.Errorf("blobstore is not configured, cannot write history scanner reports")
+	}
 	cache := res.GetDomainCache()
 	scavenger := history.NewScavenger(
 		res.GetHistoryManager(),
@@ -148,10 +185,50 @@ func HistoryScavengerActivity(
 		res.GetLogger(),
 		ctx.cfg.MaxWorkflowRetentionInDays,
 		cache,
+		history.DryRunParams{
+			Enabled:         dryRun,
+			BlobstoreClient: res.GetBlobstoreClient(),
+			ReportPrefix:    fmt.Sprintf("history_scanner_%v", activity.GetInfo(activityCtx).WorkflowExecution.RunID),
+		},
 	)
 	return scavenger.Run(activityCtx)
 }
 
+// HistoryFixerActivity is the activity that deletes the history branches listed in history scanner reports
+func HistoryFixerActivity(
+	activityCtx context.Context,
+	params HistoryFixerWorkflowParams,
+) (history.FixerHeartbeatDetails, error) {
+
+	ctx, err := getScannerContext(activityCtx)
+	if err != nil {
+		return history.FixerHeartbeatDetails{}, err
+	}
+
+	res := ctx.resource
+	if res.GetBlobstoreClient() == nil {
+		return history.FixerHeartbeatDetails{}, fmt.Errorf("blobstore is not configured, cannot read history scanner reports")
+	}
+
+	hbd := history.FixerHeartbeatDetails{}
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &hbd); err != nil {
+			res.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+		}
+	}
+	fixer := history.NewFixer(
+		res.GetHistoryManager(),
+		ctx.cfg.ScannerPersistenceMaxQPS(),
+		res.GetHistoryClient(),
+		res.GetBlobstoreClient(),
+		hbd,
+		res.GetMetricsClient(),
+		res.GetLogger(),
+		res.GetDomainCache(),
+	)
+	return fixer.Run(activityCtx, params.ReportKeys)
+}
+
 // TaskListScavengerActivity is the activity that runs task list scavenger
 func TaskListScavengerActivity(
 	activityCtx context.Context,
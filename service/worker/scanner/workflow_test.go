@@ -35,6 +35,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/service/worker/scanner/history"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
 )
 
@@ -54,6 +55,37 @@ func (s *scannerWorkflowTestSuite) TestWorkflow() {
 	s.True(env.IsWorkflowCompleted())
 }
 
+func (s *scannerWorkflowTestSuite) TestHistoryScannerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(historyScavengerActivityName, mock.Anything).Return(history.ScavengerHeartbeatDetails{
+		OrphanCount: 2,
+		ReportKeys:  []string{"history_scanner_run_0.orphans"},
+	}, nil)
+	env.ExecuteWorkflow(historyScannerWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	var result history.ScavengerHeartbeatDetails
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal([]string{"history_scanner_run_0.orphans"}, result.ReportKeys)
+}
+
+func (s *scannerWorkflowTestSuite) TestHistoryFixerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	params := HistoryFixerWorkflowParams{ReportKeys: []string{"history_scanner_run_0.orphans"}}
+	env.OnActivity(historyFixerActivityName, mock.Anything, params).Return(history.FixerHeartbeatDetails{
+		NextReportIndex: 1,
+		SuccCount:       2,
+	}, nil)
+	env.ExecuteWorkflow(historyFixerWFTypeName, params)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	var result history.FixerHeartbeatDetails
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal(2, result.SuccCount)
+}
+
 func (s *scannerWorkflowTestSuite) TestScavengerActivity() {
 	env := s.NewTestActivityEnvironment()
 	controller := gomock.NewController(s.T())
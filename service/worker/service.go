@@ -28,6 +28,7 @@ import (
 	"sync/atomic"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/asyncworkflow/requeststatus"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/constants"
@@ -83,6 +84,8 @@ type (
 		ThrottledLogRPS                     dynamicproperties.IntPropertyFn
 		PersistenceGlobalMaxQPS             dynamicproperties.IntPropertyFn
 		PersistenceMaxQPS                   dynamicproperties.IntPropertyFn
+		PersistenceGlobalRatelimiterMode    dynamicproperties.StringPropertyWithRatelimitKeyFilter
+		GlobalRatelimiterUpdateInterval     dynamicproperties.DurationPropertyFn
 		EnableBatcher                       dynamicproperties.BoolPropertyFn
 		EnableParentClosePolicyWorker       dynamicproperties.BoolPropertyFn
 		NumParentClosePolicySystemWorkflows dynamicproperties.IntPropertyFn
@@ -90,6 +93,8 @@ type (
 		DomainReplicationMaxRetryDuration   dynamicproperties.DurationPropertyFn
 		EnableESAnalyzer                    dynamicproperties.BoolPropertyFn
 		EnableAsyncWorkflowConsumption      dynamicproperties.BoolPropertyFn
+		AsyncWorkflowRequestStatusTTL       dynamicproperties.DurationPropertyFnWithDomainFilter
+		DiagnosticsEnabledInvariants        dynamicproperties.MapPropertyFnWithDomainFilter
 		HostName                            string
 	}
 )
@@ -105,6 +110,9 @@ func NewService(params *resource.Params) (resource.Resource, error) {
 			PersistenceGlobalMaxQPS:  serviceConfig.PersistenceGlobalMaxQPS,
 			ThrottledLoggerMaxRPS:    serviceConfig.ThrottledLogRPS,
 			IsErrorRetryableFunction: common.IsServiceTransientError,
+
+			PersistenceGlobalRatelimiterMode: serviceConfig.PersistenceGlobalRatelimiterMode,
+			GlobalRatelimiterUpdateInterval:  serviceConfig.GlobalRatelimiterUpdateInterval,
 			// worker service doesn't need visibility config as it never call visibilityManager API
 		},
 	)
@@ -148,6 +156,7 @@ func NewConfig(params *resource.Params) *Config {
 			ClusterMetadata:        params.ClusterMetadata,
 			TaskListScannerEnabled: dc.GetBoolProperty(dynamicproperties.TaskListScannerEnabled),
 			HistoryScannerEnabled:  dc.GetBoolProperty(dynamicproperties.HistoryScannerEnabled),
+			HistoryScannerDryRun:   dc.GetBoolProperty(dynamicproperties.HistoryScannerDryRun),
 			ShardScanners: []*shardscanner.ScannerConfig{
 				executions.ConcreteExecutionConfig(dc),
 				executions.CurrentExecutionConfig(dc),
@@ -187,8 +196,12 @@ func NewConfig(params *resource.Params) *Config {
 		ThrottledLogRPS:                     dc.GetIntProperty(dynamicproperties.WorkerThrottledLogRPS),
 		PersistenceGlobalMaxQPS:             dc.GetIntProperty(dynamicproperties.WorkerPersistenceGlobalMaxQPS),
 		PersistenceMaxQPS:                   dc.GetIntProperty(dynamicproperties.WorkerPersistenceMaxQPS),
+		PersistenceGlobalRatelimiterMode:    dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.PersistenceGlobalRatelimiterMode),
+		GlobalRatelimiterUpdateInterval:     dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
 		DomainReplicationMaxRetryDuration:   dc.GetDurationProperty(dynamicproperties.WorkerReplicationTaskMaxRetryDuration),
 		EnableAsyncWorkflowConsumption:      dc.GetBoolProperty(dynamicproperties.EnableAsyncWorkflowConsumption),
+		AsyncWorkflowRequestStatusTTL:       dc.GetDurationPropertyFilteredByDomain(dynamicproperties.AsyncWorkflowRequestStatusTTL),
+		DiagnosticsEnabledInvariants:        dc.GetMapPropertyFilteredByDomain(dynamicproperties.DiagnosticsEnabledInvariants),
 		HostName:                            params.HostName,
 	}
 	advancedVisWritingMode := dc.GetStringProperty(
@@ -255,6 +268,9 @@ func (s *Service) Start() {
 
 	cm := s.startAsyncWorkflowConsumerManager()
 	defer cm.Stop()
+	if scavenger := s.startAsyncRequestStatusScavenger(); scavenger != nil {
+		defer scavenger.Stop()
+	}
 
 	logger.Info("worker started", tag.ComponentWorker)
 	<-s.stopC
@@ -366,6 +382,9 @@ func (s *Service) startDiagnostics() {
 		Logger:          s.GetLogger(),
 		Invariants:      s.params.DiagnosticsInvariants,
 		ClusterMetadata: s.GetClusterMetadata(),
+		BlobstoreClient: s.GetBlobstoreClient(),
+		RuleFiles:       s.params.DiagnosticsRuleFiles,
+		EnabledPlugins:  s.config.DiagnosticsEnabledInvariants,
 	}
 	if err := diagnostics.New(params).Start(); err != nil {
 		s.Stop()
@@ -464,18 +483,39 @@ func (s *Service) startFailoverManager() {
 }
 
 func (s *Service) startAsyncWorkflowConsumerManager() common.Daemon {
+	options := []asyncworkflow.ConsumerManagerOptions{
+		asyncworkflow.WithEnabledPropertyFn(s.config.EnableAsyncWorkflowConsumption),
+		asyncworkflow.WithQueueManager(s.GetPersistenceBean().GetAsyncWorkflowQueueManager()),
+		asyncworkflow.WithMembershipResolver(s.GetMembershipResolver()),
+	}
+	// the status of async requests can only be described if a blobstore is configured
+	if blobstoreClient := s.GetBlobstoreClient(); blobstoreClient != nil {
+		statusStore := requeststatus.NewBlobstoreStore(blobstoreClient, s.GetTimeSource(), s.config.AsyncWorkflowRequestStatusTTL)
+		options = append(options, asyncworkflow.WithStatusStore(statusStore))
+	}
 	cm := asyncworkflow.NewConsumerManager(
 		s.GetLogger(),
 		s.GetMetricsClient(),
 		s.GetDomainCache(),
 		s.Resource.GetAsyncWorkflowQueueProvider(),
 		s.GetFrontendClient(),
-		asyncworkflow.WithEnabledPropertyFn(s.config.EnableAsyncWorkflowConsumption),
+		options...,
 	)
 	cm.Start()
 	return cm
 }
 
+// startAsyncRequestStatusScavenger deletes expired async request statuses, they are only recorded if a blobstore is configured
+func (s *Service) startAsyncRequestStatusScavenger() common.Daemon {
+	blobstoreClient := s.GetBlobstoreClient()
+	if blobstoreClient == nil {
+		return nil
+	}
+	scavenger := requeststatus.NewScavenger(blobstoreClient, s.GetTimeSource(), s.GetLogger())
+	scavenger.Start()
+	return scavenger
+}
+
 func (s *Service) startDomainDeprecation() {
 	params := domaindeprecation.Params{
 		Config: domaindeprecation.Config{
@@ -106,6 +106,45 @@ func newAdminWorkflowCommands() []*cli.Command {
 			},
 			Action: AdminRefreshWorkflowTasks,
 		},
+		{
+			Name:  "export",
+			Usage: "Export the history of a workflow run to a portable file, to import it into another domain or cluster",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: []string{"w", "wid"},
+					Usage:   "WorkflowID",
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: []string{"r", "rid"},
+					Usage:   "RunID",
+				},
+				&cli.StringFlag{
+					Name:  FlagEncodingType,
+					Value: "thriftrw",
+					Usage: "Encoding of the exported events, thriftrw or proto3",
+				},
+				&cli.StringFlag{
+					Name:    FlagOutputFilename,
+					Aliases: []string{"of"},
+					Usage:   "Output file, defaults to stdout",
+				},
+			},
+			Action: AdminExportWorkflow,
+		},
+		{
+			Name:  "import",
+			Usage: "Import a workflow run exported by the export command into the domain, which must be a global domain",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagInputFile,
+					Aliases: []string{"if"},
+					Usage:   "File written by the export command",
+				},
+			},
+			Action: AdminImportWorkflow,
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -857,7 +896,7 @@ func newAdminDLQCommands() []*cli.Command {
 		{
 			Name:    "read",
 			Aliases: []string{"r"},
-			Usage:   "Read DLQ Messages",
+			Usage:   "Read DLQ Messages, history DLQ messages also show the status of their automatic re-drive",
 			Flags: append(getDLQFlags(),
 				&cli.IntFlag{
 					Name:    FlagMaxMessageCount,
@@ -885,6 +924,34 @@ func newAdminDLQCommands() []*cli.Command {
 	}
 }
 
+func newAdminReplicationCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "status",
+			Usage: "Show the replication lag of every shard and domain to a remote cluster, and whether it is safe to fail over to it",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagCluster,
+					Usage:    "Remote cluster to show the replication status for",
+					Required: true,
+				},
+				&cli.IntFlag{
+					Name:    FlagShardID,
+					Aliases: []string{"sid"},
+					Usage:   "Only show the replication status of this shard",
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxReplicationLag,
+					Value: defaultMaxReplicationLagInSeconds,
+					Usage: "Maximum replication lag in seconds that still allows failing over to the remote cluster",
+				},
+				getFormatFlag(),
+			},
+			Action: AdminReplicationStatus,
+		},
+	}
+}
+
 func newAdminQueueCommands() []*cli.Command {
 	return []*cli.Command{
 		{
@@ -922,6 +989,25 @@ func newAdminAsyncQueueCommands() []*cli.Command {
 			},
 			Action: AdminUpdateAsyncWFConfig,
 		},
+		{
+			Name:  "inspect",
+			Usage: "show the depth of the database async workflow queue and a sample of the pending requests per domain",
+			Flags: append(getDBFlags(),
+				&cli.IntFlag{
+					Name:  FlagSampleSize,
+					Value: defaultAsyncQueueSampleSize,
+					Usage: "Number of pending requests to show per domain",
+				},
+				&cli.IntFlag{
+					Name:    FlagMaxMessageCount,
+					Aliases: []string{"mmc"},
+					Value:   defaultAsyncQueueMaxMessageCount,
+					Usage:   "Max number of pending requests to read from the queue",
+				},
+				getFormatFlag(),
+			),
+			Action: AdminInspectAsyncWFQueue,
+		},
 	}
 }
 
@@ -1260,6 +1346,148 @@ func newAdminFailoverCommands() []*cli.Command {
 			},
 			Action: AdminFailoverList,
 		},
+		{
+			Name:        "plan",
+			Usage:       "Schedule pre-validated failovers of domains with domain data IsManagedByCadence=true",
+			Subcommands: newAdminFailoverPlanCommands(),
+		},
+	}
+}
+
+func newAdminFailoverPlanCommands() []*cli.Command {
+	nameFlag := &cli.StringFlag{
+		Name:     FlagName,
+		Aliases:  []string{"n"},
+		Usage:    "Name of the failover plan",
+		Required: true,
+	}
+	runIDFlag := &cli.StringFlag{
+		Name:    FlagRunID,
+		Aliases: []string{"rid", "r"},
+		Usage:   "Optional failover plan workflow runID, default is latest runID",
+	}
+	return []*cli.Command{
+		{
+			Name:    "create",
+			Aliases: []string{"c"},
+			Usage:   "create a failover plan, pre-flight checks run at creation and again at the start time",
+			Flags: []cli.Flag{
+				nameFlag,
+				&cli.StringFlag{
+					Name:     FlagTargetCluster,
+					Aliases:  []string{"tc"},
+					Usage:    "Target cluster name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     FlagSourceCluster,
+					Aliases:  []string{"sc"},
+					Usage:    "Source cluster name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  FlagFailoverStartTime,
+					Usage: "Optional start time of the failover in RFC3339 format, eg 2006-01-02T15:04:05Z. The default is now",
+				},
+				&cli.StringSliceFlag{
+					Name: FlagFailoverDomains,
+					Usage: "Optional domains to failover, eg d1,d2..,dn. " +
+						"Only provided domains in source cluster will be failover.",
+				},
+				&cli.StringFlag{
+					Name: FlagDomainQuery,
+					Usage: "Optional comma separated field=value terms selecting the domains to failover, " +
+						"fields are name or data.<key> and values support * wildcards, eg name=payments-*,data.tier=1",
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxReplicationLag,
+					Usage: "Max replication lag in seconds of selected domains allowed by pre-flight checks",
+					Value: defaultMaxReplicationLagInSeconds,
+				},
+				&cli.IntFlag{
+					Name:    FlagFailoverTimeout,
+					Aliases: []string{"fts"},
+					Usage:   "Optional graceful failover timeout in seconds. If this field is define, the failover will use graceful failover.",
+				},
+				&cli.IntFlag{
+					Name:    FlagExecutionTimeout,
+					Aliases: []string{"et"},
+					Usage:   "Optional Failover workflow timeout in seconds",
+					Value:   defaultFailoverWorkflowTimeoutInSeconds,
+				},
+				&cli.IntFlag{
+					Name:    FlagFailoverWaitTime,
+					Aliases: []string{"fwts"},
+					Usage:   "Optional Failover wait time after each batch in seconds",
+					Value:   defaultBatchFailoverWaitTimeInSeconds,
+				},
+				&cli.IntFlag{
+					Name:    FlagFailoverBatchSize,
+					Aliases: []string{"fbs"},
+					Usage:   "Optional number of domains to failover in one batch",
+					Value:   defaultBatchFailoverSize,
+				},
+			},
+			Action: AdminFailoverPlanCreate,
+		},
+		{
+			Name:    "describe",
+			Aliases: []string{"d"},
+			Usage:   "describe a failover plan, including its state and pre-flight check results",
+			Flags:   []cli.Flag{nameFlag, runIDFlag},
+			Action:  AdminFailoverPlanDescribe,
+		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "list failover plans closed/open",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    FlagOpen,
+					Aliases: []string{"op"},
+					Usage:   "List for open failover plans, default is to list for closed ones",
+				},
+				&cli.IntFlag{
+					Name:    FlagPageSize,
+					Aliases: []string{"ps"},
+					Value:   10,
+					Usage:   "Result page size",
+				},
+				&cli.StringFlag{
+					Name:  FlagWorkflowType,
+					Usage: "Ignore this. It is a dummy flag which will be forced overwrite",
+				},
+			},
+			Action: AdminFailoverPlanList,
+		},
+		{
+			Name:    "pause",
+			Aliases: []string{"p"},
+			Usage:   "pause a failover plan, a plan paused before its start time does not start until resumed",
+			Flags:   []cli.Flag{nameFlag, runIDFlag},
+			Action:  AdminFailoverPlanPause,
+		},
+		{
+			Name:    "resume",
+			Aliases: []string{"re"},
+			Usage:   "resume a paused failover plan",
+			Flags:   []cli.Flag{nameFlag, runIDFlag},
+			Action:  AdminFailoverPlanResume,
+		},
+		{
+			Name:    "cancel",
+			Aliases: []string{"abort", "a"},
+			Usage:   "cancel a failover plan, domains already failed over are not rolled back",
+			Flags: []cli.Flag{
+				nameFlag,
+				runIDFlag,
+				&cli.StringFlag{
+					Name:  FlagReason,
+					Usage: "Optional reason why the plan is cancelled",
+				},
+			},
+			Action: AdminFailoverPlanCancel,
+		},
 	}
 }
 
@@ -25,13 +25,59 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/uber/cadence/common/asyncworkflow/queue/consumer"
+	"github.com/uber/cadence/common/asyncworkflow/queue/database"
+	"github.com/uber/cadence/common/asyncworkflow/queue/provider"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/tools/common/commoncli"
 )
 
+const (
+	defaultAsyncQueueSampleSize      = 5
+	defaultAsyncQueueMaxMessageCount = 10000
+)
+
+type (
+	// AsyncQueueState is the state of the database async workflow queue
+	AsyncQueueState struct {
+		AckLevel int64 `json:"ackLevel"`
+		DLQSize  int64 `json:"dlqSize"`
+		// PendingRequests is a lower bound of the queue depth if HasMore is set
+		PendingRequests int  `json:"pendingRequests"`
+		HasMore         bool `json:"hasMore"`
+		// CorruptedRequests is the number of pending requests which cannot be decoded
+		CorruptedRequests int                      `json:"corruptedRequests"`
+		Domains           []*AsyncQueueDomainState `json:"domains"`
+	}
+
+	// AsyncQueueDomainState contains the pending requests of a domain
+	AsyncQueueDomainState struct {
+		Domain          string                 `json:"domain"`
+		PendingRequests int                    `json:"pendingRequests"`
+		Samples         []AsyncQueueRequestRow `json:"samples"`
+	}
+
+	// AsyncQueueDomainRow is a row of the pending requests per domain table
+	AsyncQueueDomainRow struct {
+		Domain          string `header:"Domain"`
+		PendingRequests string `header:"Pending Requests"`
+	}
+
+	// AsyncQueueRequestRow is a row of the sampled pending requests table
+	AsyncQueueRequestRow struct {
+		Domain      string `header:"Domain" json:"domain"`
+		MessageID   int64  `header:"Message ID" json:"messageID"`
+		RequestType string `header:"Request Type" json:"requestType"`
+		WorkflowID  string `header:"Workflow ID" json:"workflowID"`
+		RequestID   string `header:"Request ID" json:"requestID"`
+	}
+)
+
 func AdminGetAsyncWFConfig(c *cli.Context) error {
 	adminClient, err := getDeps(c).ServerAdminClient(c)
 	if err != nil {
@@ -107,3 +153,106 @@ func AdminUpdateAsyncWFConfig(c *cli.Context) error {
 	fmt.Printf("Successfully updated async workflow queue config for domain %s\n", domainName)
 	return nil
 }
+
+// AdminInspectAsyncWFQueue shows the requests waiting in the database async workflow queue.
+// All domains share the same database queue, so it is read directly from the database and
+// the pending requests are grouped by the domain they belong to.
+func AdminInspectAsyncWFQueue(c *cli.Context) error {
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	queueManager, err := getDeps(c).initializeAsyncWorkflowQueueManager(c)
+	if err != nil {
+		return commoncli.Problem("Error in inspect async wf queue: ", err)
+	}
+
+	queueState, err := database.NewInspector().Inspect(ctx, &provider.Params{QueueManager: queueManager}, c.Int(FlagMaxMessageCount))
+	if err != nil {
+		return commoncli.Problem("Failed to inspect async wf queue", err)
+	}
+
+	state := summarizeAsyncQueueState(queueState, c.String(FlagDomain), c.Int(FlagSampleSize))
+	if c.String(FlagFormat) == formatJSON {
+		return Render(c, state, RenderOptions{})
+	}
+	return renderAsyncQueueState(c, state)
+}
+
+func summarizeAsyncQueueState(queueState *provider.QueueState, domainFilter string, sampleSize int) *AsyncQueueState {
+	state := &AsyncQueueState{
+		AckLevel: queueState.AckLevel,
+		DLQSize:  queueState.DLQSize,
+		HasMore:  queueState.HasMore,
+	}
+	domains := make(map[string]*AsyncQueueDomainState)
+	for _, message := range queueState.Pending {
+		info, err := consumer.DecodeRequestInfo(message.Payload)
+		if err != nil {
+			state.CorruptedRequests++
+			continue
+		}
+		if domainFilter != "" && info.Domain != domainFilter {
+			continue
+		}
+		state.PendingRequests++
+		domain, ok := domains[info.Domain]
+		if !ok {
+			domain = &AsyncQueueDomainState{Domain: info.Domain}
+			domains[info.Domain] = domain
+			state.Domains = append(state.Domains, domain)
+		}
+		domain.PendingRequests++
+		if len(domain.Samples) < sampleSize {
+			domain.Samples = append(domain.Samples, AsyncQueueRequestRow{
+				Domain:      info.Domain,
+				MessageID:   message.ID,
+				RequestType: info.Type,
+				WorkflowID:  info.WorkflowID,
+				RequestID:   info.RequestID,
+			})
+		}
+	}
+
+	sort.SliceStable(state.Domains, func(i, j int) bool {
+		if state.Domains[i].PendingRequests != state.Domains[j].PendingRequests {
+			return state.Domains[i].PendingRequests > state.Domains[j].PendingRequests
+		}
+		return state.Domains[i].Domain < state.Domains[j].Domain
+	})
+	return state
+}
+
+func renderAsyncQueueState(c *cli.Context, state *AsyncQueueState) error {
+	output := getDeps(c).Output()
+	fmt.Fprintf(output, "Ack level: %d, DLQ size: %d\n", state.AckLevel, state.DLQSize)
+	if state.CorruptedRequests > 0 {
+		fmt.Fprintf(output, "%d pending requests cannot be decoded\n", state.CorruptedRequests)
+	}
+	if len(state.Domains) == 0 {
+		fmt.Fprintln(output, "No pending requests")
+		return nil
+	}
+
+	domainRows := make([]AsyncQueueDomainRow, 0, len(state.Domains))
+	var sampleRows []AsyncQueueRequestRow
+	for _, domain := range state.Domains {
+		pendingRequests := strconv.Itoa(domain.PendingRequests)
+		if state.HasMore {
+			pendingRequests += "+"
+		}
+		domainRows = append(domainRows, AsyncQueueDomainRow{
+			Domain:          domain.Domain,
+			PendingRequests: pendingRequests,
+		})
+		sampleRows = append(sampleRows, domain.Samples...)
+	}
+	if err := Render(c, domainRows, RenderOptions{Color: true, DefaultTemplate: templateTable}); err != nil {
+		return err
+	}
+	if len(sampleRows) == 0 {
+		return nil
+	}
+	return Render(c, sampleRows, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
@@ -23,16 +23,26 @@
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/.gen/go/sqlblobs"
 	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/asyncworkflow/queue/provider"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/thrift"
+	"github.com/uber/cadence/tools/cli/clitest"
 )
 
 func TestAdminGetAsyncWFConfig(t *testing.T) {
@@ -235,3 +245,141 @@ func TestAdminUpdateAsyncWFConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminInspectAsyncWFQueue(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []clitest.CliArgument
+		setupMocks     func(*cliTestData)
+		expectedError  string
+		expectedOutput []string
+	}{
+		{
+			name: "success",
+			args: []clitest.CliArgument{
+				clitest.IntArgument(FlagSampleSize, 1),
+				clitest.IntArgument(FlagMaxMessageCount, 3),
+			},
+			setupMocks: func(td *cliTestData) {
+				queueManager := persistence.NewMockQueueManager(td.ctrl)
+				queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{"async-workflow-consumer": 10}, nil)
+				queueManager.EXPECT().ReadMessages(gomock.Any(), int64(10), 4).Return(persistence.QueueMessageList{
+					{ID: 11, Payload: mustEncodeAsyncStartRequest(t, "domain-a", "wf-1", "request-1")},
+					{ID: 12, Payload: mustEncodeAsyncStartRequest(t, "domain-b", "wf-2", "request-2")},
+					{ID: 13, Payload: mustEncodeAsyncStartRequest(t, "domain-b", "wf-3", "request-3")},
+					{ID: 14, Payload: []byte("corrupted")},
+				}, nil)
+				queueManager.EXPECT().GetDLQSize(gomock.Any()).Return(int64(2), nil)
+				td.mockManagerFactory.EXPECT().initializeAsyncWorkflowQueueManager(gomock.Any()).Return(queueManager, nil)
+			},
+			expectedOutput: []string{"Ack level: 10, DLQ size: 2", "domain-b", "2+", "domain-a", "1+", "wf-2", "request-1"},
+		},
+		{
+			name: "filtered by domain",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "domain-a"),
+				clitest.IntArgument(FlagSampleSize, 1),
+				clitest.IntArgument(FlagMaxMessageCount, 10),
+			},
+			setupMocks: func(td *cliTestData) {
+				queueManager := persistence.NewMockQueueManager(td.ctrl)
+				queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(map[string]int64{}, nil)
+				queueManager.EXPECT().ReadMessages(gomock.Any(), int64(-1), 11).Return(persistence.QueueMessageList{
+					{ID: 0, Payload: mustEncodeAsyncStartRequest(t, "domain-b", "wf-2", "request-2")},
+				}, nil)
+				queueManager.EXPECT().GetDLQSize(gomock.Any()).Return(int64(0), nil)
+				td.mockManagerFactory.EXPECT().initializeAsyncWorkflowQueueManager(gomock.Any()).Return(queueManager, nil)
+			},
+			expectedOutput: []string{"No pending requests"},
+		},
+		{
+			name: "failed to initialize queue manager",
+			setupMocks: func(td *cliTestData) {
+				td.mockManagerFactory.EXPECT().initializeAsyncWorkflowQueueManager(gomock.Any()).Return(nil, errors.New("no database"))
+			},
+			expectedError: "no database",
+		},
+		{
+			name: "failed to read the queue",
+			setupMocks: func(td *cliTestData) {
+				queueManager := persistence.NewMockQueueManager(td.ctrl)
+				queueManager.EXPECT().GetAckLevels(gomock.Any()).Return(nil, errors.New("read failed"))
+				td.mockManagerFactory.EXPECT().initializeAsyncWorkflowQueueManager(gomock.Any()).Return(queueManager, nil)
+			},
+			expectedError: "Failed to inspect async wf queue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.setupMocks(td)
+			c := clitest.NewCLIContext(t, td.app, tt.args...)
+
+			err := AdminInspectAsyncWFQueue(c)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, td.consoleOutput(), expected)
+			}
+		})
+	}
+}
+
+func TestSummarizeAsyncQueueState(t *testing.T) {
+	queueState := &provider.QueueState{
+		AckLevel: 1,
+		Pending: []*provider.PendingMessage{
+			{ID: 2, Payload: mustEncodeAsyncStartRequest(t, "domain-a", "wf-1", "request-1")},
+			{ID: 3, Payload: mustEncodeAsyncStartRequest(t, "domain-b", "wf-2", "request-2")},
+			{ID: 4, Payload: mustEncodeAsyncStartRequest(t, "domain-b", "wf-3", "request-3")},
+			{ID: 5, Payload: []byte("corrupted")},
+		},
+	}
+
+	state := summarizeAsyncQueueState(queueState, "", 1)
+	assert.Equal(t, &AsyncQueueState{
+		AckLevel:          1,
+		PendingRequests:   3,
+		CorruptedRequests: 1,
+		Domains: []*AsyncQueueDomainState{
+			{
+				Domain:          "domain-b",
+				PendingRequests: 2,
+				Samples: []AsyncQueueRequestRow{
+					{Domain: "domain-b", MessageID: 3, RequestType: "StartWorkflowExecutionAsyncRequest", WorkflowID: "wf-2", RequestID: "request-2"},
+				},
+			},
+			{
+				Domain:          "domain-a",
+				PendingRequests: 1,
+				Samples: []AsyncQueueRequestRow{
+					{Domain: "domain-a", MessageID: 2, RequestType: "StartWorkflowExecutionAsyncRequest", WorkflowID: "wf-1", RequestID: "request-1"},
+				},
+			},
+		},
+	}, state)
+}
+
+func mustEncodeAsyncStartRequest(t *testing.T, domain, workflowID, requestID string) []byte {
+	encoder := codec.NewThriftRWEncoder()
+	payload, err := encoder.Encode(thrift.FromStartWorkflowExecutionAsyncRequest(&types.StartWorkflowExecutionAsyncRequest{
+		StartWorkflowExecutionRequest: &types.StartWorkflowExecutionRequest{
+			Domain:     domain,
+			WorkflowID: workflowID,
+			RequestID:  requestID,
+		},
+	}))
+	require.NoError(t, err)
+
+	msg, err := encoder.Encode(&sqlblobs.AsyncRequestMessage{
+		Type:     sqlblobs.AsyncRequestTypeStartWorkflowExecutionAsyncRequest.Ptr(),
+		Encoding: common.StringPtr(string(constants.EncodingTypeThriftRW)),
+		Payload:  payload,
+	})
+	require.NoError(t, err)
+	return msg
+}
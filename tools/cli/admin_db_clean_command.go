@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -74,7 +75,7 @@ func AdminDBClean(c *cli.Context) error {
 		}
 	}
 
-	invariants := scanType.ToInvariants(collections, logger)
+	invariants := scanType.ToInvariants(collections, logger, time.Time{})
 	if len(invariants) < 1 {
 		return commoncli.Problem(
 			fmt.Sprintf("no invariants for scantype %q and collections %q",
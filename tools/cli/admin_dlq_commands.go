@@ -64,6 +64,12 @@ type DLQRow struct {
 	// Only event IDs for compact table representation
 	EventIDs       []int64 `header:"Event IDs"`
 	NewRunEventIDs []int64 `header:"New Run Event IDs"`
+
+	// The automatic re-drive status of history DLQ messages, it is only set for messages whose re-drive failed
+	RedriveAttempts  int32  `header:"Re-drive Attempts" json:"redriveAttempts,omitempty"`
+	RedriveError     string `header:"Re-drive Error" json:"redriveError,omitempty"`
+	RedriveLastError string `json:"redriveLastError,omitempty"`
+	Quarantined      bool   `header:"Quarantined" json:"quarantined,omitempty"`
 }
 
 type HistoryDLQCountRow struct {
@@ -178,18 +184,30 @@ func AdminGetDLQMessages(c *cli.Context) error {
 		return resp.DomainInfo.Name, nil
 	}
 
+	// the re-drive status of history DLQ messages is not defined by cadence-idl, they are read as JSON
+	readDLQMessages := adminClient.ReadDLQMessages
+	includeRedriveStatus := *dlqType == types.DLQTypeReplication
+	if includeRedriveStatus {
+		adminJSONClient, err := getDeps(c).ServerAdminJSONClient(c)
+		if err != nil {
+			return err
+		}
+		readDLQMessages = adminJSONClient.ReadDLQMessages
+	}
+
 	readShard := func(shardID int) ([]DLQRow, error) {
 		var rows []DLQRow
 		var pageToken []byte
 
 		for {
-			resp, err := adminClient.ReadDLQMessages(ctx, &types.ReadDLQMessagesRequest{
+			resp, err := readDLQMessages(ctx, &types.ReadDLQMessagesRequest{
 				Type:                  dlqType,
 				SourceCluster:         sourceCluster,
 				ShardID:               int32(shardID),
 				InclusiveEndMessageID: common.Int64Ptr(lastMessageID),
 				MaximumPageSize:       defaultPageSize,
 				NextPageToken:         pageToken,
+				IncludeRedriveStatus:  includeRedriveStatus,
 			})
 			if err != nil {
 				return nil, commoncli.Problem(fmt.Sprintf("fail to read dlq message for shard: %d", shardID), err)
@@ -199,6 +217,10 @@ func AdminGetDLQMessages(c *cli.Context) error {
 			for _, task := range resp.ReplicationTasks {
 				replicationTasks[task.SourceTaskID] = task
 			}
+			redriveStatuses := map[int64]*types.ReplicationDLQRedriveStatus{}
+			for _, status := range resp.RedriveStatuses {
+				redriveStatuses[status.TaskID] = status
+			}
 
 			for _, info := range resp.ReplicationTasksInfo {
 				task := replicationTasks[info.TaskID]
@@ -220,7 +242,7 @@ func AdminGetDLQMessages(c *cli.Context) error {
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, DLQRow{
+				row := DLQRow{
 					ShardID:         shardID,
 					DomainName:      domainName,
 					DomainID:        info.DomainID,
@@ -237,7 +259,14 @@ func AdminGetDLQMessages(c *cli.Context) error {
 					EventIDs:        collectEventIDs(events),
 					NewRunEvents:    newRunEvents,
 					NewRunEventIDs:  collectEventIDs(newRunEvents),
-				})
+				}
+				if status, ok := redriveStatuses[info.TaskID]; ok {
+					row.RedriveAttempts = status.Attempts
+					row.RedriveError = status.ErrorClass
+					row.RedriveLastError = status.LastError
+					row.Quarantined = status.Quarantined
+				}
+				rows = append(rows, row)
 
 				remainingMessageCount--
 				if remainingMessageCount <= 0 {
@@ -0,0 +1,300 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/failovermanager"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const (
+	defaultCancelPlanReason = "Failover plan cancelled through admin CLI"
+	// failoverPlanTimeoutBuffer is added to the plan workflow timeout on top of the wait for the start time
+	// and the failover timeout, it leaves room for pre-flight checks and a paused plan
+	failoverPlanTimeoutBuffer = 24 * time.Hour
+)
+
+var timeNowFn = time.Now
+
+// AdminFailoverPlanCreate creates a failover plan which is executed at the given start time
+func AdminFailoverPlanCreate(c *cli.Context) error {
+	name, err := getRequiredOption(c, FlagName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	params := &startParams{
+		targetCluster:                  c.String(FlagTargetCluster),
+		sourceCluster:                  c.String(FlagSourceCluster),
+		batchFailoverSize:              c.Int(FlagFailoverBatchSize),
+		batchFailoverWaitTimeInSeconds: c.Int(FlagFailoverWaitTime),
+		failoverTimeout:                c.Int(FlagFailoverTimeout),
+		failoverWorkflowTimeout:        c.Int(FlagExecutionTimeout),
+		domains:                        c.StringSlice(FlagFailoverDomains),
+	}
+	if err := validateStartParams(params); err != nil {
+		return commoncli.Problem("Invalid input parameters", err)
+	}
+	domainQuery := c.String(FlagDomainQuery)
+	if err := failovermanager.ValidateDomainQuery(domainQuery); err != nil {
+		return commoncli.Problem("Invalid domain query", err)
+	}
+
+	now := timeNowFn()
+	startTime := now
+	if c.IsSet(FlagFailoverStartTime) {
+		startTime, err = time.Parse(time.RFC3339, c.String(FlagFailoverStartTime))
+		if err != nil {
+			return commoncli.Problem("Invalid start time, use RFC3339 format eg 2006-01-02T15:04:05Z", err)
+		}
+		if startTime.Before(now) {
+			return commoncli.Problem(fmt.Sprintf("Start time %v is in the past", startTime.Format(time.RFC3339)), nil)
+		}
+	}
+
+	var gracefulFailoverTimeoutInSeconds *int32
+	if params.failoverTimeout > 0 {
+		gracefulFailoverTimeoutInSeconds = common.Int32Ptr(int32(params.failoverTimeout))
+	}
+	failoverTimeout := time.Duration(params.failoverWorkflowTimeout) * time.Second
+	planParams := failovermanager.FailoverPlanParams{
+		Name:                             name,
+		TargetCluster:                    params.targetCluster,
+		SourceCluster:                    params.sourceCluster,
+		Domains:                          params.domains,
+		DomainQuery:                      domainQuery,
+		StartTime:                        startTime,
+		MaxReplicationLag:                time.Duration(c.Int(FlagMaxReplicationLag)) * time.Second,
+		BatchFailoverSize:                params.batchFailoverSize,
+		BatchFailoverWaitTimeInSeconds:   params.batchFailoverWaitTimeInSeconds,
+		GracefulFailoverTimeoutInSeconds: gracefulFailoverTimeoutInSeconds,
+		FailoverTimeout:                  failoverTimeout,
+	}
+	input, err := json.Marshal(planParams)
+	if err != nil {
+		return commoncli.Problem("Failed to serialize failover plan params", err)
+	}
+
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	op, err := getOperatorFn()
+	if err != nil {
+		return commoncli.Problem("Error in getting operator: ", err)
+	}
+	memo, err := getWorkflowMemo(map[string]interface{}{
+		constants.MemoKeyForOperator: op,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to serialize memo", err)
+	}
+
+	workflowTimeout := startTime.Sub(now) + failoverTimeout + failoverPlanTimeoutBuffer
+	workflowID := failovermanager.GetFailoverPlanWorkflowID(name)
+	wf, err := client.StartWorkflowExecution(tcCtx, &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.SystemLocalDomainName,
+		RequestID:                           uuidFn(),
+		WorkflowID:                          workflowID,
+		WorkflowIDReusePolicy:               types.WorkflowIDReusePolicyAllowDuplicate.Ptr(),
+		TaskList:                            &types.TaskList{Name: failovermanager.TaskListName},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(workflowTimeout.Seconds())),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(defaultDecisionTimeoutInSeconds),
+		Memo:                                memo,
+		WorkflowType:                        &types.WorkflowType{Name: failovermanager.FailoverPlanWorkflowTypeName},
+		Input:                               input,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to create failover plan", err)
+	}
+
+	output := getDeps(c).Output()
+	fmt.Fprintf(output, "Failover plan %s scheduled at %s\n", name, startTime.Format(time.RFC3339))
+	fmt.Fprintf(output, "wid: %s\n", workflowID)
+	fmt.Fprintf(output, "rid: %s\n", wf.GetRunID())
+	fmt.Fprintln(output, "Pre-flight checks run now and again at the start time, use describe to see the results")
+	return nil
+}
+
+// AdminFailoverPlanDescribe describes a failover plan with its pre-flight check results
+func AdminFailoverPlanDescribe(c *cli.Context) error {
+	name, err := getRequiredOption(c, FlagName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+
+	workflowID := failovermanager.GetFailoverPlanWorkflowID(name)
+	result, err := queryPlan(tcCtx, client, workflowID, getRunID(c))
+	if err != nil {
+		return err
+	}
+	descResp, err := client.DescribeWorkflowExecution(tcCtx, &types.DescribeWorkflowExecutionRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      getRunID(c),
+		},
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to describe failover plan workflow", err)
+	}
+	if isWorkflowTerminated(descResp) {
+		result.State = failovermanager.WorkflowAborted
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), result)
+	return nil
+}
+
+// AdminFailoverPlanList lists failover plans
+func AdminFailoverPlanList(c *cli.Context) error {
+	if err := c.Set(FlagWorkflowType, failovermanager.FailoverPlanWorkflowTypeName); err != nil {
+		return err
+	}
+	if err := c.Set(FlagDomain, constants.SystemLocalDomainName); err != nil {
+		return err
+	}
+	return ListWorkflow(c)
+}
+
+// AdminFailoverPlanPause pauses a failover plan, a plan paused before its start time waits for resume
+func AdminFailoverPlanPause(c *cli.Context) error {
+	name, err := getRequiredOption(c, FlagName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	if err := signalPlan(c, name, failovermanager.PauseSignal, nil); err != nil {
+		return commoncli.Problem("Failed to pause failover plan", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Failover plan paused: %s\n", name)
+	return nil
+}
+
+// AdminFailoverPlanResume resumes a paused failover plan
+func AdminFailoverPlanResume(c *cli.Context) error {
+	name, err := getRequiredOption(c, FlagName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	if err := signalPlan(c, name, failovermanager.ResumeSignal, nil); err != nil {
+		return commoncli.Problem("Failed to resume failover plan", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Failover plan resumed: %s\n", name)
+	return nil
+}
+
+// AdminFailoverPlanCancel cancels a failover plan. Domains already failed over by the plan are not rolled back.
+func AdminFailoverPlanCancel(c *cli.Context) error {
+	name, err := getRequiredOption(c, FlagName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	reason := c.String(FlagReason)
+	if len(reason) == 0 {
+		reason = defaultCancelPlanReason
+	}
+	input, err := json.Marshal(reason)
+	if err != nil {
+		return commoncli.Problem("Failed to serialize reason", err)
+	}
+	if err := signalPlan(c, name, failovermanager.AbortSignal, input); err != nil {
+		return commoncli.Problem("Failed to cancel failover plan", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Failover plan cancelled: %s\n", name)
+	return nil
+}
+
+func signalPlan(c *cli.Context, name string, signalName string, input []byte) error {
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	return client.SignalWorkflowExecution(tcCtx, &types.SignalWorkflowExecutionRequest{
+		Domain: constants.SystemLocalDomainName,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: failovermanager.GetFailoverPlanWorkflowID(name),
+			RunID:      getRunID(c),
+		},
+		SignalName: signalName,
+		Input:      input,
+		Identity:   getCliIdentity(),
+	})
+}
+
+func queryPlan(
+	tcCtx context.Context,
+	client frontend.Client,
+	workflowID string,
+	runID string,
+) (*failovermanager.PlanQueryResult, error) {
+
+	queryResp, err := client.QueryWorkflow(tcCtx, &types.QueryWorkflowRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: failovermanager.QueryType,
+		},
+	})
+	if err != nil {
+		return nil, commoncli.Problem("Failed to query failover plan workflow", err)
+	}
+	if queryResp.GetQueryResult() == nil {
+		return nil, commoncli.Problem("QueryResult has no value", nil)
+	}
+	var result failovermanager.PlanQueryResult
+	if err := json.Unmarshal(queryResp.GetQueryResult(), &result); err != nil {
+		return nil, commoncli.Problem("Unable to deserialize QueryResult", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,250 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/failovermanager"
+)
+
+func TestAdminFailoverPlanCreate(t *testing.T) {
+	now := time.Date(2026, 1, 10, 2, 0, 0, 0, time.UTC)
+	oldUUIDFn, oldGetOperatorFn, oldTimeNowFn := uuidFn, getOperatorFn, timeNowFn
+	uuidFn = func() string { return "test-uuid" }
+	getOperatorFn = func() (string, error) { return "test-user", nil }
+	timeNowFn = func() time.Time { return now }
+	defer func() {
+		uuidFn, getOperatorFn, timeNowFn = oldUUIDFn, oldGetOperatorFn, oldTimeNowFn
+	}()
+
+	tests := []struct {
+		desc    string
+		args    []string
+		mockFn  func(*testing.T, *frontend.MockClient)
+		wantErr bool
+	}{
+		{
+			desc: "success",
+			args: []string{
+				"--name", "dr-drill",
+				"--sc", "cluster1",
+				"--tc", "cluster2",
+				"--start_time", "2026-01-10T03:00:00Z",
+				"--domain_query", "name=payments-*",
+				"--max_lag", "30",
+			},
+			mockFn: func(t *testing.T, m *frontend.MockClient) {
+				input, err := json.Marshal(failovermanager.FailoverPlanParams{
+					Name:                           "dr-drill",
+					TargetCluster:                  "cluster2",
+					SourceCluster:                  "cluster1",
+					DomainQuery:                    "name=payments-*",
+					StartTime:                      now.Add(time.Hour),
+					MaxReplicationLag:              30 * time.Second,
+					BatchFailoverSize:              defaultBatchFailoverSize,
+					BatchFailoverWaitTimeInSeconds: defaultBatchFailoverWaitTimeInSeconds,
+					FailoverTimeout:                defaultFailoverWorkflowTimeoutInSeconds * time.Second,
+				})
+				if err != nil {
+					t.Fatalf("failed to marshal plan params: %v", err)
+				}
+				workflowTimeout := time.Hour + defaultFailoverWorkflowTimeoutInSeconds*time.Second + failoverPlanTimeoutBuffer
+				wantReq := &types.StartWorkflowExecutionRequest{
+					Domain:                              constants.SystemLocalDomainName,
+					RequestID:                           "test-uuid",
+					WorkflowID:                          failovermanager.GetFailoverPlanWorkflowID("dr-drill"),
+					WorkflowIDReusePolicy:               types.WorkflowIDReusePolicyAllowDuplicate.Ptr(),
+					TaskList:                            &types.TaskList{Name: failovermanager.TaskListName},
+					Input:                               input,
+					ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(workflowTimeout.Seconds())),
+					TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(defaultDecisionTimeoutInSeconds),
+					Memo: mustGetWorkflowMemo(t, map[string]interface{}{
+						constants.MemoKeyForOperator: "test-user",
+					}),
+					WorkflowType: &types.WorkflowType{Name: failovermanager.FailoverPlanWorkflowTypeName},
+				}
+				m.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, gotReq *types.StartWorkflowExecutionRequest, opts ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+						if diff := cmp.Diff(wantReq, gotReq); diff != "" {
+							t.Fatalf("Request mismatch (-want +got):\n%s", diff)
+						}
+						return &types.StartWorkflowExecutionResponse{RunID: "run-id"}, nil
+					}).Times(1)
+			},
+		},
+		{
+			desc:    "start time in the past",
+			args:    []string{"--name", "dr-drill", "--sc", "cluster1", "--tc", "cluster2", "--start_time", "2026-01-10T01:00:00Z"},
+			mockFn:  func(t *testing.T, m *frontend.MockClient) {},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid domain query",
+			args:    []string{"--name", "dr-drill", "--sc", "cluster1", "--tc", "cluster2", "--domain_query", "tier=1"},
+			mockFn:  func(t *testing.T, m *frontend.MockClient) {},
+			wantErr: true,
+		},
+		{
+			desc:    "source and target cluster same",
+			args:    []string{"--name", "dr-drill", "--sc", "cluster1", "--tc", "cluster1"},
+			mockFn:  func(t *testing.T, m *frontend.MockClient) {},
+			wantErr: true,
+		},
+		{
+			desc: "start workflow fails",
+			args: []string{"--name", "dr-drill", "--sc", "cluster1", "--tc", "cluster2"},
+			mockFn: func(t *testing.T, m *frontend.MockClient) {
+				m.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("failed to start workflow")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			frontendCl := frontend.NewMockClient(ctrl)
+			tc.mockFn(t, frontendCl)
+			app := NewCliApp(&clientFactoryMock{
+				serverFrontendClient: frontendCl,
+			})
+
+			args := append([]string{"", "admin", "cluster", "failover", "plan", "create"}, tc.args...)
+			err := app.Run(args)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Got error: %v, wantErr?: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminFailoverPlanDescribe(t *testing.T) {
+	workflowID := failovermanager.GetFailoverPlanWorkflowID("dr-drill")
+	queryResult, err := json.Marshal(failovermanager.PlanQueryResult{
+		Name:  "dr-drill",
+		State: failovermanager.PlanScheduled,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal query result: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	frontendCl := frontend.NewMockClient(ctrl)
+	frontendCl.EXPECT().QueryWorkflow(gomock.Any(), &types.QueryWorkflowRequest{
+		Domain:    constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{WorkflowID: workflowID},
+		Query:     &types.WorkflowQuery{QueryType: failovermanager.QueryType},
+	}).Return(&types.QueryWorkflowResponse{QueryResult: queryResult}, nil).Times(1)
+	frontendCl.EXPECT().DescribeWorkflowExecution(gomock.Any(), &types.DescribeWorkflowExecutionRequest{
+		Domain:    constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{WorkflowID: workflowID},
+	}).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{},
+	}, nil).Times(1)
+	app := NewCliApp(&clientFactoryMock{
+		serverFrontendClient: frontendCl,
+	})
+
+	err = app.Run([]string{"", "admin", "cluster", "failover", "plan", "describe", "--name", "dr-drill"})
+	if err != nil {
+		t.Errorf("Got error: %v", err)
+	}
+}
+
+func TestAdminFailoverPlanSignals(t *testing.T) {
+	tests := []struct {
+		desc       string
+		command    []string
+		signalName string
+		input      []byte
+	}{
+		{
+			desc:       "pause",
+			command:    []string{"pause"},
+			signalName: failovermanager.PauseSignal,
+		},
+		{
+			desc:       "resume",
+			command:    []string{"resume"},
+			signalName: failovermanager.ResumeSignal,
+		},
+		{
+			desc:       "cancel",
+			command:    []string{"cancel"},
+			signalName: failovermanager.AbortSignal,
+			input:      []byte(`"Failover plan cancelled through admin CLI"`),
+		},
+		{
+			desc:       "cancel with reason",
+			command:    []string{"cancel", "--reason", "drill postponed"},
+			signalName: failovermanager.AbortSignal,
+			input:      []byte(`"drill postponed"`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			frontendCl := frontend.NewMockClient(ctrl)
+			frontendCl.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, gotReq *types.SignalWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+					wantReq := &types.SignalWorkflowExecutionRequest{
+						Domain: constants.SystemLocalDomainName,
+						WorkflowExecution: &types.WorkflowExecution{
+							WorkflowID: failovermanager.GetFailoverPlanWorkflowID("dr-drill"),
+						},
+						SignalName: tc.signalName,
+						Input:      tc.input,
+						Identity:   getCliIdentity(),
+					}
+					if diff := cmp.Diff(wantReq, gotReq); diff != "" {
+						t.Fatalf("Request mismatch (-want +got):\n%s", diff)
+					}
+					return nil
+				}).Times(1)
+			app := NewCliApp(&clientFactoryMock{
+				serverFrontendClient: frontendCl,
+			})
+
+			args := append([]string{"", "admin", "cluster", "failover", "plan"}, tc.command...)
+			args = append(args, "--name", "dr-drill")
+			if err := app.Run(args); err != nil {
+				t.Errorf("Got error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/ndc"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const historyExportPageSize = int32(100)
+
+// AdminExportWorkflow writes the history of a workflow run to a portable file,
+// which the ImportWorkflowExecution admin API loads into another domain or cluster
+func AdminExportWorkflow(c *cli.Context) error {
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	wid, err := getRequiredOption(c, FlagWorkflowID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	rid, err := getRequiredOption(c, FlagRunID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	encoding := constants.EncodingType(c.String(FlagEncodingType))
+	if encoding != constants.EncodingTypeThriftRW && encoding != constants.EncodingTypeProto {
+		return commoncli.Problem(fmt.Sprintf("Invalid encoding type %q, expected %q or %q", encoding, constants.EncodingTypeThriftRW, constants.EncodingTypeProto), nil)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+
+	export := &ndc.HistoryExport{
+		FormatVersion: ndc.HistoryExportFormatVersion,
+		Domain:        domain,
+		WorkflowID:    wid,
+		RunID:         rid,
+		EventEncoding: encoding,
+	}
+	var token []byte
+	for {
+		resp, err := adminClient.GetWorkflowExecutionRawHistoryV2(ctx, &types.GetWorkflowExecutionRawHistoryV2Request{
+			Domain: domain,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: wid,
+				RunID:      rid,
+			},
+			MaximumPageSize: historyExportPageSize,
+			NextPageToken:   token,
+		})
+		if err != nil {
+			return commoncli.Problem("Failed to read workflow history", err)
+		}
+		export.VersionHistory = resp.VersionHistory
+		for _, blob := range resp.HistoryBatches {
+			if blob.GetEncodingType() != types.EncodingTypeThriftRW {
+				return commoncli.Problem(fmt.Sprintf("Unsupported history encoding %v", blob.GetEncodingType()), nil)
+			}
+			events, err := ndc.DecodeHistoryBatch(blob.Data, constants.EncodingTypeThriftRW)
+			if err != nil {
+				return commoncli.Problem("Failed to decode history batch", err)
+			}
+			data, err := ndc.EncodeHistoryBatch(events, encoding)
+			if err != nil {
+				return commoncli.Problem("Failed to encode history batch", err)
+			}
+			export.Batches = append(export.Batches, data)
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+
+	output := getDeps(c).Output()
+	if c.IsSet(FlagOutputFilename) {
+		f, err := os.Create(c.String(FlagOutputFilename))
+		if err != nil {
+			return commoncli.Problem("Failed to create output file", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	if err := ndc.WriteHistoryExport(output, export); err != nil {
+		return commoncli.Problem("Failed to write history export", err)
+	}
+	return nil
+}
+
+// AdminImportWorkflow recreates a workflow run from a file written by AdminExportWorkflow in the given domain
+func AdminImportWorkflow(c *cli.Context) error {
+	adminClient, err := getDeps(c).ServerAdminJSONClient(c)
+	if err != nil {
+		return err
+	}
+
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	inputFile, err := getRequiredOption(c, FlagInputFile)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	// This code is executed from the CLI. All user input is from a CLI user.
+	// #nosec
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return commoncli.Problem("Failed to read history export", err)
+	}
+	// validate locally before sending the whole file
+	if _, err := ndc.ReadHistoryExport(bytes.NewReader(data)); err != nil {
+		return commoncli.Problem("Invalid history export", err)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	resp, err := adminClient.ImportWorkflowExecution(ctx, &types.ImportWorkflowExecutionRequest{
+		Domain:        domain,
+		HistoryExport: data,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to import workflow history", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Imported workflow %v, run %v.\n", resp.GetWorkflowExecution().GetWorkflowID(), resp.GetWorkflowExecution().GetRunID())
+	return nil
+}
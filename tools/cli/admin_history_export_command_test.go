@@ -0,0 +1,250 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/ndc"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminExportWorkflow(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{ID: 1, Version: 10, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+		{ID: 2, Version: 10, EventType: types.EventTypeDecisionTaskScheduled.Ptr(), DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{}},
+	}
+	blob, err := persistence.NewPayloadSerializer().SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	versionHistory := &types.VersionHistory{
+		Items: []*types.VersionHistoryItem{{EventID: 2, Version: 10}},
+	}
+
+	tests := []struct {
+		name        string
+		testSetup   func(td *cliTestData) *cli.Context
+		errContains string // empty if no error is expected
+	}{
+		{
+			name: "missing runID argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagWorkflowID, testWorkflowID),
+				)
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "invalid encoding",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagWorkflowID, testWorkflowID),
+					clitest.StringArgument(FlagRunID, testRunID),
+					clitest.StringArgument(FlagEncodingType, "gob"),
+				)
+			},
+			errContains: "Invalid encoding type",
+		},
+		{
+			name: "GetWorkflowExecutionRawHistoryV2 returns an error",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("critical error"))
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagWorkflowID, testWorkflowID),
+					clitest.StringArgument(FlagRunID, testRunID),
+					clitest.StringArgument(FlagEncodingType, "thriftrw"),
+				)
+			},
+			errContains: "Failed to read workflow history",
+		},
+		{
+			name: "success",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), &types.GetWorkflowExecutionRawHistoryV2Request{
+					Domain: testDomain,
+					Execution: &types.WorkflowExecution{
+						WorkflowID: testWorkflowID,
+						RunID:      testRunID,
+					},
+					MaximumPageSize: historyExportPageSize,
+				}).Return(&types.GetWorkflowExecutionRawHistoryV2Response{
+					HistoryBatches: []*types.DataBlob{blob.ToInternal()},
+					VersionHistory: versionHistory,
+				}, nil)
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagWorkflowID, testWorkflowID),
+					clitest.StringArgument(FlagRunID, testRunID),
+					clitest.StringArgument(FlagEncodingType, "proto3"),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			cliCtx := tt.testSetup(td)
+
+			err := AdminExportWorkflow(cliCtx)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			export, err := ndc.ReadHistoryExport(bytes.NewBufferString(td.consoleOutput()))
+			require.NoError(t, err)
+			assert.Equal(t, testDomain, export.Domain)
+			assert.Equal(t, testRunID, export.RunID)
+			assert.Equal(t, constants.EncodingTypeProto, export.EventEncoding)
+			assert.Equal(t, versionHistory, export.VersionHistory)
+			require.Len(t, export.Batches, 1)
+			exported, err := ndc.DecodeHistoryBatch(export.Batches[0], export.EventEncoding)
+			require.NoError(t, err)
+			assert.Len(t, exported, len(events))
+		})
+	}
+}
+
+func TestAdminImportWorkflow(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{ID: 1, Version: 10, EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{}},
+	}
+	data, err := ndc.EncodeHistoryBatch(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	var export bytes.Buffer
+	require.NoError(t, ndc.WriteHistoryExport(&export, &ndc.HistoryExport{
+		FormatVersion: ndc.HistoryExportFormatVersion,
+		Domain:        "source-domain",
+		WorkflowID:    testWorkflowID,
+		RunID:         testRunID,
+		EventEncoding: constants.EncodingTypeThriftRW,
+		Batches:       [][]byte{data},
+	}))
+	exportFile := filepath.Join(t.TempDir(), "export.json")
+	require.NoError(t, os.WriteFile(exportFile, export.Bytes(), 0600))
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`{"formatVersion":0}`), 0600))
+
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData) *cli.Context
+		errContains    string // empty if no error is expected
+		expectedOutput string
+	}{
+		{
+			name: "missing input file argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDomain, testDomain))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "invalid export",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagInputFile, invalidFile),
+				)
+			},
+			errContains: "Invalid history export",
+		},
+		{
+			name: "ImportWorkflowExecution returns an error",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminJSONClient.EXPECT().ImportWorkflowExecution(gomock.Any(), gomock.Any()).
+					Return(nil, &types.BadRequestError{Message: "History can only be imported into a global domain."})
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagInputFile, exportFile),
+				)
+			},
+			errContains: "Failed to import workflow history",
+		},
+		{
+			name: "success",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminJSONClient.EXPECT().ImportWorkflowExecution(gomock.Any(), &types.ImportWorkflowExecutionRequest{
+					Domain:        testDomain,
+					HistoryExport: export.Bytes(),
+				}).Return(&types.ImportWorkflowExecutionResponse{
+					WorkflowExecution: &types.WorkflowExecution{
+						WorkflowID: testWorkflowID,
+						RunID:      testRunID,
+					},
+				}, nil)
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagInputFile, exportFile),
+				)
+			},
+			expectedOutput: "Imported workflow test-workflow-id, run test-run-id.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			cliCtx := tt.testSetup(td)
+
+			err := AdminImportWorkflow(cliCtx)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
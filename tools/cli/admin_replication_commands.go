@@ -0,0 +1,178 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const defaultMaxReplicationLagInSeconds = 60
+
+// ReplicationShardStatusRow is a row of the shard replication status table
+type ReplicationShardStatusRow struct {
+	RemoteCluster        string `header:"Remote Cluster"`
+	ShardID              int32  `header:"Shard ID"`
+	LastReplicatedTaskID int64  `header:"Last Replicated Task ID"`
+	PendingTasks         string `header:"Pending Tasks"`
+	Lag                  string `header:"Lag"`
+	TasksPerSecond       string `header:"Tasks/s"`
+	EstimatedCatchUp     string `header:"Estimated Catch Up"`
+	DLQSize              string `header:"DLQ Size"`
+}
+
+// ReplicationDomainStatusRow is a row of the domain replication status table
+type ReplicationDomainStatusRow struct {
+	Domain           string `header:"Domain"`
+	RemoteCluster    string `header:"Remote Cluster"`
+	PendingTasks     int64  `header:"Pending Tasks"`
+	Lag              string `header:"Lag"`
+	EstimatedCatchUp string `header:"Estimated Catch Up"`
+}
+
+// AdminReplicationStatus shows the replication lag to a remote cluster and whether it is safe to fail over to it
+func AdminReplicationStatus(c *cli.Context) error {
+	adminClient, err := getDeps(c).ServerAdminJSONClient(c)
+	if err != nil {
+		return err
+	}
+	remoteCluster, err := getRequiredOption(c, FlagCluster)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+
+	request := &types.GetReplicationStatusRequest{
+		Domain:        c.String(FlagDomain),
+		RemoteCluster: remoteCluster,
+	}
+	if c.IsSet(FlagShardID) {
+		request.ShardIDs = []int32{int32(c.Int(FlagShardID))}
+	}
+	status, err := adminClient.GetReplicationStatus(ctx, request)
+	if err != nil {
+		return commoncli.Problem("Failed to get replication status", err)
+	}
+
+	if c.String(FlagFormat) == formatJSON {
+		if err := Render(c, status, RenderOptions{}); err != nil {
+			return err
+		}
+	} else if err := renderReplicationStatus(c, status); err != nil {
+		return err
+	}
+
+	maxLag := time.Duration(c.Int(FlagMaxReplicationLag)) * time.Second
+	if reasons := failoverBlockers(status, c.String(FlagDomain), maxLag); len(reasons) > 0 {
+		return commoncli.Problem(fmt.Sprintf("Failover to %v: NO-GO", remoteCluster), fmt.Errorf("%v", strings.Join(reasons, "; ")))
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Failover to %v: GO\n", remoteCluster)
+	return nil
+}
+
+func renderReplicationStatus(c *cli.Context, status *types.GetReplicationStatusResponse) error {
+	shardRows := make([]ReplicationShardStatusRow, 0, len(status.Shards))
+	for _, shard := range status.Shards {
+		pendingTasks := strconv.FormatInt(shard.PendingTasks, 10)
+		if shard.PendingTasksTruncated {
+			pendingTasks += "+"
+		}
+		dlqSize := "unknown"
+		if shard.DLQSize != nil {
+			dlqSize = strconv.FormatInt(*shard.DLQSize, 10)
+		}
+		shardRows = append(shardRows, ReplicationShardStatusRow{
+			RemoteCluster:        shard.RemoteCluster,
+			ShardID:              shard.ShardID,
+			LastReplicatedTaskID: shard.LastReplicatedTaskID,
+			PendingTasks:         pendingTasks,
+			Lag:                  formatMilliseconds(shard.LagInMilliseconds),
+			TasksPerSecond:       strconv.FormatFloat(shard.TasksPerSecond, 'f', 1, 64),
+			EstimatedCatchUp:     formatCatchUp(shard.EstimatedCatchUpInMilliseconds),
+			DLQSize:              dlqSize,
+		})
+	}
+	if err := Render(c, shardRows, RenderOptions{Color: true, DefaultTemplate: templateTable}); err != nil {
+		return err
+	}
+
+	if len(status.Domains) == 0 {
+		return nil
+	}
+	domainRows := make([]ReplicationDomainStatusRow, 0, len(status.Domains))
+	for _, domain := range status.Domains {
+		domainRows = append(domainRows, ReplicationDomainStatusRow{
+			Domain:           domain.Domain,
+			RemoteCluster:    domain.RemoteCluster,
+			PendingTasks:     domain.PendingTasks,
+			Lag:              formatMilliseconds(domain.LagInMilliseconds),
+			EstimatedCatchUp: formatCatchUp(domain.EstimatedCatchUpInMilliseconds),
+		})
+	}
+	return Render(c, domainRows, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
+
+// failoverBlockers returns the reasons why failing over is not safe yet,
+// the lag of the domain is checked when a domain is given, otherwise the lag of every shard
+func failoverBlockers(status *types.GetReplicationStatusResponse, domain string, maxLag time.Duration) []string {
+	var reasons []string
+	for _, shard := range status.Shards {
+		if shard.GetDLQSize() > 0 {
+			reasons = append(reasons, fmt.Sprintf("shard %v has %v replication tasks in the DLQ of %v", shard.ShardID, shard.GetDLQSize(), shard.RemoteCluster))
+		}
+		if domain == "" && time.Duration(shard.LagInMilliseconds)*time.Millisecond > maxLag {
+			reasons = append(reasons, fmt.Sprintf("shard %v lags %v behind", shard.ShardID, formatMilliseconds(shard.LagInMilliseconds)))
+		}
+	}
+	if domain != "" {
+		for _, status := range status.Domains {
+			if time.Duration(status.LagInMilliseconds)*time.Millisecond > maxLag {
+				reasons = append(reasons, fmt.Sprintf("domain %v lags %v behind", status.Domain, formatMilliseconds(status.LagInMilliseconds)))
+			}
+		}
+	}
+	return reasons
+}
+
+func formatMilliseconds(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+func formatCatchUp(ms *int64) string {
+	if ms == nil {
+		return "unknown"
+	}
+	return formatMilliseconds(*ms)
+}
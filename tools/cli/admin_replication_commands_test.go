@@ -0,0 +1,171 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminReplicationStatus(t *testing.T) {
+	const remoteCluster = "cluster-b"
+	shardStatus := func(shardID int32, lagInMilliseconds int64) *types.ReplicationShardStatus {
+		return &types.ReplicationShardStatus{
+			ShardID:                        shardID,
+			RemoteCluster:                  remoteCluster,
+			PendingTasks:                   1,
+			LagInMilliseconds:              lagInMilliseconds,
+			TasksPerSecond:                 2,
+			EstimatedCatchUpInMilliseconds: common.Int64Ptr(500),
+		}
+	}
+	domainStatus := func(lagInMilliseconds int64) *types.ReplicationDomainStatus {
+		return &types.ReplicationDomainStatus{
+			Domain:                         testDomain,
+			RemoteCluster:                  remoteCluster,
+			PendingTasks:                   1,
+			LagInMilliseconds:              lagInMilliseconds,
+			EstimatedCatchUpInMilliseconds: common.Int64Ptr(500),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData) *cli.Context
+		errContains    string // empty if no error is expected
+		outputContains []string
+	}{
+		{
+			name: "no cluster argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app)
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "GetReplicationStatus returns an error",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminJSONClient.EXPECT().GetReplicationStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("critical error"))
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagCluster, remoteCluster),
+					clitest.IntArgument(FlagShardID, 1),
+				)
+			},
+			errContains: "Failed to get replication status",
+		},
+		{
+			name: "replication caught up",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminJSONClient.EXPECT().GetReplicationStatus(gomock.Any(), &types.GetReplicationStatusRequest{
+					RemoteCluster: remoteCluster,
+				}).Return(&types.GetReplicationStatusResponse{
+					Shards:  []*types.ReplicationShardStatus{shardStatus(0, 1000), shardStatus(1, 2000)},
+					Domains: []*types.ReplicationDomainStatus{domainStatus(2000)},
+				}, nil)
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagCluster, remoteCluster),
+					clitest.IntArgument(FlagMaxReplicationLag, 60),
+				)
+			},
+			outputContains: []string{testDomain, "500ms", "unknown", "Failover to cluster-b: GO"},
+		},
+		{
+			name: "domain lags behind",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockAdminJSONClient.EXPECT().GetReplicationStatus(gomock.Any(), &types.GetReplicationStatusRequest{
+					Domain:        testDomain,
+					RemoteCluster: remoteCluster,
+					ShardIDs:      []int32{1},
+				}).Return(&types.GetReplicationStatusResponse{
+					Shards:  []*types.ReplicationShardStatus{shardStatus(1, 120000)},
+					Domains: []*types.ReplicationDomainStatus{domainStatus(120000)},
+				}, nil)
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagCluster, remoteCluster),
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.IntArgument(FlagShardID, 1),
+					clitest.IntArgument(FlagMaxReplicationLag, 60),
+				)
+			},
+			errContains:    "NO-GO",
+			outputContains: []string{"2m0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			cliCtx := tt.testSetup(td)
+
+			err := AdminReplicationStatus(cliCtx)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tt.outputContains {
+				assert.Contains(t, td.consoleOutput(), expected)
+			}
+		})
+	}
+}
+
+func TestFailoverBlockers(t *testing.T) {
+	status := &types.GetReplicationStatusResponse{
+		Shards: []*types.ReplicationShardStatus{
+			{ShardID: 0, RemoteCluster: "cluster-b", LagInMilliseconds: 90000, DLQSize: common.Int64Ptr(0)},
+			{ShardID: 1, RemoteCluster: "cluster-b", LagInMilliseconds: 1000, DLQSize: common.Int64Ptr(2)},
+		},
+		Domains: []*types.ReplicationDomainStatus{
+			{Domain: "domain-a", RemoteCluster: "cluster-b", LagInMilliseconds: 1000},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"shard 0 lags 1m30s behind",
+		"shard 1 has 2 replication tasks in the DLQ of cluster-b",
+	}, failoverBlockers(status, "", time.Minute))
+	assert.Equal(t, []string{
+		"shard 1 has 2 replication tasks in the DLQ of cluster-b",
+	}, failoverBlockers(status, "domain-a", time.Minute))
+	assert.Equal(t, []string{
+		"shard 1 has 2 replication tasks in the DLQ of cluster-b",
+		"domain domain-a lags 1s behind",
+	}, failoverBlockers(status, "domain-a", 0))
+}
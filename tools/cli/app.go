@@ -209,6 +209,12 @@ func NewCliApp(cf ClientFactory, opts ...CLIAppOptions) *cli.App {
 					Usage:       "Run admin operation on DLQ",
 					Subcommands: newAdminDLQCommands(),
 				},
+				{
+					Name:        "replication",
+					Aliases:     []string{"rep"},
+					Usage:       "Run admin operation on replication",
+					Subcommands: newAdminReplicationCommands(),
+				},
 				{
 					Name:        "database",
 					Aliases:     []string{"db"},
@@ -241,6 +247,12 @@ func NewCliApp(cf ClientFactory, opts ...CLIAppOptions) *cli.App {
 			Usage:       "Operate cadence cluster",
 			Subcommands: newClusterCommands(),
 		},
+		{
+			Name:   "tui",
+			Usage:  "Interactive terminal UI to investigate and operate workflows of a domain",
+			Flags:  getFlagsForTUI(),
+			Action: StartTUI,
+		},
 	}
 	app.CommandNotFound = func(context *cli.Context, command string) {
 		output := getDeps(context).Output()
@@ -41,6 +41,7 @@ import (
 
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
+	jsonClient "github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/types"
@@ -67,9 +68,11 @@ type (
 var _ ClientFactory = (*clientFactoryMock)(nil)
 
 type clientFactoryMock struct {
-	serverFrontendClient frontend.Client
-	serverAdminClient    admin.Client
-	config               *config.Config
+	serverFrontendClient     frontend.Client
+	serverAdminClient        admin.Client
+	serverAdminJSONClient    jsonClient.AdminClient
+	serverFrontendJSONClient jsonClient.APIClient
+	config                   *config.Config
 }
 
 func (m *clientFactoryMock) ServerFrontendClient(c *cli.Context) (frontend.Client, error) {
@@ -80,6 +83,14 @@ func (m *clientFactoryMock) ServerAdminClient(c *cli.Context) (admin.Client, err
 	return m.serverAdminClient, nil
 }
 
+func (m *clientFactoryMock) ServerAdminJSONClient(c *cli.Context) (jsonClient.AdminClient, error) {
+	return m.serverAdminJSONClient, nil
+}
+
+func (m *clientFactoryMock) ServerFrontendJSONClient(c *cli.Context) (jsonClient.APIClient, error) {
+	return m.serverFrontendJSONClient, nil
+}
+
 func (m *clientFactoryMock) ServerFrontendClientForMigration(c *cli.Context) (frontend.Client, error) {
 	panic("not implemented")
 }
@@ -103,6 +114,7 @@ var commands = []string{
 	"domain", "d",
 	"workflow", "wf",
 	"tasklist", "tl",
+	"tui",
 }
 
 var domainName = "cli-test-domain"
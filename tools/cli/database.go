@@ -158,6 +158,7 @@ type ManagerFactory interface {
 	initializeHistoryManager(c *cli.Context) (persistence.HistoryManager, error)
 	initializeShardManager(c *cli.Context) (persistence.ShardManager, error)
 	initializeDomainManager(c *cli.Context) (persistence.DomainManager, error)
+	initializeAsyncWorkflowQueueManager(c *cli.Context) (persistence.QueueManager, error)
 	initPersistenceFactory(c *cli.Context) (client.Factory, error)
 	initializeInvariantManager(ivs []invariant.Invariant) (invariant.Manager, error)
 }
@@ -214,6 +215,18 @@ func (f *defaultManagerFactory) initializeDomainManager(c *cli.Context) (persist
 	return domainManager, nil
 }
 
+func (f *defaultManagerFactory) initializeAsyncWorkflowQueueManager(c *cli.Context) (persistence.QueueManager, error) {
+	factory, err := f.getPersistenceFactory(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get persistence factory: %w", err)
+	}
+	queueManager, err := factory.NewAsyncWorkflowQueueManager()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize async workflow queue manager: %w", err)
+	}
+	return queueManager, nil
+}
+
 func (f *defaultManagerFactory) getPersistenceFactory(c *cli.Context) (client.Factory, error) {
 	var err error
 	if f.persistenceFactory == nil {
@@ -98,6 +98,17 @@ var resetTypesMap = map[string]string{
 	resetTypeLastDecisionScheduled:  "",
 }
 
+// serverResetTypes are the reset types to resolve on the server, see FlagServerResetPoint
+var serverResetTypes = map[string]types.ResetType{
+	resetTypeFirstDecisionCompleted: types.ResetTypeFirstDecisionCompleted,
+	resetTypeLastDecisionCompleted:  types.ResetTypeLastDecisionCompleted,
+	resetTypeLastContinuedAsNew:     types.ResetTypeLastContinuedAsNew,
+	resetTypeBadBinary:              types.ResetTypeBadBinary,
+	resetTypeDecisionCompletedTime:  types.ResetTypeDecisionCompletedTime,
+	resetTypeFirstDecisionScheduled: types.ResetTypeFirstDecisionScheduled,
+	resetTypeLastDecisionScheduled:  types.ResetTypeLastDecisionScheduled,
+}
+
 type jsonType int
 
 const (
@@ -48,6 +48,7 @@ import (
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
 	grpcClient "github.com/uber/cadence/client/wrappers/grpc"
+	jsonClient "github.com/uber/cadence/client/wrappers/json"
 	"github.com/uber/cadence/client/wrappers/thrift"
 	"github.com/uber/cadence/common"
 	cc "github.com/uber/cadence/common/client"
@@ -72,6 +73,10 @@ const (
 type ClientFactory interface {
 	ServerFrontendClient(c *cli.Context) (frontend.Client, error)
 	ServerAdminClient(c *cli.Context) (admin.Client, error)
+	// ServerAdminJSONClient admin client of the APIs not defined in cadence-idl yet
+	ServerAdminJSONClient(c *cli.Context) (jsonClient.AdminClient, error)
+	// ServerFrontendJSONClient frontend client of the APIs not defined in cadence-idl yet
+	ServerFrontendJSONClient(c *cli.Context) (jsonClient.APIClient, error)
 
 	// ServerFrontendClientForMigration frontend client of the migration destination
 	ServerFrontendClientForMigration(c *cli.Context) (frontend.Client, error)
@@ -148,6 +153,24 @@ func (b *clientFactory) ServerAdminClient(c *cli.Context) (admin.Client, error)
 	return thrift.NewAdminClient(serverAdmin.New(clientConfig)), nil
 }
 
+// ServerAdminJSONClient builds an admin client of the APIs served as JSON, over the selected transport
+func (b *clientFactory) ServerAdminJSONClient(c *cli.Context) (jsonClient.AdminClient, error) {
+	err := b.ensureDispatcher(c)
+	if err != nil {
+		return nil, commoncli.Problem("failed to create admin client dependency", err)
+	}
+	return jsonClient.NewAdminClient(b.dispatcher.ClientConfig(cadenceFrontendService)), nil
+}
+
+// ServerFrontendJSONClient builds a frontend client of the APIs served as JSON, over the selected transport
+func (b *clientFactory) ServerFrontendJSONClient(c *cli.Context) (jsonClient.APIClient, error) {
+	err := b.ensureDispatcher(c)
+	if err != nil {
+		return nil, commoncli.Problem("failed to create frontend client dependency", err)
+	}
+	return jsonClient.NewAPIClient(b.dispatcher.ClientConfig(cadenceFrontendService)), nil
+}
+
 // ServerFrontendClientForMigration builds a frontend client (based on server side thrift interface)
 func (b *clientFactory) ServerFrontendClientForMigration(c *cli.Context) (frontend.Client, error) {
 	err := b.ensureDispatcherForMigration(c)
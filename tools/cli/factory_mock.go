@@ -18,6 +18,7 @@ import (
 
 	admin "github.com/uber/cadence/client/admin"
 	frontend "github.com/uber/cadence/client/frontend"
+	json "github.com/uber/cadence/client/wrappers/json"
 	config "github.com/uber/cadence/common/config"
 )
 
@@ -75,6 +76,36 @@ func (mr *MockClientFactoryMockRecorder) ServerAdminClient(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerAdminClient", reflect.TypeOf((*MockClientFactory)(nil).ServerAdminClient), c)
 }
 
+// ServerAdminJSONClient mocks base method.
+func (m *MockClientFactory) ServerAdminJSONClient(c *cli.Context) (json.AdminClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerAdminJSONClient", c)
+	ret0, _ := ret[0].(json.AdminClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerAdminJSONClient indicates an expected call of ServerAdminJSONClient.
+func (mr *MockClientFactoryMockRecorder) ServerAdminJSONClient(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerAdminJSONClient", reflect.TypeOf((*MockClientFactory)(nil).ServerAdminJSONClient), c)
+}
+
+// ServerFrontendJSONClient mocks base method.
+func (m *MockClientFactory) ServerFrontendJSONClient(c *cli.Context) (json.APIClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerFrontendJSONClient", c)
+	ret0, _ := ret[0].(json.APIClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerFrontendJSONClient indicates an expected call of ServerFrontendJSONClient.
+func (mr *MockClientFactoryMockRecorder) ServerFrontendJSONClient(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerFrontendJSONClient", reflect.TypeOf((*MockClientFactory)(nil).ServerFrontendJSONClient), c)
+}
+
 // ServerAdminClientForMigration mocks base method.
 func (m *MockClientFactory) ServerAdminClientForMigration(c *cli.Context) (admin.Client, error) {
 	m.ctrl.T.Helper()
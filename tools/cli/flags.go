@@ -20,7 +20,11 @@
 
 package cli
 
-import "github.com/urfave/cli/v2"
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
 
 // Flags used to specify cli command line arguments
 const (
@@ -92,6 +96,7 @@ const (
 	FlagIdentity                       = "identity"
 	FlagDetail                         = "detail"
 	FlagReason                         = "reason"
+	FlagRequestID                      = "request_id"
 	FlagOpen                           = "open"
 	FlagMore                           = "more"
 	FlagAll                            = "all"
@@ -154,6 +159,7 @@ const (
 	FlagDecisionOffset                 = "decision_offset"
 	FlagResetPointsOnly                = "reset_points_only"
 	FlagResetBadBinaryChecksum         = "reset_bad_binary_checksum"
+	FlagServerResetPoint               = "server_reset_point"
 	FlagSkipSignalReapply              = "skip_signal_reapply"
 	FlagListQuery                      = "query"
 	FlagExcludeWorkflowIDByQuery       = "exclude_query"
@@ -179,6 +185,7 @@ const (
 	FlagDLQType                        = "dlq_type"
 	FlagMaxMessageCount                = "max_message_count"
 	FlagLastMessageID                  = "last_message_id"
+	FlagSampleSize                     = "sample_size"
 	FlagConcurrency                    = "concurrency"
 	FlagReportRate                     = "report_rate"
 	FlagLowerShardBound                = "lower_shard_bound"
@@ -196,6 +203,7 @@ const (
 	FlagRetryInterval                  = "retry_interval"
 	FlagRetryAttempts                  = "retry_attempts"
 	FlagMaxActivityRetries             = "max_activity_retries"
+	FlagMaxWorkflows                   = "max_workflows"
 	FlagRetryExpiration                = "retry_expiration"
 	FlagRetryBackoff                   = "retry_backoff"
 	FlagRetryMaxInterval               = "retry_max_interval"
@@ -225,6 +233,10 @@ const (
 	FlagNumReadPartitions              = "num_read_partitions"
 	FlagNumWritePartitions             = "num_write_partitions"
 	FlagCronOverlapPolicy              = "cron_overlap_policy"
+	FlagMaxReplicationLag              = "max_lag"
+	FlagFailoverStartTime              = "start_time"
+	FlagDomainQuery                    = "domain_query"
+	FlagRefreshInterval                = "refresh_interval"
 
 	FlagClustersUsage = "Clusters (example: --clusters clusterA,clusterB or --cl clusterA --cl clusterB)"
 )
@@ -776,3 +788,25 @@ func getFlagsForObserveID() []cli.Flag {
 		},
 	}
 }
+
+func getFlagsForTUI() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    FlagListQuery,
+			Aliases: []string{"q"},
+			Usage:   "Optional SQL like query of the workflow list, e.g. \"CloseTime = missing\". Can be changed in the UI",
+		},
+		&cli.IntFlag{
+			Name:    FlagPageSize,
+			Aliases: []string{"ps"},
+			Value:   50,
+			Usage:   "Number of workflows in the list",
+		},
+		&cli.DurationFlag{
+			Name:    FlagRefreshInterval,
+			Aliases: []string{"ri"},
+			Value:   5 * time.Second,
+			Usage:   "Refresh interval of the workflow list",
+		},
+	}
+}
@@ -59,6 +59,21 @@ func (mr *MockManagerFactoryMockRecorder) initPersistenceFactory(c any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initPersistenceFactory", reflect.TypeOf((*MockManagerFactory)(nil).initPersistenceFactory), c)
 }
 
+// initializeAsyncWorkflowQueueManager mocks base method.
+func (m *MockManagerFactory) initializeAsyncWorkflowQueueManager(c *cli.Context) (persistence.QueueManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeAsyncWorkflowQueueManager", c)
+	ret0, _ := ret[0].(persistence.QueueManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeAsyncWorkflowQueueManager indicates an expected call of initializeAsyncWorkflowQueueManager.
+func (mr *MockManagerFactoryMockRecorder) initializeAsyncWorkflowQueueManager(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeAsyncWorkflowQueueManager", reflect.TypeOf((*MockManagerFactory)(nil).initializeAsyncWorkflowQueueManager), c)
+}
+
 // initializeDomainManager mocks base method.
 func (m *MockManagerFactory) initializeDomainManager(c *cli.Context) (persistence.DomainManager, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +82,15 @@ func Test_RenderTable(t *testing.T) {
 				"  text                |     123 | true  | 2000-01-02T03:04:05Z | A:AA, B:BB  \n" +
 				"  .../ string this is |     456 | false | 2000-11-12T13:14:15Z |             \n",
 		},
+		{
+			name: "wide characters",
+			data: []wideRow{{"ワークフロー", "完了"}, {"rocket 🚀", "✅"}, {"zeró", "±°"}},
+			expectOutput: "" +
+				"      NAME     | STATUS  \n" +
+				"  ワークフロー | 完了    \n" +
+				"  rocket 🚀    | ✅      \n" +
+				"  zeró         | ±°      \n",
+		},
 		{
 			name:      "non-struct element",
 			data:      123,
@@ -169,6 +178,11 @@ type testRow struct {
 	IgnoredField int
 }
 
+type wideRow struct {
+	Name   string `header:"name"`
+	Status string `header:"status"`
+}
+
 var testTable = []testRow{
 	{
 		StringField: "text",
@@ -0,0 +1,260 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// maxTUIHistoryEvents limits the number of events loaded into the history view
+const maxTUIHistoryEvents = 10000
+
+// StartTUI starts interactive terminal UI to investigate workflows of a domain
+func StartTUI(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	wfClient, err := getWorkflowClient(c)
+	if err != nil {
+		return err
+	}
+
+	backend := &tuiBackend{
+		client:   wfClient,
+		domain:   domain,
+		identity: getCliIdentity(),
+		user:     getCurrentUserFromEnv(),
+		newContext: func() (context.Context, context.CancelFunc, error) {
+			return newContext(c)
+		},
+	}
+	ui := newTUI(backend, tuiOptions{
+		query:           c.String(FlagListQuery),
+		pageSize:        int32(c.Int(FlagPageSize)),
+		refreshInterval: c.Duration(FlagRefreshInterval),
+	})
+	if err := ui.run(); err != nil {
+		return commoncli.Problem("TUI failed", err)
+	}
+	return nil
+}
+
+// tuiBackend loads data and performs actions for the TUI, so the views do not talk to the client directly
+type tuiBackend struct {
+	client     frontend.Client
+	domain     string
+	identity   string
+	user       string
+	newContext func() (context.Context, context.CancelFunc, error)
+}
+
+func (b *tuiBackend) listWorkflows(query string, pageSize int32) ([]*types.WorkflowExecutionInfo, error) {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	resp, err := b.client.ListWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+		Domain:   b.domain,
+		PageSize: pageSize,
+		Query:    query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetExecutions(), nil
+}
+
+func (b *tuiBackend) describeWorkflow(execution *types.WorkflowExecution) (*types.DescribeWorkflowExecutionResponse, error) {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	return b.client.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    b.domain,
+		Execution: execution,
+	})
+}
+
+// history loads all pages of the history, up to maxTUIHistoryEvents. Returns whether the history was truncated.
+func (b *tuiBackend) history(execution *types.WorkflowExecution) ([]*types.HistoryEvent, bool, error) {
+	var (
+		events []*types.HistoryEvent
+		token  []byte
+	)
+	for {
+		resp, err := b.historyPage(execution, token)
+		if err != nil {
+			return nil, false, err
+		}
+		events = append(events, resp.GetHistory().GetEvents()...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return events, false, nil
+		}
+		if len(events) >= maxTUIHistoryEvents {
+			return events, true, nil
+		}
+	}
+}
+
+func (b *tuiBackend) historyPage(execution *types.WorkflowExecution, token []byte) (*types.GetWorkflowExecutionHistoryResponse, error) {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	return b.client.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+		Domain:        b.domain,
+		Execution:     execution,
+		NextPageToken: token,
+	})
+}
+
+func (b *tuiBackend) describeTaskList(taskList *types.TaskList, taskListType types.TaskListType) (*types.DescribeTaskListResponse, error) {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	return b.client.DescribeTaskList(ctx, &types.DescribeTaskListRequest{
+		Domain:                b.domain,
+		TaskList:              taskList,
+		TaskListType:          taskListType.Ptr(),
+		IncludeTaskListStatus: true,
+	})
+}
+
+func (b *tuiBackend) signal(execution *types.WorkflowExecution, signalName string, input []byte) error {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return b.client.SignalWorkflowExecution(ctx, &types.SignalWorkflowExecutionRequest{
+		Domain:            b.domain,
+		WorkflowExecution: execution,
+		SignalName:        signalName,
+		Input:             input,
+		Identity:          b.identity,
+		RequestID:         uuid.New(),
+	})
+}
+
+func (b *tuiBackend) terminate(execution *types.WorkflowExecution, reason string) error {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return b.client.TerminateWorkflowExecution(ctx, &types.TerminateWorkflowExecutionRequest{
+		Domain:            b.domain,
+		WorkflowExecution: execution,
+		Reason:            reason,
+		Identity:          b.identity,
+	})
+}
+
+// reset resets the workflow to the given DecisionTaskCompleted event, returns run ID of the new run
+func (b *tuiBackend) reset(execution *types.WorkflowExecution, decisionFinishEventID int64, reason string) (string, error) {
+	ctx, cancel, err := b.newContext()
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	resp, err := b.client.ResetWorkflowExecution(ctx, &types.ResetWorkflowExecutionRequest{
+		Domain:                b.domain,
+		WorkflowExecution:     execution,
+		Reason:                fmt.Sprintf("%v:%v", b.user, reason),
+		DecisionFinishEventID: decisionFinishEventID,
+		RequestID:             uuid.New(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRunID(), nil
+}
+
+// pendingActivityRows renders pending activities as rows of ID, type, state, attempt, last failure, last heartbeat and last worker
+func pendingActivityRows(activities []*types.PendingActivityInfo) [][]string {
+	rows := make([][]string, 0, len(activities))
+	for _, a := range activities {
+		attempt := fmt.Sprintf("%d", a.GetAttempt())
+		if a.GetMaximumAttempts() > 0 {
+			attempt = fmt.Sprintf("%d/%d", a.GetAttempt(), a.GetMaximumAttempts())
+		}
+		lastHeartbeat := ""
+		if a.LastHeartbeatTimestamp != nil {
+			lastHeartbeat = timestampToString(a.GetLastHeartbeatTimestamp(), false)
+		}
+		rows = append(rows, []string{
+			a.GetActivityID(),
+			a.ActivityType.GetName(),
+			a.GetState().String(),
+			attempt,
+			a.GetLastFailureReason(),
+			lastHeartbeat,
+			a.GetLastWorkerIdentity(),
+		})
+	}
+	return rows
+}
+
+// taskListRows renders backlog of the task list followed by its pollers as rows of type, identity, last access time, rate per second and backlog
+func taskListRows(taskListType types.TaskListType, resp *types.DescribeTaskListResponse) [][]string {
+	status := resp.GetTaskListStatus()
+	rows := [][]string{{
+		taskListType.String(),
+		"(task list)",
+		"",
+		fmt.Sprintf("%.2f", status.GetRatePerSecond()),
+		fmt.Sprintf("%d", status.GetBacklogCountHint()),
+	}}
+	for _, poller := range resp.GetPollers() {
+		rows = append(rows, []string{
+			taskListType.String(),
+			poller.GetIdentity(),
+			timestampToString(poller.GetLastAccessTime(), false),
+			fmt.Sprintf("%.2f", poller.GetRatePerSecond()),
+			"",
+		})
+	}
+	return rows
+}
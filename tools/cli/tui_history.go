@@ -0,0 +1,188 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// eventGroup is a fold of history events of the same decision task, activity, timer, child workflow
+// or external workflow request. Events not belonging to any of those are groups of their own.
+type eventGroup struct {
+	// prefix is the common prefix of the event types in the group, it is trimmed for the status
+	prefix string
+	title  string
+	events []*types.HistoryEvent
+}
+
+// status is the type of the last event of the group without the common prefix, e.g. "Completed"
+func (g *eventGroup) status() string {
+	last := g.events[len(g.events)-1]
+	return strings.TrimPrefix(last.GetEventType().String(), g.prefix)
+}
+
+// foldHistory groups history events, groups are ordered by their first event
+func foldHistory(events []*types.HistoryEvent) []*eventGroup {
+	var (
+		groups []*eventGroup
+		// groups by the ID of their first event, as referenced by the following events
+		byEventID = map[int64]*eventGroup{}
+		// activity cancellation requests refer to activity ID, not to the scheduled event
+		byActivityID = map[string]*eventGroup{}
+		// timer cancellation failures refer to timer ID only
+		byTimerID = map[string]*eventGroup{}
+	)
+	for _, e := range events {
+		if group := findEventGroup(e, byEventID, byActivityID, byTimerID); group != nil {
+			group.events = append(group.events, e)
+			continue
+		}
+
+		group := newEventGroup(e)
+		groups = append(groups, group)
+		byEventID[e.ID] = group
+		if attributes := e.ActivityTaskScheduledEventAttributes; attributes != nil {
+			byActivityID[attributes.ActivityID] = group
+		}
+		if attributes := e.TimerStartedEventAttributes; attributes != nil {
+			byTimerID[attributes.TimerID] = group
+		}
+	}
+	return groups
+}
+
+func newEventGroup(e *types.HistoryEvent) *eventGroup {
+	group := &eventGroup{events: []*types.HistoryEvent{e}}
+	switch e.GetEventType() {
+	case types.EventTypeDecisionTaskScheduled:
+		group.prefix = "DecisionTask"
+		group.title = "Decision task"
+	case types.EventTypeActivityTaskScheduled:
+		attributes := e.ActivityTaskScheduledEventAttributes
+		group.prefix = "ActivityTask"
+		group.title = fmt.Sprintf("Activity %s (ID: %s)", attributes.GetActivityType().GetName(), attributes.GetActivityID())
+	case types.EventTypeTimerStarted:
+		group.prefix = "Timer"
+		group.title = fmt.Sprintf("Timer %s", e.TimerStartedEventAttributes.GetTimerID())
+	case types.EventTypeStartChildWorkflowExecutionInitiated:
+		attributes := e.StartChildWorkflowExecutionInitiatedEventAttributes
+		group.prefix = "ChildWorkflowExecution"
+		group.title = fmt.Sprintf("Child workflow %s (ID: %s)", attributes.GetWorkflowType().GetName(), attributes.GetWorkflowID())
+	case types.EventTypeSignalExternalWorkflowExecutionInitiated:
+		attributes := e.SignalExternalWorkflowExecutionInitiatedEventAttributes
+		group.prefix = "SignalExternalWorkflowExecution"
+		group.title = fmt.Sprintf("Signal %s to %s", attributes.GetSignalName(), attributes.GetWorkflowExecution().GetWorkflowID())
+	case types.EventTypeRequestCancelExternalWorkflowExecutionInitiated:
+		attributes := e.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes
+		group.prefix = "RequestCancelExternalWorkflowExecution"
+		group.title = fmt.Sprintf("Cancel %s", attributes.GetWorkflowExecution().GetWorkflowID())
+	default:
+		group.title = e.GetEventType().String()
+	}
+	return group
+}
+
+// findEventGroup returns the group of the event the given event refers to, if any
+func findEventGroup(
+	e *types.HistoryEvent,
+	byEventID map[int64]*eventGroup,
+	byActivityID map[string]*eventGroup,
+	byTimerID map[string]*eventGroup,
+) *eventGroup {
+	switch e.GetEventType() {
+	case types.EventTypeDecisionTaskStarted:
+		return byEventID[e.DecisionTaskStartedEventAttributes.GetScheduledEventID()]
+	case types.EventTypeDecisionTaskCompleted:
+		if attributes := e.DecisionTaskCompletedEventAttributes; attributes != nil {
+			return byEventID[attributes.ScheduledEventID]
+		}
+	case types.EventTypeDecisionTaskFailed:
+		if attributes := e.DecisionTaskFailedEventAttributes; attributes != nil {
+			return byEventID[attributes.ScheduledEventID]
+		}
+	case types.EventTypeDecisionTaskTimedOut:
+		return byEventID[e.DecisionTaskTimedOutEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskStarted:
+		return byEventID[e.ActivityTaskStartedEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskCompleted:
+		return byEventID[e.ActivityTaskCompletedEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskFailed:
+		return byEventID[e.ActivityTaskFailedEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskTimedOut:
+		return byEventID[e.ActivityTaskTimedOutEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskCanceled:
+		return byEventID[e.ActivityTaskCanceledEventAttributes.GetScheduledEventID()]
+	case types.EventTypeActivityTaskCancelRequested:
+		return byActivityID[e.ActivityTaskCancelRequestedEventAttributes.GetActivityID()]
+	case types.EventTypeRequestCancelActivityTaskFailed:
+		if attributes := e.RequestCancelActivityTaskFailedEventAttributes; attributes != nil {
+			return byActivityID[attributes.ActivityID]
+		}
+	case types.EventTypeTimerFired:
+		return byEventID[e.TimerFiredEventAttributes.GetStartedEventID()]
+	case types.EventTypeTimerCanceled:
+		if attributes := e.TimerCanceledEventAttributes; attributes != nil {
+			return byEventID[attributes.StartedEventID]
+		}
+	case types.EventTypeCancelTimerFailed:
+		if attributes := e.CancelTimerFailedEventAttributes; attributes != nil {
+			return byTimerID[attributes.TimerID]
+		}
+	case types.EventTypeStartChildWorkflowExecutionFailed:
+		return byEventID[e.StartChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionStarted:
+		return byEventID[e.ChildWorkflowExecutionStartedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionCompleted:
+		return byEventID[e.ChildWorkflowExecutionCompletedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionFailed:
+		return byEventID[e.ChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionCanceled:
+		return byEventID[e.ChildWorkflowExecutionCanceledEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionTimedOut:
+		return byEventID[e.ChildWorkflowExecutionTimedOutEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeChildWorkflowExecutionTerminated:
+		return byEventID[e.ChildWorkflowExecutionTerminatedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeSignalExternalWorkflowExecutionFailed:
+		return byEventID[e.SignalExternalWorkflowExecutionFailedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeExternalWorkflowExecutionSignaled:
+		return byEventID[e.ExternalWorkflowExecutionSignaledEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeRequestCancelExternalWorkflowExecutionFailed:
+		return byEventID[e.RequestCancelExternalWorkflowExecutionFailedEventAttributes.GetInitiatedEventID()]
+	case types.EventTypeExternalWorkflowExecutionCancelRequested:
+		return byEventID[e.ExternalWorkflowExecutionCancelRequestedEventAttributes.GetInitiatedEventID()]
+	}
+	return nil
+}
+
+// lastDecisionCompletedEventID returns ID of the last DecisionTaskCompleted event, the default point to reset to
+func lastDecisionCompletedEventID(events []*types.HistoryEvent) int64 {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].GetEventType() == types.EventTypeDecisionTaskCompleted {
+			return events[i].ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestFoldHistory(t *testing.T) {
+	events := []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
+		{ID: 2, EventType: types.EventTypeDecisionTaskScheduled.Ptr()},
+		{ID: 3, EventType: types.EventTypeDecisionTaskStarted.Ptr(), DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{ScheduledEventID: 2}},
+		{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr(), DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{ScheduledEventID: 2}},
+		{ID: 5, EventType: types.EventTypeActivityTaskScheduled.Ptr(), ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+			ActivityID:   "activity-id",
+			ActivityType: &types.ActivityType{Name: "activity-type"},
+		}},
+		{ID: 6, EventType: types.EventTypeTimerStarted.Ptr(), TimerStartedEventAttributes: &types.TimerStartedEventAttributes{TimerID: "timer-id"}},
+		{ID: 7, EventType: types.EventTypeActivityTaskStarted.Ptr(), ActivityTaskStartedEventAttributes: &types.ActivityTaskStartedEventAttributes{ScheduledEventID: 5}},
+		{ID: 8, EventType: types.EventTypeActivityTaskFailed.Ptr(), ActivityTaskFailedEventAttributes: &types.ActivityTaskFailedEventAttributes{ScheduledEventID: 5}},
+		{ID: 9, EventType: types.EventTypeActivityTaskCancelRequested.Ptr(), ActivityTaskCancelRequestedEventAttributes: &types.ActivityTaskCancelRequestedEventAttributes{ActivityID: "activity-id"}},
+		{ID: 10, EventType: types.EventTypeTimerFired.Ptr(), TimerFiredEventAttributes: &types.TimerFiredEventAttributes{StartedEventID: 6}},
+		{ID: 11, EventType: types.EventTypeStartChildWorkflowExecutionInitiated.Ptr(), StartChildWorkflowExecutionInitiatedEventAttributes: &types.StartChildWorkflowExecutionInitiatedEventAttributes{
+			WorkflowID:   "child-id",
+			WorkflowType: &types.WorkflowType{Name: "child-type"},
+		}},
+		{ID: 12, EventType: types.EventTypeChildWorkflowExecutionStarted.Ptr(), ChildWorkflowExecutionStartedEventAttributes: &types.ChildWorkflowExecutionStartedEventAttributes{InitiatedEventID: 11}},
+		// missing attributes must not break folding
+		{ID: 13, EventType: types.EventTypeDecisionTaskCompleted.Ptr()},
+		{ID: 14, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr()},
+	}
+
+	type group struct {
+		title    string
+		status   string
+		eventIDs []int64
+	}
+	var groups []group
+	for _, g := range foldHistory(events) {
+		var ids []int64
+		for _, e := range g.events {
+			ids = append(ids, e.ID)
+		}
+		groups = append(groups, group{title: g.title, status: g.status(), eventIDs: ids})
+	}
+
+	assert.Equal(t, []group{
+		{title: "WorkflowExecutionStarted", status: "WorkflowExecutionStarted", eventIDs: []int64{1}},
+		{title: "Decision task", status: "Completed", eventIDs: []int64{2, 3, 4}},
+		{title: "Activity activity-type (ID: activity-id)", status: "CancelRequested", eventIDs: []int64{5, 7, 8, 9}},
+		{title: "Timer timer-id", status: "Fired", eventIDs: []int64{6, 10}},
+		{title: "Child workflow child-type (ID: child-id)", status: "Started", eventIDs: []int64{11, 12}},
+		{title: "DecisionTaskCompleted", status: "DecisionTaskCompleted", eventIDs: []int64{13}},
+		{title: "WorkflowExecutionSignaled", status: "WorkflowExecutionSignaled", eventIDs: []int64{14}},
+	}, groups)
+}
+
+func TestLastDecisionCompletedEventID(t *testing.T) {
+	assert.Equal(t, int64(0), lastDecisionCompletedEventID(nil))
+	assert.Equal(t, int64(4), lastDecisionCompletedEventID([]*types.HistoryEvent{
+		{ID: 2, EventType: types.EventTypeDecisionTaskCompleted.Ptr()},
+		{ID: 3, EventType: types.EventTypeActivityTaskScheduled.Ptr()},
+		{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr()},
+		{ID: 5, EventType: types.EventTypeTimerStarted.Ptr()},
+	}))
+}
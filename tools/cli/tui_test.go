@@ -0,0 +1,249 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	testTUIDomain     = "test-domain"
+	testTUIWorkflowID = "test-workflow-id"
+	testTUIRunID      = "test-run-id"
+)
+
+var testTUIExecution = &types.WorkflowExecution{WorkflowID: testTUIWorkflowID, RunID: testTUIRunID}
+
+func newTestTUIBackend(t *testing.T) (*tuiBackend, *frontend.MockClient) {
+	mockClient := frontend.NewMockClient(gomock.NewController(t))
+	return &tuiBackend{
+		client:   mockClient,
+		domain:   testTUIDomain,
+		identity: "test-identity",
+		user:     "test-user",
+		newContext: func() (context.Context, context.CancelFunc, error) {
+			ctx, cancel := context.WithCancel(context.Background())
+			return ctx, cancel, nil
+		},
+	}, mockClient
+}
+
+func TestTUIBackend_ListWorkflows(t *testing.T) {
+	backend, mockClient := newTestTUIBackend(t)
+	executions := []*types.WorkflowExecutionInfo{{Execution: testTUIExecution}}
+	mockClient.EXPECT().ListWorkflowExecutions(gomock.Any(), &types.ListWorkflowExecutionsRequest{
+		Domain:   testTUIDomain,
+		PageSize: 10,
+		Query:    "CloseTime = missing",
+	}).Return(&types.ListWorkflowExecutionsResponse{Executions: executions}, nil)
+
+	result, err := backend.listWorkflows("CloseTime = missing", 10)
+	require.NoError(t, err)
+	assert.Equal(t, executions, result)
+
+	mockClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(nil, errors.New("list failed"))
+	_, err = backend.listWorkflows("", 10)
+	assert.EqualError(t, err, "list failed")
+}
+
+func TestTUIBackend_History(t *testing.T) {
+	tests := []struct {
+		name          string
+		pages         [][]*types.HistoryEvent
+		err           error
+		wantEvents    int
+		wantTruncated bool
+		wantErr       bool
+	}{
+		{
+			name:       "single page",
+			pages:      [][]*types.HistoryEvent{testEvents(1, 3)},
+			wantEvents: 3,
+		},
+		{
+			name:       "all pages are loaded",
+			pages:      [][]*types.HistoryEvent{testEvents(1, 3), testEvents(4, 5)},
+			wantEvents: 5,
+		},
+		{
+			name:          "too long history is truncated",
+			pages:         [][]*types.HistoryEvent{testEvents(1, maxTUIHistoryEvents), testEvents(maxTUIHistoryEvents+1, maxTUIHistoryEvents+1)},
+			wantEvents:    maxTUIHistoryEvents,
+			wantTruncated: true,
+		},
+		{
+			name:    "error",
+			err:     errors.New("history failed"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, mockClient := newTestTUIBackend(t)
+			if tt.err != nil {
+				mockClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+			}
+			var token []byte
+			for i, page := range tt.pages {
+				var nextToken []byte
+				if i < len(tt.pages)-1 {
+					nextToken = []byte{byte(i + 1)}
+				}
+				resp := &types.GetWorkflowExecutionHistoryResponse{
+					History:       &types.History{Events: page},
+					NextPageToken: nextToken,
+				}
+				mockClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), &types.GetWorkflowExecutionHistoryRequest{
+					Domain:        testTUIDomain,
+					Execution:     testTUIExecution,
+					NextPageToken: token,
+				}).Return(resp, nil).MaxTimes(1)
+				token = nextToken
+			}
+
+			events, truncated, err := backend.history(testTUIExecution)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, events, tt.wantEvents)
+			assert.Equal(t, tt.wantTruncated, truncated)
+		})
+	}
+}
+
+func TestTUIBackend_DescribeTaskList(t *testing.T) {
+	backend, mockClient := newTestTUIBackend(t)
+	taskList := &types.TaskList{Name: "test-task-list"}
+	resp := &types.DescribeTaskListResponse{Pollers: []*types.PollerInfo{{Identity: "worker"}}}
+	mockClient.EXPECT().DescribeTaskList(gomock.Any(), &types.DescribeTaskListRequest{
+		Domain:                testTUIDomain,
+		TaskList:              taskList,
+		TaskListType:          types.TaskListTypeActivity.Ptr(),
+		IncludeTaskListStatus: true,
+	}).Return(resp, nil)
+
+	result, err := backend.describeTaskList(taskList, types.TaskListTypeActivity)
+	require.NoError(t, err)
+	assert.Equal(t, resp, result)
+}
+
+func TestTUIBackend_Actions(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		backend, mockClient := newTestTUIBackend(t)
+		mockClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.SignalWorkflowExecutionRequest, _ ...interface{}) error {
+				assert.Equal(t, testTUIDomain, request.Domain)
+				assert.Equal(t, testTUIExecution, request.WorkflowExecution)
+				assert.Equal(t, "test-signal", request.SignalName)
+				assert.Equal(t, []byte(`"input"`), request.Input)
+				assert.Equal(t, "test-identity", request.Identity)
+				assert.NotEmpty(t, request.RequestID)
+				return nil
+			})
+		assert.NoError(t, backend.signal(testTUIExecution, "test-signal", []byte(`"input"`)))
+	})
+
+	t.Run("terminate", func(t *testing.T) {
+		backend, mockClient := newTestTUIBackend(t)
+		mockClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), &types.TerminateWorkflowExecutionRequest{
+			Domain:            testTUIDomain,
+			WorkflowExecution: testTUIExecution,
+			Reason:            "test-reason",
+			Identity:          "test-identity",
+		}).Return(errors.New("terminate failed"))
+		assert.EqualError(t, backend.terminate(testTUIExecution, "test-reason"), "terminate failed")
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		backend, mockClient := newTestTUIBackend(t)
+		mockClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.ResetWorkflowExecutionRequest, _ ...interface{}) (*types.ResetWorkflowExecutionResponse, error) {
+				assert.Equal(t, testTUIDomain, request.Domain)
+				assert.Equal(t, testTUIExecution, request.WorkflowExecution)
+				assert.Equal(t, "test-user:test-reason", request.Reason)
+				assert.Equal(t, int64(4), request.DecisionFinishEventID)
+				assert.NotEmpty(t, request.RequestID)
+				return &types.ResetWorkflowExecutionResponse{RunID: "new-run-id"}, nil
+			})
+		runID, err := backend.reset(testTUIExecution, 4, "test-reason")
+		require.NoError(t, err)
+		assert.Equal(t, "new-run-id", runID)
+	})
+}
+
+func TestPendingActivityRows(t *testing.T) {
+	activities := []*types.PendingActivityInfo{
+		{
+			ActivityID:             "1",
+			ActivityType:           &types.ActivityType{Name: "test-activity"},
+			State:                  types.PendingActivityStateStarted.Ptr(),
+			Attempt:                2,
+			MaximumAttempts:        5,
+			LastFailureReason:      common.StringPtr("some failure"),
+			LastHeartbeatTimestamp: common.Int64Ptr(0),
+			LastWorkerIdentity:     "worker",
+		},
+		{
+			ActivityID:   "2",
+			ActivityType: &types.ActivityType{Name: "test-activity"},
+			State:        types.PendingActivityStateScheduled.Ptr(),
+		},
+	}
+	assert.Equal(t, [][]string{
+		{"1", "test-activity", "STARTED", "2/5", "some failure", timestampToString(0, false), "worker"},
+		{"2", "test-activity", "SCHEDULED", "0", "", "", ""},
+	}, pendingActivityRows(activities))
+}
+
+func TestTaskListRows(t *testing.T) {
+	resp := &types.DescribeTaskListResponse{
+		Pollers: []*types.PollerInfo{
+			{Identity: "worker-1", LastAccessTime: common.Int64Ptr(0), RatePerSecond: 100},
+		},
+		TaskListStatus: &types.TaskListStatus{BacklogCountHint: 42, RatePerSecond: 10},
+	}
+	assert.Equal(t, [][]string{
+		{"Decision", "(task list)", "", "10.00", "42"},
+		{"Decision", "worker-1", timestampToString(0, false), "100.00", ""},
+	}, taskListRows(types.TaskListTypeDecision, resp))
+}
+
+func testEvents(firstID, lastID int64) []*types.HistoryEvent {
+	var events []*types.HistoryEvent
+	for id := firstID; id <= lastID; id++ {
+		events = append(events, &types.HistoryEvent{ID: id})
+	}
+	return events
+}
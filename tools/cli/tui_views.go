@@ -0,0 +1,639 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	tuiPageWorkflows = "workflows"
+	tuiPageWorkflow  = "workflow"
+	tuiPagePending   = "pending"
+	tuiPageTaskLists = "tasklists"
+	tuiPageDialog    = "dialog"
+
+	tuiWorkflowsHelp = "[yellow]Enter[white] open  [yellow]/[white] edit query  [yellow]R[white] refresh  [yellow]q[white] quit"
+	tuiWorkflowHelp  = "[yellow]Enter[white]/[yellow]e[white] fold  [yellow]p[white] pending activities  [yellow]t[white] task lists  " +
+		"[yellow]s[white] signal  [yellow]T[white] terminate  [yellow]r[white] reset  [yellow]R[white] reload  [yellow]Esc[white] back  [yellow]q[white] quit"
+	tuiDetailsHelp = "[yellow]R[white] reload  [yellow]Esc[white] back  [yellow]q[white] quit"
+)
+
+type tuiOptions struct {
+	query           string
+	pageSize        int32
+	refreshInterval time.Duration
+}
+
+// tui is the interactive terminal UI. All the backend calls are done outside of the UI goroutine
+// and the views are updated by QueueUpdateDraw.
+type tui struct {
+	backend *tuiBackend
+	options tuiOptions
+
+	app    *tview.Application
+	pages  *tview.Pages
+	status *tview.TextView
+
+	queryInput    *tview.InputField
+	workflowTable *tview.Table
+	workflows     []*types.WorkflowExecutionInfo
+
+	workflowInfo *tview.TextView
+	historyTree  *tview.TreeView
+	eventDetails *tview.TextView
+	pendingTable *tview.Table
+	taskLists    *tview.Table
+
+	// workflow opened in the workflow page, its description and history
+	execution *types.WorkflowExecution
+	describe  *types.DescribeWorkflowExecutionResponse
+	history   []*types.HistoryEvent
+
+	// query is read by the refresh loop, so it is guarded
+	queryLock sync.Mutex
+	query     string
+	refresh   chan struct{}
+}
+
+func newTUI(backend *tuiBackend, options tuiOptions) *tui {
+	t := &tui{
+		backend: backend,
+		options: options,
+		app:     tview.NewApplication(),
+		pages:   tview.NewPages(),
+		status:  tview.NewTextView().SetDynamicColors(true),
+		query:   options.query,
+		refresh: make(chan struct{}, 1),
+	}
+	t.pages.AddPage(tuiPageWorkflows, t.newWorkflowsPage(), true, true)
+	t.pages.AddPage(tuiPageWorkflow, t.newWorkflowPage(), true, false)
+	t.pages.AddPage(tuiPagePending, t.newPendingPage(), true, false)
+	t.pages.AddPage(tuiPageTaskLists, t.newTaskListsPage(), true, false)
+	t.setHelp(tuiWorkflowsHelp)
+
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.pages, 0, 1, true).
+		AddItem(t.status, 1, 0, false)
+	t.app.SetRoot(root, true)
+	return t
+}
+
+// run blocks until the user quits
+func (t *tui) run() error {
+	done := make(chan struct{})
+	defer close(done)
+	go t.refreshLoop(done)
+	return t.app.Run()
+}
+
+// refreshLoop reloads the workflow list periodically and on demand
+func (t *tui) refreshLoop(done <-chan struct{}) {
+	ticker := time.NewTicker(t.options.refreshInterval)
+	defer ticker.Stop()
+
+	t.loadWorkflows()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		case <-t.refresh:
+		}
+		t.loadWorkflows()
+	}
+}
+
+func (t *tui) requestRefresh() {
+	select {
+	case t.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (t *tui) loadWorkflows() {
+	t.queryLock.Lock()
+	query := t.query
+	t.queryLock.Unlock()
+
+	workflows, err := t.backend.listWorkflows(query, t.options.pageSize)
+	t.app.QueueUpdateDraw(func() {
+		if err != nil {
+			t.showError("Failed to list workflows", err)
+			return
+		}
+		t.renderWorkflows(workflows)
+	})
+}
+
+func (t *tui) newWorkflowsPage() tview.Primitive {
+	t.queryInput = tview.NewInputField().
+		SetLabel("Query: ").
+		SetText(t.options.query)
+	t.queryInput.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			t.queryLock.Lock()
+			t.query = t.queryInput.GetText()
+			t.queryLock.Unlock()
+			t.requestRefresh()
+		}
+		t.app.SetFocus(t.workflowTable)
+	})
+
+	t.workflowTable = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	t.workflowTable.SetBorder(true).SetTitle(" Workflows ")
+	t.workflowTable.SetSelectedFunc(func(row, _ int) {
+		if row > 0 && row <= len(t.workflows) {
+			t.openWorkflow(t.workflows[row-1].GetExecution())
+		}
+	})
+	t.workflowTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case '/':
+			t.app.SetFocus(t.queryInput)
+			return nil
+		case 'R':
+			t.requestRefresh()
+			return nil
+		case 'q':
+			t.app.Stop()
+			return nil
+		}
+		return event
+	})
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.queryInput, 1, 0, false).
+		AddItem(t.workflowTable, 0, 1, true)
+}
+
+func (t *tui) renderWorkflows(workflows []*types.WorkflowExecutionInfo) {
+	t.workflows = workflows
+	t.workflowTable.Clear()
+	setTableHeader(t.workflowTable, "Workflow ID", "Run ID", "Type", "Start Time", "Close Time", "Status")
+	for i, w := range workflows {
+		closeTime := ""
+		if w.CloseTime != nil {
+			closeTime = timestampToString(w.GetCloseTime(), false)
+		}
+		setTableRow(t.workflowTable, i+1,
+			w.GetExecution().GetWorkflowID(),
+			w.GetExecution().GetRunID(),
+			w.GetType().GetName(),
+			timestampToString(w.GetStartTime(), false),
+			closeTime,
+			workflowStatus(w),
+		)
+	}
+	t.workflowTable.SetTitle(fmt.Sprintf(" Workflows (%d, refreshed at %s) ", len(workflows), time.Now().Format(defaultTimeFormat)))
+}
+
+func (t *tui) newWorkflowPage() tview.Primitive {
+	t.workflowInfo = tview.NewTextView().SetDynamicColors(true)
+	t.workflowInfo.SetBorder(true).SetTitle(" Workflow ")
+
+	t.historyTree = tview.NewTreeView()
+	t.historyTree.SetBorder(true).SetTitle(" History ")
+	t.historyTree.SetChangedFunc(func(node *tview.TreeNode) {
+		t.renderEventDetails(node)
+	})
+	t.historyTree.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+
+	t.eventDetails = tview.NewTextView().SetWrap(true)
+	t.eventDetails.SetBorder(true).SetTitle(" Event ")
+
+	page := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.workflowInfo, 6, 0, false).
+		AddItem(tview.NewFlex().
+			AddItem(t.historyTree, 0, 1, true).
+			AddItem(t.eventDetails, 0, 1, false), 0, 1, true)
+	page.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			t.showPage(tuiPageWorkflows, tuiWorkflowsHelp)
+			t.requestRefresh()
+			return nil
+		}
+		switch event.Rune() {
+		case 'e':
+			t.toggleAllGroups()
+		case 'p':
+			t.showPage(tuiPagePending, tuiDetailsHelp)
+			t.loadPendingActivities()
+		case 't':
+			t.showPage(tuiPageTaskLists, tuiDetailsHelp)
+			t.loadTaskLists()
+		case 's':
+			t.showSignalForm()
+		case 'T':
+			t.showTerminateForm()
+		case 'r':
+			t.showResetForm()
+		case 'R':
+			t.openWorkflow(t.execution)
+		case 'q':
+			t.app.Stop()
+		default:
+			return event
+		}
+		return nil
+	})
+	return page
+}
+
+func (t *tui) openWorkflow(execution *types.WorkflowExecution) {
+	t.execution = execution
+	t.describe = nil
+	t.history = nil
+	t.workflowInfo.SetText(fmt.Sprintf("Loading %s ...", execution.GetWorkflowID()))
+	t.historyTree.SetRoot(nil)
+	t.eventDetails.Clear()
+	t.showPage(tuiPageWorkflow, tuiWorkflowHelp)
+
+	go func() {
+		describe, err := t.backend.describeWorkflow(execution)
+		if err != nil {
+			t.app.QueueUpdateDraw(func() { t.showError("Failed to describe workflow", err) })
+			return
+		}
+		history, truncated, err := t.backend.history(execution)
+		if err != nil {
+			t.app.QueueUpdateDraw(func() { t.showError("Failed to get workflow history", err) })
+			return
+		}
+		t.app.QueueUpdateDraw(func() {
+			if t.execution != execution {
+				// another workflow was opened in the meantime
+				return
+			}
+			t.describe = describe
+			t.history = history
+			t.renderWorkflow(truncated)
+		})
+	}()
+}
+
+func (t *tui) renderWorkflow(truncated bool) {
+	info := t.describe.GetWorkflowExecutionInfo()
+	if info == nil {
+		info = &types.WorkflowExecutionInfo{Execution: t.execution}
+	}
+	text := fmt.Sprintf("[yellow]Workflow ID:[white] %s  [yellow]Run ID:[white] %s\n", info.GetExecution().GetWorkflowID(), info.GetExecution().GetRunID())
+	text += fmt.Sprintf("[yellow]Type:[white] %s  [yellow]Task list:[white] %s\n", info.GetType().GetName(), info.TaskList.GetName())
+	text += fmt.Sprintf("[yellow]Status:[white] %s  [yellow]Started:[white] %s  [yellow]History length:[white] %d\n",
+		workflowStatus(info), timestampToString(info.GetStartTime(), false), info.HistoryLength)
+	text += fmt.Sprintf("[yellow]Pending activities:[white] %d  [yellow]Pending children:[white] %d", len(t.describe.GetPendingActivities()), len(t.describe.PendingChildren))
+	if truncated {
+		text += fmt.Sprintf("\n[red]History is truncated to the first %d events", len(t.history))
+	}
+	t.workflowInfo.SetText(text)
+
+	root := tview.NewTreeNode(".")
+	for _, group := range foldHistory(t.history) {
+		first := group.events[0]
+		node := tview.NewTreeNode(fmt.Sprintf("%d %s", first.ID, group.title)).
+			SetReference(group.events[len(group.events)-1])
+		if len(group.events) > 1 {
+			node.SetText(fmt.Sprintf("%d %s: %s", first.ID, group.title, group.status())).
+				SetColor(tcell.ColorYellow).
+				SetExpanded(false)
+			for _, e := range group.events {
+				node.AddChild(tview.NewTreeNode(eventNodeText(e)).SetReference(e))
+			}
+		}
+		root.AddChild(node)
+	}
+	t.historyTree.SetRoot(root).SetTopLevel(1)
+	if children := root.GetChildren(); len(children) > 0 {
+		t.historyTree.SetCurrentNode(children[0])
+		t.renderEventDetails(children[0])
+	}
+}
+
+func (t *tui) renderEventDetails(node *tview.TreeNode) {
+	e, ok := node.GetReference().(*types.HistoryEvent)
+	if !ok {
+		t.eventDetails.Clear()
+		return
+	}
+	t.eventDetails.SetTitle(fmt.Sprintf(" Event %d ", e.ID))
+	t.eventDetails.SetText(eventNodeText(e) + "\n\n" + HistoryEventToString(e, true, 0)).ScrollToBeginning()
+}
+
+// toggleAllGroups unfolds all the groups, or folds them if all are already unfolded
+func (t *tui) toggleAllGroups() {
+	root := t.historyTree.GetRoot()
+	if root == nil {
+		return
+	}
+	expand := false
+	for _, node := range root.GetChildren() {
+		if len(node.GetChildren()) > 0 && !node.IsExpanded() {
+			expand = true
+		}
+	}
+	for _, node := range root.GetChildren() {
+		node.SetExpanded(expand)
+	}
+}
+
+func (t *tui) newPendingPage() tview.Primitive {
+	t.pendingTable = tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	t.pendingTable.SetBorder(true).SetTitle(" Pending activities ")
+	t.pendingTable.SetInputCapture(t.detailsInputCapture(t.loadPendingActivities))
+	return t.pendingTable
+}
+
+func (t *tui) loadPendingActivities() {
+	t.pendingTable.Clear()
+	execution := t.execution
+	go func() {
+		describe, err := t.backend.describeWorkflow(execution)
+		t.app.QueueUpdateDraw(func() {
+			if err != nil {
+				t.showError("Failed to describe workflow", err)
+				return
+			}
+			t.describe = describe
+			t.renderPendingActivities(describe.GetPendingActivities())
+		})
+	}()
+}
+
+func (t *tui) renderPendingActivities(activities []*types.PendingActivityInfo) {
+	t.pendingTable.Clear()
+	setTableHeader(t.pendingTable, "Activity ID", "Type", "State", "Attempt", "Last Failure", "Last Heartbeat", "Last Worker")
+	for i, rowValues := range pendingActivityRows(activities) {
+		setTableRow(t.pendingTable, i+1, rowValues...)
+	}
+	t.pendingTable.SetTitle(fmt.Sprintf(" Pending activities of %s (%d) ", t.execution.GetWorkflowID(), len(activities)))
+}
+
+func (t *tui) newTaskListsPage() tview.Primitive {
+	t.taskLists = tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	t.taskLists.SetBorder(true).SetTitle(" Task lists ")
+	t.taskLists.SetInputCapture(t.detailsInputCapture(t.loadTaskLists))
+	return t.taskLists
+}
+
+func (t *tui) loadTaskLists() {
+	t.taskLists.Clear()
+	if t.describe == nil {
+		return
+	}
+	var taskList *types.TaskList
+	if info := t.describe.GetWorkflowExecutionInfo(); info != nil {
+		taskList = info.TaskList
+	}
+	if taskList == nil && t.describe.ExecutionConfiguration != nil {
+		taskList = t.describe.ExecutionConfiguration.TaskList
+	}
+	if taskList == nil {
+		t.setStatus("[red]Task list of the workflow is unknown")
+		return
+	}
+	taskListTypes := []types.TaskListType{types.TaskListTypeDecision, types.TaskListTypeActivity}
+
+	go func() {
+		responses := make([]*types.DescribeTaskListResponse, len(taskListTypes))
+		for i, taskListType := range taskListTypes {
+			resp, err := t.backend.describeTaskList(taskList, taskListType)
+			if err != nil {
+				t.app.QueueUpdateDraw(func() { t.showError("Failed to describe task list", err) })
+				return
+			}
+			responses[i] = resp
+		}
+		t.app.QueueUpdateDraw(func() {
+			t.taskLists.Clear()
+			setTableHeader(t.taskLists, "Type", "Identity", "Last Access Time", "Rate Per Second", "Backlog")
+			row := 1
+			for i, resp := range responses {
+				for _, rowValues := range taskListRows(taskListTypes[i], resp) {
+					setTableRow(t.taskLists, row, rowValues...)
+					row++
+				}
+			}
+			t.taskLists.SetTitle(fmt.Sprintf(" Task list %s ", taskList.GetName()))
+		})
+	}()
+}
+
+// detailsInputCapture handles keys of the pages opened from the workflow page
+func (t *tui) detailsInputCapture(reload func()) func(event *tcell.EventKey) *tcell.EventKey {
+	return func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			t.showPage(tuiPageWorkflow, tuiWorkflowHelp)
+			return nil
+		}
+		switch event.Rune() {
+		case 'R':
+			reload()
+		case 'q':
+			t.app.Stop()
+		default:
+			return event
+		}
+		return nil
+	}
+}
+
+func (t *tui) showSignalForm() {
+	if t.execution == nil {
+		return
+	}
+	form := tview.NewForm().
+		AddInputField("Signal name", "", 40, nil, nil).
+		AddInputField("Input (JSON)", "", 60, nil, nil)
+	form.AddButton("Signal", func() {
+		name := form.GetFormItemByLabel("Signal name").(*tview.InputField).GetText()
+		input := form.GetFormItemByLabel("Input (JSON)").(*tview.InputField).GetText()
+		if name == "" {
+			t.setStatus("[red]Signal name is required")
+			return
+		}
+		execution := t.execution
+		t.confirm(fmt.Sprintf("Signal %q to workflow %s?", name, execution.GetWorkflowID()), func() error {
+			var payload []byte
+			if input != "" {
+				payload = []byte(input)
+			}
+			return t.backend.signal(execution, name, payload)
+		}, "Signal sent")
+	})
+	t.showForm(form, " Signal workflow ")
+}
+
+func (t *tui) showTerminateForm() {
+	if t.execution == nil {
+		return
+	}
+	form := tview.NewForm().
+		AddInputField("Reason", "", 60, nil, nil)
+	form.AddButton("Terminate", func() {
+		reason := form.GetFormItemByLabel("Reason").(*tview.InputField).GetText()
+		execution := t.execution
+		t.confirm(fmt.Sprintf("Terminate workflow %s?", execution.GetWorkflowID()), func() error {
+			return t.backend.terminate(execution, reason)
+		}, "Workflow terminated")
+	})
+	t.showForm(form, " Terminate workflow ")
+}
+
+func (t *tui) showResetForm() {
+	if t.execution == nil {
+		return
+	}
+	eventID := ""
+	if id := lastDecisionCompletedEventID(t.history); id > 0 {
+		eventID = strconv.FormatInt(id, 10)
+	}
+	form := tview.NewForm().
+		AddInputField("DecisionTaskCompleted event ID", eventID, 20, tview.InputFieldInteger, nil).
+		AddInputField("Reason", "", 60, nil, nil)
+	form.AddButton("Reset", func() {
+		id, err := strconv.ParseInt(form.GetFormItemByLabel("DecisionTaskCompleted event ID").(*tview.InputField).GetText(), 10, 64)
+		if err != nil || id <= 0 {
+			t.setStatus("[red]Valid event ID is required")
+			return
+		}
+		reason := form.GetFormItemByLabel("Reason").(*tview.InputField).GetText()
+		if reason == "" {
+			t.setStatus("[red]Reason is required")
+			return
+		}
+		execution := t.execution
+		t.confirm(fmt.Sprintf("Reset workflow %s to event %d?", execution.GetWorkflowID(), id), func() error {
+			runID, err := t.backend.reset(execution, id, reason)
+			if err != nil {
+				return err
+			}
+			t.app.QueueUpdateDraw(func() {
+				t.openWorkflow(&types.WorkflowExecution{WorkflowID: execution.GetWorkflowID(), RunID: runID})
+			})
+			return nil
+		}, "Workflow reset")
+	})
+	t.showForm(form, " Reset workflow ")
+}
+
+func (t *tui) showForm(form *tview.Form, title string) {
+	form.AddButton("Cancel", t.closeDialog)
+	form.SetCancelFunc(t.closeDialog)
+	form.SetBorder(true).SetTitle(title)
+	t.pages.AddPage(tuiPageDialog, centered(form, 80, 11), true, true)
+}
+
+// confirm asks the user to confirm the action, then performs it outside of the UI goroutine
+func (t *tui) confirm(question string, action func() error, success string) {
+	modal := tview.NewModal().
+		SetText(question).
+		AddButtons([]string{"Cancel", "Confirm"}).
+		SetDoneFunc(func(_ int, label string) {
+			t.closeDialog()
+			if label != "Confirm" {
+				return
+			}
+			t.setStatus("Working ...")
+			go func() {
+				err := action()
+				t.app.QueueUpdateDraw(func() {
+					if err != nil {
+						t.showError("Action failed", err)
+						return
+					}
+					t.setStatus("[green]" + success)
+				})
+			}()
+		})
+	t.pages.RemovePage(tuiPageDialog)
+	t.pages.AddPage(tuiPageDialog, modal, true, true)
+}
+
+func (t *tui) closeDialog() {
+	t.pages.RemovePage(tuiPageDialog)
+	t.showPage(tuiPageWorkflow, tuiWorkflowHelp)
+}
+
+func (t *tui) showPage(name string, help string) {
+	t.pages.SwitchToPage(name)
+	t.setHelp(help)
+}
+
+func (t *tui) setHelp(help string) {
+	t.status.SetText(help)
+}
+
+func (t *tui) setStatus(text string) {
+	t.status.SetText(text)
+}
+
+func (t *tui) showError(msg string, err error) {
+	t.setStatus(fmt.Sprintf("[red]%s: %s", msg, tview.Escape(err.Error())))
+}
+
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+func setTableHeader(table *tview.Table, titles ...string) {
+	for i, title := range titles {
+		table.SetCell(0, i, tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+}
+
+func setTableRow(table *tview.Table, row int, values ...string) {
+	for i, value := range values {
+		table.SetCell(row, i, tview.NewTableCell(tview.Escape(value)).SetExpansion(1))
+	}
+}
+
+func eventNodeText(e *types.HistoryEvent) string {
+	return fmt.Sprintf("%d %s %s", e.ID, e.GetEventType(), timestampToString(e.GetTimestamp(), true))
+}
+
+func workflowStatus(w *types.WorkflowExecutionInfo) string {
+	if w.CloseStatus == nil {
+		return "RUNNING"
+	}
+	return w.CloseStatus.String()
+}
@@ -45,6 +45,12 @@ func newWorkflowCommands() []*cli.Command {
 			Flags:   flagsForExecution,
 			Action:  DiagnoseWorkflow,
 		},
+		{
+			Name:        "diagnose-batch",
+			Aliases:     []string{"diagb"},
+			Usage:       "diagnoses all workflows matching a query, and summarizes the common issues",
+			Subcommands: newDiagnosticsBatchCommands(),
+		},
 		{
 			Name:        "activity",
 			Aliases:     []string{"act"},
@@ -173,6 +179,20 @@ func newWorkflowCommands() []*cli.Command {
 			Flags:       getFlagsForDescribeID(),
 			Action:      DescribeWorkflowWithID,
 		},
+		{
+			Name:    "describe-async-request",
+			Aliases: []string{"desc-async"},
+			Usage:   "show the status of a request made with an async API, once it was consumed from the async workflow queue",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagRequestID,
+					Aliases:  []string{"rqid"},
+					Usage:    "RequestID returned by the async API",
+					Required: true,
+				},
+			},
+			Action: DescribeAsyncRequest,
+		},
 		{
 			Name:    "observe",
 			Aliases: []string{"ob"},
@@ -236,6 +256,16 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagSkipSignalReapply,
 					Usage: "whether or not skipping signals reapply after the reset point",
 				},
+				&cli.BoolFlag{
+					Name: FlagServerResetPoint,
+					Usage: "let the server resolve the reset point of resetType instead of the CLI. " +
+						"decision_offset is not supported then, and the server must be of a release that serves reset over JSON.",
+				},
+				&cli.BoolFlag{
+					Name: FlagDryRun,
+					Usage: "only print the reset point resolved by the server, the signals that would be reapplied and the activities that would be failed, " +
+						"without resetting the workflow. Implies server_reset_point.",
+				},
 			},
 			Action: ResetWorkflow,
 		},
@@ -419,6 +449,54 @@ func newActivityCommands() []*cli.Command {
 	}
 }
 
+func newDiagnosticsBatchCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "start",
+			Usage: "Start diagnosing workflows matching a query",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagListQuery,
+					Aliases: []string{"q"},
+					Usage:   "Query to get workflows to diagnose",
+				},
+				&cli.IntFlag{
+					Name:  FlagPageSize,
+					Value: 100,
+					Usage: "Number of workflows diagnosed per page",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Value: 10,
+					Usage: "Number of workflows diagnosed concurrently",
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxWorkflows,
+					Usage: "Optional limit of the number of workflows to diagnose, 0 means no limit",
+				},
+				&cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Optional flag to disable confirmation prompt",
+				},
+			},
+			Action: StartDiagnosticsBatchJob,
+		},
+		{
+			Name:    "describe",
+			Aliases: []string{"desc"},
+			Usage:   "Show the report of a diagnostics batch job",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagJobID,
+					Aliases: []string{"jid"},
+					Usage:   "Diagnostics batch job ID",
+				},
+			},
+			Action: DescribeDiagnosticsBatchJob,
+		},
+	}
+}
+
 func newBatchCommands() []*cli.Command {
 	return []*cli.Command{
 		{
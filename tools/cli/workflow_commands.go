@@ -1047,6 +1047,39 @@ func DescribeWorkflowWithID(c *cli.Context) error {
 	return describeWorkflowHelper(c, wid, rid)
 }
 
+// DescribeAsyncRequest shows the status of a request made with an async API
+func DescribeAsyncRequest(c *cli.Context) error {
+	frontendClient, err := getDeps(c).ServerFrontendJSONClient(c)
+	if err != nil {
+		return err
+	}
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	requestID, err := getRequiredOption(c, FlagRequestID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context: ", err)
+	}
+
+	resp, err := frontendClient.DescribeAsyncRequest(ctx, &types.DescribeAsyncRequestRequest{
+		Domain:    domain,
+		RequestID: requestID,
+	})
+	if err != nil {
+		return commoncli.Problem("Describe async request failed", err)
+	}
+
+	prettyPrintJSONObject(getDeps(c).Output(), resp)
+	return nil
+}
+
 func describeWorkflowHelper(c *cli.Context, wid, rid string) error {
 	frontendClient, err := getDeps(c).ServerFrontendClient(c)
 	if err != nil {
@@ -1839,6 +1872,21 @@ func ResetWorkflow(c *cli.Context) error {
 		}
 	}
 
+	if c.Bool(FlagServerResetPoint) || c.Bool(FlagDryRun) {
+		return resetWorkflowOnServer(ctx, c, &types.ResetWorkflowExecutionRequest{
+			Domain: domain,
+			WorkflowExecution: &types.WorkflowExecution{
+				WorkflowID: wid,
+				RunID:      rid,
+			},
+			Reason:                fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), reason),
+			DecisionFinishEventID: eventID,
+			RequestID:             uuid.New(),
+			SkipSignalReapply:     c.Bool(FlagSkipSignalReapply),
+			DryRun:                c.Bool(FlagDryRun),
+		}, resetType, decisionOffset)
+	}
+
 	resetBaseRunID := rid
 	decisionFinishID := eventID
 	if resetType != "" {
@@ -1865,6 +1913,39 @@ func ResetWorkflow(c *cli.Context) error {
 	return nil
 }
 
+// resetWorkflowOnServer lets the server resolve the reset point of the reset type.
+// The server takes these requests as JSON, cadence-idl does not define the reset type and dry-run.
+func resetWorkflowOnServer(ctx context.Context, c *cli.Context, request *types.ResetWorkflowExecutionRequest, resetType string, decisionOffset int) error {
+	if resetType != "" {
+		if decisionOffset != 0 {
+			return commoncli.Problem("Decision offset is not supported when the server resolves the reset point", nil)
+		}
+		request.DecisionFinishEventID = 0
+		request.ResetType = serverResetTypes[resetType].Ptr()
+		switch resetType {
+		case resetTypeBadBinary:
+			request.BadBinaryChecksum = c.String(FlagResetBadBinaryChecksum)
+		case resetTypeDecisionCompletedTime:
+			earliestTime, err := parseTime(c.String(FlagEarliestTime), 0)
+			if err != nil {
+				return commoncli.Problem("Error parsing earliest time: ", err)
+			}
+			request.ResetTimestamp = common.Int64Ptr(earliestTime)
+		}
+	}
+
+	frontendClient, err := getDeps(c).ServerFrontendJSONClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := frontendClient.ResetWorkflowExecution(ctx, request)
+	if err != nil {
+		return commoncli.Problem("reset failed", err)
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), resp)
+	return nil
+}
+
 func processResets(c *cli.Context, domain string, wes chan types.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType) {
 	for {
 		select {
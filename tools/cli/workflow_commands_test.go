@@ -171,6 +171,71 @@ func Test_DescribeWorkflow(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_DescribeAsyncRequest(t *testing.T) {
+	const requestID = "test-request-id"
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData) *cli.Context
+		errContains    string // empty if no error is expected
+		outputContains string
+	}{
+		{
+			name: "no request ID",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDomain, testDomain))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "DescribeAsyncRequest returns an error",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockFrontendJSONClient.EXPECT().DescribeAsyncRequest(gomock.Any(), gomock.Any()).
+					Return(nil, &types.EntityNotExistsError{Message: "not recorded"})
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagRequestID, requestID),
+				)
+			},
+			errContains: "Describe async request failed",
+		},
+		{
+			name: "success",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockFrontendJSONClient.EXPECT().DescribeAsyncRequest(gomock.Any(), &types.DescribeAsyncRequestRequest{
+					Domain:    testDomain,
+					RequestID: requestID,
+				}).Return(&types.DescribeAsyncRequestResponse{
+					Status:            "started",
+					WorkflowExecution: &types.WorkflowExecution{WorkflowID: "test-workflow-id", RunID: "test-run-id"},
+				}, nil)
+				return clitest.NewCLIContext(
+					t,
+					td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagRequestID, requestID),
+				)
+			},
+			outputContains: `"runId": "test-run-id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			err := DescribeAsyncRequest(tt.testSetup(td))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, td.consoleOutput(), `"status": "started"`)
+			assert.Contains(t, td.consoleOutput(), tt.outputContains)
+		})
+	}
+}
+
 func Test_DescribeWorkflowWithID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	serverFrontendClient := frontend.NewMockClient(mockCtrl)
@@ -637,6 +702,101 @@ func Test_ResetWorkflow(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_ResetWorkflow_ServerResetPoint(t *testing.T) {
+	execution := &types.WorkflowExecution{WorkflowID: "test-workflow-id", RunID: "test-run-id"}
+	tests := []struct {
+		name            string
+		args            []clitest.CliArgument
+		expectedRequest *types.ResetWorkflowExecutionRequest
+		errContains     string // empty if no error is expected
+	}{
+		{
+			name: "reset type",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagResetType, resetTypeLastDecisionCompleted),
+				clitest.BoolArgument(FlagServerResetPoint, true),
+			},
+			expectedRequest: &types.ResetWorkflowExecutionRequest{ResetType: types.ResetTypeLastDecisionCompleted.Ptr()},
+		},
+		{
+			name: "bad binary dry-run",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagResetType, resetTypeBadBinary),
+				clitest.StringArgument(FlagResetBadBinaryChecksum, "test-checksum"),
+				clitest.BoolArgument(FlagDryRun, true),
+			},
+			expectedRequest: &types.ResetWorkflowExecutionRequest{
+				ResetType:         types.ResetTypeBadBinary.Ptr(),
+				BadBinaryChecksum: "test-checksum",
+				DryRun:            true,
+			},
+		},
+		{
+			name: "decision completed time",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagResetType, resetTypeDecisionCompletedTime),
+				clitest.StringArgument(FlagEarliestTime, "1000"),
+				clitest.BoolArgument(FlagServerResetPoint, true),
+			},
+			expectedRequest: &types.ResetWorkflowExecutionRequest{
+				ResetType:      types.ResetTypeDecisionCompletedTime.Ptr(),
+				ResetTimestamp: common.Int64Ptr(1000),
+			},
+		},
+		{
+			name: "event ID dry-run",
+			args: []clitest.CliArgument{
+				clitest.Int64Argument(FlagEventID, 4),
+				clitest.BoolArgument(FlagDryRun, true),
+			},
+			expectedRequest: &types.ResetWorkflowExecutionRequest{DecisionFinishEventID: 4, DryRun: true},
+		},
+		{
+			name: "decision offset",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagResetType, resetTypeLastDecisionCompleted),
+				clitest.IntArgument(FlagDecisionOffset, -1),
+				clitest.BoolArgument(FlagServerResetPoint, true),
+			},
+			errContains: "Decision offset is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			if tt.expectedRequest != nil {
+				td.mockFrontendJSONClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *types.ResetWorkflowExecutionRequest, _ ...interface{}) (*types.ResetWorkflowExecutionResponse, error) {
+						assert.Equal(t, testDomain, request.Domain)
+						assert.Equal(t, execution, request.WorkflowExecution)
+						assert.Equal(t, tt.expectedRequest.DecisionFinishEventID, request.DecisionFinishEventID)
+						assert.Equal(t, tt.expectedRequest.ResetType, request.ResetType)
+						assert.Equal(t, tt.expectedRequest.ResetTimestamp, request.ResetTimestamp)
+						assert.Equal(t, tt.expectedRequest.BadBinaryChecksum, request.BadBinaryChecksum)
+						assert.Equal(t, tt.expectedRequest.DryRun, request.DryRun)
+						return &types.ResetWorkflowExecutionResponse{
+							DryRunResult: &types.ResetWorkflowDryRunResult{BaseRunID: "test-run-id", DecisionFinishEventID: 4},
+						}, nil
+					})
+			}
+			args := append([]clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.StringArgument(FlagWorkflowID, execution.WorkflowID),
+				clitest.StringArgument(FlagRunID, execution.RunID),
+				clitest.StringArgument(FlagReason, "test"),
+			}, tt.args...)
+			err := ResetWorkflow(clitest.NewCLIContext(t, td.app, args...))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, td.consoleOutput(), `"decisionFinishEventId": 4`)
+		})
+	}
+}
+
 func Test_ResetWorkflow_Invalid_Decision_Offset(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	serverFrontendClient := frontend.NewMockClient(mockCtrl)
@@ -0,0 +1,159 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/service/worker/diagnostics"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// StartDiagnosticsBatchJob starts diagnosing all workflows matching a query
+func StartDiagnosticsBatchJob(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	query, err := getRequiredOption(c, FlagListQuery)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+
+	resp, err := svcClient.CountWorkflowExecutions(
+		tcCtx,
+		&types.CountWorkflowExecutionsRequest{
+			Domain: domain,
+			Query:  query,
+		},
+	)
+	if err != nil {
+		return commoncli.Problem("Failed to count workflows for starting a diagnostics batch job", err)
+	}
+	fmt.Printf("This diagnostics batch job will be diagnosing %v workflows.\n", resp.GetCount())
+	if !c.Bool(FlagYes) {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Print("Please confirm[Yes/No]:")
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return commoncli.Problem("Failed to get confirmation for starting a diagnostics batch job", err)
+		}
+		if !strings.EqualFold(strings.TrimSpace(text), "yes") {
+			fmt.Println("Diagnostics batch job is not started")
+			return nil
+		}
+	}
+	tcCtx, cancel, err = newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+
+	input, err := json.Marshal(diagnostics.DiagnosticsBatchWorkflowInput{
+		Domain:       domain,
+		Query:        query,
+		PageSize:     c.Int(FlagPageSize),
+		Concurrency:  c.Int(FlagConcurrency),
+		MaxWorkflows: c.Int(FlagMaxWorkflows),
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to encode diagnostics batch job parameters", err)
+	}
+	workflowID := uuid.NewRandom().String()
+	_, err = svcClient.StartWorkflowExecution(tcCtx, &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.SystemLocalDomainName,
+		RequestID:                           uuid.New(),
+		WorkflowID:                          workflowID,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(batcher.InfiniteDuration.Seconds())),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(defaultDecisionTimeoutInSeconds)),
+		TaskList:                            &types.TaskList{Name: diagnostics.DiagnosticsTaskListName},
+		WorkflowType:                        &types.WorkflowType{Name: diagnostics.DiagnosticsBatchWorkflowTypeName},
+		Input:                               input,
+		Identity:                            getCliIdentity(),
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to start diagnostics batch job", err)
+	}
+	output := map[string]interface{}{
+		"msg":   "diagnostics batch job is started",
+		"jobID": workflowID,
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), output)
+	return nil
+}
+
+// DescribeDiagnosticsBatchJob prints the report of a diagnostics batch job, which is partial while it is running
+func DescribeDiagnosticsBatchJob(c *cli.Context) error {
+	jobID, err := getRequiredOption(c, FlagJobID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+
+	resp, err := svcClient.QueryWorkflow(tcCtx, &types.QueryWorkflowRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: jobID,
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: diagnostics.QueryDiagnosticsBatchReport,
+		},
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to describe diagnostics batch job", err)
+	}
+	var report diagnostics.DiagnosticsBatchReport
+	if err := json.Unmarshal(resp.GetQueryResult(), &report); err != nil {
+		return commoncli.Problem("Failed to decode diagnostics batch report", err)
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), report)
+	return nil
+}
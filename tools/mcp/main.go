@@ -26,18 +26,39 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path"
-	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/uber/cadence/tools/mcp/tools"
 )
 
 func main() {
+	var (
+		clientConfig tools.ClientConfig
+		opts         tools.Options
+	)
+	flag.StringVar(&clientConfig.Address, "address", "localhost:7833", "gRPC address of the Cadence frontend")
+	flag.StringVar(&clientConfig.TLSCACertPath, "tls_cert_path", "", "path to the CA certificate of the frontend, enables TLS")
+	flag.StringVar(&clientConfig.JWT, "jwt", os.Getenv("CADENCE_CLI_JWT"), "JWT passed as the authorization token, defaults to $CADENCE_CLI_JWT")
+	flag.BoolVar(&opts.AllowWrites, "allow_writes", false, "enable tools changing state of workflows (signal and cancellation request)")
+	flag.StringVar(&opts.Identity, "identity", defaultIdentity(), "identity of the requests made by the tools")
+	flag.DurationVar(&opts.Timeout, "timeout", 10*time.Second, "timeout of requests to the frontend")
+	flag.Parse()
+
+	client, stop, err := tools.NewFrontendClient(clientConfig)
+	if err != nil {
+		debugLog("Failed to create frontend client: %v", err)
+		os.Exit(1)
+	}
+	defer stop()
 
 	// Create MCP server
 	s := server.NewMCPServer(
@@ -47,17 +68,7 @@ func main() {
 	)
 
 	// Add tool handlers
-	s.AddTool(mcp.NewTool("domain_rr",
-		mcp.WithDescription("Check if a cadence domain is resilient to regional outages"),
-		mcp.WithString("domain",
-			mcp.Required(),
-			mcp.Description("Name of the cadence domain to check"),
-		),
-		mcp.WithString("grpc_endpoint",
-			mcp.DefaultString("localhost:7833"),
-			mcp.Description("gRPC endpoint of the cadence domain"),
-		),
-	), domainRRHandler)
+	tools.Register(s, client, opts)
 
 	s.AddTool(mcp.NewTool("payload_decoder",
 		mcp.WithDescription("Decode a payload that is encoded by hex or base64. The payload is from Cadence database."),
@@ -67,7 +78,7 @@ func main() {
 		),
 	), payloadDecoderHandler)
 
-	debugLog("Cadence MCP started")
+	debugLog("Cadence MCP started, frontend: %s, writes allowed: %v", clientConfig.Address, opts.AllowWrites)
 
 	// Start the stdio server
 	if err := server.ServeStdio(s); err != nil {
@@ -77,47 +88,12 @@ func main() {
 	debugLog("Cadence MCP stopped")
 }
 
-func domainRRHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	defer func() {
-		// recover from panic
-		if r := recover(); r != nil {
-			// include the stack trace
-			debugLog("Panic: %v\n", r)
-			debugLog("Stack trace: %s\n", string(debug.Stack()))
-		}
-	}()
-
-	domain, ok := request.Params.Arguments["domain"].(string)
-	if !ok {
-		return nil, errors.New("domain must be a string")
-	}
-
-	endpoint, ok := request.Params.Arguments["grpc_endpoint"].(string)
-	if !ok {
-		endpoint = "localhost:7833"
-	}
-
-	// run cadence CLI to check if it's a global domain or not
-	cmd := exec.Command("docker", "run", "-t", "--rm", "--network", "host", "ubercadence/cli:master",
-		"--transport", "grpc",
-		"--address", endpoint,
-		"--domain", domain,
-		"domain", "describe")
-	// run the cmd and capture both stdout and stderr
-	output, err := cmd.CombinedOutput()
+func defaultIdentity() string {
+	hostname, err := os.Hostname()
 	if err != nil {
-		debugLog("Error checking domain resilience: %v, %s\n", err, string(output))
-		return mcp.NewToolResultError("Error checking domain resilience: " + err.Error() + "\n" + string(output)), nil
-	}
-
-	// parse the output of the cadence CLI
-	// if it contains "IsGlobal(XDC)Domain: true" then it's a global domain
-	// otherwise it's not
-	if strings.Contains(string(output), "IsGlobal(XDC)Domain: true") {
-		return mcp.NewToolResultText("Yes, this domain is resilient to regional outages"), nil
+		hostname = "unknown"
 	}
-
-	return mcp.NewToolResultText("No, this domain is not resilient to regional outages. Consider making it a global domain."), nil
+	return "cadence-mcp@" + hostname
 }
 
 func payloadDecoderHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -0,0 +1,117 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tools
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/transport/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/uber/cadence/client/frontend"
+	grpcClient "github.com/uber/cadence/client/wrappers/grpc"
+	"github.com/uber/cadence/common"
+	cc "github.com/uber/cadence/common/client"
+	"github.com/uber/cadence/common/service"
+)
+
+const clientName = "cadence-mcp"
+
+// ClientConfig configures the connection to the frontend
+type ClientConfig struct {
+	// Address is the gRPC address of the frontend
+	Address string
+	// TLSCACertPath is the path to the CA certificate of the frontend, TLS is disabled if empty
+	TLSCACertPath string
+	// JWT is passed as the authorization token of the requests, if set
+	JWT string
+}
+
+// NewFrontendClient creates a gRPC frontend client. Returned function stops the underlying dispatcher.
+func NewFrontendClient(cfg ClientConfig) (frontend.Client, func() error, error) {
+	if cfg.Address == "" {
+		return nil, nil, errors.New("frontend address is not set")
+	}
+
+	grpcTransport := grpc.NewTransport()
+	outbound := grpcTransport.NewSingleOutbound(cfg.Address)
+	if cfg.TLSCACertPath != "" {
+		caCert, err := os.ReadFile(cfg.TLSCACertPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading CA certificate: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, nil, fmt.Errorf("no certificates found in %s", cfg.TLSCACertPath)
+		}
+		creds := credentials.NewTLS(&tls.Config{RootCAs: caCertPool})
+		chooser := peer.NewSingle(hostport.Identify(cfg.Address), grpcTransport.NewDialer(grpc.DialerCredentials(creds)))
+		outbound = grpcTransport.NewOutbound(chooser)
+	}
+
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name:      clientName,
+		Outbounds: yarpc.Outbounds{service.Frontend: {Unary: outbound}},
+		OutboundMiddleware: yarpc.OutboundMiddleware{
+			Unary: &headersMiddleware{jwt: cfg.JWT},
+		},
+	})
+	if err := dispatcher.Start(); err != nil {
+		return nil, nil, fmt.Errorf("starting dispatcher: %w", err)
+	}
+
+	clientConfig := dispatcher.ClientConfig(service.Frontend)
+	client := grpcClient.NewFrontendClient(
+		apiv1.NewDomainAPIYARPCClient(clientConfig),
+		apiv1.NewWorkflowAPIYARPCClient(clientConfig),
+		apiv1.NewWorkerAPIYARPCClient(clientConfig),
+		apiv1.NewVisibilityAPIYARPCClient(clientConfig),
+	)
+	return client, dispatcher.Stop, nil
+}
+
+// headersMiddleware identifies requests the same way as the CLI does, so they pass the client version check
+type headersMiddleware struct {
+	jwt string
+}
+
+func (m *headersMiddleware) Call(ctx context.Context, request *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	request.Headers = request.Headers.
+		With(common.ClientImplHeaderName, cc.CLI).
+		With(common.FeatureVersionHeaderName, cc.SupportedCLIVersion).
+		With(common.ClientFeatureFlagsHeaderName, cc.FeatureFlagsHeader(cc.DefaultCLIFeatureFlags))
+	if m.jwt != "" {
+		request.Headers = request.Headers.With(common.AuthorizationTokenHeaderName, m.jwt)
+	}
+	return out.Call(ctx, request)
+}
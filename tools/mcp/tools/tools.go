@@ -0,0 +1,496 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package tools implements the Cadence tools exposed by the MCP server.
+//
+// Tools are read-only by default. Tools changing the state of workflows are only registered
+// when explicitly allowed, and are limited to operations that workflows are expected to handle:
+// signals and cancellation requests. Terminate and reset are deliberately not exposed.
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/pborman/uuid"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	defaultTimeout         = 10 * time.Second
+	defaultWorkflowsPage   = 20
+	defaultHistoryPage     = 100
+	diagnosticsReportQuery = "query-diagnostics-report" // query type of the diagnostics workflow, see service/worker/diagnostics
+)
+
+// Options configures the tools
+type Options struct {
+	// AllowWrites registers tools changing the state of workflows
+	AllowWrites bool
+	// Identity is sent as the identity of requests made by the tools
+	Identity string
+	// Timeout of a single request to the frontend
+	Timeout time.Duration
+}
+
+type (
+	handlers struct {
+		client frontend.Client
+		opts   Options
+	}
+
+	tool struct {
+		tool    mcp.Tool
+		handler server.ToolHandlerFunc
+		write   bool
+	}
+
+	// arguments of a tool call
+	arguments map[string]interface{}
+)
+
+// Register adds the Cadence tools to the MCP server
+func Register(s *server.MCPServer, client frontend.Client, opts Options) {
+	for _, t := range newHandlers(client, opts).tools() {
+		s.AddTool(t.tool, t.handler)
+	}
+}
+
+func newHandlers(client frontend.Client, opts Options) *handlers {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	return &handlers{client: client, opts: opts}
+}
+
+func (h *handlers) tools() []tool {
+	all := []tool{
+		{
+			tool: mcp.NewTool("describe_domain",
+				mcp.WithDescription("Describe a Cadence domain: status, owner, retention, archival, clusters and failover settings"),
+				domainArgument(),
+			),
+			handler: h.call(h.describeDomain),
+		},
+		{
+			tool: mcp.NewTool("domain_rr",
+				mcp.WithDescription("Check if a Cadence domain is resilient to regional outages"),
+				domainArgument(),
+			),
+			handler: h.call(h.domainRR),
+		},
+		{
+			tool: mcp.NewTool("list_workflows",
+				mcp.WithDescription("List workflow executions of a domain matching a visibility query"),
+				domainArgument(),
+				mcp.WithString("query",
+					mcp.Description("Visibility query, e.g. WorkflowType = 'MyWorkflow' AND CloseTime = missing. All workflows if empty"),
+				),
+				mcp.WithNumber("page_size",
+					mcp.DefaultNumber(defaultWorkflowsPage),
+					mcp.Description("Maximum number of workflows to return"),
+				),
+				nextPageTokenArgument(),
+			),
+			handler: h.call(h.listWorkflows),
+		},
+		{
+			tool: mcp.NewTool("describe_workflow",
+				mcp.WithDescription("Describe a workflow execution: status, pending activities, pending children and pending decision"),
+				domainArgument(),
+				workflowIDArgument(),
+				runIDArgument(),
+			),
+			handler: h.call(h.describeWorkflow),
+		},
+		{
+			tool: mcp.NewTool("get_workflow_history",
+				mcp.WithDescription("Get a page of history events of a workflow execution"),
+				domainArgument(),
+				workflowIDArgument(),
+				runIDArgument(),
+				mcp.WithNumber("page_size",
+					mcp.DefaultNumber(defaultHistoryPage),
+					mcp.Description("Maximum number of events to return"),
+				),
+				nextPageTokenArgument(),
+			),
+			handler: h.call(h.getWorkflowHistory),
+		},
+		{
+			tool: mcp.NewTool("diagnose_workflow",
+				mcp.WithDescription("Start diagnostics of a workflow execution, e.g. for timeouts and failures. "+
+					"Returns the diagnostics workflow, use get_diagnostics_report to fetch its report"),
+				domainArgument(),
+				workflowIDArgument(),
+				mcp.WithString("run_id",
+					mcp.Required(),
+					mcp.Description("Run ID of the workflow execution"),
+				),
+			),
+			handler: h.call(h.diagnoseWorkflow),
+		},
+		{
+			tool: mcp.NewTool("get_diagnostics_report",
+				mcp.WithDescription("Get the report of a diagnostics workflow started by diagnose_workflow"),
+				mcp.WithString("domain",
+					mcp.Required(),
+					mcp.Description("Domain of the diagnostics workflow, as returned by diagnose_workflow"),
+				),
+				workflowIDArgument(),
+				mcp.WithString("run_id",
+					mcp.Required(),
+					mcp.Description("Run ID of the diagnostics workflow"),
+				),
+			),
+			handler: h.call(h.getDiagnosticsReport),
+		},
+		{
+			tool: mcp.NewTool("describe_task_list",
+				mcp.WithDescription("Describe pollers, backlog and partitions of a task list"),
+				domainArgument(),
+				mcp.WithString("task_list",
+					mcp.Required(),
+					mcp.Description("Name of the task list"),
+				),
+				mcp.WithString("task_list_type",
+					mcp.DefaultString("decision"),
+					mcp.Description("Type of the task list: decision or activity"),
+				),
+			),
+			handler: h.call(h.describeTaskList),
+		},
+		{
+			tool: mcp.NewTool("signal_workflow",
+				mcp.WithDescription("Send a signal to a running workflow execution"),
+				domainArgument(),
+				workflowIDArgument(),
+				runIDArgument(),
+				mcp.WithString("signal_name",
+					mcp.Required(),
+					mcp.Description("Name of the signal"),
+				),
+				mcp.WithString("input",
+					mcp.Description("Input of the signal, usually JSON"),
+				),
+			),
+			handler: h.call(h.signalWorkflow),
+			write:   true,
+		},
+		{
+			tool: mcp.NewTool("cancel_workflow",
+				mcp.WithDescription("Request cancellation of a workflow execution. The workflow can handle the request, it is not terminated"),
+				domainArgument(),
+				workflowIDArgument(),
+				runIDArgument(),
+				mcp.WithString("reason",
+					mcp.Description("Reason of the cancellation"),
+				),
+			),
+			handler: h.call(h.cancelWorkflow),
+			write:   true,
+		},
+	}
+
+	var tools []tool
+	for _, t := range all {
+		if !t.write || h.opts.AllowWrites {
+			tools = append(tools, t)
+		}
+	}
+	return tools
+}
+
+// call adapts a handler to the MCP handler. Errors are returned as error results, so the model can see them.
+func (h *handlers) call(fn func(context.Context, arguments) (interface{}, error)) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+		defer cancel()
+
+		result, err := fn(ctx, request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if text, ok := result.(string); ok {
+			return mcp.NewToolResultText(text), nil
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to encode result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (h *handlers) describeDomain(ctx context.Context, args arguments) (interface{}, error) {
+	domain, err := args.requiredString("domain")
+	if err != nil {
+		return nil, err
+	}
+	return h.client.DescribeDomain(ctx, &types.DescribeDomainRequest{Name: common.StringPtr(domain)})
+}
+
+func (h *handlers) domainRR(ctx context.Context, args arguments) (interface{}, error) {
+	domain, err := args.requiredString("domain")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.DescribeDomain(ctx, &types.DescribeDomainRequest{Name: common.StringPtr(domain)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetIsGlobalDomain() {
+		return "Yes, this domain is resilient to regional outages", nil
+	}
+	return "No, this domain is not resilient to regional outages. Consider making it a global domain.", nil
+}
+
+func (h *handlers) listWorkflows(ctx context.Context, args arguments) (interface{}, error) {
+	domain, err := args.requiredString("domain")
+	if err != nil {
+		return nil, err
+	}
+	token, err := args.bytes("next_page_token")
+	if err != nil {
+		return nil, err
+	}
+	return h.client.ListWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+		Domain:        domain,
+		PageSize:      args.int32("page_size", defaultWorkflowsPage),
+		NextPageToken: token,
+		Query:         args.string("query"),
+	})
+}
+
+func (h *handlers) describeWorkflow(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	return h.client.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    domain,
+		Execution: execution,
+	})
+}
+
+func (h *handlers) getWorkflowHistory(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	token, err := args.bytes("next_page_token")
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+		Domain:          domain,
+		Execution:       execution,
+		MaximumPageSize: args.int32("page_size", defaultHistoryPage),
+		NextPageToken:   token,
+	})
+}
+
+func (h *handlers) diagnoseWorkflow(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	if execution.RunID == "" {
+		return nil, errors.New("run_id is required")
+	}
+	return h.client.DiagnoseWorkflowExecution(ctx, &types.DiagnoseWorkflowExecutionRequest{
+		Domain:            domain,
+		WorkflowExecution: execution,
+		Identity:          h.opts.Identity,
+	})
+}
+
+func (h *handlers) getDiagnosticsReport(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.QueryWorkflow(ctx, &types.QueryWorkflowRequest{
+		Domain:    domain,
+		Execution: execution,
+		Query:     &types.WorkflowQuery{QueryType: diagnosticsReportQuery},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return string(resp.GetQueryResult()), nil
+}
+
+func (h *handlers) describeTaskList(ctx context.Context, args arguments) (interface{}, error) {
+	domain, err := args.requiredString("domain")
+	if err != nil {
+		return nil, err
+	}
+	taskList, err := args.requiredString("task_list")
+	if err != nil {
+		return nil, err
+	}
+	var taskListType types.TaskListType
+	switch value := args.string("task_list_type"); value {
+	case "", "decision":
+		taskListType = types.TaskListTypeDecision
+	case "activity":
+		taskListType = types.TaskListTypeActivity
+	default:
+		return nil, fmt.Errorf("invalid task_list_type %q, expected decision or activity", value)
+	}
+	return h.client.DescribeTaskList(ctx, &types.DescribeTaskListRequest{
+		Domain:                domain,
+		TaskList:              &types.TaskList{Name: taskList},
+		TaskListType:          taskListType.Ptr(),
+		IncludeTaskListStatus: true,
+	})
+}
+
+func (h *handlers) signalWorkflow(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	signalName, err := args.requiredString("signal_name")
+	if err != nil {
+		return nil, err
+	}
+	var input []byte
+	if value := args.string("input"); value != "" {
+		input = []byte(value)
+	}
+	err = h.client.SignalWorkflowExecution(ctx, &types.SignalWorkflowExecutionRequest{
+		Domain:            domain,
+		WorkflowExecution: execution,
+		SignalName:        signalName,
+		Input:             input,
+		Identity:          h.opts.Identity,
+		RequestID:         uuid.New(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return "Signal sent", nil
+}
+
+func (h *handlers) cancelWorkflow(ctx context.Context, args arguments) (interface{}, error) {
+	domain, execution, err := args.workflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	err = h.client.RequestCancelWorkflowExecution(ctx, &types.RequestCancelWorkflowExecutionRequest{
+		Domain:            domain,
+		WorkflowExecution: execution,
+		Identity:          h.opts.Identity,
+		RequestID:         uuid.New(),
+		Cause:             args.string("reason"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return "Cancellation requested", nil
+}
+
+func domainArgument() mcp.ToolOption {
+	return mcp.WithString("domain",
+		mcp.Required(),
+		mcp.Description("Name of the Cadence domain"),
+	)
+}
+
+func workflowIDArgument() mcp.ToolOption {
+	return mcp.WithString("workflow_id",
+		mcp.Required(),
+		mcp.Description("Workflow ID of the workflow execution"),
+	)
+}
+
+func runIDArgument() mcp.ToolOption {
+	return mcp.WithString("run_id",
+		mcp.Description("Run ID of the workflow execution, the latest run if empty"),
+	)
+}
+
+func nextPageTokenArgument() mcp.ToolOption {
+	return mcp.WithString("next_page_token",
+		mcp.Description("nextPageToken from the previous response, to get the next page"),
+	)
+}
+
+func (a arguments) string(name string) string {
+	value, _ := a[name].(string)
+	return value
+}
+
+func (a arguments) requiredString(name string) (string, error) {
+	value := a.string(name)
+	if value == "" {
+		return "", fmt.Errorf("%s is required", name)
+	}
+	return value, nil
+}
+
+// int32 returns the number argument, JSON numbers are decoded as float64
+func (a arguments) int32(name string, defaultValue int32) int32 {
+	if value, ok := a[name].(float64); ok && value > 0 {
+		return int32(value)
+	}
+	return defaultValue
+}
+
+// bytes decodes base64 argument, matching how []byte fields are encoded in the results
+func (a arguments) bytes(name string) ([]byte, error) {
+	value := a.string(name)
+	if value == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid token: %w", name, err)
+	}
+	return decoded, nil
+}
+
+func (a arguments) workflowExecution() (string, *types.WorkflowExecution, error) {
+	domain, err := a.requiredString("domain")
+	if err != nil {
+		return "", nil, err
+	}
+	workflowID, err := a.requiredString("workflow_id")
+	if err != nil {
+		return "", nil, err
+	}
+	return domain, &types.WorkflowExecution{
+		WorkflowID: workflowID,
+		RunID:      a.string("run_id"),
+	}, nil
+}
@@ -0,0 +1,284 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	testDomain     = "test-domain"
+	testWorkflowID = "test-workflow"
+	testRunID      = "test-run"
+	testIdentity   = "test-identity"
+)
+
+func TestToolsAllowWrites(t *testing.T) {
+	readOnly := []string{
+		"describe_domain", "domain_rr", "list_workflows", "describe_workflow",
+		"get_workflow_history", "diagnose_workflow", "get_diagnostics_report", "describe_task_list",
+	}
+
+	assert.ElementsMatch(t, readOnly, toolNames(newHandlers(nil, Options{})))
+	assert.ElementsMatch(t, append(readOnly, "signal_workflow", "cancel_workflow"), toolNames(newHandlers(nil, Options{AllowWrites: true})))
+}
+
+func TestTools(t *testing.T) {
+	token := []byte("token")
+	tests := []struct {
+		desc      string
+		tool      string
+		args      map[string]interface{}
+		mockFn    func(*frontend.MockClient)
+		wantText  string
+		wantError string
+	}{
+		{
+			desc: "describe domain",
+			tool: "describe_domain",
+			args: map[string]interface{}{"domain": testDomain},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr(testDomain)}).
+					Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: testDomain}}, nil)
+			},
+			wantText: `"name": "test-domain"`,
+		},
+		{
+			desc:      "describe domain without domain",
+			tool:      "describe_domain",
+			args:      map[string]interface{}{},
+			wantError: "domain is required",
+		},
+		{
+			desc: "describe domain failure",
+			tool: "describe_domain",
+			args: map[string]interface{}{"domain": testDomain},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+					Return(nil, &types.EntityNotExistsError{Message: "domain does not exist"})
+			},
+			wantError: "domain does not exist",
+		},
+		{
+			desc: "global domain is resilient",
+			tool: "domain_rr",
+			args: map[string]interface{}{"domain": testDomain},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+					Return(&types.DescribeDomainResponse{IsGlobalDomain: true}, nil)
+			},
+			wantText: "Yes, this domain is resilient to regional outages",
+		},
+		{
+			desc: "local domain is not resilient",
+			tool: "domain_rr",
+			args: map[string]interface{}{"domain": testDomain},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+					Return(&types.DescribeDomainResponse{}, nil)
+			},
+			wantText: "No, this domain is not resilient to regional outages",
+		},
+		{
+			desc: "list workflows",
+			tool: "list_workflows",
+			args: map[string]interface{}{
+				"domain":          testDomain,
+				"query":           "CloseTime = missing",
+				"page_size":       float64(5),
+				"next_page_token": base64.StdEncoding.EncodeToString(token),
+			},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().ListWorkflowExecutions(gomock.Any(), &types.ListWorkflowExecutionsRequest{
+					Domain:        testDomain,
+					PageSize:      5,
+					NextPageToken: token,
+					Query:         "CloseTime = missing",
+				}).Return(&types.ListWorkflowExecutionsResponse{}, nil)
+			},
+			wantText: "{}",
+		},
+		{
+			desc:      "list workflows with invalid token",
+			tool:      "list_workflows",
+			args:      map[string]interface{}{"domain": testDomain, "next_page_token": "not base64!"},
+			wantError: "next_page_token is not a valid token",
+		},
+		{
+			desc: "get workflow history",
+			tool: "get_workflow_history",
+			args: map[string]interface{}{"domain": testDomain, "workflow_id": testWorkflowID},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), &types.GetWorkflowExecutionHistoryRequest{
+					Domain:          testDomain,
+					Execution:       &types.WorkflowExecution{WorkflowID: testWorkflowID},
+					MaximumPageSize: defaultHistoryPage,
+				}).Return(&types.GetWorkflowExecutionHistoryResponse{}, nil)
+			},
+			wantText: "{}",
+		},
+		{
+			desc: "diagnose workflow",
+			tool: "diagnose_workflow",
+			args: map[string]interface{}{"domain": testDomain, "workflow_id": testWorkflowID, "run_id": testRunID},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DiagnoseWorkflowExecution(gomock.Any(), &types.DiagnoseWorkflowExecutionRequest{
+					Domain:            testDomain,
+					WorkflowExecution: &types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID},
+					Identity:          testIdentity,
+				}).Return(&types.DiagnoseWorkflowExecutionResponse{Domain: constants.SystemLocalDomainName}, nil)
+			},
+			wantText: constants.SystemLocalDomainName,
+		},
+		{
+			desc:      "diagnose workflow without run id",
+			tool:      "diagnose_workflow",
+			args:      map[string]interface{}{"domain": testDomain, "workflow_id": testWorkflowID},
+			wantError: "run_id is required",
+		},
+		{
+			desc: "get diagnostics report",
+			tool: "get_diagnostics_report",
+			args: map[string]interface{}{"domain": constants.SystemLocalDomainName, "workflow_id": testWorkflowID, "run_id": testRunID},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().QueryWorkflow(gomock.Any(), &types.QueryWorkflowRequest{
+					Domain:    constants.SystemLocalDomainName,
+					Execution: &types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID},
+					Query:     &types.WorkflowQuery{QueryType: diagnosticsReportQuery},
+				}).Return(&types.QueryWorkflowResponse{QueryResult: []byte(`{"issues": []}`)}, nil)
+			},
+			wantText: `{"issues": []}`,
+		},
+		{
+			desc: "describe activity task list",
+			tool: "describe_task_list",
+			args: map[string]interface{}{"domain": testDomain, "task_list": "test-tasklist", "task_list_type": "activity"},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().DescribeTaskList(gomock.Any(), &types.DescribeTaskListRequest{
+					Domain:                testDomain,
+					TaskList:              &types.TaskList{Name: "test-tasklist"},
+					TaskListType:          types.TaskListTypeActivity.Ptr(),
+					IncludeTaskListStatus: true,
+				}).Return(&types.DescribeTaskListResponse{}, nil)
+			},
+			wantText: "{}",
+		},
+		{
+			desc:      "describe task list with invalid type",
+			tool:      "describe_task_list",
+			args:      map[string]interface{}{"domain": testDomain, "task_list": "test-tasklist", "task_list_type": "unknown"},
+			wantError: `invalid task_list_type "unknown"`,
+		},
+		{
+			desc: "signal workflow",
+			tool: "signal_workflow",
+			args: map[string]interface{}{"domain": testDomain, "workflow_id": testWorkflowID, "signal_name": "test-signal", "input": `{"a": 1}`},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *types.SignalWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+						assert.Equal(t, testDomain, request.Domain)
+						assert.Equal(t, &types.WorkflowExecution{WorkflowID: testWorkflowID}, request.WorkflowExecution)
+						assert.Equal(t, "test-signal", request.SignalName)
+						assert.Equal(t, []byte(`{"a": 1}`), request.Input)
+						assert.Equal(t, testIdentity, request.Identity)
+						assert.NotEmpty(t, request.RequestID)
+						return nil
+					})
+			},
+			wantText: "Signal sent",
+		},
+		{
+			desc: "cancel workflow",
+			tool: "cancel_workflow",
+			args: map[string]interface{}{"domain": testDomain, "workflow_id": testWorkflowID, "run_id": testRunID, "reason": "stuck"},
+			mockFn: func(c *frontend.MockClient) {
+				c.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *types.RequestCancelWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+						assert.Equal(t, &types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID}, request.WorkflowExecution)
+						assert.Equal(t, "stuck", request.Cause)
+						return nil
+					})
+			},
+			wantText: "Cancellation requested",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := frontend.NewMockClient(gomock.NewController(t))
+			if tc.mockFn != nil {
+				tc.mockFn(client)
+			}
+			h := newHandlers(client, Options{AllowWrites: true, Identity: testIdentity})
+
+			result := callTool(t, h, tc.tool, tc.args)
+
+			require.Len(t, result.Content, 1)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tc.wantError != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, text, tc.wantError)
+				return
+			}
+			assert.False(t, result.IsError, text)
+			assert.Contains(t, text, tc.wantText)
+		})
+	}
+}
+
+func toolNames(h *handlers) []string {
+	var names []string
+	for _, t := range h.tools() {
+		names = append(names, t.tool.Name)
+	}
+	return names
+}
+
+func callTool(t *testing.T, h *handlers, name string, args map[string]interface{}) *mcp.CallToolResult {
+	for _, tool := range h.tools() {
+		if tool.tool.Name != name {
+			continue
+		}
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		result, err := tool.handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	t.Fatalf("tool %s is not registered", name)
+	return nil
+}